@@ -28,9 +28,9 @@ type BackupDirectory struct {
 }
 
 func (backupDirectory *BackupDirectory) GetArtifactSize(artifactIdentifier orchestrator.ArtifactIdentifier) (string, error) {
-	filename := backupDirectory.instanceFilename(artifactIdentifier)
+	filenames := backupDirectory.artifactFilenames(artifactIdentifier)
 
-	cmd := exec.Command("du", "-sh", filename)
+	cmd := exec.Command("du", append([]string{"-shc"}, filenames...)...)
 
 	output, err := cmd.Output()
 
@@ -38,13 +38,29 @@ func (backupDirectory *BackupDirectory) GetArtifactSize(artifactIdentifier orche
 		return "", err
 	}
 
-	size := strings.Fields(string(output))[0]
+	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+	size := strings.Fields(lines[len(lines)-1])[0]
 	return size, nil
 }
 
 func (backupDirectory *BackupDirectory) GetArtifactByteSize(artifactIdentifier orchestrator.ArtifactIdentifier) (int, error) {
-	filename := backupDirectory.instanceFilename(artifactIdentifier)
+	var total int64
+	for _, filename := range backupDirectory.artifactFilenames(artifactIdentifier) {
+		size, err := fileByteSize(filename)
+		if err != nil {
+			return 0, err
+		}
+		total += int64(size)
+	}
+
+	if total > math.MaxInt {
+		return 0, fmt.Errorf("artifact %s is too large (%d bytes) to fit in an int; cannot compute percentage", logName(artifactIdentifier), total)
+	}
+
+	return int(total), nil
+}
 
+func fileByteSize(filename string) (int, error) {
 	info, err := os.Stat(filename)
 	if err != nil {
 		return 0, fmt.Errorf("failed to determine file size for file %s: %w", filename, err)
@@ -97,18 +113,42 @@ func (backupDirectory *BackupDirectory) CreateArtifact(artifactIdentifier orches
 	return file, err
 }
 
-func (backupDirectory *BackupDirectory) ReadArtifact(artifactIdentifier orchestrator.ArtifactIdentifier) (io.ReadCloser, error) {
-	filename := backupDirectory.instanceFilename(artifactIdentifier)
-	backupDirectory.Debug("bbr", "Trying to open %s", filename)
-	file, err := os.Open(filename)
+// CreateArtifactPart creates one of the files of an artifact that is drained
+// over several streams. Parts are numbered from 0.
+func (backupDirectory *BackupDirectory) CreateArtifactPart(artifactIdentifier orchestrator.ArtifactIdentifier, part int) (io.WriteCloser, error) {
+	name := partFileName(artifactIdentifier, part)
+	backupDirectory.Debug("bbr", "Trying to create file %s", name)
+
+	file, err := os.Create(path.Join(backupDirectory.baseDirName, name))
 	if err != nil {
-		backupDirectory.Debug("bbr", "Error reading artifact file %s", filename)
-		return nil, backupDirectory.logAndReturn(err, "Error reading artifact file %s", filename)
+		return nil, backupDirectory.logAndReturn(err, "Error creating file %s", name)
 	}
 
 	return file, nil
 }
 
+func (backupDirectory *BackupDirectory) ReadArtifactParts(artifactIdentifier orchestrator.ArtifactIdentifier) ([]orchestrator.ArtifactPart, error) {
+	var parts []orchestrator.ArtifactPart
+	for _, filename := range backupDirectory.artifactFilenames(artifactIdentifier) {
+		size, err := fileByteSize(filename)
+		if err == nil {
+			var file *os.File
+			file, err = os.Open(filename)
+			if err == nil {
+				parts = append(parts, orchestrator.ArtifactPart{Reader: file, SizeInBytes: size})
+				continue
+			}
+		}
+
+		for _, part := range parts {
+			part.Reader.Close() //nolint:errcheck
+		}
+		return nil, backupDirectory.logAndReturn(err, "Error reading artifact file %s", filename)
+	}
+
+	return parts, nil
+}
+
 func (backupDirectory *BackupDirectory) FetchChecksum(artifactIdentifier orchestrator.ArtifactIdentifier) (orchestrator.BackupChecksum, error) {
 	metadata, err := readMetadata(backupDirectory.metadataFilename())
 
@@ -146,21 +186,33 @@ func logName(artifactIdentifer orchestrator.ArtifactIdentifier) string {
 }
 
 func (backupDirectory *BackupDirectory) CalculateChecksum(artifactIdentifier orchestrator.ArtifactIdentifier) (orchestrator.BackupChecksum, error) {
-	file, err := backupDirectory.ReadArtifact(artifactIdentifier)
+	checksum := map[string]string{}
+	for _, filename := range backupDirectory.artifactFilenames(artifactIdentifier) {
+		err := backupDirectory.addFileChecksums(checksum, filename, artifactIdentifier)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return checksum, nil
+}
+
+func (backupDirectory *BackupDirectory) addFileChecksums(checksum orchestrator.BackupChecksum, filename string, artifactIdentifier orchestrator.ArtifactIdentifier) error {
+	backupDirectory.Debug("bbr", "Trying to open %s", filename)
+	file, err := os.Open(filename)
 	if err != nil {
-		return nil, backupDirectory.logAndReturn(err, "Error opening artifact file %v", artifactIdentifier)
+		return backupDirectory.logAndReturn(err, "Error reading artifact file %s", filename)
 	}
 	defer file.Close() //nolint:errcheck
 
 	tarReader := tar.NewReader(file)
-	checksum := map[string]string{}
 	for {
 		tarHeader, err := tarReader.Next()
 		if err == io.EOF {
 			break
 		}
 		if err != nil {
-			return nil, backupDirectory.logAndReturn(err, "Error reading tar for %s", logName(artifactIdentifier))
+			return backupDirectory.logAndReturn(err, "Error reading tar for %s", logName(artifactIdentifier))
 		}
 		if tarHeader.FileInfo().IsDir() || tarHeader.FileInfo().Name() == "./" {
 			continue
@@ -168,13 +220,13 @@ func (backupDirectory *BackupDirectory) CalculateChecksum(artifactIdentifier orc
 
 		fileShasum := sha256.New()
 		if _, err := io.Copy(fileShasum, tarReader); err != nil {
-			return nil, backupDirectory.logAndReturn(err, "Error calculating sha for %s", logName(artifactIdentifier))
+			return backupDirectory.logAndReturn(err, "Error calculating sha for %s", logName(artifactIdentifier))
 		}
 		backupDirectory.Logger.Debug("bbr", "Calculating shasum for local file %s", tarHeader.Name) //nolint:staticcheck
 		checksum[tarHeader.Name] = fmt.Sprintf("%x", fileShasum.Sum(nil))
 	}
 
-	return checksum, nil
+	return nil
 }
 
 func (backupDirectory *BackupDirectory) AddChecksum(artifactIdentifier orchestrator.ArtifactIdentifier, shasum orchestrator.BackupChecksum) error {
@@ -194,18 +246,114 @@ func (backupDirectory *BackupDirectory) AddChecksum(artifactIdentifier orchestra
 		metadata.MetadataForEachArtifact = append(metadata.MetadataForEachArtifact, artifactMetadata{
 			Name:     artifactIdentifier.Name(),
 			Checksum: shasum,
+			Parts:    backupDirectory.partFileNamesOnDisk(artifactIdentifier),
 		})
 	} else {
 		instanceMetadata := metadata.findOrCreateInstanceMetadata(artifactIdentifier.InstanceName(), artifactIdentifier.InstanceIndex())
 		instanceMetadata.Artifacts = append(instanceMetadata.Artifacts, artifactMetadata{
 			Name:     artifactIdentifier.Name(),
 			Checksum: shasum,
+			Parts:    backupDirectory.partFileNamesOnDisk(artifactIdentifier),
 		})
 	}
 
 	return metadata.save(backupDirectory.metadataFilename())
 }
 
+func (backupDirectory *BackupDirectory) AddArtifactTransfer(artifactIdentifier orchestrator.ArtifactIdentifier, transfer orchestrator.ArtifactTransfer) error {
+	defer backupDirectory.Unlock()
+	backupDirectory.Lock()
+
+	metadata, err := readMetadata(backupDirectory.metadataFilename())
+	if err != nil {
+		return backupDirectory.logAndReturn(err, "Error reading metadata from %s", backupDirectory.metadataFilename())
+	}
+
+	artifactMetadata := metadata.findArtifactMetadata(artifactIdentifier)
+	if artifactMetadata == nil {
+		return errors.Errorf("artifact %s not found in metadata", logName(artifactIdentifier))
+	}
+
+	artifactMetadata.SizeInBytes = transfer.SizeInBytes
+	artifactMetadata.TransferDuration = transfer.Duration.String()
+	artifactMetadata.TransferBytesPerSecond = transfer.BytesPerSecond()
+
+	return metadata.save(backupDirectory.metadataFilename())
+}
+
+func (backupDirectory *BackupDirectory) FetchArtifactSizeInBytes(artifactIdentifier orchestrator.ArtifactIdentifier) (int, error) {
+	metadata, err := readMetadata(backupDirectory.metadataFilename())
+	if err != nil {
+		return 0, backupDirectory.logAndReturn(err, "Error reading metadata from %s", backupDirectory.metadataFilename())
+	}
+
+	artifactMetadata := metadata.findArtifactMetadata(artifactIdentifier)
+	if artifactMetadata == nil || artifactMetadata.SizeInBytes == 0 {
+		return 0, errors.Errorf("no size recorded for artifact %s", logName(artifactIdentifier))
+	}
+
+	return artifactMetadata.SizeInBytes, nil
+}
+
+func (backupDirectory *BackupDirectory) AddPhaseTimings(timings []orchestrator.PhaseTiming) error {
+	defer backupDirectory.Unlock()
+	backupDirectory.Lock()
+
+	metadata, err := readMetadata(backupDirectory.metadataFilename())
+	if err != nil {
+		return backupDirectory.logAndReturn(err, "Error reading metadata from %s", backupDirectory.metadataFilename())
+	}
+
+	for _, timing := range timings {
+		metadata.MetadataForBackupActivity.Phases = append(metadata.MetadataForBackupActivity.Phases, phaseMetadata{
+			Name:       timing.Phase,
+			Job:        timing.JobName,
+			Artifact:   timing.ArtifactName,
+			Instance:   timing.Instance,
+			StartTime:  timing.StartTime.Format(timestampFormat),
+			FinishTime: timing.FinishTime.Format(timestampFormat),
+		})
+	}
+
+	return metadata.save(backupDirectory.metadataFilename())
+}
+
+func (backupDirectory *BackupDirectory) AddOrigin(origin orchestrator.Origin) error {
+	defer backupDirectory.Unlock()
+	backupDirectory.Lock()
+
+	metadata, err := readMetadata(backupDirectory.metadataFilename())
+	if err != nil {
+		return backupDirectory.logAndReturn(err, "Error reading metadata from %s", backupDirectory.metadataFilename())
+	}
+
+	metadata.Origin = &originMetadata{
+		Hostname:           origin.Hostname,
+		HostKeyFingerprint: origin.HostKeyFingerprint,
+		DirectorUUID:       origin.DirectorUUID,
+	}
+
+	return metadata.save(backupDirectory.metadataFilename())
+}
+
+// FetchOrigin returns a zero Origin for backups that did not record one.
+func (backupDirectory *BackupDirectory) FetchOrigin() (orchestrator.Origin, error) {
+	metadata, err := readMetadata(backupDirectory.metadataFilename())
+	if err != nil {
+		return orchestrator.Origin{}, backupDirectory.logAndReturn(err, "Error reading metadata from %s", backupDirectory.metadataFilename())
+	}
+
+	if metadata.Origin == nil {
+		return orchestrator.Origin{}, nil
+	}
+
+	return orchestrator.Origin{
+		Hostname:           metadata.Origin.Hostname,
+		HostKeyFingerprint: metadata.Origin.HostKeyFingerprint,
+		DirectorUUID:       metadata.Origin.DirectorUUID,
+	}, nil
+}
+
 func (backupDirectory *BackupDirectory) CreateMetadataFileWithStartTime(startTime time.Time) error {
 	exists, _ := backupDirectory.metadataExistsAndIsReadable() //nolint:errcheck
 	if exists {
@@ -289,6 +437,40 @@ func (backupDirectory *BackupDirectory) instanceFilename(artifactIdentifier orch
 	return path.Join(backupDirectory.baseDirName, fileName(artifactIdentifier))
 }
 
+// artifactFilenames returns the tar files an artifact is stored in: the parts
+// listed in the metadata, or the parts on disk while the backup is still being
+// drained, or else the artifact's single tar file.
+func (backupDirectory *BackupDirectory) artifactFilenames(artifactIdentifier orchestrator.ArtifactIdentifier) []string {
+	var partNames []string
+	metadata, err := readMetadata(backupDirectory.metadataFilename())
+	if artifactMetadata := metadata.findArtifactMetadata(artifactIdentifier); err == nil && artifactMetadata != nil {
+		partNames = artifactMetadata.Parts
+	} else {
+		partNames = backupDirectory.partFileNamesOnDisk(artifactIdentifier)
+	}
+
+	if len(partNames) == 0 {
+		return []string{backupDirectory.instanceFilename(artifactIdentifier)}
+	}
+
+	var filenames []string
+	for _, name := range partNames {
+		filenames = append(filenames, path.Join(backupDirectory.baseDirName, name))
+	}
+	return filenames
+}
+
+func (backupDirectory *BackupDirectory) partFileNamesOnDisk(artifactIdentifier orchestrator.ArtifactIdentifier) []string {
+	var names []string
+	for part := 0; ; part++ {
+		name := partFileName(artifactIdentifier, part)
+		if _, err := os.Stat(path.Join(backupDirectory.baseDirName, name)); err != nil {
+			return names
+		}
+		names = append(names, name)
+	}
+}
+
 func (backupDirectory *BackupDirectory) metadataFilename() string {
 	return path.Join(backupDirectory.baseDirName, "metadata")
 }
@@ -320,3 +502,7 @@ func instanceArtifactFileName(instanceName string, instanceIndex string, name st
 func customArtifactFileName(artifactName string) string {
 	return artifactName + ".tar"
 }
+
+func partFileName(artifactIdentifier orchestrator.ArtifactIdentifier, part int) string {
+	return fmt.Sprintf("%s.part%d.tar", strings.TrimSuffix(fileName(artifactIdentifier), ".tar"), part)
+}
@@ -2,6 +2,10 @@ package backup
 
 import (
 	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
 
 	"fmt"
 
@@ -44,3 +48,49 @@ func (BackupDirectoryManager) Open(name string, logger orchestrator.Logger) (orc
 	_, err := os.Stat(name)
 	return &BackupDirectory{baseDirName: name, Logger: logger}, errors.Wrap(err, "failed opening the directory")
 }
+
+var backupTimestamp = regexp.MustCompile(`^\d{8}T\d{6}Z$`)
+
+// Latest opens the most recent backup of deploymentName under path, or
+// returns nil when there is none.
+func (BackupDirectoryManager) Latest(path, deploymentName string, logger orchestrator.Logger) (orchestrator.Backup, error) {
+	if path == "" {
+		path = "."
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed listing previous backups in %s", path)
+	}
+
+	prefix := deploymentName + "_"
+	var backupNames []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() && strings.HasPrefix(name, prefix) && backupTimestamp.MatchString(strings.TrimPrefix(name, prefix)) {
+			backupNames = append(backupNames, name)
+		}
+	}
+
+	sort.Sort(sort.Reverse(sort.StringSlice(backupNames)))
+	for _, name := range backupNames {
+		backupDirectory := &BackupDirectory{baseDirName: filepath.Join(path, name), Logger: logger}
+		if _, err := os.Stat(backupDirectory.metadataFilename()); err == nil {
+			return backupDirectory, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (BackupDirectoryManager) FreeSpaceInBytes(path string) (int, error) {
+	if path == "" {
+		path = "."
+	}
+
+	free, err := freeSpaceInBytes(path)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed checking free space in %s", path)
+	}
+	return free, nil
+}
@@ -3,6 +3,7 @@ package backup_test
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 
 	. "github.com/cloudfoundry/bosh-backup-and-restore/backup"
 	. "github.com/onsi/ginkgo/v2"
@@ -90,4 +91,50 @@ var _ = Context("BackupManager", func() {
 			})
 		})
 	})
+
+	Describe("Latest", func() {
+		createBackup := func(name string, withMetadata bool) {
+			Expect(os.Mkdir(filepath.Join(artifactPath, name), 0700)).To(Succeed())
+			if withMetadata {
+				metadata := fmt.Sprintf("origin: {hostname: %s}", name)
+				Expect(os.WriteFile(filepath.Join(artifactPath, name, "metadata"), []byte(metadata), 0600)).To(Succeed())
+			}
+		}
+
+		It("opens the most recent backup of the deployment that has metadata", func() {
+			createBackup("my-cool-redis_20161021T010203Z", true)
+			createBackup("my-cool-redis_20161022T010203Z", true)
+			createBackup("my-cool-redis_20161023T010203Z", false)
+			createBackup("my-cool-redis-two_20161024T010203Z", true)
+			createBackup("my-cool-redis_not-a-timestamp", true)
+
+			latest, err := backupManager.Latest(artifactPath, "my-cool-redis", nil)
+			Expect(err).NotTo(HaveOccurred())
+			origin, err := latest.FetchOrigin()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(origin.Hostname).To(Equal("my-cool-redis_20161022T010203Z"))
+		})
+
+		It("returns nil when there is no previous backup", func() {
+			latest, err := backupManager.Latest(artifactPath, "my-cool-redis", nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(latest).To(BeNil())
+		})
+
+		It("fails when the artifact path cannot be listed", func() {
+			_, err := backupManager.Latest(filepath.Join(artifactPath, "missing"), "my-cool-redis", nil)
+			Expect(err).To(MatchError(ContainSubstring("failed listing previous backups")))
+		})
+	})
+
+	Describe("FreeSpaceInBytes", func() {
+		It("returns the space available at the artifact path", func() {
+			Expect(backupManager.FreeSpaceInBytes(artifactPath)).To(BeNumerically(">", 0))
+		})
+
+		It("fails when the artifact path does not exist", func() {
+			_, err := backupManager.FreeSpaceInBytes(filepath.Join(artifactPath, "missing"))
+			Expect(err).To(MatchError(ContainSubstring("failed checking free space in")))
+		})
+	})
 })
@@ -383,7 +383,7 @@ instances:
 		})
 	})
 
-	Describe("ReadArtifact", func() {
+	Describe("ReadArtifactParts of an artifact in one file", func() {
 		var artifact orchestrator.Backup
 		var fileReadError error
 		var reader io.Reader
@@ -410,7 +410,10 @@ instances:
 			})
 
 			JustBeforeEach(func() {
-				reader, fileReadError = artifact.ReadArtifact(fakeBackupArtifact)
+				var parts []orchestrator.ArtifactPart
+				parts, fileReadError = artifact.ReadArtifactParts(fakeBackupArtifact)
+				Expect(parts).To(HaveLen(1))
+				reader = parts[0].Reader
 			})
 
 			It("does not fail", func() {
@@ -439,7 +442,10 @@ instances:
 			})
 
 			JustBeforeEach(func() {
-				reader, fileReadError = artifact.ReadArtifact(fakeBackupArtifact)
+				var parts []orchestrator.ArtifactPart
+				parts, fileReadError = artifact.ReadArtifactParts(fakeBackupArtifact)
+				Expect(parts).To(HaveLen(1))
+				reader = parts[0].Reader
 			})
 
 			It("does not fail", func() {
@@ -456,7 +462,7 @@ instances:
 
 		Context("File is not readable", func() {
 			It("fails", func() {
-				_, fileReadError = artifact.ReadArtifact(fakeBackupArtifact)
+				_, fileReadError = artifact.ReadArtifactParts(fakeBackupArtifact)
 				Expect(fileReadError).To(MatchError(ContainSubstring("Error reading artifact file")))
 			})
 		})
@@ -559,6 +565,107 @@ instances:
 		})
 	})
 
+	Describe("artifacts stored in parts", func() {
+		var artifact orchestrator.Backup
+		var fakeBackupArtifact *fakes.FakeBackupArtifact
+
+		writePart := func(part int, files map[string]string) {
+			writer, err := artifact.CreateArtifactPart(fakeBackupArtifact, part)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = writer.Write(createTarWithContents(files))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(writer.Close()).To(Succeed())
+		}
+
+		BeforeEach(func() {
+			fakeBackupArtifact = new(fakes.FakeBackupArtifact)
+			fakeBackupArtifact.InstanceNameReturns("redis-server")
+			fakeBackupArtifact.InstanceIndexReturns("0")
+			fakeBackupArtifact.NameReturns("redis")
+
+			var err error
+			artifact, err = backupDirectoryManager.Create("", backupName, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(artifact.CreateMetadataFileWithStartTime(nowFunc())).To(Succeed())
+
+			writePart(0, map[string]string{"big.rdb": "a big file"})
+			writePart(1, map[string]string{"small.rdb": "small", "tiny.rdb": "t"})
+		})
+
+		It("stores each part in its own file", func() {
+			Expect(backupName + "/redis-server-0-redis.part0.tar").To(BeARegularFile())
+			Expect(backupName + "/redis-server-0-redis.part1.tar").To(BeARegularFile())
+			Expect(backupName + "/redis-server-0-redis.tar").NotTo(BeAnExistingFile())
+		})
+
+		It("calculates the checksum of the files in every part", func() {
+			Expect(artifact.CalculateChecksum(fakeBackupArtifact)).To(Equal(orchestrator.BackupChecksum{
+				"big.rdb":   fmt.Sprintf("%x", sha256.Sum256([]byte("a big file"))),
+				"small.rdb": fmt.Sprintf("%x", sha256.Sum256([]byte("small"))),
+				"tiny.rdb":  fmt.Sprintf("%x", sha256.Sum256([]byte("t"))),
+			}))
+		})
+
+		It("sizes the artifact as the sum of its parts", func() {
+			part0, err := os.Stat(backupName + "/redis-server-0-redis.part0.tar")
+			Expect(err).NotTo(HaveOccurred())
+			part1, err := os.Stat(backupName + "/redis-server-0-redis.part1.tar")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(artifact.GetArtifactByteSize(fakeBackupArtifact)).To(Equal(int(part0.Size() + part1.Size())))
+			Expect(artifact.GetArtifactSize(fakeBackupArtifact)).NotTo(BeEmpty())
+		})
+
+		It("reads every part with its size", func() {
+			parts, err := artifact.ReadArtifactParts(fakeBackupArtifact)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parts).To(HaveLen(2))
+
+			for _, part := range parts {
+				contents, err := io.ReadAll(part.Reader)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(part.SizeInBytes).To(Equal(len(contents)))
+				Expect(part.Reader.Close()).To(Succeed())
+			}
+		})
+
+		Context("once the checksum is added", func() {
+			BeforeEach(func() {
+				checksum, err := artifact.CalculateChecksum(fakeBackupArtifact)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(artifact.AddChecksum(fakeBackupArtifact, checksum)).To(Succeed())
+			})
+
+			It("lists the parts in the metadata", func() {
+				data, err := os.ReadFile(backupName + "/metadata")
+				Expect(err).NotTo(HaveOccurred())
+
+				var metadata struct {
+					Instances []struct {
+						Artifacts []struct {
+							Parts []string `yaml:"parts"`
+						} `yaml:"artifacts"`
+					} `yaml:"instances"`
+				}
+				Expect(yaml.Unmarshal(data, &metadata)).To(Succeed())
+				Expect(metadata.Instances[0].Artifacts[0].Parts).To(Equal([]string{
+					"redis-server-0-redis.part0.tar",
+					"redis-server-0-redis.part1.tar",
+				}))
+			})
+
+			It("reads the parts listed in the metadata", func() {
+				Expect(os.Remove(backupName + "/redis-server-0-redis.part1.tar")).To(Succeed())
+
+				_, err := artifact.ReadArtifactParts(fakeBackupArtifact)
+				Expect(err).To(MatchError(ContainSubstring("redis-server-0-redis.part1.tar")))
+
+				valid, _ := artifact.Valid() //nolint:errcheck
+				Expect(valid).To(BeFalse())
+			})
+		})
+	})
+
 	Describe("AddChecksum", func() {
 		var artifact orchestrator.Backup
 		var addChecksumError error
@@ -980,6 +1087,203 @@ backup_activity:
 		})
 	})
 
+	Describe("AddArtifactTransfer", func() {
+		var artifact orchestrator.Backup
+		var fakeBackupArtifact *fakes.FakeBackupArtifact
+
+		BeforeEach(func() {
+			var err error
+			artifact, err = backupDirectoryManager.Create("", backupName, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(artifact.CreateMetadataFileWithStartTime(time.Date(2015, 10, 21, 1, 2, 3, 0, time.UTC))).To(Succeed())
+
+			fakeBackupArtifact = new(fakes.FakeBackupArtifact)
+			fakeBackupArtifact.InstanceNameReturns("redis-server")
+			fakeBackupArtifact.InstanceIndexReturns("0")
+			fakeBackupArtifact.NameReturns("redis")
+		})
+
+		Context("when the artifact checksum has been added", func() {
+			BeforeEach(func() {
+				Expect(artifact.AddChecksum(fakeBackupArtifact, map[string]string{"filename": "foobar"})).To(Succeed())
+			})
+
+			It("records the size, duration and throughput of the transfer", func() {
+				Expect(artifact.AddArtifactTransfer(fakeBackupArtifact, orchestrator.ArtifactTransfer{
+					SizeInBytes: 4096,
+					Duration:    2 * time.Second,
+				})).To(Succeed())
+
+				expectedMetadata := `---
+backup_activity:
+  start_time: 2015/10/21 01:02:03 UTC
+instances:
+- name: redis-server
+  index: "0"
+  artifacts:
+  - name: redis
+    checksums:
+      filename: foobar
+    size_in_bytes: 4096
+    transfer_duration: 2s
+    transfer_bytes_per_second: 2048`
+				Expect(os.ReadFile(backupName + "/metadata")).To(MatchYAML(expectedMetadata))
+			})
+		})
+
+		Context("when the artifact is not in the metadata", func() {
+			It("returns an error", func() {
+				err := artifact.AddArtifactTransfer(fakeBackupArtifact, orchestrator.ArtifactTransfer{SizeInBytes: 1})
+				Expect(err).To(MatchError(ContainSubstring("artifact redis/0 not found in metadata")))
+			})
+		})
+	})
+
+	Describe("FetchArtifactSizeInBytes", func() {
+		var artifact orchestrator.Backup
+		var fakeBackupArtifact *fakes.FakeBackupArtifact
+
+		BeforeEach(func() {
+			var err error
+			artifact, err = backupDirectoryManager.Create("", backupName, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(artifact.CreateMetadataFileWithStartTime(time.Date(2015, 10, 21, 1, 2, 3, 0, time.UTC))).To(Succeed())
+
+			fakeBackupArtifact = new(fakes.FakeBackupArtifact)
+			fakeBackupArtifact.InstanceNameReturns("redis-server")
+			fakeBackupArtifact.InstanceIndexReturns("0")
+			fakeBackupArtifact.NameReturns("redis")
+			Expect(artifact.AddChecksum(fakeBackupArtifact, map[string]string{"filename": "foobar"})).To(Succeed())
+		})
+
+		It("returns the size recorded when the artifact was transferred", func() {
+			Expect(artifact.AddArtifactTransfer(fakeBackupArtifact, orchestrator.ArtifactTransfer{SizeInBytes: 4096})).To(Succeed())
+
+			Expect(artifact.FetchArtifactSizeInBytes(fakeBackupArtifact)).To(Equal(4096))
+		})
+
+		Context("when no size was recorded", func() {
+			It("returns an error", func() {
+				_, err := artifact.FetchArtifactSizeInBytes(fakeBackupArtifact)
+				Expect(err).To(MatchError(ContainSubstring("no size recorded for artifact redis/0")))
+			})
+		})
+	})
+
+	Describe("AddPhaseTimings", func() {
+		var artifact orchestrator.Backup
+
+		BeforeEach(func() {
+			var err error
+			artifact, err = backupDirectoryManager.Create("", backupName, logger)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when no metadata file exists", func() {
+			It("returns an error", func() {
+				Expect(artifact.AddPhaseTimings(nil)).To(MatchError(ContainSubstring("Error reading metadata")))
+			})
+		})
+
+		Context("when the metadata file already exists", func() {
+			It("appends the phase timings for each job and artifact", func() {
+				Expect(artifact.CreateMetadataFileWithStartTime(time.Date(2015, 10, 21, 1, 2, 3, 0, time.UTC))).To(Succeed())
+
+				Expect(artifact.AddPhaseTimings([]orchestrator.PhaseTiming{{
+					Phase:      orchestrator.LockPhase,
+					JobName:    "redis",
+					Instance:   "redis-server/abc",
+					StartTime:  time.Date(2015, 10, 21, 1, 2, 4, 0, time.UTC),
+					FinishTime: time.Date(2015, 10, 21, 1, 2, 5, 0, time.UTC),
+				}})).To(Succeed())
+				Expect(artifact.AddPhaseTimings([]orchestrator.PhaseTiming{{
+					Phase:      orchestrator.UnlockPhase,
+					JobName:    "redis",
+					Instance:   "redis-server/abc",
+					StartTime:  time.Date(2015, 10, 21, 1, 2, 6, 0, time.UTC),
+					FinishTime: time.Date(2015, 10, 21, 1, 2, 7, 0, time.UTC),
+				}})).To(Succeed())
+				Expect(artifact.AddPhaseTimings([]orchestrator.PhaseTiming{{
+					Phase:        orchestrator.DrainPhase,
+					ArtifactName: "redis-backup",
+					Instance:     "redis-server/abc",
+					StartTime:    time.Date(2015, 10, 21, 1, 2, 8, 0, time.UTC),
+					FinishTime:   time.Date(2015, 10, 21, 1, 2, 9, 0, time.UTC),
+				}})).To(Succeed())
+
+				expectedMetadata := `---
+backup_activity:
+  start_time: 2015/10/21 01:02:03 UTC
+  phases:
+  - name: lock
+    job: redis
+    instance: redis-server/abc
+    start_time: 2015/10/21 01:02:04 UTC
+    finish_time: 2015/10/21 01:02:05 UTC
+  - name: unlock
+    job: redis
+    instance: redis-server/abc
+    start_time: 2015/10/21 01:02:06 UTC
+    finish_time: 2015/10/21 01:02:07 UTC
+  - name: drain
+    artifact: redis-backup
+    instance: redis-server/abc
+    start_time: 2015/10/21 01:02:08 UTC
+    finish_time: 2015/10/21 01:02:09 UTC`
+				Expect(os.ReadFile(backupName + "/metadata")).To(MatchYAML(expectedMetadata))
+			})
+		})
+	})
+
+	Describe("AddOrigin and FetchOrigin", func() {
+		var artifact orchestrator.Backup
+
+		BeforeEach(func() {
+			var err error
+			artifact, err = backupDirectoryManager.Create("", backupName, logger)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when no metadata file exists", func() {
+			It("returns an error", func() {
+				Expect(artifact.AddOrigin(orchestrator.Origin{})).To(MatchError(ContainSubstring("Error reading metadata")))
+
+				_, err := artifact.FetchOrigin()
+				Expect(err).To(MatchError(ContainSubstring("Error reading metadata")))
+			})
+		})
+
+		Context("when the metadata file already exists", func() {
+			BeforeEach(func() {
+				Expect(artifact.CreateMetadataFileWithStartTime(time.Date(2015, 10, 21, 1, 2, 3, 0, time.UTC))).To(Succeed())
+			})
+
+			It("records the origin", func() {
+				origin := orchestrator.Origin{
+					Hostname:           "10.0.0.6",
+					HostKeyFingerprint: "SHA256:abc",
+					DirectorUUID:       "director-uuid",
+				}
+				Expect(artifact.AddOrigin(origin)).To(Succeed())
+
+				Expect(os.ReadFile(backupName + "/metadata")).To(MatchYAML(`---
+backup_activity:
+  start_time: 2015/10/21 01:02:03 UTC
+origin:
+  hostname: 10.0.0.6
+  host_key_fingerprint: SHA256:abc
+  director_uuid: director-uuid`))
+				Expect(artifact.FetchOrigin()).To(Equal(origin))
+			})
+
+			It("returns a zero origin when none was recorded", func() {
+				origin, err := artifact.FetchOrigin()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(origin.IsZero()).To(BeTrue())
+			})
+		})
+	})
+
 	Describe("GetArtifactSize", func() {
 		var (
 			jobName            string
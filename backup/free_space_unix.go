@@ -0,0 +1,13 @@
+//go:build !windows
+
+package backup
+
+import "syscall"
+
+func freeSpaceInBytes(path string) (int, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, err
+	}
+	return int(stat.Bavail) * int(stat.Bsize), nil
+}
@@ -3,13 +3,24 @@ package backup
 import (
 	"os"
 
+	"github.com/cloudfoundry/bosh-backup-and-restore/orchestrator"
 	"github.com/pkg/errors"
 	"gopkg.in/yaml.v2"
 )
 
 type backupActivityMetadata struct {
+	StartTime  string          `yaml:"start_time"`
+	FinishTime string          `yaml:"finish_time,omitempty"`
+	Phases     []phaseMetadata `yaml:"phases,omitempty"`
+}
+
+type phaseMetadata struct {
+	Name       string `yaml:"name"`
+	Job        string `yaml:"job,omitempty"`
+	Artifact   string `yaml:"artifact,omitempty"`
+	Instance   string `yaml:"instance"`
 	StartTime  string `yaml:"start_time"`
-	FinishTime string `yaml:"finish_time,omitempty"`
+	FinishTime string `yaml:"finish_time"`
 }
 
 type instanceMetadata struct {
@@ -19,14 +30,25 @@ type instanceMetadata struct {
 }
 
 type artifactMetadata struct {
-	Name     string            `yaml:"name"`
-	Checksum map[string]string `yaml:"checksums"`
+	Name                   string            `yaml:"name"`
+	Checksum               map[string]string `yaml:"checksums"`
+	Parts                  []string          `yaml:"parts,omitempty"`
+	SizeInBytes            int               `yaml:"size_in_bytes,omitempty"`
+	TransferDuration       string            `yaml:"transfer_duration,omitempty"`
+	TransferBytesPerSecond int64             `yaml:"transfer_bytes_per_second,omitempty"`
+}
+
+type originMetadata struct {
+	Hostname           string `yaml:"hostname,omitempty"`
+	HostKeyFingerprint string `yaml:"host_key_fingerprint,omitempty"`
+	DirectorUUID       string `yaml:"director_uuid,omitempty"`
 }
 
 type metadata struct {
 	MetadataForEachInstance   []*instanceMetadata    `yaml:"instances,omitempty"`
 	MetadataForEachArtifact   []artifactMetadata     `yaml:"custom_artifacts,omitempty"`
 	MetadataForBackupActivity backupActivityMetadata `yaml:"backup_activity"`
+	Origin                    *originMetadata        `yaml:"origin,omitempty"`
 }
 
 func readMetadata(filename string) (metadata, error) {
@@ -52,6 +74,28 @@ func (data *metadata) save(filename string) error {
 	return os.WriteFile(filename, contents, 0666)
 }
 
+func (data *metadata) findArtifactMetadata(artifactIdentifier orchestrator.ArtifactIdentifier) *artifactMetadata {
+	if artifactIdentifier.HasCustomName() {
+		for i := range data.MetadataForEachArtifact {
+			if data.MetadataForEachArtifact[i].Name == artifactIdentifier.Name() {
+				return &data.MetadataForEachArtifact[i]
+			}
+		}
+		return nil
+	}
+
+	for _, instanceMetadata := range data.MetadataForEachInstance {
+		if instanceMetadata.Name == artifactIdentifier.InstanceName() && instanceMetadata.Index == artifactIdentifier.InstanceIndex() {
+			for i := range instanceMetadata.Artifacts {
+				if instanceMetadata.Artifacts[i].Name == artifactIdentifier.Name() {
+					return &instanceMetadata.Artifacts[i]
+				}
+			}
+		}
+	}
+	return nil
+}
+
 func (data *metadata) findOrCreateInstanceMetadata(name, index string) *instanceMetadata {
 	for _, instanceMetadata := range data.MetadataForEachInstance {
 		if instanceMetadata.Name == name && instanceMetadata.Index == index {
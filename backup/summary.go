@@ -0,0 +1,61 @@
+package backup
+
+import (
+	"path"
+	"time"
+
+	"github.com/cloudfoundry/bosh-backup-and-restore/orchestrator"
+	"github.com/pkg/errors"
+)
+
+type Summary struct {
+	BytesTransferred int64
+	LockDuration     time.Duration
+}
+
+// ReadSummary aggregates the per-artifact transfer sizes and the per-job
+// phase timings recorded in the metadata of the backup in backupDirectory.
+func ReadSummary(backupDirectory string) (Summary, error) {
+	metadata, err := readMetadata(path.Join(backupDirectory, "metadata"))
+	if err != nil {
+		return Summary{}, err
+	}
+
+	var summary Summary
+	for _, artifact := range metadata.MetadataForEachArtifact {
+		summary.BytesTransferred += int64(artifact.SizeInBytes)
+	}
+	for _, inst := range metadata.MetadataForEachInstance {
+		for _, artifact := range inst.Artifacts {
+			summary.BytesTransferred += int64(artifact.SizeInBytes)
+		}
+	}
+
+	var firstLock, lastUnlock time.Time
+	for _, phase := range metadata.MetadataForBackupActivity.Phases {
+		switch phase.Name {
+		case orchestrator.LockPhase:
+			startTime, err := time.Parse(timestampFormat, phase.StartTime)
+			if err != nil {
+				return Summary{}, errors.Wrap(err, "failed to parse lock start time")
+			}
+			if firstLock.IsZero() || startTime.Before(firstLock) {
+				firstLock = startTime
+			}
+		case orchestrator.UnlockPhase:
+			finishTime, err := time.Parse(timestampFormat, phase.FinishTime)
+			if err != nil {
+				return Summary{}, errors.Wrap(err, "failed to parse unlock finish time")
+			}
+			if finishTime.After(lastUnlock) {
+				lastUnlock = finishTime
+			}
+		}
+	}
+
+	if !firstLock.IsZero() && lastUnlock.After(firstLock) {
+		summary.LockDuration = lastUnlock.Sub(firstLock)
+	}
+
+	return summary, nil
+}
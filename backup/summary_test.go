@@ -0,0 +1,90 @@
+package backup_test
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/cloudfoundry/bosh-backup-and-restore/backup"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ReadSummary", func() {
+	var backupDir string
+
+	BeforeEach(func() {
+		backupDir = GinkgoT().TempDir()
+	})
+
+	Context("when the metadata contains transfers and phase timings", func() {
+		BeforeEach(func() {
+			metadata := `---
+instances:
+- name: redis-server
+  index: "0"
+  artifacts:
+  - name: redis
+    checksums: {}
+    size_in_bytes: 1000
+  - name: broker
+    checksums: {}
+    size_in_bytes: 24
+custom_artifacts:
+- name: named
+  checksums: {}
+  size_in_bytes: 100
+backup_activity:
+  start_time: 2015/10/21 01:02:03 UTC
+  phases:
+  - name: lock
+    job: redis
+    instance: redis-server/abc
+    start_time: 2015/10/21 01:02:10 UTC
+    finish_time: 2015/10/21 01:02:11 UTC
+  - name: lock
+    job: broker
+    instance: redis-server/abc
+    start_time: 2015/10/21 01:02:05 UTC
+    finish_time: 2015/10/21 01:02:06 UTC
+  - name: unlock
+    job: redis
+    instance: redis-server/abc
+    start_time: 2015/10/21 01:03:00 UTC
+    finish_time: 2015/10/21 01:03:05 UTC
+  - name: drain
+    job: redis
+    instance: redis-server/abc
+    start_time: 2015/10/21 01:04:00 UTC
+    finish_time: 2015/10/21 01:05:00 UTC`
+			Expect(os.WriteFile(filepath.Join(backupDir, "metadata"), []byte(metadata), 0600)).To(Succeed())
+		})
+
+		It("sums the transferred bytes and measures how long the deployment was locked", func() {
+			summary, err := backup.ReadSummary(backupDir)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(summary.BytesTransferred).To(Equal(int64(1124)))
+			Expect(summary.LockDuration).To(Equal(time.Minute))
+		})
+	})
+
+	Context("when the metadata has no phase timings", func() {
+		BeforeEach(func() {
+			metadata := `---
+backup_activity:
+  start_time: 2015/10/21 01:02:03 UTC`
+			Expect(os.WriteFile(filepath.Join(backupDir, "metadata"), []byte(metadata), 0600)).To(Succeed())
+		})
+
+		It("returns an empty summary", func() {
+			Expect(backup.ReadSummary(backupDir)).To(Equal(backup.Summary{}))
+		})
+	})
+
+	Context("when the metadata does not exist", func() {
+		It("returns an error", func() {
+			_, err := backup.ReadSummary(backupDir)
+			Expect(err).To(MatchError(ContainSubstring("failed to read metadata")))
+		})
+	})
+})
@@ -0,0 +1,136 @@
+// Package bbr runs the backups, restores, pre-backup checks and cleanups of
+// BOSH deployments and directors in-process, without the bbr CLI.
+//
+// Each operation takes an options struct and returns a Result rather than
+// printing to stdout or exiting. Cancelling its context stops the operation
+// the way SIGTERM stops the CLI: no further instances or steps are started,
+// the scripts and other SSH commands that are running are stopped, and the
+// deployment is unlocked and cleaned up before the operation returns. A
+// request to the BOSH director that is already in flight is not interrupted.
+package bbr
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/cloudfoundry/bosh-backup-and-restore/factory"
+	"github.com/cloudfoundry/bosh-backup-and-restore/orchestrator"
+	"github.com/cloudfoundry/bosh-backup-and-restore/ssh"
+	"github.com/cloudfoundry/bosh-backup-and-restore/standalone"
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+)
+
+const artifactTimeStampFormat = "20060102T150405Z"
+
+// Options are common to every operation.
+type Options struct {
+	// LogWriter receives the logs bbr would print. They are discarded when it
+	// is nil.
+	LogWriter io.Writer
+	Debug     bool
+	// Events, if set, is told when the operation and each of its steps start
+	// and finish.
+	Events EventHandler
+	// Version is the bbr version recorded in backups and reported to the
+	// instances.
+	Version string
+	// ProxyJump routes the SSH connections of the operation through jump
+	// hosts, see standalone.ProxyJumpConfig.
+	ProxyJump []ssh.JumpRoute
+}
+
+// Target is the BOSH director that deployments are found through.
+type Target struct {
+	URL      string
+	Username string
+	Password string
+	// CACert is the path or value of the custom CA certificate of the
+	// director.
+	CACert string
+}
+
+// DirectorSSH is how a BOSH director is reached to back it up or restore it.
+type DirectorSSH struct {
+	// Host is the hostname of the director, with an optional port that
+	// defaults to 22. Backups and results are named after the hostname.
+	Host     string
+	Username string
+	// PrivateKeyPath is required unless UseAgent is set.
+	PrivateKeyPath  string
+	CertificatePath string
+	UseAgent        bool
+	// KnownHostsPath or HostKeyFingerprint verify the host key of the
+	// director. Without them any host key is accepted.
+	KnownHostsPath     string
+	HostKeyFingerprint string
+}
+
+// Hooks are commands run locally around a backup or restore.
+type Hooks struct {
+	PreBackup   []string
+	PostLock    []string
+	PostBackup  []string
+	PreRestore  []string
+	PostRestore []string
+}
+
+func (options Options) logger() boshlog.Logger {
+	writer := options.LogWriter
+	if writer == nil {
+		writer = io.Discard
+	}
+	return factory.BuildBoshLoggerWithCustomWriter(writer, options.Debug)
+}
+
+func (directorSSH DirectorSSH) config() standalone.SSHConfig {
+	return standalone.SSHConfig{
+		PrivateKeyPath:     directorSSH.PrivateKeyPath,
+		CertificatePath:    directorSSH.CertificatePath,
+		UseAgent:           directorSSH.UseAgent,
+		KnownHostsPath:     directorSSH.KnownHostsPath,
+		HostKeyFingerprint: directorSSH.HostKeyFingerprint,
+	}
+}
+
+func (hooks Hooks) orchestratorHooks() orchestrator.Hooks {
+	return orchestrator.Hooks(hooks)
+}
+
+// orDefault returns value, or fallback when value is left unset.
+func orDefault(value, fallback int) int {
+	if value == 0 {
+		return fallback
+	}
+	return value
+}
+
+// run runs operation with the step events of options, and turns the errors
+// that it returns into a Result.
+func run(ctx context.Context, options Options, operation Operation, deployment, artifact string, do func(context.Context) orchestrator.Error) Result {
+	events := newEventEmitter(options.Events, operation)
+	if events != nil {
+		ctx = orchestrator.ContextWithStepObserver(ctx, events)
+	}
+
+	result := Result{
+		Operation:  operation,
+		Deployment: deployment,
+		Artifact:   artifact,
+		StartTime:  time.Now(),
+	}
+	events.operationStarted(deployment, result.StartTime)
+
+	result.setErrors(do(ctx))
+	result.FinishTime = time.Now()
+
+	events.operationFinished(result)
+	return result
+}
+
+// fail returns the result of an operation that could not be started.
+func fail(options Options, operation Operation, deployment string, err error) Result {
+	return run(context.Background(), options, operation, deployment, "", func(context.Context) orchestrator.Error {
+		return orchestrator.NewError(err)
+	})
+}
@@ -0,0 +1,13 @@
+package bbr_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestBbr(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Bbr Suite")
+}
@@ -0,0 +1,142 @@
+package bbr_test
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/cloudfoundry/bosh-backup-and-restore/bbr"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("bbr", func() {
+	var events []bbr.Event
+	var logs *bytes.Buffer
+	var options bbr.Options
+
+	BeforeEach(func() {
+		events = nil
+		logs = new(bytes.Buffer)
+		options = bbr.Options{
+			LogWriter: logs,
+			Events: bbr.EventHandlerFunc(func(event bbr.Event) {
+				events = append(events, event)
+			}),
+			Version: "1.2.3",
+		}
+	})
+
+	eventTypes := func() []bbr.EventType {
+		var types []bbr.EventType
+		for _, event := range events {
+			types = append(types, event.Type)
+		}
+		return types
+	}
+
+	Describe("PreBackupCheckDirector", func() {
+		Context("when the director cannot be reached", func() {
+			var result bbr.Result
+
+			BeforeEach(func() {
+				result = bbr.PreBackupCheckDirector(context.Background(), bbr.DirectorOptions{
+					Options: options,
+					SSH: bbr.DirectorSSH{
+						Host:           "10.0.0.6:2222",
+						Username:       "vcap",
+						PrivateKeyPath: "/does/not/exist",
+					},
+				})
+			})
+
+			It("returns the failure as a result", func() {
+				Expect(result.Succeeded()).To(BeFalse())
+				Expect(result.Operation).To(Equal(bbr.PreBackupCheck))
+				Expect(result.Deployment).To(Equal("10.0.0.6"))
+				Expect(result.Err).To(MatchError(ContainSubstring("failed reading private key")))
+				Expect(result.ExitCode).To(Equal(3))
+				Expect(result.Category).To(Equal("discovery"))
+				Expect(result.CleanupAdvised).To(BeFalse())
+				Expect(result.FinishTime).NotTo(BeTemporally("<", result.StartTime))
+			})
+
+			It("reports the operation and its steps as events", func() {
+				Expect(eventTypes()).To(Equal([]bbr.EventType{bbr.OperationStarted, bbr.StepStarted, bbr.StepFinished, bbr.OperationFinished}))
+
+				Expect(events[1].Step).To(Equal("FindDeploymentStep"))
+				Expect(events[1].Deployment).To(Equal("10.0.0.6"))
+				Expect(events[2].Err).To(MatchError(ContainSubstring("failed reading private key")))
+
+				Expect(events[3].Operation).To(Equal(bbr.PreBackupCheck))
+				Expect(events[3].Result).To(Equal(&result))
+			})
+		})
+	})
+
+	Describe("BackupDirector", func() {
+		It("names the artifact after the director", func() {
+			result := bbr.BackupDirector(context.Background(), bbr.DirectorBackupOptions{
+				Options:      options,
+				SSH:          bbr.DirectorSSH{Host: "10.0.0.6", Username: "vcap", PrivateKeyPath: "/does/not/exist"},
+				ArtifactPath: "/backups",
+			})
+
+			Expect(result.Succeeded()).To(BeFalse())
+			Expect(result.Artifact).To(MatchRegexp(`^/backups/10\.0\.0\.6_\d{8}T\d{6}Z$`))
+		})
+
+		It("fails without running when there are fewer than one artifact streams", func() {
+			result := bbr.BackupDirector(context.Background(), bbr.DirectorBackupOptions{
+				Options:         options,
+				SSH:             bbr.DirectorSSH{Host: "10.0.0.6", Username: "vcap", PrivateKeyPath: "/does/not/exist"},
+				ArtifactStreams: -1,
+			})
+
+			Expect(result.Err).To(MatchError(ContainSubstring("artifact streams must be at least 1")))
+			Expect(result.ExitCode).To(Equal(1))
+			Expect(result.Category).To(Equal("other"))
+			Expect(eventTypes()).To(Equal([]bbr.EventType{bbr.OperationStarted, bbr.OperationFinished}))
+		})
+
+		It("fails without running when the concurrency is below one", func() {
+			result := bbr.BackupDeployment(context.Background(), bbr.DeploymentBackupOptions{
+				Options:     options,
+				Target:      bbr.Target{URL: "https://10.0.0.6:25555"},
+				Deployment:  "cf",
+				Concurrency: -1,
+			})
+
+			Expect(result.Err).To(MatchError(ContainSubstring("concurrency must be at least 1")))
+			Expect(result.ExitCode).To(Equal(1))
+			Expect(eventTypes()).To(Equal([]bbr.EventType{bbr.OperationStarted, bbr.OperationFinished}))
+		})
+	})
+
+	Describe("RestoreDeployment and RestoreDirector", func() {
+		It("require the artifact path", func() {
+			result := bbr.RestoreDeployment(context.Background(), bbr.DeploymentRestoreOptions{
+				Options:    options,
+				Target:     bbr.Target{URL: "https://10.0.0.6:25555"},
+				Deployment: "cf",
+			})
+			Expect(result.Err).To(MatchError(ContainSubstring("the artifact path of the backup to restore is required")))
+			Expect(result.Operation).To(Equal(bbr.Restore))
+
+			result = bbr.RestoreDirector(context.Background(), bbr.DirectorRestoreOptions{
+				Options: options,
+				SSH:     bbr.DirectorSSH{Host: "10.0.0.6"},
+			})
+			Expect(result.Err).To(MatchError(ContainSubstring("the artifact path of the backup to restore is required")))
+		})
+	})
+
+	It("runs without an event handler or a log writer", func() {
+		result := bbr.CleanupDirectorBackup(context.Background(), bbr.DirectorOptions{
+			SSH: bbr.DirectorSSH{Host: "10.0.0.6", Username: "vcap", PrivateKeyPath: "/does/not/exist"},
+		})
+
+		Expect(result.Operation).To(Equal(bbr.BackupCleanup))
+		Expect(result.Category).To(Equal("discovery"))
+	})
+})
@@ -0,0 +1,132 @@
+package bbr
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/cloudfoundry/bosh-backup-and-restore/executor"
+	"github.com/cloudfoundry/bosh-backup-and-restore/factory"
+	"github.com/cloudfoundry/bosh-backup-and-restore/orchestrator"
+)
+
+type DeploymentBackupOptions struct {
+	Options
+	Target     Target
+	Deployment string
+	// ArtifactPath is the directory the backup is created in, which defaults
+	// to the working directory.
+	ArtifactPath   string
+	WithManifest   bool
+	UnsafeLockFree bool
+	// CheckDiskSpace fails the backup before locking if it is not expected
+	// to fit on the instances or in ArtifactPath.
+	CheckDiskSpace bool
+	// ArtifactStreams is how many parallel SSH streams each large artifact
+	// is downloaded over. It defaults to 1.
+	ArtifactStreams int
+	// Concurrency is how many instances are backed up at once. It defaults
+	// to 10.
+	Concurrency int
+	Hooks       Hooks
+}
+
+type DeploymentRestoreOptions struct {
+	Options
+	Target     Target
+	Deployment string
+	// ArtifactPath is the directory of the backup to restore.
+	ArtifactPath string
+	Hooks        Hooks
+}
+
+// DeploymentOptions are the options of the pre-backup check and the
+// cleanups of a deployment.
+type DeploymentOptions struct {
+	Options
+	Target     Target
+	Deployment string
+}
+
+func BackupDeployment(ctx context.Context, options DeploymentBackupOptions) Result {
+	options.ArtifactStreams = orDefault(options.ArtifactStreams, 1)
+	options.Concurrency = orDefault(options.Concurrency, executor.DefaultMaxInFlight)
+	if err := factory.ValidateArtifactStreams(options.ArtifactStreams); err != nil {
+		return fail(options.Options, Backup, options.Deployment, err)
+	}
+	if err := factory.ValidateConcurrency(options.Concurrency); err != nil {
+		return fail(options.Options, Backup, options.Deployment, err)
+	}
+
+	logger := options.logger()
+	timestamp := time.Now().UTC().Format(artifactTimeStampFormat)
+	target := options.Target
+	artifact := factory.BackupArtifactDir(options.ArtifactPath, options.Deployment, timestamp)
+
+	return run(ctx, options.Options, Backup, options.Deployment, artifact, func(ctx context.Context) orchestrator.Error {
+		backuper, err := factory.BuildDeploymentBackuper(target.URL, target.Username, target.Password, target.CACert, options.ProxyJump,
+			options.WithManifest, options.UnsafeLockFree, options.Version, logger, timestamp, options.Hooks.orchestratorHooks(),
+			options.CheckDiskSpace, options.ArtifactStreams, options.Concurrency)
+		if err != nil {
+			return orchestrator.NewError(orchestrator.NewDiscoveryErrorFrom(err))
+		}
+
+		return backuper.BackupWithContext(ctx, options.Deployment, options.ArtifactPath)
+	})
+}
+
+func RestoreDeployment(ctx context.Context, options DeploymentRestoreOptions) Result {
+	if options.ArtifactPath == "" {
+		return fail(options.Options, Restore, options.Deployment, errors.New("the artifact path of the backup to restore is required"))
+	}
+
+	target := options.Target
+	return run(ctx, options.Options, Restore, options.Deployment, options.ArtifactPath, func(ctx context.Context) orchestrator.Error {
+		restorer, err := factory.BuildDeploymentRestorerWithLogger(target.URL, target.Username, target.Password, target.CACert,
+			options.Version, options.ProxyJump, options.logger(), options.Hooks.orchestratorHooks())
+		if err != nil {
+			return orchestrator.NewError(orchestrator.NewDiscoveryErrorFrom(err))
+		}
+
+		return restorer.RestoreWithContext(ctx, options.Deployment, options.ArtifactPath)
+	})
+}
+
+func PreBackupCheckDeployment(ctx context.Context, options DeploymentOptions) Result {
+	target := options.Target
+	return run(ctx, options.Options, PreBackupCheck, options.Deployment, "", func(ctx context.Context) orchestrator.Error {
+		logger := options.logger()
+		boshClient, err := factory.BuildBoshClient(target.URL, target.Username, target.Password, target.CACert, options.Version, options.ProxyJump, logger)
+		if err != nil {
+			return orchestrator.NewError(orchestrator.NewDiscoveryErrorFrom(err))
+		}
+
+		return factory.BuildDeploymentBackupChecker(boshClient, logger, false).CheckWithContext(ctx, options.Deployment)
+	})
+}
+
+func CleanupDeploymentBackup(ctx context.Context, options DeploymentOptions) Result {
+	target := options.Target
+	return run(ctx, options.Options, BackupCleanup, options.Deployment, "", func(ctx context.Context) orchestrator.Error {
+		cleaner, err := factory.BuildDeploymentBackupCleanuper(target.URL, target.Username, target.Password, target.CACert,
+			options.Version, options.ProxyJump, options.logger())
+		if err != nil {
+			return orchestrator.NewError(orchestrator.NewDiscoveryErrorFrom(err))
+		}
+
+		return cleaner.CleanupWithContext(ctx, options.Deployment)
+	})
+}
+
+func CleanupDeploymentRestore(ctx context.Context, options DeploymentOptions) Result {
+	target := options.Target
+	return run(ctx, options.Options, RestoreCleanup, options.Deployment, "", func(ctx context.Context) orchestrator.Error {
+		cleaner, err := factory.BuildDeploymentRestoreCleanuperWithLogger(target.URL, target.Username, target.Password, target.CACert,
+			options.Version, options.ProxyJump, false, options.logger())
+		if err != nil {
+			return orchestrator.NewError(orchestrator.NewDiscoveryErrorFrom(err))
+		}
+
+		return cleaner.CleanupWithContext(ctx, options.Deployment)
+	})
+}
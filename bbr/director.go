@@ -0,0 +1,114 @@
+package bbr
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/cloudfoundry/bosh-backup-and-restore/factory"
+	"github.com/cloudfoundry/bosh-backup-and-restore/orchestrator"
+)
+
+type DirectorBackupOptions struct {
+	Options
+	SSH DirectorSSH
+	// ArtifactPath is the directory the backup is created in, which defaults
+	// to the working directory.
+	ArtifactPath string
+	// CheckDiskSpace fails the backup before locking if it is not expected
+	// to fit on the director or in ArtifactPath.
+	CheckDiskSpace bool
+	// ArtifactStreams is how many parallel SSH streams each large artifact
+	// is downloaded over. It defaults to 1.
+	ArtifactStreams int
+	Hooks           Hooks
+}
+
+type DirectorRestoreOptions struct {
+	Options
+	SSH DirectorSSH
+	// ArtifactPath is the directory of the backup to restore.
+	ArtifactPath string
+	// AllowDifferentDirector restores a backup taken from another director.
+	AllowDifferentDirector bool
+	Hooks                  Hooks
+}
+
+// DirectorOptions are the options of the pre-backup check and the cleanups
+// of a director.
+type DirectorOptions struct {
+	Options
+	SSH DirectorSSH
+}
+
+func BackupDirector(ctx context.Context, options DirectorBackupOptions) Result {
+	name := directorName(options.SSH.Host)
+	options.ArtifactStreams = orDefault(options.ArtifactStreams, 1)
+	if err := factory.ValidateArtifactStreams(options.ArtifactStreams); err != nil {
+		return fail(options.Options, Backup, name, err)
+	}
+
+	timestamp := time.Now().UTC().Format(artifactTimeStampFormat)
+	artifact := factory.BackupArtifactDir(options.ArtifactPath, name, timestamp)
+
+	return run(ctx, options.Options, Backup, name, artifact, func(ctx context.Context) orchestrator.Error {
+		backuper := factory.BuildDirectorBackuperWithLogger(options.SSH.Host, options.SSH.Username, options.SSH.config(), options.ProxyJump,
+			options.Version, options.logger(), timestamp, options.Hooks.orchestratorHooks(), options.CheckDiskSpace, options.ArtifactStreams)
+
+		return backuper.BackupWithContext(ctx, name, options.ArtifactPath)
+	})
+}
+
+func RestoreDirector(ctx context.Context, options DirectorRestoreOptions) Result {
+	name := directorName(options.SSH.Host)
+	if options.ArtifactPath == "" {
+		return fail(options.Options, Restore, name, errors.New("the artifact path of the backup to restore is required"))
+	}
+
+	return run(ctx, options.Options, Restore, name, options.ArtifactPath, func(ctx context.Context) orchestrator.Error {
+		restorer := factory.BuildDirectorRestorerWithLogger(options.SSH.Host, options.SSH.Username, options.SSH.config(), options.ProxyJump,
+			options.AllowDifferentDirector, options.Version, options.logger(), options.Hooks.orchestratorHooks())
+
+		return restorer.RestoreWithContext(ctx, name, options.ArtifactPath)
+	})
+}
+
+func PreBackupCheckDirector(ctx context.Context, options DirectorOptions) Result {
+	name := directorName(options.SSH.Host)
+	return run(ctx, options.Options, PreBackupCheck, name, "", func(ctx context.Context) orchestrator.Error {
+		checker := factory.BuildDirectorBackupCheckerWithLogger(options.SSH.Host, options.SSH.Username, options.SSH.config(), options.ProxyJump,
+			options.Version, options.logger())
+
+		return checker.CheckWithContext(ctx, name)
+	})
+}
+
+func CleanupDirectorBackup(ctx context.Context, options DirectorOptions) Result {
+	name := directorName(options.SSH.Host)
+	return run(ctx, options.Options, BackupCleanup, name, "", func(ctx context.Context) orchestrator.Error {
+		cleaner := factory.BuildDirectorBackupCleanerWithLogger(options.SSH.Host, options.SSH.Username, options.SSH.config(), options.ProxyJump,
+			options.Version, options.logger())
+
+		return cleaner.CleanupWithContext(ctx, name)
+	})
+}
+
+func CleanupDirectorRestore(ctx context.Context, options DirectorOptions) Result {
+	name := directorName(options.SSH.Host)
+	return run(ctx, options.Options, RestoreCleanup, name, "", func(ctx context.Context) orchestrator.Error {
+		cleaner := factory.BuildDirectorRestoreCleanerWithLogger(options.SSH.Host, options.SSH.Username, options.SSH.config(), options.ProxyJump,
+			options.Version, options.logger())
+
+		return cleaner.CleanupWithContext(ctx, name)
+	})
+}
+
+func directorName(host string) string {
+	hostURL, err := url.Parse(host)
+	if err == nil && hostURL.Hostname() != "" {
+		host = hostURL.Hostname()
+	}
+	return strings.Split(host, ":")[0]
+}
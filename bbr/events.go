@@ -0,0 +1,71 @@
+package bbr
+
+import "time"
+
+type EventType string
+
+const (
+	OperationStarted  EventType = "operation-started"
+	StepStarted       EventType = "step-started"
+	StepFinished      EventType = "step-finished"
+	OperationFinished EventType = "operation-finished"
+)
+
+// Event reports the progress of an operation. Step is only set for step
+// events, Err only for the steps that failed, and Result only when the
+// operation has finished.
+type Event struct {
+	Type       EventType
+	Operation  Operation
+	Deployment string
+	Step       string
+	Time       time.Time
+	Err        error
+	Result     *Result
+}
+
+// EventHandler is called on the goroutine running the operation, so it
+// should not block.
+type EventHandler interface {
+	HandleEvent(Event)
+}
+
+type EventHandlerFunc func(Event)
+
+func (f EventHandlerFunc) HandleEvent(event Event) {
+	f(event)
+}
+
+// eventEmitter is the orchestrator.StepObserver that sends the steps of an
+// operation to its EventHandler. It is nil when there is no EventHandler.
+type eventEmitter struct {
+	handler   EventHandler
+	operation Operation
+}
+
+func newEventEmitter(handler EventHandler, operation Operation) *eventEmitter {
+	if handler == nil {
+		return nil
+	}
+	return &eventEmitter{handler: handler, operation: operation}
+}
+
+func (e *eventEmitter) operationStarted(deployment string, startTime time.Time) {
+	if e != nil {
+		e.handler.HandleEvent(Event{Type: OperationStarted, Operation: e.operation, Deployment: deployment, Time: startTime})
+	}
+}
+
+func (e *eventEmitter) operationFinished(result Result) {
+	if e != nil {
+		e.handler.HandleEvent(Event{Type: OperationFinished, Operation: e.operation, Deployment: result.Deployment, Time: result.FinishTime, Err: result.Err, Result: &result})
+	}
+}
+
+func (e *eventEmitter) StepStarted(deployment, step string) {
+	e.handler.HandleEvent(Event{Type: StepStarted, Operation: e.operation, Deployment: deployment, Step: step, Time: time.Now()})
+}
+
+func (e *eventEmitter) StepFinished(deployment, step string, err error) {
+	e.handler.HandleEvent(Event{Type: StepFinished, Operation: e.operation, Deployment: deployment, Step: step, Time: time.Now(), Err: err})
+}
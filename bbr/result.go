@@ -0,0 +1,86 @@
+package bbr
+
+import (
+	"time"
+
+	"github.com/cloudfoundry/bosh-backup-and-restore/orchestrator"
+)
+
+type Operation string
+
+const (
+	Backup         Operation = "backup"
+	Restore        Operation = "restore"
+	PreBackupCheck Operation = "pre-backup-check"
+	BackupCleanup  Operation = "backup-cleanup"
+	RestoreCleanup Operation = "restore-cleanup"
+)
+
+// Result is the outcome of an operation.
+type Result struct {
+	Operation Operation
+	// Deployment is the name of the deployment, or of the director.
+	Deployment string
+	// Artifact is the directory of the backup that was created or restored.
+	// A backup that failed may not have created it.
+	Artifact   string
+	StartTime  time.Time
+	FinishTime time.Time
+
+	// Err is nil if the operation succeeded. Otherwise it lists every error,
+	// in the order they occurred.
+	Err error
+	// ExitCode is what the bbr CLI would have exited with, see the README.
+	ExitCode int
+	// Category classifies why the operation failed.
+	Category string
+	// FailedScripts are the scripts that failed on the instances.
+	FailedScripts []ScriptError
+	// CleanupAdvised is set when the deployment may have been left locked or
+	// with files on its instances, so the backup or restore cleanup should be
+	// run before retrying the backup or restore.
+	CleanupAdvised bool
+}
+
+type ScriptError struct {
+	Instance string
+	Job      string
+	Script   string
+	// ExitCode and Stderr are only set if the script ran and exited non-zero.
+	ExitCode int
+	Stderr   string
+}
+
+func (result Result) Succeeded() bool {
+	return result.Err == nil
+}
+
+func (result *Result) setErrors(errs orchestrator.Error) {
+	if errs.IsNil() {
+		return
+	}
+
+	result.Err = errs
+	result.ExitCode = orchestrator.BuildExitCode(errs)
+	result.Category = string(orchestrator.Category(errs[0]))
+	if category, failed := orchestrator.FailureCategory(errs); failed {
+		result.Category = string(category)
+	}
+
+	switch result.Operation {
+	case Backup, PreBackupCheck:
+		result.CleanupAdvised = errs.ContainsUnlockOrCleanupOrArtifactDirExists()
+	case Restore:
+		result.CleanupAdvised = true
+	}
+
+	for _, scriptError := range orchestrator.FailedScripts(errs) {
+		result.FailedScripts = append(result.FailedScripts, ScriptError{
+			Instance: scriptError.Instance,
+			Job:      scriptError.Job,
+			Script:   scriptError.Script,
+			ExitCode: scriptError.ExitCode,
+			Stderr:   scriptError.Stderr,
+		})
+	}
+}
@@ -11,6 +11,12 @@ import (
 )
 
 func BuildClient(targetUrl, username, password, caCert, bbrVersion string, logger boshlog.Logger) (Client, error) {
+	return BuildClientWithProxyJump(targetUrl, username, password, caCert, bbrVersion, nil, logger)
+}
+
+// BuildClientWithProxyJump connects to instances through the first of
+// proxyJump that matches their address.
+func BuildClientWithProxyJump(targetUrl, username, password, caCert, bbrVersion string, proxyJump []ssh.JumpRoute, logger boshlog.Logger) (Client, error) {
 	var client Client
 
 	factoryConfig, err := director.NewConfigFromURL(targetUrl)
@@ -44,7 +50,7 @@ func BuildClient(targetUrl, username, password, caCert, bbrVersion string, logge
 		return client, errors.Wrap(err, "error building bosh director client")
 	}
 
-	return NewClient(boshDirector, director.NewSSHOpts, ssh.NewSshRemoteRunner, logger, instance.NewJobFinder(bbrVersion, logger), NewBoshManifestQuerier), nil
+	return NewClient(boshDirector, director.NewSSHOpts, ssh.NewRemoteRunnerFactory(proxyJump), logger, instance.NewJobFinder(bbrVersion, logger), NewBoshManifestQuerier), nil
 }
 
 func getDirectorInfo(directorFactory director.Factory, factoryConfig director.FactoryConfig) (director.Info, error) {
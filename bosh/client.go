@@ -1,7 +1,9 @@
 package bosh
 
 import (
+	"context"
 	"strconv"
+	"sync"
 
 	"github.com/cloudfoundry/bosh-backup-and-restore/instance"
 	"github.com/cloudfoundry/bosh-backup-and-restore/orchestrator"
@@ -18,7 +20,7 @@ import (
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
 //counterfeiter:generate -o fakes/fake_bosh_client.go . BoshClient
 type BoshClient interface {
-	FindInstances(deploymentName string) ([]orchestrator.Instance, error)
+	FindInstances(ctx context.Context, deploymentName string) ([]orchestrator.Instance, error)
 	GetManifest(deploymentName string) (string, error)
 }
 
@@ -55,7 +57,7 @@ type Logger interface {
 	Error(tag, msg string, args ...interface{})
 }
 
-func (c Client) FindInstances(deploymentName string) ([]orchestrator.Instance, error) {
+func (c Client) FindInstances(ctx context.Context, deploymentName string) ([]orchestrator.Instance, error) {
 	deployment, err := c.Director.FindDeployment(deploymentName) //nolint:staticcheck
 	if err != nil {
 		return nil, errors.Wrap(err, "couldn't find deployment "+deploymentName)
@@ -72,9 +74,6 @@ func (c Client) FindInstances(deploymentName string) ([]orchestrator.Instance, e
 	}
 	c.Logger.Debug("bbr", "SSH user generated: %s", sshOpts.Username) //nolint:staticcheck
 
-	var instances []orchestrator.Instance
-	var slugs []director.AllOrInstanceGroupOrInstanceSlug
-
 	manifest, err := deployment.Manifest()
 	if err != nil {
 		return nil, errors.Wrap(err, "couldn't find manifest for deployment "+deploymentName)
@@ -85,87 +84,216 @@ func (c Client) FindInstances(deploymentName string) ([]orchestrator.Instance, e
 		return nil, errors.Wrap(err, "couldn't generate manifest querier for deployment "+deploymentName)
 	}
 
-	for _, instanceGroupName := range uniqueInstanceGroupNamesFromVMs(vms) {
-		c.Logger.Debug("bbr", "Setting up SSH for job %s", instanceGroupName) //nolint:staticcheck
+	d := discovery{
+		client:          c,
+		deployment:      deployment,
+		vms:             vms,
+		sshOpts:         sshOpts,
+		privateKey:      privateKey,
+		manifestQuerier: manifestQuerier,
+	}
+
+	groups, err := d.setUpSSH(uniqueInstanceGroupNamesFromVMs(vms))
+	if err != nil {
+		d.cleanup()
+		return nil, err
+	}
+
+	instances, err := d.findInstances(ctx, groups)
+	if err != nil {
+		d.cleanup()
+		return nil, err
+	}
+
+	return instances, nil
+}
+
+// maxDiscoveryInFlight bounds the number of concurrent calls made to the
+// director and to the VMs while finding instances.
+const maxDiscoveryInFlight = 10
+
+type discovery struct {
+	client          Client
+	deployment      director.Deployment
+	vms             []director.VMInfo
+	sshOpts         director.SSHOpts
+	privateKey      string
+	manifestQuerier instance.ManifestQuerier
+
+	slugsMutex sync.Mutex
+	slugs      []director.AllOrInstanceGroupOrInstanceSlug
+}
+
+type discoveredGroup struct {
+	name      string
+	hosts     []director.Host
+	instances []orchestrator.Instance
+}
+
+func (d *discovery) setUpSSH(instanceGroupNames []string) ([]*discoveredGroup, error) {
+	groups := make([]*discoveredGroup, len(instanceGroupNames))
+
+	err := inParallel(len(instanceGroupNames), func(i int) error {
+		instanceGroupName := instanceGroupNames[i]
+		d.client.Logger.Debug("bbr", "Setting up SSH for job %s", instanceGroupName) //nolint:staticcheck
 
 		allVmInstances, err := director.NewAllOrInstanceGroupOrInstanceSlugFromString(instanceGroupName)
 		if err != nil {
-			cleanupAlreadyMadeConnections(deployment, slugs, sshOpts)
-			return nil, errors.Wrap(err, "invalid instance group name: "+instanceGroupName)
+			return errors.Wrap(err, "invalid instance group name: "+instanceGroupName)
 		}
 
-		sshRes, err := deployment.SetUpSSH(allVmInstances, sshOpts)
+		sshRes, err := d.deployment.SetUpSSH(allVmInstances, d.sshOpts)
 		if err != nil {
-			cleanupAlreadyMadeConnections(deployment, slugs, sshOpts)
-			return nil, errors.Wrap(err, "failed to set up ssh")
+			return errors.Wrap(err, "failed to set up ssh")
 		}
-		slugs = append(slugs, allVmInstances)
+		d.addSlug(allVmInstances)
 
-		for _, host := range sshRes.Hosts {
+		groups[i] = &discoveredGroup{name: instanceGroupName, hosts: sshRes.Hosts}
+		return nil
+	})
 
-			var err error
+	return groups, err
+}
 
-			c.Logger.Debug("bbr", "Attempting to SSH onto %s, %s", host.Host, host.IndexOrID) //nolint:staticcheck
+// findInstances first finds the jobs on the first instance of every group.
+// Groups whose first instance has no scripts are not searched any further;
+// the rest of the instances are then searched all at once.
+func (d *discovery) findInstances(ctx context.Context, groups []*discoveredGroup) ([]orchestrator.Instance, error) {
+	remaining := make([][]director.Host, len(groups))
 
-			hostPublicKey, _, _, _, err := gossh.ParseAuthorizedKey([]byte(host.HostPublicKey))
-			if err != nil {
-				return nil, errors.Wrap(err, "ssh.NewConnection.ParseAuthorizedKey failed")
-			}
+	err := inParallel(len(groups), func(i int) error {
+		group := groups[i]
+		if len(group.hosts) == 0 {
+			return nil
+		}
 
-			remoteRunner, err := c.RemoteRunnerFactory(host.Host, host.Username, privateKey, gossh.FixedHostKey(hostPublicKey), supportedEncryptionAlgorithms(hostPublicKey), c.Logger)
-			if err != nil {
-				cleanupAlreadyMadeConnections(deployment, slugs, sshOpts)
-				return nil, errors.Wrap(err, "failed to connect using ssh")
-			}
+		host := group.hosts[0]
+		deployedInstance, jobs, err := d.findInstance(ctx, group.name, host)
+		if err != nil {
+			return err
+		}
 
-			isBootstrap := isInstanceABootstrapNode(instanceGroupName, host.Host, vms)
-			instanceIdentifier := instance.InstanceIdentifier{InstanceGroupName: instanceGroupName, InstanceId: host.IndexOrID, Bootstrap: isBootstrap}
+		group.instances = append(group.instances, deployedInstance)
+		if len(jobs) == 0 {
+			d.client.Logger.Debug("bbr", "no scripts found on instance %s/%s, skipping rest of the instances for %s", group.name, host.IndexOrID, group.name) //nolint:staticcheck
+			return nil
+		}
 
-			isWindows, err := remoteRunner.IsWindows()
-			if err != nil {
-				cleanupAlreadyMadeConnections(deployment, slugs, sshOpts)
-				return nil, errors.Wrap(err, "failed to check os")
-			}
+		remaining[i] = group.hosts[1:]
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
 
-			if isWindows {
-				c.Logger.Warn("bbr", "skipping Windows instance %s/%s", instanceGroupName, host.IndexOrID) //nolint:staticcheck
-				continue
-			}
+	type pendingHost struct {
+		group *discoveredGroup
+		host  director.Host
+	}
+	var pending []pendingHost
+	for i, group := range groups {
+		for _, host := range remaining[i] {
+			pending = append(pending, pendingHost{group: group, host: host})
+		}
+	}
 
-			jobs, err := c.jobFinder.FindJobs(instanceIdentifier, remoteRunner, manifestQuerier) //TODO: here
+	found := make([]orchestrator.Instance, len(pending))
+	err = inParallel(len(pending), func(i int) error {
+		deployedInstance, _, err := d.findInstance(ctx, pending[i].group.name, pending[i].host)
+		found[i] = deployedInstance
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
 
-			if err != nil {
-				cleanupAlreadyMadeConnections(deployment, slugs, sshOpts)
-				return nil, errors.Wrap(err, "couldn't find jobs")
-			}
+	for i, deployedInstance := range found {
+		pending[i].group.instances = append(pending[i].group.instances, deployedInstance)
+	}
 
-			vmIndex, err := findInstanceIndexById(vms, host.IndexOrID)
-			if err != nil {
-				cleanupAlreadyMadeConnections(deployment, slugs, sshOpts)
-				return nil, errors.Wrap(err, "couldn't find instance index")
-			}
+	var instances []orchestrator.Instance
+	for _, group := range groups {
+		instances = append(instances, group.instances...)
+	}
+	return instances, nil
+}
 
-			instances = append(instances,
-				NewBoshDeployedInstance(
-					instanceGroupName,
-					vmIndex,
-					host.IndexOrID,
-					remoteRunner,
-					deployment,
-					false,
-					c.Logger,
-					jobs,
-				),
-			)
-
-			if len(jobs) == 0 {
-				c.Logger.Debug("bbr", "no scripts found on instance %s/%s, skipping rest of the instances for %s", instanceGroupName, host.IndexOrID, instanceGroupName) //nolint:staticcheck
-				break
-			}
-		}
+func (d *discovery) findInstance(ctx context.Context, instanceGroupName string, host director.Host) (orchestrator.Instance, orchestrator.Jobs, error) {
+	d.client.Logger.Debug("bbr", "Attempting to SSH onto %s, %s", host.Host, host.IndexOrID) //nolint:staticcheck
+
+	hostPublicKey, _, _, _, err := gossh.ParseAuthorizedKey([]byte(host.HostPublicKey))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "ssh.NewConnection.ParseAuthorizedKey failed")
 	}
 
-	return instances, nil
+	remoteRunner, err := d.client.RemoteRunnerFactory(ctx, host.Host, host.Username, d.privateKey, gossh.FixedHostKey(hostPublicKey), supportedEncryptionAlgorithms(hostPublicKey), d.client.Logger)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to connect using ssh")
+	}
+
+	vmIndex, err := findInstanceIndexById(d.vms, host.IndexOrID)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "couldn't find instance index")
+	}
+
+	isBootstrap := isInstanceABootstrapNode(instanceGroupName, host.Host, d.vms)
+	instanceIdentifier := instance.InstanceIdentifier{InstanceGroupName: instanceGroupName, InstanceId: host.IndexOrID, InstanceIndex: vmIndex, Bootstrap: isBootstrap}
+
+	jobs, err := d.client.jobFinder.FindJobs(ctx, instanceIdentifier, remoteRunner, d.manifestQuerier)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "couldn't find jobs")
+	}
+
+	return NewBoshDeployedInstance(
+		instanceGroupName,
+		vmIndex,
+		host.IndexOrID,
+		remoteRunner,
+		d.deployment,
+		false,
+		d.client.Logger,
+		jobs,
+	), jobs, nil
+}
+
+func (d *discovery) addSlug(slug director.AllOrInstanceGroupOrInstanceSlug) {
+	d.slugsMutex.Lock()
+	defer d.slugsMutex.Unlock()
+	d.slugs = append(d.slugs, slug)
+}
+
+func (d *discovery) cleanup() {
+	d.slugsMutex.Lock()
+	defer d.slugsMutex.Unlock()
+	cleanupAlreadyMadeConnections(d.deployment, d.slugs, d.sshOpts)
+}
+
+// inParallel calls task for every index below count, with at most
+// maxDiscoveryInFlight running at once. It waits for all of them to finish and
+// returns the error of the lowest failing index, so that the result does not
+// depend on scheduling.
+func inParallel(count int, task func(int) error) error {
+	errs := make([]error, count)
+	guard := make(chan bool, maxDiscoveryInFlight)
+	var wg sync.WaitGroup
+
+	for i := 0; i < count; i++ {
+		guard <- true
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = task(i)
+			<-guard
+		}(i)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func supportedEncryptionAlgorithms(key gossh.PublicKey) []string {
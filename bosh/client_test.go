@@ -1,12 +1,16 @@
 package bosh_test
 
 import (
+	"context"
 	"log"
 
 	"bytes"
 	"io"
 
 	"errors"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/cloudfoundry/bosh-backup-and-restore/bosh"
 	"github.com/cloudfoundry/bosh-backup-and-restore/instance"
@@ -73,7 +77,7 @@ var _ = Describe("Director", func() {
 		)
 
 		JustBeforeEach(func() {
-			actualInstances, actualError = b.FindInstances(deploymentName)
+			actualInstances, actualError = b.FindInstances(context.Background(), deploymentName)
 		})
 
 		Context("finds instances for the deployment", func() {
@@ -152,7 +156,7 @@ var _ = Describe("Director", func() {
 
 			It("finds the jobs with the job finder", func() {
 				Expect(fakeJobFinder.FindJobsCallCount()).To(Equal(1))
-				_, _, manifestQuerier := fakeJobFinder.FindJobsArgsForCall(0)
+				_, _, _, manifestQuerier := fakeJobFinder.FindJobsArgsForCall(0)
 				Expect(manifestQuerier).To(Equal(manifestQuerier))
 			})
 
@@ -166,7 +170,7 @@ var _ = Describe("Director", func() {
 
 			It("creates a remote runner for each host", func() {
 				Expect(remoteRunnerFactory.CallCount()).To(Equal(1))
-				host, username, privateKey, _, hostPublicKeyAlgorithm, logger := remoteRunnerFactory.ArgsForCall(0)
+				_, host, username, privateKey, _, hostPublicKeyAlgorithm, logger := remoteRunnerFactory.ArgsForCall(0)
 				Expect(host).To(Equal("10.0.0.0"))
 				Expect(username).To(Equal("username"))
 				Expect(privateKey).To(Equal("private_key"))
@@ -195,7 +199,7 @@ var _ = Describe("Director", func() {
 
 			It("uses the specified port", func() {
 				Expect(remoteRunnerFactory.CallCount()).To(Equal(1))
-				host, username, privateKey, _, hostPublicKeyAlgorithm, logger := remoteRunnerFactory.ArgsForCall(0)
+				_, host, username, privateKey, _, hostPublicKeyAlgorithm, logger := remoteRunnerFactory.ArgsForCall(0)
 				Expect(host).To(Equal("10.0.0.0:3457"))
 				Expect(username).To(Equal("username"))
 				Expect(privateKey).To(Equal("private_key"))
@@ -261,7 +265,7 @@ var _ = Describe("Director", func() {
 						false,
 					),
 				}
-				fakeJobFinder.FindJobsStub = func(instanceIdentifier instance.InstanceIdentifier, remoteRunner ssh.RemoteRunner, manifestQuerier instance.ManifestQuerier) (orchestrator.Jobs, error) {
+				fakeJobFinder.FindJobsStub = func(_ context.Context, instanceIdentifier instance.InstanceIdentifier, remoteRunner ssh.RemoteRunner, manifestQuerier instance.ManifestQuerier) (orchestrator.Jobs, error) {
 					if instanceIdentifier.InstanceId == "id1" {
 						return instance0Jobs, nil
 					} else {
@@ -328,14 +332,14 @@ var _ = Describe("Director", func() {
 			It("creates a remote runner for each host", func() {
 				Expect(remoteRunnerFactory.CallCount()).To(Equal(2))
 
-				host, username, privateKey, _, hostPublicKeyAlgorithm, logger := remoteRunnerFactory.ArgsForCall(0)
+				_, host, username, privateKey, _, hostPublicKeyAlgorithm, logger := remoteRunnerFactory.ArgsForCall(0)
 				Expect(host).To(Equal("10.0.0.1"))
 				Expect(username).To(Equal("username"))
 				Expect(privateKey).To(Equal("private_key"))
 				Expect(hostPublicKeyAlgorithm).To(Equal(hostKeyAlgorithmRSA))
 				Expect(logger).To(Equal(boshLogger))
 
-				host, username, privateKey, _, hostPublicKeyAlgorithm, logger = remoteRunnerFactory.ArgsForCall(1)
+				_, host, username, privateKey, _, hostPublicKeyAlgorithm, logger = remoteRunnerFactory.ArgsForCall(1)
 				Expect(host).To(Equal("10.0.0.2"))
 				Expect(username).To(Equal("username"))
 				Expect(privateKey).To(Equal("private_key"))
@@ -349,7 +353,8 @@ var _ = Describe("Director", func() {
 		})
 
 		Context("finds instances for the deployment, having multiple instances, including a windows vm, in an instance group", func() {
-			var instance0Jobs orchestrator.Jobs
+			var linuxJobs, windowsJobs orchestrator.Jobs
+			var windowsRemoteRunner *sshfakes.FakeRemoteRunner
 
 			BeforeEach(func() {
 				boshDirector.FindDeploymentReturns(boshDeployment, nil)
@@ -381,12 +386,17 @@ var _ = Describe("Director", func() {
 					},
 				}}, nil)
 
-				remoteRunner.IsWindowsReturnsOnCall(0, false, nil)
-				remoteRunner.IsWindowsReturnsOnCall(1, true, nil)
+				windowsRemoteRunner = new(sshfakes.FakeRemoteRunner)
+				windowsRemoteRunner.IsWindowsReturns(true, nil)
 
-				remoteRunnerFactory.Returns(remoteRunner, nil)
+				remoteRunnerFactory.Stub = func(_ context.Context, host, user, privateKey string, publicKeyCallback gossh.HostKeyCallback, publicKeyAlgorithm []string, logger ssh.Logger) (ssh.RemoteRunner, error) {
+					if host == "10.0.0.2" {
+						return windowsRemoteRunner, nil
+					}
+					return remoteRunner, nil
+				}
 
-				instance0Jobs = []orchestrator.Job{
+				linuxJobs = []orchestrator.Job{
 					instance.NewJob(
 						remoteRunner,
 						"",
@@ -398,19 +408,30 @@ var _ = Describe("Director", func() {
 						false,
 					),
 				}
+				windowsJobs = []orchestrator.Job{
+					instance.NewJob(
+						windowsRemoteRunner,
+						"",
+						boshLogger,
+						"",
+						instance.BackupAndRestoreScripts{"/var/vcap/jobs/dotnet_app/bin/bbr/backup"},
+						instance.Metadata{},
+						false,
+						false,
+					),
+				}
 
-				fakeJobFinder.FindJobsStub = func(instanceIdentifier instance.InstanceIdentifier, remoteRunner ssh.RemoteRunner, manifestQuerier instance.ManifestQuerier) (orchestrator.Jobs, error) {
+				fakeJobFinder.FindJobsStub = func(_ context.Context, instanceIdentifier instance.InstanceIdentifier, remoteRunner ssh.RemoteRunner, manifestQuerier instance.ManifestQuerier) (orchestrator.Jobs, error) {
 					if instanceIdentifier.InstanceId == "linux1" {
-						return instance0Jobs, nil
-					} else {
-						return nil, errors.New("should not call FindJobs on non-Linux VMs")
+						return linuxJobs, nil
 					}
+					return windowsJobs, nil
 				}
 
 				manifestQuerierCreator.Returns(manifestQuerier, nil)
 			})
 
-			It("collects the instances", func() {
+			It("collects the windows instance too", func() {
 				Expect(actualInstances).To(Equal([]orchestrator.Instance{
 					bosh.NewBoshDeployedInstance(
 						"job1",
@@ -420,7 +441,17 @@ var _ = Describe("Director", func() {
 						boshDeployment,
 						false,
 						boshLogger,
-						instance0Jobs,
+						linuxJobs,
+					),
+					bosh.NewBoshDeployedInstance(
+						"job1",
+						"1",
+						"windows2",
+						windowsRemoteRunner,
+						boshDeployment,
+						false,
+						boshLogger,
+						windowsJobs,
 					),
 				}))
 			})
@@ -429,12 +460,10 @@ var _ = Describe("Director", func() {
 				Expect(actualError).NotTo(HaveOccurred())
 			})
 
-			It("checks the os is linux", func() {
-				Expect(remoteRunner.IsWindowsCallCount()).To(Equal(2))
-			})
-
-			It("only finds the jobs with the job finder on the linux instance", func() {
-				Expect(fakeJobFinder.FindJobsCallCount()).To(Equal(1))
+			It("finds the jobs on the windows instance with its own remote runner", func() {
+				Expect(fakeJobFinder.FindJobsCallCount()).To(Equal(2))
+				_, _, actualRemoteRunner, _ := fakeJobFinder.FindJobsArgsForCall(1)
+				Expect(actualRemoteRunner).To(Equal(windowsRemoteRunner))
 			})
 		})
 
@@ -506,7 +535,7 @@ var _ = Describe("Director", func() {
 					}
 				}
 				remoteRunnerFactory.Returns(remoteRunner, nil)
-				fakeJobFinder.FindJobsStub = func(instanceIdentifier instance.InstanceIdentifier,
+				fakeJobFinder.FindJobsStub = func(_ context.Context, instanceIdentifier instance.InstanceIdentifier,
 					remoteRunner ssh.RemoteRunner, manifestQuerier instance.ManifestQuerier) (orchestrator.Jobs, error) {
 					if instanceIdentifier.InstanceGroupName == "job2" {
 						return []orchestrator.Job{
@@ -609,57 +638,102 @@ var _ = Describe("Director", func() {
 			It("sets up ssh for each group found", func() {
 				Expect(boshDeployment.SetUpSSHCallCount()).To(Equal(2))
 
-				slug, opts := boshDeployment.SetUpSSHArgsForCall(0)
-				Expect(slug).To(Equal(director.NewAllOrInstanceGroupOrInstanceSlug("job1", "")))
-				Expect(opts).To(Equal(stubbedSshOpts))
-
-				slug, opts = boshDeployment.SetUpSSHArgsForCall(1)
-				Expect(slug).To(Equal(director.NewAllOrInstanceGroupOrInstanceSlug("job2", "")))
-				Expect(opts).To(Equal(stubbedSshOpts))
+				var slugs []director.AllOrInstanceGroupOrInstanceSlug
+				for i := 0; i < boshDeployment.SetUpSSHCallCount(); i++ {
+					slug, opts := boshDeployment.SetUpSSHArgsForCall(i)
+					Expect(opts).To(Equal(stubbedSshOpts))
+					slugs = append(slugs, slug)
+				}
+				Expect(slugs).To(ConsistOf(
+					director.NewAllOrInstanceGroupOrInstanceSlug("job1", ""),
+					director.NewAllOrInstanceGroupOrInstanceSlug("job2", ""),
+				))
 			})
 
 			It("creates a remote runner for each host that has scripts, and the first instance of each group that doesn't", func() {
 				Expect(remoteRunnerFactory.CallCount()).To(Equal(3))
 
-				host, username, privateKey, _, hostPublicKeyAlgorithm, logger := remoteRunnerFactory.ArgsForCall(0)
-				Expect(host).To(Equal("10.0.0.1"))
-				Expect(username).To(Equal("username"))
-				Expect(privateKey).To(Equal("private_key"))
-				Expect(hostPublicKeyAlgorithm).To(Equal(hostKeyAlgorithmRSA))
-				Expect(logger).To(Equal(boshLogger))
-
-				host, username, privateKey, _, hostPublicKeyAlgorithm, logger = remoteRunnerFactory.ArgsForCall(1)
-				Expect(host).To(Equal("10.0.0.3"))
-				Expect(username).To(Equal("username"))
-				Expect(privateKey).To(Equal("private_key"))
-				Expect(hostPublicKeyAlgorithm).To(Equal(hostKeyAlgorithmRSA))
-				Expect(logger).To(Equal(boshLogger))
-
-				host, username, privateKey, _, hostPublicKeyAlgorithm, logger = remoteRunnerFactory.ArgsForCall(2)
-				Expect(host).To(Equal("10.0.0.4"))
-				Expect(username).To(Equal("username"))
-				Expect(privateKey).To(Equal("private_key"))
-				Expect(hostPublicKeyAlgorithm).To(Equal(hostKeyAlgorithmRSA))
-				Expect(logger).To(Equal(boshLogger))
+				var hosts []string
+				for i := 0; i < remoteRunnerFactory.CallCount(); i++ {
+					_, host, username, privateKey, _, hostPublicKeyAlgorithm, logger := remoteRunnerFactory.ArgsForCall(i)
+					Expect(username).To(Equal("username"))
+					Expect(privateKey).To(Equal("private_key"))
+					Expect(hostPublicKeyAlgorithm).To(Equal(hostKeyAlgorithmRSA))
+					Expect(logger).To(Equal(boshLogger))
+					hosts = append(hosts, host)
+				}
+				Expect(hosts).To(ConsistOf("10.0.0.1", "10.0.0.3", "10.0.0.4"))
 			})
 
 			It("for each remote runner, it finds the jobs with the job finder", func() {
 				Expect(fakeJobFinder.FindJobsCallCount()).To(Equal(3))
 
-				actualInstanceIdentifier, actualRemoteRunner, actualManifestQuerier := fakeJobFinder.FindJobsArgsForCall(0)
-				Expect(actualInstanceIdentifier).To(Equal(instance.InstanceIdentifier{InstanceGroupName: "job1", InstanceId: "id1", Bootstrap: true}))
-				Expect(actualRemoteRunner).To(Equal(remoteRunner))
-				Expect(actualManifestQuerier).To(Equal(manifestQuerier))
+				var instanceIdentifiers []instance.InstanceIdentifier
+				for i := 0; i < fakeJobFinder.FindJobsCallCount(); i++ {
+					_, actualInstanceIdentifier, actualRemoteRunner, actualManifestQuerier := fakeJobFinder.FindJobsArgsForCall(i)
+					Expect(actualRemoteRunner).To(Equal(remoteRunner))
+					Expect(actualManifestQuerier).To(Equal(manifestQuerier))
+					instanceIdentifiers = append(instanceIdentifiers, actualInstanceIdentifier)
+				}
+				Expect(instanceIdentifiers).To(ConsistOf(
+					instance.InstanceIdentifier{InstanceGroupName: "job1", InstanceId: "id1", InstanceIndex: "0", Bootstrap: true},
+					instance.InstanceIdentifier{InstanceGroupName: "job2", InstanceId: "id3", InstanceIndex: "0", Bootstrap: true},
+					instance.InstanceIdentifier{InstanceGroupName: "job2", InstanceId: "id4", InstanceIndex: "1", Bootstrap: false},
+				))
+			})
+		})
 
-				actualInstanceIdentifier, actualRemoteRunner, actualManifestQuerier = fakeJobFinder.FindJobsArgsForCall(1)
-				Expect(actualInstanceIdentifier).To(Equal(instance.InstanceIdentifier{InstanceGroupName: "job2", InstanceId: "id3", Bootstrap: true}))
-				Expect(actualRemoteRunner).To(Equal(remoteRunner))
-				Expect(actualManifestQuerier).To(Equal(manifestQuerier))
+		Context("finds instances for the deployment, when discovery on later instances finishes first", func() {
+			var instanceGroupNames = []string{"group-a", "group-b", "group-c", "group-d"}
+
+			BeforeEach(func() {
+				var vms []director.VMInfo
+				for _, name := range instanceGroupNames {
+					for index := 0; index < 3; index++ {
+						vms = append(vms, director.VMInfo{JobName: name, ID: fmt.Sprintf("%s-%d", name, index), Index: newIndex(index)})
+					}
+				}
 
-				actualInstanceIdentifier, actualRemoteRunner, actualManifestQuerier = fakeJobFinder.FindJobsArgsForCall(2)
-				Expect(actualInstanceIdentifier).To(Equal(instance.InstanceIdentifier{InstanceGroupName: "job2", InstanceId: "id4", Bootstrap: false}))
-				Expect(actualRemoteRunner).To(Equal(remoteRunner))
-				Expect(actualManifestQuerier).To(Equal(manifestQuerier))
+				boshDirector.FindDeploymentReturns(boshDeployment, nil)
+				boshDeployment.VMInfosReturns(vms, nil)
+				optsGenerator.Returns(stubbedSshOpts, "private_key", nil)
+				boshDeployment.SetUpSSHStub = func(slug director.AllOrInstanceGroupOrInstanceSlug, sshOpts director.SSHOpts) (director.SSHResult, error) {
+					var hosts []director.Host
+					for index := 0; index < 3; index++ {
+						hosts = append(hosts, director.Host{
+							Username:      "username",
+							Host:          fmt.Sprintf("%s-%d", slug.Name(), index),
+							IndexOrID:     fmt.Sprintf("%s-%d", slug.Name(), index),
+							HostPublicKey: hostsPublicKeyRSA,
+						})
+					}
+					return director.SSHResult{Hosts: hosts}, nil
+				}
+				remoteRunnerFactory.Returns(remoteRunner, nil)
+				fakeJobFinder.FindJobsStub = func(_ context.Context, instanceIdentifier instance.InstanceIdentifier, remoteRunner ssh.RemoteRunner, manifestQuerier instance.ManifestQuerier) (orchestrator.Jobs, error) {
+					if strings.HasPrefix(instanceIdentifier.InstanceId, "group-a") {
+						time.Sleep(20 * time.Millisecond)
+					}
+					return orchestrator.Jobs{instance.NewJob(remoteRunner, "", boshLogger, "", instance.BackupAndRestoreScripts{"/var/vcap/jobs/a/bin/bbr/backup"}, instance.Metadata{}, false, false)}, nil
+				}
+				manifestQuerierCreator.Returns(manifestQuerier, nil)
+			})
+
+			It("returns the instances in the order of the instance groups and their hosts", func() {
+				Expect(actualError).NotTo(HaveOccurred())
+
+				var ids []string
+				for _, actualInstance := range actualInstances {
+					ids = append(ids, actualInstance.ID())
+				}
+
+				var expectedIds []string
+				for _, name := range instanceGroupNames {
+					for index := 0; index < 3; index++ {
+						expectedIds = append(expectedIds, fmt.Sprintf("%s-%d", name, index))
+					}
+				}
+				Expect(ids).To(Equal(expectedIds))
 			})
 		})
 
@@ -689,7 +763,7 @@ var _ = Describe("Director", func() {
 
 			It("uses the ECDSA algorithm to create its remote runners", func() {
 				Expect(remoteRunnerFactory.CallCount()).To(Equal(1))
-				_, _, _, _, hostPublicKeyAlgorithm, _ := remoteRunnerFactory.ArgsForCall(0)
+				_, _, _, _, _, hostPublicKeyAlgorithm, _ := remoteRunnerFactory.ArgsForCall(0)
 				Expect(hostPublicKeyAlgorithm).To(Equal(hostKeyAlgorithmECDSA))
 			})
 
@@ -889,6 +963,79 @@ var _ = Describe("Director", func() {
 				})
 			})
 
+			Context("fails to parse the host public key of a vm", func() {
+				BeforeEach(func() {
+					boshDirector.FindDeploymentReturns(boshDeployment, nil)
+					boshDeployment.VMInfosReturns([]director.VMInfo{{
+						JobName: "job1",
+					}}, nil)
+					optsGenerator.Returns(stubbedSshOpts, "private_key", nil)
+					boshDeployment.SetUpSSHReturns(director.SSHResult{Hosts: []director.Host{
+						{
+							Username:      "username",
+							Host:          "10.0.0.0",
+							IndexOrID:     "index",
+							HostPublicKey: "not a public key",
+						},
+					}}, nil)
+				})
+
+				It("does fail", func() {
+					Expect(actualError).To(MatchError(ContainSubstring("ParseAuthorizedKey failed")))
+				})
+
+				It("cleanup the ssh user from the instance", func() {
+					Expect(boshDeployment.CleanUpSSHCallCount()).To(Equal(1))
+				})
+			})
+
+			Context("sets up ssh for many groups, but finding jobs fails on one of them", func() {
+				BeforeEach(func() {
+					var vms []director.VMInfo
+					for index := 0; index < 15; index++ {
+						vms = append(vms, director.VMInfo{JobName: fmt.Sprintf("job%d", index), ID: "jobID", Index: newIndex(0)})
+					}
+
+					boshDirector.FindDeploymentReturns(boshDeployment, nil)
+					boshDeployment.VMInfosReturns(vms, nil)
+					optsGenerator.Returns(stubbedSshOpts, "private_key", nil)
+					boshDeployment.SetUpSSHStub = func(slug director.AllOrInstanceGroupOrInstanceSlug, opts director.SSHOpts) (director.SSHResult, error) {
+						return director.SSHResult{Hosts: []director.Host{
+							{
+								Username:      "username",
+								Host:          slug.Name(),
+								IndexOrID:     "jobID",
+								HostPublicKey: hostsPublicKeyRSA,
+							},
+						}}, nil
+					}
+					remoteRunnerFactory.Returns(remoteRunner, nil)
+					fakeJobFinder.FindJobsStub = func(_ context.Context, instanceIdentifier instance.InstanceIdentifier, remoteRunner ssh.RemoteRunner, manifestQuerier instance.ManifestQuerier) (orchestrator.Jobs, error) {
+						if instanceIdentifier.InstanceGroupName == "job7" {
+							return nil, errors.New(expectedError)
+						}
+						return orchestrator.Jobs{}, nil
+					}
+					manifestQuerierCreator.Returns(manifestQuerier, nil)
+				})
+
+				It("fails", func() {
+					Expect(actualError).To(MatchError(ContainSubstring(expectedError)))
+				})
+
+				It("cleans up the ssh user from every instance group", func() {
+					Expect(boshDeployment.CleanUpSSHCallCount()).To(Equal(15))
+
+					var slugs []string
+					for i := 0; i < boshDeployment.CleanUpSSHCallCount(); i++ {
+						slug, opts := boshDeployment.CleanUpSSHArgsForCall(i)
+						Expect(opts).To(Equal(director.SSHOpts{Username: stubbedSshOpts.Username}))
+						slugs = append(slugs, slug.Name())
+					}
+					Expect(slugs).To(ContainElements("job0", "job7", "job14"))
+				})
+			})
+
 			Context("succeeds creating remote runners for some vms, fails others", func() {
 				BeforeEach(func() {
 					boshDirector.FindDeploymentReturns(boshDeployment, nil)
@@ -995,7 +1142,7 @@ var _ = Describe("Director", func() {
 						}}, nil
 					}
 
-					remoteRunnerFactory.Stub = func(host, user, privateKey string, publicKeyCallback gossh.HostKeyCallback, publicKeyAlgorithm []string, logger ssh.Logger) (ssh.RemoteRunner, error) {
+					remoteRunnerFactory.Stub = func(_ context.Context, host, user, privateKey string, publicKeyCallback gossh.HostKeyCallback, publicKeyAlgorithm []string, logger ssh.Logger) (ssh.RemoteRunner, error) {
 						if host == "10.0.0.0_job1" {
 							return remoteRunner, nil
 						}
@@ -1011,43 +1158,6 @@ var _ = Describe("Director", func() {
 					Expect(boshDeployment.CleanUpSSHCallCount()).To(Equal(2))
 				})
 			})
-
-			Context("fails when os checker returns an error", func() {
-				var expectedErrorMessage = "failed to check os"
-
-				BeforeEach(func() {
-					boshDirector.FindDeploymentReturns(boshDeployment, nil)
-					boshDeployment.VMInfosReturns([]director.VMInfo{
-						{
-							JobName: "job1",
-						},
-					}, nil)
-					optsGenerator.Returns(stubbedSshOpts, "private_key", nil)
-
-					boshDeployment.SetUpSSHStub = func(slug director.AllOrInstanceGroupOrInstanceSlug, opts director.SSHOpts) (director.SSHResult, error) {
-						return director.SSHResult{Hosts: []director.Host{
-							{
-								Username:      "username",
-								Host:          "10.0.0.0_" + slug.Name(),
-								IndexOrID:     "index",
-								HostPublicKey: hostsPublicKeyRSA,
-							},
-						}}, nil
-					}
-
-					remoteRunner.IsWindowsReturns(false, errors.New(expectedErrorMessage))
-
-					remoteRunnerFactory.Returns(remoteRunner, nil)
-				})
-
-				It("fails", func() {
-					Expect(actualError).To(MatchError(ContainSubstring(expectedErrorMessage)))
-				})
-
-				It("cleans up the successful SSH connection", func() {
-					Expect(boshDeployment.CleanUpSSHCallCount()).To(Equal(1))
-				})
-			})
 		})
 	})
 
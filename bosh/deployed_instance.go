@@ -1,6 +1,8 @@
 package bosh
 
 import (
+	"context"
+
 	"github.com/cloudfoundry/bosh-backup-and-restore/instance"
 	"github.com/cloudfoundry/bosh-backup-and-restore/orchestrator"
 	"github.com/cloudfoundry/bosh-backup-and-restore/ssh"
@@ -28,11 +30,11 @@ func NewBoshDeployedInstance(instanceGroupName,
 	}
 }
 
-func (i *BoshDeployedInstance) Cleanup() error {
+func (i *BoshDeployedInstance) Cleanup(ctx context.Context) error {
 	var errs []error
 
 	if i.ArtifactDirCreated() {
-		removeArtifactError := i.RemoveArtifactDir()
+		removeArtifactError := i.RemoveArtifactDir(ctx)
 		if removeArtifactError != nil {
 			errs = append(errs, errors.Wrap(removeArtifactError, "failed to remove backup artifact"))
 		}
@@ -46,10 +48,10 @@ func (i *BoshDeployedInstance) Cleanup() error {
 	return orchestrator.ConvertErrors(errs)
 }
 
-func (i *BoshDeployedInstance) CleanupPrevious() error {
+func (i *BoshDeployedInstance) CleanupPrevious(ctx context.Context) error {
 	var errs []error
 
-	removeArtifactError := i.RemoveArtifactDir()
+	removeArtifactError := i.RemoveArtifactDir(ctx)
 	if removeArtifactError != nil {
 		errs = append(errs, errors.Wrap(removeArtifactError, "failed to remove backup artifact"))
 	}
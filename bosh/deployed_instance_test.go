@@ -1,6 +1,7 @@
 package bosh_test
 
 import (
+	"context"
 	"fmt"
 	"log"
 
@@ -56,13 +57,13 @@ var _ = Describe("BoshDeployedInstance", func() {
 		var expectedError error
 
 		JustBeforeEach(func() {
-			actualError = backuperInstance.Cleanup()
+			actualError = backuperInstance.Cleanup(context.Background())
 		})
 
 		Describe("cleans up successfully", func() {
 			It("deletes the backup folder", func() {
 				Expect(remoteRunner.RemoveDirectoryCallCount()).To(Equal(1))
-				dir := remoteRunner.RemoveDirectoryArgsForCall(0)
+				_, dir := remoteRunner.RemoveDirectoryArgsForCall(0)
 				Expect(dir).To(Equal("/var/vcap/store/bbr-backup"))
 			})
 
@@ -152,13 +153,13 @@ var _ = Describe("BoshDeployedInstance", func() {
 		var expectedError error
 
 		JustBeforeEach(func() {
-			actualError = backuperInstance.CleanupPrevious()
+			actualError = backuperInstance.CleanupPrevious(context.Background())
 		})
 
 		Describe("cleans up successfully", func() {
 			It("deletes the backup folder", func() {
 				Expect(remoteRunner.RemoveDirectoryCallCount()).To(Equal(1))
-				dir := remoteRunner.RemoveDirectoryArgsForCall(0)
+				_, dir := remoteRunner.RemoveDirectoryArgsForCall(0)
 				Expect(dir).To(Equal("/var/vcap/store/bbr-backup"))
 			})
 
@@ -179,7 +180,7 @@ var _ = Describe("BoshDeployedInstance", func() {
 
 			It("does attempt to delete the existing artifact", func() {
 				Expect(remoteRunner.RemoveDirectoryCallCount()).To(Equal(1))
-				dir := remoteRunner.RemoveDirectoryArgsForCall(0)
+				_, dir := remoteRunner.RemoveDirectoryArgsForCall(0)
 				Expect(dir).To(Equal("/var/vcap/store/bbr-backup"))
 			})
 
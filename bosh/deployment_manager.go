@@ -1,6 +1,8 @@
 package bosh
 
 import (
+	"context"
+
 	"github.com/cloudfoundry/bosh-backup-and-restore/orchestrator"
 	"github.com/pkg/errors"
 )
@@ -15,8 +17,8 @@ type DeploymentManager struct {
 	downloadManifest bool
 }
 
-func (b *DeploymentManager) Find(deploymentName string) (orchestrator.Deployment, error) {
-	instances, err := b.FindInstances(deploymentName)
+func (b *DeploymentManager) Find(ctx context.Context, deploymentName string) (orchestrator.Deployment, error) {
+	instances, err := b.FindInstances(ctx, deploymentName)
 	return orchestrator.NewDeployment(b.Logger, instances), errors.Wrap(err, "failed to find instances for deployment "+deploymentName)
 }
 
@@ -1,6 +1,7 @@
 package bosh_test
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/cloudfoundry/bosh-backup-and-restore/orchestrator"
@@ -37,11 +38,12 @@ var _ = Describe("DeploymentManager", func() {
 			boshClient.FindInstancesReturns(instances, nil)
 		})
 		JustBeforeEach(func() {
-			deployment, findError = deploymentManager.Find(deploymentName)
+			deployment, findError = deploymentManager.Find(context.Background(), deploymentName)
 		})
 		It("asks the bosh director for instances", func() {
 			Expect(boshClient.FindInstancesCallCount()).To(Equal(1))
-			Expect(boshClient.FindInstancesArgsForCall(0)).To(Equal(deploymentName))
+			_, name := boshClient.FindInstancesArgsForCall(0)
+			Expect(name).To(Equal(deploymentName))
 		})
 		It("returns the deployment manager with instances", func() {
 			Expect(deployment).To(Equal(orchestrator.NewDeployment(logger, instances)))
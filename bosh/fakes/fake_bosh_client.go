@@ -2,6 +2,7 @@
 package fakes
 
 import (
+	"context"
 	"sync"
 
 	"github.com/cloudfoundry/bosh-backup-and-restore/bosh"
@@ -9,10 +10,11 @@ import (
 )
 
 type FakeBoshClient struct {
-	FindInstancesStub        func(string) ([]orchestrator.Instance, error)
+	FindInstancesStub        func(context.Context, string) ([]orchestrator.Instance, error)
 	findInstancesMutex       sync.RWMutex
 	findInstancesArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
+		arg2 string
 	}
 	findInstancesReturns struct {
 		result1 []orchestrator.Instance
@@ -39,18 +41,19 @@ type FakeBoshClient struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeBoshClient) FindInstances(arg1 string) ([]orchestrator.Instance, error) {
+func (fake *FakeBoshClient) FindInstances(arg1 context.Context, arg2 string) ([]orchestrator.Instance, error) {
 	fake.findInstancesMutex.Lock()
 	ret, specificReturn := fake.findInstancesReturnsOnCall[len(fake.findInstancesArgsForCall)]
 	fake.findInstancesArgsForCall = append(fake.findInstancesArgsForCall, struct {
-		arg1 string
-	}{arg1})
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
 	stub := fake.FindInstancesStub
 	fakeReturns := fake.findInstancesReturns
-	fake.recordInvocation("FindInstances", []interface{}{arg1})
+	fake.recordInvocation("FindInstances", []interface{}{arg1, arg2})
 	fake.findInstancesMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -64,17 +67,17 @@ func (fake *FakeBoshClient) FindInstancesCallCount() int {
 	return len(fake.findInstancesArgsForCall)
 }
 
-func (fake *FakeBoshClient) FindInstancesCalls(stub func(string) ([]orchestrator.Instance, error)) {
+func (fake *FakeBoshClient) FindInstancesCalls(stub func(context.Context, string) ([]orchestrator.Instance, error)) {
 	fake.findInstancesMutex.Lock()
 	defer fake.findInstancesMutex.Unlock()
 	fake.FindInstancesStub = stub
 }
 
-func (fake *FakeBoshClient) FindInstancesArgsForCall(i int) string {
+func (fake *FakeBoshClient) FindInstancesArgsForCall(i int) (context.Context, string) {
 	fake.findInstancesMutex.RLock()
 	defer fake.findInstancesMutex.RUnlock()
 	argsForCall := fake.findInstancesArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeBoshClient) FindInstancesReturns(result1 []orchestrator.Instance, result2 error) {
@@ -18,10 +18,10 @@ import (
 	"github.com/urfave/cli"
 )
 
-func runForAllDeployments(action ActionFunc, boshClient bosh.Client, summaryErrorMsg, summarySuccessMsg string, errorHandler deployment.ErrorHandleFunc, executor deployment.DeploymentExecutor) error {
-	deployments, err := getAllDeployments(boshClient)
+func runForAllDeployments(reporter errorReporter, action ActionFunc, boshClient bosh.Client, excludedDeployments []string, summaryErrorMsg, summarySuccessMsg string, errorHandler deployment.ErrorHandleFunc, executor deployment.DeploymentExecutor) error {
+	deployments, err := getAllDeployments(reporter, boshClient, excludedDeployments)
 	if err != nil {
-		return processError(orchestrator.NewError(err))
+		return reporter.process(orchestrator.NewError(orchestrator.NewDiscoveryErrorFrom(err)))
 	}
 
 	printPending(deployments)
@@ -35,14 +35,20 @@ func runForAllDeployments(action ActionFunc, boshClient bosh.Client, summaryErro
 	if len(errs) != 0 {
 		printFailed(failedDeployments)
 		errMsg := summaryError(errs, deployments, summaryErrorMsg)
-		return errorHandler(deployment.AllDeploymentsError{Summary: errMsg, DeploymentErrs: errs})
+		return errorHandler(deployment.AllDeploymentsError{
+			Summary:        errMsg,
+			DeploymentErrs: errs,
+			Command:        reporter.command,
+			ReportDir:      reporter.dir,
+			CleanupCommand: reporter.cleanupCommand,
+		})
 	}
 
 	return cli.NewExitError("", 0)
 
 }
 
-func getAllDeployments(boshClient bosh.Client) ([]string, error) {
+func getAllDeployments(reporter errorReporter, boshClient bosh.Client, excludedDeployments []string) ([]string, error) {
 	allDeployments, err := boshClient.Director.Deployments() //nolint:staticcheck
 	if err != nil {
 		return nil, orchestrator.NewError(err)
@@ -50,11 +56,13 @@ func getAllDeployments(boshClient bosh.Client) ([]string, error) {
 
 	deploymentNames := []string{}
 	for _, dep := range allDeployments {
-		deploymentNames = append(deploymentNames, dep.Name())
+		if !contains(excludedDeployments, dep.Name()) {
+			deploymentNames = append(deploymentNames, dep.Name())
+		}
 	}
 
 	if len(deploymentNames) == 0 {
-		return nil, processError(orchestrator.NewError(errors.New("Failed to find any deployments"))) //nolint:staticcheck
+		return nil, reporter.process(orchestrator.NewError(errors.New("Failed to find any deployments"))) //nolint:staticcheck
 	}
 
 	return deploymentNames, nil
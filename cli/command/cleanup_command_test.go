@@ -0,0 +1,108 @@
+package command
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/urfave/cli"
+)
+
+var _ = Describe("Cleanup commands", func() {
+	run := func(subcommand string, flags []cli.Flag, cleanupCommand func(*cli.Context, string) string, args ...string) string {
+		var command string
+
+		app := cli.NewApp()
+		app.Writer = GinkgoWriter
+		app.ErrWriter = GinkgoWriter
+		app.Commands = []cli.Command{{
+			Name:  subcommand,
+			Flags: flags,
+			Subcommands: []cli.Command{{
+				Name: "restore",
+				Action: func(c *cli.Context) error {
+					command = cleanupCommand(c, "restore-cleanup")
+					return nil
+				},
+			}},
+		}}
+
+		Expect(app.Run(append(append([]string{"bbr", subcommand}, args...), "restore"))).To(Succeed())
+		return command
+	}
+
+	Describe("deploymentCleanupCommand", func() {
+		flags := []cli.Flag{
+			cli.StringFlag{Name: "target"},
+			cli.StringFlag{Name: "username"},
+			cli.StringFlag{Name: "password"},
+			cli.StringFlag{Name: "deployment"},
+			cli.StringFlag{Name: "ca-cert"},
+			cli.StringFlag{Name: "proxy-jump-config"},
+			cli.BoolFlag{Name: "all-deployments"},
+			cli.StringSliceFlag{Name: "exclude-deployment"},
+		}
+
+		It("repeats the flags it needs, without the password", func() {
+			Expect(run("deployment", flags, deploymentCleanupCommand,
+				"--target", "https://10.0.0.6:25555",
+				"--username", "admin",
+				"--password", "secret",
+				"--ca-cert", "/tmp/director ca.crt",
+				"--proxy-jump-config", "jump.yml",
+				"--deployment", "cf",
+			)).To(Equal("bbr deployment --target https://10.0.0.6:25555 --username admin --ca-cert '/tmp/director ca.crt' --proxy-jump-config jump.yml --deployment cf restore-cleanup"))
+		})
+
+		It("leaves out a CA certificate given as a PEM value", func() {
+			Expect(run("deployment", flags, deploymentCleanupCommand,
+				"--target", "director",
+				"--username", "admin",
+				"--ca-cert", "-----BEGIN CERTIFICATE-----\nabc\n-----END CERTIFICATE-----",
+				"--deployment", "cf",
+			)).To(Equal("bbr deployment --target director --username admin --deployment cf restore-cleanup"))
+		})
+
+		It("repeats the excluded deployments of --all-deployments", func() {
+			Expect(run("deployment", flags, deploymentCleanupCommand,
+				"--target", "director",
+				"--username", "admin",
+				"--all-deployments",
+				"--exclude-deployment", "cf",
+				"--exclude-deployment", "it's mine",
+			)).To(Equal(`bbr deployment --target director --username admin --all-deployments --exclude-deployment cf --exclude-deployment 'it'\''s mine' restore-cleanup`))
+		})
+	})
+
+	Describe("directorCleanupCommand", func() {
+		It("repeats the SSH flags", func() {
+			Expect(run("director", []cli.Flag{
+				cli.StringFlag{Name: "host"},
+				cli.StringFlag{Name: "username"},
+				cli.StringFlag{Name: "private-key-path"},
+				cli.StringFlag{Name: "certificate-path"},
+				cli.BoolFlag{Name: "ssh-agent"},
+				cli.StringFlag{Name: "known-hosts"},
+				cli.StringFlag{Name: "host-key-fingerprint"},
+				cli.StringFlag{Name: "proxy-jump-config"},
+			}, directorCleanupCommand,
+				"--host", "10.0.0.6",
+				"--username", "vcap",
+				"--certificate-path", "key-cert.pub",
+				"--ssh-agent",
+				"--known-hosts", "known_hosts",
+				"--host-key-fingerprint", "SHA256:abc+/=",
+				"--proxy-jump-config", "jump.yml",
+			)).To(Equal("bbr director --host 10.0.0.6 --username vcap --certificate-path key-cert.pub --ssh-agent --known-hosts known_hosts --host-key-fingerprint SHA256:abc+/= --proxy-jump-config jump.yml restore-cleanup"))
+		})
+	})
+
+	Describe("standaloneCleanupCommand", func() {
+		It("repeats the inventory", func() {
+			Expect(run("standalone", []cli.Flag{
+				cli.StringFlag{Name: "inventory"},
+				cli.StringFlag{Name: "proxy-jump-config"},
+			}, standaloneCleanupCommand,
+				"--inventory", "$HOME/inventory.yml",
+			)).To(Equal("bbr standalone --inventory '$HOME/inventory.yml' restore-cleanup"))
+		})
+	})
+})
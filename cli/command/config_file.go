@@ -0,0 +1,110 @@
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/cloudfoundry/bosh-backup-and-restore/cli/config"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+)
+
+const defaultConfigFile = ".bbr/bbr.yml"
+
+// ApplyDeploymentConfig, ApplyDirectorConfig and ApplyStandaloneConfig set
+// the flags of bbr deployment, director and standalone from the profile
+// chosen with --profile in the file given by --config.
+func ApplyDeploymentConfig(c *cli.Context) error {
+	return applyConfig(c, c, func(profile config.Profile) config.Flags {
+		flags := profile.DeploymentFlags()
+		if c.Bool("all-deployments") {
+			delete(flags, "deployment")
+		}
+		return flags
+	})
+}
+
+func ApplyDirectorConfig(c *cli.Context) error {
+	return applyConfig(c, c, config.Profile.DirectorFlags)
+}
+
+func ApplyStandaloneConfig(c *cli.Context) error {
+	return applyConfig(c, c, config.Profile.StandaloneFlags)
+}
+
+// applyBackupConfig sets the flags of a backup command from the backup
+// settings of the profile, and those of the deployment being backed up.
+func applyBackupConfig(c *cli.Context) error {
+	return applyConfig(c.Parent(), c, func(profile config.Profile) config.Flags {
+		return profile.BackupFlags(c.Parent().String("deployment"))
+	})
+}
+
+// applyConfig sets each flag of c that was given neither on the command line
+// nor by its environment variable. configContext has the --config and
+// --profile flags.
+func applyConfig(configContext, c *cli.Context, flagsOf func(config.Profile) config.Flags) error {
+	profile, err := loadProfile(configContext)
+	if err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+
+	flags := flagsOf(profile)
+	for _, flag := range definedFlags(c) {
+		name := strings.TrimSpace(strings.Split(flag.GetName(), ",")[0])
+		if c.IsSet(name) {
+			continue
+		}
+
+		for _, value := range flags[name] {
+			resolved, err := value.Resolve()
+			if err != nil {
+				return cli.NewExitError(errors.Wrapf(err, "failed resolving %s from the config file", name).Error(), 1)
+			}
+			if err := c.Set(name, resolved); err != nil {
+				return cli.NewExitError(errors.Wrapf(err, "invalid %s in the config file", name).Error(), 1)
+			}
+		}
+	}
+	return nil
+}
+
+// loadProfile returns the empty profile when there is no config file, unless
+// a profile was asked for.
+func loadProfile(c *cli.Context) (config.Profile, error) {
+	path := c.String("config")
+	if path == "" {
+		path = defaultConfigPath()
+	}
+
+	cfg, err := config.Load(path)
+	if errors.Is(err, os.ErrNotExist) && c.String("config") == "" {
+		if c.String("profile") != "" {
+			return config.Profile{}, errors.Errorf("--profile requires a config file, but %s does not exist", path)
+		}
+		return config.Profile{}, nil
+	}
+	if err != nil {
+		return config.Profile{}, err
+	}
+
+	return cfg.Profile(c.String("profile"))
+}
+
+func defaultConfigPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, defaultConfigFile)
+}
+
+// definedFlags returns the flags of the command c was parsed for, or of the
+// parent command when c belongs to one with subcommands.
+func definedFlags(c *cli.Context) []cli.Flag {
+	if c.Command.Name == "" {
+		return c.App.Flags
+	}
+	return c.Command.Flags
+}
@@ -0,0 +1,177 @@
+package command
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/urfave/cli"
+)
+
+var _ = Describe("applying the config file", func() {
+	var dir string
+	var configPath string
+	var flags map[string]interface{}
+	var osExiter func(int)
+	var errWriter io.Writer
+
+	run := func(args ...string) error {
+		app := cli.NewApp()
+		app.Writer = GinkgoWriter
+		app.ErrWriter = GinkgoWriter
+		app.Commands = []cli.Command{{
+			Name: "deployment",
+			Flags: []cli.Flag{
+				cli.StringFlag{Name: "target, t", EnvVar: "BBR_CONFIG_TEST_TARGET"},
+				cli.StringFlag{Name: "password, p"},
+				cli.StringFlag{Name: "deployment, d"},
+				cli.BoolFlag{Name: "all-deployments"},
+				cli.StringSliceFlag{Name: "exclude-deployment"},
+				cli.StringFlag{Name: "config"},
+				cli.StringFlag{Name: "profile"},
+			},
+			Before: ApplyDeploymentConfig,
+			Subcommands: []cli.Command{{
+				Name:   "backup",
+				Before: applyBackupConfig,
+				Flags: []cli.Flag{
+					cli.StringFlag{Name: "artifact-path, a"},
+					cli.BoolFlag{Name: "with-manifest"},
+					artifactStreamsFlag,
+					concurrencyFlag,
+				},
+				Action: func(c *cli.Context) error {
+					flags = map[string]interface{}{
+						"target":             c.Parent().String("target"),
+						"password":           c.Parent().String("password"),
+						"deployment":         c.Parent().String("deployment"),
+						"exclude-deployment": c.Parent().StringSlice("exclude-deployment"),
+						"artifact-path":      c.String("artifact-path"),
+						"with-manifest":      c.Bool("with-manifest"),
+						"artifact-streams":   c.Int("artifact-streams"),
+						"concurrency":        c.Int("concurrency"),
+					}
+					return nil
+				},
+			}},
+		}}
+		return app.Run(append([]string{"bbr", "deployment"}, args...))
+	}
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "bbr-config-")
+		Expect(err).NotTo(HaveOccurred())
+		GinkgoT().Setenv("HOME", dir)
+		flags = nil
+
+		osExiter, errWriter = cli.OsExiter, cli.ErrWriter
+		cli.OsExiter = func(int) {}
+		cli.ErrWriter = GinkgoWriter
+
+		configPath = filepath.Join(dir, "bbr.yml")
+		Expect(os.WriteFile(configPath, []byte(`---
+default_profile: prod
+profiles:
+  prod:
+    target: https://prod.example.com
+    password: {env: BBR_CONFIG_TEST_SECRET}
+    deployment: cf
+    exclude_deployments: [concourse]
+    backup:
+      artifact_path: /backups
+    deployments:
+      cf:
+        artifact_path: /backups/cf
+        with_manifest: true
+        artifact_streams: 4
+        concurrency: 5
+  staging:
+    target: https://staging.example.com
+`), 0600)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		cli.OsExiter, cli.ErrWriter = osExiter, errWriter
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	It("sets the flags from the profile and the settings of the deployment", func() {
+		GinkgoT().Setenv("BBR_CONFIG_TEST_SECRET", "prod-secret")
+
+		Expect(run("--config", configPath, "backup")).To(Succeed())
+
+		Expect(flags).To(Equal(map[string]interface{}{
+			"target":             "https://prod.example.com",
+			"password":           "prod-secret",
+			"deployment":         "cf",
+			"exclude-deployment": []string{"concourse"},
+			"artifact-path":      "/backups/cf",
+			"with-manifest":      true,
+			"artifact-streams":   4,
+			"concurrency":        5,
+		}))
+	})
+
+	It("prefers flags and environment variables over the config file", func() {
+		GinkgoT().Setenv("BBR_CONFIG_TEST_TARGET", "https://env.example.com")
+
+		Expect(run("--config", configPath, "-p", "flag-secret", "-d", "redis", "backup", "-a", "/tmp", "--artifact-streams", "2", "--concurrency", "3")).To(Succeed())
+
+		Expect(flags).To(Equal(map[string]interface{}{
+			"target":             "https://env.example.com",
+			"password":           "flag-secret",
+			"deployment":         "redis",
+			"exclude-deployment": []string{"concourse"},
+			"artifact-path":      "/tmp",
+			"with-manifest":      false,
+			"artifact-streams":   2,
+			"concurrency":        3,
+		}))
+	})
+
+	It("does not set the deployment of the profile when backing up all deployments", func() {
+		Expect(run("--config", configPath, "-p", "secret", "--all-deployments", "backup")).To(Succeed())
+
+		Expect(flags["deployment"]).To(BeEmpty())
+		Expect(flags["artifact-path"]).To(Equal("/backups"))
+	})
+
+	It("uses the profile given by --profile", func() {
+		Expect(run("--config", configPath, "--profile", "staging", "backup")).To(Succeed())
+
+		Expect(flags["target"]).To(Equal("https://staging.example.com"))
+		Expect(flags["artifact-streams"]).To(Equal(1))
+		Expect(flags["concurrency"]).To(Equal(10))
+	})
+
+	It("fails when a secret of the profile cannot be resolved", func() {
+		err := run("--config", configPath, "backup")
+
+		Expect(err).To(MatchError("failed resolving password from the config file: environment variable BBR_CONFIG_TEST_SECRET is not set"))
+	})
+
+	It("reads ~/.bbr/bbr.yml when --config is not given", func() {
+		Expect(os.Mkdir(filepath.Join(dir, ".bbr"), 0700)).To(Succeed())
+		Expect(os.Rename(configPath, filepath.Join(dir, ".bbr", "bbr.yml"))).To(Succeed())
+
+		Expect(run("--profile", "staging", "backup")).To(Succeed())
+
+		Expect(flags["target"]).To(Equal("https://staging.example.com"))
+	})
+
+	It("sets nothing when there is no config file", func() {
+		Expect(run("-t", "https://flag.example.com", "backup")).To(Succeed())
+
+		Expect(flags["target"]).To(Equal("https://flag.example.com"))
+		Expect(flags["artifact-path"]).To(BeEmpty())
+	})
+
+	It("fails when a profile is given without a config file", func() {
+		err := run("--profile", "staging", "backup")
+
+		Expect(err).To(MatchError(ContainSubstring("--profile requires a config file")))
+	})
+})
@@ -1,17 +1,37 @@
 package command
 
 import (
+	"context"
 	"fmt"
 	"time"
 
+	"github.com/cloudfoundry/bosh-backup-and-restore/executor"
 	"github.com/cloudfoundry/bosh-backup-and-restore/executor/deployment"
 	"github.com/cloudfoundry/bosh-backup-and-restore/factory"
 	"github.com/cloudfoundry/bosh-backup-and-restore/orchestrator"
+	"github.com/cloudfoundry/bosh-backup-and-restore/ssh"
 	"github.com/urfave/cli"
 )
 
 const artifactTimeStampFormat = "20060102T150405Z"
 
+var checkDiskSpaceFlag = cli.BoolFlag{
+	Name:  "check-disk-space",
+	Usage: "Fail before locking if the backup is not expected to fit on the instances or in the artifact path. Sizes are estimated with backup-size scripts or from the previous backup in the artifact path",
+}
+
+var artifactStreamsFlag = cli.IntFlag{
+	Name:  "artifact-streams",
+	Value: 1,
+	Usage: "Download each large artifact over up to this many parallel SSH streams, storing it as that many tar parts",
+}
+
+var concurrencyFlag = cli.IntFlag{
+	Name:  "concurrency",
+	Value: executor.DefaultMaxInFlight,
+	Usage: "Run backup scripts on, and download artifacts from, at most this many instances at once",
+}
+
 type DeploymentBackupCommand struct {
 }
 
@@ -24,8 +44,9 @@ func (d DeploymentBackupCommand) Cli() cli.Command {
 		Name:    "backup",
 		Aliases: []string{"b"},
 		Usage:   "Backup a deployment",
+		Before:  applyBackupConfig,
 		Action:  d.Action,
-		Flags: []cli.Flag{
+		Flags: combineFlags([]cli.Flag{
 			cli.BoolFlag{
 				Name:  "with-manifest",
 				Usage: "Download the deployment manifest",
@@ -38,30 +59,49 @@ func (d DeploymentBackupCommand) Cli() cli.Command {
 				Name:  "unsafe-lock-free",
 				Usage: "Experimental feature to skip locking steps when backing up the BOSH deployment. Cannot be used in combination with the all-deployments flag",
 			},
-		},
+			checkDiskSpaceFlag,
+			artifactStreamsFlag,
+			concurrencyFlag,
+		}, backupHookFlags, metricsFlags, notificationFlags, tracingFlags),
 	}
 }
 
 func (d DeploymentBackupCommand) Action(c *cli.Context) error {
-	trapSigint(true)
+	ctx := trapSignals(c, backupSignalMessages)
+	defer startTracing(c)()
 
 	username, password, target, caCert, bbrVersion, debug, deployment, allDeployments := getDeploymentParams(c)
 	withManifest := c.Bool("with-manifest")
 	unsafeLockFree := c.Bool("unsafe-lock-free")
 	artifactPath := c.String("artifact-path")
+	checkDiskSpace := c.Bool("check-disk-space")
+	artifactStreams := c.Int("artifact-streams")
+	concurrency := c.Int("concurrency")
+	recorder := newMetricsRecorder(c)
+	notifier := newOutcomeNotifier(c)
+	hooks := backupHooks(c)
+	reporter := errorReporter{command: "backup", deployment: deployment, dir: artifactPath, cleanupCommand: deploymentCleanupCommand(c, "backup-cleanup")}
+
+	if err := factory.ValidateArtifactStreams(artifactStreams); err != nil {
+		return reporter.process(orchestrator.NewError(err))
+	}
+	if err := factory.ValidateConcurrency(concurrency); err != nil {
+		return reporter.process(orchestrator.NewError(err))
+	}
 
 	if allDeployments {
 		if unsafeLockFree {
-			return processError(orchestrator.NewError(fmt.Errorf("Cannot use the --unsafe-lock-free flag in conjunction with the --all-deployments flag"))) //nolint:staticcheck
+			return reporter.process(orchestrator.NewError(fmt.Errorf("Cannot use the --unsafe-lock-free flag in conjunction with the --all-deployments flag"))) //nolint:staticcheck
 		}
-		return backupAll(target, username, password, caCert, artifactPath, withManifest, bbrVersion, debug)
+		return backupAll(ctx, reporter, target, username, password, caCert, proxyJump(c), artifactPath, withManifest, bbrVersion, debug, hooks, checkDiskSpace, artifactStreams, concurrency, recorder, notifier, c.Parent().StringSlice("exclude-deployment"))
 	}
 
-	return backupSingleDeployment(deployment, target, username, password, caCert, artifactPath, withManifest, bbrVersion, unsafeLockFree, debug)
+	return backupSingleDeployment(ctx, reporter, deployment, target, username, password, caCert, proxyJump(c), artifactPath, withManifest, bbrVersion, unsafeLockFree, debug, hooks, checkDiskSpace, artifactStreams, concurrency, recorder, notifier)
 }
 
-func backupAll(target, username, password, caCert, artifactPath string, withManifest bool, bbrVersion string, debug bool) error {
+func backupAll(ctx context.Context, reporter errorReporter, target, username, password, caCert string, proxyJump []ssh.JumpRoute, artifactPath string, withManifest bool, bbrVersion string, debug bool, hooks orchestrator.Hooks, checkDiskSpace bool, artifactStreams, concurrency int, recorder *metricsRecorder, notifier outcomeNotifier, excludedDeployments []string) error {
 	backupAction := func(deploymentName string) orchestrator.Error {
+		startTime := time.Now()
 		timestamp := time.Now().UTC().Format(artifactTimeStampFormat)
 		logFilePath, buffer, logger, logErr := createLogger(timestamp, artifactPath, deploymentName, debug)
 		if logErr != nil {
@@ -73,18 +113,25 @@ func backupAll(target, username, password, caCert, artifactPath string, withMani
 			username,
 			password,
 			caCert,
+			proxyJump,
 			withManifest,
 			false,
 			bbrVersion,
 			logger,
 			timestamp,
+			hooks,
+			checkDiskSpace,
+			artifactStreams,
+			concurrency,
 		)
 		if factoryErr != nil {
 			return orchestrator.NewError(factoryErr)
 		}
 
 		printlnWithTimestamp(fmt.Sprintf("Starting backup of %s, log file: %s", deploymentName, logFilePath))
-		err := backuper.Backup(deploymentName, artifactPath)
+		err := backuper.BackupWithContext(ctx, deploymentName, artifactPath)
+		recorder.record(deploymentName, artifactPath, timestamp, startTime, err)
+		notifier.notify("backup", deploymentName, factory.BackupArtifactDir(artifactPath, deploymentName, timestamp), startTime, err, err.ContainsUnlockOrCleanupOrArtifactDirExists())
 
 		if err != nil {
 			printlnWithTimestamp(fmt.Sprintf("ERROR: failed to backup %s", deploymentName))
@@ -106,34 +153,42 @@ func backupAll(target, username, password, caCert, artifactPath string, withMani
 	fmt.Println("Starting backup...")
 
 	logger, _ := factory.BuildBoshLoggerWithCustomBuffer(debug) //nolint:errcheck
-	boshClient, err := factory.BuildBoshClient(target, username, password, caCert, bbrVersion, logger)
+	boshClient, err := factory.BuildBoshClient(target, username, password, caCert, bbrVersion, proxyJump, logger)
 	if err != nil {
-		return processError(orchestrator.NewError(err))
+		return reporter.process(orchestrator.NewError(orchestrator.NewDiscoveryErrorFrom(err)))
 	}
 
-	return runForAllDeployments(backupAction,
+	defer recorder.export()
+
+	return runForAllDeployments(reporter, backupAction,
 		boshClient,
+		excludedDeployments,
 		"cannot be backed up",
 		"backed up",
 		errorHandler,
 		deployment.NewParallelExecutor())
 }
 
-func backupSingleDeployment(deployment, target, username, password, caCert, artifactPath string, withManifest bool, bbrVersion string, unsafeLockFree, debug bool) error {
+func backupSingleDeployment(ctx context.Context, reporter errorReporter, deployment, target, username, password, caCert string, proxyJump []ssh.JumpRoute, artifactPath string, withManifest bool, bbrVersion string, unsafeLockFree, debug bool, hooks orchestrator.Hooks, checkDiskSpace bool, artifactStreams, concurrency int, recorder *metricsRecorder, notifier outcomeNotifier) error {
 	logger := factory.BuildBoshLogger(debug)
+	startTime := time.Now()
 	timeStamp := time.Now().UTC().Format(artifactTimeStampFormat)
 
-	backuper, err := factory.BuildDeploymentBackuper(target, username, password, caCert, withManifest, unsafeLockFree, bbrVersion, logger, timeStamp)
+	backuper, err := factory.BuildDeploymentBackuper(target, username, password, caCert, proxyJump, withManifest, unsafeLockFree, bbrVersion, logger, timeStamp, hooks, checkDiskSpace, artifactStreams, concurrency)
 	if err != nil {
-		return processError(orchestrator.NewError(err))
+		return reporter.process(orchestrator.NewError(orchestrator.NewDiscoveryErrorFrom(err)))
 	}
 
-	backupErr := backuper.Backup(deployment, artifactPath)
+	backupErr := backuper.BackupWithContext(ctx, deployment, artifactPath)
+	recorder.record(deployment, artifactPath, timeStamp, startTime, backupErr)
+	recorder.export()
+	notifier.notify("backup", deployment, factory.BackupArtifactDir(artifactPath, deployment, timeStamp), startTime, backupErr, backupErr.ContainsUnlockOrCleanupOrArtifactDirExists())
+
 	if backupErr.ContainsUnlockOrCleanupOrArtifactDirExists() {
-		return processErrorWithFooter(backupErr, backupCleanupAdvisedNotice)
+		return reporter.processWithFooter(backupErr, backupCleanupAdvisedNotice)
 	}
 
-	return processError(backupErr)
+	return reporter.process(backupErr)
 }
 
 func printlnWithTimestamp(str string) {
@@ -1,6 +1,7 @@
 package command
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -8,6 +9,7 @@ import (
 
 	"github.com/cloudfoundry/bosh-backup-and-restore/factory"
 	"github.com/cloudfoundry/bosh-backup-and-restore/orchestrator"
+	"github.com/cloudfoundry/bosh-backup-and-restore/ssh"
 	"github.com/urfave/cli"
 )
 
@@ -27,9 +29,10 @@ func (d DeploymentBackupCleanupCommand) Cli() cli.Command {
 }
 
 func (d DeploymentBackupCleanupCommand) Action(c *cli.Context) error {
-	trapSigint(true)
+	ctx := trapSignals(c, backupCleanupSignalMessages)
 
 	username, password, target, caCert, bbrVersion, debug, deployment, allDeployments := getDeploymentParams(c)
+	reporter := errorReporter{command: "backup-cleanup", deployment: deployment}
 
 	if !allDeployments {
 		logger := factory.BuildBoshLogger(debug)
@@ -40,20 +43,21 @@ func (d DeploymentBackupCleanupCommand) Action(c *cli.Context) error {
 			password,
 			caCert,
 			c.App.Version,
+			proxyJump(c),
 			logger,
 		)
 		if err != nil {
-			return processError(orchestrator.NewError(err))
+			return reporter.process(orchestrator.NewError(orchestrator.NewDiscoveryErrorFrom(err)))
 		}
 
-		cleanupErr := cleaner.Cleanup(deployment)
-		return processError(cleanupErr)
+		cleanupErr := cleaner.CleanupWithContext(ctx, deployment)
+		return reporter.process(cleanupErr)
 	}
 
-	return cleanupAllDeployments(target, username, password, caCert, bbrVersion, debug)
+	return cleanupAllDeployments(ctx, reporter, target, username, password, caCert, proxyJump(c), bbrVersion, debug, c.Parent().StringSlice("exclude-deployment"))
 }
 
-func cleanupAllDeployments(target, username, password, caCert, bbrVersion string, debug bool) error {
+func cleanupAllDeployments(ctx context.Context, reporter errorReporter, target, username, password, caCert string, proxyJump []ssh.JumpRoute, bbrVersion string, debug bool, excludedDeployments []string) error {
 	cleanupAction := func(deploymentName string) orchestrator.Error {
 		timestamp := time.Now().UTC().Format(artifactTimeStampFormat)
 		logFilePath, buffer, logger, logErr := createLogger(timestamp, "", deploymentName, debug)
@@ -67,6 +71,7 @@ func cleanupAllDeployments(target, username, password, caCert, bbrVersion string
 			password,
 			caCert,
 			bbrVersion,
+			proxyJump,
 			logger,
 		)
 
@@ -75,7 +80,7 @@ func cleanupAllDeployments(target, username, password, caCert, bbrVersion string
 		}
 
 		printlnWithTimestamp(fmt.Sprintf("Starting cleanup of %s, log file: %s", deploymentName, logFilePath))
-		err := cleanup(cleaner, deploymentName)
+		err := cleanup(ctx, cleaner, deploymentName)
 
 		if err != nil {
 			printlnWithTimestamp(fmt.Sprintf("ERROR: failed to cleanup %s", deploymentName))
@@ -93,7 +98,7 @@ func cleanupAllDeployments(target, username, password, caCert, bbrVersion string
 
 	logger, _ := factory.BuildBoshLoggerWithCustomBuffer(debug)
 
-	boshClient, err := factory.BuildBoshClient(target, username, password, caCert, bbrVersion, logger)
+	boshClient, err := factory.BuildBoshClient(target, username, password, caCert, bbrVersion, proxyJump, logger)
 	if err != nil {
 		return err
 	}
@@ -101,16 +106,18 @@ func cleanupAllDeployments(target, username, password, caCert, bbrVersion string
 	fmt.Println("Starting cleanup...")
 
 	return runForAllDeployments(
+		reporter,
 		cleanupAction,
 		boshClient,
+		excludedDeployments,
 		"could not be cleaned up",
 		"cleaned up",
 		errorHandler,
 		deployment.NewParallelExecutor())
 }
 
-func cleanup(cleaner *orchestrator.BackupCleaner, deployment string) orchestrator.Error {
-	err := cleaner.Cleanup(deployment)
+func cleanup(ctx context.Context, cleaner *orchestrator.BackupCleaner, deployment string) orchestrator.Error {
+	err := cleaner.CleanupWithContext(ctx, deployment)
 	if err != nil {
 		fmt.Printf("Failed to cleanup deployment '%s'\n", deployment)
 		return err
@@ -30,21 +30,22 @@ func (d DeploymentPreBackupCheck) Cli() cli.Command {
 
 func (d DeploymentPreBackupCheck) Action(c *cli.Context) error {
 	username, password, target, caCert, bbrVersion, debug, deployment, allDeployments := getDeploymentParams(c)
+	reporter := errorReporter{command: "pre-backup-check", deployment: deployment, cleanupCommand: deploymentCleanupCommand(c, "backup-cleanup")}
 	var logger logger.Logger
 	if allDeployments {
 		logger, _ = factory.BuildBoshLoggerWithCustomBuffer(debug)
 	} else {
 		logger = factory.BuildBoshLogger(debug)
 	}
-	boshClient, err := factory.BuildBoshClient(target, username, password, caCert, bbrVersion, logger)
+	boshClient, err := factory.BuildBoshClient(target, username, password, caCert, bbrVersion, proxyJump(c), logger)
 	if err != nil {
-		return processError(orchestrator.NewError(err))
+		return reporter.process(orchestrator.NewError(orchestrator.NewDiscoveryErrorFrom(err)))
 	}
 
 	backupChecker := factory.BuildDeploymentBackupChecker(boshClient, logger, false)
 
 	if allDeployments {
-		errs := allDeploymentsBackupCheck(boshClient, backupChecker)
+		errs := allDeploymentsBackupCheck(reporter, boshClient, backupChecker, c.Parent().StringSlice("exclude-deployment"))
 		if errs != nil {
 			return errs
 		}
@@ -52,9 +53,9 @@ func (d DeploymentPreBackupCheck) Action(c *cli.Context) error {
 		errs := backupableCheck(backupChecker, deployment)
 		if errs != nil {
 			if errs.ContainsArtifactDirError() {
-				return processErrorWithFooter(errs, backupCleanupAdvisedNotice)
+				return reporter.processWithFooter(errs, backupCleanupAdvisedNotice)
 			}
-			return processError(errs)
+			return reporter.process(errs)
 		}
 	}
 
@@ -74,7 +75,7 @@ func backupableCheck(backupChecker *orchestrator.BackupChecker, deploymentName s
 	return nil
 }
 
-func allDeploymentsBackupCheck(boshClient bosh.Client, backupChecker *orchestrator.BackupChecker) error {
+func allDeploymentsBackupCheck(reporter errorReporter, boshClient bosh.Client, backupChecker *orchestrator.BackupChecker, excludedDeployments []string) error {
 	backupCheckerAction := func(deploymentName string) orchestrator.Error {
 		return backupableCheck(backupChecker, deploymentName)
 	}
@@ -86,8 +87,9 @@ func allDeploymentsBackupCheck(boshClient bosh.Client, backupChecker *orchestrat
 		return deploymentError.Process()
 	}
 
-	return runForAllDeployments(backupCheckerAction,
+	return runForAllDeployments(reporter, backupCheckerAction,
 		boshClient,
+		excludedDeployments,
 		"cannot be backed up",
 		"can be backed up",
 		errorHandler,
@@ -0,0 +1,62 @@
+package command
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/cloudfoundry/bosh-backup-and-restore/cli/flags"
+	"github.com/cloudfoundry/bosh-backup-and-restore/executor/deployment"
+	"github.com/cloudfoundry/bosh-backup-and-restore/factory"
+	"github.com/cloudfoundry/bosh-backup-and-restore/orchestrator"
+	"github.com/urfave/cli"
+)
+
+type DeploymentPreRestoreCheck struct{}
+
+func NewDeploymentPreRestoreCheckCommand() DeploymentPreRestoreCheck {
+	return DeploymentPreRestoreCheck{}
+}
+
+func (d DeploymentPreRestoreCheck) Cli() cli.Command {
+	return cli.Command{
+		Name:   "pre-restore-check",
+		Usage:  "Check a deployment can be restored from a backup, without locking it or copying the backup to it",
+		Action: d.Action,
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  "artifact-path, a",
+				Usage: "Path to the artifact to restore",
+			},
+		},
+	}
+}
+
+func (d DeploymentPreRestoreCheck) Action(c *cli.Context) error {
+	if err := flags.Validate([]string{"artifact-path"}, c); err != nil {
+		return err
+	}
+
+	username, password, target, caCert, bbrVersion, debug, deploymentName, allDeployments := getDeploymentParams(c)
+	reporter := errorReporter{command: "pre-restore-check", deployment: deploymentName, dir: filepath.Dir(c.String("artifact-path"))}
+	if allDeployments {
+		return reporter.process(orchestrator.NewError(fmt.Errorf("Cannot use the --all-deployments flag with pre-restore-check"))) //nolint:staticcheck
+	}
+
+	logger := factory.BuildBoshLogger(debug)
+	boshClient, err := factory.BuildBoshClient(target, username, password, caCert, bbrVersion, proxyJump(c), logger)
+	if err != nil {
+		return reporter.process(orchestrator.NewError(orchestrator.NewDiscoveryErrorFrom(err)))
+	}
+
+	restoreChecker := factory.BuildDeploymentRestoreChecker(boshClient, logger)
+
+	errs := restoreChecker.Check(deploymentName, c.String("artifact-path"))
+	if errs != nil {
+		printlnWithTimestamp(fmt.Sprintf("Deployment '%s' cannot be restored.", deploymentName))
+		fmt.Println(deployment.IndentBlock(errs.Error()))
+		return reporter.process(errs)
+	}
+
+	printlnWithTimestamp(fmt.Sprintf("Deployment '%s' can be restored.", deploymentName))
+	return cli.NewExitError("", 0)
+}
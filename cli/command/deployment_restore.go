@@ -1,6 +1,9 @@
 package command
 
 import (
+	"path/filepath"
+	"time"
+
 	"github.com/cloudfoundry/bosh-backup-and-restore/cli/flags"
 	"github.com/cloudfoundry/bosh-backup-and-restore/factory"
 	"github.com/cloudfoundry/bosh-backup-and-restore/orchestrator"
@@ -20,15 +23,16 @@ func (d DeploymentRestoreCommand) Cli() cli.Command {
 		Aliases: []string{"r"},
 		Usage:   "Restore a deployment from backup",
 		Action:  d.Action,
-		Flags: []cli.Flag{cli.StringFlag{
+		Flags: combineFlags([]cli.Flag{cli.StringFlag{
 			Name:  "artifact-path, a",
 			Usage: "Path to the artifact to restore",
-		}},
+		}}, restoreHookFlags, notificationFlags, tracingFlags),
 	}
 }
 
 func (d DeploymentRestoreCommand) Action(c *cli.Context) error {
-	trapSigint(false)
+	ctx := trapSignals(c, restoreSignalMessages)
+	defer startTracing(c)()
 
 	if err := flags.Validate([]string{"artifact-path"}, c); err != nil {
 		return err
@@ -36,18 +40,24 @@ func (d DeploymentRestoreCommand) Action(c *cli.Context) error {
 
 	deployment := c.Parent().String("deployment")
 	artifactPath := c.String("artifact-path")
+	notifier := newOutcomeNotifier(c)
+	startTime := time.Now()
+	reporter := errorReporter{command: "restore", deployment: deployment, dir: filepath.Dir(artifactPath), cleanupCommand: deploymentCleanupCommand(c, "restore-cleanup"), alwaysAdviseCleanup: true}
 
 	restorer, err := factory.BuildDeploymentRestorer(c.Parent().String("target"),
 		c.Parent().String("username"),
 		c.Parent().String("password"),
 		c.Parent().String("ca-cert"),
 		c.App.Version,
-		c.GlobalBool("debug"))
+		proxyJump(c),
+		c.GlobalBool("debug"),
+		restoreHooks(c))
 
 	if err != nil {
-		return processError(orchestrator.NewError(err))
+		return reporter.process(orchestrator.NewError(orchestrator.NewDiscoveryErrorFrom(err)))
 	}
 
-	restoreErr := restorer.Restore(deployment, artifactPath)
-	return processError(restoreErr)
+	restoreErr := restorer.RestoreWithContext(ctx, deployment, artifactPath)
+	notifier.notify("restore", deployment, artifactPath, startTime, restoreErr, !restoreErr.IsNil())
+	return reporter.process(restoreErr)
 }
@@ -22,22 +22,25 @@ func (d DeploymentRestoreCleanupCommand) Cli() cli.Command {
 }
 
 func (d DeploymentRestoreCleanupCommand) Action(c *cli.Context) error {
-	trapSigint(true)
+	ctx := trapSignals(c, restoreCleanupSignalMessages)
+
+	reporter := errorReporter{command: "restore-cleanup", deployment: c.Parent().String("deployment")}
 
 	cleaner, err := factory.BuildDeploymentRestoreCleanuper(c.Parent().String("target"),
 		c.Parent().String("username"),
 		c.Parent().String("password"),
 		c.Parent().String("ca-cert"),
 		c.App.Version,
+		proxyJump(c),
 		c.Bool("with-manifest"),
 		c.GlobalBool("debug"))
 
 	if err != nil {
-		return processError(orchestrator.NewError(err))
+		return reporter.process(orchestrator.NewError(orchestrator.NewDiscoveryErrorFrom(err)))
 	}
 
 	deployment := c.Parent().String("deployment")
-	cleanupErr := cleaner.Cleanup(deployment)
+	cleanupErr := cleaner.CleanupWithContext(ctx, deployment)
 
-	return processError(cleanupErr)
+	return reporter.process(cleanupErr)
 }
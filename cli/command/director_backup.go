@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/cloudfoundry/bosh-backup-and-restore/factory"
+	"github.com/cloudfoundry/bosh-backup-and-restore/orchestrator"
 	"github.com/urfave/cli"
 )
 
@@ -19,36 +20,56 @@ func (checkCommand DirectorBackupCommand) Cli() cli.Command {
 		Name:    "backup",
 		Aliases: []string{"b"},
 		Usage:   "Backup a BOSH Director",
+		Before:  applyBackupConfig,
 		Action:  checkCommand.Action,
-		Flags: []cli.Flag{
+		Flags: combineFlags([]cli.Flag{
 			cli.StringFlag{
 				Name:  "artifact-path, a",
 				Usage: "Specify an optional path to save the backup artifacts to",
 			},
-		},
+			checkDiskSpaceFlag,
+			artifactStreamsFlag,
+		}, backupHookFlags, metricsFlags, notificationFlags, tracingFlags),
 	}
 
 }
 
 func (checkCommand DirectorBackupCommand) Action(c *cli.Context) error {
-	trapSigint(true)
+	ctx := trapSignals(c, backupSignalMessages)
+	defer startTracing(c)()
 
 	directorName := extractNameFromAddress(c.Parent().String("host"))
+	reporter := errorReporter{command: "backup", deployment: directorName, dir: c.String("artifact-path"), cleanupCommand: directorCleanupCommand(c, "backup-cleanup")}
+
+	if err := factory.ValidateArtifactStreams(c.Int("artifact-streams")); err != nil {
+		return reporter.process(orchestrator.NewError(err))
+	}
+
+	recorder := newMetricsRecorder(c)
+	notifier := newOutcomeNotifier(c)
+	startTime := time.Now()
 	timeStamp := time.Now().UTC().Format(artifactTimeStampFormat)
 
 	backuper := factory.BuildDirectorBackuper(
 		c.Parent().String("host"),
 		c.Parent().String("username"),
-		c.Parent().String("private-key-path"),
+		directorSSHConfig(c),
+		proxyJump(c),
 		c.App.Version,
 		c.GlobalBool("debug"),
-		timeStamp)
+		timeStamp,
+		backupHooks(c),
+		c.Bool("check-disk-space"),
+		c.Int("artifact-streams"))
 
-	backupErr := backuper.Backup(directorName, c.String("artifact-path"))
+	backupErr := backuper.BackupWithContext(ctx, directorName, c.String("artifact-path"))
+	recorder.record(directorName, c.String("artifact-path"), timeStamp, startTime, backupErr)
+	recorder.export()
+	notifier.notify("backup", directorName, factory.BackupArtifactDir(c.String("artifact-path"), directorName, timeStamp), startTime, backupErr, backupErr.ContainsUnlockOrCleanupOrArtifactDirExists())
 
 	if backupErr.ContainsUnlockOrCleanupOrArtifactDirExists() {
-		return processErrorWithFooter(backupErr, backupCleanupAdvisedNotice)
+		return reporter.processWithFooter(backupErr, backupCleanupAdvisedNotice)
 	}
 
-	return processError(backupErr)
+	return reporter.process(backupErr)
 }
@@ -20,18 +20,20 @@ func (d DirectorBackupCleanupCommand) Cli() cli.Command {
 }
 
 func (d DirectorBackupCleanupCommand) Action(c *cli.Context) error {
-	trapSigint(true)
+	ctx := trapSignals(c, backupCleanupSignalMessages)
 
 	directorName := extractNameFromAddress(c.Parent().String("host"))
+	reporter := errorReporter{command: "backup-cleanup", deployment: directorName}
 
 	cleaner := factory.BuildDirectorBackupCleaner(c.Parent().String("host"),
 		c.Parent().String("username"),
-		c.Parent().String("private-key-path"),
+		directorSSHConfig(c),
+		proxyJump(c),
 		c.App.Version,
 		c.GlobalBool("debug"),
 	)
 
-	cleanupErr := cleaner.Cleanup(directorName)
+	cleanupErr := cleaner.CleanupWithContext(ctx, directorName)
 
-	return processError(cleanupErr)
+	return reporter.process(cleanupErr)
 }
@@ -25,11 +25,13 @@ func NewDirectorPreBackupCheckCommand() DirectorPreBackupCheckCommand {
 
 func (checkCommand DirectorPreBackupCheckCommand) Action(c *cli.Context) error {
 	directorName := extractNameFromAddress(c.Parent().String("host"))
+	reporter := errorReporter{command: "pre-backup-check", deployment: directorName, cleanupCommand: directorCleanupCommand(c, "backup-cleanup")}
 
 	backupChecker := factory.BuildDirectorBackupChecker(
 		c.Parent().String("host"),
 		c.Parent().String("username"),
-		c.Parent().String("private-key-path"),
+		directorSSHConfig(c),
+		proxyJump(c),
 		c.App.Version,
 		c.GlobalBool("debug"),
 	)
@@ -40,10 +42,10 @@ func (checkCommand DirectorPreBackupCheckCommand) Action(c *cli.Context) error {
 		fmt.Printf("Director cannot be backed up.\n")
 
 		if err.ContainsArtifactDirError() {
-			return processErrorWithFooter(err, backupCleanupAdvisedNotice)
+			return reporter.processWithFooter(err, backupCleanupAdvisedNotice)
 		}
 
-		return processError(err)
+		return reporter.process(err)
 	}
 
 	fmt.Printf("Director can be backed up.\n")
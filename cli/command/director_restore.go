@@ -1,6 +1,9 @@
 package command
 
 import (
+	"path/filepath"
+	"time"
+
 	"github.com/cloudfoundry/bosh-backup-and-restore/cli/flags"
 	"github.com/cloudfoundry/bosh-backup-and-restore/factory"
 	"github.com/urfave/cli"
@@ -19,33 +22,46 @@ func (cmd DirectorRestoreCommand) Cli() cli.Command {
 		Aliases: []string{"r"},
 		Usage:   "Restore a deployment from backup",
 		Action:  cmd.Action,
-		Flags: []cli.Flag{
+		Flags: combineFlags([]cli.Flag{
 			cli.StringFlag{
 				Name:  "artifact-path, a",
 				Usage: "Path to the artifact to restore",
 			},
-		},
+			cli.BoolFlag{
+				Name:  "allow-different-director",
+				Usage: "Restore a backup that was taken from a different BOSH Director",
+			},
+		}, restoreHookFlags, notificationFlags, tracingFlags),
 	}
 }
 
 func (cmd DirectorRestoreCommand) Action(c *cli.Context) error {
-	trapSigint(false)
+	ctx := trapSignals(c, restoreSignalMessages)
+	defer startTracing(c)()
+
+	directorName := extractNameFromAddress(c.Parent().String("host"))
+	reporter := errorReporter{command: "restore", deployment: directorName, dir: filepath.Dir(c.String("artifact-path")), cleanupCommand: directorCleanupCommand(c, "restore-cleanup"), alwaysAdviseCleanup: true}
 
 	if err := flags.Validate([]string{"artifact-path"}, c); err != nil {
 		return err
 	}
 
-	directorName := extractNameFromAddress(c.Parent().String("host"))
 	artifactPath := c.String("artifact-path")
+	notifier := newOutcomeNotifier(c)
+	startTime := time.Now()
 
 	restorer := factory.BuildDirectorRestorer(
 		c.Parent().String("host"),
 		c.Parent().String("username"),
-		c.Parent().String("private-key-path"),
+		directorSSHConfig(c),
+		proxyJump(c),
+		c.Bool("allow-different-director"),
 		c.App.Version,
 		c.GlobalBool("debug"),
+		restoreHooks(c),
 	)
 
-	restoreErr := restorer.Restore(directorName, artifactPath)
-	return processError(restoreErr)
+	restoreErr := restorer.RestoreWithContext(ctx, directorName, artifactPath)
+	notifier.notify("restore", directorName, artifactPath, startTime, restoreErr, !restoreErr.IsNil())
+	return reporter.process(restoreErr)
 }
@@ -20,19 +20,21 @@ func (d DirectorRestoreCleanupCommand) Cli() cli.Command {
 }
 
 func (d DirectorRestoreCleanupCommand) Action(c *cli.Context) error {
-	trapSigint(true)
+	ctx := trapSignals(c, restoreCleanupSignalMessages)
 
 	directorName := extractNameFromAddress(c.Parent().String("host"))
+	reporter := errorReporter{command: "restore-cleanup", deployment: directorName}
 
 	cleaner := factory.BuildDirectorRestoreCleaner(
 		c.Parent().String("host"),
 		c.Parent().String("username"),
-		c.Parent().String("private-key-path"),
+		directorSSHConfig(c),
+		proxyJump(c),
 		c.App.Version,
 		c.GlobalBool("debug"),
 	)
 
-	cleanupErr := cleaner.Cleanup(directorName)
+	cleanupErr := cleaner.CleanupWithContext(ctx, directorName)
 
-	return processError(cleanupErr)
+	return reporter.process(cleanupErr)
 }
@@ -0,0 +1,17 @@
+package command
+
+import (
+	"github.com/cloudfoundry/bosh-backup-and-restore/standalone"
+	"github.com/urfave/cli"
+)
+
+// directorSSHConfig reads the SSH flags of the parent `bbr director` command.
+func directorSSHConfig(c *cli.Context) standalone.SSHConfig {
+	return standalone.SSHConfig{
+		PrivateKeyPath:     c.Parent().String("private-key-path"),
+		CertificatePath:    c.Parent().String("certificate-path"),
+		UseAgent:           c.Parent().Bool("ssh-agent"),
+		KnownHostsPath:     c.Parent().String("known-hosts"),
+		HostKeyFingerprint: c.Parent().String("host-key-fingerprint"),
+	}
+}
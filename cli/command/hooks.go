@@ -0,0 +1,47 @@
+package command
+
+import (
+	"github.com/cloudfoundry/bosh-backup-and-restore/orchestrator"
+	"github.com/urfave/cli"
+)
+
+var backupHookFlags = []cli.Flag{
+	cli.StringSliceFlag{
+		Name:  "pre-backup-hook",
+		Usage: "Command to run locally before the deployment is locked. Can be specified multiple times",
+	},
+	cli.StringSliceFlag{
+		Name:  "post-lock-hook",
+		Usage: "Command to run locally after the deployment is locked and before it is backed up. Can be specified multiple times",
+	},
+	cli.StringSliceFlag{
+		Name:  "post-backup-hook",
+		Usage: "Command to run locally after the backup artifact has been downloaded. Can be specified multiple times",
+	},
+}
+
+var restoreHookFlags = []cli.Flag{
+	cli.StringSliceFlag{
+		Name:  "pre-restore-hook",
+		Usage: "Command to run locally before the backup artifact is copied to the deployment. Can be specified multiple times",
+	},
+	cli.StringSliceFlag{
+		Name:  "post-restore-hook",
+		Usage: "Command to run locally after a successful restore has unlocked the deployment. Can be specified multiple times",
+	},
+}
+
+func backupHooks(c *cli.Context) orchestrator.Hooks {
+	return orchestrator.Hooks{
+		PreBackup:  c.StringSlice("pre-backup-hook"),
+		PostLock:   c.StringSlice("post-lock-hook"),
+		PostBackup: c.StringSlice("post-backup-hook"),
+	}
+}
+
+func restoreHooks(c *cli.Context) orchestrator.Hooks {
+	return orchestrator.Hooks{
+		PreRestore:  c.StringSlice("pre-restore-hook"),
+		PostRestore: c.StringSlice("post-restore-hook"),
+	}
+}
@@ -3,8 +3,31 @@ package command
 const backupSigintQuestion = "Stopping a backup can leave the system in bad state. Are you sure you want to cancel? [yes/no]"
 const backupStdinErrorMessage = "Couldn't read from Stdin, if you still want to stop the backup send SIGTERM."
 const backupCleanupAdvisedNotice = "It is recommended that you run `bbr backup-cleanup` to ensure that any temp files are cleaned up and all jobs are unlocked."
+const backupAbortNotice = "Stopping the backup. bbr will unlock and clean up before exiting; send the signal again to exit immediately."
 const backupCleanupAllDeploymentsAdvisedNotice = "It is recommended that you run `bbr deployment --all-deployments backup-cleanup` to ensure that any temp files are cleaned up and all jobs are unlocked."
 
 const restoreSigintQuestion = "Stopping a restore can leave the system in bad state. Are you sure you want to cancel? [yes/no]"
 const restoreStdinErrorMessage = "Couldn't read from Stdin, if you still want to stop the restore send SIGTERM."
+const restoreAbortNotice = "Stopping the restore. bbr will unlock and clean up before exiting; send the signal again to exit immediately."
 const restoreCleanupAdvisedNotice = "It is recommended that you run `bbr restore-cleanup` to ensure that any temp files are cleaned up and all jobs are unlocked."
+
+const backupCleanupSigintQuestion = "Stopping a backup cleanup can leave jobs locked. Are you sure you want to cancel? [yes/no]"
+const backupCleanupStdinErrorMessage = "Couldn't read from Stdin, if you still want to stop the backup cleanup send SIGTERM."
+const backupCleanupAbortNotice = "Stopping the backup cleanup; send the signal again to exit immediately."
+
+const restoreCleanupSigintQuestion = "Stopping a restore cleanup can leave jobs locked. Are you sure you want to cancel? [yes/no]"
+const restoreCleanupStdinErrorMessage = "Couldn't read from Stdin, if you still want to stop the restore cleanup send SIGTERM."
+const restoreCleanupAbortNotice = "Stopping the restore cleanup; send the signal again to exit immediately."
+
+// signalMessages are what bbr prints when it is signalled during an operation.
+type signalMessages struct {
+	sigintQuestion       string
+	stdinErrorMessage    string
+	abortNotice          string
+	cleanupAdvisedNotice string
+}
+
+var backupSignalMessages = signalMessages{backupSigintQuestion, backupStdinErrorMessage, backupAbortNotice, backupCleanupAdvisedNotice}
+var restoreSignalMessages = signalMessages{restoreSigintQuestion, restoreStdinErrorMessage, restoreAbortNotice, restoreCleanupAdvisedNotice}
+var backupCleanupSignalMessages = signalMessages{backupCleanupSigintQuestion, backupCleanupStdinErrorMessage, backupCleanupAbortNotice, backupCleanupAdvisedNotice}
+var restoreCleanupSignalMessages = signalMessages{restoreCleanupSigintQuestion, restoreCleanupStdinErrorMessage, restoreCleanupAbortNotice, restoreCleanupAdvisedNotice}
@@ -0,0 +1,79 @@
+package command
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/cloudfoundry/bosh-backup-and-restore/backup"
+	"github.com/cloudfoundry/bosh-backup-and-restore/factory"
+	"github.com/cloudfoundry/bosh-backup-and-restore/metrics"
+	"github.com/cloudfoundry/bosh-backup-and-restore/orchestrator"
+	"github.com/urfave/cli"
+)
+
+const metricsExportTimeout = 30 * time.Second
+
+var metricsFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "metrics-textfile",
+		Usage: "Write Prometheus metrics about the backup to this file, for the node exporter textfile collector",
+	},
+	cli.StringFlag{
+		Name:  "metrics-pushgateway-url",
+		Usage: "Push Prometheus metrics about the backup to this Pushgateway URL",
+	},
+}
+
+type metricsRecorder struct {
+	exporters []metrics.Exporter
+
+	sync.Mutex
+	runs []metrics.Run
+}
+
+func newMetricsRecorder(c *cli.Context) *metricsRecorder {
+	recorder := &metricsRecorder{}
+
+	if textfilePath := c.String("metrics-textfile"); textfilePath != "" {
+		recorder.exporters = append(recorder.exporters, metrics.NewTextfileExporter(textfilePath))
+	}
+	if pushgatewayURL := c.String("metrics-pushgateway-url"); pushgatewayURL != "" {
+		recorder.exporters = append(recorder.exporters, metrics.NewPushgatewayExporter(pushgatewayURL, &http.Client{Timeout: metricsExportTimeout}))
+	}
+
+	return recorder
+}
+
+func (r *metricsRecorder) record(deploymentName, artifactPath, timestamp string, startTime time.Time, backupErr orchestrator.Error) {
+	if len(r.exporters) == 0 {
+		return
+	}
+
+	run := metrics.Run{
+		Deployment: deploymentName,
+		StartTime:  startTime,
+		FinishTime: time.Now(),
+		Errors:     backupErr,
+	}
+
+	if summary, err := backup.ReadSummary(factory.BackupArtifactDir(artifactPath, deploymentName, timestamp)); err == nil {
+		run.BytesTransferred = summary.BytesTransferred
+		run.LockDuration = summary.LockDuration
+	}
+
+	r.Lock()
+	defer r.Unlock()
+	r.runs = append(r.runs, run)
+}
+
+// export does not fail the command, as the backup itself has already
+// finished by the time metrics are exported.
+func (r *metricsRecorder) export() {
+	for _, exporter := range r.exporters {
+		if err := exporter.Export(r.runs); err != nil {
+			printlnWithTimestamp(fmt.Sprintf("WARNING: failed to export metrics: %s", err))
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package command
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/cloudfoundry/bosh-backup-and-restore/notification"
+	"github.com/cloudfoundry/bosh-backup-and-restore/orchestrator"
+	"github.com/urfave/cli"
+)
+
+const (
+	notificationTimeout       = 30 * time.Second
+	notificationRetryInterval = 5 * time.Second
+)
+
+var notificationFlags = []cli.Flag{
+	cli.StringSliceFlag{
+		Name:  "notify-url",
+		Usage: "POST a JSON description of the outcome to this webhook URL. Can be specified multiple times",
+	},
+	cli.IntFlag{
+		Name:  "notify-retries",
+		Value: 3,
+		Usage: "Number of times to retry a failed webhook notification",
+	},
+	cli.BoolFlag{
+		Name:  "notify-on-failure-only",
+		Usage: "Only send webhook notifications when the operation fails",
+	},
+}
+
+type outcomeNotifier struct {
+	notifier      notification.WebhookNotifier
+	enabled       bool
+	onFailureOnly bool
+}
+
+func newOutcomeNotifier(c *cli.Context) outcomeNotifier {
+	urls := c.StringSlice("notify-url")
+
+	return outcomeNotifier{
+		notifier:      notification.NewWebhookNotifier(urls, &http.Client{Timeout: notificationTimeout}, c.Int("notify-retries"), notificationRetryInterval),
+		enabled:       len(urls) > 0,
+		onFailureOnly: c.Bool("notify-on-failure-only"),
+	}
+}
+
+// notify does not fail the command: a notification that cannot be delivered
+// should not hide the outcome of the backup or restore itself.
+func (n outcomeNotifier) notify(operation, deploymentName, artifactPath string, startTime time.Time, err orchestrator.Error, cleanupAdvised bool) {
+	if !n.enabled || (n.onFailureOnly && err.IsNil()) {
+		return
+	}
+
+	notifyErr := n.notifier.Notify(notification.Outcome{
+		Operation:      operation,
+		Deployment:     deploymentName,
+		ArtifactPath:   artifactPath,
+		StartTime:      startTime,
+		FinishTime:     time.Now(),
+		Errors:         err,
+		CleanupAdvised: cleanupAdvised,
+	})
+	if notifyErr != nil {
+		printlnWithTimestamp(fmt.Sprintf("WARNING: failed to send notification: %s", notifyErr))
+	}
+}
@@ -0,0 +1,41 @@
+package command
+
+import (
+	"github.com/cloudfoundry/bosh-backup-and-restore/factory"
+	"github.com/cloudfoundry/bosh-backup-and-restore/ssh"
+	"github.com/cloudfoundry/bosh-backup-and-restore/standalone"
+	"github.com/urfave/cli"
+)
+
+const proxyJumpMetadataKey = "proxy-jump"
+
+// ConfigureProxyJump reads the jump hosts in the file given by
+// --proxy-jump-config, if any, for the SSH connections of the command.
+func ConfigureProxyJump(c *cli.Context) error {
+	path := c.String("proxy-jump-config")
+	if path == "" {
+		return nil
+	}
+
+	config, err := standalone.LoadProxyJumpConfig(path)
+	if err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+
+	routes, err := config.JumpRoutes(factory.BuildLogger(c.Bool("debug")))
+	if err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+
+	if c.App.Metadata == nil {
+		c.App.Metadata = map[string]interface{}{}
+	}
+	c.App.Metadata[proxyJumpMetadataKey] = routes
+	return nil
+}
+
+// proxyJump returns the jump routes read by ConfigureProxyJump.
+func proxyJump(c *cli.Context) []ssh.JumpRoute {
+	routes, _ := c.App.Metadata[proxyJumpMetadataKey].([]ssh.JumpRoute)
+	return routes
+}
@@ -0,0 +1,81 @@
+package command
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/cloudfoundry/bosh-backup-and-restore/ssh"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/urfave/cli"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+var _ = Describe("ConfigureProxyJump", func() {
+	var dir string
+	var configPath string
+
+	run := func(args ...string) []ssh.JumpRoute {
+		var routes []ssh.JumpRoute
+
+		app := cli.NewApp()
+		app.Writer = GinkgoWriter
+		app.ErrWriter = GinkgoWriter
+		app.Commands = []cli.Command{{
+			Name: "director",
+			Flags: []cli.Flag{
+				cli.StringFlag{Name: "proxy-jump-config"},
+			},
+			Before: ConfigureProxyJump,
+			Subcommands: []cli.Command{{
+				Name: "backup",
+				Action: func(c *cli.Context) error {
+					routes = proxyJump(c)
+					return nil
+				},
+			}},
+		}}
+
+		Expect(app.Run(append([]string{"bbr", "director"}, args...))).To(Succeed())
+		return routes
+	}
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "bbr-proxy-jump-")
+		Expect(err).NotTo(HaveOccurred())
+
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		Expect(err).NotTo(HaveOccurred())
+		block, err := gossh.MarshalPrivateKey(key, "")
+		Expect(err).NotTo(HaveOccurred())
+		keyPath := filepath.Join(dir, "jumpbox.key")
+		Expect(os.WriteFile(keyPath, pem.EncodeToMemory(block), 0600)).To(Succeed())
+
+		configPath = filepath.Join(dir, "proxy-jump.yml")
+		Expect(os.WriteFile(configPath, []byte(fmt.Sprintf(`---
+routes:
+- hops:
+  - address: jumpbox.example.com
+    username: jumpbox
+    private_key_path: %s
+    host_key_fingerprint: SHA256:abc
+`, keyPath)), 0600)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir) //nolint:errcheck
+	})
+
+	It("gives the routes to the command it configures, and not to others", func() {
+		routes := run("--proxy-jump-config", configPath, "backup")
+		Expect(routes).To(HaveLen(1))
+		Expect(routes[0].Hops[0].Address).To(Equal("jumpbox.example.com"))
+
+		Expect(run("backup")).To(BeEmpty())
+	})
+})
@@ -0,0 +1,48 @@
+package command
+
+import (
+	"errors"
+	"os"
+	"syscall"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("confirmAbort", func() {
+	var answers chan stdinAnswer
+	var signals chan os.Signal
+
+	BeforeEach(func() {
+		answers = make(chan stdinAnswer, 1)
+		signals = make(chan os.Signal, 1)
+	})
+
+	It("stops when the user answers yes", func() {
+		answers <- stdinAnswer{line: "Yes\n"}
+		confirmed, sig := confirmAbort(backupSignalMessages, answers, signals)
+		Expect(confirmed).To(BeTrue())
+		Expect(sig).To(BeNil())
+	})
+
+	It("carries on when the user answers anything else", func() {
+		answers <- stdinAnswer{line: "no\n"}
+		confirmed, sig := confirmAbort(backupSignalMessages, answers, signals)
+		Expect(confirmed).To(BeFalse())
+		Expect(sig).To(BeNil())
+	})
+
+	It("carries on when stdin can't be read", func() {
+		answers <- stdinAnswer{err: errors.New("EOF")}
+		confirmed, sig := confirmAbort(backupSignalMessages, answers, signals)
+		Expect(confirmed).To(BeFalse())
+		Expect(sig).To(BeNil())
+	})
+
+	It("returns a signal that arrives before an answer", func() {
+		signals <- syscall.SIGTERM
+		confirmed, sig := confirmAbort(backupSignalMessages, answers, signals)
+		Expect(confirmed).To(BeFalse())
+		Expect(sig).To(Equal(syscall.SIGTERM))
+	})
+})
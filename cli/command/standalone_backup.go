@@ -0,0 +1,77 @@
+package command
+
+import (
+	"time"
+
+	"github.com/cloudfoundry/bosh-backup-and-restore/factory"
+	"github.com/cloudfoundry/bosh-backup-and-restore/orchestrator"
+	"github.com/urfave/cli"
+)
+
+type StandaloneBackupCommand struct {
+}
+
+func NewStandaloneBackupCommand() StandaloneBackupCommand {
+	return StandaloneBackupCommand{}
+}
+
+func (cmd StandaloneBackupCommand) Cli() cli.Command {
+	return cli.Command{
+		Name:    "backup",
+		Aliases: []string{"b"},
+		Usage:   "Backup the VMs listed in an inventory",
+		Before:  applyBackupConfig,
+		Action:  cmd.Action,
+		Flags: combineFlags([]cli.Flag{
+			cli.StringFlag{
+				Name:  "artifact-path, a",
+				Usage: "Specify an optional path to save the backup artifacts to",
+			},
+			checkDiskSpaceFlag,
+			artifactStreamsFlag,
+		}, backupHookFlags, metricsFlags, notificationFlags, tracingFlags),
+	}
+}
+
+func (cmd StandaloneBackupCommand) Action(c *cli.Context) error {
+	ctx := trapSignals(c, backupSignalMessages)
+	defer startTracing(c)()
+
+	reporter := errorReporter{command: "backup", dir: c.String("artifact-path"), cleanupCommand: standaloneCleanupCommand(c, "backup-cleanup")}
+
+	inventory, err := standaloneInventory(c)
+	if err != nil {
+		return reporter.process(orchestrator.NewError(err))
+	}
+	reporter.deployment = inventory.Name
+
+	if err := factory.ValidateArtifactStreams(c.Int("artifact-streams")); err != nil {
+		return reporter.process(orchestrator.NewError(err))
+	}
+
+	recorder := newMetricsRecorder(c)
+	notifier := newOutcomeNotifier(c)
+	startTime := time.Now()
+	timeStamp := time.Now().UTC().Format(artifactTimeStampFormat)
+
+	backuper := factory.BuildStandaloneBackuper(
+		inventory,
+		proxyJump(c),
+		c.App.Version,
+		c.GlobalBool("debug"),
+		timeStamp,
+		backupHooks(c),
+		c.Bool("check-disk-space"),
+		c.Int("artifact-streams"))
+
+	backupErr := backuper.BackupWithContext(ctx, inventory.Name, c.String("artifact-path"))
+	recorder.record(inventory.Name, c.String("artifact-path"), timeStamp, startTime, backupErr)
+	recorder.export()
+	notifier.notify("backup", inventory.Name, factory.BackupArtifactDir(c.String("artifact-path"), inventory.Name, timeStamp), startTime, backupErr, backupErr.ContainsUnlockOrCleanupOrArtifactDirExists())
+
+	if backupErr.ContainsUnlockOrCleanupOrArtifactDirExists() {
+		return reporter.processWithFooter(backupErr, backupCleanupAdvisedNotice)
+	}
+
+	return reporter.process(backupErr)
+}
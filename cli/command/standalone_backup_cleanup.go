@@ -0,0 +1,44 @@
+package command
+
+import (
+	"github.com/cloudfoundry/bosh-backup-and-restore/factory"
+	"github.com/cloudfoundry/bosh-backup-and-restore/orchestrator"
+	"github.com/urfave/cli"
+)
+
+type StandaloneBackupCleanupCommand struct {
+}
+
+func NewStandaloneBackupCleanupCommand() StandaloneBackupCleanupCommand {
+	return StandaloneBackupCleanupCommand{}
+}
+func (d StandaloneBackupCleanupCommand) Cli() cli.Command {
+	return cli.Command{
+		Name:   "backup-cleanup",
+		Usage:  "Cleanup the VMs listed in an inventory after a backup was interrupted",
+		Action: d.Action,
+	}
+}
+
+func (d StandaloneBackupCleanupCommand) Action(c *cli.Context) error {
+	ctx := trapSignals(c, backupCleanupSignalMessages)
+
+	reporter := errorReporter{command: "backup-cleanup"}
+
+	inventory, err := standaloneInventory(c)
+	if err != nil {
+		return reporter.process(orchestrator.NewError(err))
+	}
+	reporter.deployment = inventory.Name
+
+	cleaner := factory.BuildStandaloneBackupCleaner(
+		inventory,
+		proxyJump(c),
+		c.App.Version,
+		c.GlobalBool("debug"),
+	)
+
+	cleanupErr := cleaner.CleanupWithContext(ctx, inventory.Name)
+
+	return reporter.process(cleanupErr)
+}
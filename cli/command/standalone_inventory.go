@@ -0,0 +1,11 @@
+package command
+
+import (
+	"github.com/cloudfoundry/bosh-backup-and-restore/standalone"
+	"github.com/urfave/cli"
+)
+
+// standaloneInventory loads the inventory given to the parent `bbr standalone` command.
+func standaloneInventory(c *cli.Context) (standalone.Inventory, error) {
+	return standalone.LoadInventory(c.Parent().String("inventory"))
+}
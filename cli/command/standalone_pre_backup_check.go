@@ -0,0 +1,57 @@
+package command
+
+import (
+	"fmt"
+
+	"github.com/cloudfoundry/bosh-backup-and-restore/factory"
+	"github.com/cloudfoundry/bosh-backup-and-restore/orchestrator"
+	"github.com/urfave/cli"
+)
+
+type StandalonePreBackupCheckCommand struct {
+}
+
+func (checkCommand StandalonePreBackupCheckCommand) Cli() cli.Command {
+	return cli.Command{
+		Name:    "pre-backup-check",
+		Aliases: []string{"c"},
+		Usage:   "Check the VMs listed in an inventory can be backed up",
+		Action:  checkCommand.Action,
+	}
+}
+
+func NewStandalonePreBackupCheckCommand() StandalonePreBackupCheckCommand {
+	return StandalonePreBackupCheckCommand{}
+}
+
+func (checkCommand StandalonePreBackupCheckCommand) Action(c *cli.Context) error {
+	reporter := errorReporter{command: "pre-backup-check", cleanupCommand: standaloneCleanupCommand(c, "backup-cleanup")}
+
+	inventory, loadErr := standaloneInventory(c)
+	if loadErr != nil {
+		return reporter.process(orchestrator.NewError(loadErr))
+	}
+	reporter.deployment = inventory.Name
+
+	backupChecker := factory.BuildStandaloneBackupChecker(
+		inventory,
+		proxyJump(c),
+		c.App.Version,
+		c.GlobalBool("debug"),
+	)
+
+	err := backupChecker.Check(inventory.Name)
+
+	if err != nil {
+		fmt.Printf("Deployment '%s' cannot be backed up.\n", inventory.Name)
+
+		if err.ContainsArtifactDirError() {
+			return reporter.processWithFooter(err, backupCleanupAdvisedNotice)
+		}
+
+		return reporter.process(err)
+	}
+
+	fmt.Printf("Deployment '%s' can be backed up.\n", inventory.Name)
+	return cli.NewExitError("", 0)
+}
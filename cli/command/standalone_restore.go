@@ -0,0 +1,66 @@
+package command
+
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/cloudfoundry/bosh-backup-and-restore/cli/flags"
+	"github.com/cloudfoundry/bosh-backup-and-restore/factory"
+	"github.com/cloudfoundry/bosh-backup-and-restore/orchestrator"
+	"github.com/urfave/cli"
+)
+
+type StandaloneRestoreCommand struct {
+}
+
+func NewStandaloneRestoreCommand() StandaloneRestoreCommand {
+	return StandaloneRestoreCommand{}
+}
+
+func (cmd StandaloneRestoreCommand) Cli() cli.Command {
+	return cli.Command{
+		Name:    "restore",
+		Aliases: []string{"r"},
+		Usage:   "Restore the VMs listed in an inventory from backup",
+		Action:  cmd.Action,
+		Flags: combineFlags([]cli.Flag{
+			cli.StringFlag{
+				Name:  "artifact-path, a",
+				Usage: "Path to the artifact to restore",
+			},
+		}, restoreHookFlags, notificationFlags, tracingFlags),
+	}
+}
+
+func (cmd StandaloneRestoreCommand) Action(c *cli.Context) error {
+	ctx := trapSignals(c, restoreSignalMessages)
+	defer startTracing(c)()
+
+	reporter := errorReporter{command: "restore", dir: filepath.Dir(c.String("artifact-path")), cleanupCommand: standaloneCleanupCommand(c, "restore-cleanup"), alwaysAdviseCleanup: true}
+
+	if err := flags.Validate([]string{"artifact-path"}, c); err != nil {
+		return err
+	}
+
+	inventory, err := standaloneInventory(c)
+	if err != nil {
+		return reporter.process(orchestrator.NewError(err))
+	}
+	reporter.deployment = inventory.Name
+
+	artifactPath := c.String("artifact-path")
+	notifier := newOutcomeNotifier(c)
+	startTime := time.Now()
+
+	restorer := factory.BuildStandaloneRestorer(
+		inventory,
+		proxyJump(c),
+		c.App.Version,
+		c.GlobalBool("debug"),
+		restoreHooks(c),
+	)
+
+	restoreErr := restorer.RestoreWithContext(ctx, inventory.Name, artifactPath)
+	notifier.notify("restore", inventory.Name, artifactPath, startTime, restoreErr, !restoreErr.IsNil())
+	return reporter.process(restoreErr)
+}
@@ -0,0 +1,44 @@
+package command
+
+import (
+	"github.com/cloudfoundry/bosh-backup-and-restore/factory"
+	"github.com/cloudfoundry/bosh-backup-and-restore/orchestrator"
+	"github.com/urfave/cli"
+)
+
+type StandaloneRestoreCleanupCommand struct {
+}
+
+func NewStandaloneRestoreCleanupCommand() StandaloneRestoreCleanupCommand {
+	return StandaloneRestoreCleanupCommand{}
+}
+func (d StandaloneRestoreCleanupCommand) Cli() cli.Command {
+	return cli.Command{
+		Name:   "restore-cleanup",
+		Usage:  "Cleanup the VMs listed in an inventory after a restore was interrupted",
+		Action: d.Action,
+	}
+}
+
+func (d StandaloneRestoreCleanupCommand) Action(c *cli.Context) error {
+	ctx := trapSignals(c, restoreCleanupSignalMessages)
+
+	reporter := errorReporter{command: "restore-cleanup"}
+
+	inventory, err := standaloneInventory(c)
+	if err != nil {
+		return reporter.process(orchestrator.NewError(err))
+	}
+	reporter.deployment = inventory.Name
+
+	cleaner := factory.BuildStandaloneRestoreCleaner(
+		inventory,
+		proxyJump(c),
+		c.App.Version,
+		c.GlobalBool("debug"),
+	)
+
+	cleanupErr := cleaner.CleanupWithContext(ctx, inventory.Name)
+
+	return reporter.process(cleanupErr)
+}
@@ -0,0 +1,39 @@
+package command
+
+import (
+	"fmt"
+
+	"github.com/cloudfoundry/bosh-backup-and-restore/tracing"
+	"github.com/urfave/cli"
+)
+
+var tracingFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "trace-file",
+		Usage: "Write OpenTelemetry spans for the workflow steps, job scripts, SSH commands and artifact transfers to this file",
+	},
+	cli.StringFlag{
+		Name:  "otlp-endpoint",
+		Usage: "Export OpenTelemetry spans to this OTLP/HTTP collector URL, e.g. http://localhost:4318",
+	},
+}
+
+// startTracing returns a function that flushes the spans. It must run before
+// the command returns, because the cli exits the process straight after.
+func startTracing(c *cli.Context) func() {
+	shutdown, err := tracing.Setup(tracing.Config{
+		File:         c.String("trace-file"),
+		OTLPEndpoint: c.String("otlp-endpoint"),
+		Version:      c.App.Version,
+	})
+	if err != nil {
+		printlnWithTimestamp(fmt.Sprintf("WARNING: tracing is disabled: %s", err))
+		return func() {}
+	}
+
+	return func() {
+		if err := shutdown(); err != nil {
+			printlnWithTimestamp(fmt.Sprintf("WARNING: %s", err))
+		}
+	}
+}
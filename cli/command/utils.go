@@ -2,15 +2,18 @@ package command
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"os"
 	"os/signal"
 	"strings"
+	"syscall"
 
 	"time"
 
 	"net/url"
 
+	"github.com/cloudfoundry/bosh-backup-and-restore/errorreport"
 	"github.com/cloudfoundry/bosh-backup-and-restore/factory"
 	"github.com/cloudfoundry/bosh-backup-and-restore/orchestrator"
 	"github.com/urfave/cli"
@@ -18,52 +21,123 @@ import (
 
 const defaultLogfilePermissions = 0644
 
-func trapSigint(backup bool) {
-	sigintChan := make(chan os.Signal, 1)
-	signal.Notify(sigintChan, os.Interrupt)
+// trapSignals returns a context that is cancelled by SIGTERM, or by SIGINT
+// once the user confirms it, so that bbr unlocks and cleans up before
+// exiting. A second signal, even while bbr is asking, stops it all the same,
+// and a signal after the context is cancelled exits immediately.
+func trapSignals(c *cli.Context, messages signalMessages) context.Context {
+	ctx, cancel := context.WithCancelCause(context.Background())
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
 
-	var sigintQuestion, stdInErrorMessage, cleanupAdvisedNotice string
-	if backup {
-		sigintQuestion = backupSigintQuestion
-		stdInErrorMessage = backupStdinErrorMessage
-		cleanupAdvisedNotice = backupCleanupAdvisedNotice
-	} else {
-		sigintQuestion = restoreSigintQuestion
-		stdInErrorMessage = restoreStdinErrorMessage
-		cleanupAdvisedNotice = restoreCleanupAdvisedNotice
-	}
+	interactive := !c.GlobalBool("non-interactive")
 
 	go func() {
-		for range sigintChan {
-			stdinReader := bufio.NewReader(os.Stdin)
-			factory.ApplicationLoggerStdout.Pause()
-			factory.ApplicationLoggerStderr.Pause()
-			fmt.Fprintln(os.Stdout, "\n"+sigintQuestion) //nolint:errcheck
-			input, err := stdinReader.ReadString('\n')
-			if err != nil {
-				fmt.Println("\n" + stdInErrorMessage)
-			} else if strings.ToLower(strings.TrimSpace(input)) == "yes" {
-				fmt.Println(cleanupAdvisedNotice)
+		var answers <-chan stdinAnswer
+		for sig := range signals {
+			if ctx.Err() != nil {
+				fmt.Println("\n" + messages.cleanupAdvisedNotice)
 				os.Exit(1)
 			}
-			factory.ApplicationLoggerStdout.Resume() //nolint:errcheck
-			factory.ApplicationLoggerStderr.Resume() //nolint:errcheck
+
+			if sig == os.Interrupt && interactive {
+				if answers == nil {
+					answers = readStdinLines()
+				}
+
+				confirmed, nextSig := confirmAbort(messages, answers, signals)
+				if nextSig != nil {
+					sig = nextSig
+				} else if !confirmed {
+					continue
+				}
+			}
+
+			fmt.Println("\n" + messages.abortNotice)
+			cancel(fmt.Errorf("received %s", sig))
 		}
 	}()
+
+	return ctx
+}
+
+type stdinAnswer struct {
+	line string
+	err  error
+}
+
+// readStdinLines reads stdin in the background, so that waiting for an
+// answer never keeps bbr from handling another signal.
+func readStdinLines() <-chan stdinAnswer {
+	answers := make(chan stdinAnswer)
+	go func() {
+		defer close(answers)
+		stdinReader := bufio.NewReader(os.Stdin)
+		for {
+			line, err := stdinReader.ReadString('\n')
+			answers <- stdinAnswer{line: line, err: err}
+			if err != nil {
+				return
+			}
+		}
+	}()
+	return answers
+}
+
+// confirmAbort asks the user whether to stop. It returns the signal that
+// arrived instead of an answer, if any.
+func confirmAbort(messages signalMessages, answers <-chan stdinAnswer, signals <-chan os.Signal) (bool, os.Signal) {
+	factory.ApplicationLoggerStdout.Pause()
+	factory.ApplicationLoggerStderr.Pause()
+	defer factory.ApplicationLoggerStdout.Resume() //nolint:errcheck
+	defer factory.ApplicationLoggerStderr.Resume() //nolint:errcheck
+
+	fmt.Fprintln(os.Stdout, "\n"+messages.sigintQuestion) //nolint:errcheck
+	select {
+	case sig := <-signals:
+		return false, sig
+	case answer, ok := <-answers:
+		if !ok || answer.err != nil {
+			fmt.Println("\n" + messages.stdinErrorMessage)
+			return false, nil
+		}
+		return strings.ToLower(strings.TrimSpace(answer.line)) == "yes", nil
+	}
+}
+
+func combineFlags(flagSets ...[]cli.Flag) []cli.Flag {
+	var combined []cli.Flag
+	for _, flagSet := range flagSets {
+		combined = append(combined, flagSet...)
+	}
+	return combined
 }
 
-func processError(err orchestrator.Error) error {
-	return processErrorWithFooter(err, "")
+// errorReporter turns the errors of a command into an exit error and writes
+// a report of them into dir, which is usually where the artifact is.
+type errorReporter struct {
+	command    string
+	deployment string
+	dir        string
+	// cleanupCommand is added to the report when bbr advises a cleanup.
+	cleanupCommand string
+	// alwaysAdviseCleanup is set by restores, which always leave something
+	// to clean up when they fail.
+	alwaysAdviseCleanup bool
 }
 
-func processErrorWithFooter(err orchestrator.Error, footer string) error {
+func (r errorReporter) process(err orchestrator.Error) error {
+	return r.processWithFooter(err, "")
+}
+
+func (r errorReporter) processWithFooter(err orchestrator.Error, footer string) error {
 	errorCode := orchestrator.BuildExitCode(err)
 	errorMessage := err.Error()
-	errorWithStackTrace := err.PrettyError(true)
 
-	writeErr := writeStackTrace(errorWithStackTrace)
-	if writeErr != nil {
-		errorMessage = errorWithStackTrace
+	if err != nil {
+		if writeErr := r.write(err, footer); writeErr != nil {
+			errorMessage = err.PrettyError(true)
+		}
 	}
 
 	errorMessage = errorMessage + "\n" + footer
@@ -71,14 +145,74 @@ func processErrorWithFooter(err orchestrator.Error, footer string) error {
 	return cli.NewExitError(errorMessage, errorCode)
 }
 
-func writeStackTrace(errorWithStackTrace string) error {
-	if errorWithStackTrace != "" {
-		err := os.WriteFile(fmt.Sprintf("bbr-%s.err.log", time.Now().UTC().Format(time.RFC3339)), []byte(errorWithStackTrace), defaultLogfilePermissions)
-		if err != nil {
-			return err
+func (r errorReporter) write(err orchestrator.Error, footer string) error {
+	cleanupCommand := ""
+	if footer != "" || r.alwaysAdviseCleanup {
+		cleanupCommand = r.cleanupCommand
+	}
+
+	report := errorreport.New(r.command, []errorreport.DeploymentErrors{{Deployment: r.deployment, Errors: err}}, cleanupCommand)
+	_, writeErr := report.Write(r.dir, time.Now())
+	return writeErr
+}
+
+// deploymentCleanupCommand repeats the flags of bbr deployment that the
+// cleanup command needs, except the password. A CA certificate given as a
+// PEM value rather than a path, as bosh-cli tells them apart, is left out too,
+// so that it has to be given again through BOSH_CA_CERT.
+func deploymentCleanupCommand(c *cli.Context, cleanupCommand string) string {
+	command := cleanupCommandWith(c, "deployment", "target", "username")
+	if caCert := c.Parent().String("ca-cert"); caCert != "" && !strings.Contains(caCert, "BEGIN") {
+		command = append(command, "--ca-cert", shellQuote(caCert))
+	}
+	command = appendStringFlags(command, c, "proxy-jump-config")
+
+	if c.Parent().Bool("all-deployments") {
+		command = append(command, "--all-deployments")
+		for _, excluded := range c.Parent().StringSlice("exclude-deployment") {
+			command = append(command, "--exclude-deployment", shellQuote(excluded))
+		}
+	} else {
+		command = appendStringFlags(command, c, "deployment")
+	}
+	return strings.Join(append(command, cleanupCommand), " ")
+}
+
+func directorCleanupCommand(c *cli.Context, cleanupCommand string) string {
+	command := cleanupCommandWith(c, "director", "host", "username", "private-key-path", "certificate-path")
+	if c.Parent().Bool("ssh-agent") {
+		command = append(command, "--ssh-agent")
+	}
+	command = appendStringFlags(command, c, "known-hosts", "host-key-fingerprint", "proxy-jump-config")
+	return strings.Join(append(command, cleanupCommand), " ")
+}
+
+func standaloneCleanupCommand(c *cli.Context, cleanupCommand string) string {
+	command := cleanupCommandWith(c, "standalone", "inventory", "proxy-jump-config")
+	return strings.Join(append(command, cleanupCommand), " ")
+}
+
+func cleanupCommandWith(c *cli.Context, subcommand string, flagNames ...string) []string {
+	return appendStringFlags([]string{"bbr", subcommand}, c, flagNames...)
+}
+
+// appendStringFlags appends the flags of the parent command that are set,
+// with their values quoted for the shell.
+func appendStringFlags(command []string, c *cli.Context, flagNames ...string) []string {
+	for _, name := range flagNames {
+		if value := c.Parent().String(name); value != "" {
+			command = append(command, "--"+name, shellQuote(value))
 		}
 	}
-	return nil
+	return command
+}
+
+// shellQuote quotes value for a POSIX shell, unless it is safe to use as it is.
+func shellQuote(value string) string {
+	if value != "" && strings.Trim(value, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789@%+=:,./_-") == "" {
+		return value
+	}
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
 }
 
 func extractNameFromAddress(address string) string {
@@ -0,0 +1,212 @@
+package config
+
+import (
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// Config is a bbr.yml file of named profiles, usually one per BOSH director,
+// e.g.
+//
+//	default_profile: prod
+//	profiles:
+//	  prod:
+//	    target: https://10.0.0.6:25555
+//	    username: admin
+//	    password: {env: PROD_BOSH_CLIENT_SECRET}
+//	    ca_cert: {file: /home/me/prod/director.crt}
+//	    exclude_deployments: [concourse]
+//	    director:
+//	      host: 10.0.0.6
+//	      username: jumpbox
+//	      private_key_path: /home/me/prod/jumpbox.key
+//	    backup:
+//	      artifact_path: /backups/prod
+//	    deployments:
+//	      cf:
+//	        artifact_path: /backups/prod/cf
+//	        with_manifest: true
+//	        artifact_streams: 4
+//	        concurrency: 5
+//
+// Every value supplies the default of the bbr flag with the same name, so
+// flags and their environment variables always take precedence.
+type Config struct {
+	DefaultProfile string             `yaml:"default_profile"`
+	Profiles       map[string]Profile `yaml:"profiles"`
+}
+
+type Profile struct {
+	Target             Value                     `yaml:"target"`
+	Username           Value                     `yaml:"username"`
+	Password           Value                     `yaml:"password"`
+	CACert             Value                     `yaml:"ca_cert"`
+	Deployment         string                    `yaml:"deployment"`
+	ExcludeDeployments []string                  `yaml:"exclude_deployments"`
+	ProxyJumpConfig    string                    `yaml:"proxy_jump_config"`
+	NonInteractive     *bool                     `yaml:"non_interactive"`
+	Debug              *bool                     `yaml:"debug"`
+	Director           DirectorSettings          `yaml:"director"`
+	Standalone         StandaloneSettings        `yaml:"standalone"`
+	Backup             BackupSettings            `yaml:"backup"`
+	Deployments        map[string]BackupSettings `yaml:"deployments"`
+}
+
+// DirectorSettings are the SSH credentials used by bbr director.
+type DirectorSettings struct {
+	Host               string `yaml:"host"`
+	Username           Value  `yaml:"username"`
+	PrivateKeyPath     string `yaml:"private_key_path"`
+	CertificatePath    string `yaml:"certificate_path"`
+	SSHAgent           *bool  `yaml:"ssh_agent"`
+	KnownHosts         string `yaml:"known_hosts"`
+	HostKeyFingerprint string `yaml:"host_key_fingerprint"`
+}
+
+type StandaloneSettings struct {
+	Inventory string `yaml:"inventory"`
+}
+
+// BackupSettings are the defaults of the backup commands. Those of a
+// deployment override those of its profile.
+type BackupSettings struct {
+	ArtifactPath    string `yaml:"artifact_path"`
+	WithManifest    *bool  `yaml:"with_manifest"`
+	ArtifactStreams int    `yaml:"artifact_streams"`
+	CheckDiskSpace  *bool  `yaml:"check_disk_space"`
+	Concurrency     int    `yaml:"concurrency"`
+}
+
+// Flags maps flag names to the values to set them to. Flags that can be
+// given more than once have a value per occurrence. Values are only resolved
+// when used, so that a flag given on the command line never needs the
+// environment variable or file its profile refers to.
+type Flags map[string][]Value
+
+func Load(path string) (Config, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, errors.Wrap(err, "failed reading config file")
+	}
+
+	var config Config
+	if err := yaml.UnmarshalStrict(contents, &config); err != nil {
+		return Config{}, errors.Wrapf(err, "failed parsing config file %s", path)
+	}
+
+	if config.DefaultProfile != "" {
+		if _, ok := config.Profiles[config.DefaultProfile]; !ok {
+			return Config{}, errors.Errorf("invalid config file %s: default_profile %q is not one of the profiles", path, config.DefaultProfile)
+		}
+	}
+	return config, nil
+}
+
+// Profile returns the profile called name, or the default profile when name
+// is empty. Without a default profile the empty profile is returned, which
+// sets no flags.
+func (config Config) Profile(name string) (Profile, error) {
+	if name == "" {
+		name = config.DefaultProfile
+	}
+	if name == "" {
+		return Profile{}, nil
+	}
+
+	profile, ok := config.Profiles[name]
+	if !ok {
+		return Profile{}, errors.Errorf("profile %q not found, expected one of: %s", name, strings.Join(config.profileNames(), ", "))
+	}
+	return profile, nil
+}
+
+func (config Config) profileNames() []string {
+	var names []string
+	for name := range config.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// DeploymentFlags returns the flags of bbr deployment set by the profile.
+func (profile Profile) DeploymentFlags() Flags {
+	flags := profile.commonFlags()
+	flags.set("target", profile.Target)
+	flags.set("username", profile.Username)
+	flags.set("password", profile.Password)
+	flags.set("ca-cert", profile.CACert)
+	flags.setString("deployment", profile.Deployment)
+	for _, deployment := range profile.ExcludeDeployments {
+		flags["exclude-deployment"] = append(flags["exclude-deployment"], Value{Value: deployment})
+	}
+	return flags
+}
+
+// DirectorFlags returns the flags of bbr director set by the profile.
+func (profile Profile) DirectorFlags() Flags {
+	director := profile.Director
+	flags := profile.commonFlags()
+	flags.setString("host", director.Host)
+	flags.set("username", director.Username)
+	flags.setString("private-key-path", director.PrivateKeyPath)
+	flags.setString("certificate-path", director.CertificatePath)
+	flags.setBool("ssh-agent", director.SSHAgent)
+	flags.setString("known-hosts", director.KnownHosts)
+	flags.setString("host-key-fingerprint", director.HostKeyFingerprint)
+	return flags
+}
+
+// StandaloneFlags returns the flags of bbr standalone set by the profile.
+func (profile Profile) StandaloneFlags() Flags {
+	flags := profile.commonFlags()
+	flags.setString("inventory", profile.Standalone.Inventory)
+	return flags
+}
+
+// BackupFlags returns the flags of the backup commands set by the profile
+// for deployment, which is empty when not backing up a single deployment.
+func (profile Profile) BackupFlags(deployment string) Flags {
+	flags := Flags{}
+	for _, settings := range []BackupSettings{profile.Backup, profile.Deployments[deployment]} {
+		flags.setString("artifact-path", settings.ArtifactPath)
+		flags.setBool("with-manifest", settings.WithManifest)
+		flags.setBool("check-disk-space", settings.CheckDiskSpace)
+		if settings.ArtifactStreams != 0 {
+			flags.setString("artifact-streams", strconv.Itoa(settings.ArtifactStreams))
+		}
+		if settings.Concurrency != 0 {
+			flags.setString("concurrency", strconv.Itoa(settings.Concurrency))
+		}
+	}
+	return flags
+}
+
+func (profile Profile) commonFlags() Flags {
+	flags := Flags{}
+	flags.setString("proxy-jump-config", profile.ProxyJumpConfig)
+	flags.setBool("non-interactive", profile.NonInteractive)
+	flags.setBool("debug", profile.Debug)
+	return flags
+}
+
+func (flags Flags) set(name string, value Value) {
+	if !value.IsZero() {
+		flags[name] = []Value{value}
+	}
+}
+
+func (flags Flags) setString(name, value string) {
+	flags.set(name, Value{Value: value})
+}
+
+func (flags Flags) setBool(name string, value *bool) {
+	if value != nil {
+		flags.setString(name, strconv.FormatBool(*value))
+	}
+}
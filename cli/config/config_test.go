@@ -0,0 +1,231 @@
+package config_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	. "github.com/cloudfoundry/bosh-backup-and-restore/cli/config"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Config", func() {
+	var dir string
+	var config Config
+	var loadErr error
+
+	writeFile := func(name, contents string) string {
+		path := filepath.Join(dir, name)
+		Expect(os.WriteFile(path, []byte(contents), 0600)).To(Succeed())
+		return path
+	}
+
+	loadConfig := func(contents string) {
+		config, loadErr = Load(writeFile("bbr.yml", contents))
+	}
+
+	resolve := func(flags Flags) map[string][]string {
+		resolved := map[string][]string{}
+		for name, values := range flags {
+			for _, value := range values {
+				resolvedValue, err := value.Resolve()
+				Expect(err).NotTo(HaveOccurred())
+				resolved[name] = append(resolved[name], resolvedValue)
+			}
+		}
+		return resolved
+	}
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "bbr-config-")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	Context("when the config has profiles", func() {
+		BeforeEach(func() {
+			writeFile("director.crt", "-----BEGIN CERTIFICATE-----\n")
+			os.Setenv("BBR_CONFIG_TEST_SECRET", "prod-secret") //nolint:errcheck
+
+			loadConfig(`---
+default_profile: prod
+profiles:
+  prod:
+    target: https://10.0.0.6:25555
+    username: admin
+    password: {env: BBR_CONFIG_TEST_SECRET}
+    ca_cert: {file: ` + filepath.Join(dir, "director.crt") + `}
+    deployment: cf
+    exclude_deployments: [concourse, credhub]
+    non_interactive: true
+    director:
+      host: 10.0.0.6
+      username: jumpbox
+      private_key_path: /home/me/jumpbox.key
+      ssh_agent: false
+    standalone:
+      inventory: /home/me/inventory.yml
+    backup:
+      artifact_path: /backups
+      check_disk_space: true
+      artifact_streams: 2
+    deployments:
+      cf:
+        artifact_path: /backups/cf
+        with_manifest: true
+        artifact_streams: 4
+        concurrency: 5
+  staging:
+    target: https://10.1.0.6:25555
+`)
+			Expect(loadErr).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			os.Unsetenv("BBR_CONFIG_TEST_SECRET") //nolint:errcheck
+		})
+
+		It("uses the default profile when none is named", func() {
+			profile, err := config.Profile("")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resolve(profile.DeploymentFlags())).To(Equal(map[string][]string{
+				"target":             {"https://10.0.0.6:25555"},
+				"username":           {"admin"},
+				"password":           {"prod-secret"},
+				"ca-cert":            {"-----BEGIN CERTIFICATE-----"},
+				"deployment":         {"cf"},
+				"exclude-deployment": {"concourse", "credhub"},
+				"non-interactive":    {"true"},
+			}))
+		})
+
+		It("uses the named profile", func() {
+			profile, err := config.Profile("staging")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resolve(profile.DeploymentFlags())).To(Equal(map[string][]string{
+				"target": {"https://10.1.0.6:25555"},
+			}))
+		})
+
+		It("fails when the named profile does not exist", func() {
+			_, err := config.Profile("dev")
+
+			Expect(err).To(MatchError(`profile "dev" not found, expected one of: prod, staging`))
+		})
+
+		It("returns the flags of bbr director", func() {
+			profile, _ := config.Profile("prod")
+
+			Expect(resolve(profile.DirectorFlags())).To(Equal(map[string][]string{
+				"host":             {"10.0.0.6"},
+				"username":         {"jumpbox"},
+				"private-key-path": {"/home/me/jumpbox.key"},
+				"ssh-agent":        {"false"},
+				"non-interactive":  {"true"},
+			}))
+		})
+
+		It("returns the flags of bbr standalone", func() {
+			profile, _ := config.Profile("prod")
+
+			Expect(resolve(profile.StandaloneFlags())).To(Equal(map[string][]string{
+				"inventory":       {"/home/me/inventory.yml"},
+				"non-interactive": {"true"},
+			}))
+		})
+
+		It("overrides the backup settings of the profile with those of the deployment", func() {
+			profile, _ := config.Profile("prod")
+
+			Expect(resolve(profile.BackupFlags("cf"))).To(Equal(map[string][]string{
+				"artifact-path":    {"/backups/cf"},
+				"with-manifest":    {"true"},
+				"check-disk-space": {"true"},
+				"artifact-streams": {"4"},
+				"concurrency":      {"5"},
+			}))
+			Expect(resolve(profile.BackupFlags("redis"))).To(Equal(map[string][]string{
+				"artifact-path":    {"/backups"},
+				"check-disk-space": {"true"},
+				"artifact-streams": {"2"},
+			}))
+		})
+	})
+
+	Context("when the config has no default profile", func() {
+		It("sets no flags when no profile is named", func() {
+			loadConfig(`---
+profiles:
+  prod:
+    target: https://10.0.0.6:25555
+`)
+			Expect(loadErr).NotTo(HaveOccurred())
+
+			profile, err := config.Profile("")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(profile.DeploymentFlags()).To(BeEmpty())
+		})
+	})
+
+	Context("when the config is invalid", func() {
+		It("fails when the default profile does not exist", func() {
+			loadConfig(`---
+default_profile: prod
+profiles:
+  staging: {}
+`)
+			Expect(loadErr).To(MatchError(ContainSubstring(`default_profile "prod" is not one of the profiles`)))
+		})
+
+		It("fails on unknown keys", func() {
+			loadConfig(`---
+profiles:
+  prod:
+    tagret: https://10.0.0.6:25555
+`)
+			Expect(loadErr).To(MatchError(ContainSubstring("field tagret not found")))
+		})
+
+		It("fails when a value refers to both an environment variable and a file", func() {
+			loadConfig(`---
+profiles:
+  prod:
+    password: {env: SECRET, file: /secret}
+`)
+			Expect(loadErr).To(MatchError(ContainSubstring("refer to exactly one of env or file")))
+		})
+	})
+
+	It("fails when the file does not exist", func() {
+		_, err := Load(filepath.Join(dir, "missing.yml"))
+
+		Expect(err).To(MatchError(ContainSubstring("failed reading config file")))
+		Expect(errors.Is(err, os.ErrNotExist)).To(BeTrue())
+	})
+})
+
+var _ = Describe("Value", func() {
+	It("resolves an inline value", func() {
+		Expect(Value{Value: "admin"}.Resolve()).To(Equal("admin"))
+	})
+
+	It("fails when its environment variable is not set", func() {
+		_, err := Value{Env: "BBR_CONFIG_TEST_UNSET"}.Resolve()
+
+		Expect(err).To(MatchError("environment variable BBR_CONFIG_TEST_UNSET is not set"))
+	})
+
+	It("fails when its file cannot be read", func() {
+		_, err := Value{File: "/does/not/exist"}.Resolve()
+
+		Expect(err).To(MatchError(ContainSubstring("failed reading value")))
+	})
+})
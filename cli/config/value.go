@@ -0,0 +1,65 @@
+package config
+
+import (
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Value is given inline, or refers to the environment variable or file it
+// is read from so that secrets can be kept out of the config file, e.g.
+//
+//	password: {env: BOSH_CLIENT_SECRET}
+//	ca_cert: {file: /home/me/director.crt}
+type Value struct {
+	Value string
+	Env   string `yaml:"env"`
+	File  string `yaml:"file"`
+}
+
+func (value *Value) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	if err := unmarshal(&value.Value); err == nil {
+		return nil
+	}
+
+	var reference struct {
+		Env  string `yaml:"env"`
+		File string `yaml:"file"`
+	}
+	if err := unmarshal(&reference); err != nil {
+		return err
+	}
+	if (reference.Env == "") == (reference.File == "") {
+		return errors.New("a value must be a string, or refer to exactly one of env or file")
+	}
+
+	value.Env = reference.Env
+	value.File = reference.File
+	return nil
+}
+
+func (value Value) IsZero() bool {
+	return value == Value{}
+}
+
+// Resolve returns the value, reading it from its environment variable or
+// file if it refers to one. Trailing newlines are trimmed from files.
+func (value Value) Resolve() (string, error) {
+	switch {
+	case value.Env != "":
+		resolved, ok := os.LookupEnv(value.Env)
+		if !ok {
+			return "", errors.Errorf("environment variable %s is not set", value.Env)
+		}
+		return resolved, nil
+	case value.File != "":
+		contents, err := os.ReadFile(value.File)
+		if err != nil {
+			return "", errors.Wrap(err, "failed reading value")
+		}
+		return strings.TrimRight(string(contents), "\r\n"), nil
+	default:
+		return value.Value, nil
+	}
+}
@@ -31,6 +31,28 @@ func ValidateDeployment(c *cli.Context) error {
 	return nil
 }
 
+func ValidateDirectorSSH(c *cli.Context) error {
+	if containsHelpFlag(c) {
+		return nil
+	}
+
+	var err error
+	switch {
+	case c.String("private-key-path") == "" && !c.Bool("ssh-agent"):
+		err = errors.New("provide one of '--private-key-path' or '--ssh-agent' flags.")
+	case c.String("certificate-path") != "" && c.String("private-key-path") == "":
+		err = errors.New("'--certificate-path' requires '--private-key-path'.")
+	case c.String("known-hosts") != "" && c.String("host-key-fingerprint") != "":
+		err = errors.New("provide only one of '--known-hosts' or '--host-key-fingerprint' flags.")
+	}
+
+	if err != nil {
+		cli.ShowSubcommandHelp(c) //nolint:errcheck
+		return redCliError(err)
+	}
+	return nil
+}
+
 func containsHelpFlag(c *cli.Context) bool {
 	for _, arg := range c.Args() {
 		if arg == "--help" || arg == "-h" {
@@ -31,11 +31,12 @@ func main() {
 			Name:   "deployment",
 			Usage:  "Backup BOSH deployments",
 			Flags:  availableDeploymentFlags(),
-			Before: validateDeploymentFlags,
+			Before: withConfig(command.ApplyDeploymentConfig, withProxyJump(validateDeploymentFlags)),
 			Subcommands: []cli.Command{
 				command.NewDeploymentPreBackupCheckCommand().Cli(),
 				command.NewDeploymentBackupCommand().Cli(),
 				command.NewDeploymentRestoreCommand().Cli(),
+				command.NewDeploymentPreRestoreCheckCommand().Cli(),
 				command.NewDeploymentBackupCleanupCommand().Cli(),
 				command.NewDeploymentRestoreCleanupCommand().Cli(),
 			},
@@ -44,7 +45,7 @@ func main() {
 			Name:   "director",
 			Usage:  "Backup BOSH director",
 			Flags:  availableDirectorFlags(),
-			Before: validateDirectorFlags,
+			Before: withConfig(command.ApplyDirectorConfig, withProxyJump(validateDirectorFlags)),
 			Subcommands: []cli.Command{
 				command.NewDirectorPreBackupCheckCommand().Cli(),
 				command.NewDirectorBackupCommand().Cli(),
@@ -53,6 +54,19 @@ func main() {
 				command.NewDirectorRestoreCleanupCommand().Cli(),
 			},
 		},
+		{
+			Name:   "standalone",
+			Usage:  "Backup VMs that are not managed by BOSH",
+			Flags:  availableStandaloneFlags(),
+			Before: withConfig(command.ApplyStandaloneConfig, withProxyJump(validateStandaloneFlags)),
+			Subcommands: []cli.Command{
+				command.NewStandalonePreBackupCheckCommand().Cli(),
+				command.NewStandaloneBackupCommand().Cli(),
+				command.NewStandaloneRestoreCommand().Cli(),
+				command.NewStandaloneBackupCleanupCommand().Cli(),
+				command.NewStandaloneRestoreCleanupCommand().Cli(),
+			},
+		},
 		{
 			Name:    "help",
 			Aliases: []string{"h"},
@@ -85,6 +99,26 @@ func versionAction(c *cli.Context) error {
 	return nil
 }
 
+func withConfig(applyConfig, next cli.BeforeFunc) cli.BeforeFunc {
+	return func(c *cli.Context) error {
+		if err := applyConfig(c); err != nil {
+			return err
+		}
+
+		return next(c)
+	}
+}
+
+func withProxyJump(validate cli.BeforeFunc) cli.BeforeFunc {
+	return func(c *cli.Context) error {
+		if err := validate(c); err != nil {
+			return err
+		}
+
+		return command.ConfigureProxyJump(c)
+	}
+}
+
 func validateDeploymentFlags(c *cli.Context) error {
 	err := flags.Validate([]string{"target", "username", "password"}, c)
 	if err != nil {
@@ -100,7 +134,16 @@ func validateDeploymentFlags(c *cli.Context) error {
 }
 
 func validateDirectorFlags(c *cli.Context) error {
-	return flags.Validate([]string{"host", "username", "private-key-path"}, c)
+	err := flags.Validate([]string{"host", "username"}, c)
+	if err != nil {
+		return err
+	}
+
+	return flags.ValidateDirectorSSH(c)
+}
+
+func validateStandaloneFlags(c *cli.Context) error {
+	return flags.Validate([]string{"inventory"}, c)
 }
 
 func availableDeploymentFlags() []cli.Flag {
@@ -135,6 +178,10 @@ func availableDeploymentFlags() []cli.Flag {
 			EnvVar: "CA_CERT,BOSH_CA_CERT",
 			Usage:  "Path or value of BOSH Director custom CA certificate",
 		},
+		proxyJumpFlag(),
+		nonInteractiveFlag(),
+		configFlag(),
+		profileFlag(),
 		cli.BoolFlag{
 			Name:  "debug",
 			Usage: "Enable debug logs",
@@ -143,6 +190,10 @@ func availableDeploymentFlags() []cli.Flag {
 			Name:  "all-deployments",
 			Usage: "Run command for all deployments. Omit if '--deployment' is provided. Currently only supported for: pre-backup-check, backup and backup-cleanup",
 		},
+		cli.StringSliceFlag{
+			Name:  "exclude-deployment",
+			Usage: "Skip this deployment when '--all-deployments' is provided. Can be given more than once",
+		},
 	}
 }
 
@@ -161,11 +212,87 @@ func availableDirectorFlags() []cli.Flag {
 		cli.StringFlag{
 			Name:  "private-key-path, key",
 			Value: "",
-			Usage: "BOSH Director SSH private key",
+			Usage: "BOSH Director SSH private key. Omit if '--ssh-agent' is provided",
+		},
+		cli.StringFlag{
+			Name:  "certificate-path",
+			Value: "",
+			Usage: "OpenSSH certificate for the private key",
 		},
+		cli.BoolFlag{
+			Name:  "ssh-agent",
+			Usage: "Authenticate with the keys held by the SSH agent at SSH_AUTH_SOCK",
+		},
+		cli.StringFlag{
+			Name:  "known-hosts",
+			Value: "",
+			Usage: "known_hosts file to verify the BOSH Director host key against",
+		},
+		cli.StringFlag{
+			Name:  "host-key-fingerprint",
+			Value: "",
+			Usage: "Expected fingerprint of the BOSH Director host key, e.g. SHA256:...",
+		},
+		proxyJumpFlag(),
+		nonInteractiveFlag(),
+		configFlag(),
+		profileFlag(),
+		cli.BoolFlag{
+			Name:  "debug",
+			Usage: "Enable debug logs",
+		},
+	}
+}
+
+func availableStandaloneFlags() []cli.Flag {
+	return []cli.Flag{
+		cli.StringFlag{
+			Name:  "inventory, i",
+			Value: "",
+			Usage: "Path to a YAML inventory of the hosts, instance groups and SSH credentials",
+		},
+		proxyJumpFlag(),
+		nonInteractiveFlag(),
+		configFlag(),
+		profileFlag(),
 		cli.BoolFlag{
 			Name:  "debug",
 			Usage: "Enable debug logs",
 		},
 	}
 }
+
+func proxyJumpFlag() cli.Flag {
+	return cli.StringFlag{
+		Name:   "proxy-jump-config",
+		Value:  "",
+		EnvVar: "BBR_PROXY_JUMP_CONFIG",
+		Usage:  "Path to a YAML file of the SSH jump hosts to connect through",
+	}
+}
+
+func nonInteractiveFlag() cli.Flag {
+	return cli.BoolFlag{
+		Name:   "non-interactive",
+		EnvVar: "BBR_NON_INTERACTIVE",
+		Usage:  "Stop on the first SIGINT without asking for confirmation",
+	}
+}
+
+func configFlag() cli.Flag {
+	return cli.StringFlag{
+		Name:   "config",
+		Value:  "",
+		EnvVar: "BBR_CONFIG",
+		Usage:  "Path to a bbr.yml file of profiles that supply defaults for these flags. Defaults to ~/.bbr/bbr.yml",
+	}
+}
+
+func profileFlag() cli.Flag {
+	return cli.StringFlag{
+		Name:   "profile",
+		Value:  "",
+		EnvVar: "BBR_PROFILE",
+		Usage:  "Profile of the config file to use. Defaults to its default_profile",
+	}
+}
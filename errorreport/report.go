@@ -0,0 +1,103 @@
+package errorreport
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/cloudfoundry/bosh-backup-and-restore/orchestrator"
+)
+
+const filePermissions = 0644
+
+// Report describes why a bbr command failed, so that automation can decide
+// how to recover without parsing the output of bbr.
+type Report struct {
+	Command  string `json:"command"`
+	ExitCode int    `json:"exit_code"`
+	// CleanupCommand is only set when bbr recommends cleaning up before
+	// trying again.
+	CleanupCommand string  `json:"cleanup_command,omitempty"`
+	Errors         []Error `json:"errors"`
+}
+
+// Error is a single failure. A failure made up of several failed scripts is
+// reported as an Error per script.
+type Error struct {
+	Category   orchestrator.ErrorCategory `json:"category"`
+	Message    string                     `json:"message"`
+	Deployment string                     `json:"deployment,omitempty"`
+	Instance   string                     `json:"instance,omitempty"`
+	Job        string                     `json:"job,omitempty"`
+	Phase      string                     `json:"phase,omitempty"`
+	ExitCode   int                        `json:"exit_code,omitempty"`
+	Stderr     string                     `json:"stderr,omitempty"`
+	StackTrace string                     `json:"stack_trace"`
+}
+
+// DeploymentErrors are the errors of a command on a single deployment.
+type DeploymentErrors struct {
+	Deployment string
+	Errors     orchestrator.Error
+}
+
+// New reports the errors of command, with the exit code bbr exits with.
+func New(command string, deploymentErrs []DeploymentErrors, cleanupCommand string) Report {
+	report := Report{Command: command, CleanupCommand: cleanupCommand, Errors: []Error{}}
+
+	var allErrs []error
+	for _, deploymentErr := range deploymentErrs {
+		for _, err := range deploymentErr.Errors {
+			report.Errors = append(report.Errors, newErrors(deploymentErr.Deployment, err)...)
+		}
+		allErrs = append(allErrs, deploymentErr.Errors...)
+	}
+	report.ExitCode = orchestrator.BuildExitCode(allErrs)
+
+	return report
+}
+
+func newErrors(deployment string, err error) []Error {
+	reportErr := Error{
+		Category:   orchestrator.Category(err),
+		Message:    err.Error(),
+		Deployment: deployment,
+		StackTrace: fmt.Sprintf("%+v", err),
+	}
+
+	failedScripts := orchestrator.FailedScripts(err)
+	if len(failedScripts) == 0 {
+		return []Error{reportErr}
+	}
+
+	var reportErrs []Error
+	for _, script := range failedScripts {
+		scriptErr := reportErr
+		scriptErr.Message = script.Error()
+		scriptErr.Instance = script.Instance
+		scriptErr.Job = script.Job
+		scriptErr.Phase = script.Script
+		scriptErr.ExitCode = script.ExitCode
+		scriptErr.Stderr = script.Stderr
+		reportErrs = append(reportErrs, scriptErr)
+	}
+	return reportErrs
+}
+
+// Write saves the report as bbr-<timestamp>.err.json in dir and returns the
+// path of the file.
+func (r Report) Write(dir string, now time.Time) (string, error) {
+	contents, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(dir, fmt.Sprintf("bbr-%s.err.json", now.UTC().Format(time.RFC3339)))
+	if err := os.WriteFile(path, append(contents, '\n'), filePermissions); err != nil {
+		return "", err
+	}
+
+	return path, nil
+}
@@ -0,0 +1,153 @@
+package errorreport_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/cloudfoundry/bosh-backup-and-restore/errorreport"
+	"github.com/cloudfoundry/bosh-backup-and-restore/orchestrator"
+	"github.com/pkg/errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type exitError struct {
+	stderr   string
+	exitCode int
+}
+
+func (e exitError) Error() string  { return fmt.Sprintf("%s - exit code %d", e.stderr, e.exitCode) }
+func (e exitError) ExitCode() int  { return e.exitCode }
+func (e exitError) Stderr() string { return e.stderr }
+
+var _ = Describe("Report", func() {
+	var scriptError orchestrator.ScriptError
+
+	BeforeEach(func() {
+		scriptError = orchestrator.NewScriptError("redis/0", "redis-server", "backup",
+			errors.Wrap(exitError{stderr: "disk full", exitCode: 3}, "Error attempting to run backup for job redis-server on redis/0"))
+	})
+
+	Describe("New", func() {
+		It("describes each error with its category and deployment", func() {
+			report := errorreport.New("backup", []errorreport.DeploymentErrors{{
+				Deployment: "redis",
+				Errors: orchestrator.NewError(
+					orchestrator.NewDrainError("connection reset"),
+					orchestrator.NewCleanupError("could not remove the artifact directory"),
+				),
+			}}, "bbr deployment --deployment redis backup-cleanup")
+
+			Expect(report.Command).To(Equal("backup"))
+			Expect(report.ExitCode).To(Equal(81))
+			Expect(report.CleanupCommand).To(Equal("bbr deployment --deployment redis backup-cleanup"))
+			Expect(report.Errors).To(HaveLen(2))
+
+			Expect(report.Errors[0].Category).To(Equal(orchestrator.TransferCategory))
+			Expect(report.Errors[0].Message).To(Equal("connection reset"))
+			Expect(report.Errors[0].Deployment).To(Equal("redis"))
+			Expect(report.Errors[0].Instance).To(BeEmpty())
+
+			Expect(report.Errors[1].Category).To(Equal(orchestrator.CleanupCategory))
+			Expect(report.Errors[1].Message).To(Equal("could not remove the artifact directory"))
+		})
+
+		It("describes the instance, job, phase, exit code and stderr of failed scripts", func() {
+			report := errorreport.New("backup", []errorreport.DeploymentErrors{{
+				Deployment: "redis",
+				Errors:     orchestrator.NewError(scriptError),
+			}}, "")
+
+			Expect(report.Errors).To(ConsistOf(errorreport.Error{
+				Category:   orchestrator.OtherCategory,
+				Message:    "Error attempting to run backup for job redis-server on redis/0: disk full - exit code 3",
+				Deployment: "redis",
+				Instance:   "redis/0",
+				Job:        "redis-server",
+				Phase:      "backup",
+				ExitCode:   3,
+				Stderr:     "disk full",
+				StackTrace: "Error attempting to run backup for job redis-server on redis/0: disk full - exit code 3",
+			}))
+		})
+
+		It("keeps the stack trace of each error", func() {
+			report := errorreport.New("restore", []errorreport.DeploymentErrors{{
+				Deployment: "redis",
+				Errors:     orchestrator.NewError(errors.New("could not open backup")),
+			}}, "")
+
+			Expect(report.Errors[0].StackTrace).To(ContainSubstring("could not open backup"))
+			Expect(report.Errors[0].StackTrace).To(ContainSubstring("report_test.go"))
+		})
+
+		It("combines the errors of several deployments", func() {
+			report := errorreport.New("backup", []errorreport.DeploymentErrors{
+				{Deployment: "redis", Errors: orchestrator.NewError(orchestrator.NewLockError("could not lock"))},
+				{Deployment: "mysql", Errors: orchestrator.NewError(orchestrator.NewPreCheckError("no backup scripts"))},
+			}, "")
+
+			Expect(report.ExitCode).To(Equal(37))
+			Expect(report.Errors).To(HaveLen(2))
+			Expect(report.Errors[0].Deployment).To(Equal("redis"))
+			Expect(report.Errors[1].Deployment).To(Equal("mysql"))
+		})
+	})
+
+	Describe("Write", func() {
+		var dir string
+
+		BeforeEach(func() {
+			var err error
+			dir, err = os.MkdirTemp("", "error-report")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			Expect(os.RemoveAll(dir)).To(Succeed())
+		})
+
+		It("writes the report as JSON into the directory", func() {
+			report := errorreport.New("backup", []errorreport.DeploymentErrors{{
+				Deployment: "redis",
+				Errors:     orchestrator.NewError(scriptError),
+			}}, "bbr deployment --deployment redis backup-cleanup")
+
+			path, err := report.Write(dir, time.Date(2015, 10, 21, 1, 2, 3, 0, time.UTC))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(path).To(Equal(filepath.Join(dir, "bbr-2015-10-21T01:02:03Z.err.json")))
+
+			contents, err := os.ReadFile(path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(contents).To(MatchJSON(`{
+				"command": "backup",
+				"exit_code": 1,
+				"cleanup_command": "bbr deployment --deployment redis backup-cleanup",
+				"errors": [{
+					"category": "other",
+					"message": "Error attempting to run backup for job redis-server on redis/0: disk full - exit code 3",
+					"deployment": "redis",
+					"instance": "redis/0",
+					"job": "redis-server",
+					"phase": "backup",
+					"exit_code": 3,
+					"stderr": "disk full",
+					"stack_trace": "Error attempting to run backup for job redis-server on redis/0: disk full - exit code 3"
+				}]
+			}`))
+
+			var decoded errorreport.Report
+			Expect(json.Unmarshal(contents, &decoded)).To(Succeed())
+			Expect(decoded).To(Equal(report))
+		})
+
+		It("fails when the directory does not exist", func() {
+			_, err := errorreport.Report{}.Write(filepath.Join(dir, "missing"), time.Now())
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
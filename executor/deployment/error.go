@@ -2,10 +2,10 @@ package deployment
 
 import (
 	"fmt"
-	"os"
 	"strings"
 	"time"
 
+	"github.com/cloudfoundry/bosh-backup-and-restore/errorreport"
 	"github.com/cloudfoundry/bosh-backup-and-restore/orchestrator"
 	"github.com/urfave/cli"
 )
@@ -20,6 +20,11 @@ type DeploymentError struct {
 type AllDeploymentsError struct {
 	Summary        string
 	DeploymentErrs []DeploymentError
+	Command        string
+	ReportDir      string
+	// CleanupCommand is added to the error report when a footer advises a
+	// cleanup.
+	CleanupCommand string
 }
 
 func (a AllDeploymentsError) Error() string {
@@ -39,7 +44,7 @@ func (a AllDeploymentsError) ProcessWithFooter(footer string) error {
 		msgWithStackTrace = msgWithStackTrace + fmt.Sprintf("Deployment %s: %s\n", err.Deployment, err.Errs.PrettyError(true))
 	}
 
-	if writeStackTrace(msgWithStackTrace) != nil {
+	if a.writeReport(footer) != nil {
 		msg = msgWithStackTrace
 	}
 
@@ -47,17 +52,32 @@ func (a AllDeploymentsError) ProcessWithFooter(footer string) error {
 		msg = msg + "\n" + footer
 	}
 
-	return cli.NewExitError(msg, 1)
+	return cli.NewExitError(msg, orchestrator.BuildExitCode(a.errors()))
 }
 
-func writeStackTrace(errorWithStackTrace string) error {
-	if errorWithStackTrace != "" {
-		err := os.WriteFile(fmt.Sprintf("bbr-%s.err.log", time.Now().UTC().Format(time.RFC3339)), []byte(errorWithStackTrace), 0644)
-		if err != nil {
-			return err
-		}
+// errors combines the errors of every deployment, so that the exit code
+// reflects all of the failures.
+func (a AllDeploymentsError) errors() orchestrator.Error {
+	var errs []error
+	for _, err := range a.DeploymentErrs {
+		errs = append(errs, err.Errs...)
+	}
+	return orchestrator.NewError(errs...)
+}
+
+func (a AllDeploymentsError) writeReport(footer string) error {
+	cleanupCommand := ""
+	if footer != "" {
+		cleanupCommand = a.CleanupCommand
 	}
-	return nil
+
+	var deploymentErrs []errorreport.DeploymentErrors
+	for _, err := range a.DeploymentErrs {
+		deploymentErrs = append(deploymentErrs, errorreport.DeploymentErrors{Deployment: err.Deployment, Errors: err.Errs})
+	}
+
+	_, err := errorreport.New(a.Command, deploymentErrs, cleanupCommand).Write(a.ReportDir, time.Now())
+	return err
 }
 
 func IndentBlock(block string) string {
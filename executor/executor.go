@@ -1,12 +1,14 @@
 package executor
 
+import "context"
+
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
 //counterfeiter:generate -o fakes/fake_executor.go . Executor
 type Executor interface {
-	Run([][]Executable) []error
+	Run(context.Context, [][]Executable) []error
 }
 
 //counterfeiter:generate -o fakes/fake_executable.go . Executable
 type Executable interface {
-	Execute() error
+	Execute(context.Context) error
 }
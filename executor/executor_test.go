@@ -1,6 +1,10 @@
 package executor_test
 
 import (
+	"context"
+	"sync/atomic"
+	"time"
+
 	. "github.com/cloudfoundry/bosh-backup-and-restore/executor"
 
 	"github.com/cloudfoundry/bosh-backup-and-restore/executor/fakes"
@@ -15,35 +19,39 @@ var _ = Describe("Executor", func() {
 			var errs []error
 			var executable1, executable2, executable3, executable4 *fakes.FakeExecutable
 			var orderOfExecution []string
+			var ctx context.Context
 
 			BeforeEach(func() {
+				ctx = context.Background()
+				orderOfExecution = nil
+
 				executable1 = new(fakes.FakeExecutable)
-				executable1.ExecuteStub = func() error {
+				executable1.ExecuteStub = func(context.Context) error {
 					orderOfExecution = append(orderOfExecution, "executable1")
 					return nil
 				}
 
 				executable2 = new(fakes.FakeExecutable)
-				executable2.ExecuteStub = func() error {
+				executable2.ExecuteStub = func(context.Context) error {
 					orderOfExecution = append(orderOfExecution, "executable2")
 					return nil
 				}
 
 				executable3 = new(fakes.FakeExecutable)
-				executable3.ExecuteStub = func() error {
+				executable3.ExecuteStub = func(context.Context) error {
 					orderOfExecution = append(orderOfExecution, "executable3")
 					return nil
 				}
 
 				executable4 = new(fakes.FakeExecutable)
-				executable4.ExecuteStub = func() error {
+				executable4.ExecuteStub = func(context.Context) error {
 					orderOfExecution = append(orderOfExecution, "executable4")
 					return nil
 				}
 			})
 
 			JustBeforeEach(func() {
-				errs = executor.Run([][]Executable{
+				errs = executor.Run(ctx, [][]Executable{
 					{executable1},
 					{executable2, executable3},
 					{executable4},
@@ -81,9 +89,63 @@ var _ = Describe("Executor", func() {
 					Expect(executable4.ExecuteCallCount()).To(Equal(1))
 				})
 			})
+
+			It("passes the context to the executables", func() {
+				Expect(executable1.ExecuteArgsForCall(0)).To(Equal(ctx))
+				Expect(executable4.ExecuteArgsForCall(0)).To(Equal(ctx))
+			})
+
+			Context("when the context is cancelled while an executable runs", func() {
+				BeforeEach(func() {
+					var cancel context.CancelCauseFunc
+					ctx, cancel = context.WithCancelCause(context.Background())
+					executable1.ExecuteStub = func(context.Context) error {
+						orderOfExecution = append(orderOfExecution, "executable1")
+						cancel(errors.New("received terminated"))
+						return nil
+					}
+				})
+
+				It("does not start the remaining executables and returns the cause", func() {
+					Expect(errs).To(ConsistOf(MatchError("received terminated")))
+
+					Expect(executable1.ExecuteCallCount()).To(Equal(1))
+					Expect(executable2.ExecuteCallCount()).To(Equal(0))
+					Expect(executable3.ExecuteCallCount()).To(Equal(0))
+					Expect(executable4.ExecuteCallCount()).To(Equal(0))
+				})
+			})
 		})
 	}
 
 	ExecutorTests("SerialExecutor", NewSerialExecutor())
 	ExecutorTests("ParallelExecutor", NewParallelExecutor())
+
+	Describe("ParallelExecutor with a max in flight", func() {
+		It("runs at most that many executables at once", func() {
+			var running, maxRunning int32
+			var executables []Executable
+			for i := 0; i < 5; i++ {
+				executable := new(fakes.FakeExecutable)
+				executable.ExecuteStub = func(context.Context) error {
+					current := atomic.AddInt32(&running, 1)
+					for {
+						highest := atomic.LoadInt32(&maxRunning)
+						if current <= highest || atomic.CompareAndSwapInt32(&maxRunning, highest, current) {
+							break
+						}
+					}
+					time.Sleep(20 * time.Millisecond)
+					atomic.AddInt32(&running, -1)
+					return nil
+				}
+				executables = append(executables, executable)
+			}
+
+			errs := NewParallelExecutorWithMaxInFlight(2).Run(context.Background(), [][]Executable{executables})
+
+			Expect(errs).To(BeEmpty())
+			Expect(atomic.LoadInt32(&maxRunning)).To(Equal(int32(2)))
+		})
+	})
 })
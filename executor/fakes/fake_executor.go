@@ -2,16 +2,18 @@
 package fakes
 
 import (
+	"context"
 	"sync"
 
 	"github.com/cloudfoundry/bosh-backup-and-restore/executor"
 )
 
 type FakeExecutor struct {
-	RunStub        func([][]executor.Executable) []error
+	RunStub        func(context.Context, [][]executor.Executable) []error
 	runMutex       sync.RWMutex
 	runArgsForCall []struct {
-		arg1 [][]executor.Executable
+		arg1 context.Context
+		arg2 [][]executor.Executable
 	}
 	runReturns struct {
 		result1 []error
@@ -23,23 +25,24 @@ type FakeExecutor struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeExecutor) Run(arg1 [][]executor.Executable) []error {
-	var arg1Copy [][]executor.Executable
-	if arg1 != nil {
-		arg1Copy = make([][]executor.Executable, len(arg1))
-		copy(arg1Copy, arg1)
+func (fake *FakeExecutor) Run(arg1 context.Context, arg2 [][]executor.Executable) []error {
+	var arg2Copy [][]executor.Executable
+	if arg2 != nil {
+		arg2Copy = make([][]executor.Executable, len(arg2))
+		copy(arg2Copy, arg2)
 	}
 	fake.runMutex.Lock()
 	ret, specificReturn := fake.runReturnsOnCall[len(fake.runArgsForCall)]
 	fake.runArgsForCall = append(fake.runArgsForCall, struct {
-		arg1 [][]executor.Executable
-	}{arg1Copy})
+		arg1 context.Context
+		arg2 [][]executor.Executable
+	}{arg1, arg2Copy})
 	stub := fake.RunStub
 	fakeReturns := fake.runReturns
-	fake.recordInvocation("Run", []interface{}{arg1Copy})
+	fake.recordInvocation("Run", []interface{}{arg1, arg2Copy})
 	fake.runMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
@@ -53,17 +56,17 @@ func (fake *FakeExecutor) RunCallCount() int {
 	return len(fake.runArgsForCall)
 }
 
-func (fake *FakeExecutor) RunCalls(stub func([][]executor.Executable) []error) {
+func (fake *FakeExecutor) RunCalls(stub func(context.Context, [][]executor.Executable) []error) {
 	fake.runMutex.Lock()
 	defer fake.runMutex.Unlock()
 	fake.RunStub = stub
 }
 
-func (fake *FakeExecutor) RunArgsForCall(i int) [][]executor.Executable {
+func (fake *FakeExecutor) RunArgsForCall(i int) (context.Context, [][]executor.Executable) {
 	fake.runMutex.RLock()
 	defer fake.runMutex.RUnlock()
 	argsForCall := fake.runArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeExecutor) RunReturns(result1 []error) {
@@ -1,8 +1,18 @@
 package executor
 
+import "context"
+
+const DefaultMaxInFlight = 10
+
 func NewParallelExecutor() ParallelExecutor {
+	return NewParallelExecutorWithMaxInFlight(DefaultMaxInFlight)
+}
+
+// NewParallelExecutorWithMaxInFlight runs at most maxInFlight executables of
+// a group at once.
+func NewParallelExecutorWithMaxInFlight(maxInFlight int) ParallelExecutor {
 	return ParallelExecutor{
-		maxInFlight: 10,
+		maxInFlight: maxInFlight,
 	}
 }
 
@@ -14,26 +24,39 @@ func (s ParallelExecutor) SetMaxInFlight(maxInFlight int) {
 	s.maxInFlight = maxInFlight //nolint:ineffassign,staticcheck
 }
 
-func (s ParallelExecutor) Run(executablesList [][]Executable) []error {
+// Run executes each group of executables in parallel, waiting for a group to
+// finish before starting the next. Once ctx is done, no further executables
+// are started and the cause of the cancellation is returned alongside the
+// errors of those already running.
+func (s ParallelExecutor) Run(ctx context.Context, executablesList [][]Executable) []error {
 	var errors []error
 	for _, executables := range executablesList {
 		guard := make(chan bool, s.maxInFlight)
 		errs := make(chan error, len(executables))
 
+		started := 0
 		for _, executable := range executables {
 			guard <- true
+			if ctx.Err() != nil {
+				break
+			}
+			started++
 			go func(executable Executable) {
-				errs <- executable.Execute()
+				errs <- executable.Execute(ctx)
 				<-guard
 			}(executable)
 		}
 
-		for range executables {
+		for i := 0; i < started; i++ {
 			err := <-errs
 			if err != nil {
 				errors = append(errors, err)
 			}
 		}
+
+		if ctx.Err() != nil {
+			return append(errors, context.Cause(ctx))
+		}
 	}
 
 	return errors
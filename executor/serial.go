@@ -1,5 +1,7 @@
 package executor
 
+import "context"
+
 func NewSerialExecutor() SerialExecutor {
 	return SerialExecutor{}
 }
@@ -7,11 +9,17 @@ func NewSerialExecutor() SerialExecutor {
 type SerialExecutor struct {
 }
 
-func (s SerialExecutor) Run(executablesList [][]Executable) []error {
+// Run executes the executables one after another. Once ctx is done, the
+// remaining executables are not started and the cause of the cancellation
+// is returned alongside any earlier errors.
+func (s SerialExecutor) Run(ctx context.Context, executablesList [][]Executable) []error {
 	var errors []error
 	for _, executables := range executablesList {
 		for _, executable := range executables {
-			if err := executable.Execute(); err != nil {
+			if ctx.Err() != nil {
+				return append(errors, context.Cause(ctx))
+			}
+			if err := executable.Execute(ctx); err != nil {
 				errors = append(errors, err)
 			}
 		}
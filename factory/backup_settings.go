@@ -0,0 +1,30 @@
+package factory
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+)
+
+// BackupArtifactDir is the directory in artifactPath that the backup of
+// deploymentName taken at timestamp is written to.
+func BackupArtifactDir(artifactPath, deploymentName, timestamp string) string {
+	return filepath.Join(artifactPath, fmt.Sprintf("%s_%s", deploymentName, timestamp))
+}
+
+// ValidateArtifactStreams rejects stream counts that would leave nothing to
+// download artifacts with.
+func ValidateArtifactStreams(artifactStreams int) error {
+	if artifactStreams < 1 {
+		return errors.New("artifact streams must be at least 1")
+	}
+	return nil
+}
+
+// ValidateConcurrency rejects values that would leave no instance to back up.
+func ValidateConcurrency(concurrency int) error {
+	if concurrency < 1 {
+		return errors.New("concurrency must be at least 1")
+	}
+	return nil
+}
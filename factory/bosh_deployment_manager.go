@@ -2,13 +2,14 @@ package factory
 
 import (
 	"github.com/cloudfoundry/bosh-backup-and-restore/bosh"
+	"github.com/cloudfoundry/bosh-backup-and-restore/ssh"
 	boshlog "github.com/cloudfoundry/bosh-utils/logger"
 
 	boshcmd "github.com/cloudfoundry/bosh-cli/v7/cmd/opts"
 	boshsys "github.com/cloudfoundry/bosh-utils/system"
 )
 
-func BuildBoshClient(targetUrl, username, password, caCertPathOrValue, bbrVersion string, logger boshlog.Logger) (bosh.Client, error) {
+func BuildBoshClient(targetUrl, username, password, caCertPathOrValue, bbrVersion string, proxyJump []ssh.JumpRoute, logger boshlog.Logger) (bosh.Client, error) {
 	var boshClient bosh.Client
 	var err error
 	fs := boshsys.NewOsFileSystem(logger)
@@ -20,7 +21,7 @@ func BuildBoshClient(targetUrl, username, password, caCertPathOrValue, bbrVersio
 		return boshClient, err
 	}
 
-	boshClient, err = bosh.BuildClient(targetUrl, username, password, caCertArg.Content, bbrVersion, logger)
+	boshClient, err = bosh.BuildClientWithProxyJump(targetUrl, username, password, caCertArg.Content, bbrVersion, proxyJump, logger)
 	if err != nil {
 		return boshClient, err
 	}
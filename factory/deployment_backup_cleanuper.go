@@ -5,6 +5,7 @@ import (
 	"github.com/cloudfoundry/bosh-backup-and-restore/executor"
 	"github.com/cloudfoundry/bosh-backup-and-restore/orchestrator"
 	"github.com/cloudfoundry/bosh-backup-and-restore/orderer"
+	"github.com/cloudfoundry/bosh-backup-and-restore/ssh"
 	"github.com/cloudfoundry/bosh-utils/logger"
 )
 
@@ -14,10 +15,11 @@ func BuildDeploymentBackupCleanuper(
 	password,
 	caCert,
 	bbrVersion string,
+	proxyJump []ssh.JumpRoute,
 	logger logger.Logger,
 ) (*orchestrator.BackupCleaner, error) {
 
-	boshClient, err := BuildBoshClient(target, username, password, caCert, bbrVersion, logger)
+	boshClient, err := BuildBoshClient(target, username, password, caCert, bbrVersion, proxyJump, logger)
 
 	if err != nil {
 		return nil, err
@@ -6,8 +6,10 @@ import (
 	"github.com/cloudfoundry/bosh-backup-and-restore/backup"
 	"github.com/cloudfoundry/bosh-backup-and-restore/bosh"
 	"github.com/cloudfoundry/bosh-backup-and-restore/executor"
+	"github.com/cloudfoundry/bosh-backup-and-restore/hook"
 	"github.com/cloudfoundry/bosh-backup-and-restore/orchestrator"
 	"github.com/cloudfoundry/bosh-backup-and-restore/orderer"
+	"github.com/cloudfoundry/bosh-backup-and-restore/ssh"
 	boshlog "github.com/cloudfoundry/bosh-utils/logger"
 )
 
@@ -16,18 +18,23 @@ func BuildDeploymentBackuper(
 	username,
 	password,
 	caCert string,
+	proxyJump []ssh.JumpRoute,
 	withManifest bool,
 	unsafeLockFree bool,
 	bbrVersion string,
 	logger boshlog.Logger,
 	timestamp string,
+	hooks orchestrator.Hooks,
+	checkDiskSpace bool,
+	artifactStreams int,
+	maxInFlight int,
 ) (*orchestrator.Backuper, error) {
-	boshClient, err := BuildBoshClient(target, username, password, caCert, bbrVersion, logger)
+	boshClient, err := BuildBoshClient(target, username, password, caCert, bbrVersion, proxyJump, logger)
 	if err != nil {
 		return nil, err
 	}
 
-	execr := executor.NewParallelExecutor()
+	execr := executor.NewParallelExecutorWithMaxInFlight(maxInFlight)
 
 	return orchestrator.NewBackuper(
 		backup.BackupDirectoryManager{},
@@ -36,8 +43,19 @@ func BuildDeploymentBackuper(
 		orderer.NewKahnBackupLockOrderer(),
 		execr,
 		time.Now,
-		orchestrator.NewArtifactCopier(execr, logger),
+		orchestrator.NewArtifactCopierWithStreams(execr, artifactStreams, logger),
 		unsafeLockFree,
 		timestamp,
+		hooks,
+		hook.NewLocalRunner(logger),
+		artifactSpace(checkDiskSpace),
 	), nil
 }
+
+// artifactSpace enables the disk space pre-check of backups when it is asked for.
+func artifactSpace(checkDiskSpace bool) orchestrator.ArtifactSpace {
+	if checkDiskSpace {
+		return backup.BackupDirectoryManager{}
+	}
+	return nil
+}
@@ -0,0 +1,17 @@
+package factory
+
+import (
+	"github.com/cloudfoundry/bosh-backup-and-restore/backup"
+	"github.com/cloudfoundry/bosh-backup-and-restore/bosh"
+	"github.com/cloudfoundry/bosh-backup-and-restore/orchestrator"
+	"github.com/cloudfoundry/bosh-backup-and-restore/orderer"
+)
+
+func BuildDeploymentRestoreChecker(boshClient bosh.Client, logger bosh.Logger) *orchestrator.RestoreChecker {
+	return orchestrator.NewRestoreChecker(
+		backup.BackupDirectoryManager{},
+		logger,
+		bosh.NewDeploymentManager(boshClient, logger, false),
+		orderer.NewKahnRestoreLockOrderer(),
+	)
+}
@@ -5,6 +5,8 @@ import (
 	"github.com/cloudfoundry/bosh-backup-and-restore/executor"
 	"github.com/cloudfoundry/bosh-backup-and-restore/orchestrator"
 	"github.com/cloudfoundry/bosh-backup-and-restore/orderer"
+	"github.com/cloudfoundry/bosh-backup-and-restore/ssh"
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
 )
 
 func BuildDeploymentRestoreCleanuper(target,
@@ -12,10 +14,20 @@ func BuildDeploymentRestoreCleanuper(target,
 	password,
 	caCert,
 	bbrVersion string,
+	proxyJump []ssh.JumpRoute,
 	withManifest,
 	isDebug bool) (*orchestrator.RestoreCleaner, error) {
+	return BuildDeploymentRestoreCleanuperWithLogger(target, usename, password, caCert, bbrVersion, proxyJump, withManifest, BuildLogger(isDebug))
+}
 
-	logger := BuildLogger(isDebug)
+func BuildDeploymentRestoreCleanuperWithLogger(target,
+	usename,
+	password,
+	caCert,
+	bbrVersion string,
+	proxyJump []ssh.JumpRoute,
+	withManifest bool,
+	logger boshlog.Logger) (*orchestrator.RestoreCleaner, error) {
 
 	boshClient, err := BuildBoshClient(
 		target,
@@ -23,6 +35,7 @@ func BuildDeploymentRestoreCleanuper(target,
 		password,
 		caCert,
 		bbrVersion,
+		proxyJump,
 		logger,
 	)
 
@@ -4,18 +4,25 @@ import (
 	"github.com/cloudfoundry/bosh-backup-and-restore/backup"
 	"github.com/cloudfoundry/bosh-backup-and-restore/bosh"
 	"github.com/cloudfoundry/bosh-backup-and-restore/executor"
+	"github.com/cloudfoundry/bosh-backup-and-restore/hook"
 	"github.com/cloudfoundry/bosh-backup-and-restore/orchestrator"
 	"github.com/cloudfoundry/bosh-backup-and-restore/orderer"
+	"github.com/cloudfoundry/bosh-backup-and-restore/ssh"
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
 )
 
-func BuildDeploymentRestorer(target, username, password, caCert, bbrVersion string, debug bool) (*orchestrator.Restorer, error) {
-	logger := BuildLogger(debug)
+func BuildDeploymentRestorer(target, username, password, caCert, bbrVersion string, proxyJump []ssh.JumpRoute, debug bool, hooks orchestrator.Hooks) (*orchestrator.Restorer, error) {
+	return BuildDeploymentRestorerWithLogger(target, username, password, caCert, bbrVersion, proxyJump, BuildLogger(debug), hooks)
+}
+
+func BuildDeploymentRestorerWithLogger(target, username, password, caCert, bbrVersion string, proxyJump []ssh.JumpRoute, logger boshlog.Logger, hooks orchestrator.Hooks) (*orchestrator.Restorer, error) {
 	boshClient, err := BuildBoshClient(
 		target,
 		username,
 		password,
 		caCert,
 		bbrVersion,
+		proxyJump,
 		logger,
 	)
 	if err != nil {
@@ -29,5 +36,7 @@ func BuildDeploymentRestorer(target, username, password, caCert, bbrVersion stri
 		orderer.NewKahnRestoreLockOrderer(),
 		executor.NewSerialExecutor(),
 		orchestrator.NewArtifactCopier(executor.NewParallelExecutor(), logger),
+		hooks,
+		hook.NewLocalRunner(logger),
 	), nil
 }
@@ -6,16 +6,21 @@ import (
 	"github.com/cloudfoundry/bosh-backup-and-restore/orderer"
 	"github.com/cloudfoundry/bosh-backup-and-restore/ssh"
 	"github.com/cloudfoundry/bosh-backup-and-restore/standalone"
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
 )
 
-func BuildDirectorBackupChecker(host, username, privateKeyPath, bbrVersion string, hasDebug bool) *orchestrator.BackupChecker {
-	logger := BuildLogger(hasDebug)
+func BuildDirectorBackupChecker(host, username string, sshConfig standalone.SSHConfig, proxyJump []ssh.JumpRoute, bbrVersion string, hasDebug bool) *orchestrator.BackupChecker {
+	return BuildDirectorBackupCheckerWithLogger(host, username, sshConfig, proxyJump, bbrVersion, BuildLogger(hasDebug))
+}
+
+func BuildDirectorBackupCheckerWithLogger(host, username string, sshConfig standalone.SSHConfig, proxyJump []ssh.JumpRoute, bbrVersion string, logger boshlog.Logger) *orchestrator.BackupChecker {
 	deploymentManager := standalone.NewDeploymentManager(logger,
 		host,
 		username,
-		privateKeyPath,
+		sshConfig,
+		false,
 		instance.NewJobFinderOmitMetadataReleases(bbrVersion, logger),
-		ssh.NewSshRemoteRunner,
+		ssh.NewAuthenticatedRemoteRunnerFactory(proxyJump),
 	)
 
 	return orchestrator.NewBackupChecker(logger, deploymentManager, orderer.NewKahnBackupLockOrderer())
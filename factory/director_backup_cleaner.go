@@ -7,21 +7,32 @@ import (
 	"github.com/cloudfoundry/bosh-backup-and-restore/orderer"
 	"github.com/cloudfoundry/bosh-backup-and-restore/ssh"
 	"github.com/cloudfoundry/bosh-backup-and-restore/standalone"
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
 )
 
 func BuildDirectorBackupCleaner(host,
-	username,
-	privateKeyPath,
+	username string,
+	sshConfig standalone.SSHConfig,
+	proxyJump []ssh.JumpRoute,
 	bbrVersion string,
 	hasDebug bool) *orchestrator.BackupCleaner {
+	return BuildDirectorBackupCleanerWithLogger(host, username, sshConfig, proxyJump, bbrVersion, BuildLogger(hasDebug))
+}
+
+func BuildDirectorBackupCleanerWithLogger(host,
+	username string,
+	sshConfig standalone.SSHConfig,
+	proxyJump []ssh.JumpRoute,
+	bbrVersion string,
+	logger boshlog.Logger) *orchestrator.BackupCleaner {
 
-	logger := BuildLogger(hasDebug)
 	deploymentManager := standalone.NewDeploymentManager(logger,
 		host,
 		username,
-		privateKeyPath,
+		sshConfig,
+		false,
 		instance.NewJobFinderOmitMetadataReleases(bbrVersion, logger),
-		ssh.NewSshRemoteRunner,
+		ssh.NewAuthenticatedRemoteRunnerFactory(proxyJump),
 	)
 
 	return orchestrator.NewBackupCleaner(logger, deploymentManager, orderer.NewKahnBackupLockOrderer(), executor.NewParallelExecutor())
@@ -5,23 +5,29 @@ import (
 
 	"github.com/cloudfoundry/bosh-backup-and-restore/backup"
 	"github.com/cloudfoundry/bosh-backup-and-restore/executor"
+	"github.com/cloudfoundry/bosh-backup-and-restore/hook"
 	"github.com/cloudfoundry/bosh-backup-and-restore/instance"
 	"github.com/cloudfoundry/bosh-backup-and-restore/orchestrator"
 	"github.com/cloudfoundry/bosh-backup-and-restore/orderer"
 	"github.com/cloudfoundry/bosh-backup-and-restore/ssh"
 	"github.com/cloudfoundry/bosh-backup-and-restore/standalone"
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
 )
 
-func BuildDirectorBackuper(host, username, privateKeyPath, bbrVersion string, hasDebug bool, timeStamp string) *orchestrator.Backuper {
-	logger := BuildLogger(hasDebug)
+func BuildDirectorBackuper(host, username string, sshConfig standalone.SSHConfig, proxyJump []ssh.JumpRoute, bbrVersion string, hasDebug bool, timeStamp string, hooks orchestrator.Hooks, checkDiskSpace bool, artifactStreams int) *orchestrator.Backuper {
+	return BuildDirectorBackuperWithLogger(host, username, sshConfig, proxyJump, bbrVersion, BuildLogger(hasDebug), timeStamp, hooks, checkDiskSpace, artifactStreams)
+}
+
+func BuildDirectorBackuperWithLogger(host, username string, sshConfig standalone.SSHConfig, proxyJump []ssh.JumpRoute, bbrVersion string, logger boshlog.Logger, timeStamp string, hooks orchestrator.Hooks, checkDiskSpace bool, artifactStreams int) *orchestrator.Backuper {
 	deploymentManager := standalone.NewDeploymentManager(logger,
 		host,
 		username,
-		privateKeyPath,
+		sshConfig,
+		false,
 		instance.NewJobFinderOmitMetadataReleases(bbrVersion, logger),
-		ssh.NewSshRemoteRunner,
+		ssh.NewAuthenticatedRemoteRunnerFactory(proxyJump),
 	)
 	execr := executor.NewParallelExecutor()
 
-	return orchestrator.NewBackuper(backup.BackupDirectoryManager{}, logger, deploymentManager, orderer.NewKahnBackupLockOrderer(), execr, time.Now, orchestrator.NewArtifactCopier(execr, logger), false, timeStamp)
+	return orchestrator.NewBackuper(backup.BackupDirectoryManager{}, logger, deploymentManager, orderer.NewKahnBackupLockOrderer(), execr, time.Now, orchestrator.NewArtifactCopierWithStreams(execr, artifactStreams, logger), false, timeStamp, hooks, hook.NewLocalRunner(logger), artifactSpace(checkDiskSpace))
 }
@@ -7,22 +7,32 @@ import (
 	"github.com/cloudfoundry/bosh-backup-and-restore/orderer"
 	"github.com/cloudfoundry/bosh-backup-and-restore/ssh"
 	"github.com/cloudfoundry/bosh-backup-and-restore/standalone"
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
 )
 
 func BuildDirectorRestoreCleaner(host,
-	username,
-	privateKeyPath,
+	username string,
+	sshConfig standalone.SSHConfig,
+	proxyJump []ssh.JumpRoute,
 	bbrVersion string,
 	hasDebug bool) *orchestrator.RestoreCleaner {
+	return BuildDirectorRestoreCleanerWithLogger(host, username, sshConfig, proxyJump, bbrVersion, BuildLogger(hasDebug))
+}
 
-	logger := BuildLogger(hasDebug)
+func BuildDirectorRestoreCleanerWithLogger(host,
+	username string,
+	sshConfig standalone.SSHConfig,
+	proxyJump []ssh.JumpRoute,
+	bbrVersion string,
+	logger boshlog.Logger) *orchestrator.RestoreCleaner {
 
 	deploymentManager := standalone.NewDeploymentManager(logger,
 		host,
 		username,
-		privateKeyPath,
+		sshConfig,
+		false,
 		instance.NewJobFinderOmitMetadataReleases(bbrVersion, logger),
-		ssh.NewSshRemoteRunner,
+		ssh.NewAuthenticatedRemoteRunnerFactory(proxyJump),
 	)
 
 	return orchestrator.NewRestoreCleaner(logger, deploymentManager, orderer.NewKahnRestoreLockOrderer(), executor.NewSerialExecutor())
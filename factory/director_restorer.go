@@ -3,21 +3,27 @@ package factory
 import (
 	"github.com/cloudfoundry/bosh-backup-and-restore/backup"
 	"github.com/cloudfoundry/bosh-backup-and-restore/executor"
+	"github.com/cloudfoundry/bosh-backup-and-restore/hook"
 	"github.com/cloudfoundry/bosh-backup-and-restore/instance"
 	"github.com/cloudfoundry/bosh-backup-and-restore/orchestrator"
 	"github.com/cloudfoundry/bosh-backup-and-restore/orderer"
 	"github.com/cloudfoundry/bosh-backup-and-restore/ssh"
 	"github.com/cloudfoundry/bosh-backup-and-restore/standalone"
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
 )
 
-func BuildDirectorRestorer(host, username, privateKeyPath, bbrVersion string, hasDebug bool) *orchestrator.Restorer {
-	logger := BuildLogger(hasDebug)
+func BuildDirectorRestorer(host, username string, sshConfig standalone.SSHConfig, proxyJump []ssh.JumpRoute, allowDifferentDirector bool, bbrVersion string, hasDebug bool, hooks orchestrator.Hooks) *orchestrator.Restorer {
+	return BuildDirectorRestorerWithLogger(host, username, sshConfig, proxyJump, allowDifferentDirector, bbrVersion, BuildLogger(hasDebug), hooks)
+}
+
+func BuildDirectorRestorerWithLogger(host, username string, sshConfig standalone.SSHConfig, proxyJump []ssh.JumpRoute, allowDifferentDirector bool, bbrVersion string, logger boshlog.Logger, hooks orchestrator.Hooks) *orchestrator.Restorer {
 	deploymentManager := standalone.NewDeploymentManager(logger,
 		host,
 		username,
-		privateKeyPath,
+		sshConfig,
+		allowDifferentDirector,
 		instance.NewJobFinderOmitMetadataReleases(bbrVersion, logger),
-		ssh.NewSshRemoteRunner,
+		ssh.NewAuthenticatedRemoteRunnerFactory(proxyJump),
 	)
 
 	return orchestrator.NewRestorer(
@@ -27,5 +33,7 @@ func BuildDirectorRestorer(host, username, privateKeyPath, bbrVersion string, ha
 		orderer.NewKahnRestoreLockOrderer(),
 		executor.NewSerialExecutor(),
 		orchestrator.NewArtifactCopier(executor.NewParallelExecutor(), logger),
+		hooks,
+		hook.NewLocalRunner(logger),
 	)
 }
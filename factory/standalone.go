@@ -0,0 +1,69 @@
+package factory
+
+import (
+	"time"
+
+	"github.com/cloudfoundry/bosh-backup-and-restore/backup"
+	"github.com/cloudfoundry/bosh-backup-and-restore/executor"
+	"github.com/cloudfoundry/bosh-backup-and-restore/hook"
+	"github.com/cloudfoundry/bosh-backup-and-restore/instance"
+	"github.com/cloudfoundry/bosh-backup-and-restore/orchestrator"
+	"github.com/cloudfoundry/bosh-backup-and-restore/orderer"
+	"github.com/cloudfoundry/bosh-backup-and-restore/ssh"
+	"github.com/cloudfoundry/bosh-backup-and-restore/standalone"
+)
+
+func BuildStandaloneBackuper(inventory standalone.Inventory, proxyJump []ssh.JumpRoute, bbrVersion string, hasDebug bool, timeStamp string, hooks orchestrator.Hooks, checkDiskSpace bool, artifactStreams int) *orchestrator.Backuper {
+	logger := BuildLogger(hasDebug)
+	deploymentManager := buildInventoryDeploymentManager(logger, inventory, proxyJump, bbrVersion)
+	execr := executor.NewParallelExecutor()
+
+	return orchestrator.NewBackuper(backup.BackupDirectoryManager{}, logger, deploymentManager,
+		standalone.NewInventoryLockOrderer(inventory, orderer.NewKahnBackupLockOrderer()), execr, time.Now,
+		orchestrator.NewArtifactCopierWithStreams(execr, artifactStreams, logger), false, timeStamp, hooks, hook.NewLocalRunner(logger), artifactSpace(checkDiskSpace))
+}
+
+func BuildStandaloneRestorer(inventory standalone.Inventory, proxyJump []ssh.JumpRoute, bbrVersion string, hasDebug bool, hooks orchestrator.Hooks) *orchestrator.Restorer {
+	logger := BuildLogger(hasDebug)
+	deploymentManager := buildInventoryDeploymentManager(logger, inventory, proxyJump, bbrVersion)
+
+	return orchestrator.NewRestorer(
+		backup.BackupDirectoryManager{},
+		logger,
+		deploymentManager,
+		standalone.NewInventoryLockOrderer(inventory, orderer.NewKahnRestoreLockOrderer()),
+		executor.NewSerialExecutor(),
+		orchestrator.NewArtifactCopier(executor.NewParallelExecutor(), logger),
+		hooks,
+		hook.NewLocalRunner(logger),
+	)
+}
+
+func BuildStandaloneBackupChecker(inventory standalone.Inventory, proxyJump []ssh.JumpRoute, bbrVersion string, hasDebug bool) *orchestrator.BackupChecker {
+	logger := BuildLogger(hasDebug)
+	deploymentManager := buildInventoryDeploymentManager(logger, inventory, proxyJump, bbrVersion)
+
+	return orchestrator.NewBackupChecker(logger, deploymentManager, standalone.NewInventoryLockOrderer(inventory, orderer.NewKahnBackupLockOrderer()))
+}
+
+func BuildStandaloneBackupCleaner(inventory standalone.Inventory, proxyJump []ssh.JumpRoute, bbrVersion string, hasDebug bool) *orchestrator.BackupCleaner {
+	logger := BuildLogger(hasDebug)
+	deploymentManager := buildInventoryDeploymentManager(logger, inventory, proxyJump, bbrVersion)
+
+	return orchestrator.NewBackupCleaner(logger, deploymentManager, standalone.NewInventoryLockOrderer(inventory, orderer.NewKahnBackupLockOrderer()), executor.NewParallelExecutor())
+}
+
+func BuildStandaloneRestoreCleaner(inventory standalone.Inventory, proxyJump []ssh.JumpRoute, bbrVersion string, hasDebug bool) *orchestrator.RestoreCleaner {
+	logger := BuildLogger(hasDebug)
+	deploymentManager := buildInventoryDeploymentManager(logger, inventory, proxyJump, bbrVersion)
+
+	return orchestrator.NewRestoreCleaner(logger, deploymentManager, standalone.NewInventoryLockOrderer(inventory, orderer.NewKahnRestoreLockOrderer()), executor.NewSerialExecutor())
+}
+
+func buildInventoryDeploymentManager(logger orchestrator.Logger, inventory standalone.Inventory, proxyJump []ssh.JumpRoute, bbrVersion string) standalone.InventoryDeploymentManager {
+	return standalone.NewInventoryDeploymentManager(logger,
+		inventory,
+		instance.NewJobFinderOmitMetadataReleases(bbrVersion, logger),
+		ssh.NewAuthenticatedRemoteRunnerFactory(proxyJump),
+	)
+}
@@ -0,0 +1,13 @@
+package hook_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestHook(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Hook Suite")
+}
@@ -0,0 +1,41 @@
+package hook
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/cloudfoundry/bosh-backup-and-restore/orchestrator"
+	"github.com/pkg/errors"
+)
+
+const hookWaitDelay = time.Second
+
+type LocalRunner struct {
+	logger orchestrator.Logger
+}
+
+// NewLocalRunner returns a runner for hook commands on the machine bbr is
+// running on. Commands are interpreted by sh so that operators can use pipes
+// and redirection.
+func NewLocalRunner(logger orchestrator.Logger) LocalRunner {
+	return LocalRunner{logger: logger}
+}
+
+// Run kills the command when ctx is cancelled. Children that sh leaves
+// behind get hookWaitDelay to exit before their output is abandoned.
+func (r LocalRunner) Run(ctx context.Context, command string, env []string) error {
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Env = append(os.Environ(), env...)
+	cmd.WaitDelay = hookWaitDelay
+
+	output, err := cmd.CombinedOutput()
+	r.logger.Debug("bbr", "Hook command `%s` output: %s", command, output)
+	if err != nil {
+		return errors.Wrapf(err, "output: %s", strings.TrimSpace(string(output)))
+	}
+
+	return nil
+}
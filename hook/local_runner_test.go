@@ -0,0 +1,61 @@
+package hook_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/cloudfoundry/bosh-backup-and-restore/hook"
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LocalRunner", func() {
+	var (
+		logOutput *bytes.Buffer
+		runner    hook.LocalRunner
+	)
+
+	BeforeEach(func() {
+		logOutput = new(bytes.Buffer)
+		runner = hook.NewLocalRunner(boshlog.NewWriterLogger(boshlog.LevelDebug, logOutput))
+	})
+
+	It("runs the command with the given environment in a shell", func() {
+		outputFile := filepath.Join(GinkgoT().TempDir(), "output")
+
+		err := runner.Run(context.Background(), `echo "$BBR_DEPLOYMENT $BBR_ARTIFACT_PATH" > `+outputFile, []string{"BBR_DEPLOYMENT=redis", "BBR_ARTIFACT_PATH=/backups/redis"})
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(os.ReadFile(outputFile)).To(Equal([]byte("redis /backups/redis\n")))
+	})
+
+	It("logs the output of the command", func() {
+		Expect(runner.Run(context.Background(), "echo snapshot taken", nil)).To(Succeed())
+		Expect(logOutput.String()).To(ContainSubstring("snapshot taken"))
+	})
+
+	Context("when the command fails", func() {
+		It("returns an error including the output of the command", func() {
+			err := runner.Run(context.Background(), "echo scheduler unreachable >&2; exit 3", nil)
+			Expect(err).To(MatchError(ContainSubstring("exit status 3")))
+			Expect(err).To(MatchError(ContainSubstring("scheduler unreachable")))
+		})
+	})
+
+	Context("when the context is cancelled", func() {
+		It("kills the command", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+
+			start := time.Now()
+			err := runner.Run(ctx, "sleep 10", nil)
+
+			Expect(err).To(MatchError(ContainSubstring("signal: killed")))
+			Expect(time.Since(start)).To(BeNumerically("<", 5*time.Second))
+		})
+	})
+})
@@ -1,6 +1,7 @@
 package instance
 
 import (
+	"context"
 	"fmt"
 	"io"
 
@@ -62,9 +63,9 @@ type Artifact struct {
 	remoteRunner ssh.RemoteRunner
 }
 
-func (b *Artifact) StreamFromRemote(writer io.Writer) error {
+func (b *Artifact) StreamFromRemote(ctx context.Context, writer io.Writer) error {
 	b.Logger.Debug("bbr", "Streaming backup from instance %s/%s", b.instance.Name(), b.instance.ID()) //nolint:staticcheck
-	err := b.remoteRunner.ArchiveAndDownload(b.artifactDirectory, writer)
+	err := b.remoteRunner.ArchiveAndDownload(ctx, b.artifactDirectory, writer)
 	if err != nil {
 		return errors.Wrap(err, fmt.Sprintf("Error streaming backup from remote instance. Error: %s", err.Error()))
 	}
@@ -72,20 +73,30 @@ func (b *Artifact) StreamFromRemote(writer io.Writer) error {
 	return nil
 }
 
-func (b *Artifact) StreamToRemote(reader io.Reader) error {
-	err := b.remoteRunner.CreateDirectory(b.artifactDirectory)
+func (b *Artifact) StreamFilesFromRemote(ctx context.Context, files []string, writer io.Writer) error {
+	b.Logger.Debug("bbr", "Streaming %d files of backup from instance %s/%s", len(files), b.instance.Name(), b.instance.ID()) //nolint:staticcheck
+	err := b.remoteRunner.ArchiveFilesAndDownload(ctx, b.artifactDirectory, files, writer)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("Error streaming backup from remote instance. Error: %s", err.Error()))
+	}
+
+	return nil
+}
+
+func (b *Artifact) StreamToRemote(ctx context.Context, reader io.Reader) error {
+	err := b.remoteRunner.CreateDirectory(ctx, b.artifactDirectory)
 	if err != nil {
 		return errors.Wrap(err, "Creating backup directory on the remote failed")
 	}
 
 	b.Logger.Debug("bbr", "Streaming backup to instance %s/%s", b.instance.Name(), b.instance.ID()) //nolint:staticcheck
-	return b.remoteRunner.ExtractAndUpload(reader, b.artifactDirectory)
+	return b.remoteRunner.ExtractAndUpload(ctx, reader, b.artifactDirectory)
 }
 
-func (b *Artifact) Size() (string, error) {
+func (b *Artifact) Size(ctx context.Context) (string, error) {
 	b.Logger.Debug("bbr", "Calculating size of backup on %s/%s", b.instance.Name(), b.instance.ID()) //nolint:staticcheck
 
-	size, err := b.remoteRunner.SizeOf(b.artifactDirectory)
+	size, err := b.remoteRunner.SizeOf(ctx, b.artifactDirectory)
 	if err != nil {
 		return "", errors.Wrap(err, fmt.Sprintf("Unable to check size of %s", b.artifactDirectory))
 	}
@@ -93,18 +104,26 @@ func (b *Artifact) Size() (string, error) {
 	return size, nil
 }
 
-func (b *Artifact) SizeInBytes() (int, error) {
-	size, err := b.remoteRunner.SizeInBytes(b.artifactDirectory)
+func (b *Artifact) SizeInBytes(ctx context.Context) (int, error) {
+	size, err := b.remoteRunner.SizeInBytes(ctx, b.artifactDirectory)
 	if err != nil {
 		return 0, errors.Wrap(err, fmt.Sprintf("Unable to check size of %s", b.artifactDirectory))
 	}
 	return size, nil
 }
 
-func (b *Artifact) Checksum() (orchestrator.BackupChecksum, error) {
+func (b *Artifact) FileSizesInBytes(ctx context.Context) (map[string]int, error) {
+	sizes, err := b.remoteRunner.FileSizesInBytes(ctx, b.artifactDirectory)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("Unable to list files in %s", b.artifactDirectory))
+	}
+	return sizes, nil
+}
+
+func (b *Artifact) Checksum(ctx context.Context) (orchestrator.BackupChecksum, error) {
 	b.Logger.Debug("bbr", "Calculating shasum for remote files on %s/%s", b.instance.Name(), b.instance.ID()) //nolint:staticcheck
 
-	backupChecksum, err := b.remoteRunner.ChecksumDirectory(b.artifactDirectory)
+	backupChecksum, err := b.remoteRunner.ChecksumDirectory(ctx, b.artifactDirectory)
 	if err != nil {
 		return nil, errors.Wrap(err, "Unable to calculate backup checksum")
 	}
@@ -112,10 +131,10 @@ func (b *Artifact) Checksum() (orchestrator.BackupChecksum, error) {
 	return backupChecksum, nil
 }
 
-func (b *Artifact) Delete() error {
+func (b *Artifact) Delete(ctx context.Context) error {
 	b.Logger.Debug("bbr", "Deleting artifact directory on %s/%s", b.instance.Name(), b.instance.ID()) //nolint:staticcheck
 
-	err := b.remoteRunner.RemoveDirectory(b.artifactDirectory)
+	err := b.remoteRunner.RemoveDirectory(ctx, b.artifactDirectory)
 	if err != nil {
 		return errors.Wrap(err, fmt.Sprintf("Unable to delete artifact directory on instance %s/%s", b.instance.Name(), b.instance.ID()))
 	}
@@ -2,6 +2,7 @@ package instance_test
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"log"
 
@@ -40,7 +41,7 @@ var _ = Describe("artifact", func() {
 			var writer = bytes.NewBufferString("dave")
 
 			JustBeforeEach(func() {
-				err = backupArtifact.StreamFromRemote(writer)
+				err = backupArtifact.StreamFromRemote(context.Background(), writer)
 			})
 
 			Describe("when successful", func() {
@@ -51,7 +52,7 @@ var _ = Describe("artifact", func() {
 				It("uses the remote runner to tar the backup and download it to the local machine", func() {
 					Expect(remoteRunner.ArchiveAndDownloadCallCount()).To(Equal(1))
 
-					dir, returnedWriter := remoteRunner.ArchiveAndDownloadArgsForCall(0)
+					_, dir, returnedWriter := remoteRunner.ArchiveAndDownloadArgsForCall(0)
 					Expect(dir).To(Equal(artifactDirectory))
 					Expect(returnedWriter).To(Equal(writer))
 				})
@@ -69,7 +70,7 @@ var _ = Describe("artifact", func() {
 				It("uses the remote runner to tar the backup and download it to the local machine", func() {
 					Expect(remoteRunner.ArchiveAndDownloadCallCount()).To(Equal(1))
 
-					dir, returnedWriter := remoteRunner.ArchiveAndDownloadArgsForCall(0)
+					_, dir, returnedWriter := remoteRunner.ArchiveAndDownloadArgsForCall(0)
 					Expect(dir).To(Equal(artifactDirectory))
 					Expect(returnedWriter).To(Equal(writer))
 				})
@@ -80,12 +81,58 @@ var _ = Describe("artifact", func() {
 			})
 		})
 
+		Describe("StreamFilesFromRemote", func() {
+			var err error
+			var writer = bytes.NewBufferString("dave")
+
+			JustBeforeEach(func() {
+				err = backupArtifact.StreamFilesFromRemote(context.Background(), []string{"./a", "./b"}, writer)
+			})
+
+			It("uses the remote runner to tar only those files and download them", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(remoteRunner.ArchiveFilesAndDownloadCallCount()).To(Equal(1))
+
+				_, dir, files, returnedWriter := remoteRunner.ArchiveFilesAndDownloadArgsForCall(0)
+				Expect(dir).To(Equal(artifactDirectory))
+				Expect(files).To(Equal([]string{"./a", "./b"}))
+				Expect(returnedWriter).To(Equal(writer))
+			})
+
+			Context("when there is an error in archive and download", func() {
+				BeforeEach(func() {
+					remoteRunner.ArchiveFilesAndDownloadReturns(fmt.Errorf("oh no, it broke"))
+				})
+
+				It("fails", func() {
+					Expect(err).To(MatchError(ContainSubstring("oh no, it broke")))
+				})
+			})
+		})
+
+		Describe("FileSizesInBytes", func() {
+			It("delegates to the remote runner", func() {
+				remoteRunner.FileSizesInBytesReturns(map[string]int{"./a": 1}, nil)
+
+				Expect(backupArtifact.FileSizesInBytes(context.Background())).To(Equal(map[string]int{"./a": 1}))
+				_, dir := remoteRunner.FileSizesInBytesArgsForCall(0)
+				Expect(dir).To(Equal(artifactDirectory))
+			})
+
+			It("wraps errors", func() {
+				remoteRunner.FileSizesInBytesReturns(nil, fmt.Errorf("find failed"))
+
+				_, err := backupArtifact.FileSizesInBytes(context.Background())
+				Expect(err).To(MatchError(ContainSubstring(fmt.Sprintf("Unable to list files in %s: find failed", artifactDirectory))))
+			})
+		})
+
 		Describe("BackupChecksum", func() {
 			var actualChecksum map[string]string
 			var actualChecksumError error
 
 			JustBeforeEach(func() {
-				actualChecksum, actualChecksumError = backupArtifact.Checksum()
+				actualChecksum, actualChecksumError = backupArtifact.Checksum(context.Background())
 			})
 
 			Context("can calculate checksum", func() {
@@ -100,7 +147,8 @@ var _ = Describe("artifact", func() {
 				})
 
 				It("generates the correct request", func() {
-					Expect(remoteRunner.ChecksumDirectoryArgsForCall(0)).To(Equal(artifactDirectory))
+					_, dir := remoteRunner.ChecksumDirectoryArgsForCall(0)
+					Expect(dir).To(Equal(artifactDirectory))
 				})
 
 				It("returns the checksum", func() {
@@ -124,7 +172,7 @@ var _ = Describe("artifact", func() {
 			var err error
 
 			JustBeforeEach(func() {
-				err = backupArtifact.Delete()
+				err = backupArtifact.Delete(context.Background())
 			})
 
 			It("succeeds", func() {
@@ -133,7 +181,8 @@ var _ = Describe("artifact", func() {
 
 			It("deletes only the named artifact directory on the remote", func() {
 				Expect(remoteRunner.RemoveDirectoryCallCount()).To(Equal(1))
-				Expect(remoteRunner.RemoveDirectoryArgsForCall(0)).To(Equal(artifactDirectory))
+				_, dir := remoteRunner.RemoveDirectoryArgsForCall(0)
+				Expect(dir).To(Equal(artifactDirectory))
 			})
 
 			Context("when there is an error from the remote runner", func() {
@@ -157,11 +206,12 @@ var _ = Describe("artifact", func() {
 			})
 			Context("when the remoteRunner can determine the size", func() {
 				It("delegates to the remoteRunner", func() {
-					size, err := backupArtifact.SizeInBytes()
+					size, err := backupArtifact.SizeInBytes(context.Background())
 					Expect(err).NotTo(HaveOccurred())
 					Expect(size).To(Equal(65537))
 					Expect(remoteRunner.SizeInBytesCallCount()).To(Equal(1))
-					Expect(remoteRunner.SizeInBytesArgsForCall(0)).To(Equal("some path"))
+					_, dir := remoteRunner.SizeInBytesArgsForCall(0)
+					Expect(dir).To(Equal("some path"))
 				})
 			})
 
@@ -171,7 +221,7 @@ var _ = Describe("artifact", func() {
 					backupArtifact.SetArtifactDirectory("my cool thing")
 				})
 				It("wraps the error and returns it", func() {
-					_, err := backupArtifact.SizeInBytes()
+					_, err := backupArtifact.SizeInBytes(context.Background())
 					Expect(err).To(MatchError(ContainSubstring("Unable to check size of my cool thing: I am completely broken")))
 				})
 			})
@@ -186,12 +236,13 @@ var _ = Describe("artifact", func() {
 				})
 
 				JustBeforeEach(func() {
-					size, _ = backupArtifact.Size() //nolint:errcheck
+					size, _ = backupArtifact.Size(context.Background()) //nolint:errcheck
 				})
 
 				It("returns the size of the backup according to the root user, as a string", func() {
 					Expect(remoteRunner.SizeOfCallCount()).To(Equal(1))
-					Expect(remoteRunner.SizeOfArgsForCall(0)).To(Equal(artifactDirectory))
+					_, dir := remoteRunner.SizeOfArgsForCall(0)
+					Expect(dir).To(Equal(artifactDirectory))
 					Expect(size).To(Equal("4.1G"))
 				})
 			})
@@ -204,12 +255,13 @@ var _ = Describe("artifact", func() {
 				})
 
 				JustBeforeEach(func() {
-					_, err = backupArtifact.Size()
+					_, err = backupArtifact.Size(context.Background())
 				})
 
 				It("returns the size of the backup according to the root user, as a string", func() {
 					Expect(remoteRunner.SizeOfCallCount()).To(Equal(1))
-					Expect(remoteRunner.SizeOfArgsForCall(0)).To(Equal(artifactDirectory))
+					_, dir := remoteRunner.SizeOfArgsForCall(0)
+					Expect(dir).To(Equal(artifactDirectory))
 					Expect(err).To(SatisfyAll(
 						MatchError(ContainSubstring("Unable to check size of "+artifactDirectory)),
 						MatchError(ContainSubstring("no backup directory or something")),
@@ -223,19 +275,19 @@ var _ = Describe("artifact", func() {
 			var reader = bytes.NewBufferString("dave")
 
 			JustBeforeEach(func() {
-				err = backupArtifact.StreamToRemote(reader)
+				err = backupArtifact.StreamToRemote(context.Background(), reader)
 			})
 
 			Describe("when successful", func() {
 				It("uses the remote runner to make the backup directory on the remote machine", func() {
 					Expect(remoteRunner.CreateDirectoryCallCount()).To(Equal(1))
-					dir := remoteRunner.CreateDirectoryArgsForCall(0)
+					_, dir := remoteRunner.CreateDirectoryArgsForCall(0)
 					Expect(dir).To(Equal(artifactDirectory))
 				})
 
 				It("uses the remote runner to stream files to the remote machine", func() {
 					Expect(remoteRunner.ExtractAndUploadCallCount()).To(Equal(1))
-					sentReader, dir := remoteRunner.ExtractAndUploadArgsForCall(0)
+					_, sentReader, dir := remoteRunner.ExtractAndUploadArgsForCall(0)
 					Expect(dir).To(Equal(artifactDirectory))
 					Expect(reader).To(Equal(sentReader))
 				})
@@ -1,7 +1,9 @@
 package instance
 
 import (
+	"context"
 	"fmt"
+	"path"
 
 	"github.com/cloudfoundry/bosh-backup-and-restore/orchestrator"
 	"github.com/cloudfoundry/bosh-backup-and-restore/ssh"
@@ -30,12 +32,18 @@ func NewDeployedInstance(instanceIndex string, instanceGroupName string, instanc
 	}
 }
 
-func (i *DeployedInstance) ArtifactDirExists() (bool, error) {
-	return i.remoteRunner.DirectoryExists(orchestrator.ArtifactDirectory)
+func (i *DeployedInstance) ArtifactDirExists(ctx context.Context) (bool, error) {
+	return i.remoteRunner.DirectoryExists(ctx, orchestrator.ArtifactDirectory)
 }
 
-func (i *DeployedInstance) RemoveArtifactDir() error {
-	return i.remoteRunner.RemoveDirectory(orchestrator.ArtifactDirectory)
+// FreeSpaceInBytes reports the space left on the disk that backup scripts
+// write their artifacts to.
+func (i *DeployedInstance) FreeSpaceInBytes(ctx context.Context) (int, error) {
+	return i.remoteRunner.FreeSpaceInBytes(ctx, path.Dir(orchestrator.ArtifactDirectory))
+}
+
+func (i *DeployedInstance) RemoveArtifactDir(ctx context.Context) error {
+	return i.remoteRunner.RemoveDirectory(ctx, orchestrator.ArtifactDirectory)
 }
 
 func (i *DeployedInstance) IsBackupable() bool {
@@ -58,10 +66,10 @@ func (i *DeployedInstance) Jobs() []orchestrator.Job {
 	return i.jobs
 }
 
-func (i *DeployedInstance) Backup() error {
+func (i *DeployedInstance) Backup(ctx context.Context) error {
 	var backupErrors []error
 	for _, job := range i.jobs {
-		if err := job.Backup(); err != nil {
+		if err := job.Backup(ctx); err != nil {
 			backupErrors = append(backupErrors, err)
 		}
 	}
@@ -80,10 +88,10 @@ func artifactDirectoryVariables(artifactDirectory string) map[string]string {
 	}
 }
 
-func (i *DeployedInstance) Restore() error {
+func (i *DeployedInstance) Restore(ctx context.Context) error {
 	var restoreErrors []error
 	for _, job := range i.jobs {
-		if err := job.Restore(); err != nil {
+		if err := job.Restore(ctx); err != nil {
 			restoreErrors = append(restoreErrors, err)
 		}
 	}
@@ -1,6 +1,7 @@
 package instance_test
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"log"
@@ -99,16 +100,27 @@ var _ = Describe("DeployedInstance", func() {
 		})
 	})
 
+	Describe("FreeSpaceInBytes", func() {
+		It("checks the disk that holds the artifact directory", func() {
+			remoteRunner.FreeSpaceInBytesReturns(4096, nil)
+
+			Expect(deployedInstance.FreeSpaceInBytes(context.Background())).To(Equal(4096))
+			_, dir := remoteRunner.FreeSpaceInBytesArgsForCall(0)
+			Expect(dir).To(Equal("/var/vcap/store"))
+		})
+	})
+
 	Describe("ArtifactDirExists", func() {
 		var dirExists bool
 
 		JustBeforeEach(func() {
-			dirExists, _ = deployedInstance.ArtifactDirExists() //nolint:errcheck
+			dirExists, _ = deployedInstance.ArtifactDirExists(context.Background()) //nolint:errcheck
 		})
 
 		It("queries whether the artifact directory is present", func() {
 			Expect(remoteRunner.DirectoryExistsCallCount()).To(Equal(1))
-			Expect(remoteRunner.DirectoryExistsArgsForCall(0)).To(Equal("/var/vcap/store/bbr-backup"))
+			_, dir := remoteRunner.DirectoryExistsArgsForCall(0)
+			Expect(dir).To(Equal("/var/vcap/store/bbr-backup"))
 		})
 
 		Context("when artifact directory does not exist", func() {
@@ -265,7 +277,7 @@ var _ = Describe("DeployedInstance", func() {
 		var err error
 
 		JustBeforeEach(func() {
-			err = deployedInstance.Backup()
+			err = deployedInstance.Backup(context.Background())
 		})
 
 		Context("when there are multiple backup scripts in multiple job directories", func() {
@@ -315,30 +327,30 @@ var _ = Describe("DeployedInstance", func() {
 				Expect(remoteRunner.CreateDirectoryCallCount()).To(Equal(3))
 				Expect(remoteRunner.RunScriptWithEnvCallCount()).To(Equal(3))
 				Expect([]string{
-					remoteRunner.CreateDirectoryArgsForCall(0),
-					remoteRunner.CreateDirectoryArgsForCall(1),
-					remoteRunner.CreateDirectoryArgsForCall(2),
+					createdDirectory(remoteRunner, 0),
+					createdDirectory(remoteRunner, 1),
+					createdDirectory(remoteRunner, 2),
 				}).To(ConsistOf(
 					"/var/vcap/store/bbr-backup/foo",
 					"/var/vcap/store/bbr-backup/bar",
 					"/var/vcap/store/bbr-backup/baz",
 				))
 
-				specifiedScriptPath, specifiedEnvVars, _, _ := remoteRunner.RunScriptWithEnvArgsForCall(0)
+				_, specifiedScriptPath, specifiedEnvVars, _, _ := remoteRunner.RunScriptWithEnvArgsForCall(0)
 				Expect(specifiedScriptPath).To(Equal("/var/vcap/jobs/foo/bin/bbr/backup"))
 				Expect(specifiedEnvVars).To(Equal(map[string]string{
 					"ARTIFACT_DIRECTORY":     "/var/vcap/store/bbr-backup/foo/",
 					"BBR_ARTIFACT_DIRECTORY": "/var/vcap/store/bbr-backup/foo/",
 				}))
 
-				specifiedScriptPath, specifiedEnvVars, _, _ = remoteRunner.RunScriptWithEnvArgsForCall(1)
+				_, specifiedScriptPath, specifiedEnvVars, _, _ = remoteRunner.RunScriptWithEnvArgsForCall(1)
 				Expect(specifiedScriptPath).To(Equal("/var/vcap/jobs/bar/bin/bbr/backup"))
 				Expect(specifiedEnvVars).To(Equal(map[string]string{
 					"ARTIFACT_DIRECTORY":     "/var/vcap/store/bbr-backup/bar/",
 					"BBR_ARTIFACT_DIRECTORY": "/var/vcap/store/bbr-backup/bar/",
 				}))
 
-				specifiedScriptPath, specifiedEnvVars, _, _ = remoteRunner.RunScriptWithEnvArgsForCall(2)
+				_, specifiedScriptPath, specifiedEnvVars, _, _ = remoteRunner.RunScriptWithEnvArgsForCall(2)
 				Expect(specifiedScriptPath).To(Equal("/var/vcap/jobs/baz/bin/bbr/backup"))
 				Expect(specifiedEnvVars).To(Equal(map[string]string{
 					"ARTIFACT_DIRECTORY":     "/var/vcap/store/bbr-backup/baz/",
@@ -416,20 +428,20 @@ var _ = Describe("DeployedInstance", func() {
 				Expect(remoteRunner.CreateDirectoryCallCount()).To(Equal(2))
 				Expect(remoteRunner.RunScriptWithEnvCallCount()).To(Equal(2))
 				Expect([]string{
-					remoteRunner.CreateDirectoryArgsForCall(0),
-					remoteRunner.CreateDirectoryArgsForCall(1),
+					createdDirectory(remoteRunner, 0),
+					createdDirectory(remoteRunner, 1),
 				}).To(ConsistOf(
 					"/var/vcap/store/bbr-backup/foo",
 					"/var/vcap/store/bbr-backup/baz-dave-backup-one-restore-all",
 				))
-				specifiedScriptPath, specifiedEnvVars, _, _ := remoteRunner.RunScriptWithEnvArgsForCall(0)
+				_, specifiedScriptPath, specifiedEnvVars, _, _ := remoteRunner.RunScriptWithEnvArgsForCall(0)
 				Expect(specifiedScriptPath).To(Equal("/var/vcap/jobs/foo/bin/bbr/backup"))
 				Expect(specifiedEnvVars).To(Equal(map[string]string{
 					"ARTIFACT_DIRECTORY":     "/var/vcap/store/bbr-backup/foo/",
 					"BBR_ARTIFACT_DIRECTORY": "/var/vcap/store/bbr-backup/foo/",
 				}))
 
-				specifiedScriptPath, specifiedEnvVars, _, _ = remoteRunner.RunScriptWithEnvArgsForCall(1)
+				_, specifiedScriptPath, specifiedEnvVars, _, _ = remoteRunner.RunScriptWithEnvArgsForCall(1)
 				Expect(specifiedScriptPath).To(Equal("/var/vcap/jobs/baz/bin/bbr/backup"))
 				Expect(specifiedEnvVars).To(Equal(map[string]string{
 					"ARTIFACT_DIRECTORY":     "/var/vcap/store/bbr-backup/baz-dave-backup-one-restore-all/",
@@ -514,7 +526,7 @@ var _ = Describe("DeployedInstance", func() {
 					),
 				})
 
-				remoteRunner.RunScriptWithEnvStub = func(cmd string, envVars map[string]string, label string, stdout io.Writer) error {
+				remoteRunner.RunScriptWithEnvStub = func(_ context.Context, cmd string, envVars map[string]string, label string, stdout io.Writer) error {
 					if strings.Contains(cmd, "jobs/bar") {
 						return fmt.Errorf("no space left on device")
 					} else if strings.Contains(cmd, "jobs/baz") {
@@ -548,7 +560,7 @@ var _ = Describe("DeployedInstance", func() {
 		var actualError error
 
 		JustBeforeEach(func() {
-			actualError = deployedInstance.Restore()
+			actualError = deployedInstance.Restore(context.Background())
 		})
 
 		Context("when there are multiple restore scripts in multiple job directories", func() {
@@ -596,21 +608,21 @@ var _ = Describe("DeployedInstance", func() {
 			It("uses the remote runner to run each restore script providing the correct ARTIFACT_DIRECTORY", func() {
 				Expect(remoteRunner.RunScriptWithEnvCallCount()).To(Equal(3))
 
-				specifiedScriptPath, specifiedEnvVars, _, _ := remoteRunner.RunScriptWithEnvArgsForCall(0)
+				_, specifiedScriptPath, specifiedEnvVars, _, _ := remoteRunner.RunScriptWithEnvArgsForCall(0)
 				Expect(specifiedScriptPath).To(Equal("/var/vcap/jobs/foo/bin/bbr/restore"))
 				Expect(specifiedEnvVars).To(Equal(map[string]string{
 					"ARTIFACT_DIRECTORY":     "/var/vcap/store/bbr-backup/foo/",
 					"BBR_ARTIFACT_DIRECTORY": "/var/vcap/store/bbr-backup/foo/",
 				}))
 
-				specifiedScriptPath, specifiedEnvVars, _, _ = remoteRunner.RunScriptWithEnvArgsForCall(1)
+				_, specifiedScriptPath, specifiedEnvVars, _, _ = remoteRunner.RunScriptWithEnvArgsForCall(1)
 				Expect(specifiedScriptPath).To(Equal("/var/vcap/jobs/bar/bin/bbr/restore"))
 				Expect(specifiedEnvVars).To(Equal(map[string]string{
 					"ARTIFACT_DIRECTORY":     "/var/vcap/store/bbr-backup/bar/",
 					"BBR_ARTIFACT_DIRECTORY": "/var/vcap/store/bbr-backup/bar/",
 				}))
 
-				specifiedScriptPath, specifiedEnvVars, _, _ = remoteRunner.RunScriptWithEnvArgsForCall(2)
+				_, specifiedScriptPath, specifiedEnvVars, _, _ = remoteRunner.RunScriptWithEnvArgsForCall(2)
 				Expect(specifiedScriptPath).To(Equal("/var/vcap/jobs/baz/bin/bbr/restore"))
 				Expect(specifiedEnvVars).To(Equal(map[string]string{
 					"ARTIFACT_DIRECTORY":     "/var/vcap/store/bbr-backup/baz/",
@@ -698,21 +710,21 @@ var _ = Describe("DeployedInstance", func() {
 			It("uses the remote runner to create each job's backup folder and run each backup script providing the correct BBR_ARTIFACT_DIRECTORY and ARTIFACT_DIRECTORY", func() {
 				Expect(remoteRunner.RunScriptWithEnvCallCount()).To(Equal(3))
 
-				specifiedScriptPath, specifiedEnvVars, _, _ := remoteRunner.RunScriptWithEnvArgsForCall(0)
+				_, specifiedScriptPath, specifiedEnvVars, _, _ := remoteRunner.RunScriptWithEnvArgsForCall(0)
 				Expect(specifiedScriptPath).To(Equal("/var/vcap/jobs/foo/bin/bbr/restore"))
 				Expect(specifiedEnvVars).To(Equal(map[string]string{
 					"ARTIFACT_DIRECTORY":     "/var/vcap/store/bbr-backup/foo/",
 					"BBR_ARTIFACT_DIRECTORY": "/var/vcap/store/bbr-backup/foo/",
 				}))
 
-				specifiedScriptPath, specifiedEnvVars, _, _ = remoteRunner.RunScriptWithEnvArgsForCall(1)
+				_, specifiedScriptPath, specifiedEnvVars, _, _ = remoteRunner.RunScriptWithEnvArgsForCall(1)
 				Expect(specifiedScriptPath).To(Equal("/var/vcap/jobs/bar/bin/bbr/restore"))
 				Expect(specifiedEnvVars).To(Equal(map[string]string{
 					"ARTIFACT_DIRECTORY":     "/var/vcap/store/bbr-backup/bar/",
 					"BBR_ARTIFACT_DIRECTORY": "/var/vcap/store/bbr-backup/bar/",
 				}))
 
-				specifiedScriptPath, specifiedEnvVars, _, _ = remoteRunner.RunScriptWithEnvArgsForCall(2)
+				_, specifiedScriptPath, specifiedEnvVars, _, _ = remoteRunner.RunScriptWithEnvArgsForCall(2)
 				Expect(specifiedScriptPath).To(Equal("/var/vcap/jobs/baz/bin/bbr/restore"))
 				Expect(specifiedEnvVars).To(Equal(map[string]string{
 					"ARTIFACT_DIRECTORY":     "/var/vcap/store/bbr-backup/special-backup/",
@@ -762,7 +774,7 @@ var _ = Describe("DeployedInstance", func() {
 					),
 				})
 
-				remoteRunner.RunScriptWithEnvStub = func(cmd string, envVars map[string]string, label string, stdout io.Writer) error {
+				remoteRunner.RunScriptWithEnvStub = func(_ context.Context, cmd string, envVars map[string]string, label string, stdout io.Writer) error {
 					if strings.Contains(cmd, "jobs/bar") {
 						return fmt.Errorf("no space left on device")
 					} else if strings.Contains(cmd, "jobs/baz") {
@@ -1061,3 +1073,8 @@ var _ = Describe("DeployedInstance", func() {
 		})
 	})
 })
+
+func createdDirectory(remoteRunner *sshfakes.FakeRemoteRunner, i int) string {
+	_, directory := remoteRunner.CreateDirectoryArgsForCall(i)
+	return directory
+}
@@ -2,6 +2,7 @@
 package fakes
 
 import (
+	"context"
 	"sync"
 
 	"github.com/cloudfoundry/bosh-backup-and-restore/instance"
@@ -10,12 +11,13 @@ import (
 )
 
 type FakeJobFinder struct {
-	FindJobsStub        func(instance.InstanceIdentifier, ssh.RemoteRunner, instance.ManifestQuerier) (orchestrator.Jobs, error)
+	FindJobsStub        func(context.Context, instance.InstanceIdentifier, ssh.RemoteRunner, instance.ManifestQuerier) (orchestrator.Jobs, error)
 	findJobsMutex       sync.RWMutex
 	findJobsArgsForCall []struct {
-		arg1 instance.InstanceIdentifier
-		arg2 ssh.RemoteRunner
-		arg3 instance.ManifestQuerier
+		arg1 context.Context
+		arg2 instance.InstanceIdentifier
+		arg3 ssh.RemoteRunner
+		arg4 instance.ManifestQuerier
 	}
 	findJobsReturns struct {
 		result1 orchestrator.Jobs
@@ -29,20 +31,21 @@ type FakeJobFinder struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeJobFinder) FindJobs(arg1 instance.InstanceIdentifier, arg2 ssh.RemoteRunner, arg3 instance.ManifestQuerier) (orchestrator.Jobs, error) {
+func (fake *FakeJobFinder) FindJobs(arg1 context.Context, arg2 instance.InstanceIdentifier, arg3 ssh.RemoteRunner, arg4 instance.ManifestQuerier) (orchestrator.Jobs, error) {
 	fake.findJobsMutex.Lock()
 	ret, specificReturn := fake.findJobsReturnsOnCall[len(fake.findJobsArgsForCall)]
 	fake.findJobsArgsForCall = append(fake.findJobsArgsForCall, struct {
-		arg1 instance.InstanceIdentifier
-		arg2 ssh.RemoteRunner
-		arg3 instance.ManifestQuerier
-	}{arg1, arg2, arg3})
+		arg1 context.Context
+		arg2 instance.InstanceIdentifier
+		arg3 ssh.RemoteRunner
+		arg4 instance.ManifestQuerier
+	}{arg1, arg2, arg3, arg4})
 	stub := fake.FindJobsStub
 	fakeReturns := fake.findJobsReturns
-	fake.recordInvocation("FindJobs", []interface{}{arg1, arg2, arg3})
+	fake.recordInvocation("FindJobs", []interface{}{arg1, arg2, arg3, arg4})
 	fake.findJobsMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3)
+		return stub(arg1, arg2, arg3, arg4)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -56,17 +59,17 @@ func (fake *FakeJobFinder) FindJobsCallCount() int {
 	return len(fake.findJobsArgsForCall)
 }
 
-func (fake *FakeJobFinder) FindJobsCalls(stub func(instance.InstanceIdentifier, ssh.RemoteRunner, instance.ManifestQuerier) (orchestrator.Jobs, error)) {
+func (fake *FakeJobFinder) FindJobsCalls(stub func(context.Context, instance.InstanceIdentifier, ssh.RemoteRunner, instance.ManifestQuerier) (orchestrator.Jobs, error)) {
 	fake.findJobsMutex.Lock()
 	defer fake.findJobsMutex.Unlock()
 	fake.FindJobsStub = stub
 }
 
-func (fake *FakeJobFinder) FindJobsArgsForCall(i int) (instance.InstanceIdentifier, ssh.RemoteRunner, instance.ManifestQuerier) {
+func (fake *FakeJobFinder) FindJobsArgsForCall(i int) (context.Context, instance.InstanceIdentifier, ssh.RemoteRunner, instance.ManifestQuerier) {
 	fake.findJobsMutex.RLock()
 	defer fake.findJobsMutex.RUnlock()
 	argsForCall := fake.findJobsArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
 }
 
 func (fake *FakeJobFinder) FindJobsReturns(result1 orchestrator.Jobs, result2 error) {
@@ -1,16 +1,19 @@
 package instance
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"strconv"
+	"strings"
 
 	"github.com/cloudfoundry/bosh-backup-and-restore/orchestrator"
 	"github.com/cloudfoundry/bosh-backup-and-restore/ssh"
 	"github.com/pkg/errors"
 )
 
-func NewJob(remoteRunner ssh.RemoteRunner, instanceIdentifier string, logger Logger, release string, jobScripts BackupAndRestoreScripts, metadata Metadata, backupOneRestoreAll bool, onBootstrapNode bool) Job {
+func NewJob(remoteRunner ssh.RemoteRunner, instanceIdentifier string, logger Logger, release string, jobScripts BackupAndRestoreScripts, metadata Metadata, backupOneRestoreAll bool, onBackupSourceNode bool) Job {
 	jobName := jobScripts[0].JobName()
 	return Job{
 		Logger:              logger,
@@ -20,13 +23,14 @@ func NewJob(remoteRunner ssh.RemoteRunner, instanceIdentifier string, logger Log
 		release:             release,
 		metadata:            metadata,
 		backupScript:        jobScripts.BackupOnly().firstOrBlank(),
+		backupSizeScript:    jobScripts.BackupSizeOnly().firstOrBlank(),
 		restoreScript:       jobScripts.RestoreOnly().firstOrBlank(),
 		preBackupScript:     jobScripts.PreBackupLockOnly().firstOrBlank(),
 		preRestoreScript:    jobScripts.PreRestoreLockOnly().firstOrBlank(),
 		postBackupScript:    jobScripts.PostBackupUnlockOnly().firstOrBlank(),
 		postRestoreScript:   jobScripts.SinglePostRestoreUnlockScript(),
 		backupOneRestoreAll: backupOneRestoreAll,
-		onBootstrapNode:     onBootstrapNode,
+		onBackupSourceNode:  onBackupSourceNode,
 	}
 }
 
@@ -36,6 +40,7 @@ type Job struct {
 	release             string
 	metadata            Metadata
 	backupScript        Script
+	backupSizeScript    Script
 	preBackupScript     Script
 	postBackupScript    Script
 	preRestoreScript    Script
@@ -44,7 +49,7 @@ type Job struct {
 	remoteRunner        ssh.RemoteRunner
 	instanceIdentifier  string
 	backupOneRestoreAll bool
-	onBootstrapNode     bool
+	onBackupSourceNode  bool
 }
 
 func (j Job) Name() string {
@@ -60,7 +65,7 @@ func (j Job) InstanceIdentifier() string {
 }
 
 func (j Job) BackupArtifactName() string {
-	if j.backupOneRestoreAll && j.onBootstrapNode {
+	if j.backupOneRestoreAll && j.onBackupSourceNode {
 		return j.backupOneRestoreAllArtifactName()
 	}
 
@@ -107,25 +112,30 @@ func (j Job) HasRestore() bool {
 }
 
 func (j Job) HasNamedBackupArtifact() bool {
-	return j.backupOneRestoreAll && j.onBootstrapNode
+	return j.backupOneRestoreAll && j.onBackupSourceNode
+}
+
+func (j Job) IsBackupOneRestoreAll() bool {
+	return j.backupOneRestoreAll
 }
 
 func (j Job) HasNamedRestoreArtifact() bool {
 	return j.backupOneRestoreAll || j.metadata.RestoreName != ""
 }
 
-func (j Job) Backup() error {
+func (j Job) Backup(ctx context.Context) error {
 	if j.backupScript != "" {
 		j.Logger.Debug("bbr", "> %s", j.backupScript)                                //nolint:staticcheck
 		j.Logger.Info("bbr", "Backing up %s on %s...", j.name, j.instanceIdentifier) //nolint:staticcheck
 
-		err := j.remoteRunner.CreateDirectory(j.BackupArtifactDirectory())
+		err := j.remoteRunner.CreateDirectory(ctx, j.BackupArtifactDirectory())
 		if err != nil {
 			return err
 		}
 
 		env := artifactDirectoryVariables(j.BackupArtifactDirectory())
 		err = j.remoteRunner.RunScriptWithEnv(
+			ctx,
 			string(j.backupScript),
 			env,
 			fmt.Sprintf("backup %s on %s", j.name, j.instanceIdentifier),
@@ -135,11 +145,11 @@ func (j Job) Backup() error {
 		if err != nil {
 			j.Logger.Error("bbr", "Error backing up %s on %s.", j.name, j.instanceIdentifier) //nolint:staticcheck
 
-			return errors.Wrap(err, fmt.Sprintf(
+			return orchestrator.NewScriptError(j.instanceIdentifier, j.name, "backup", errors.Wrap(err, fmt.Sprintf(
 				"Error attempting to run backup for job %s on %s",
 				j.Name(),
 				j.instanceIdentifier,
-			))
+			)))
 		}
 
 		j.Logger.Info("bbr", "Finished backing up %s on %s.", j.name, j.instanceIdentifier) //nolint:staticcheck
@@ -148,23 +158,66 @@ func (j Job) Backup() error {
 	return nil
 }
 
-func (j Job) PreBackupLock() error {
+func (j Job) HasBackupSize() bool {
+	return j.backupSizeScript != ""
+}
+
+// BackupSize runs the optional backup-size script, which prints an estimate
+// of how many bytes the backup script will write to the artifact directory.
+func (j Job) BackupSize(ctx context.Context) (int, error) {
+	if j.backupSizeScript == "" {
+		return 0, errors.Errorf("Job %s on %s has no backup-size script", j.name, j.instanceIdentifier)
+	}
+
+	j.Logger.Debug("bbr", "> %s", j.backupSizeScript) //nolint:staticcheck
+
+	stdout := new(bytes.Buffer)
+	err := j.remoteRunner.RunScriptWithEnv(
+		ctx,
+		string(j.backupSizeScript),
+		artifactDirectoryVariables(j.BackupArtifactDirectory()),
+		fmt.Sprintf("backup-size %s on %s", j.name, j.instanceIdentifier),
+		stdout,
+	)
+	if err != nil {
+		return 0, errors.Wrap(err, fmt.Sprintf(
+			"Error attempting to run backup-size for job %s on %s",
+			j.Name(),
+			j.instanceIdentifier,
+		))
+	}
+
+	size, err := strconv.Atoi(strings.TrimSpace(stdout.String()))
+	if err != nil || size < 0 {
+		return 0, errors.Errorf(
+			"backup-size for job %s on %s printed %q, expected a number of bytes",
+			j.Name(),
+			j.instanceIdentifier,
+			strings.TrimSpace(stdout.String()),
+		)
+	}
+
+	return size, nil
+}
+
+func (j Job) PreBackupLock(ctx context.Context) error {
 	if j.preBackupScript != "" {
 		j.Logger.Debug("bbr", "> %s", j.preBackupScript)                                     //nolint:staticcheck
 		j.Logger.Info("bbr", "Locking %s on %s for backup...", j.name, j.instanceIdentifier) //nolint:staticcheck
 
 		err := j.remoteRunner.RunScript(
+			ctx,
 			string(j.preBackupScript),
 			fmt.Sprintf("pre-backup lock %s on %s", j.name, j.instanceIdentifier),
 		)
 		if err != nil {
 			j.Logger.Error("bbr", "Error locking %s on %s.", j.name, j.instanceIdentifier) //nolint:staticcheck
 
-			return errors.Wrap(err, fmt.Sprintf(
+			return orchestrator.NewScriptError(j.instanceIdentifier, j.name, "pre-backup-lock", errors.Wrap(err, fmt.Sprintf(
 				"Error attempting to run pre-backup-lock for job %s on %s",
 				j.Name(),
 				j.instanceIdentifier,
-			))
+			)))
 		}
 
 		j.Logger.Info("bbr", "Finished locking %s on %s for backup.", j.name, j.instanceIdentifier) //nolint:staticcheck
@@ -173,7 +226,7 @@ func (j Job) PreBackupLock() error {
 	return nil
 }
 
-func (j Job) PostBackupUnlock(afterSuccessfulBackup bool) error {
+func (j Job) PostBackupUnlock(ctx context.Context, afterSuccessfulBackup bool) error {
 	if j.postBackupScript != "" {
 		j.Logger.Debug("bbr", "> %s", j.postBackupScript)                           //nolint:staticcheck
 		j.Logger.Info("bbr", "Unlocking %s on %s...", j.name, j.instanceIdentifier) //nolint:staticcheck
@@ -181,6 +234,7 @@ func (j Job) PostBackupUnlock(afterSuccessfulBackup bool) error {
 			"BBR_AFTER_BACKUP_SCRIPTS_SUCCESSFUL": strconv.FormatBool(afterSuccessfulBackup),
 		}
 		err := j.remoteRunner.RunScriptWithEnv(
+			ctx,
 			string(j.postBackupScript),
 			env,
 			fmt.Sprintf("post-backup unlock %s on %s", j.name, j.instanceIdentifier),
@@ -189,11 +243,11 @@ func (j Job) PostBackupUnlock(afterSuccessfulBackup bool) error {
 		if err != nil {
 			j.Logger.Error("bbr", "Error unlocking %s on %s.", j.name, j.instanceIdentifier) //nolint:staticcheck
 
-			return errors.Wrap(err, fmt.Sprintf(
+			return orchestrator.NewScriptError(j.instanceIdentifier, j.name, "post-backup-unlock", errors.Wrap(err, fmt.Sprintf(
 				"Error attempting to run post-backup-unlock for job %s on %s",
 				j.Name(),
 				j.instanceIdentifier,
-			))
+			)))
 		}
 
 		j.Logger.Info("bbr", "Finished unlocking %s on %s.", j.name, j.instanceIdentifier) //nolint:staticcheck
@@ -202,23 +256,24 @@ func (j Job) PostBackupUnlock(afterSuccessfulBackup bool) error {
 	return nil
 }
 
-func (j Job) PreRestoreLock() error {
+func (j Job) PreRestoreLock(ctx context.Context) error {
 	if j.preRestoreScript != "" {
 		j.Logger.Debug("bbr", "> %s", j.preRestoreScript)                                     //nolint:staticcheck
 		j.Logger.Info("bbr", "Locking %s on %s for restore...", j.name, j.instanceIdentifier) //nolint:staticcheck
 
 		err := j.remoteRunner.RunScript(
+			ctx,
 			string(j.preRestoreScript),
 			fmt.Sprintf("pre-restore lock %s on %s", j.name, j.instanceIdentifier),
 		)
 		if err != nil {
 			j.Logger.Error("bbr", "Error locking %s on %s.", j.name, j.instanceIdentifier) //nolint:staticcheck
 
-			return errors.Wrap(err, fmt.Sprintf(
+			return orchestrator.NewScriptError(j.instanceIdentifier, j.name, "pre-restore-lock", errors.Wrap(err, fmt.Sprintf(
 				"Error attempting to run pre-restore-lock for job %s on %s",
 				j.Name(),
 				j.instanceIdentifier,
-			))
+			)))
 		}
 
 		j.Logger.Info("bbr", "Finished locking %s on %s for restore.", j.name, j.instanceIdentifier) //nolint:staticcheck
@@ -227,13 +282,14 @@ func (j Job) PreRestoreLock() error {
 	return nil
 }
 
-func (j Job) Restore() error {
+func (j Job) Restore(ctx context.Context) error {
 	if j.restoreScript != "" {
 		j.Logger.Debug("bbr", "> %s", j.restoreScript)                              //nolint:staticcheck
 		j.Logger.Info("bbr", "Restoring %s on %s...", j.name, j.instanceIdentifier) //nolint:staticcheck
 
 		env := artifactDirectoryVariables(j.RestoreArtifactDirectory())
 		err := j.remoteRunner.RunScriptWithEnv(
+			ctx,
 			string(j.restoreScript), env,
 			fmt.Sprintf("restore %s on %s", j.name, j.instanceIdentifier),
 			io.Discard,
@@ -241,11 +297,11 @@ func (j Job) Restore() error {
 		if err != nil {
 			j.Logger.Error("bbr", "Error restoring %s on %s.", j.name, j.instanceIdentifier) //nolint:staticcheck
 
-			return errors.Wrap(err, fmt.Sprintf(
+			return orchestrator.NewScriptError(j.instanceIdentifier, j.name, "restore", errors.Wrap(err, fmt.Sprintf(
 				"Error attempting to run restore for job %s on %s",
 				j.Name(),
 				j.instanceIdentifier,
-			))
+			)))
 		}
 
 		j.Logger.Info("bbr", "Finished restoring %s on %s.", j.name, j.instanceIdentifier) //nolint:staticcheck
@@ -254,23 +310,24 @@ func (j Job) Restore() error {
 	return nil
 }
 
-func (j Job) PostRestoreUnlock() error {
+func (j Job) PostRestoreUnlock(ctx context.Context) error {
 	if j.postRestoreScript != "" {
 		j.Logger.Debug("bbr", "> %s", j.postRestoreScript)                          //nolint:staticcheck
 		j.Logger.Info("bbr", "Unlocking %s on %s...", j.name, j.instanceIdentifier) //nolint:staticcheck
 
 		err := j.remoteRunner.RunScript(
+			ctx,
 			string(j.postRestoreScript),
 			fmt.Sprintf("post-restore unlock %s on %s", j.name, j.instanceIdentifier),
 		)
 		if err != nil {
 			j.Logger.Error("bbr", "Error unlocking %s on %s.", j.name, j.instanceIdentifier) //nolint:staticcheck
 
-			return errors.Wrap(err, fmt.Sprintf(
+			return orchestrator.NewScriptError(j.instanceIdentifier, j.name, "post-restore-unlock", errors.Wrap(err, fmt.Sprintf(
 				"Error attempting to run post-restore-unlock for job %s on %s",
 				j.Name(),
 				j.instanceIdentifier,
-			))
+			)))
 		}
 
 		j.Logger.Info("bbr", "Finished unlocking %s on %s.", j.name, j.instanceIdentifier) //nolint:staticcheck
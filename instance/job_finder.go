@@ -2,6 +2,7 @@ package instance
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"path/filepath"
 
@@ -13,6 +14,7 @@ import (
 type InstanceIdentifier struct {
 	InstanceGroupName string
 	InstanceId        string
+	InstanceIndex     string
 	Bootstrap         bool
 }
 
@@ -23,7 +25,7 @@ func (i InstanceIdentifier) String() string {
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
 //counterfeiter:generate -o fakes/fake_job_finder.go . JobFinder
 type JobFinder interface {
-	FindJobs(instanceIdentifier InstanceIdentifier, remoteRunner ssh.RemoteRunner, manifestQuerier ManifestQuerier) (orchestrator.Jobs, error)
+	FindJobs(ctx context.Context, instanceIdentifier InstanceIdentifier, remoteRunner ssh.RemoteRunner, manifestQuerier ManifestQuerier) (orchestrator.Jobs, error)
 }
 
 type JobFinderFromScripts struct {
@@ -48,10 +50,10 @@ func NewJobFinderOmitMetadataReleases(bbrVersion string, logger Logger) *JobFind
 	}
 }
 
-func (j *JobFinderFromScripts) FindJobs(instanceIdentifier InstanceIdentifier, remoteRunner ssh.RemoteRunner,
+func (j *JobFinderFromScripts) FindJobs(ctx context.Context, instanceIdentifier InstanceIdentifier, remoteRunner ssh.RemoteRunner,
 	manifestQuerier ManifestQuerier) (orchestrator.Jobs, error) {
 
-	findOutput, err := j.findBBRScripts(instanceIdentifier, remoteRunner)
+	findOutput, err := j.findBBRScripts(ctx, instanceIdentifier, remoteRunner)
 	if err != nil {
 		return nil, err
 	}
@@ -59,7 +61,7 @@ func (j *JobFinderFromScripts) FindJobs(instanceIdentifier InstanceIdentifier, r
 	scripts := NewBackupAndRestoreScripts(findOutput)
 	for _, script := range scripts {
 		if script.isMetadata() {
-			jobMetadata, err := j.findMetadata(instanceIdentifier, script, remoteRunner)
+			jobMetadata, err := j.findMetadata(ctx, instanceIdentifier, script, remoteRunner)
 
 			if err != nil {
 				return nil, err
@@ -84,16 +86,24 @@ func (j *JobFinderFromScripts) logMetadata(jobMetadata *Metadata, jobName string
 		j.Logger.Info("bbr", "Detected order: %s should be locked before %s during restore", jobName, filepath.Join(lockBefore.Release, lockBefore.JobName))
 	}
 
+	if jobMetadata.BackupOneRestoreAll {
+		source := jobMetadata.BackupOneRestoreAllSource
+		if source == "" {
+			source = bootstrapBackupSource
+		}
+		j.Logger.Info("bbr", "Detected backup-one-restore-all for %s, backing up from the %s instance", jobName, source)
+	}
+
 	if jobMetadata.BackupName != "" {
 		j.Logger.Warn("bbr", "discontinued metadata keys backup_name/restore_name found in job %s. bbr will not be able to restore this backup artifact.", jobName)
 	}
 }
 
-func (j *JobFinderFromScripts) findBBRScripts(instanceIdentifierForLogging InstanceIdentifier,
+func (j *JobFinderFromScripts) findBBRScripts(ctx context.Context, instanceIdentifierForLogging InstanceIdentifier,
 	remoteRunner ssh.RemoteRunner) ([]string, error) {
 	j.Logger.Debug("bbr", "Attempting to find scripts on %s", instanceIdentifierForLogging)
 
-	scripts, err := remoteRunner.FindFiles("/var/vcap/jobs/*/bin/bbr/*")
+	scripts, err := remoteRunner.FindFiles(ctx, "/var/vcap/jobs/*/bin/bbr/*")
 	if err != nil {
 		return nil, errors.Wrap(err, fmt.Sprintf("finding scripts failed on %s", instanceIdentifierForLogging))
 	}
@@ -101,9 +111,10 @@ func (j *JobFinderFromScripts) findBBRScripts(instanceIdentifierForLogging Insta
 	return scripts, nil
 }
 
-func (j *JobFinderFromScripts) findMetadata(instanceIdentifier InstanceIdentifier, script Script, remoteRunner ssh.RemoteRunner) (*Metadata, error) {
+func (j *JobFinderFromScripts) findMetadata(ctx context.Context, instanceIdentifier InstanceIdentifier, script Script, remoteRunner ssh.RemoteRunner) (*Metadata, error) {
 	metadataBuffer := &bytes.Buffer{}
 	err := remoteRunner.RunScriptWithEnv(
+		ctx,
 		string(script),
 		map[string]string{"BBR_VERSION": j.bbrVersion},
 		fmt.Sprintf("find metadata for %s on %s", script.JobName(), instanceIdentifier),
@@ -159,6 +170,7 @@ func (j *JobFinderFromScripts) buildJobs(remoteRunner ssh.RemoteRunner,
 		}
 
 		backupOneRestoreAll, _ := manifestQuerier.IsJobBackupOneRestoreAll(instanceIdentifier.InstanceGroupName, jobName) //nolint:errcheck
+		backupOneRestoreAll = backupOneRestoreAll || metadata[jobName].BackupOneRestoreAll
 
 		jobs = append(jobs, NewJob(
 			remoteRunner,
@@ -168,7 +180,7 @@ func (j *JobFinderFromScripts) buildJobs(remoteRunner ssh.RemoteRunner,
 			jobScripts,
 			metadata[jobName],
 			backupOneRestoreAll,
-			instanceIdentifier.Bootstrap,
+			metadata[jobName].IsBackupOneRestoreAllSource(instanceIdentifier),
 		))
 	}
 
@@ -1,6 +1,7 @@
 package instance_test
 
 import (
+	"context"
 	"strings"
 
 	. "github.com/cloudfoundry/bosh-backup-and-restore/instance"
@@ -62,13 +63,14 @@ var _ = Describe("JobFinderFromScripts", func() {
 		})
 
 		JustBeforeEach(func() {
-			jobs, jobsError = jobFinder.FindJobs(instanceIdentifier, remoteRunner, manifestQuerier)
+			jobs, jobsError = jobFinder.FindJobs(context.Background(), instanceIdentifier, remoteRunner, manifestQuerier)
 		})
 
 		It("finds the jobs", func() {
-			jobs, jobsError = jobFinder.FindJobs(instanceIdentifier, remoteRunner, manifestQuerier)
+			jobs, jobsError = jobFinder.FindJobs(context.Background(), instanceIdentifier, remoteRunner, manifestQuerier)
 			By("finding the scripts", func() {
-				Expect(remoteRunner.FindFilesArgsForCall(0)).To(Equal("/var/vcap/jobs/*/bin/bbr/*"))
+				_, pattern := remoteRunner.FindFilesArgsForCall(0)
+				Expect(pattern).To(Equal("/var/vcap/jobs/*/bin/bbr/*"))
 			})
 
 			By("logging the scripts found", func() {
@@ -128,7 +130,7 @@ var _ = Describe("JobFinderFromScripts", func() {
 			})
 
 			It("finds the jobs", func() {
-				jobs, _ = jobFinder.FindJobs(instanceIdentifier, remoteRunner, manifestQuerier) //nolint:errcheck
+				jobs, _ = jobFinder.FindJobs(context.Background(), instanceIdentifier, remoteRunner, manifestQuerier) //nolint:errcheck
 				Expect(jobs).To(ConsistOf(
 					NewJob(
 						remoteRunner,
@@ -157,7 +159,8 @@ var _ = Describe("JobFinderFromScripts", func() {
 
 			It("ignores them", func() {
 				By("finding the scripts", func() {
-					Expect(remoteRunner.FindFilesArgsForCall(0)).To(Equal("/var/vcap/jobs/*/bin/bbr/*"))
+					_, pattern := remoteRunner.FindFilesArgsForCall(0)
+					Expect(pattern).To(Equal("/var/vcap/jobs/*/bin/bbr/*"))
 				})
 
 				By("not returning an error", func() {
@@ -252,7 +255,7 @@ var _ = Describe("JobFinderFromScripts", func() {
 			Context("when metadata is valid", func() {
 				BeforeEach(func() {
 					remoteRunner.FindFilesReturns([]string{"/var/vcap/jobs/consul_agent/bin/bbr/metadata"}, nil)
-					remoteRunner.RunScriptWithEnvStub = func(_ string, _ map[string]string, _ string, stdout io.Writer) error {
+					remoteRunner.RunScriptWithEnvStub = func(_ context.Context, _ string, _ map[string]string, _ string, stdout io.Writer) error {
 						stdout.Write([]byte( //nolint:errcheck
 							`---
 backup_name: consul_backup
@@ -266,7 +269,7 @@ backup_should_be_locked_before:
 
 				It("attaches the metadata to the corresponding jobs", func() {
 					By("executing the metadata scripts passing the correct arguments", func() {
-						cmd, env, _, _ := remoteRunner.RunScriptWithEnvArgsForCall(0)
+						_, cmd, env, _, _ := remoteRunner.RunScriptWithEnvArgsForCall(0)
 						Expect(cmd).To(Equal("/var/vcap/jobs/consul_agent/bin/bbr/metadata"))
 						Expect(env).To(Equal(map[string]string{"BBR_VERSION": bbrVersion}))
 					})
@@ -304,7 +307,7 @@ backup_should_be_locked_before:
 
 					It("attaches the metadata to the corresponding jobs", func() {
 						By("executing the metadata scripts passing the correct arguments", func() {
-							cmd, env, _, _ := remoteRunner.RunScriptWithEnvArgsForCall(0)
+							_, cmd, env, _, _ := remoteRunner.RunScriptWithEnvArgsForCall(0)
 							Expect(cmd).To(Equal("/var/vcap/jobs/consul_agent/bin/bbr/metadata"))
 							Expect(env).To(Equal(map[string]string{"BBR_VERSION": bbrVersion}))
 						})
@@ -374,7 +377,7 @@ backup_should_be_locked_before:
 			Context("when a metadata script returns invalid metadata YAML", func() {
 				BeforeEach(func() {
 					remoteRunner.FindFilesReturns([]string{"/var/vcap/jobs/consul_agent/bin/bbr/metadata"}, nil)
-					remoteRunner.RunScriptWithEnvStub = func(_ string, _ map[string]string, _ string, stdout io.Writer) error {
+					remoteRunner.RunScriptWithEnvStub = func(_ context.Context, _ string, _ map[string]string, _ string, stdout io.Writer) error {
 						stdout.Write([]byte(`this metadata is missing all the keys`)) //nolint:errcheck
 						return nil
 					}
@@ -387,10 +390,85 @@ backup_should_be_locked_before:
 				})
 			})
 
+			Context("when the metadata declares the job backup-one-restore-all", func() {
+				var rawMetadata string
+
+				BeforeEach(func() {
+					manifestQuerier.IsJobBackupOneRestoreAllReturns(false, nil)
+					remoteRunner.FindFilesReturns([]string{
+						"/var/vcap/jobs/consul_agent/bin/bbr/backup",
+						"/var/vcap/jobs/consul_agent/bin/bbr/metadata",
+					}, nil)
+					remoteRunner.RunScriptWithEnvStub = func(_ context.Context, _ string, _ map[string]string, _ string, stdout io.Writer) error {
+						stdout.Write([]byte(rawMetadata)) //nolint:errcheck
+						return nil
+					}
+					rawMetadata = `---
+backup_one_restore_all: true`
+				})
+
+				It("backs up from the bootstrap instance and restores to all of them", func() {
+					Expect(jobsError).NotTo(HaveOccurred())
+					Expect(jobs).To(HaveLen(1))
+					Expect(jobs[0].HasNamedBackupArtifact()).To(BeTrue())
+					Expect(jobs[0].BackupArtifactName()).To(Equal("consul_agent-consul-agent-release-backup-one-restore-all"))
+					Expect(jobs[0].HasNamedRestoreArtifact()).To(BeTrue())
+					Expect(logStream.String()).To(ContainSubstring("Detected backup-one-restore-all for consul_agent, backing up from the bootstrap instance"))
+				})
+
+				Context("and the instance is not the bootstrap instance", func() {
+					BeforeEach(func() {
+						instanceIdentifier.Bootstrap = false
+					})
+
+					It("only restores to it", func() {
+						Expect(jobs[0].HasNamedBackupArtifact()).To(BeFalse())
+						Expect(jobs[0].HasNamedRestoreArtifact()).To(BeTrue())
+					})
+				})
+
+				Context("and it names another instance as the backup source", func() {
+					BeforeEach(func() {
+						rawMetadata = `---
+backup_one_restore_all: true
+backup_one_restore_all_source: "2"`
+					})
+
+					It("does not back up from the bootstrap instance", func() {
+						Expect(jobs[0].HasNamedBackupArtifact()).To(BeFalse())
+						Expect(jobs[0].HasNamedRestoreArtifact()).To(BeTrue())
+						Expect(logStream.String()).To(ContainSubstring("backing up from the 2 instance"))
+					})
+
+					Context("and the instance is that source", func() {
+						BeforeEach(func() {
+							instanceIdentifier = InstanceIdentifier{InstanceGroupName: "identifier", InstanceId: "5b0c7a3e-2d1f-4e6a-9b8c-7d6e5f4a3b2c", InstanceIndex: "2", Bootstrap: false}
+						})
+
+						It("backs up from it", func() {
+							Expect(jobs[0].HasNamedBackupArtifact()).To(BeTrue())
+						})
+					})
+
+					Context("and the instance has that ID rather than that index", func() {
+						BeforeEach(func() {
+							rawMetadata = `---
+backup_one_restore_all: true
+backup_one_restore_all_source: 5b0c7a3e-2d1f-4e6a-9b8c-7d6e5f4a3b2c`
+							instanceIdentifier = InstanceIdentifier{InstanceGroupName: "identifier", InstanceId: "5b0c7a3e-2d1f-4e6a-9b8c-7d6e5f4a3b2c", InstanceIndex: "1", Bootstrap: false}
+						})
+
+						It("backs up from it", func() {
+							Expect(jobs[0].HasNamedBackupArtifact()).To(BeTrue())
+						})
+					})
+				})
+			})
+
 			Context("when the bbr job is disabled", func() {
 				BeforeEach(func() {
 					remoteRunner.FindFilesReturns([]string{"/var/vcap/jobs/consul_agent/bin/bbr/metadata"}, nil)
-					remoteRunner.RunScriptWithEnvStub = func(_ string, _ map[string]string, _ string, stdout io.Writer) error {
+					remoteRunner.RunScriptWithEnvStub = func(_ context.Context, _ string, _ map[string]string, _ string, stdout io.Writer) error {
 						stdout.Write([]byte( //nolint:errcheck
 							`---
 skip_bbr_scripts: true`))
@@ -400,7 +478,7 @@ skip_bbr_scripts: true`))
 
 				It("ignores the job", func() {
 					By("executing the metadata scripts passing the correct arguments", func() {
-						cmd, env, _, _ := remoteRunner.RunScriptWithEnvArgsForCall(0)
+						_, cmd, env, _, _ := remoteRunner.RunScriptWithEnvArgsForCall(0)
 						Expect(cmd).To(Equal("/var/vcap/jobs/consul_agent/bin/bbr/metadata"))
 						Expect(env).To(Equal(map[string]string{"BBR_VERSION": bbrVersion}))
 					})
@@ -1,12 +1,15 @@
 package instance_test
 
 import (
+	"context"
+
 	"github.com/cloudfoundry/bosh-backup-and-restore/instance"
 	boshlog "github.com/cloudfoundry/bosh-utils/logger"
 
 	"log"
 
 	"fmt"
+	"io"
 
 	sshfakes "github.com/cloudfoundry/bosh-backup-and-restore/ssh/fakes"
 
@@ -26,8 +29,13 @@ var _ = Describe("Job", func() {
 	var instanceIdentifier = "instance/identifier"
 	var backupOneRestoreAll bool
 	var onBootstrapNode bool
+	var ctx context.Context
 
 	BeforeEach(func() {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(context.Background())
+		DeferCleanup(cancel)
+
 		jobScripts = instance.BackupAndRestoreScripts{
 			"/var/vcap/jobs/jobname/bin/bbr/restore",
 			"/var/vcap/jobs/jobname/bin/bbr/backup",
@@ -341,11 +349,87 @@ var _ = Describe("Job", func() {
 		})
 	})
 
+	Describe("BackupSize", func() {
+		var size int
+		var sizeError error
+		var ctx context.Context
+
+		BeforeEach(func() {
+			jobScripts = instance.BackupAndRestoreScripts{
+				"/var/vcap/jobs/jobname/bin/bbr/backup",
+				"/var/vcap/jobs/jobname/bin/bbr/backup-size",
+			}
+			remoteRunner.RunScriptWithEnvStub = func(_ context.Context, path string, env map[string]string, label string, stdout io.Writer) error {
+				fmt.Fprintln(stdout, "1048576") //nolint:errcheck
+				return nil
+			}
+		})
+
+		JustBeforeEach(func() {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithCancel(context.Background())
+			DeferCleanup(cancel)
+
+			size, sizeError = job.BackupSize(ctx)
+		})
+
+		It("runs the backup-size script with the artifact directory and the context", func() {
+			Expect(job.HasBackupSize()).To(BeTrue())
+			Expect(sizeError).NotTo(HaveOccurred())
+			Expect(size).To(Equal(1048576))
+
+			Expect(remoteRunner.RunScriptWithEnvCallCount()).To(Equal(1))
+			specifiedCtx, specifiedScriptPath, specifiedEnvVars, _, _ := remoteRunner.RunScriptWithEnvArgsForCall(0)
+			Expect(specifiedCtx).To(BeIdenticalTo(ctx))
+			Expect(specifiedScriptPath).To(Equal("/var/vcap/jobs/jobname/bin/bbr/backup-size"))
+			Expect(specifiedEnvVars).To(HaveKeyWithValue("BBR_ARTIFACT_DIRECTORY", "/var/vcap/store/bbr-backup/jobname/"))
+			Expect(remoteRunner.CreateDirectoryCallCount()).To(BeZero())
+		})
+
+		Context("when the script prints something other than a number", func() {
+			BeforeEach(func() {
+				remoteRunner.RunScriptWithEnvStub = func(_ context.Context, path string, env map[string]string, label string, stdout io.Writer) error {
+					fmt.Fprintln(stdout, "lots") //nolint:errcheck
+					return nil
+				}
+			})
+
+			It("fails", func() {
+				Expect(sizeError).To(MatchError(`backup-size for job jobname on instance/identifier printed "lots", expected a number of bytes`))
+			})
+		})
+
+		Context("when the script fails", func() {
+			BeforeEach(func() {
+				remoteRunner.RunScriptWithEnvStub = nil
+				remoteRunner.RunScriptWithEnvReturns(fmt.Errorf("exit 1"))
+			})
+
+			It("fails", func() {
+				Expect(sizeError).To(MatchError(ContainSubstring("Error attempting to run backup-size for job jobname on instance/identifier")))
+			})
+		})
+
+		Context("when the job has no backup-size script", func() {
+			BeforeEach(func() {
+				jobScripts = instance.BackupAndRestoreScripts{
+					"/var/vcap/jobs/jobname/bin/bbr/backup",
+				}
+			})
+
+			It("fails without running anything", func() {
+				Expect(job.HasBackupSize()).To(BeFalse())
+				Expect(sizeError).To(HaveOccurred())
+				Expect(remoteRunner.RunScriptWithEnvCallCount()).To(BeZero())
+			})
+		})
+	})
+
 	Describe("Backup", func() {
 		var backupError error
 
 		JustBeforeEach(func() {
-			backupError = job.Backup()
+			backupError = job.Backup(ctx)
 		})
 
 		Context("job has no backup script", func() {
@@ -371,8 +455,10 @@ var _ = Describe("Job", func() {
 				Expect(remoteRunner.CreateDirectoryCallCount()).To(Equal(1))
 				Expect(remoteRunner.RunScriptWithEnvCallCount()).To(Equal(1))
 
-				Expect(remoteRunner.CreateDirectoryArgsForCall(0)).To(Equal("/var/vcap/store/bbr-backup/jobname"))
-				specifiedScriptPath, specifiedEnvVars, _, _ := remoteRunner.RunScriptWithEnvArgsForCall(0)
+				_, dir := remoteRunner.CreateDirectoryArgsForCall(0)
+				Expect(dir).To(Equal("/var/vcap/store/bbr-backup/jobname"))
+				specifiedCtx, specifiedScriptPath, specifiedEnvVars, _, _ := remoteRunner.RunScriptWithEnvArgsForCall(0)
+				Expect(specifiedCtx).To(Equal(ctx))
 				Expect(specifiedScriptPath).To(Equal("/var/vcap/jobs/jobname/bin/bbr/backup"))
 				Expect(specifiedEnvVars).To(SatisfyAll(
 					HaveLen(2),
@@ -407,7 +493,7 @@ var _ = Describe("Job", func() {
 		var restoreError error
 
 		JustBeforeEach(func() {
-			restoreError = job.Restore()
+			restoreError = job.Restore(ctx)
 		})
 
 		Context("job has no restore script", func() {
@@ -432,7 +518,7 @@ var _ = Describe("Job", func() {
 			It("uses the remote runner to run the script", func() {
 				Expect(remoteRunner.RunScriptWithEnvCallCount()).To(Equal(1))
 
-				specifiedScriptPath, specifiedEnvVars, _, _ := remoteRunner.RunScriptWithEnvArgsForCall(0)
+				_, specifiedScriptPath, specifiedEnvVars, _, _ := remoteRunner.RunScriptWithEnvArgsForCall(0)
 				Expect(specifiedScriptPath).To(Equal("/var/vcap/jobs/jobname/bin/bbr/restore"))
 				Expect(specifiedEnvVars).To(SatisfyAll(
 					HaveLen(2),
@@ -468,7 +554,7 @@ var _ = Describe("Job", func() {
 		var preBackupLockError error
 
 		JustBeforeEach(func() {
-			preBackupLockError = job.PreBackupLock()
+			preBackupLockError = job.PreBackupLock(ctx)
 		})
 
 		Context("job has no pre-backup-lock script", func() {
@@ -493,7 +579,8 @@ var _ = Describe("Job", func() {
 			It("runs the script", func() {
 				By("calling the remote runner", func() {
 					Expect(remoteRunner.RunScriptCallCount()).To(Equal(1))
-					cmd, _ := remoteRunner.RunScriptArgsForCall(0)
+					specifiedCtx, cmd, _ := remoteRunner.RunScriptArgsForCall(0)
+					Expect(specifiedCtx).To(Equal(ctx))
 					Expect(cmd).To(Equal("/var/vcap/jobs/jobname/bin/bbr/pre-backup-lock"))
 				})
 
@@ -548,7 +635,7 @@ var _ = Describe("Job", func() {
 		})
 
 		JustBeforeEach(func() {
-			postBackupUnlockError = job.PostBackupUnlock(afterSuccessfulBackup)
+			postBackupUnlockError = job.PostBackupUnlock(ctx, afterSuccessfulBackup)
 		})
 
 		Context("job has no post-backup-unlock script", func() {
@@ -573,7 +660,7 @@ var _ = Describe("Job", func() {
 
 				It("uses remote runner to run the script", func() {
 					Expect(remoteRunner.RunScriptWithEnvCallCount()).To(Equal(1))
-					cmd, envVars, _, _ := remoteRunner.RunScriptWithEnvArgsForCall(0)
+					_, cmd, envVars, _, _ := remoteRunner.RunScriptWithEnvArgsForCall(0)
 					Expect(cmd).To(Equal("/var/vcap/jobs/jobname/bin/bbr/post-backup-unlock"))
 					Expect(envVars).To(HaveKeyWithValue("BBR_AFTER_BACKUP_SCRIPTS_SUCCESSFUL", "true"))
 				})
@@ -599,7 +686,7 @@ var _ = Describe("Job", func() {
 
 				It("uses remote runner to run the script", func() {
 					Expect(remoteRunner.RunScriptWithEnvCallCount()).To(Equal(1))
-					cmd, envVars, _, _ := remoteRunner.RunScriptWithEnvArgsForCall(0)
+					_, cmd, envVars, _, _ := remoteRunner.RunScriptWithEnvArgsForCall(0)
 					Expect(cmd).To(Equal("/var/vcap/jobs/jobname/bin/bbr/post-backup-unlock"))
 					Expect(envVars).To(HaveKeyWithValue("BBR_AFTER_BACKUP_SCRIPTS_SUCCESSFUL", "false"))
 				})
@@ -628,7 +715,7 @@ var _ = Describe("Job", func() {
 		var preRestoreLockError error
 
 		JustBeforeEach(func() {
-			preRestoreLockError = job.PreRestoreLock()
+			preRestoreLockError = job.PreRestoreLock(ctx)
 		})
 
 		Context("job has no pre-restore-lock script", func() {
@@ -653,7 +740,7 @@ var _ = Describe("Job", func() {
 			It("runs the script", func() {
 				By("using the remote runner", func() {
 					Expect(remoteRunner.RunScriptCallCount()).To(Equal(1))
-					cmd, _ := remoteRunner.RunScriptArgsForCall(0)
+					_, cmd, _ := remoteRunner.RunScriptArgsForCall(0)
 					Expect(cmd).To(Equal("/var/vcap/jobs/jobname/bin/bbr/pre-restore-lock"))
 				})
 
@@ -701,7 +788,7 @@ var _ = Describe("Job", func() {
 		var postRestoreUnlockError error
 
 		JustBeforeEach(func() {
-			postRestoreUnlockError = job.PostRestoreUnlock()
+			postRestoreUnlockError = job.PostRestoreUnlock(ctx)
 		})
 
 		Context("job has no post-restore-unlock script", func() {
@@ -725,7 +812,7 @@ var _ = Describe("Job", func() {
 
 			It("uses the remote runner to run the script", func() {
 				Expect(remoteRunner.RunScriptCallCount()).To(Equal(1))
-				cmd, _ := remoteRunner.RunScriptArgsForCall(0)
+				_, cmd, _ := remoteRunner.RunScriptArgsForCall(0)
 				Expect(cmd).To(Equal("/var/vcap/jobs/jobname/bin/bbr/post-restore-unlock"))
 			})
 
@@ -18,6 +18,22 @@ type Metadata struct {
 	BackupShouldBeLockedBefore  []LockBefore `yaml:"backup_should_be_locked_before"`
 	RestoreShouldBeLockedBefore []LockBefore `yaml:"restore_should_be_locked_before"`
 	SkipBBRScripts              bool         `yaml:"skip_bbr_scripts"`
+	BackupOneRestoreAll         bool         `yaml:"backup_one_restore_all"`
+	BackupOneRestoreAllSource   string       `yaml:"backup_one_restore_all_source"`
+}
+
+const bootstrapBackupSource = "bootstrap"
+
+// IsBackupOneRestoreAllSource reports whether the instance is the one that
+// takes the backup of a backup-one-restore-all job. The source is the
+// bootstrap instance unless the metadata names an instance index or ID.
+func (m Metadata) IsBackupOneRestoreAllSource(instanceIdentifier InstanceIdentifier) bool {
+	if m.BackupOneRestoreAllSource == "" || m.BackupOneRestoreAllSource == bootstrapBackupSource {
+		return instanceIdentifier.Bootstrap
+	}
+
+	return m.BackupOneRestoreAllSource == instanceIdentifier.InstanceIndex ||
+		m.BackupOneRestoreAllSource == instanceIdentifier.InstanceId
 }
 
 func ParseJobMetadata(data string) (*Metadata, error) {
@@ -84,6 +84,39 @@ skip_bbr_scripts: true`
 		Expect(m.BackupShouldBeLockedBefore).To(ConsistOf(expectedLockBefores))
 	})
 
+	It("has optional `backup_one_restore_all` and `backup_one_restore_all_source` fields", func() {
+		rawMetadata := `---
+backup_one_restore_all: true
+backup_one_restore_all_source: "1"`
+
+		m, err := metadataParserFunc(rawMetadata)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(m.BackupOneRestoreAll).To(BeTrue())
+		Expect(m.IsBackupOneRestoreAllSource(InstanceIdentifier{InstanceId: "3e4f0f4c-8a4b-4a5e-9d7e-0f1b2c3d4e5f", InstanceIndex: "1"})).To(BeTrue())
+		Expect(m.IsBackupOneRestoreAllSource(InstanceIdentifier{InstanceId: "9a8b7c6d-5e4f-4a3b-8c2d-1e0f9a8b7c6d", InstanceIndex: "0", Bootstrap: true})).To(BeFalse())
+	})
+
+	It("accepts the ID of the instance as the backup-one-restore-all source", func() {
+		rawMetadata := `---
+backup_one_restore_all: true
+backup_one_restore_all_source: 3e4f0f4c-8a4b-4a5e-9d7e-0f1b2c3d4e5f`
+
+		m, err := metadataParserFunc(rawMetadata)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(m.IsBackupOneRestoreAllSource(InstanceIdentifier{InstanceId: "3e4f0f4c-8a4b-4a5e-9d7e-0f1b2c3d4e5f", InstanceIndex: "1"})).To(BeTrue())
+		Expect(m.IsBackupOneRestoreAllSource(InstanceIdentifier{InstanceId: "9a8b7c6d-5e4f-4a3b-8c2d-1e0f9a8b7c6d", InstanceIndex: "0", Bootstrap: true})).To(BeFalse())
+	})
+
+	It("uses the bootstrap instance as the backup-one-restore-all source by default", func() {
+		m, err := metadataParserFunc("backup_one_restore_all: true")
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(m.IsBackupOneRestoreAllSource(InstanceIdentifier{InstanceId: "9a8b7c6d-5e4f-4a3b-8c2d-1e0f9a8b7c6d", InstanceIndex: "0", Bootstrap: true})).To(BeTrue())
+		Expect(m.IsBackupOneRestoreAllSource(InstanceIdentifier{InstanceId: "3e4f0f4c-8a4b-4a5e-9d7e-0f1b2c3d4e5f", InstanceIndex: "1"})).To(BeFalse())
+	})
+
 	It("errors if either the job name or release are missing from backup_should_be_locked_before", func() {
 		rawMetadata := `---
 backup_name: foo
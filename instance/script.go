@@ -11,6 +11,7 @@ const (
 	backupScriptName            = "backup"
 	restoreScriptName           = "restore"
 	metadataScriptName          = "metadata"
+	backupSizeScriptName        = "backup-size"
 	preBackupLockScriptName     = "pre-backup-lock"
 	preRestoreLockScriptName    = "pre-restore-lock"
 	postBackupUnlockScriptName  = "post-backup-unlock"
@@ -23,6 +24,7 @@ const (
 	backupScriptMatcher            = jobDirectoryMatcher + backupScriptName
 	restoreScriptMatcher           = jobDirectoryMatcher + restoreScriptName
 	metadataScriptMatcher          = jobDirectoryMatcher + metadataScriptName
+	backupSizeScriptMatcher        = jobDirectoryMatcher + backupSizeScriptName
 	preBackupLockScriptMatcher     = jobDirectoryMatcher + preBackupLockScriptName
 	preRestoreLockScriptMatcher    = jobDirectoryMatcher + preRestoreLockScriptName
 	postBackupUnlockScriptMatcher  = jobDirectoryMatcher + postBackupUnlockScriptName
@@ -44,6 +46,11 @@ func (s Script) isMetadata() bool {
 	return match
 }
 
+func (s Script) isBackupSize() bool {
+	match, _ := filepath.Match(backupSizeScriptMatcher, string(s)) //nolint:errcheck
+	return match
+}
+
 func (s Script) isPreBackupUnlock() bool {
 	match, _ := filepath.Match(preBackupLockScriptMatcher, string(s)) //nolint:errcheck
 	return match
@@ -81,7 +88,8 @@ func (s Script) isPlatformScript() bool {
 		s.isPreRestoreLock() ||
 		s.isPostBackupUnlock() ||
 		s.isPostRestoreUnlock() ||
-		s.isMetadata()
+		s.isMetadata() ||
+		s.isBackupSize()
 }
 
 func (s Script) splitPath() []string {
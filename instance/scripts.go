@@ -44,6 +44,16 @@ func (s BackupAndRestoreScripts) MetadataOnly() BackupAndRestoreScripts {
 	return scripts
 }
 
+func (s BackupAndRestoreScripts) BackupSizeOnly() BackupAndRestoreScripts {
+	scripts := BackupAndRestoreScripts{}
+	for _, script := range s {
+		if script.isBackupSize() {
+			scripts = append(scripts, script)
+		}
+	}
+	return scripts
+}
+
 func (s BackupAndRestoreScripts) RestoreOnly() BackupAndRestoreScripts {
 	scripts := BackupAndRestoreScripts{}
 	for _, script := range s {
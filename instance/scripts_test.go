@@ -146,6 +146,17 @@ var _ = Describe("Backup and Restore Scripts", func() {
 				}))
 			})
 		})
+
+		Context("BackupSize", func() {
+			It("returns the matching scripts", func() {
+				var allScripts = []string{"/var/vcap/jobs/cloud_controller_clock/bin/baz",
+					"/var/vcap/jobs/cloud_controller_clock/bin/bbr/backup-size",
+					"/var/vcap/jobs/cloud_controller_clock/bin/pre-start"}
+				Expect(NewBackupAndRestoreScripts(allScripts)).To(Equal(BackupAndRestoreScripts{
+					"/var/vcap/jobs/cloud_controller_clock/bin/bbr/backup-size",
+				}))
+			})
+		})
 	})
 
 	Describe("BackupOnly", func() {
@@ -235,6 +246,20 @@ var _ = Describe("Backup and Restore Scripts", func() {
 		})
 	})
 
+	Describe("BackupSizeOnly", func() {
+		It("returns the backup-size script", func() {
+			s := BackupAndRestoreScripts{"/var/vcap/jobs/cloud_controller_clock/bin/bbr/backup",
+				"/var/vcap/jobs/cloud_controller_clock/bin/bbr/backup-size",
+				"/var/vcap/jobs/cloud_controller_clock/bin/pre-start"}
+			Expect(s.BackupSizeOnly()).To(Equal(BackupAndRestoreScripts{"/var/vcap/jobs/cloud_controller_clock/bin/bbr/backup-size"}))
+		})
+
+		It("returns empty when it has none", func() {
+			s := BackupAndRestoreScripts{"/var/vcap/jobs/cloud_controller_clock/bin/bbr/backup"}
+			Expect(s.BackupSizeOnly()).To(Equal(BackupAndRestoreScripts{}))
+		})
+	})
+
 	Describe("PreBackupLockOnly", func() {
 		It("returns the pre-backup-lock scripts when it only has one", func() {
 			s := BackupAndRestoreScripts{"/var/vcap/jobs/cloud_controller_clock/bin/baz",
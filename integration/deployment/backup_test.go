@@ -1,6 +1,7 @@
 package deployment
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
@@ -10,9 +11,11 @@ import (
 	"strings"
 	"time"
 
+	"github.com/cloudfoundry/bosh-backup-and-restore/errorreport"
 	. "github.com/cloudfoundry/bosh-backup-and-restore/integration"
 	"github.com/cloudfoundry/bosh-backup-and-restore/internal/cf-webmock/mockbosh"
 	"github.com/cloudfoundry/bosh-backup-and-restore/internal/cf-webmock/mockhttp"
+	"github.com/cloudfoundry/bosh-backup-and-restore/orchestrator"
 	"github.com/cloudfoundry/bosh-backup-and-restore/testcluster"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -192,12 +195,14 @@ printf "backupcontent2" > $BBR_ARTIFACT_DIRECTORY/backupdump2
 
 						fmt.Fprintln(stdin, "yes") //nolint:errcheck
 
-						By("then exiting with a failure", func() {
-							Eventually(session, 10).Should(gexec.Exit(1))
+						By("stopping the backup", func() {
+							Eventually(session).Should(gbytes.Say("Stopping the backup. bbr will unlock and clean up before exiting"))
 						})
 
-						By("outputting a warning about cleanup", func() {
-							Eventually(session).Should(gbytes.Say("It is recommended that you run `bbr backup-cleanup` to ensure that any temp files are cleaned up and all jobs are unlocked."))
+						By("then exiting with a failure once the deployment is unlocked and cleaned up", func() {
+							Eventually(session, 20).Should(gexec.Exit(99))
+							Expect(session.Err).To(gbytes.Say("Aborted: received interrupt"))
+							Expect(instance1.FileExists("/var/vcap/store/bbr-backup")).To(BeFalse())
 						})
 
 						By("not creating an artifact tar from the interrupted backup script", func() {
@@ -207,6 +212,27 @@ printf "backupcontent2" > $BBR_ARTIFACT_DIRECTORY/backupdump2
 					})
 				})
 
+				Context("and a second signal is received", func() {
+					BeforeEach(func() {
+						verifyMocks = false
+					})
+
+					It("exits immediately", func() {
+						Eventually(session, "30s").Should(gbytes.Say("Backing up"))
+						session.Terminate()
+						Eventually(session).Should(gbytes.Say("Stopping the backup"))
+						session.Terminate()
+
+						By("exiting with a failure", func() {
+							Eventually(session, 2).Should(gexec.Exit(1))
+						})
+
+						By("outputting a warning about cleanup", func() {
+							Eventually(session).Should(gbytes.Say("It is recommended that you run `bbr backup-cleanup` to ensure that any temp files are cleaned up and all jobs are unlocked."))
+						})
+					})
+				})
+
 				Context("and the user decides to continue backup", func() {
 					It("continues to run", func() {
 						session.Interrupt()
@@ -233,6 +259,50 @@ printf "backupcontent2" > $BBR_ARTIFACT_DIRECTORY/backupdump2
 				})
 			})
 
+			Context("and the bbr process receives SIGTERM while backing up", func() {
+				BeforeEach(func() {
+					waitForBackupToFinish = false
+					verifyMocks = false
+
+					MockDirectorWith(director,
+						mockbosh.Info().WithAuthTypeBasic(),
+						VmsForDeployment(deploymentName, singleInstanceResponse("redis-dedicated-node")),
+						DownloadManifest(deploymentName, manifest),
+						SetupSSH(deploymentName, "redis-dedicated-node", "fake-uuid", 0, instance1),
+						CleanupSSH(deploymentName, "redis-dedicated-node"))
+
+					instance1.CreateScript("/var/vcap/jobs/redis/bin/bbr/backup", `#!/usr/bin/env sh
+
+						set -u
+
+						sleep 5
+
+						printf "backupcontent1" > $BBR_ARTIFACT_DIRECTORY/backupdump1
+					`)
+				})
+
+				It("stops the backup without asking for confirmation", func() {
+					Eventually(session, "30s").Should(gbytes.Say("Backing up"))
+					session.Terminate()
+
+					By("not asking for confirmation", func() {
+						Eventually(session).Should(gbytes.Say("Stopping the backup. bbr will unlock and clean up before exiting"))
+						Expect(string(session.Out.Contents())).NotTo(ContainSubstring("[yes/no]"))
+					})
+
+					By("exiting with a failure once the deployment is cleaned up", func() {
+						Eventually(session, 20).Should(gexec.Exit(99))
+						Expect(session.Err).To(gbytes.Say("Aborted: received terminated"))
+						Expect(instance1.FileExists("/var/vcap/store/bbr-backup")).To(BeFalse())
+					})
+
+					By("not creating an artifact tar from the interrupted backup script", func() {
+						boshBackupFilePath := path.Join(backupDirectory(), "/redis-dedicated-node-0-redis.tar")
+						Expect(boshBackupFilePath).NotTo(BeAnExistingFile())
+					})
+				})
+			})
+
 			Context("and we don't ask for the manifest to be downloaded", func() {
 				BeforeEach(func() {
 					MockDirectorWith(director,
@@ -853,8 +923,8 @@ echo "Unlocking release"`)
 			})
 
 			It("errors and exits gracefully", func() {
-				By("returning exit code 1", func() {
-					Expect(session.ExitCode()).To(Equal(1))
+				By("returning exit code 35", func() {
+					Expect(session.ExitCode()).To(Equal(35))
 				})
 
 				By("running the the post-backup-unlock scripts", func() {
@@ -885,8 +955,8 @@ echo "Unlocking release"`)
 			})
 
 			It("exits correctly and prints an error", func() {
-				By("returning exit code 17 (16 + 1)", func() {
-					Expect(session.ExitCode()).To(Equal(17))
+				By("returning exit code 51 (16 + 32 + 2 + 1)", func() {
+					Expect(session.ExitCode()).To(Equal(51))
 				})
 
 				By("printing an error", func() {
@@ -900,6 +970,30 @@ echo "Unlocking release"`)
 				By("printing a recommendation to run bbr backup-cleanup", func() {
 					Expect(session.Err).To(gbytes.Say("It is recommended that you run `bbr backup-cleanup`"))
 				})
+
+				By("writing an error report", func() {
+					files, err := filepath.Glob(filepath.Join(backupWorkspace, "bbr-*.err.json"))
+					Expect(err).NotTo(HaveOccurred())
+					Expect(files).To(HaveLen(1))
+					contents, err := os.ReadFile(files[0])
+					Expect(err).NotTo(HaveOccurred())
+
+					var report errorreport.Report
+					Expect(json.Unmarshal(contents, &report)).To(Succeed())
+					Expect(report.Command).To(Equal("backup"))
+					Expect(report.ExitCode).To(Equal(51))
+					Expect(report.CleanupCommand).To(Equal(fmt.Sprintf("bbr deployment --target %s --username admin --ca-cert %s --deployment %s backup-cleanup", director.URL, sslCertPath, deploymentName)))
+					Expect(report.Errors).To(ContainElement(And(
+						HaveField("Category", orchestrator.BackupScriptCategory),
+						HaveField("Deployment", deploymentName),
+						HaveField("Instance", "redis-dedicated-node/fake-uuid"),
+						HaveField("Job", "redis"),
+						HaveField("Phase", "backup"),
+						HaveField("ExitCode", 1),
+						HaveField("Stderr", ContainSubstring("ultra-baz")),
+					)))
+					Expect(report.Errors).To(ContainElement(HaveField("Category", orchestrator.CleanupCategory)))
+				})
 			})
 		})
 
@@ -965,7 +1059,7 @@ echo "not valid yaml
 				})
 
 				By("exiting with the correct error code", func() {
-					Expect(session).To(gexec.Exit(1))
+					Expect(session).To(gexec.Exit(3))
 				})
 
 				By("not printing a recommendation to run bbr backup-cleanup", func() {
@@ -999,7 +1093,7 @@ skip_bbr_scripts: true
 
 			It("Should exit say no bbr jobs found", func() {
 				By("exiting with an error", func() {
-					Expect(session).To(gexec.Exit(1))
+					Expect(session).To(gexec.Exit(33))
 				})
 
 				By("printing a helpful error message", func() {
@@ -1165,7 +1259,7 @@ backup_should_be_locked_before:
 
 				It("Should fail", func() {
 					By("exiting with an error", func() {
-						Expect(session).To(gexec.Exit(1))
+						Expect(session).To(gexec.Exit(33))
 					})
 
 					By("printing a helpful error message", func() {
@@ -1423,8 +1517,8 @@ touch /tmp/backup-script-was-run
 		})
 
 		It("errors and exits", func() {
-			By("returning exit code 1", func() {
-				Expect(session.ExitCode()).To(Equal(1))
+			By("returning exit code 3", func() {
+				Expect(session.ExitCode()).To(Equal(3))
 			})
 
 			By("printing an error", func() {
@@ -3,6 +3,7 @@ package deployment
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"github.com/cloudfoundry/bosh-backup-and-restore/internal/cf-webmock/mockbosh"
 	"github.com/cloudfoundry/bosh-backup-and-restore/internal/cf-webmock/mockhttp"
@@ -94,6 +95,55 @@ var _ = Describe("CLI Interface", func() {
 					director.VerifyMocks()
 				})
 
+				It("can invoke command with a profile of the config file", func() {
+					director.VerifyAndMock(
+						mockbosh.Info().WithAuthTypeBasic(),
+						mockbosh.VMsForDeployment("my-new-deployment").NotFound(),
+					)
+					configPath, err := filepath.Abs(filepath.Join(backupWorkspace, "bbr.yml"))
+					Expect(err).NotTo(HaveOccurred())
+					Expect(os.WriteFile(configPath, []byte(fmt.Sprintf(`---
+profiles:
+  prod:
+    target: %s
+    username: admin
+    password: {env: PROD_CLIENT_SECRET}
+    ca_cert: {file: %s}
+    deployment: my-new-deployment
+`, director.URL, sslCertPath)), 0600)).To(Succeed())
+
+					binary.Run(backupWorkspace,
+						[]string{"PROD_CLIENT_SECRET=admin"},
+						append([]string{"deployment", "--config", configPath, "--profile", "prod", cmd}, extraArgs...)...)
+
+					director.VerifyMocks()
+				})
+
+				It("prefers flags over the config file", func() {
+					director.VerifyAndMock(
+						mockbosh.Info().WithAuthTypeBasic(),
+						mockbosh.VMsForDeployment("my-new-deployment").NotFound(),
+					)
+					configPath, err := filepath.Abs(filepath.Join(backupWorkspace, "bbr.yml"))
+					Expect(err).NotTo(HaveOccurred())
+					Expect(os.WriteFile(configPath, []byte(fmt.Sprintf(`---
+default_profile: prod
+profiles:
+  prod:
+    target: %s
+    username: admin
+    password: wrong-password
+    ca_cert: %s
+    deployment: another-deployment
+`, director.URL, sslCertPath)), 0600)).To(Succeed())
+
+					binary.Run(backupWorkspace,
+						[]string{fmt.Sprintf("BBR_CONFIG=%s", configPath)},
+						append([]string{"deployment", "--password", "admin", "--deployment", "my-new-deployment", cmd}, extraArgs...)...)
+
+					director.VerifyMocks()
+				})
+
 				It("can invoke command with the CA_CERT environment variable", func() {
 					director.VerifyAndMock(
 						mockbosh.Info().WithAuthTypeBasic(),
@@ -0,0 +1,296 @@
+package deployment
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/cloudfoundry/bosh-backup-and-restore/bbr"
+	. "github.com/cloudfoundry/bosh-backup-and-restore/integration"
+	"github.com/cloudfoundry/bosh-backup-and-restore/internal/cf-webmock/mockbosh"
+	"github.com/cloudfoundry/bosh-backup-and-restore/internal/cf-webmock/mockhttp"
+	"github.com/cloudfoundry/bosh-backup-and-restore/testcluster"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Library", func() {
+	const deploymentName = "my-little-deployment"
+	const manifest = `---
+instance_groups:
+- name: redis-dedicated-node
+  instances: 1
+  jobs:
+  - name: redis
+    release: redis
+`
+
+	var (
+		director    *mockhttp.Server
+		instance1   *testcluster.Instance
+		workspace   string
+		verifyMocks bool
+		events      []bbr.Event
+		options     bbr.Options
+		target      bbr.Target
+	)
+
+	stepEvents := func(eventType bbr.EventType) []string {
+		var steps []string
+		for _, event := range events {
+			if event.Type == eventType {
+				steps = append(steps, event.Step)
+			}
+		}
+		return steps
+	}
+
+	BeforeEach(func() {
+		director = mockbosh.NewTLS()
+		director.ExpectedBasicAuth("admin", "admin")
+		instance1 = testcluster.NewInstance(fixturesDir)
+		verifyMocks = true
+
+		var err error
+		workspace, err = os.MkdirTemp(".", "library-workspace-")
+		Expect(err).NotTo(HaveOccurred())
+
+		events = nil
+		options = bbr.Options{
+			LogWriter: GinkgoWriter,
+			Debug:     true,
+			Version:   bbrVersion,
+			Events: bbr.EventHandlerFunc(func(event bbr.Event) {
+				events = append(events, event)
+			}),
+		}
+		target = bbr.Target{URL: director.URL, Username: "admin", Password: "admin", CACert: sslCertPath}
+
+		MockDirectorWith(director,
+			mockbosh.Info().WithAuthTypeBasic(),
+			VmsForDeployment(deploymentName, []mockbosh.VMsOutput{{
+				IPs:     []string{"10.0.0.1"},
+				JobName: "redis-dedicated-node",
+				Index:   newIndex(0),
+				ID:      "fake-uuid",
+			}}),
+			DownloadManifest(deploymentName, manifest),
+			SetupSSH(deploymentName, "redis-dedicated-node", "fake-uuid", 0, instance1),
+			CleanupSSH(deploymentName, "redis-dedicated-node"))
+	})
+
+	AfterEach(func() {
+		if verifyMocks {
+			director.VerifyMocks()
+		}
+		director.Close()
+
+		instance1.DieInBackground()
+		Expect(os.RemoveAll(workspace)).To(Succeed())
+	})
+
+	Describe("BackupDeployment", func() {
+		BeforeEach(func() {
+			instance1.CreateScript("/var/vcap/jobs/redis/bin/bbr/pre-backup-lock", `#!/usr/bin/env sh
+touch /tmp/pre-backup-lock-script-was-run
+`)
+			instance1.CreateScript("/var/vcap/jobs/redis/bin/bbr/post-backup-unlock", `#!/usr/bin/env sh
+touch /tmp/post-backup-unlock-script-was-run
+`)
+		})
+
+		Context("when the backup script succeeds", func() {
+			var result bbr.Result
+
+			BeforeEach(func() {
+				instance1.CreateScript("/var/vcap/jobs/redis/bin/bbr/backup", `#!/usr/bin/env sh
+set -u
+printf "backupcontent1" > $BBR_ARTIFACT_DIRECTORY/backupdump1
+`)
+			})
+
+			JustBeforeEach(func() {
+				result = bbr.BackupDeployment(context.Background(), bbr.DeploymentBackupOptions{
+					Options:      options,
+					Target:       target,
+					Deployment:   deploymentName,
+					ArtifactPath: workspace,
+				})
+			})
+
+			It("backs up the deployment into the artifact of the result", func() {
+				Expect(result.Err).NotTo(HaveOccurred())
+				Expect(result.Operation).To(Equal(bbr.Backup))
+				Expect(result.Deployment).To(Equal(deploymentName))
+				Expect(result.ExitCode).To(BeZero())
+				Expect(result.CleanupAdvised).To(BeFalse())
+
+				By("creating the backup in the directory named by the result", func() {
+					Expect(possibleBackupDirectories(deploymentName, workspace)).To(ConsistOf(filepath.Base(result.Artifact)))
+					Expect(filepath.Dir(result.Artifact)).To(Equal(workspace))
+
+					archive := OpenTarArchive(filepath.Join(result.Artifact, "redis-dedicated-node-0-redis.tar"))
+					Expect(archive.Files()).To(ConsistOf("backupdump1"))
+					Expect(archive.FileContents("backupdump1")).To(Equal("backupcontent1"))
+				})
+
+				By("locking, unlocking and cleaning up the instance", func() {
+					Expect(instance1.FileExists("/tmp/pre-backup-lock-script-was-run")).To(BeTrue())
+					Expect(instance1.FileExists("/tmp/post-backup-unlock-script-was-run")).To(BeTrue())
+					Expect(instance1.FileExists("/var/vcap/store/bbr-backup")).To(BeFalse())
+				})
+			})
+
+			It("reports each step of the backup as it starts and finishes", func() {
+				steps := []string{
+					"FindDeploymentStep",
+					"BackupableStep",
+					"CreateArtifactStep",
+					"HookStep",
+					"LockStep",
+					"HookStep",
+					"BackupStep",
+					"PostBackupUnlockStep",
+					"DrainStep",
+					"HookStep",
+					"CleanupStep",
+					"AddFinishTimeStep",
+				}
+				Expect(stepEvents(bbr.StepStarted)).To(Equal(steps))
+				Expect(stepEvents(bbr.StepFinished)).To(Equal(steps))
+
+				Expect(events[0].Type).To(Equal(bbr.OperationStarted))
+				for i := 1; i < len(events)-1; i += 2 {
+					Expect(events[i].Type).To(Equal(bbr.StepStarted))
+					Expect(events[i+1].Type).To(Equal(bbr.StepFinished))
+					Expect(events[i+1].Step).To(Equal(events[i].Step))
+					Expect(events[i+1].Err).NotTo(HaveOccurred())
+				}
+
+				finished := events[len(events)-1]
+				Expect(finished.Type).To(Equal(bbr.OperationFinished))
+				Expect(finished.Result).NotTo(BeNil())
+				Expect(finished.Result.Artifact).To(Equal(result.Artifact))
+			})
+		})
+
+		Context("when the context is cancelled while the backup script runs", func() {
+			var result bbr.Result
+
+			BeforeEach(func() {
+				verifyMocks = false
+				instance1.CreateScript("/var/vcap/jobs/redis/bin/bbr/backup", `#!/usr/bin/env sh
+set -u
+sleep 10
+printf "backupcontent1" > $BBR_ARTIFACT_DIRECTORY/backupdump1
+`)
+			})
+
+			JustBeforeEach(func() {
+				ctx, cancel := context.WithCancelCause(context.Background())
+				defer cancel(nil)
+
+				cancellingOptions := options
+				cancellingOptions.Events = bbr.EventHandlerFunc(func(event bbr.Event) {
+					options.Events.HandleEvent(event)
+					if event.Type == bbr.StepStarted && event.Step == "BackupStep" {
+						time.AfterFunc(time.Second, func() { cancel(errors.New("stopped by the test")) })
+					}
+				})
+
+				result = bbr.BackupDeployment(ctx, bbr.DeploymentBackupOptions{
+					Options:      cancellingOptions,
+					Target:       target,
+					Deployment:   deploymentName,
+					ArtifactPath: workspace,
+				})
+			})
+
+			It("stops the backup and returns an abort", func() {
+				Expect(result.Err).To(MatchError(ContainSubstring("Aborted: stopped by the test")))
+				Expect(result.Category).To(Equal("abort"))
+				Expect(result.ExitCode).To(Equal(99))
+				Expect(result.FinishTime.Sub(result.StartTime)).To(BeNumerically("<", 10*time.Second))
+
+				By("unlocking the instance", func() {
+					Expect(stepEvents(bbr.StepFinished)).To(ContainElement("PostBackupUnlockStep"))
+					Expect(instance1.FileExists("/tmp/post-backup-unlock-script-was-run")).To(BeTrue())
+				})
+
+				By("cleaning up the instance", func() {
+					Expect(stepEvents(bbr.StepFinished)).To(ContainElement("CleanupStep"))
+					Expect(instance1.FileExists("/var/vcap/store/bbr-backup")).To(BeFalse())
+				})
+
+				By("not draining the backup", func() {
+					Expect(stepEvents(bbr.StepStarted)).NotTo(ContainElement("DrainStep"))
+					Expect(filepath.Join(result.Artifact, "redis-dedicated-node-0-redis.tar")).NotTo(BeAnExistingFile())
+				})
+			})
+		})
+	})
+
+	Describe("RestoreDeployment", func() {
+		var result bbr.Result
+
+		BeforeEach(func() {
+			instance1.CreateScript("/var/vcap/jobs/redis/bin/bbr/pre-restore-lock", `#!/usr/bin/env sh
+touch /tmp/pre-restore-lock-script-was-run
+`)
+			instance1.CreateScript("/var/vcap/jobs/redis/bin/bbr/restore", `#!/usr/bin/env sh
+set -u
+cp -r $BBR_ARTIFACT_DIRECTORY* /var/vcap/store/redis-server
+touch /tmp/restore-script-was-run
+`)
+			instance1.CreateScript("/var/vcap/jobs/redis/bin/bbr/post-restore-unlock", `#!/usr/bin/env sh
+touch /tmp/post-restore-unlock-script-was-run
+`)
+
+			artifact := filepath.Join(workspace, deploymentName)
+			Expect(os.Mkdir(artifact, 0777)).To(Succeed())
+			createFileWithContents(filepath.Join(artifact, "metadata"), []byte(`---
+instances:
+- name: redis-dedicated-node
+  index: 0
+  artifacts:
+  - name: redis
+    checksums:
+      ./redis/redis-backup: 8d7fa73732d6dba6f6af01621552d3a6d814d2042c959465d0562a97c3f796b0`))
+
+			backupContents, err := os.ReadFile(filepath.Join(fixturesDir, "backup.tar"))
+			Expect(err).NotTo(HaveOccurred())
+			createFileWithContents(filepath.Join(artifact, "redis-dedicated-node-0-redis.tar"), backupContents)
+		})
+
+		JustBeforeEach(func() {
+			result = bbr.RestoreDeployment(context.Background(), bbr.DeploymentRestoreOptions{
+				Options:      options,
+				Target:       target,
+				Deployment:   deploymentName,
+				ArtifactPath: filepath.Join(workspace, deploymentName),
+			})
+		})
+
+		It("restores the artifact of the options", func() {
+			Expect(result.Err).NotTo(HaveOccurred())
+			Expect(result.Operation).To(Equal(bbr.Restore))
+			Expect(result.Artifact).To(Equal(filepath.Join(workspace, deploymentName)))
+			Expect(result.CleanupAdvised).To(BeFalse())
+
+			Expect(instance1.FileExists("/tmp/pre-restore-lock-script-was-run")).To(BeTrue())
+			Expect(instance1.FileExists("/var/vcap/store/redis-server/redis-backup")).To(BeTrue())
+			Expect(instance1.FileExists("/tmp/restore-script-was-run")).To(BeTrue())
+			Expect(instance1.FileExists("/tmp/post-restore-unlock-script-was-run")).To(BeTrue())
+			Expect(instance1.FileExists("/var/vcap/store/bbr-backup")).To(BeFalse())
+		})
+
+		It("reports the steps of the restore", func() {
+			Expect(events[0].Type).To(Equal(bbr.OperationStarted))
+			Expect(stepEvents(bbr.StepStarted)).To(ContainElements("PreRestoreLockStep", "RestoreStep", "PostRestoreUnlockStep", "CleanupStep"))
+			Expect(stepEvents(bbr.StepFinished)).To(Equal(stepEvents(bbr.StepStarted)))
+			Expect(events[len(events)-1].Type).To(Equal(bbr.OperationFinished))
+		})
+	})
+})
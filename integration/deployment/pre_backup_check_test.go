@@ -141,7 +141,7 @@ backup_should_be_locked_before:
 
 					It("Should fail", func() {
 						By("exiting with an error", func() {
-							Expect(session).To(gexec.Exit(1))
+							Expect(session).To(gexec.Exit(33))
 						})
 
 						By("printing a helpful error message", func() {
@@ -155,8 +155,8 @@ backup_should_be_locked_before:
 						instance1.CreateDir("/var/vcap/store/bbr-backup")
 					})
 
-					It("returns exit code 1", func() {
-						Expect(session.ExitCode()).To(Equal(1))
+					It("returns exit code 97", func() {
+						Expect(session.ExitCode()).To(Equal(97))
 					})
 
 					It("prints an error with a backup-cleanup footer", func() {
@@ -183,8 +183,8 @@ backup_should_be_locked_before:
 					)
 				})
 
-				It("returns exit code 1", func() {
-					Expect(session.ExitCode()).To(Equal(1))
+				It("returns exit code 33", func() {
+					Expect(session.ExitCode()).To(Equal(33))
 				})
 
 				It("prints an error", func() {
@@ -193,8 +193,8 @@ backup_should_be_locked_before:
 					Expect(string(session.Err.Contents())).NotTo(ContainSubstring("main.go"))
 				})
 
-				It("writes the stack trace", func() {
-					files, err := filepath.Glob(filepath.Join(backupWorkspace, "bbr-*.err.log"))
+				It("writes the error report", func() {
+					files, err := filepath.Glob(filepath.Join(backupWorkspace, "bbr-*.err.json"))
 					Expect(err).NotTo(HaveOccurred())
 					logFilePath := files[0]
 					_, err = os.Stat(logFilePath)
@@ -216,8 +216,8 @@ backup_should_be_locked_before:
 				)
 			})
 
-			It("returns exit code 1", func() {
-				Expect(session.ExitCode()).To(Equal(1))
+			It("returns exit code 3", func() {
+				Expect(session.ExitCode()).To(Equal(3))
 			})
 
 			It("prints an error", func() {
@@ -237,8 +237,8 @@ backup_should_be_locked_before:
 				)
 			})
 
-			It("returns exit code 1", func() {
-				Expect(session.ExitCode()).To(Equal(1))
+			It("returns exit code 3", func() {
+				Expect(session.ExitCode()).To(Equal(3))
 			})
 
 			It("prints an error", func() {
@@ -370,7 +370,7 @@ backup_should_be_locked_before:
 			})
 
 			It("fails and outputs a log message saying which deployments can be backed up", func() {
-				Expect(session.ExitCode()).To(Equal(1))
+				Expect(session.ExitCode()).To(Equal(97))
 
 				Expect(session.Out).To(gbytes.Say("Deployment '" + deploymentName1 + "' cannot be backed up."))
 				Expect(session.Out).To(gbytes.Say("Directory /var/vcap/store/bbr-backup already exists on instance redis-dedicated-node/fake-uuid"))
@@ -0,0 +1,109 @@
+package deployment
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/cloudfoundry/bosh-backup-and-restore/internal/cf-webmock/mockbosh"
+	"github.com/cloudfoundry/bosh-backup-and-restore/internal/cf-webmock/mockhttp"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("Pre-restore checks", func() {
+	var director *mockhttp.Server
+	var restoreWorkspace string
+	var session *gexec.Session
+	deploymentName := "my-new-deployment"
+
+	runPreRestoreCheck := func(args ...string) *gexec.Session {
+		return binary.Run(
+			restoreWorkspace,
+			[]string{"BOSH_CLIENT_SECRET=admin", fmt.Sprintf("PATH=%s", os.Getenv("PATH"))},
+			append([]string{
+				"deployment",
+				"--ca-cert", sslCertPath,
+				"--username", "admin",
+				"--target", director.URL,
+			}, args...)...,
+		)
+	}
+
+	BeforeEach(func() {
+		director = mockbosh.NewTLS()
+		director.ExpectedBasicAuth("admin", "admin")
+		var err error
+		restoreWorkspace, err = os.MkdirTemp(".", "restore-workspace-")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(restoreWorkspace)).To(Succeed())
+		director.VerifyMocks()
+	})
+
+	Context("when the artifact is not present", func() {
+		BeforeEach(func() {
+			director.VerifyAndMock(mockbosh.Info().WithAuthTypeBasic())
+			session = runPreRestoreCheck("--deployment", deploymentName, "pre-restore-check", "--artifact-path", "i-am-not-here")
+		})
+
+		It("reports that the deployment cannot be restored", func() {
+			Expect(session.ExitCode()).To(Equal(1))
+			Expect(session.Out).To(gbytes.Say("Deployment '%s' cannot be restored.", deploymentName))
+			Expect(session.Err).To(gbytes.Say("i-am-not-here: no such file or directory"))
+		})
+	})
+
+	Context("when the backup is corrupted", func() {
+		BeforeEach(func() {
+			director.VerifyAndMock(mockbosh.Info().WithAuthTypeBasic())
+
+			Expect(os.Mkdir(filepath.Join(restoreWorkspace, deploymentName), 0777)).To(Succeed())
+			createFileWithContents(filepath.Join(restoreWorkspace, deploymentName, "metadata"), []byte(`---
+instances:
+- name: redis-dedicated-node
+  index: 0
+  artifacts:
+  - name: redis
+    checksums:
+      redis-backup: this-is-not-a-checksum-this-is-only-a-tribute`))
+			backupContents, err := os.ReadFile(filepath.Join(fixturesDir, "backup.tar"))
+			Expect(err).NotTo(HaveOccurred())
+			createFileWithContents(filepath.Join(restoreWorkspace, deploymentName, "redis-dedicated-node-0-redis.tar"), backupContents)
+
+			session = runPreRestoreCheck("--deployment", deploymentName, "pre-restore-check", "--artifact-path", deploymentName)
+		})
+
+		It("fails without finding the deployment", func() {
+			Expect(session.ExitCode()).To(Equal(65))
+			Expect(session.Err).To(gbytes.Say("Backup is corrupted"))
+		})
+	})
+
+	Context("when run with --all-deployments", func() {
+		BeforeEach(func() {
+			session = runPreRestoreCheck("--all-deployments", "pre-restore-check", "--artifact-path", deploymentName)
+		})
+
+		It("fails", func() {
+			Expect(session.ExitCode()).To(Equal(1))
+			Expect(session.Err).To(gbytes.Say("Cannot use the --all-deployments flag with pre-restore-check"))
+		})
+	})
+
+	Context("when the artifact path is not provided", func() {
+		BeforeEach(func() {
+			session = runPreRestoreCheck("--deployment", deploymentName, "pre-restore-check")
+		})
+
+		It("fails", func() {
+			Expect(session.ExitCode()).To(Equal(1))
+			Expect(session.Err).To(gbytes.Say("--artifact-path flag is required"))
+		})
+	})
+})
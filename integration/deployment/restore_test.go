@@ -101,7 +101,7 @@ instances: []`))
 
 		It("fails and prints an error", func() {
 			By("failing", func() {
-				Expect(session.ExitCode()).To(Equal(1))
+				Expect(session.ExitCode()).To(Equal(3))
 			})
 
 			By("printing an error", func() {
@@ -112,8 +112,8 @@ instances: []`))
 				Expect(string(session.Err.Contents())).NotTo(ContainSubstring("main.go"))
 			})
 
-			By("writes the stack trace", func() {
-				files, err := filepath.Glob(filepath.Join(restoreWorkspace, "bbr-*.err.log"))
+			By("writes the error report", func() {
+				files, err := filepath.Glob(filepath.Join(restoreWorkspace, "bbr-*.err.json"))
 				Expect(err).NotTo(HaveOccurred())
 				logFilePath := files[0]
 				_, err = os.Stat(logFilePath)
@@ -156,8 +156,8 @@ instances: []`))
 				Expect(string(session.Err.Contents())).NotTo(ContainSubstring("main.go"))
 			})
 
-			By("writes the stack trace", func() {
-				files, err := filepath.Glob(filepath.Join(restoreWorkspace, "bbr-*.err.log"))
+			By("writes the error report", func() {
+				files, err := filepath.Glob(filepath.Join(restoreWorkspace, "bbr-*.err.json"))
 				Expect(err).NotTo(HaveOccurred())
 				logFilePath := files[0]
 				_, err = os.Stat(logFilePath)
@@ -205,7 +205,7 @@ instances:
 
 		It("fails and prints an error", func() {
 			By("failing", func() {
-				Expect(session.ExitCode()).To(Equal(1))
+				Expect(session.ExitCode()).To(Equal(65))
 			})
 
 			By("logging the steps it takes", func() {
@@ -221,8 +221,8 @@ instances:
 				Expect(string(session.Err.Contents())).NotTo(ContainSubstring("main.go"))
 			})
 
-			By("writes the stack trace", func() {
-				files, err := filepath.Glob(filepath.Join(restoreWorkspace, "bbr-*.err.log"))
+			By("writes the error report", func() {
+				files, err := filepath.Glob(filepath.Join(restoreWorkspace, "bbr-*.err.json"))
 				Expect(err).NotTo(HaveOccurred())
 				logFilePath := files[0]
 				_, err = os.Stat(logFilePath)
@@ -392,12 +392,13 @@ touch /tmp/restore-script-was-run`)
 
 						stdin.Write([]byte("yes\n")) //nolint:errcheck
 
-						By("waiting for the restore to finish successfully", func() {
-							Eventually(session, 10).Should(gexec.Exit(1))
+						By("exiting with a failure", func() {
+							Eventually(session, 20).Should(gexec.Exit(1))
 						})
 
-						By("outputting a warning about cleanup", func() {
-							Eventually(session).Should(gbytes.Say("It is recommended that you run `bbr restore-cleanup` to ensure that any temp files are cleaned up and all jobs are unlocked."))
+						By("unlocking and cleaning up before exiting", func() {
+							Eventually(session).Should(gbytes.Say("Stopping the restore. bbr will unlock and clean up before exiting"))
+							Expect(session.Err).To(gbytes.Say("Aborted: received interrupt"))
 						})
 
 						By("not completing the restore", func() {
@@ -466,7 +467,7 @@ touch /tmp/restore-script-was-run`)
 
 			It("fails and returns the failure", func() {
 				By("failing", func() {
-					Expect(session.ExitCode()).To(Equal(1))
+					Expect(session.ExitCode()).To(Equal(67))
 				})
 
 				By("returning the failure", func() {
@@ -476,8 +477,8 @@ touch /tmp/restore-script-was-run`)
 					Expect(string(session.Err.Contents())).NotTo(ContainSubstring("main.go"))
 				})
 
-				By("writes the stack trace", func() {
-					files, err := filepath.Glob(filepath.Join(restoreWorkspace, "bbr-*.err.log"))
+				By("writes the error report", func() {
+					files, err := filepath.Glob(filepath.Join(restoreWorkspace, "bbr-*.err.json"))
 					Expect(err).NotTo(HaveOccurred())
 					logFilePath := files[0]
 					_, err = os.Stat(logFilePath)
@@ -504,7 +505,7 @@ touch /tmp/restore-script-was-run`)
 
 			It("fails, returns an error and does not delete the artifact", func() {
 				By("failing", func() {
-					Expect(session.ExitCode()).To(Equal(1))
+					Expect(session.ExitCode()).To(Equal(97))
 				})
 
 				By("returning the correct error", func() {
@@ -517,8 +518,8 @@ touch /tmp/restore-script-was-run`)
 					Expect(string(session.Err.Contents())).NotTo(ContainSubstring("main.go"))
 				})
 
-				By("writes the stack trace", func() {
-					files, err := filepath.Glob(filepath.Join(restoreWorkspace, "bbr-*.err.log"))
+				By("writes the error report", func() {
+					files, err := filepath.Glob(filepath.Join(restoreWorkspace, "bbr-*.err.json"))
 					Expect(err).NotTo(HaveOccurred())
 					logFilePath := files[0]
 					_, err = os.Stat(logFilePath)
@@ -773,7 +774,7 @@ restore_should_be_locked_before:
 
 			It("Should fail", func() {
 				By("exiting with an error", func() {
-					Expect(session).To(gexec.Exit(1))
+					Expect(session).To(gexec.Exit(33))
 				})
 
 				By("printing a helpful error message", func() {
@@ -1128,12 +1129,12 @@ custom_artifacts:
 		})
 
 		It("fails", func() {
-			Expect(session.ExitCode()).To(Equal(1))
+			Expect(session.ExitCode()).To(Equal(65))
 			Expect(session.Err).To(gbytes.Say("Backup is corrupted"))
 		})
 
-		It("writes the stack trace", func() {
-			files, err := filepath.Glob(filepath.Join(restoreWorkspace, "bbr-*.err.log"))
+		It("writes the error report", func() {
+			files, err := filepath.Glob(filepath.Join(restoreWorkspace, "bbr-*.err.json"))
 			Expect(err).NotTo(HaveOccurred())
 			logFilePath := files[0]
 			_, err = os.Stat(logFilePath)
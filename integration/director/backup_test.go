@@ -193,8 +193,8 @@ printf "backupcontent2" > $BBR_ARTIFACT_DIRECTORY/backupdump2
 				})
 
 				It("fails to backup the director", func() {
-					By("returning exit code 1", func() {
-						Expect(session.ExitCode()).To(Equal(1))
+					By("returning exit code 35", func() {
+						Expect(session.ExitCode()).To(Equal(35))
 					})
 				})
 			})
@@ -304,7 +304,7 @@ backup_should_be_locked_before:
 
 				It("Should fail", func() {
 					By("exiting with an error", func() {
-						Expect(session).To(gexec.Exit(1))
+						Expect(session).To(gexec.Exit(33))
 					})
 
 					By("printing a helpful error message", func() {
@@ -320,8 +320,8 @@ backup_should_be_locked_before:
 			})
 
 			It("fails to backup the director", func() {
-				By("returning exit code 1", func() {
-					Expect(session.ExitCode()).To(Equal(1))
+				By("returning exit code 33", func() {
+					Expect(session.ExitCode()).To(Equal(33))
 				})
 
 				By("printing an error", func() {
@@ -365,8 +365,9 @@ backup_should_be_locked_before:
 						Eventually(session, 20*time.Second).Should(gexec.Exit(1))
 					})
 
-					By("outputting a warning about cleanup", func() {
-						Eventually(session).Should(gbytes.Say("It is recommended that you run `bbr backup-cleanup` to ensure that any temp files are cleaned up and all jobs are unlocked."))
+					By("unlocking and cleaning up before exiting", func() {
+						Eventually(session).Should(gbytes.Say("Stopping the backup. bbr will unlock and clean up before exiting"))
+						Expect(session.Err).To(gbytes.Say("Aborted: received interrupt"))
 					})
 
 					By("not creating an artifact tar from the interrupted director backup script", func() {
@@ -410,8 +411,8 @@ backup_should_be_locked_before:
 		})
 
 		It("fails to backup the director", func() {
-			By("returning exit code 1", func() {
-				Expect(session.ExitCode()).To(Equal(1))
+			By("returning exit code 3", func() {
+				Expect(session.ExitCode()).To(Equal(3))
 			})
 
 			By("printing an error", func() {
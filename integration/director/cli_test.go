@@ -65,8 +65,8 @@ var _ = Describe("CLI Interface", func() {
 						Expect(session.Err).NotTo(gbytes.Say("main.go"))
 					})
 
-					By("saving the stack trace into a file", func() {
-						files, err := filepath.Glob(filepath.Join(backupWorkspace, "bbr-*.err.log"))
+					By("saving the error report into a file", func() {
+						files, err := filepath.Glob(filepath.Join(backupWorkspace, "bbr-*.err.json"))
 						Expect(err).NotTo(HaveOccurred())
 						logFilePath := files[0]
 						_, err = os.Stat(logFilePath)
@@ -79,6 +79,47 @@ var _ = Describe("CLI Interface", func() {
 			})
 		})
 
+		Describe("backup without any ssh credentials", func() {
+			var session *gexec.Session
+
+			BeforeEach(func() {
+				session = binary.Run(backupWorkspace,
+					[]string{},
+					"director",
+					"-u", "admin",
+					"--host", "10.0.0.5",
+					"backup")
+				Eventually(session).Should(gexec.Exit())
+			})
+
+			It("fails", func() {
+				Expect(session.ExitCode()).NotTo(BeZero())
+				Expect(session.Err).To(gbytes.Say("provide one of '--private-key-path' or '--ssh-agent' flags."))
+			})
+		})
+
+		Describe("backup with both a known_hosts file and a host key fingerprint", func() {
+			var session *gexec.Session
+
+			BeforeEach(func() {
+				session = binary.Run(backupWorkspace,
+					[]string{},
+					"director",
+					"-u", "admin",
+					"--host", "10.0.0.5",
+					"--private-key-path", "doesn't matter",
+					"--known-hosts", "doesn't matter",
+					"--host-key-fingerprint", "SHA256:doesntmatter",
+					"backup")
+				Eventually(session).Should(gexec.Exit())
+			})
+
+			It("fails", func() {
+				Expect(session.ExitCode()).NotTo(BeZero())
+				Expect(session.Err).To(gbytes.Say("provide only one of '--known-hosts' or '--host-key-fingerprint' flags."))
+			})
+		})
+
 		Describe("restore with incorrect artifact-path", func() {
 			Context("restore command with missing artifact-path", func() {
 				var session *gexec.Session
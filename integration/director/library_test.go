@@ -0,0 +1,97 @@
+package director
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/cloudfoundry/bosh-backup-and-restore/bbr"
+	. "github.com/cloudfoundry/bosh-backup-and-restore/integration"
+	"github.com/cloudfoundry/bosh-backup-and-restore/testcluster"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Library", func() {
+	var (
+		directorInstance *testcluster.Instance
+		workspace        string
+		events           []bbr.Event
+		result           bbr.Result
+	)
+
+	BeforeEach(func() {
+		directorInstance = testcluster.NewInstance(fixturesDir)
+		directorInstance.CreateUser("foobar", readFile(pathToPublicKeyFile))
+		directorInstance.CreateScript("/var/vcap/jobs/bosh/bin/bbr/backup", `#!/usr/bin/env sh
+set -u
+printf "backupcontent1" > $BBR_ARTIFACT_DIRECTORY/backupdump1
+`)
+
+		var err error
+		workspace, err = os.MkdirTemp(".", "library-workspace-")
+		Expect(err).NotTo(HaveOccurred())
+		events = nil
+	})
+
+	AfterEach(func() {
+		directorInstance.DieInBackground()
+		Expect(os.RemoveAll(workspace)).To(Succeed())
+	})
+
+	JustBeforeEach(func() {
+		result = bbr.BackupDirector(context.Background(), bbr.DirectorBackupOptions{
+			Options: bbr.Options{
+				LogWriter: GinkgoWriter,
+				Events: bbr.EventHandlerFunc(func(event bbr.Event) {
+					events = append(events, event)
+				}),
+			},
+			SSH: bbr.DirectorSSH{
+				Host:           directorInstance.Address(),
+				Username:       "foobar",
+				PrivateKeyPath: pathToPrivateKeyFile,
+			},
+			ArtifactPath: workspace,
+		})
+	})
+
+	It("backs up the director into the artifact of the result", func() {
+		Expect(result.Err).NotTo(HaveOccurred())
+		Expect(result.Operation).To(Equal(bbr.Backup))
+		Expect(result.Deployment).To(Equal(directorInstance.IP()))
+
+		By("creating the backup in the directory named by the result", func() {
+			entries, err := os.ReadDir(workspace)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(entries).To(HaveLen(1))
+			Expect(filepath.Join(workspace, entries[0].Name())).To(Equal(result.Artifact))
+			Expect(result.Artifact).To(MatchRegexp(`%s_\d{8}T\d{6}Z$`, directorInstance.IP()))
+
+			archive := OpenTarArchive(filepath.Join(result.Artifact, "bosh-0-bosh.tar"))
+			Expect(archive.FileContents("backupdump1")).To(Equal("backupcontent1"))
+		})
+
+		By("cleaning up the director", func() {
+			Expect(directorInstance.FileExists("/var/vcap/store/bbr-backup")).To(BeFalse())
+		})
+	})
+
+	It("reports each step of the backup as it starts and finishes", func() {
+		Expect(events[0].Type).To(Equal(bbr.OperationStarted))
+		Expect(events[len(events)-1].Type).To(Equal(bbr.OperationFinished))
+
+		var started, finished []string
+		for _, event := range events[1 : len(events)-1] {
+			switch event.Type {
+			case bbr.StepStarted:
+				started = append(started, event.Step)
+			case bbr.StepFinished:
+				Expect(event.Err).NotTo(HaveOccurred())
+				finished = append(finished, event.Step)
+			}
+		}
+		Expect(started).To(ContainElements("LockStep", "BackupStep", "PostBackupUnlockStep", "DrainStep", "CleanupStep"))
+		Expect(finished).To(Equal(started))
+	})
+})
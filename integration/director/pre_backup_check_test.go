@@ -125,8 +125,8 @@ restore_should_be_locked_before:
 			})
 
 			It("fails", func() {
-				By("returning exit code 1", func() {
-					Expect(session.ExitCode()).To(Equal(1))
+				By("returning exit code 33", func() {
+					Expect(session.ExitCode()).To(Equal(33))
 				})
 
 				By("printing an error", func() {
@@ -137,7 +137,7 @@ restore_should_be_locked_before:
 				})
 
 				By("writing the stack trace", func() {
-					files, err := filepath.Glob(filepath.Join(backupWorkspace, "bbr-*.err.log"))
+					files, err := filepath.Glob(filepath.Join(backupWorkspace, "bbr-*.err.json"))
 					Expect(err).NotTo(HaveOccurred())
 					logFilePath := files[0]
 					_, err = os.Stat(logFilePath)
@@ -155,8 +155,8 @@ restore_should_be_locked_before:
 			directorAddress = "no:22"
 		})
 
-		It("returns exit code 1", func() {
-			Expect(session.ExitCode()).To(Equal(1))
+		It("returns exit code 3", func() {
+			Expect(session.ExitCode()).To(Equal(3))
 		})
 
 		It("prints an error", func() {
@@ -168,8 +168,8 @@ restore_should_be_locked_before:
 			Expect(string(session.Err.Contents())).NotTo(ContainSubstring("main.go"))
 		})
 
-		It("writes the stack trace", func() {
-			files, err := filepath.Glob(filepath.Join(backupWorkspace, "bbr-*.err.log"))
+		It("writes the error report", func() {
+			files, err := filepath.Glob(filepath.Join(backupWorkspace, "bbr-*.err.json"))
 			Expect(err).NotTo(HaveOccurred())
 			logFilePath := files[0]
 			_, err = os.Stat(logFilePath)
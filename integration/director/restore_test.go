@@ -167,12 +167,13 @@ cat $BBR_ARTIFACT_DIRECTORY/backup > /var/vcap/store/bosh/restored_file
 
 							stdin.Write([]byte("yes\n")) //nolint:errcheck
 
-							By("waiting for the restore to finish successfully", func() {
-								Eventually(session, 10).Should(gexec.Exit(1))
+							By("exiting with a failure", func() {
+								Eventually(session, 20).Should(gexec.Exit(1))
 							})
 
-							By("outputting a warning about cleanup", func() {
-								Eventually(session).Should(gbytes.Say("It is recommended that you run `bbr restore-cleanup` to ensure that any temp files are cleaned up and all jobs are unlocked."))
+							By("unlocking and cleaning up before exiting", func() {
+								Eventually(session).Should(gbytes.Say("Stopping the restore. bbr will unlock and clean up before exiting"))
+								Expect(session.Err).To(gbytes.Say("Aborted: received interrupt"))
 							})
 
 							By("not completing the restore", func() {
@@ -344,8 +345,8 @@ exit 1
 				})
 
 				It("fails to restore the director", func() {
-					By("returning exit code 1", func() {
-						Expect(session.ExitCode()).To(Equal(1))
+					By("returning exit code 67", func() {
+						Expect(session.ExitCode()).To(Equal(67))
 						Expect(session.Out).To(gbytes.Say("NOPE!"))
 					})
 				})
@@ -486,7 +487,7 @@ restore_should_be_locked_before:
 
 				It("Should fail", func() {
 					By("exiting with an error", func() {
-						Expect(session).To(gexec.Exit(1))
+						Expect(session).To(gexec.Exit(33))
 					})
 
 					By("printing a helpful error message", func() {
@@ -503,16 +504,16 @@ restore_should_be_locked_before:
 			})
 
 			It("fails to restore the director", func() {
-				By("returning exit code 1", func() {
-					Expect(session.ExitCode()).To(Equal(1))
+				By("returning exit code 33", func() {
+					Expect(session.ExitCode()).To(Equal(33))
 				})
 
 				By("printing an error", func() {
 					Expect(session.Err).To(gbytes.Say(fmt.Sprintf("Deployment '%s' has no restore scripts", directorIP)))
 				})
 
-				By("saving the stack trace into a file", func() {
-					files, err := filepath.Glob(filepath.Join(restoreWorkspace, "bbr-*.err.log"))
+				By("saving the error report into a file", func() {
+					files, err := filepath.Glob(filepath.Join(restoreWorkspace, "bbr-*.err.json"))
 					Expect(err).NotTo(HaveOccurred())
 					logFilePath := files[0]
 					_, err = os.Stat(logFilePath)
@@ -531,8 +532,8 @@ restore_should_be_locked_before:
 		})
 
 		It("fails to restore the director", func() {
-			By("returning exit code 1", func() {
-				Expect(session.ExitCode()).To(Equal(1))
+			By("returning exit code 3", func() {
+				Expect(session.ExitCode()).To(Equal(3))
 			})
 
 			By("printing an error", func() {
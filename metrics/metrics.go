@@ -0,0 +1,151 @@
+package metrics
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cloudfoundry/bosh-backup-and-restore/orchestrator"
+)
+
+const (
+	successMetric          = "bbr_backup_success"
+	lastRunMetric          = "bbr_backup_last_run_timestamp_seconds"
+	lastSuccessMetric      = "bbr_backup_last_success_timestamp_seconds"
+	durationMetric         = "bbr_backup_duration_seconds"
+	bytesTransferredMetric = "bbr_backup_transferred_bytes"
+	lockDurationMetric     = "bbr_backup_lock_duration_seconds"
+	errorsMetric           = "bbr_backup_errors"
+)
+
+var metricHelp = map[string]string{
+	successMetric:          "Whether the last bbr backup of the deployment succeeded.",
+	lastRunMetric:          "Unix time at which the last bbr backup of the deployment finished.",
+	lastSuccessMetric:      "Unix time at which the last successful bbr backup of the deployment finished.",
+	durationMetric:         "Duration of the last bbr backup of the deployment.",
+	bytesTransferredMetric: "Bytes transferred from the deployment during the last bbr backup.",
+	lockDurationMetric:     "Time the deployment was locked during the last bbr backup.",
+	errorsMetric:           "Number of errors of each category in the last bbr backup of the deployment.",
+}
+
+type Exporter interface {
+	Export(runs []Run) error
+}
+
+// Run describes the outcome of backing up a single deployment or director.
+type Run struct {
+	Deployment       string
+	StartTime        time.Time
+	FinishTime       time.Time
+	BytesTransferred int64
+	LockDuration     time.Duration
+	Errors           orchestrator.Error
+}
+
+func (r Run) Succeeded() bool {
+	return r.Errors.IsNil()
+}
+
+type sample struct {
+	name   string
+	labels map[string]string
+	value  float64
+}
+
+func (r Run) samples() []sample {
+	deploymentLabel := map[string]string{"deployment": r.Deployment}
+
+	samples := []sample{
+		{name: successMetric, labels: deploymentLabel, value: boolToFloat(r.Succeeded())},
+		{name: lastRunMetric, labels: deploymentLabel, value: float64(r.FinishTime.Unix())},
+		{name: durationMetric, labels: deploymentLabel, value: r.FinishTime.Sub(r.StartTime).Seconds()},
+		{name: bytesTransferredMetric, labels: deploymentLabel, value: float64(r.BytesTransferred)},
+		{name: lockDurationMetric, labels: deploymentLabel, value: r.LockDuration.Seconds()},
+	}
+	if r.Succeeded() {
+		samples = append(samples, sample{name: lastSuccessMetric, labels: deploymentLabel, value: float64(r.FinishTime.Unix())})
+	}
+
+	errorCounts := map[orchestrator.ErrorCategory]int{}
+	for _, err := range r.Errors {
+		errorCounts[orchestrator.Category(err)]++
+	}
+	for _, category := range orchestrator.ErrorCategories {
+		samples = append(samples, sample{
+			name:   errorsMetric,
+			labels: map[string]string{"deployment": r.Deployment, "category": string(category)},
+			value:  float64(errorCounts[category]),
+		})
+	}
+
+	return samples
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// writeExposition writes the samples in the Prometheus text exposition format,
+// grouped by metric name and sorted so that the output is stable.
+func writeExposition(writer io.Writer, samples []sample) error {
+	byName := map[string][]sample{}
+	var names []string
+	for _, s := range samples {
+		if _, found := byName[s.name]; !found {
+			names = append(names, s.name)
+		}
+		byName[s.name] = append(byName[s.name], s)
+	}
+	sort.Strings(names)
+
+	buffer := new(bytes.Buffer)
+	for _, name := range names {
+		if help, found := metricHelp[name]; found {
+			fmt.Fprintf(buffer, "# HELP %s %s\n", name, help)
+		}
+		fmt.Fprintf(buffer, "# TYPE %s gauge\n", name)
+
+		lines := []string{}
+		for _, s := range byName[name] {
+			lines = append(lines, fmt.Sprintf("%s%s %s", s.name, formatLabels(s.labels), strconv.FormatFloat(s.value, 'f', -1, 64)))
+		}
+		sort.Strings(lines)
+		buffer.WriteString(strings.Join(lines, "\n") + "\n")
+	}
+
+	_, err := writer.Write(buffer.Bytes())
+	return err
+}
+
+func formatLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+
+	var keys []string
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var pairs []string
+	for _, key := range keys {
+		pairs = append(pairs, fmt.Sprintf("%s=%q", key, labels[key]))
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func runSamples(runs []Run) []sample {
+	var samples []sample
+	for _, run := range runs {
+		samples = append(samples, run.samples()...)
+	}
+	return samples
+}
@@ -0,0 +1,158 @@
+package metrics_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/cloudfoundry/bosh-backup-and-restore/metrics"
+	"github.com/cloudfoundry/bosh-backup-and-restore/orchestrator"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Exporters", func() {
+	var (
+		startTime, finishTime time.Time
+		successfulRun         metrics.Run
+		failedRun             metrics.Run
+	)
+
+	BeforeEach(func() {
+		startTime = time.Unix(1445389323, 0)
+		finishTime = startTime.Add(90 * time.Second)
+
+		successfulRun = metrics.Run{
+			Deployment:       "redis",
+			StartTime:        startTime,
+			FinishTime:       finishTime,
+			BytesTransferred: 2048,
+			LockDuration:     30 * time.Second,
+		}
+		failedRun = metrics.Run{
+			Deployment: "redis",
+			StartTime:  startTime.Add(time.Hour),
+			FinishTime: finishTime.Add(time.Hour),
+			Errors: orchestrator.NewError(
+				orchestrator.NewLockError("lock failed"),
+				orchestrator.NewCleanupError("cleanup failed"),
+				fmt.Errorf("some other error"),
+			),
+		}
+	})
+
+	Describe("TextfileExporter", func() {
+		var textfilePath string
+
+		BeforeEach(func() {
+			textfilePath = filepath.Join(GinkgoT().TempDir(), "bbr.prom")
+		})
+
+		It("writes the metrics of each run in the exposition format", func() {
+			Expect(metrics.NewTextfileExporter(textfilePath).Export([]metrics.Run{successfulRun})).To(Succeed())
+
+			contents, err := os.ReadFile(textfilePath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(contents)).To(ContainSubstring("# TYPE bbr_backup_success gauge\nbbr_backup_success{deployment=\"redis\"} 1\n"))
+			Expect(string(contents)).To(ContainSubstring("bbr_backup_last_success_timestamp_seconds{deployment=\"redis\"} 1445389413\n"))
+			Expect(string(contents)).To(ContainSubstring("bbr_backup_duration_seconds{deployment=\"redis\"} 90\n"))
+			Expect(string(contents)).To(ContainSubstring("bbr_backup_transferred_bytes{deployment=\"redis\"} 2048\n"))
+			Expect(string(contents)).To(ContainSubstring("bbr_backup_lock_duration_seconds{deployment=\"redis\"} 30\n"))
+			Expect(string(contents)).To(ContainSubstring("bbr_backup_errors{category=\"lock\",deployment=\"redis\"} 0\n"))
+		})
+
+		It("counts the errors of a failed run by category and keeps the last success timestamp", func() {
+			Expect(metrics.NewTextfileExporter(textfilePath).Export([]metrics.Run{successfulRun})).To(Succeed())
+			Expect(metrics.NewTextfileExporter(textfilePath).Export([]metrics.Run{failedRun})).To(Succeed())
+
+			contents, err := os.ReadFile(textfilePath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(contents)).To(ContainSubstring("bbr_backup_success{deployment=\"redis\"} 0\n"))
+			Expect(string(contents)).To(ContainSubstring("bbr_backup_last_run_timestamp_seconds{deployment=\"redis\"} 1445393013\n"))
+			Expect(string(contents)).To(ContainSubstring("bbr_backup_last_success_timestamp_seconds{deployment=\"redis\"} 1445389413\n"))
+			Expect(string(contents)).To(ContainSubstring("bbr_backup_errors{category=\"lock\",deployment=\"redis\"} 1\n"))
+			Expect(string(contents)).To(ContainSubstring("bbr_backup_errors{category=\"cleanup\",deployment=\"redis\"} 1\n"))
+			Expect(string(contents)).To(ContainSubstring("bbr_backup_errors{category=\"other\",deployment=\"redis\"} 1\n"))
+			Expect(string(contents)).To(ContainSubstring("bbr_backup_errors{category=\"unlock\",deployment=\"redis\"} 0\n"))
+		})
+
+		It("keeps the metrics of deployments that were not part of the run", func() {
+			otherRun := successfulRun
+			otherRun.Deployment = "cf"
+			Expect(metrics.NewTextfileExporter(textfilePath).Export([]metrics.Run{otherRun})).To(Succeed())
+			Expect(metrics.NewTextfileExporter(textfilePath).Export([]metrics.Run{failedRun})).To(Succeed())
+
+			contents, err := os.ReadFile(textfilePath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(contents)).To(ContainSubstring("bbr_backup_success{deployment=\"cf\"} 1\n"))
+			Expect(string(contents)).To(ContainSubstring("bbr_backup_success{deployment=\"redis\"} 0\n"))
+		})
+
+		Context("when the existing textfile cannot be parsed", func() {
+			BeforeEach(func() {
+				Expect(os.WriteFile(textfilePath, []byte("not a metric"), 0644)).To(Succeed())
+			})
+
+			It("returns an error", func() {
+				err := metrics.NewTextfileExporter(textfilePath).Export([]metrics.Run{successfulRun})
+				Expect(err).To(MatchError(ContainSubstring("failed to parse existing metrics textfile")))
+			})
+		})
+	})
+
+	Describe("PushgatewayExporter", func() {
+		var (
+			server       *httptest.Server
+			requestPaths []string
+			requestBody  string
+			statusCode   int
+		)
+
+		BeforeEach(func() {
+			requestPaths = nil
+			statusCode = http.StatusOK
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				Expect(r.Method).To(Equal(http.MethodPost))
+				body, _ := io.ReadAll(r.Body) //nolint:errcheck
+				requestPaths = append(requestPaths, r.URL.Path)
+				requestBody = string(body)
+				w.WriteHeader(statusCode)
+			}))
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("pushes the metrics of each deployment to its own group", func() {
+			otherRun := successfulRun
+			otherRun.Deployment = "cf"
+
+			err := metrics.NewPushgatewayExporter(server.URL+"/", http.DefaultClient).Export([]metrics.Run{successfulRun, otherRun})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requestPaths).To(Equal([]string{"/metrics/job/bbr/deployment/redis", "/metrics/job/bbr/deployment/cf"}))
+			Expect(requestBody).To(ContainSubstring("bbr_backup_success{deployment=\"cf\"} 1\n"))
+		})
+
+		It("does not push a last success timestamp for a failed run", func() {
+			Expect(metrics.NewPushgatewayExporter(server.URL, http.DefaultClient).Export([]metrics.Run{failedRun})).To(Succeed())
+			Expect(requestBody).NotTo(ContainSubstring("bbr_backup_last_success_timestamp_seconds"))
+		})
+
+		Context("when the gateway rejects the metrics", func() {
+			BeforeEach(func() {
+				statusCode = http.StatusBadRequest
+			})
+
+			It("returns an error", func() {
+				err := metrics.NewPushgatewayExporter(server.URL, http.DefaultClient).Export([]metrics.Run{successfulRun})
+				Expect(err).To(MatchError(ContainSubstring("failed to push metrics for redis: 400 Bad Request")))
+			})
+		})
+	})
+})
@@ -0,0 +1,71 @@
+package metrics
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const pushgatewayJobName = "bbr"
+
+type PushgatewayExporter struct {
+	url        string
+	httpClient *http.Client
+}
+
+// NewPushgatewayExporter returns an exporter that pushes the metrics of each
+// run to a Pushgateway-compatible endpoint, grouped by deployment.
+func NewPushgatewayExporter(pushgatewayURL string, httpClient *http.Client) PushgatewayExporter {
+	return PushgatewayExporter{
+		url:        strings.TrimSuffix(pushgatewayURL, "/"),
+		httpClient: httpClient,
+	}
+}
+
+// Export pushes each run to its own grouping key. POST is used rather than PUT
+// so that the last success timestamp of a failed run is kept by the gateway.
+func (e PushgatewayExporter) Export(runs []Run) error {
+	var errs []string
+	for _, run := range runs {
+		if err := e.push(run); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "\n"))
+	}
+	return nil
+}
+
+func (e PushgatewayExporter) push(run Run) error {
+	body := new(bytes.Buffer)
+	if err := writeExposition(body, run.samples()); err != nil {
+		return errors.Wrap(err, "failed to render metrics")
+	}
+
+	pushURL := fmt.Sprintf("%s/metrics/job/%s/deployment/%s", e.url, pushgatewayJobName, url.PathEscape(run.Deployment))
+	request, err := http.NewRequest(http.MethodPost, pushURL, body)
+	if err != nil {
+		return errors.Wrapf(err, "failed to build request to push metrics for %s", run.Deployment)
+	}
+	request.Header.Set("Content-Type", "text/plain; version=0.0.4")
+
+	response, err := e.httpClient.Do(request)
+	if err != nil {
+		return errors.Wrapf(err, "failed to push metrics for %s", run.Deployment)
+	}
+	defer response.Body.Close() //nolint:errcheck
+
+	if response.StatusCode/100 != 2 {
+		responseBody, _ := io.ReadAll(response.Body) //nolint:errcheck
+		return errors.Errorf("failed to push metrics for %s: %s %s", run.Deployment, response.Status, strings.TrimSpace(string(responseBody)))
+	}
+
+	return nil
+}
@@ -0,0 +1,115 @@
+package metrics
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var labelPattern = regexp.MustCompile(`(\w+)="((?:[^"\\]|\\.)*)"`)
+
+type TextfileExporter struct {
+	path string
+}
+
+// NewTextfileExporter returns an exporter that writes metrics to a file in
+// the format read by the node exporter textfile collector.
+func NewTextfileExporter(path string) TextfileExporter {
+	return TextfileExporter{path: path}
+}
+
+// Export merges the metrics of runs into the textfile. Metrics for
+// deployments that are not part of runs are kept, as is the last success
+// timestamp of deployments whose backup failed.
+func (e TextfileExporter) Export(runs []Run) error {
+	existingSamples, err := e.readExistingSamples()
+	if err != nil {
+		return err
+	}
+
+	exportedDeployments := map[string]Run{}
+	for _, run := range runs {
+		exportedDeployments[run.Deployment] = run
+	}
+
+	samples := runSamples(runs)
+	for _, s := range existingSamples {
+		run, exported := exportedDeployments[s.labels["deployment"]]
+		if !exported || (s.name == lastSuccessMetric && !run.Succeeded()) {
+			samples = append(samples, s)
+		}
+	}
+
+	buffer := new(bytes.Buffer)
+	if err := writeExposition(buffer, samples); err != nil {
+		return errors.Wrap(err, "failed to render metrics")
+	}
+
+	// write to a temporary file first so that the collector never reads a partial file
+	tempPath := filepath.Join(filepath.Dir(e.path), "."+filepath.Base(e.path)+".tmp")
+	if err := os.WriteFile(tempPath, buffer.Bytes(), 0644); err != nil {
+		return errors.Wrap(err, "failed to write metrics textfile")
+	}
+
+	return errors.Wrap(os.Rename(tempPath, e.path), "failed to write metrics textfile")
+}
+
+func (e TextfileExporter) readExistingSamples() ([]sample, error) {
+	contents, err := os.ReadFile(e.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read existing metrics textfile")
+	}
+
+	var samples []sample
+	scanner := bufio.NewScanner(bytes.NewReader(contents))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		s, err := parseSample(line)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse existing metrics textfile %s", e.path)
+		}
+		samples = append(samples, s)
+	}
+
+	return samples, nil
+}
+
+func parseSample(line string) (sample, error) {
+	valueIndex := strings.LastIndex(line, " ")
+	if valueIndex == -1 {
+		return sample{}, errors.Errorf("invalid sample %q", line)
+	}
+
+	value, err := strconv.ParseFloat(line[valueIndex+1:], 64)
+	if err != nil {
+		return sample{}, errors.Errorf("invalid sample value in %q", line)
+	}
+
+	nameAndLabels := line[:valueIndex]
+	s := sample{name: nameAndLabels, labels: map[string]string{}, value: value}
+	if labelsIndex := strings.Index(nameAndLabels, "{"); labelsIndex != -1 {
+		s.name = nameAndLabels[:labelsIndex]
+		for _, match := range labelPattern.FindAllStringSubmatch(nameAndLabels[labelsIndex:], -1) {
+			labelValue, err := strconv.Unquote(`"` + match[2] + `"`)
+			if err != nil {
+				return sample{}, errors.Errorf("invalid label value in %q", line)
+			}
+			s.labels[match[1]] = labelValue
+		}
+	}
+
+	return s, nil
+}
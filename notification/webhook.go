@@ -0,0 +1,149 @@
+package notification
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/cloudfoundry/bosh-backup-and-restore/orchestrator"
+	"github.com/pkg/errors"
+)
+
+// Outcome describes the result of a single backup or restore operation.
+type Outcome struct {
+	Operation      string
+	Deployment     string
+	ArtifactPath   string
+	StartTime      time.Time
+	FinishTime     time.Time
+	Errors         orchestrator.Error
+	CleanupAdvised bool
+}
+
+func (o Outcome) Succeeded() bool {
+	return o.Errors.IsNil()
+}
+
+type payload struct {
+	Operation       string         `json:"operation"`
+	Deployment      string         `json:"deployment"`
+	ArtifactPath    string         `json:"artifact_path"`
+	Success         bool           `json:"success"`
+	StartTime       string         `json:"start_time"`
+	FinishTime      string         `json:"finish_time"`
+	DurationSeconds float64        `json:"duration_seconds"`
+	Errors          []payloadError `json:"errors"`
+	CleanupAdvised  bool           `json:"cleanup_advised"`
+}
+
+type payloadError struct {
+	Category string `json:"category"`
+	Message  string `json:"message"`
+}
+
+func newPayload(outcome Outcome) payload {
+	errs := []payloadError{}
+	for _, err := range outcome.Errors {
+		errs = append(errs, payloadError{Category: string(orchestrator.Category(err)), Message: err.Error()})
+	}
+
+	return payload{
+		Operation:       outcome.Operation,
+		Deployment:      outcome.Deployment,
+		ArtifactPath:    outcome.ArtifactPath,
+		Success:         outcome.Succeeded(),
+		StartTime:       outcome.StartTime.UTC().Format(time.RFC3339),
+		FinishTime:      outcome.FinishTime.UTC().Format(time.RFC3339),
+		DurationSeconds: outcome.FinishTime.Sub(outcome.StartTime).Seconds(),
+		Errors:          errs,
+		CleanupAdvised:  outcome.CleanupAdvised,
+	}
+}
+
+type WebhookNotifier struct {
+	urls          []string
+	httpClient    *http.Client
+	attempts      int
+	retryInterval time.Duration
+}
+
+// NewWebhookNotifier returns a notifier that POSTs a JSON description of each
+// outcome to every url, retrying failed deliveries up to retries times. Each
+// url is tried at least once, even when retries is negative.
+func NewWebhookNotifier(urls []string, httpClient *http.Client, retries int, retryInterval time.Duration) WebhookNotifier {
+	return WebhookNotifier{
+		urls:          urls,
+		httpClient:    httpClient,
+		attempts:      max(retries, 0) + 1,
+		retryInterval: retryInterval,
+	}
+}
+
+func (n WebhookNotifier) Notify(outcome Outcome) error {
+	body, err := json.Marshal(newPayload(outcome))
+	if err != nil {
+		return errors.Wrap(err, "failed to render notification")
+	}
+
+	var errs []string
+	for _, url := range n.urls {
+		if err := n.deliver(url, body); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "\n"))
+	}
+	return nil
+}
+
+func (n WebhookNotifier) deliver(url string, body []byte) error {
+	var lastErr error
+	for attempt := 1; attempt <= n.attempts; attempt++ {
+		if attempt > 1 {
+			time.Sleep(n.retryInterval)
+		}
+
+		retryable, err := n.post(url, body)
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+
+		if !retryable {
+			break
+		}
+	}
+
+	return errors.Wrapf(lastErr, "failed to notify %s", url)
+}
+
+// post reports whether a failed delivery is worth retrying. Client errors
+// other than rate limiting will not succeed on a second attempt.
+func (n WebhookNotifier) post(url string, body []byte) (bool, error) {
+	request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := n.httpClient.Do(request)
+	if err != nil {
+		return true, err
+	}
+	defer response.Body.Close() //nolint:errcheck
+
+	if response.StatusCode/100 == 2 {
+		return false, nil
+	}
+
+	responseBody, _ := io.ReadAll(response.Body) //nolint:errcheck
+	err = fmt.Errorf("%s %s", response.Status, strings.TrimSpace(string(responseBody)))
+	retryable := response.StatusCode >= 500 || response.StatusCode == http.StatusTooManyRequests
+	return retryable, err
+}
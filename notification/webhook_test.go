@@ -0,0 +1,149 @@
+package notification_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/cloudfoundry/bosh-backup-and-restore/notification"
+	"github.com/cloudfoundry/bosh-backup-and-restore/orchestrator"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WebhookNotifier", func() {
+	var (
+		server      *httptest.Server
+		mutex       sync.Mutex
+		requests    []map[string]interface{}
+		statusCodes []int
+		outcome     notification.Outcome
+	)
+
+	BeforeEach(func() {
+		requests = nil
+		statusCodes = nil
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer GinkgoRecover()
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			Expect(r.Method).To(Equal(http.MethodPost))
+			Expect(r.Header.Get("Content-Type")).To(Equal("application/json"))
+
+			body, err := io.ReadAll(r.Body)
+			Expect(err).NotTo(HaveOccurred())
+			var request map[string]interface{}
+			Expect(json.Unmarshal(body, &request)).To(Succeed())
+			requests = append(requests, request)
+
+			statusCode := http.StatusOK
+			if len(statusCodes) > 0 {
+				statusCode, statusCodes = statusCodes[0], statusCodes[1:]
+			}
+			w.WriteHeader(statusCode)
+		}))
+
+		startTime := time.Date(2015, 10, 21, 1, 2, 3, 0, time.UTC)
+		outcome = notification.Outcome{
+			Operation:    "backup",
+			Deployment:   "redis",
+			ArtifactPath: "/backups/redis_20151021T010203Z",
+			StartTime:    startTime,
+			FinishTime:   startTime.Add(90 * time.Second),
+		}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("posts a description of a successful outcome to every url", func() {
+		notifier := notification.NewWebhookNotifier([]string{server.URL + "/one", server.URL + "/two"}, http.DefaultClient, 0, 0)
+
+		Expect(notifier.Notify(outcome)).To(Succeed())
+
+		Expect(requests).To(HaveLen(2))
+		Expect(requests[0]).To(Equal(map[string]interface{}{
+			"operation":        "backup",
+			"deployment":       "redis",
+			"artifact_path":    "/backups/redis_20151021T010203Z",
+			"success":          true,
+			"start_time":       "2015-10-21T01:02:03Z",
+			"finish_time":      "2015-10-21T01:03:33Z",
+			"duration_seconds": float64(90),
+			"errors":           []interface{}{},
+			"cleanup_advised":  false,
+		}))
+	})
+
+	It("describes the category of each error of a failed outcome", func() {
+		outcome.Errors = orchestrator.NewError(orchestrator.NewPostUnlockError("unlock failed"), fmt.Errorf("something else"))
+		outcome.CleanupAdvised = true
+		notifier := notification.NewWebhookNotifier([]string{server.URL}, http.DefaultClient, 0, 0)
+
+		Expect(notifier.Notify(outcome)).To(Succeed())
+
+		Expect(requests).To(HaveLen(1))
+		Expect(requests[0]).To(HaveKeyWithValue("success", false))
+		Expect(requests[0]).To(HaveKeyWithValue("cleanup_advised", true))
+		Expect(requests[0]).To(HaveKeyWithValue("errors", []interface{}{
+			map[string]interface{}{"category": "unlock", "message": "unlock failed"},
+			map[string]interface{}{"category": "other", "message": "something else"},
+		}))
+	})
+
+	Context("when the webhook fails with a server error", func() {
+		BeforeEach(func() {
+			statusCodes = []int{http.StatusBadGateway, http.StatusServiceUnavailable}
+		})
+
+		It("retries until the notification is delivered", func() {
+			notifier := notification.NewWebhookNotifier([]string{server.URL}, http.DefaultClient, 2, time.Millisecond)
+
+			Expect(notifier.Notify(outcome)).To(Succeed())
+			Expect(requests).To(HaveLen(3))
+		})
+
+		It("returns an error when it runs out of retries", func() {
+			notifier := notification.NewWebhookNotifier([]string{server.URL}, http.DefaultClient, 1, time.Millisecond)
+
+			err := notifier.Notify(outcome)
+
+			Expect(err).To(MatchError(ContainSubstring(fmt.Sprintf("failed to notify %s: 503 Service Unavailable", server.URL))))
+			Expect(requests).To(HaveLen(2))
+		})
+
+		It("tries once when the number of retries is negative", func() {
+			notifier := notification.NewWebhookNotifier([]string{server.URL}, http.DefaultClient, -1, time.Millisecond)
+
+			Expect(notifier.Notify(outcome)).To(MatchError(ContainSubstring("502 Bad Gateway")))
+			Expect(requests).To(HaveLen(1))
+		})
+	})
+
+	Context("when the webhook rejects the notification", func() {
+		BeforeEach(func() {
+			statusCodes = []int{http.StatusBadRequest}
+		})
+
+		It("does not retry", func() {
+			notifier := notification.NewWebhookNotifier([]string{server.URL}, http.DefaultClient, 3, time.Millisecond)
+
+			Expect(notifier.Notify(outcome)).To(MatchError(ContainSubstring("400 Bad Request")))
+			Expect(requests).To(HaveLen(1))
+		})
+	})
+
+	Context("when the webhook cannot be reached", func() {
+		It("returns an error after retrying", func() {
+			notifier := notification.NewWebhookNotifier([]string{"http://127.0.0.1:0/unreachable"}, http.DefaultClient, 1, time.Millisecond)
+
+			Expect(notifier.Notify(outcome)).To(MatchError(ContainSubstring("failed to notify http://127.0.0.1:0/unreachable")))
+		})
+	})
+})
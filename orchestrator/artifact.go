@@ -17,13 +17,26 @@ type Backup interface {
 	GetArtifactSize(ArtifactIdentifier) (string, error)
 	GetArtifactByteSize(ArtifactIdentifier) (int, error)
 	CreateArtifact(ArtifactIdentifier) (io.WriteCloser, error)
-	ReadArtifact(ArtifactIdentifier) (io.ReadCloser, error)
+	CreateArtifactPart(ArtifactIdentifier, int) (io.WriteCloser, error)
+	ReadArtifactParts(ArtifactIdentifier) ([]ArtifactPart, error)
 	AddChecksum(ArtifactIdentifier, BackupChecksum) error
+	AddArtifactTransfer(ArtifactIdentifier, ArtifactTransfer) error
+	AddPhaseTimings([]PhaseTiming) error
+	AddOrigin(Origin) error
+	FetchOrigin() (Origin, error)
 	CreateMetadataFileWithStartTime(time.Time) error
 	AddFinishTime(time.Time) error
 	FetchChecksum(ArtifactIdentifier) (BackupChecksum, error)
+	FetchArtifactSizeInBytes(ArtifactIdentifier) (int, error)
 	CalculateChecksum(ArtifactIdentifier) (BackupChecksum, error)
 	DeploymentMatches(string, []Instance) (bool, error)
 	SaveManifest(manifest string) error
 	Valid() (bool, error)
 }
+
+// ArtifactPart is one of the tar files an artifact is stored in. Artifacts
+// that were drained in a single stream have a single part.
+type ArtifactPart struct {
+	Reader      io.ReadCloser
+	SizeInBytes int
+}
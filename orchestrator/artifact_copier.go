@@ -1,44 +1,55 @@
 package orchestrator
 
 import (
+	"context"
+
 	"github.com/cloudfoundry/bosh-backup-and-restore/executor"
 )
 
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
 //counterfeiter:generate -o fakes/fake_artifact_copier.go . ArtifactCopier
 type ArtifactCopier interface {
-	DownloadBackupFromDeployment(Backup, Deployment) error
-	UploadBackupToDeployment(Backup, Deployment) error
+	DownloadBackupFromDeployment(context.Context, Backup, Deployment) error
+	UploadBackupToDeployment(context.Context, Backup, Deployment) error
 }
 
 type artifactCopier struct {
 	Logger
-	executor executor.Executor
+	executor           executor.Executor
+	streamsPerArtifact int
 }
 
 func NewArtifactCopier(executor executor.Executor, logger Logger) ArtifactCopier {
+	return NewArtifactCopierWithStreams(executor, 1, logger)
+}
+
+// NewArtifactCopierWithStreams downloads each large artifact over as many as
+// streamsPerArtifact SSH sessions. Restores always upload every part that was
+// stored, whatever streamsPerArtifact is.
+func NewArtifactCopierWithStreams(executor executor.Executor, streamsPerArtifact int, logger Logger) ArtifactCopier {
 	return artifactCopier{
-		Logger:   logger,
-		executor: executor,
+		Logger:             logger,
+		executor:           executor,
+		streamsPerArtifact: streamsPerArtifact,
 	}
 }
 
-func (c artifactCopier) DownloadBackupFromDeployment(localBackup Backup, deployment Deployment) error {
+func (c artifactCopier) DownloadBackupFromDeployment(ctx context.Context, localBackup Backup, deployment Deployment) error {
 	instances := deployment.BackupableInstances()
 
 	var executables []executor.Executable
 	for _, instance := range instances {
 		for _, remoteBackupArtifact := range instance.ArtifactsToBackup() {
-			executables = append(executables, NewBackupDownloadExecutable(localBackup, remoteBackupArtifact, c.Logger))
+			executables = append(executables, NewBackupDownloadExecutableWithStreams(localBackup, remoteBackupArtifact, c.streamsPerArtifact, c.Logger))
 		}
 	}
 
-	errs := c.executor.Run([][]executor.Executable{executables})
+	errs := c.executor.Run(ctx, [][]executor.Executable{executables})
 
 	return ConvertErrors(errs)
 }
 
-func (c artifactCopier) UploadBackupToDeployment(localBackup Backup, deployment Deployment) error {
+func (c artifactCopier) UploadBackupToDeployment(ctx context.Context, localBackup Backup, deployment Deployment) error {
 	instances := deployment.RestorableInstances()
 
 	var executables []executor.Executable
@@ -48,7 +59,7 @@ func (c artifactCopier) UploadBackupToDeployment(localBackup Backup, deployment
 		}
 	}
 
-	errs := c.executor.Run([][]executor.Executable{executables})
+	errs := c.executor.Run(ctx, [][]executor.Executable{executables})
 
 	return ConvertErrors(errs)
 }
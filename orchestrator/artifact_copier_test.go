@@ -1,6 +1,7 @@
 package orchestrator_test
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/cloudfoundry/bosh-backup-and-restore/executor"
@@ -13,6 +14,7 @@ import (
 
 var _ = Describe("ArtifactCopier", func() {
 	var (
+		ctx            context.Context
 		artifactCopier orchestrator.ArtifactCopier
 		logger         *fakes.FakeLogger
 		deployment     *fakes.FakeDeployment
@@ -28,6 +30,9 @@ var _ = Describe("ArtifactCopier", func() {
 	)
 
 	BeforeEach(func() {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(context.Background())
+		DeferCleanup(cancel)
 		logger = new(fakes.FakeLogger)
 		fakeExecutor = new(executorFakes.FakeExecutor)
 
@@ -52,7 +57,7 @@ var _ = Describe("ArtifactCopier", func() {
 		})
 
 		JustBeforeEach(func() {
-			err = artifactCopier.DownloadBackupFromDeployment(localBackup, deployment)
+			err = artifactCopier.DownloadBackupFromDeployment(ctx, localBackup, deployment)
 		})
 
 		It("downloads the backup from deployment", func() {
@@ -71,13 +76,30 @@ var _ = Describe("ArtifactCopier", func() {
 
 			By("running the executor with the executables", func() {
 				Expect(fakeExecutor.RunCallCount()).To(Equal(1))
-				Expect(fakeExecutor.RunArgsForCall(0)).To(Equal([][]executor.Executable{{
+				runCtx, executables := fakeExecutor.RunArgsForCall(0)
+				Expect(runCtx).To(Equal(ctx))
+				Expect(executables).To(Equal([][]executor.Executable{{
 					orchestrator.NewBackupDownloadExecutable(localBackup, remoteBackup1, logger),
 					orchestrator.NewBackupDownloadExecutable(localBackup, remoteBackup2, logger),
 				}}))
 			})
 		})
 
+		Context("when the copier streams each artifact in several parts", func() {
+			BeforeEach(func() {
+				artifactCopier = orchestrator.NewArtifactCopierWithStreams(fakeExecutor, 4, logger)
+			})
+
+			It("passes the number of streams to the executables", func() {
+				runCtx, executables := fakeExecutor.RunArgsForCall(0)
+				Expect(runCtx).To(Equal(ctx))
+				Expect(executables).To(Equal([][]executor.Executable{{
+					orchestrator.NewBackupDownloadExecutableWithStreams(localBackup, remoteBackup1, 4, logger),
+					orchestrator.NewBackupDownloadExecutableWithStreams(localBackup, remoteBackup2, 4, logger),
+				}}))
+			})
+		})
+
 		Context("When the executor fails to run", func() {
 			BeforeEach(func() {
 				fakeExecutor.RunReturns([]error{fmt.Errorf("run error")})
@@ -97,7 +119,7 @@ var _ = Describe("ArtifactCopier", func() {
 		})
 
 		JustBeforeEach(func() {
-			err = artifactCopier.UploadBackupToDeployment(localBackup, deployment)
+			err = artifactCopier.UploadBackupToDeployment(ctx, localBackup, deployment)
 		})
 
 		It("uploads the backup to the deployment", func() {
@@ -116,7 +138,9 @@ var _ = Describe("ArtifactCopier", func() {
 
 			By("running the executor with the executables", func() {
 				Expect(fakeExecutor.RunCallCount()).To(Equal(1))
-				Expect(fakeExecutor.RunArgsForCall(0)).To(Equal([][]executor.Executable{{
+				runCtx, executables := fakeExecutor.RunArgsForCall(0)
+				Expect(runCtx).To(Equal(ctx))
+				Expect(executables).To(Equal([][]executor.Executable{{
 					orchestrator.NewBackupUploadExecutable(localBackup, remoteBackup1, instance1, logger),
 					orchestrator.NewBackupUploadExecutable(localBackup, remoteBackup2, instance2, logger),
 				}}))
@@ -0,0 +1,124 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cloudfoundry/bosh-backup-and-restore/executor"
+	"github.com/cloudfoundry/bosh-backup-and-restore/readwriter"
+)
+
+// minimumPartSizeInBytes keeps small artifacts in a single stream, where the
+// extra SSH sessions would cost more than they save.
+const minimumPartSizeInBytes = 128 * 1024 * 1024
+
+type filePart struct {
+	files       []string
+	sizeInBytes int
+}
+
+// splitFilesBySize shares the files between at most maxParts parts of about
+// the same size, placing the largest files first. It returns fewer parts
+// when there are not enough files or bytes to fill them, and nil when the
+// files are best kept in a single part. Directories, whose paths end with a
+// slash, all go in the first part, so that their modes and owners are
+// restored even when they are empty.
+func splitFilesBySize(fileSizes map[string]int, maxParts int) []filePart {
+	total := 0
+	var files, directories []string
+	for file, size := range fileSizes {
+		if strings.HasSuffix(file, "/") {
+			directories = append(directories, file)
+			continue
+		}
+		files = append(files, file)
+		total += size
+	}
+
+	partCount := maxParts
+	if len(files) < partCount {
+		partCount = len(files)
+	}
+	if total/minimumPartSizeInBytes < partCount {
+		partCount = total / minimumPartSizeInBytes
+	}
+	if partCount < 2 {
+		return nil
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		if fileSizes[files[i]] != fileSizes[files[j]] {
+			return fileSizes[files[i]] > fileSizes[files[j]]
+		}
+		return files[i] < files[j]
+	})
+
+	parts := make([]filePart, partCount)
+	for _, file := range files {
+		smallest := 0
+		for i := range parts {
+			if parts[i].sizeInBytes < parts[smallest].sizeInBytes {
+				smallest = i
+			}
+		}
+		parts[smallest].files = append(parts[smallest].files, file)
+		parts[smallest].sizeInBytes += fileSizes[file]
+	}
+
+	parts[0].files = append(parts[0].files, directories...)
+	for _, part := range parts {
+		sort.Strings(part.files)
+	}
+
+	return parts
+}
+
+func partProgressMessage(artifact ArtifactIdentifier, part, parts int) string {
+	return fmt.Sprintf("Copying backup for job %s on %s/%s (part %d of %d) -- %%d%%%% complete", artifact.Name(), artifact.InstanceName(), artifact.InstanceID(), part+1, parts)
+}
+
+type partDownloadExecutable struct {
+	localBackup    Backup
+	remoteArtifact BackupArtifact
+	index          int
+	parts          int
+	filePart
+	Logger
+}
+
+func (e partDownloadExecutable) Execute(ctx context.Context) error {
+	writer, err := e.localBackup.CreateArtifactPart(e.remoteArtifact, e.index)
+	if err != nil {
+		return err
+	}
+
+	percentageLogger := readwriter.NewLogPercentageWriter(writer, e.Logger, e.sizeInBytes, "bbr", partProgressMessage(e.remoteArtifact, e.index, e.parts))
+	err = e.remoteArtifact.StreamFilesFromRemote(ctx, e.files, percentageLogger)
+	if err != nil {
+		writer.Close() //nolint:errcheck
+		return err
+	}
+
+	return writer.Close()
+}
+
+type partUploadExecutable struct {
+	remoteArtifact BackupArtifact
+	index          int
+	parts          int
+	ArtifactPart
+	Logger
+}
+
+func (e partUploadExecutable) Execute(ctx context.Context) error {
+	defer e.Reader.Close() //nolint:errcheck
+
+	percentageLogger := readwriter.NewLogPercentageReader(e.Reader, e.Logger, e.SizeInBytes, "bbr", partProgressMessage(e.remoteArtifact, e.index, e.parts))
+	return e.remoteArtifact.StreamToRemote(ctx, percentageLogger)
+}
+
+func runPartsInParallel(ctx context.Context, executables []executor.Executable) error {
+	return ConvertErrors(executor.NewParallelExecutor().Run(ctx, [][]executor.Executable{executables}))
+}
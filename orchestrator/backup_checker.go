@@ -1,5 +1,7 @@
 package orchestrator
 
+import "context"
+
 type BackupChecker struct {
 	*Workflow
 }
@@ -20,9 +22,14 @@ func NewBackupChecker(logger Logger, deploymentManager DeploymentManager, lockOr
 }
 
 func (b BackupChecker) Check(deploymentName string) Error {
+	return b.CheckWithContext(context.Background(), deploymentName)
+}
+
+// CheckWithContext stops checking the deployment when ctx is cancelled.
+func (b BackupChecker) CheckWithContext(ctx context.Context, deploymentName string) Error {
 	session := NewSession(deploymentName)
 
-	err := b.Workflow.Run(session) //nolint:staticcheck
+	err := b.Workflow.Run(ctx, session) //nolint:staticcheck
 
 	return err
 }
@@ -1,6 +1,7 @@
 package orchestrator_test
 
 import (
+	"context"
 	"fmt"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -45,7 +46,8 @@ var _ = Describe("Check", func() {
 
 		It("finds the deployment", func() {
 			Expect(deploymentManager.FindCallCount()).To(Equal(1))
-			Expect(deploymentManager.FindArgsForCall(0)).To(Equal(deploymentName))
+			_, name := deploymentManager.FindArgsForCall(0)
+			Expect(name).To(Equal(deploymentName))
 		})
 
 		It("checks if the deployment is backupable", func() {
@@ -74,7 +76,34 @@ var _ = Describe("Check", func() {
 
 		It("attempts to find the deployment", func() {
 			Expect(deploymentManager.FindCallCount()).To(Equal(1))
-			Expect(deploymentManager.FindArgsForCall(0)).To(Equal(deploymentName))
+			_, name := deploymentManager.FindArgsForCall(0)
+			Expect(name).To(Equal(deploymentName))
+		})
+	})
+
+	Context("when the context has a step observer", func() {
+		var observer *fakes.FakeStepObserver
+
+		BeforeEach(func() {
+			observer = new(fakes.FakeStepObserver)
+			deploymentManager.FindReturns(nil, fmt.Errorf("deployment not found"))
+		})
+
+		JustBeforeEach(func() {
+			actualCanBeBackedUpError = b.CheckWithContext(orchestrator.ContextWithStepObserver(context.Background(), observer), deploymentName)
+		})
+
+		It("reports each step that runs", func() {
+			Expect(observer.StepStartedCallCount()).To(Equal(1))
+			deployment, step := observer.StepStartedArgsForCall(0)
+			Expect(deployment).To(Equal(deploymentName))
+			Expect(step).To(Equal("FindDeploymentStep"))
+
+			Expect(observer.StepFinishedCallCount()).To(Equal(1))
+			deployment, step, err := observer.StepFinishedArgsForCall(0)
+			Expect(deployment).To(Equal(deploymentName))
+			Expect(step).To(Equal("FindDeploymentStep"))
+			Expect(err).To(MatchError("deployment not found"))
 		})
 	})
 })
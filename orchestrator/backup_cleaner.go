@@ -1,6 +1,10 @@
 package orchestrator
 
-import "github.com/cloudfoundry/bosh-backup-and-restore/executor"
+import (
+	"context"
+
+	"github.com/cloudfoundry/bosh-backup-and-restore/executor"
+)
 
 func NewBackupCleaner(logger Logger, deploymentManager DeploymentManager, lockOrderer LockOrderer,
 	executor executor.Executor) *BackupCleaner {
@@ -26,8 +30,13 @@ type BackupCleaner struct {
 }
 
 func (c BackupCleaner) Cleanup(deploymentName string) Error {
+	return c.CleanupWithContext(context.Background(), deploymentName)
+}
+
+// CleanupWithContext stops cleaning up the deployment when ctx is cancelled.
+func (c BackupCleaner) CleanupWithContext(ctx context.Context, deploymentName string) Error {
 	session := NewSession(deploymentName)
-	currentError := c.Workflow.Run(session) //nolint:staticcheck
+	currentError := c.Workflow.Run(ctx, session) //nolint:staticcheck
 
 	if len(currentError) == 0 {
 		c.Logger.Info("bbr", "'%s' cleaned up\n", deploymentName) //nolint:staticcheck
@@ -1,33 +1,55 @@
 package orchestrator
 
 import (
+	"context"
 	"fmt"
+	"time"
 
+	"github.com/cloudfoundry/bosh-backup-and-restore/executor"
 	"github.com/cloudfoundry/bosh-backup-and-restore/readwriter"
-	"github.com/pkg/errors"
+	"github.com/cloudfoundry/bosh-backup-and-restore/tracing"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type BackupDownloadExecutable struct {
 	localBackup    Backup
 	remoteArtifact BackupArtifact
+	streams        int
 	Logger
 }
 
 func NewBackupDownloadExecutable(localBackup Backup, remoteArtifact BackupArtifact, logger Logger) BackupDownloadExecutable {
+	return NewBackupDownloadExecutableWithStreams(localBackup, remoteArtifact, 1, logger)
+}
+
+// NewBackupDownloadExecutableWithStreams splits a large artifact into as many
+// as streams parts, which are downloaded in parallel and stored separately.
+func NewBackupDownloadExecutableWithStreams(localBackup Backup, remoteArtifact BackupArtifact, streams int, logger Logger) BackupDownloadExecutable {
 	return BackupDownloadExecutable{
 		localBackup:    localBackup,
 		remoteArtifact: remoteArtifact,
+		streams:        streams,
 		Logger:         logger,
 	}
 }
 
-func (e BackupDownloadExecutable) Execute() error {
-	err := e.downloadBackupArtifact(e.localBackup, e.remoteArtifact)
+func (e BackupDownloadExecutable) Execute(ctx context.Context) error {
+	ctx, span := tracing.Start(ctx, "download artifact", artifactAttributes(e.remoteArtifact)...)
+	err := e.download(ctx, span)
+	tracing.End(span, err)
+
+	return err
+}
+
+func (e BackupDownloadExecutable) download(ctx context.Context, span trace.Span) error {
+	startTime := time.Now()
+	err := e.downloadBackupArtifact(ctx, e.localBackup, e.remoteArtifact)
 	if err != nil {
 		return err
 	}
+	finishTime := time.Now()
 
-	checksum, err := e.compareChecksums(e.localBackup, e.remoteArtifact)
+	checksum, err := e.compareChecksums(ctx, e.localBackup, e.remoteArtifact)
 	if err != nil {
 		return err
 	}
@@ -37,7 +59,13 @@ func (e BackupDownloadExecutable) Execute() error {
 		return err
 	}
 
-	err = e.remoteArtifact.Delete()
+	sizeInBytes, err := e.recordTransfer(startTime, finishTime)
+	if err != nil {
+		return err
+	}
+	span.SetAttributes(tracing.BytesKey.Int(sizeInBytes))
+
+	err = e.remoteArtifact.Delete(ctx)
 	if err != nil {
 		return err
 	}
@@ -46,18 +74,57 @@ func (e BackupDownloadExecutable) Execute() error {
 	return nil
 }
 
-func (e BackupDownloadExecutable) downloadBackupArtifact(localBackup Backup, remoteBackupArtifact BackupArtifact) error {
-	localBackupArtifactWriter, err := localBackup.CreateArtifact(remoteBackupArtifact)
+func (e BackupDownloadExecutable) recordTransfer(startTime, finishTime time.Time) (int, error) {
+	sizeInBytes, err := e.localBackup.GetArtifactByteSize(e.remoteArtifact)
+	if err != nil {
+		return 0, err
+	}
+
+	err = e.localBackup.AddArtifactTransfer(e.remoteArtifact, ArtifactTransfer{
+		SizeInBytes: sizeInBytes,
+		Duration:    finishTime.Sub(startTime),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return sizeInBytes, e.localBackup.AddPhaseTimings([]PhaseTiming{{
+		Phase:        DrainPhase,
+		ArtifactName: e.remoteArtifact.Name(),
+		Instance:     fmt.Sprintf("%s/%s", e.remoteArtifact.InstanceName(), e.remoteArtifact.InstanceID()),
+		StartTime:    startTime,
+		FinishTime:   finishTime,
+	}})
+}
+
+func (e BackupDownloadExecutable) downloadBackupArtifact(ctx context.Context, localBackup Backup, remoteBackupArtifact BackupArtifact) error {
+	size, err := remoteBackupArtifact.Size(ctx)
 	if err != nil {
 		return err
 	}
 
-	size, err := remoteBackupArtifact.Size()
+	sizeInBytes, err := remoteBackupArtifact.SizeInBytes(ctx)
 	if err != nil {
 		return err
 	}
 
-	sizeInBytes, err := remoteBackupArtifact.SizeInBytes()
+	e.Logger.Info("bbr", "Copying backup -- %s uncompressed -- for job %s on %s/%s...", size, remoteBackupArtifact.Name(), remoteBackupArtifact.InstanceName(), remoteBackupArtifact.InstanceID()) //nolint:staticcheck
+
+	if parts := e.splitIntoParts(ctx, remoteBackupArtifact, sizeInBytes); parts != nil {
+		err = e.downloadParts(ctx, localBackup, remoteBackupArtifact, parts)
+	} else {
+		err = e.downloadInOneStream(ctx, localBackup, remoteBackupArtifact, sizeInBytes)
+	}
+	if err != nil {
+		return err
+	}
+
+	e.Logger.Info("bbr", "Finished copying backup -- for job %s on %s/%s...", remoteBackupArtifact.Name(), remoteBackupArtifact.InstanceName(), remoteBackupArtifact.InstanceID()) //nolint:staticcheck
+	return nil
+}
+
+func (e BackupDownloadExecutable) downloadInOneStream(ctx context.Context, localBackup Backup, remoteBackupArtifact BackupArtifact, sizeInBytes int) error {
+	localBackupArtifactWriter, err := localBackup.CreateArtifact(remoteBackupArtifact)
 	if err != nil {
 		return err
 	}
@@ -65,22 +132,49 @@ func (e BackupDownloadExecutable) downloadBackupArtifact(localBackup Backup, rem
 	percentageMessage := fmt.Sprintf("Copying backup for job %s on %s/%s -- %%d%%%% complete", remoteBackupArtifact.Name(), remoteBackupArtifact.InstanceName(), remoteBackupArtifact.InstanceID())
 	percentageLogger := readwriter.NewLogPercentageWriter(localBackupArtifactWriter, e.Logger, sizeInBytes, "bbr", percentageMessage)
 
-	e.Logger.Info("bbr", "Copying backup -- %s uncompressed -- for job %s on %s/%s...", size, remoteBackupArtifact.Name(), remoteBackupArtifact.InstanceName(), remoteBackupArtifact.InstanceID()) //nolint:staticcheck
-	err = remoteBackupArtifact.StreamFromRemote(percentageLogger)
+	err = remoteBackupArtifact.StreamFromRemote(ctx, percentageLogger)
 	if err != nil {
 		return err
 	}
 
-	err = localBackupArtifactWriter.Close()
+	return localBackupArtifactWriter.Close()
+}
+
+// splitIntoParts returns nil when the artifact should be downloaded in one
+// stream, including when its files cannot be listed.
+func (e BackupDownloadExecutable) splitIntoParts(ctx context.Context, remoteBackupArtifact BackupArtifact, sizeInBytes int) []filePart {
+	if e.streams < 2 || sizeInBytes < 2*minimumPartSizeInBytes {
+		return nil
+	}
+
+	fileSizes, err := remoteBackupArtifact.FileSizesInBytes(ctx)
 	if err != nil {
-		return err
+		e.Logger.Warn("bbr", "Copying backup for job %s on %s/%s in a single stream: %s", remoteBackupArtifact.Name(), remoteBackupArtifact.InstanceName(), remoteBackupArtifact.InstanceID(), err) //nolint:staticcheck
+		return nil
 	}
 
-	e.Logger.Info("bbr", "Finished copying backup -- for job %s on %s/%s...", remoteBackupArtifact.Name(), remoteBackupArtifact.InstanceName(), remoteBackupArtifact.InstanceID()) //nolint:staticcheck
-	return nil
+	return splitFilesBySize(fileSizes, e.streams)
 }
 
-func (e BackupDownloadExecutable) compareChecksums(localBackup Backup, remoteBackupArtifact BackupArtifact) (BackupChecksum, error) {
+func (e BackupDownloadExecutable) downloadParts(ctx context.Context, localBackup Backup, remoteBackupArtifact BackupArtifact, parts []filePart) error {
+	e.Logger.Info("bbr", "Copying backup for job %s on %s/%s in %d parallel streams...", remoteBackupArtifact.Name(), remoteBackupArtifact.InstanceName(), remoteBackupArtifact.InstanceID(), len(parts)) //nolint:staticcheck
+
+	var executables []executor.Executable
+	for i, part := range parts {
+		executables = append(executables, partDownloadExecutable{
+			localBackup:    localBackup,
+			remoteArtifact: remoteBackupArtifact,
+			index:          i,
+			parts:          len(parts),
+			filePart:       part,
+			Logger:         e.Logger,
+		})
+	}
+
+	return runPartsInParallel(ctx, executables)
+}
+
+func (e BackupDownloadExecutable) compareChecksums(ctx context.Context, localBackup Backup, remoteBackupArtifact BackupArtifact) (BackupChecksum, error) {
 	e.Logger.Info("bbr", "Starting validity checks -- for job %s on %s/%s...", remoteBackupArtifact.Name(), remoteBackupArtifact.InstanceName(), remoteBackupArtifact.InstanceID()) //nolint:staticcheck
 
 	localChecksum, err := localBackup.CalculateChecksum(remoteBackupArtifact)
@@ -88,7 +182,7 @@ func (e BackupDownloadExecutable) compareChecksums(localBackup Backup, remoteBac
 		return nil, err
 	}
 
-	remoteChecksum, err := remoteBackupArtifact.Checksum()
+	remoteChecksum, err := remoteBackupArtifact.Checksum(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -100,11 +194,10 @@ func (e BackupDownloadExecutable) compareChecksums(localBackup Backup, remoteBac
 		e.Logger.Debug("bbr", "Checksums didn't match for:")        //nolint:staticcheck
 		e.Logger.Debug("bbr", fmt.Sprintf("%v\n", mismatchedFiles)) //nolint:staticcheck
 
-		err = errors.Errorf(
+		return nil, NewChecksumError(fmt.Sprintf(
 			"Backup is corrupted, checksum failed for %s/%s %s - checksums don't match for %v. "+
 				"Checksum failed for %d files in total",
-			remoteBackupArtifact.InstanceName(), remoteBackupArtifact.InstanceID(), remoteBackupArtifact.Name(), getFirstTen(mismatchedFiles), len(mismatchedFiles))
-		return nil, err
+			remoteBackupArtifact.InstanceName(), remoteBackupArtifact.InstanceID(), remoteBackupArtifact.Name(), getFirstTen(mismatchedFiles), len(mismatchedFiles)))
 	}
 
 	return localChecksum, nil
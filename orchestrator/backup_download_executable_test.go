@@ -1,7 +1,9 @@
 package orchestrator_test
 
 import (
+	"context"
 	"fmt"
+	"io"
 
 	"github.com/cloudfoundry/bosh-backup-and-restore/executor"
 	"github.com/cloudfoundry/bosh-backup-and-restore/orchestrator"
@@ -13,6 +15,7 @@ import (
 
 var _ = Describe("BackupDownloadExecutable", func() {
 	var (
+		ctx                       context.Context
 		executable                executor.Executable
 		localBackup               *fakes.FakeBackup
 		remoteArtifact            *fakes.FakeBackupArtifact
@@ -21,17 +24,22 @@ var _ = Describe("BackupDownloadExecutable", func() {
 		actualError               error
 	)
 	BeforeEach(func() {
+		ctx = context.Background()
 		localBackup = new(fakes.FakeBackup)
 		remoteArtifact = new(fakes.FakeBackupArtifact)
 		logger = new(fakes.FakeLogger)
 		localBackupArtifactWriter = new(fakes.FakeWriteCloser)
 
 		localBackup.CreateArtifactReturns(localBackupArtifactWriter, nil)
+		localBackup.GetArtifactByteSizeReturns(2048, nil)
+		remoteArtifact.NameReturns("redis")
+		remoteArtifact.InstanceNameReturns("redis-server")
+		remoteArtifact.InstanceIDReturns("abc")
 	})
 
 	JustBeforeEach(func() {
 		executable = orchestrator.NewBackupDownloadExecutable(localBackup, remoteArtifact, logger)
-		actualError = executable.Execute()
+		actualError = executable.Execute(ctx)
 	})
 
 	It("downloads the artifact", func() {
@@ -50,7 +58,7 @@ var _ = Describe("BackupDownloadExecutable", func() {
 
 		By("streaming from the remote artifact", func() {
 			Expect(remoteArtifact.StreamFromRemoteCallCount()).To(Equal(1))
-			streamWriter := remoteArtifact.StreamFromRemoteArgsForCall(0)
+			_, streamWriter := remoteArtifact.StreamFromRemoteArgsForCall(0)
 			Expect(streamWriter).To(BeAssignableToTypeOf(&readwriter.LogPercentageWriter{}))
 			logPercentageWriter := streamWriter.(*readwriter.LogPercentageWriter)
 			Expect(logPercentageWriter.Writer).To(Equal(localBackupArtifactWriter))
@@ -71,6 +79,38 @@ var _ = Describe("BackupDownloadExecutable", func() {
 		By("logging the download", func() {
 			Expect(logger.InfoCallCount()).To(BeNumerically(">", 0))
 		})
+
+		By("recording the transfer size and duration", func() {
+			Expect(localBackup.AddArtifactTransferCallCount()).To(Equal(1))
+			artifactIdentifier, transfer := localBackup.AddArtifactTransferArgsForCall(0)
+			Expect(artifactIdentifier).To(Equal(remoteArtifact))
+			Expect(transfer.SizeInBytes).To(Equal(2048))
+		})
+
+		By("recording the drain phase timing for the artifact", func() {
+			Expect(localBackup.AddPhaseTimingsCallCount()).To(Equal(1))
+			timings := localBackup.AddPhaseTimingsArgsForCall(0)
+			Expect(timings).To(HaveLen(1))
+			Expect(timings[0].Phase).To(Equal(orchestrator.DrainPhase))
+			Expect(timings[0].ArtifactName).To(Equal("redis"))
+			Expect(timings[0].JobName).To(BeEmpty())
+			Expect(timings[0].Instance).To(Equal("redis-server/abc"))
+			Expect(timings[0].FinishTime).NotTo(BeTemporally("<", timings[0].StartTime))
+		})
+	})
+
+	Context("When the transfer cannot be recorded", func() {
+		BeforeEach(func() {
+			localBackup.AddArtifactTransferReturns(fmt.Errorf("metadata error"))
+		})
+
+		It("fails", func() {
+			Expect(actualError).To(MatchError("metadata error"))
+		})
+
+		It("does not delete the remote artifact", func() {
+			Expect(remoteArtifact.DeleteCallCount()).To(Equal(0))
+		})
 	})
 
 	Context("When the local artifact cannot be created", func() {
@@ -141,6 +181,7 @@ var _ = Describe("BackupDownloadExecutable", func() {
 
 		It("should fail", func() {
 			Expect(actualError).To(MatchError(ContainSubstring("Backup is corrupted, checksum failed")))
+			Expect(actualError).To(BeAssignableToTypeOf(orchestrator.ChecksumError{}))
 		})
 	})
 
@@ -164,3 +205,133 @@ var _ = Describe("BackupDownloadExecutable", func() {
 		})
 	})
 })
+
+var _ = Describe("BackupDownloadExecutable with several streams", func() {
+	const megabyte = 1024 * 1024
+
+	var (
+		ctx            context.Context
+		localBackup    *fakes.FakeBackup
+		remoteArtifact *fakes.FakeBackupArtifact
+		logger         *fakes.FakeLogger
+		partWriters    []*fakes.FakeWriteCloser
+		streams        int
+		actualError    error
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		localBackup = new(fakes.FakeBackup)
+		remoteArtifact = new(fakes.FakeBackupArtifact)
+		logger = new(fakes.FakeLogger)
+		streams = 2
+
+		partWriters = []*fakes.FakeWriteCloser{new(fakes.FakeWriteCloser), new(fakes.FakeWriteCloser), new(fakes.FakeWriteCloser)}
+		localBackup.CreateArtifactPartStub = func(_ orchestrator.ArtifactIdentifier, part int) (io.WriteCloser, error) {
+			return partWriters[part], nil
+		}
+		remoteArtifact.NameReturns("redis")
+		remoteArtifact.InstanceNameReturns("redis-server")
+		remoteArtifact.InstanceIDReturns("abc")
+		remoteArtifact.SizeInBytesReturns(600*megabyte, nil)
+		remoteArtifact.FileSizesInBytesReturns(map[string]int{
+			"./":       0,
+			"./big":    300 * megabyte,
+			"./empty/": 0,
+			"./medium": 200 * megabyte,
+			"./small":  100 * megabyte,
+		}, nil)
+	})
+
+	JustBeforeEach(func() {
+		executable := orchestrator.NewBackupDownloadExecutableWithStreams(localBackup, remoteArtifact, streams, logger)
+		actualError = executable.Execute(ctx)
+	})
+
+	streamedParts := func() [][]string {
+		var parts [][]string
+		for i := 0; i < remoteArtifact.StreamFilesFromRemoteCallCount(); i++ {
+			_, files, _ := remoteArtifact.StreamFilesFromRemoteArgsForCall(i)
+			parts = append(parts, files)
+		}
+		return parts
+	}
+
+	It("downloads parts of about the same size in parallel", func() {
+		Expect(actualError).NotTo(HaveOccurred())
+		Expect(remoteArtifact.StreamFromRemoteCallCount()).To(BeZero())
+		Expect(localBackup.CreateArtifactCallCount()).To(BeZero())
+
+		Expect(streamedParts()).To(ConsistOf(
+			[]string{"./", "./big", "./empty/"},
+			[]string{"./medium", "./small"},
+		))
+		Expect(localBackup.CreateArtifactPartCallCount()).To(Equal(2))
+		Expect(partWriters[0].CloseCallCount()).To(Equal(1))
+		Expect(partWriters[1].CloseCallCount()).To(Equal(1))
+
+		By("checking the checksums of the whole artifact once", func() {
+			Expect(localBackup.CalculateChecksumCallCount()).To(Equal(1))
+			Expect(remoteArtifact.ChecksumCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when there are more streams than files", func() {
+		BeforeEach(func() {
+			streams = 8
+		})
+
+		It("uses one part per file, and puts the directories in the first part", func() {
+			Expect(streamedParts()).To(ConsistOf([]string{"./", "./big", "./empty/"}, []string{"./medium"}, []string{"./small"}))
+		})
+	})
+
+	Context("when the artifact is too small to be worth splitting", func() {
+		BeforeEach(func() {
+			remoteArtifact.SizeInBytesReturns(200*megabyte, nil)
+			localBackup.CreateArtifactReturns(new(fakes.FakeWriteCloser), nil)
+		})
+
+		It("downloads it in one stream without listing its files", func() {
+			Expect(actualError).NotTo(HaveOccurred())
+			Expect(remoteArtifact.FileSizesInBytesCallCount()).To(BeZero())
+			Expect(remoteArtifact.StreamFromRemoteCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when the files cannot be listed", func() {
+		BeforeEach(func() {
+			remoteArtifact.FileSizesInBytesReturns(nil, fmt.Errorf("not supported"))
+			localBackup.CreateArtifactReturns(new(fakes.FakeWriteCloser), nil)
+		})
+
+		It("warns and downloads it in one stream", func() {
+			Expect(actualError).NotTo(HaveOccurred())
+			Expect(remoteArtifact.StreamFromRemoteCallCount()).To(Equal(1))
+			Expect(logger.WarnCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when a part cannot be downloaded", func() {
+		BeforeEach(func() {
+			remoteArtifact.StreamFilesFromRemoteReturnsOnCall(0, fmt.Errorf("stream error"))
+		})
+
+		It("fails after closing its file, and keeps the remote artifact", func() {
+			Expect(actualError).To(MatchError(ContainSubstring("stream error")))
+			Expect(partWriters[0].CloseCallCount() + partWriters[1].CloseCallCount()).To(Equal(2))
+			Expect(remoteArtifact.DeleteCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when a part cannot be created locally", func() {
+		BeforeEach(func() {
+			localBackup.CreateArtifactPartReturns(nil, fmt.Errorf("disk full"))
+			localBackup.CreateArtifactPartStub = nil
+		})
+
+		It("fails", func() {
+			Expect(actualError).To(MatchError(ContainSubstring("disk full")))
+		})
+	})
+})
@@ -1,6 +1,7 @@
 package orchestrator_test
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/cloudfoundry/bosh-backup-and-restore/executor"
@@ -12,12 +13,14 @@ import (
 
 var _ = Describe("BackupExecutables", func() {
 	var (
+		ctx        context.Context
 		err        error
 		executable executor.Executable
 		fakeJob    *fakes.FakeJob
 	)
 
 	BeforeEach(func() {
+		ctx = context.Background()
 		fakeJob = new(fakes.FakeJob)
 	})
 
@@ -26,7 +29,7 @@ var _ = Describe("BackupExecutables", func() {
 			executable = orchestrator.NewBackupExecutable(fakeJob)
 		})
 		JustBeforeEach(func() {
-			err = executable.Execute()
+			err = executable.Execute(ctx)
 		})
 
 		It("executes backup", func() {
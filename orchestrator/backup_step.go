@@ -7,9 +7,14 @@ type BackupStep struct {
 }
 
 func (s *BackupStep) Run(session *Session) error {
-	err := session.CurrentDeployment().Backup(s.executor)
+	timedExecutor := newTimingExecutor(BackupPhase, newTracingExecutor(session, "backup", s.executor))
+	err := session.CurrentDeployment().Backup(session.Context(), timedExecutor)
+	timingsErr := timedExecutor.saveTo(session.CurrentArtifact())
 	if err != nil {
-		return NewBackupError(err.Error())
+		return newBackupErrorFrom(err)
+	}
+	if timingsErr != nil {
+		return NewBackupError(timingsErr.Error())
 	}
 	return nil
 }
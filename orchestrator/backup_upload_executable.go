@@ -1,11 +1,13 @@
 package orchestrator
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/cloudfoundry/bosh-backup-and-restore/executor"
 	"github.com/cloudfoundry/bosh-backup-and-restore/readwriter"
-
-	"github.com/pkg/errors"
+	"github.com/cloudfoundry/bosh-backup-and-restore/tracing"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type BackupUploadExecutable struct {
@@ -24,27 +26,39 @@ func NewBackupUploadExecutable(localBackup Backup, remoteArtifact BackupArtifact
 	}
 }
 
-func (e BackupUploadExecutable) Execute() error {
-	localBackupArtifactReader, err := e.localBackup.ReadArtifact(e.remoteArtifact)
+func (e BackupUploadExecutable) Execute(ctx context.Context) error {
+	ctx, span := tracing.Start(ctx, "upload artifact", artifactAttributes(e.remoteArtifact)...)
+	err := e.upload(ctx, span)
+	tracing.End(span, err)
+
+	return err
+}
+
+func (e BackupUploadExecutable) upload(ctx context.Context, span trace.Span) error {
+	parts, err := e.localBackup.ReadArtifactParts(e.remoteArtifact)
 	if err != nil {
 		return err
 	}
 
 	size, err := e.localBackup.GetArtifactSize(e.remoteArtifact)
 	if err != nil {
+		closeParts(parts)
 		return err
 	}
 
 	sizeInBytes, err := e.localBackup.GetArtifactByteSize(e.remoteArtifact)
 	if err != nil {
+		closeParts(parts)
 		return err
 	}
-
-	percentageMessage := fmt.Sprintf("Copying backup for job %s on %s/%s -- %%d%%%% complete", e.remoteArtifact.Name(), e.remoteArtifact.InstanceName(), e.remoteArtifact.InstanceID())
-	percentageLogger := readwriter.NewLogPercentageReader(localBackupArtifactReader, e.Logger, sizeInBytes, "bbr", percentageMessage)
+	span.SetAttributes(tracing.BytesKey.Int(sizeInBytes))
 
 	e.Logger.Info("bbr", "Copying backup -- %s uncompressed -- for job %s on %s/%s...", size, e.remoteArtifact.Name(), e.instance.Name(), e.instance.Index()) //nolint:staticcheck
-	err = e.remoteArtifact.StreamToRemote(percentageLogger)
+	if len(parts) == 1 {
+		err = e.uploadInOneStream(ctx, parts[0], sizeInBytes)
+	} else {
+		err = e.uploadParts(ctx, parts)
+	}
 	if err != nil {
 		return err
 	}
@@ -56,7 +70,7 @@ func (e BackupUploadExecutable) Execute() error {
 		return err
 	}
 
-	remoteChecksum, err := e.remoteArtifact.Checksum()
+	remoteChecksum, err := e.remoteArtifact.Checksum(ctx)
 	if err != nil {
 		return err
 	}
@@ -65,15 +79,49 @@ func (e BackupUploadExecutable) Execute() error {
 	if !match {
 		e.Logger.Debug("bbr", "Checksums didn't match for:")        //nolint:staticcheck
 		e.Logger.Debug("bbr", fmt.Sprintf("%v\n", mismatchedFiles)) //nolint:staticcheck
-		return errors.Errorf("Backup couldn't be transferred, checksum failed for %s/%s %s - checksums don't match for %v. Checksum failed for %d files in total",
+		return NewChecksumError(fmt.Sprintf("Backup couldn't be transferred, checksum failed for %s/%s %s - checksums don't match for %v. Checksum failed for %d files in total",
 			e.instance.Name(),
 			e.instance.ID(),
 			e.remoteArtifact.Name(),
 			getFirstTen(mismatchedFiles),
 			len(mismatchedFiles),
-		)
+		))
 	}
 	e.Logger.Info("bbr", "Finished copying backup for job %s on %s/%s.", e.remoteArtifact.Name(), e.instance.Name(), e.instance.Index()) //nolint:staticcheck
 
 	return nil
 }
+
+func (e BackupUploadExecutable) uploadInOneStream(ctx context.Context, part ArtifactPart, sizeInBytes int) error {
+	defer part.Reader.Close() //nolint:errcheck
+
+	percentageMessage := fmt.Sprintf("Copying backup for job %s on %s/%s -- %%d%%%% complete", e.remoteArtifact.Name(), e.remoteArtifact.InstanceName(), e.remoteArtifact.InstanceID())
+	percentageLogger := readwriter.NewLogPercentageReader(part.Reader, e.Logger, sizeInBytes, "bbr", percentageMessage)
+
+	return e.remoteArtifact.StreamToRemote(ctx, percentageLogger)
+}
+
+// uploadParts extracts every part into the same job directory, one stream per
+// part.
+func (e BackupUploadExecutable) uploadParts(ctx context.Context, parts []ArtifactPart) error {
+	e.Logger.Info("bbr", "Copying backup for job %s on %s/%s in %d parallel streams...", e.remoteArtifact.Name(), e.instance.Name(), e.instance.Index(), len(parts)) //nolint:staticcheck
+
+	var executables []executor.Executable
+	for i, part := range parts {
+		executables = append(executables, partUploadExecutable{
+			remoteArtifact: e.remoteArtifact,
+			index:          i,
+			parts:          len(parts),
+			ArtifactPart:   part,
+			Logger:         e.Logger,
+		})
+	}
+
+	return runPartsInParallel(ctx, executables)
+}
+
+func closeParts(parts []ArtifactPart) {
+	for _, part := range parts {
+		part.Reader.Close() //nolint:errcheck
+	}
+}
@@ -1,6 +1,8 @@
 package orchestrator_test
 
 import (
+	"context"
+
 	"github.com/cloudfoundry/bosh-backup-and-restore/orchestrator"
 	"github.com/cloudfoundry/bosh-backup-and-restore/readwriter"
 	"github.com/pkg/errors"
@@ -17,6 +19,7 @@ import (
 
 var _ = Describe("BackupUploadExecutable", func() {
 	var (
+		ctx                       context.Context
 		executable                executor.Executable
 		backup                    *fakes.FakeBackup
 		remoteArtifact            *fakes.FakeBackupArtifact
@@ -26,20 +29,21 @@ var _ = Describe("BackupUploadExecutable", func() {
 		localBackupArtifactReader io.ReadCloser
 	)
 	BeforeEach(func() {
+		ctx = context.Background()
 		backup = new(fakes.FakeBackup)
 		remoteArtifact = new(fakes.FakeBackupArtifact)
 		instance = new(fakes.FakeInstance)
 		logger = new(fakes.FakeLogger)
 
 		localBackupArtifactReader = io.NopCloser(bytes.NewBufferString("this-is-some-backup-data"))
-		backup.ReadArtifactReturns(localBackupArtifactReader, nil)
+		backup.ReadArtifactPartsReturns([]orchestrator.ArtifactPart{{Reader: localBackupArtifactReader, SizeInBytes: 24}}, nil)
 		backup.FetchChecksumReturns(orchestrator.BackupChecksum{"file1": "abcd", "file2": "foo"}, nil)
 		remoteArtifact.ChecksumReturns(orchestrator.BackupChecksum{"file1": "abcd", "file2": "foo"}, nil)
 	})
 
 	JustBeforeEach(func() {
 		executable = orchestrator.NewBackupUploadExecutable(backup, remoteArtifact, instance, logger)
-		actualError = executable.Execute()
+		actualError = executable.Execute(ctx)
 
 	})
 
@@ -56,13 +60,13 @@ var _ = Describe("BackupUploadExecutable", func() {
 			})
 
 			By("fetching the remote artifact from the backup", func() {
-				Expect(backup.ReadArtifactCallCount()).To(Equal(1))
-				Expect(backup.ReadArtifactArgsForCall(0)).To(Equal(remoteArtifact))
+				Expect(backup.ReadArtifactPartsCallCount()).To(Equal(1))
+				Expect(backup.ReadArtifactPartsArgsForCall(0)).To(Equal(remoteArtifact))
 			})
 
 			By("streaming from the remote artifact", func() {
 				Expect(remoteArtifact.StreamToRemoteCallCount()).To(Equal(1))
-				streamReader := remoteArtifact.StreamToRemoteArgsForCall(0)
+				_, streamReader := remoteArtifact.StreamToRemoteArgsForCall(0)
 				Expect(streamReader).To(BeAssignableToTypeOf(&readwriter.LogPercentageReader{}))
 				logPercentageReader := streamReader.(*readwriter.LogPercentageReader)
 				Expect(logPercentageReader.Reader).To(Equal(localBackupArtifactReader))
@@ -73,7 +77,7 @@ var _ = Describe("BackupUploadExecutable", func() {
 			})
 
 			By("fetching local checksum", func() {
-				Expect(backup.ReadArtifactCallCount()).To(Equal(1))
+				Expect(backup.FetchChecksumCallCount()).To(Equal(1))
 				Expect(backup.FetchChecksumArgsForCall(0)).To(Equal(remoteArtifact))
 			})
 
@@ -91,6 +95,48 @@ var _ = Describe("BackupUploadExecutable", func() {
 		})
 	})
 
+	Context("When the artifact is stored in several parts", func() {
+		var partReaders []*closeRecordingReader
+
+		BeforeEach(func() {
+			partReaders = []*closeRecordingReader{
+				{Reader: bytes.NewBufferString("part-0")},
+				{Reader: bytes.NewBufferString("part-1")},
+			}
+			backup.ReadArtifactPartsReturns([]orchestrator.ArtifactPart{
+				{Reader: partReaders[0], SizeInBytes: 6},
+				{Reader: partReaders[1], SizeInBytes: 6},
+			}, nil)
+		})
+
+		It("streams every part to the remote and closes them", func() {
+			Expect(actualError).NotTo(HaveOccurred())
+			Expect(remoteArtifact.StreamToRemoteCallCount()).To(Equal(2))
+
+			var streamed []io.Reader
+			for i := 0; i < 2; i++ {
+				_, reader := remoteArtifact.StreamToRemoteArgsForCall(i)
+				streamed = append(streamed, reader.(*readwriter.LogPercentageReader).Reader)
+			}
+			Expect(streamed).To(ConsistOf(partReaders[0], partReaders[1]))
+			Expect(partReaders[0].closed).To(BeTrue())
+			Expect(partReaders[1].closed).To(BeTrue())
+
+			Expect(remoteArtifact.ChecksumCallCount()).To(Equal(1))
+		})
+
+		Context("and a part cannot be streamed", func() {
+			BeforeEach(func() {
+				remoteArtifact.StreamToRemoteReturnsOnCall(1, fmt.Errorf("stream error"))
+			})
+
+			It("fails without checking the checksum", func() {
+				Expect(actualError).To(MatchError(ContainSubstring("stream error")))
+				Expect(remoteArtifact.ChecksumCallCount()).To(BeZero())
+			})
+		})
+	})
+
 	Context("When the artifact size fails to be calculated", func() {
 		BeforeEach(func() {
 			backup.GetArtifactSizeReturns("1G", errors.New("I failed"))
@@ -105,7 +151,7 @@ var _ = Describe("BackupUploadExecutable", func() {
 
 	Context("When the artifact cannot be read from the backup", func() {
 		BeforeEach(func() {
-			backup.ReadArtifactReturns(nil, fmt.Errorf("artifact error"))
+			backup.ReadArtifactPartsReturns(nil, fmt.Errorf("artifact error"))
 		})
 
 		It("should fail", func() {
@@ -140,7 +186,18 @@ var _ = Describe("BackupUploadExecutable", func() {
 
 		It("should fail", func() {
 			Expect(actualError).To(MatchError(ContainSubstring("Backup couldn't be transferred, checksum failed")))
+			Expect(actualError).To(BeAssignableToTypeOf(orchestrator.ChecksumError{}))
 		})
 	})
 
 })
+
+type closeRecordingReader struct {
+	io.Reader
+	closed bool
+}
+
+func (r *closeRecordingReader) Close() error {
+	r.closed = true
+	return nil
+}
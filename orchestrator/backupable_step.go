@@ -1,33 +1,48 @@
 package orchestrator
 
 import (
-	"github.com/pkg/errors"
+	"fmt"
 )
 
 type BackupableStep struct {
-	lockOrderer LockOrderer
-	logger      Logger
+	lockOrderer   LockOrderer
+	artifactSpace ArtifactSpace
+	logger        Logger
 }
 
 func NewBackupableStep(lockOrderer LockOrderer, logger Logger) Step {
 	return &BackupableStep{lockOrderer: lockOrderer, logger: logger}
 }
 
+// NewBackupableStepWithDiskSpaceCheck also fails the pre-checks when the
+// backup is not expected to fit on the instances or at the artifact path.
+func NewBackupableStepWithDiskSpaceCheck(lockOrderer LockOrderer, artifactSpace ArtifactSpace, logger Logger) Step {
+	return &BackupableStep{lockOrderer: lockOrderer, artifactSpace: artifactSpace, logger: logger}
+}
+
 func (s *BackupableStep) Run(session *Session) error {
 	s.logger.Info("bbr", "Running pre-checks for backup of %s...\n", session.DeploymentName())
 
 	deployment := session.CurrentDeployment()
 	if !deployment.IsBackupable() {
-		return errors.Errorf("Deployment '%s' has no backup scripts", session.DeploymentName())
+		return NewPreCheckError(fmt.Sprintf("Deployment '%s' has no backup scripts", session.DeploymentName()))
 	}
 
-	err := deployment.CheckArtifactDir()
+	err := deployment.CheckArtifactDir(session.Context())
 	if err != nil {
 		return NewArtifactDirError(err.Error())
 	}
 
 	if err := deployment.ValidateLockingDependencies(s.lockOrderer); err != nil {
-		return err
+		return NewPreCheckError(err.Error())
+	}
+
+	if err := deployment.ValidateBackupOneRestoreAllSources(); err != nil {
+		return NewPreCheckError(err.Error())
+	}
+
+	if s.artifactSpace != nil {
+		return diskSpaceCheck{artifactSpace: s.artifactSpace, logger: s.logger}.Run(session)
 	}
 	return nil
 }
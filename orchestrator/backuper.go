@@ -1,22 +1,31 @@
 package orchestrator
 
 import (
+	"context"
 	"time"
 
 	exe "github.com/cloudfoundry/bosh-backup-and-restore/executor"
+	"github.com/cloudfoundry/bosh-backup-and-restore/tracing"
 )
 
 func NewBackuper(backupManager BackupManager, logger Logger, deploymentManager DeploymentManager, lockOrderer LockOrderer,
-	executor exe.Executor, nowFunc func() time.Time, artifactCopier ArtifactCopier, unsafeLockFree bool, timestamp string) *Backuper {
+	executor exe.Executor, nowFunc func() time.Time, artifactCopier ArtifactCopier, unsafeLockFree bool, timestamp string,
+	hooks Hooks, hookRunner HookRunner, artifactSpace ArtifactSpace) *Backuper {
 
 	findDeploymentStep := NewFindDeploymentStep(deploymentManager, logger)
 	backupable := NewBackupableStep(lockOrderer, logger)
+	if artifactSpace != nil {
+		backupable = NewBackupableStepWithDiskSpaceCheck(lockOrderer, artifactSpace, logger)
+	}
 	createArtifact := NewCreateArtifactStep(logger, backupManager, deploymentManager, nowFunc, timestamp)
 
 	backup := NewBackupStep(executor)
 	drain := NewDrainStep(logger, artifactCopier)
 	cleanup := NewCleanupStep()
 	addFinishTimeStep := NewAddFinishTimeStep(nowFunc)
+	preBackupHook := NewHookStep(PreBackupHook, "backup", hooks.PreBackup, hookRunner, logger)
+	postLockHook := NewHookStep(PostLockHook, "backup", hooks.PostLock, hookRunner, logger)
+	postBackupHook := NewHookStep(PostBackupHook, "backup", hooks.PostBackup, hookRunner, logger)
 
 	var lock, unlockAfterSuccessfulBackup, unlockAfterFailedBackup Step
 	if !unsafeLockFree {
@@ -32,13 +41,16 @@ func NewBackuper(backupManager BackupManager, logger Logger, deploymentManager D
 	workflow := NewWorkflow()
 	workflow.StartWith(findDeploymentStep).OnSuccess(backupable)
 	workflow.Add(backupable).OnSuccess(createArtifact).OnFailure(cleanup)
-	workflow.Add(createArtifact).OnSuccess(lock).OnFailure(cleanup)
-	workflow.Add(lock).OnSuccess(backup).OnFailure(unlockAfterFailedBackup)
+	workflow.Add(createArtifact).OnSuccess(preBackupHook).OnFailure(cleanup)
+	workflow.Add(preBackupHook).OnSuccess(lock).OnFailure(cleanup)
+	workflow.Add(lock).OnSuccess(postLockHook).OnFailure(unlockAfterFailedBackup)
+	workflow.Add(postLockHook).OnSuccess(backup).OnFailure(unlockAfterFailedBackup)
 	workflow.Add(backup).OnSuccess(unlockAfterSuccessfulBackup).OnFailure(unlockAfterFailedBackup)
-	workflow.Add(unlockAfterSuccessfulBackup).OnSuccessOrFailure(drain)
-	workflow.Add(unlockAfterFailedBackup).OnSuccessOrFailure(cleanup)
-	workflow.Add(drain).OnSuccessOrFailure(cleanup)
-	workflow.Add(cleanup).OnSuccessOrFailure(addFinishTimeStep)
+	workflow.Add(unlockAfterSuccessfulBackup).OnSuccessOrFailure(drain).RunWhenAborted()
+	workflow.Add(unlockAfterFailedBackup).OnSuccessOrFailure(cleanup).RunWhenAborted()
+	workflow.Add(drain).OnSuccess(postBackupHook).OnFailure(cleanup)
+	workflow.Add(postBackupHook).OnSuccessOrFailure(cleanup)
+	workflow.Add(cleanup).OnSuccessOrFailure(addFinishTimeStep).RunWhenAborted()
 	workflow.Add(addFinishTimeStep)
 
 	return &Backuper{
@@ -57,10 +69,18 @@ type AuthInfo struct {
 
 // Backup checks if a deployment has backupable instances and backs them up.
 func (b Backuper) Backup(deploymentName, artifactPath string) Error {
+	return b.BackupWithContext(context.Background(), deploymentName, artifactPath)
+}
+
+// BackupWithContext aborts the backup when ctx is cancelled, unlocking and
+// cleaning up the deployment before returning.
+func (b Backuper) BackupWithContext(ctx context.Context, deploymentName, artifactPath string) Error {
 	session := NewSession(deploymentName)
 	session.SetCurrentArtifactPath(artifactPath)
 
-	err := b.workflow.Run(session)
+	ctx, span := tracing.Start(ctx, "backup", tracing.DeploymentKey.String(deploymentName))
+	err := b.workflow.Run(ctx, session)
+	tracing.End(span, ConvertErrors(err))
 
 	return err
 }
@@ -1,6 +1,8 @@
 package orchestrator_test
 
 import (
+	"context"
+	"errors"
 	"fmt"
 
 	"time"
@@ -8,8 +10,13 @@ import (
 	"github.com/cloudfoundry/bosh-backup-and-restore/executor"
 	"github.com/cloudfoundry/bosh-backup-and-restore/orchestrator"
 	"github.com/cloudfoundry/bosh-backup-and-restore/orchestrator/fakes"
+	"github.com/cloudfoundry/bosh-backup-and-restore/tracing"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace/noop"
 )
 
 var _ = Describe("Backup", func() {
@@ -28,6 +35,10 @@ var _ = Describe("Backup", func() {
 		timeStamp             string
 		unsafeLockFree        bool
 		nowFunc               func() time.Time
+		hooks                 orchestrator.Hooks
+		hookRunner            *fakes.FakeHookRunner
+		artifactSpace         orchestrator.ArtifactSpace
+		ctx                   context.Context
 	)
 
 	BeforeEach(func() {
@@ -50,12 +61,15 @@ var _ = Describe("Backup", func() {
 		}
 
 		artifactCopier = new(fakes.FakeArtifactCopier)
-
+		hooks = orchestrator.Hooks{}
+		hookRunner = new(fakes.FakeHookRunner)
+		artifactSpace = nil
+		ctx = context.Background()
 	})
 
 	JustBeforeEach(func() {
-		b = orchestrator.NewBackuper(fakeBackupManager, logger, deploymentManager, lockOrderer, executor.NewParallelExecutor(), nowFunc, artifactCopier, unsafeLockFree, timeStamp)
-		actualBackupError = b.Backup(deploymentName, "")
+		b = orchestrator.NewBackuper(fakeBackupManager, logger, deploymentManager, lockOrderer, executor.NewParallelExecutor(), nowFunc, artifactCopier, unsafeLockFree, timeStamp, hooks, hookRunner, artifactSpace)
+		actualBackupError = b.BackupWithContext(ctx, deploymentName, "")
 	})
 
 	Context("backs up a deployment", func() {
@@ -73,7 +87,8 @@ var _ = Describe("Backup", func() {
 
 		It("finds the deployment", func() {
 			Expect(deploymentManager.FindCallCount()).To(Equal(1))
-			Expect(deploymentManager.FindArgsForCall(0)).To(Equal(deploymentName))
+			_, name := deploymentManager.FindArgsForCall(0)
+			Expect(name).To(Equal(deploymentName))
 		})
 
 		It("saves the deployment manifest", func() {
@@ -97,7 +112,7 @@ var _ = Describe("Backup", func() {
 
 		It("runs post-backup-unlock scripts on the deployment", func() {
 			Expect(deployment.PostBackupUnlockCallCount()).To(Equal(1))
-			afterSuccessfulBackup, _, _ := deployment.PostBackupUnlockArgsForCall(0)
+			_, afterSuccessfulBackup, _, _ := deployment.PostBackupUnlockArgsForCall(0)
 			Expect(afterSuccessfulBackup).To(BeTrue())
 		})
 
@@ -119,7 +134,7 @@ var _ = Describe("Backup", func() {
 		It("drains the backup to the artifact", func() {
 			Expect(artifactCopier.DownloadBackupFromDeploymentCallCount()).To(Equal(1))
 
-			downloadedBackup, downloadedFromDeployment := artifactCopier.DownloadBackupFromDeploymentArgsForCall(0)
+			_, downloadedBackup, downloadedFromDeployment := artifactCopier.DownloadBackupFromDeploymentArgsForCall(0)
 			Expect(downloadedBackup).To(Equal(fakeBackup))
 			Expect(downloadedFromDeployment).To(Equal(deployment))
 		})
@@ -128,6 +143,115 @@ var _ = Describe("Backup", func() {
 			Expect(fakeBackup.CreateMetadataFileWithStartTimeArgsForCall(0)).To(Equal(startTime))
 			Expect(fakeBackup.AddFinishTimeArgsForCall(0)).To(Equal(finishTime))
 		})
+
+		Context("when jobs are locked, backed up and unlocked", func() {
+			BeforeEach(func() {
+				job := new(fakes.FakeJob)
+				job.NameReturns("redis")
+				job.InstanceIdentifierReturns("redis-server/0")
+
+				deployment.PreBackupLockStub = func(ctx context.Context, _ orchestrator.LockOrderer, exe executor.Executor) error {
+					return orchestrator.ConvertErrors(exe.Run(ctx, [][]executor.Executable{{orchestrator.NewJobPreBackupLockExecutable(job)}}))
+				}
+				deployment.BackupStub = func(ctx context.Context, exe executor.Executor) error {
+					return orchestrator.ConvertErrors(exe.Run(ctx, [][]executor.Executable{{orchestrator.NewBackupExecutable(job)}}))
+				}
+				deployment.PostBackupUnlockStub = func(ctx context.Context, _ bool, _ orchestrator.LockOrderer, exe executor.Executor) error {
+					return orchestrator.ConvertErrors(exe.Run(ctx, [][]executor.Executable{{orchestrator.NewJobPostSuccessfulBackupUnlockExecutable(job)}}))
+				}
+			})
+
+			It("saves the timings of each phase for each job in the metadata file", func() {
+				Expect(fakeBackup.AddPhaseTimingsCallCount()).To(Equal(3))
+
+				for i, phase := range []string{orchestrator.LockPhase, orchestrator.BackupPhase, orchestrator.UnlockPhase} {
+					timings := fakeBackup.AddPhaseTimingsArgsForCall(i)
+					Expect(timings).To(HaveLen(1))
+					Expect(timings[0].Phase).To(Equal(phase))
+					Expect(timings[0].JobName).To(Equal("redis"))
+					Expect(timings[0].Instance).To(Equal("redis-server/0"))
+				}
+			})
+
+			Context("when tracing is enabled", func() {
+				var spanRecorder *tracetest.SpanRecorder
+
+				BeforeEach(func() {
+					spanRecorder = tracetest.NewSpanRecorder()
+					otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder)))
+				})
+
+				AfterEach(func() {
+					otel.SetTracerProvider(noop.NewTracerProvider())
+				})
+
+				It("traces each job script as part of the step that ran it", func() {
+					spans := map[string]sdktrace.ReadOnlySpan{}
+					for _, span := range spanRecorder.Ended() {
+						spans[span.Name()] = span
+					}
+
+					Expect(spans).To(HaveKey("backup"))
+					Expect(spans).To(HaveKey("FindDeploymentStep"))
+					Expect(spans["LockStep"].Parent().SpanID()).To(Equal(spans["backup"].SpanContext().SpanID()))
+					Expect(spans["pre-backup-lock"].Parent().SpanID()).To(Equal(spans["LockStep"].SpanContext().SpanID()))
+					Expect(spans["pre-backup-lock"].Attributes()).To(ConsistOf(
+						tracing.DeploymentKey.String(deploymentName),
+						tracing.JobKey.String("redis"),
+						tracing.InstanceKey.String("redis-server/0"),
+					))
+					Expect(spans["BackupStep"].Parent().SpanID()).To(Equal(spans["backup"].SpanContext().SpanID()))
+					Expect(spans["backup"].Parent().IsValid()).To(BeFalse())
+				})
+			})
+		})
+	})
+
+	Context("backs up a deployment with hooks", func() {
+		var runOrder []string
+
+		BeforeEach(func() {
+			runOrder = nil
+			hooks = orchestrator.Hooks{
+				PreBackup:  []string{"pause-scheduler"},
+				PostLock:   []string{"snapshot-filesystem"},
+				PostBackup: []string{"upload-to-tape", "resume-scheduler"},
+			}
+			hookRunner.RunStub = func(_ context.Context, command string, _ []string) error {
+				runOrder = append(runOrder, command)
+				return nil
+			}
+			fakeBackupManager.CreateReturns(fakeBackup, nil)
+			deploymentManager.FindReturns(deployment, nil)
+			deployment.IsBackupableReturns(true)
+			deployment.PreBackupLockStub = func(context.Context, orchestrator.LockOrderer, executor.Executor) error {
+				runOrder = append(runOrder, "lock")
+				return nil
+			}
+			deployment.BackupStub = func(context.Context, executor.Executor) error {
+				runOrder = append(runOrder, "backup")
+				return nil
+			}
+			artifactCopier.DownloadBackupFromDeploymentStub = func(context.Context, orchestrator.Backup, orchestrator.Deployment) error {
+				runOrder = append(runOrder, "drain")
+				return nil
+			}
+		})
+
+		It("runs each hook at its point in the workflow", func() {
+			Expect(actualBackupError).NotTo(HaveOccurred())
+			Expect(runOrder).To(Equal([]string{"pause-scheduler", "lock", "snapshot-filesystem", "backup", "drain", "upload-to-tape", "resume-scheduler"}))
+		})
+
+		It("describes the deployment and artifact to the hooks", func() {
+			_, _, env := hookRunner.RunArgsForCall(0)
+			Expect(env).To(ConsistOf(
+				"BBR_HOOK=pre-backup",
+				"BBR_OPERATION=backup",
+				"BBR_DEPLOYMENT="+deploymentName,
+				fmt.Sprintf("BBR_ARTIFACT_PATH=%s_%s", deploymentName, timeStamp),
+			))
+		})
 	})
 
 	Context("backs up a deployment without locking it", func() {
@@ -146,7 +270,8 @@ var _ = Describe("Backup", func() {
 
 		It("finds the deployment", func() {
 			Expect(deploymentManager.FindCallCount()).To(Equal(1))
-			Expect(deploymentManager.FindArgsForCall(0)).To(Equal(deploymentName))
+			_, name := deploymentManager.FindArgsForCall(0)
+			Expect(name).To(Equal(deploymentName))
 		})
 
 		It("saves the deployment manifest", func() {
@@ -190,7 +315,7 @@ var _ = Describe("Backup", func() {
 		It("drains the backup to the artifact", func() {
 			Expect(artifactCopier.DownloadBackupFromDeploymentCallCount()).To(Equal(1))
 
-			downloadedBackup, downloadedFromDeployment := artifactCopier.DownloadBackupFromDeploymentArgsForCall(0)
+			_, downloadedBackup, downloadedFromDeployment := artifactCopier.DownloadBackupFromDeploymentArgsForCall(0)
 			Expect(downloadedBackup).To(Equal(fakeBackup))
 			Expect(downloadedFromDeployment).To(Equal(deployment))
 		})
@@ -201,6 +326,51 @@ var _ = Describe("Backup", func() {
 		})
 	})
 
+	Context("backs up a deployment with a disk space check", func() {
+		var fakeArtifactSpace *fakes.FakeArtifactSpace
+		var instance *fakes.FakeInstance
+
+		BeforeEach(func() {
+			job := new(fakes.FakeJob)
+			job.HasBackupReturns(true)
+			job.HasBackupSizeReturns(true)
+			job.BackupSizeReturns(2048, nil)
+
+			instance = new(fakes.FakeInstance)
+			instance.JobsReturns([]orchestrator.Job{job})
+			instance.FreeSpaceInBytesReturns(4096, nil)
+
+			fakeArtifactSpace = new(fakes.FakeArtifactSpace)
+			fakeArtifactSpace.FreeSpaceInBytesReturns(4096, nil)
+			artifactSpace = fakeArtifactSpace
+
+			fakeBackupManager.CreateReturns(fakeBackup, nil)
+			deploymentManager.FindReturns(deployment, nil)
+			deployment.IsBackupableReturns(true)
+			deployment.BackupableInstancesReturns([]orchestrator.Instance{instance})
+		})
+
+		It("checks disk space before creating the artifact", func() {
+			Expect(actualBackupError).NotTo(HaveOccurred())
+			Expect(instance.FreeSpaceInBytesCallCount()).To(Equal(1))
+			Expect(fakeArtifactSpace.FreeSpaceInBytesCallCount()).To(Equal(1))
+			Expect(fakeBackupManager.CreateCallCount()).To(Equal(1))
+		})
+
+		Context("when there is not enough space", func() {
+			BeforeEach(func() {
+				fakeArtifactSpace.FreeSpaceInBytesReturns(1024, nil)
+			})
+
+			It("fails before locking or creating the artifact", func() {
+				Expect(actualBackupError).To(MatchError(ContainSubstring("Artifact path . needs about 2.0K but only 1.0K is free")))
+				Expect(fakeBackupManager.CreateCallCount()).To(BeZero())
+				Expect(deployment.PreBackupLockCallCount()).To(BeZero())
+				Expect(deployment.CleanupCallCount()).To(Equal(1))
+			})
+		})
+	})
+
 	Describe("failures", func() {
 		var expectedError = fmt.Errorf("Profanity")
 		var assertCleanupError = func() {
@@ -222,6 +392,10 @@ var _ = Describe("Backup", func() {
 			It("fails the backup process", func() {
 				expectErrorMatch(actualBackupError, expectedError)
 			})
+
+			It("reports it as a discovery error", func() {
+				Expect(actualBackupError).To(ConsistOf(BeAssignableToTypeOf(orchestrator.DiscoveryError{})))
+			})
 		})
 
 		Context("fails if manifest can't be saved", func() {
@@ -251,7 +425,8 @@ var _ = Describe("Backup", func() {
 
 			It("finds a deployment with the deployment name", func() {
 				Expect(deploymentManager.FindCallCount()).To(Equal(1))
-				Expect(deploymentManager.FindArgsForCall(0)).To(Equal(deploymentName))
+				_, name := deploymentManager.FindArgsForCall(0)
+				Expect(name).To(Equal(deploymentName))
 			})
 
 			It("checks if the deployment is backupable", func() {
@@ -259,7 +434,10 @@ var _ = Describe("Backup", func() {
 			})
 
 			It("fails the backup process", func() {
-				Expect(actualBackupError).To(ConsistOf(MatchError("Deployment '" + deploymentName + "' has no backup scripts")))
+				Expect(actualBackupError).To(ConsistOf(And(
+					MatchError("Deployment '"+deploymentName+"' has no backup scripts"),
+					BeAssignableToTypeOf(orchestrator.PreCheckError{}),
+				)))
 			})
 
 			It("ensures that deployment is cleaned up", func() {
@@ -285,6 +463,23 @@ var _ = Describe("Backup", func() {
 			})
 		})
 
+		Context("fails if a backup-one-restore-all job does not have exactly one backup source", func() {
+			BeforeEach(func() {
+				fakeBackupManager.CreateReturns(fakeBackup, nil)
+				deploymentManager.FindReturns(deployment, nil)
+				deployment.IsBackupableReturns(true)
+				deployment.ValidateBackupOneRestoreAllSourcesReturns(fmt.Errorf("no source"))
+			})
+
+			It("fails the backup process before locking", func() {
+				Expect(actualBackupError).To(ConsistOf(And(
+					MatchError("no source"),
+					BeAssignableToTypeOf(orchestrator.PreCheckError{}),
+				)))
+				Expect(deployment.PreBackupLockCallCount()).To(BeZero())
+			})
+		})
+
 		Context("fails if pre-backup-lock fails", func() {
 			var lockError = orchestrator.NewLockError("smoooooooth jazz")
 
@@ -303,7 +498,7 @@ var _ = Describe("Backup", func() {
 
 			It("also runs post-backup-unlock", func() {
 				Expect(deployment.PostBackupUnlockCallCount()).To(Equal(1))
-				afterSuccessfulBackup, _, _ := deployment.PostBackupUnlockArgsForCall(0)
+				_, afterSuccessfulBackup, _, _ := deployment.PostBackupUnlockArgsForCall(0)
 				Expect(afterSuccessfulBackup).To(BeFalse())
 			})
 
@@ -394,6 +589,20 @@ var _ = Describe("Backup", func() {
 
 			It("fails the backup process", func() {
 				Expect(actualBackupError.Error()).To(ContainSubstring(drainError.Error()))
+				Expect(actualBackupError).To(ConsistOf(BeAssignableToTypeOf(orchestrator.DrainError{})))
+			})
+
+			Context("because the checksums do not match", func() {
+				BeforeEach(func() {
+					artifactCopier.DownloadBackupFromDeploymentReturns(orchestrator.NewError(orchestrator.NewChecksumError("Backup is corrupted")))
+				})
+
+				It("reports it as a checksum error", func() {
+					Expect(actualBackupError).To(ConsistOf(And(
+						MatchError(ContainSubstring("Backup is corrupted")),
+						BeAssignableToTypeOf(orchestrator.ChecksumError{}),
+					)))
+				})
 			})
 
 			It("ensures that deployment's instance is cleaned up", func() {
@@ -464,6 +673,139 @@ var _ = Describe("Backup", func() {
 			Context("cleanup fails as well", assertCleanupError)
 		})
 
+		Context("fails if the pre-backup hook fails", func() {
+			BeforeEach(func() {
+				hooks = orchestrator.Hooks{PreBackup: []string{"pause-scheduler"}}
+				hookRunner.RunReturns(expectedError)
+				deploymentManager.FindReturns(deployment, nil)
+				deployment.IsBackupableReturns(true)
+				fakeBackupManager.CreateReturns(fakeBackup, nil)
+			})
+
+			It("returns a hook error", func() {
+				Expect(actualBackupError).To(ConsistOf(BeAssignableToTypeOf(orchestrator.HookError{})))
+				Expect(actualBackupError).To(MatchError(ContainSubstring("pre-backup hook `pause-scheduler` failed: Profanity")))
+			})
+
+			It("does not lock the deployment", func() {
+				Expect(deployment.PreBackupLockCallCount()).To(BeZero())
+			})
+
+			It("ensures that deployment is cleaned up", func() {
+				Expect(deployment.CleanupCallCount()).To(Equal(1))
+			})
+
+			Context("cleanup fails as well", assertCleanupError)
+		})
+
+		Context("fails if the post-lock hook fails", func() {
+			BeforeEach(func() {
+				hooks = orchestrator.Hooks{PostLock: []string{"snapshot-filesystem"}}
+				hookRunner.RunReturns(expectedError)
+				deploymentManager.FindReturns(deployment, nil)
+				deployment.IsBackupableReturns(true)
+				fakeBackupManager.CreateReturns(fakeBackup, nil)
+			})
+
+			It("does not back up the deployment", func() {
+				Expect(deployment.BackupCallCount()).To(BeZero())
+			})
+
+			It("unlocks the deployment as after a failed backup", func() {
+				Expect(deployment.PostBackupUnlockCallCount()).To(Equal(1))
+				_, afterSuccessfulBackup, _, _ := deployment.PostBackupUnlockArgsForCall(0)
+				Expect(afterSuccessfulBackup).To(BeFalse())
+			})
+
+			It("ensures that deployment is cleaned up", func() {
+				Expect(deployment.CleanupCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("fails if the post-backup hook fails", func() {
+			BeforeEach(func() {
+				hooks = orchestrator.Hooks{PostBackup: []string{"upload-to-tape", "resume-scheduler"}}
+				hookRunner.RunReturns(expectedError)
+				deploymentManager.FindReturns(deployment, nil)
+				deployment.IsBackupableReturns(true)
+				fakeBackupManager.CreateReturns(fakeBackup, nil)
+			})
+
+			It("does not run the remaining hooks", func() {
+				Expect(hookRunner.RunCallCount()).To(Equal(1))
+			})
+
+			It("returns a hook error", func() {
+				Expect(actualBackupError).To(ConsistOf(BeAssignableToTypeOf(orchestrator.HookError{})))
+			})
+
+			It("ensures that deployment is cleaned up", func() {
+				Expect(deployment.CleanupCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when the backup is aborted while the backup scripts run", func() {
+			BeforeEach(func() {
+				var cancel context.CancelCauseFunc
+				ctx, cancel = context.WithCancelCause(context.Background())
+
+				deploymentManager.FindReturns(deployment, nil)
+				deployment.IsBackupableReturns(true)
+				fakeBackupManager.CreateReturns(fakeBackup, nil)
+				deployment.BackupStub = func(context.Context, executor.Executor) error {
+					cancel(errors.New("received terminated"))
+					return nil
+				}
+			})
+
+			It("returns an abort error", func() {
+				Expect(actualBackupError).To(ConsistOf(BeAssignableToTypeOf(orchestrator.AbortError{})))
+				Expect(actualBackupError).To(MatchError(ContainSubstring("Aborted: received terminated")))
+			})
+
+			It("unlocks the deployment with a context that is not cancelled", func() {
+				Expect(deployment.PostBackupUnlockCallCount()).To(Equal(1))
+				unlockCtx, _, _, _ := deployment.PostBackupUnlockArgsForCall(0)
+				Expect(unlockCtx.Err()).NotTo(HaveOccurred())
+			})
+
+			It("does not drain the backup", func() {
+				Expect(artifactCopier.DownloadBackupFromDeploymentCallCount()).To(BeZero())
+			})
+
+			It("cleans up the deployment", func() {
+				Expect(deployment.CleanupCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when the backup scripts fail because the backup is aborted", func() {
+			BeforeEach(func() {
+				var cancel context.CancelCauseFunc
+				ctx, cancel = context.WithCancelCause(context.Background())
+
+				deploymentManager.FindReturns(deployment, nil)
+				deployment.IsBackupableReturns(true)
+				fakeBackupManager.CreateReturns(fakeBackup, nil)
+				deployment.BackupStub = func(ctx context.Context, _ executor.Executor) error {
+					cancel(errors.New("received terminated"))
+					return context.Cause(ctx)
+				}
+			})
+
+			It("reports the abort alongside the failure of the backup scripts", func() {
+				Expect(actualBackupError).To(ConsistOf(
+					BeAssignableToTypeOf(orchestrator.AbortError{}),
+					BeAssignableToTypeOf(orchestrator.BackupError{}),
+				))
+			})
+
+			It("unlocks the deployment as after a failed backup", func() {
+				Expect(deployment.PostBackupUnlockCallCount()).To(Equal(1))
+				_, afterSuccessfulBackup, _, _ := deployment.PostBackupUnlockArgsForCall(0)
+				Expect(afterSuccessfulBackup).To(BeFalse())
+			})
+		})
+
 		Context("fails if backup is not a success", func() {
 			var backupError = fmt.Errorf("syzygy")
 			BeforeEach(func() {
@@ -491,9 +833,22 @@ var _ = Describe("Backup", func() {
 				Expect(actualBackupError.Error()).To(ContainSubstring(backupError.Error()))
 			})
 
+			Context("when a backup script failed", func() {
+				var scriptError = orchestrator.NewScriptError("redis/0", "redis-server", "backup", fmt.Errorf("syzygy"))
+
+				BeforeEach(func() {
+					deployment.BackupReturns(orchestrator.NewError(scriptError))
+				})
+
+				It("reports the failed script", func() {
+					Expect(actualBackupError).To(ContainElement(BeAssignableToTypeOf(orchestrator.BackupError{})))
+					Expect(orchestrator.FailedScripts(actualBackupError)).To(ConsistOf(scriptError))
+				})
+			})
+
 			It("runs post-backup-unlock scripts on the deployment", func() {
 				Expect(deployment.PostBackupUnlockCallCount()).To(Equal(1))
-				afterSuccessfulBackup, _, _ := deployment.PostBackupUnlockArgsForCall(0)
+				_, afterSuccessfulBackup, _, _ := deployment.PostBackupUnlockArgsForCall(0)
 				Expect(afterSuccessfulBackup).To(BeFalse())
 			})
 
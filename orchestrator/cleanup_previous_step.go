@@ -7,5 +7,5 @@ func NewCleanupPreviousStep() Step {
 }
 
 func (s *CleanupPreviousStep) Run(session *Session) error {
-	return session.CurrentDeployment().CleanupPrevious()
+	return session.CurrentDeployment().CleanupPrevious(session.Context())
 }
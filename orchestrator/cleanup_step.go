@@ -10,7 +10,7 @@ func NewCleanupStep() Step {
 
 func (s *CleanupStep) Run(session *Session) error {
 
-	if err := session.CurrentDeployment().Cleanup(); err != nil {
+	if err := session.CurrentDeployment().Cleanup(session.Context()); err != nil {
 		return NewCleanupError(
 			fmt.Sprintf("Deployment '%s' failed while cleaning up with error: %v", session.DeploymentName(), err))
 	}
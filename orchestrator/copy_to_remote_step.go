@@ -1,7 +1,7 @@
 package orchestrator
 
 import (
-	"github.com/pkg/errors"
+	"fmt"
 )
 
 type CopyToRemoteStep struct {
@@ -15,9 +15,13 @@ func NewCopyToRemoteStep(artifactCopier ArtifactCopier) Step {
 }
 
 func (s *CopyToRemoteStep) Run(session *Session) error {
-	err := s.artifactCopier.UploadBackupToDeployment(session.CurrentArtifact(), session.CurrentDeployment())
+	err := s.artifactCopier.UploadBackupToDeployment(session.Context(), session.CurrentArtifact(), session.CurrentDeployment())
 	if err != nil {
-		return errors.Errorf("Unable to send backup to remote machine. Got error: %s", err)
+		errorMessage := fmt.Sprintf("Unable to send backup to remote machine. Got error: %s", err)
+		if containsChecksumError(err) {
+			return NewChecksumError(errorMessage)
+		}
+		return NewTransferError(errorMessage)
 	}
 	return nil
 }
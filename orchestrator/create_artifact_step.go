@@ -2,6 +2,7 @@ package orchestrator
 
 import (
 	"fmt"
+	"path"
 	"time"
 )
 
@@ -23,6 +24,7 @@ func (s *CreateArtifactStep) Run(session *Session) error {
 	}
 	artifact.CreateMetadataFileWithStartTime(s.nowFunc()) //nolint:errcheck
 	session.SetCurrentArtifact(artifact)
+	session.SetArtifactDirectory(path.Join(session.CurrentArtifactPath(), directoryName))
 
 	err = s.deploymentManager.SaveManifest(session.DeploymentName(), artifact)
 	if err != nil {
@@ -1,6 +1,7 @@
 package orchestrator
 
 import (
+	"context"
 	"fmt"
 
 	"strings"
@@ -16,19 +17,20 @@ const ArtifactDirectory = "/var/vcap/store/bbr-backup"
 type Deployment interface {
 	IsBackupable() bool
 	BackupableInstances() []Instance
-	CheckArtifactDir() error
+	CheckArtifactDir(context.Context) error
 	IsRestorable() bool
 	RestorableInstances() []Instance
-	PreBackupLock(LockOrderer, executor.Executor) error
-	Backup(executor.Executor) error
-	PostBackupUnlock(bool, LockOrderer, executor.Executor) error
-	Restore() error
-	Cleanup() error
-	CleanupPrevious() error
+	PreBackupLock(context.Context, LockOrderer, executor.Executor) error
+	Backup(context.Context, executor.Executor) error
+	PostBackupUnlock(context.Context, bool, LockOrderer, executor.Executor) error
+	Restore(context.Context) error
+	Cleanup(context.Context) error
+	CleanupPrevious(context.Context) error
 	Instances() []Instance
-	PreRestoreLock(LockOrderer, executor.Executor) error
-	PostRestoreUnlock(LockOrderer, executor.Executor) error
+	PreRestoreLock(context.Context, LockOrderer, executor.Executor) error
+	PostRestoreUnlock(context.Context, LockOrderer, executor.Executor) error
 	ValidateLockingDependencies(orderer LockOrderer) error
+	ValidateBackupOneRestoreAllSources() error
 }
 
 //counterfeiter:generate -o fakes/fake_lock_orderer.go . LockOrderer
@@ -54,11 +56,11 @@ func (bd *deployment) BackupableInstances() []Instance {
 	return bd.instances.AllBackupable()
 }
 
-func (bd *deployment) CheckArtifactDir() error {
+func (bd *deployment) CheckArtifactDir(ctx context.Context) error {
 	var errs []string
 
 	for _, inst := range bd.instances {
-		exists, err := inst.ArtifactDirExists()
+		exists, err := inst.ArtifactDirExists(ctx)
 		if err != nil {
 			errs = append(errs, fmt.Sprintf("Error checking %s on instance %s/%s", ArtifactDirectory, inst.Name(), inst.ID()))
 		} else if exists {
@@ -79,7 +81,45 @@ func (bd *deployment) ValidateLockingDependencies(lockOrderer LockOrderer) error
 	return err
 }
 
-func (bd *deployment) PreBackupLock(lockOrderer LockOrderer, executor executor.Executor) error {
+// ValidateBackupOneRestoreAllSources checks that every backup-one-restore-all
+// job is backed up from exactly one instance.
+func (bd *deployment) ValidateBackupOneRestoreAllSources() error {
+	var names []string
+	sources := map[string][]string{}
+	for _, job := range bd.instances.Jobs() {
+		if !job.IsBackupOneRestoreAll() || !job.HasBackup() {
+			continue
+		}
+
+		name := fmt.Sprintf("%s/%s", job.Release(), job.Name())
+		if _, found := sources[name]; !found {
+			names = append(names, name)
+			sources[name] = nil
+		}
+		if job.HasNamedBackupArtifact() {
+			sources[name] = append(sources[name], job.InstanceIdentifier())
+		}
+	}
+
+	var errs []string
+	for _, name := range names {
+		switch len(sources[name]) {
+		case 0:
+			errs = append(errs, fmt.Sprintf("No instance is the backup source of backup-one-restore-all job %s", name))
+		case 1:
+		default:
+			errs = append(errs, fmt.Sprintf("Backup-one-restore-all job %s has more than one backup source: %s", name, strings.Join(sources[name], ", ")))
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "\n"))
+	}
+
+	return nil
+}
+
+func (bd *deployment) PreBackupLock(ctx context.Context, lockOrderer LockOrderer, executor executor.Executor) error {
 	bd.Logger.Info("bbr", "Running pre-backup-lock scripts...") //nolint:staticcheck
 
 	jobs := bd.instances.Jobs()
@@ -89,13 +129,13 @@ func (bd *deployment) PreBackupLock(lockOrderer LockOrderer, executor executor.E
 		return err
 	}
 
-	preBackupLockErrors := executor.Run(newJobExecutables(orderedJobs, NewJobPreBackupLockExecutable))
+	preBackupLockErrors := executor.Run(ctx, newJobExecutables(orderedJobs, NewJobPreBackupLockExecutable))
 
 	bd.Logger.Info("bbr", "Finished running pre-backup-lock scripts.") //nolint:staticcheck
 	return ConvertErrors(preBackupLockErrors)
 }
 
-func (bd *deployment) Backup(exe executor.Executor) error {
+func (bd *deployment) Backup(ctx context.Context, exe executor.Executor) error {
 	bd.Logger.Info("bbr", "Running backup scripts...") //nolint:staticcheck
 
 	instances := bd.instances.AllBackupable()
@@ -108,13 +148,13 @@ func (bd *deployment) Backup(exe executor.Executor) error {
 		}
 	}
 
-	backupErr := exe.Run([][]executor.Executable{executables})
+	backupErr := exe.Run(ctx, [][]executor.Executable{executables})
 
 	bd.Logger.Info("bbr", "Finished running backup scripts.") //nolint:staticcheck
 	return ConvertErrors(backupErr)
 }
 
-func (bd *deployment) PostBackupUnlock(afterSuccessfulBackup bool, lockOrderer LockOrderer, executor executor.Executor) error {
+func (bd *deployment) PostBackupUnlock(ctx context.Context, afterSuccessfulBackup bool, lockOrderer LockOrderer, executor executor.Executor) error {
 	bd.Logger.Info("bbr", "Running post-backup-unlock scripts...") //nolint:staticcheck
 
 	jobs := bd.instances.Jobs()
@@ -130,13 +170,13 @@ func (bd *deployment) PostBackupUnlock(afterSuccessfulBackup bool, lockOrderer L
 		executableJobConstructor = NewJobPostSuccessfulBackupUnlockExecutable
 
 	}
-	postBackupUnlockErrors := executor.Run(newJobExecutables(reversedJobs, executableJobConstructor))
+	postBackupUnlockErrors := executor.Run(ctx, newJobExecutables(reversedJobs, executableJobConstructor))
 
 	bd.Logger.Info("bbr", "Finished running post-backup-unlock scripts.") //nolint:staticcheck
 	return ConvertErrors(postBackupUnlockErrors)
 }
 
-func (bd *deployment) PreRestoreLock(lockOrderer LockOrderer, executor executor.Executor) error {
+func (bd *deployment) PreRestoreLock(ctx context.Context, lockOrderer LockOrderer, executor executor.Executor) error {
 	bd.Logger.Info("bbr", "Running pre-restore-lock scripts...") //nolint:staticcheck
 
 	jobs := bd.instances.Jobs()
@@ -146,20 +186,20 @@ func (bd *deployment) PreRestoreLock(lockOrderer LockOrderer, executor executor.
 		return err
 	}
 
-	preRestoreLockErrors := executor.Run(newJobExecutables(orderedJobs, NewJobPreRestoreLockExecutable))
+	preRestoreLockErrors := executor.Run(ctx, newJobExecutables(orderedJobs, NewJobPreRestoreLockExecutable))
 
 	bd.Logger.Info("bbr", "Finished running pre-restore-lock scripts.") //nolint:staticcheck
 	return ConvertErrors(preRestoreLockErrors)
 }
 
-func (bd *deployment) Restore() error {
+func (bd *deployment) Restore(ctx context.Context) error {
 	bd.Logger.Info("bbr", "Running restore scripts...") //nolint:staticcheck
-	err := bd.instances.AllRestoreable().Restore()
+	err := bd.instances.AllRestoreable().Restore(ctx)
 	bd.Logger.Info("bbr", "Finished running restore scripts.") //nolint:staticcheck
 	return err
 }
 
-func (bd *deployment) PostRestoreUnlock(lockOrderer LockOrderer, executor executor.Executor) error {
+func (bd *deployment) PostRestoreUnlock(ctx context.Context, lockOrderer LockOrderer, executor executor.Executor) error {
 	bd.Logger.Info("bbr", "Running post-restore-unlock scripts...") //nolint:staticcheck
 
 	jobs := bd.instances.Jobs()
@@ -170,7 +210,7 @@ func (bd *deployment) PostRestoreUnlock(lockOrderer LockOrderer, executor execut
 	}
 	reversedJobs := Reverse(orderedJobs)
 
-	postRestoreUnlockErrors := executor.Run(newJobExecutables(reversedJobs, NewJobPostRestoreUnlockExecutable))
+	postRestoreUnlockErrors := executor.Run(ctx, newJobExecutables(reversedJobs, NewJobPostRestoreUnlockExecutable))
 
 	bd.Logger.Info("bbr", "Finished running post-restore-unlock scripts.") //nolint:staticcheck
 	return ConvertErrors(postRestoreUnlockErrors)
@@ -188,12 +228,12 @@ func newJobExecutables(jobsList [][]Job, newJobExecutable func(Job) executor.Exe
 	return executablesList
 }
 
-func (bd *deployment) Cleanup() error {
-	return bd.instances.Cleanup()
+func (bd *deployment) Cleanup(ctx context.Context) error {
+	return bd.instances.Cleanup(ctx)
 }
 
-func (bd *deployment) CleanupPrevious() error {
-	return bd.instances.AllBackupableOrRestorable().CleanupPrevious()
+func (bd *deployment) CleanupPrevious(ctx context.Context) error {
+	return bd.instances.AllBackupableOrRestorable().CleanupPrevious(ctx)
 }
 
 func (bd *deployment) IsRestorable() bool {
@@ -1,8 +1,10 @@
 package orchestrator
 
+import "context"
+
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
 //counterfeiter:generate -o fakes/fake_deployment_manager.go . DeploymentManager
 type DeploymentManager interface {
-	Find(deploymentName string) (Deployment, error)
+	Find(ctx context.Context, deploymentName string) (Deployment, error)
 	SaveManifest(deploymentName string, artifact Backup) error
 }
@@ -1,6 +1,7 @@
 package orchestrator_test
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/cloudfoundry/bosh-backup-and-restore/executor"
@@ -13,6 +14,7 @@ import (
 
 var _ = Describe("Deployment", func() {
 	var (
+		ctx        context.Context
 		deployment orchestrator.Deployment
 		logger     *fakes.FakeLogger
 
@@ -28,6 +30,10 @@ var _ = Describe("Deployment", func() {
 	)
 
 	BeforeEach(func() {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(context.Background())
+		DeferCleanup(cancel)
+
 		logger = new(fakes.FakeLogger)
 
 		instance1 = new(fakes.FakeInstance)
@@ -63,13 +69,15 @@ var _ = Describe("Deployment", func() {
 		})
 
 		JustBeforeEach(func() {
-			lockError = deployment.PreBackupLock(lockOrderer, fakeExecutor)
+			lockError = deployment.PreBackupLock(ctx, lockOrderer, fakeExecutor)
 		})
 
 		It("delegates the execution to the executor", func() {
 			Expect(lockError).NotTo(HaveOccurred())
 			Expect(lockOrderer.OrderArgsForCall(0)).To(ConsistOf(job1a, job1b, job2a, job3a))
-			Expect(fakeExecutor.RunArgsForCall(0)).To(Equal([][]executor.Executable{
+			runCtx, executables := fakeExecutor.RunArgsForCall(0)
+			Expect(runCtx).To(Equal(ctx))
+			Expect(executables).To(Equal([][]executor.Executable{
 				{orchestrator.NewJobPreBackupLockExecutable(job2a)},
 				{orchestrator.NewJobPreBackupLockExecutable(job3a), orchestrator.NewJobPreBackupLockExecutable(job1a)},
 				{orchestrator.NewJobPreBackupLockExecutable(job1b)},
@@ -108,7 +116,7 @@ var _ = Describe("Deployment", func() {
 		var fakeExecutor *executorFakes.FakeExecutor
 
 		JustBeforeEach(func() {
-			err = deployment.Backup(fakeExecutor)
+			err = deployment.Backup(ctx, fakeExecutor)
 		})
 
 		BeforeEach(func() {
@@ -126,7 +134,9 @@ var _ = Describe("Deployment", func() {
 		It("calls Backup() on all backupable instances", func() {
 			Expect(err).NotTo(HaveOccurred())
 
-			Expect(fakeExecutor.RunArgsForCall(0)).To(Equal([][]executor.Executable{
+			runCtx, executables := fakeExecutor.RunArgsForCall(0)
+			Expect(runCtx).To(Equal(ctx))
+			Expect(executables).To(Equal([][]executor.Executable{
 				{orchestrator.NewBackupExecutable(job1a), orchestrator.NewBackupExecutable(job3a)},
 			}))
 		})
@@ -141,7 +151,9 @@ var _ = Describe("Deployment", func() {
 			It("fails and stops the backup", func() {
 				Expect(err).To(MatchError(ContainSubstring("backup instance1 failed")))
 
-				Expect(fakeExecutor.RunArgsForCall(0)).To(Equal([][]executor.Executable{
+				runCtx, executables := fakeExecutor.RunArgsForCall(0)
+				Expect(runCtx).To(Equal(ctx))
+				Expect(executables).To(Equal([][]executor.Executable{
 					{orchestrator.NewBackupExecutable(job1a), orchestrator.NewBackupExecutable(job3a)},
 				}))
 			})
@@ -163,11 +175,13 @@ var _ = Describe("Deployment", func() {
 		})
 
 		It("calls the executor with post successful backup executables", func() {
-			lockError = deployment.PostBackupUnlock(true, lockOrderer, fakeExecutor)
+			lockError = deployment.PostBackupUnlock(ctx, true, lockOrderer, fakeExecutor)
 
 			Expect(lockError).NotTo(HaveOccurred())
 			Expect(lockOrderer.OrderArgsForCall(0)).To(ConsistOf(job1a, job1b, job2a, job3a))
-			Expect(fakeExecutor.RunArgsForCall(0)).To(Equal([][]executor.Executable{
+			runCtx, executables := fakeExecutor.RunArgsForCall(0)
+			Expect(runCtx).To(Equal(ctx))
+			Expect(executables).To(Equal([][]executor.Executable{
 				{orchestrator.NewJobPostSuccessfulBackupUnlockExecutable(job2a)},
 				{orchestrator.NewJobPostSuccessfulBackupUnlockExecutable(job3a), orchestrator.NewJobPostSuccessfulBackupUnlockExecutable(job1a)},
 				{orchestrator.NewJobPostSuccessfulBackupUnlockExecutable(job1b)},
@@ -176,11 +190,13 @@ var _ = Describe("Deployment", func() {
 
 		Context("when called after a failed backup", func() {
 			It("calls the executor with post failed backup executables", func() {
-				lockError = deployment.PostBackupUnlock(false, lockOrderer, fakeExecutor)
+				lockError = deployment.PostBackupUnlock(ctx, false, lockOrderer, fakeExecutor)
 
 				Expect(lockError).NotTo(HaveOccurred())
 				Expect(lockOrderer.OrderArgsForCall(0)).To(ConsistOf(job1a, job1b, job2a, job3a))
-				Expect(fakeExecutor.RunArgsForCall(0)).To(Equal([][]executor.Executable{
+				runCtx, executables := fakeExecutor.RunArgsForCall(0)
+				Expect(runCtx).To(Equal(ctx))
+				Expect(executables).To(Equal([][]executor.Executable{
 					{orchestrator.NewJobPostFailedBackupUnlockExecutable(job2a)},
 					{orchestrator.NewJobPostFailedBackupUnlockExecutable(job3a), orchestrator.NewJobPostFailedBackupUnlockExecutable(job1a)},
 					{orchestrator.NewJobPostFailedBackupUnlockExecutable(job1b)},
@@ -195,7 +211,7 @@ var _ = Describe("Deployment", func() {
 					fmt.Errorf("job2a failed"),
 				})
 
-				lockError = deployment.PostBackupUnlock(true, lockOrderer, fakeExecutor)
+				lockError = deployment.PostBackupUnlock(ctx, true, lockOrderer, fakeExecutor)
 
 				Expect(lockError).To(MatchError(SatisfyAll(
 					ContainSubstring("job1b failed"),
@@ -208,7 +224,7 @@ var _ = Describe("Deployment", func() {
 			It("fails", func() {
 				lockOrderer.OrderReturns(nil, fmt.Errorf("test lock orderer error"))
 
-				lockError = deployment.PostBackupUnlock(true, lockOrderer, fakeExecutor)
+				lockError = deployment.PostBackupUnlock(ctx, true, lockOrderer, fakeExecutor)
 
 				Expect(lockError).To(MatchError(ContainSubstring("test lock orderer error")))
 			})
@@ -270,7 +286,7 @@ var _ = Describe("Deployment", func() {
 		})
 
 		JustBeforeEach(func() {
-			artifactDirError = deployment.CheckArtifactDir()
+			artifactDirError = deployment.CheckArtifactDir(context.Background())
 		})
 
 		Context("when artifact directory does not exist", func() {
@@ -305,11 +321,57 @@ var _ = Describe("Deployment", func() {
 		})
 	})
 
+	Context("ValidateBackupOneRestoreAllSources", func() {
+		var validationError error
+
+		BeforeEach(func() {
+			for i, job := range []*fakes.FakeJob{job1a, job2a, job3a} {
+				job.NameReturns("consul_agent")
+				job.ReleaseReturns("consul")
+				job.InstanceIdentifierReturns(fmt.Sprintf("consul/%d", i))
+				job.HasBackupReturns(true)
+				job.IsBackupOneRestoreAllReturns(true)
+			}
+			job1a.HasNamedBackupArtifactReturns(true)
+			job1b.NameReturns("other_job")
+			job1b.HasBackupReturns(true)
+			instances = []orchestrator.Instance{instance1, instance2, instance3}
+		})
+
+		JustBeforeEach(func() {
+			validationError = deployment.ValidateBackupOneRestoreAllSources()
+		})
+
+		It("does not fail when one instance is the backup source", func() {
+			Expect(validationError).NotTo(HaveOccurred())
+		})
+
+		Context("when no instance is the backup source", func() {
+			BeforeEach(func() {
+				job1a.HasNamedBackupArtifactReturns(false)
+			})
+
+			It("fails", func() {
+				Expect(validationError).To(MatchError("No instance is the backup source of backup-one-restore-all job consul/consul_agent"))
+			})
+		})
+
+		Context("when more than one instance is the backup source", func() {
+			BeforeEach(func() {
+				job3a.HasNamedBackupArtifactReturns(true)
+			})
+
+			It("fails and names the instances", func() {
+				Expect(validationError).To(MatchError("Backup-one-restore-all job consul/consul_agent has more than one backup source: consul/0, consul/2"))
+			})
+		})
+	})
+
 	Context("Restore", func() {
 		var err error
 
 		JustBeforeEach(func() {
-			err = deployment.Restore()
+			err = deployment.Restore(ctx)
 		})
 
 		BeforeEach(func() {
@@ -357,13 +419,15 @@ var _ = Describe("Deployment", func() {
 		})
 
 		JustBeforeEach(func() {
-			lockError = deployment.PreRestoreLock(lockOrderer, fakeExecutor)
+			lockError = deployment.PreRestoreLock(ctx, lockOrderer, fakeExecutor)
 		})
 
 		It("delegates the execution to the executor", func() {
 			Expect(lockError).NotTo(HaveOccurred())
 			Expect(lockOrderer.OrderArgsForCall(0)).To(ConsistOf(job1a, job1b, job2a, job3a))
-			Expect(fakeExecutor.RunArgsForCall(0)).To(Equal([][]executor.Executable{
+			runCtx, executables := fakeExecutor.RunArgsForCall(0)
+			Expect(runCtx).To(Equal(ctx))
+			Expect(executables).To(Equal([][]executor.Executable{
 				{orchestrator.NewJobPreRestoreLockExecutable(job2a)},
 				{orchestrator.NewJobPreRestoreLockExecutable(job3a), orchestrator.NewJobPreRestoreLockExecutable(job1a)},
 				{orchestrator.NewJobPreRestoreLockExecutable(job1b)},
@@ -412,13 +476,15 @@ var _ = Describe("Deployment", func() {
 		})
 
 		JustBeforeEach(func() {
-			lockError = deployment.PostRestoreUnlock(lockOrderer, fakeExecutor)
+			lockError = deployment.PostRestoreUnlock(ctx, lockOrderer, fakeExecutor)
 		})
 
 		It("delegates the execution to the executor", func() {
 			Expect(lockError).NotTo(HaveOccurred())
 			Expect(lockOrderer.OrderArgsForCall(0)).To(ConsistOf(job1a, job1b, job2a, job3a))
-			Expect(fakeExecutor.RunArgsForCall(0)).To(Equal([][]executor.Executable{
+			runCtx, executables := fakeExecutor.RunArgsForCall(0)
+			Expect(runCtx).To(Equal(ctx))
+			Expect(executables).To(Equal([][]executor.Executable{
 				{orchestrator.NewJobPostRestoreUnlockExecutable(job2a)},
 				{orchestrator.NewJobPostRestoreUnlockExecutable(job3a), orchestrator.NewJobPostRestoreUnlockExecutable(job1a)},
 				{orchestrator.NewJobPostRestoreUnlockExecutable(job1b)},
@@ -495,7 +561,7 @@ var _ = Describe("Deployment", func() {
 		var err error
 
 		JustBeforeEach(func() {
-			err = deployment.Cleanup()
+			err = deployment.Cleanup(context.Background())
 		})
 
 		BeforeEach(func() {
@@ -533,7 +599,7 @@ var _ = Describe("Deployment", func() {
 		var err error
 
 		JustBeforeEach(func() {
-			err = deployment.CleanupPrevious()
+			err = deployment.CleanupPrevious(context.Background())
 		})
 
 		BeforeEach(func() {
@@ -0,0 +1,176 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cloudfoundry/bosh-backup-and-restore/readwriter"
+)
+
+// ArtifactSpace reports on the local disk that backups are drained to.
+//
+//counterfeiter:generate -o fakes/fake_artifact_space.go . ArtifactSpace
+type ArtifactSpace interface {
+	FreeSpaceInBytes(artifactPath string) (int, error)
+	Latest(artifactPath, deploymentName string, logger Logger) (Backup, error)
+}
+
+// diskSpaceCheck estimates the size of each artifact, from the job's
+// backup-size script when it has one and otherwise from the size recorded in
+// the previous backup, and compares the totals with the space left on each
+// instance and at the artifact path. Artifacts whose size cannot be estimated
+// are left out, so the check only fails when even a partial estimate does not
+// fit.
+type diskSpaceCheck struct {
+	artifactSpace ArtifactSpace
+	logger        Logger
+}
+
+func (c diskSpaceCheck) Run(session *Session) error {
+	c.logger.Info("bbr", "Checking disk space for backup of %s...", session.DeploymentName())
+
+	previousBackup, err := c.artifactSpace.Latest(session.CurrentArtifactPath(), session.DeploymentName(), c.logger)
+	if err != nil {
+		c.logger.Warn("bbr", "Unable to find a previous backup of %s to estimate sizes from: %s", session.DeploymentName(), err)
+	}
+
+	var shortfalls []string
+	totalEstimate := 0
+
+	for _, inst := range session.CurrentDeployment().BackupableInstances() {
+		instanceEstimate := 0
+		for _, job := range Jobs(inst.Jobs()).Backupable() {
+			size, err := c.estimate(session.Context(), inst, job, previousBackup)
+			if err != nil {
+				c.logger.Warn("bbr", "Unable to estimate the backup size of %s on %s/%s: %s", job.Name(), inst.Name(), inst.ID(), err)
+				continue
+			}
+			instanceEstimate += size
+		}
+		totalEstimate += instanceEstimate
+
+		if instanceEstimate == 0 {
+			continue
+		}
+
+		free, err := inst.FreeSpaceInBytes(session.Context())
+		if err != nil {
+			c.logger.Warn("bbr", "Unable to check free disk space on %s/%s: %s", inst.Name(), inst.ID(), err)
+			continue
+		}
+
+		c.logger.Debug("bbr", "Instance %s/%s needs about %s and has %s free", inst.Name(), inst.ID(), readwriter.HumanReadableSize(instanceEstimate), readwriter.HumanReadableSize(free))
+		if instanceEstimate > free {
+			shortfalls = append(shortfalls, fmt.Sprintf(
+				"Instance %s/%s needs about %s in %s but only %s is free",
+				inst.Name(), inst.ID(), readwriter.HumanReadableSize(instanceEstimate), ArtifactDirectory, readwriter.HumanReadableSize(free),
+			))
+		}
+	}
+
+	if totalEstimate > 0 {
+		artifactPath := session.CurrentArtifactPath()
+		if artifactPath == "" {
+			artifactPath = "."
+		}
+
+		free, err := c.artifactSpace.FreeSpaceInBytes(session.CurrentArtifactPath())
+		if err != nil {
+			c.logger.Warn("bbr", "Unable to check free disk space at %s: %s", artifactPath, err)
+		} else if totalEstimate > free {
+			shortfalls = append(shortfalls, fmt.Sprintf(
+				"Artifact path %s needs about %s but only %s is free",
+				artifactPath, readwriter.HumanReadableSize(totalEstimate), readwriter.HumanReadableSize(free),
+			))
+		}
+	}
+
+	if len(shortfalls) > 0 {
+		return NewDiskSpaceError(fmt.Sprintf("Not enough disk space to back up %s:\n%s", session.DeploymentName(), strings.Join(shortfalls, "\n")))
+	}
+
+	return nil
+}
+
+func (c diskSpaceCheck) estimate(ctx context.Context, inst Instance, job Job, previousBackup Backup) (int, error) {
+	if job.HasBackupSize() {
+		return job.BackupSize(ctx)
+	}
+
+	if previousBackup == nil {
+		return 0, fmt.Errorf("no backup-size script and no previous backup")
+	}
+
+	return previousBackup.FetchArtifactSizeInBytes(jobArtifact{instance: inst, job: job})
+}
+
+// jobArtifact identifies the artifact that job's backup script writes, to
+// look it up in a previous backup.
+type jobArtifact struct {
+	instance Instance
+	job      Job
+}
+
+func (a jobArtifact) InstanceName() string  { return a.instance.Name() }
+func (a jobArtifact) InstanceIndex() string { return a.instance.Index() }
+func (a jobArtifact) InstanceID() string    { return a.instance.ID() }
+func (a jobArtifact) HasCustomName() bool   { return a.job.HasNamedBackupArtifact() }
+
+func (a jobArtifact) Name() string {
+	if a.job.HasNamedBackupArtifact() {
+		return a.job.BackupArtifactName()
+	}
+	return a.job.Name()
+}
+
+// RestoreDiskSpaceStep checks that every instance has room for the artifacts
+// that a restore would copy to it.
+type RestoreDiskSpaceStep struct {
+	logger Logger
+}
+
+func NewRestoreDiskSpaceStep(logger Logger) Step {
+	return &RestoreDiskSpaceStep{logger: logger}
+}
+
+func (s *RestoreDiskSpaceStep) Run(session *Session) error {
+	s.logger.Info("bbr", "Checking disk space for restore of %s...", session.DeploymentName())
+
+	var shortfalls []string
+	for _, inst := range session.CurrentDeployment().RestorableInstances() {
+		required := 0
+		for _, artifact := range inst.ArtifactsToRestore() {
+			size, err := session.CurrentArtifact().GetArtifactByteSize(artifact)
+			if err != nil {
+				s.logger.Warn("bbr", "Unable to find the size of artifact %s for %s/%s: %s", artifact.Name(), inst.Name(), inst.ID(), err)
+				continue
+			}
+			required += size
+		}
+
+		if required == 0 {
+			continue
+		}
+
+		free, err := inst.FreeSpaceInBytes(session.Context())
+		if err != nil {
+			s.logger.Warn("bbr", "Unable to check free disk space on %s/%s: %s", inst.Name(), inst.ID(), err)
+			continue
+		}
+
+		s.logger.Debug("bbr", "Instance %s/%s needs %s and has %s free", inst.Name(), inst.ID(), readwriter.HumanReadableSize(required), readwriter.HumanReadableSize(free))
+		if required > free {
+			shortfalls = append(shortfalls, fmt.Sprintf(
+				"Instance %s/%s needs %s in %s but only %s is free",
+				inst.Name(), inst.ID(), readwriter.HumanReadableSize(required), ArtifactDirectory, readwriter.HumanReadableSize(free),
+			))
+		}
+	}
+
+	if len(shortfalls) > 0 {
+		return NewDiskSpaceError(fmt.Sprintf("Not enough disk space to restore %s:\n%s", session.DeploymentName(), strings.Join(shortfalls, "\n")))
+	}
+
+	return nil
+}
@@ -0,0 +1,190 @@
+package orchestrator_test
+
+import (
+	"fmt"
+
+	"github.com/cloudfoundry/bosh-backup-and-restore/orchestrator"
+	"github.com/cloudfoundry/bosh-backup-and-restore/orchestrator/fakes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BackupableStep with a disk space check", func() {
+	const gigabyte = 1024 * 1024 * 1024
+
+	var (
+		step           orchestrator.Step
+		session        *orchestrator.Session
+		deployment     *fakes.FakeDeployment
+		artifactSpace  *fakes.FakeArtifactSpace
+		previousBackup *fakes.FakeBackup
+		logger         *fakes.FakeLogger
+		instance0      *fakes.FakeInstance
+		instance1      *fakes.FakeInstance
+		jobWithScript  *fakes.FakeJob
+		jobWithHistory *fakes.FakeJob
+		namedJob       *fakes.FakeJob
+		err            error
+	)
+
+	newJob := func(name string) *fakes.FakeJob {
+		job := new(fakes.FakeJob)
+		job.NameReturns(name)
+		job.HasBackupReturns(true)
+		return job
+	}
+
+	newInstance := func(name, index string, free int, jobs ...orchestrator.Job) *fakes.FakeInstance {
+		inst := new(fakes.FakeInstance)
+		inst.NameReturns(name)
+		inst.IndexReturns(index)
+		inst.IDReturns(name + "-id")
+		inst.JobsReturns(jobs)
+		inst.FreeSpaceInBytesReturns(free, nil)
+		return inst
+	}
+
+	BeforeEach(func() {
+		logger = new(fakes.FakeLogger)
+		artifactSpace = new(fakes.FakeArtifactSpace)
+		previousBackup = new(fakes.FakeBackup)
+		artifactSpace.LatestReturns(previousBackup, nil)
+		artifactSpace.FreeSpaceInBytesReturns(10*gigabyte, nil)
+
+		jobWithScript = newJob("redis")
+		jobWithScript.HasBackupSizeReturns(true)
+		jobWithScript.BackupSizeReturns(2*gigabyte, nil)
+
+		jobWithHistory = newJob("postgres")
+		namedJob = newJob("uaa")
+		namedJob.HasNamedBackupArtifactReturns(true)
+		namedJob.BackupArtifactNameReturns("uaa-db")
+
+		previousBackup.FetchArtifactSizeInBytesStub = func(artifact orchestrator.ArtifactIdentifier) (int, error) {
+			switch {
+			case artifact.HasCustomName() && artifact.Name() == "uaa-db":
+				return 1 * gigabyte, nil
+			case artifact.InstanceName() == "database" && artifact.InstanceIndex() == "0" && artifact.Name() == "postgres":
+				return 3 * gigabyte, nil
+			}
+			return 0, fmt.Errorf("no size recorded for artifact %s", artifact.Name())
+		}
+
+		instance0 = newInstance("redis", "0", 5*gigabyte, jobWithScript)
+		instance1 = newInstance("database", "0", 5*gigabyte, jobWithHistory, namedJob)
+
+		deployment = new(fakes.FakeDeployment)
+		deployment.IsBackupableReturns(true)
+		deployment.BackupableInstancesReturns([]orchestrator.Instance{instance0, instance1})
+
+		session = orchestrator.NewSession("my-deployment")
+		session.SetCurrentArtifactPath("/backups")
+		session.SetCurrentDeployment(deployment)
+
+		step = orchestrator.NewBackupableStepWithDiskSpaceCheck(new(fakes.FakeLockOrderer), artifactSpace, logger)
+	})
+
+	JustBeforeEach(func() {
+		err = step.Run(session)
+	})
+
+	It("succeeds when the backup fits everywhere", func() {
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("estimates from backup-size scripts and the previous backup in the artifact path", func() {
+		Expect(jobWithScript.BackupSizeCallCount()).To(Equal(1))
+		Expect(jobWithHistory.BackupSizeCallCount()).To(Equal(0))
+
+		path, deploymentName, _ := artifactSpace.LatestArgsForCall(0)
+		Expect(path).To(Equal("/backups"))
+		Expect(deploymentName).To(Equal("my-deployment"))
+		Expect(artifactSpace.FreeSpaceInBytesArgsForCall(0)).To(Equal("/backups"))
+	})
+
+	Context("when an instance does not have enough space", func() {
+		BeforeEach(func() {
+			instance1.FreeSpaceInBytesReturns(3*gigabyte, nil)
+		})
+
+		It("fails with a report naming the instance", func() {
+			Expect(err).To(BeAssignableToTypeOf(orchestrator.DiskSpaceError{}))
+			Expect(err).To(MatchError(ContainSubstring("Not enough disk space to back up my-deployment")))
+			Expect(err).To(MatchError(ContainSubstring("Instance database/database-id needs about 4.0G in /var/vcap/store/bbr-backup but only 3.0G is free")))
+			Expect(err.Error()).NotTo(ContainSubstring("redis/redis-id"))
+		})
+	})
+
+	Context("when the artifact path does not have enough space", func() {
+		BeforeEach(func() {
+			artifactSpace.FreeSpaceInBytesReturns(5*gigabyte, nil)
+		})
+
+		It("fails with the total estimate", func() {
+			Expect(err).To(MatchError(ContainSubstring("Artifact path /backups needs about 6.0G but only 5.0G is free")))
+		})
+	})
+
+	Context("when several places do not have enough space", func() {
+		BeforeEach(func() {
+			instance0.FreeSpaceInBytesReturns(1*gigabyte, nil)
+			artifactSpace.FreeSpaceInBytesReturns(1*gigabyte, nil)
+		})
+
+		It("reports all of them", func() {
+			Expect(err).To(MatchError(ContainSubstring("Instance redis/redis-id needs about 2.0G")))
+			Expect(err).To(MatchError(ContainSubstring("Artifact path /backups needs about 6.0G")))
+		})
+	})
+
+	Context("when a size cannot be estimated", func() {
+		BeforeEach(func() {
+			artifactSpace.LatestReturns(nil, nil)
+			instance1.FreeSpaceInBytesReturns(0, nil)
+		})
+
+		It("warns and leaves the artifact out of the estimate", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instance1.FreeSpaceInBytesCallCount()).To(Equal(0))
+
+			_, message, args := logger.WarnArgsForCall(0)
+			Expect(fmt.Sprintf(message, args...)).To(ContainSubstring("Unable to estimate the backup size of postgres on database/database-id"))
+		})
+	})
+
+	Context("when the backup-size script fails", func() {
+		BeforeEach(func() {
+			jobWithScript.BackupSizeReturns(0, fmt.Errorf("script failed"))
+			instance0.FreeSpaceInBytesReturns(0, nil)
+		})
+
+		It("warns and leaves the artifact out of the estimate", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(logger.WarnCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when free space on an instance cannot be checked", func() {
+		BeforeEach(func() {
+			instance0.FreeSpaceInBytesReturns(0, fmt.Errorf("df failed"))
+		})
+
+		It("warns and carries on", func() {
+			Expect(err).NotTo(HaveOccurred())
+
+			_, message, args := logger.WarnArgsForCall(0)
+			Expect(fmt.Sprintf(message, args...)).To(ContainSubstring("Unable to check free disk space on redis/redis-id: df failed"))
+		})
+	})
+
+	Context("when the deployment is not backupable", func() {
+		BeforeEach(func() {
+			deployment.IsBackupableReturns(false)
+		})
+
+		It("does not check disk space", func() {
+			Expect(err).To(MatchError("Deployment 'my-deployment' has no backup scripts"))
+			Expect(artifactSpace.LatestCallCount()).To(Equal(0))
+		})
+	})
+})
@@ -17,9 +17,12 @@ func NewDrainStep(logger Logger, artifactCopier ArtifactCopier) Step {
 }
 
 func (s *DrainStep) Run(session *Session) error {
-	err := s.artifactCopier.DownloadBackupFromDeployment(session.CurrentArtifact(), session.CurrentDeployment())
+	err := s.artifactCopier.DownloadBackupFromDeployment(session.Context(), session.CurrentArtifact(), session.CurrentDeployment())
 	if err != nil {
 		s.logger.Info("bbr", "Failed to create backup of %s on %v, failed during drain step\n", session.DeploymentName(), time.Now())
+		if containsChecksumError(err) {
+			return NewChecksumError(err.Error())
+		}
 		return NewDrainError(err.Error())
 	}
 	s.logger.Info("bbr", "Backup created of %s on %v\n", session.DeploymentName(), time.Now())
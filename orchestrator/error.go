@@ -9,38 +9,169 @@ import (
 )
 
 type customError struct {
+	stackTrace
+	failedScripts
+}
+
+// stackTrace formats as the error it wraps, so that %+v prints the stack trace
+// recorded by github.com/pkg/errors.
+type stackTrace struct {
 	error
 }
 
+func (s stackTrace) Format(state fmt.State, verb rune) {
+	fmt.Fprintf(state, fmt.FormatString(state, verb), s.error) //nolint:errcheck
+}
+
+// failedScripts are the scripts whose failure an error was built from.
+type failedScripts []ScriptError
+
+func (f failedScripts) FailedScripts() []ScriptError {
+	return f
+}
+
 type LockError customError
 type BackupError customError
 type UnlockError customError
 type CleanupError customError
 type ArtifactDirError customError
 type DrainError customError
+type HookError customError
+type DiskSpaceError customError
+type DiscoveryError customError
+type PreCheckError customError
+type TransferError customError
+type ChecksumError customError
+type RestoreError customError
+type AbortError customError
 
 func NewLockError(errorMessage string) LockError {
-	return LockError{errors.New(errorMessage)}
+	return LockError{stackTrace: stackTrace{errors.New(errorMessage)}}
 }
 
 func NewBackupError(errorMessage string) BackupError {
-	return BackupError{errors.New(errorMessage)}
+	return BackupError{stackTrace: stackTrace{errors.New(errorMessage)}}
 }
 
 func NewPostUnlockError(errorMessage string) UnlockError {
-	return UnlockError{errors.New(errorMessage)}
+	return UnlockError{stackTrace: stackTrace{errors.New(errorMessage)}}
 }
 
 func NewDrainError(errorMessage string) DrainError {
-	return DrainError{errors.New(errorMessage)}
+	return DrainError{stackTrace: stackTrace{errors.New(errorMessage)}}
 }
 
 func NewCleanupError(errorMessage string) CleanupError {
-	return CleanupError{errors.New(errorMessage)}
+	return CleanupError{stackTrace: stackTrace{errors.New(errorMessage)}}
 }
 
 func NewArtifactDirError(errorMessage string) ArtifactDirError {
-	return ArtifactDirError{errors.New(errorMessage)}
+	return ArtifactDirError{stackTrace: stackTrace{errors.New(errorMessage)}}
+}
+
+func NewHookError(errorMessage string) HookError {
+	return HookError{stackTrace: stackTrace{errors.New(errorMessage)}}
+}
+
+func NewDiskSpaceError(errorMessage string) DiskSpaceError {
+	return DiskSpaceError{stackTrace: stackTrace{errors.New(errorMessage)}}
+}
+
+func newLockErrorFrom(err error) LockError {
+	return LockError{stackTrace: stackTrace{err}, failedScripts: FailedScripts(err)}
+}
+
+func newBackupErrorFrom(err error) BackupError {
+	return BackupError{stackTrace: stackTrace{err}, failedScripts: FailedScripts(err)}
+}
+
+func newPostUnlockErrorFrom(err error) UnlockError {
+	return UnlockError{stackTrace: stackTrace{err}, failedScripts: FailedScripts(err)}
+}
+
+func newRestoreErrorFrom(err error) RestoreError {
+	return RestoreError{stackTrace: stackTrace{err}, failedScripts: FailedScripts(err)}
+}
+
+func NewDiscoveryError(errorMessage string) DiscoveryError {
+	return DiscoveryError{stackTrace: stackTrace{errors.New(errorMessage)}}
+}
+
+// NewDiscoveryErrorFrom keeps the stack trace of err.
+func NewDiscoveryErrorFrom(err error) DiscoveryError {
+	return DiscoveryError{stackTrace: stackTrace{err}}
+}
+
+func NewPreCheckError(errorMessage string) PreCheckError {
+	return PreCheckError{stackTrace: stackTrace{errors.New(errorMessage)}}
+}
+
+func NewTransferError(errorMessage string) TransferError {
+	return TransferError{stackTrace: stackTrace{errors.New(errorMessage)}}
+}
+
+func NewChecksumError(errorMessage string) ChecksumError {
+	return ChecksumError{stackTrace: stackTrace{errors.New(errorMessage)}}
+}
+
+func NewRestoreError(errorMessage string) RestoreError {
+	return RestoreError{stackTrace: stackTrace{errors.New(errorMessage)}}
+}
+
+func NewAbortError(errorMessage string) AbortError {
+	return AbortError{stackTrace: stackTrace{errors.New(errorMessage)}}
+}
+
+// ScriptError is returned when a script of a job fails on an instance.
+type ScriptError struct {
+	error
+	Instance string
+	Job      string
+	Script   string
+	// ExitCode and Stderr are only set if the script ran and exited non-zero.
+	ExitCode int
+	Stderr   string
+}
+
+// exitStatus is implemented by the errors of commands that exited non-zero on
+// an instance.
+type exitStatus interface {
+	ExitCode() int
+	Stderr() string
+}
+
+func NewScriptError(instance, job, script string, err error) ScriptError {
+	scriptError := ScriptError{error: err, Instance: instance, Job: job, Script: script}
+
+	if status, ok := errors.Cause(err).(exitStatus); ok {
+		scriptError.ExitCode = status.ExitCode()
+		scriptError.Stderr = status.Stderr()
+	}
+
+	return scriptError
+}
+
+// FailedScripts returns the scripts whose failure caused err, however deeply
+// they are wrapped.
+func FailedScripts(err error) []ScriptError {
+	switch e := err.(type) {
+	case nil:
+		return nil
+	case ScriptError:
+		return []ScriptError{e}
+	case Error:
+		var scriptErrors []ScriptError
+		for _, wrappedErr := range e {
+			scriptErrors = append(scriptErrors, FailedScripts(wrappedErr)...)
+		}
+		return scriptErrors
+	case interface{ FailedScripts() []ScriptError }:
+		return e.FailedScripts()
+	case interface{ Cause() error }:
+		return FailedScripts(e.Cause())
+	default:
+		return nil
+	}
 }
 
 func ConvertErrors(errs []error) error {
@@ -115,6 +246,8 @@ func (err Error) ContainsUnlockOrCleanupOrArtifactDirExists() bool {
 			return true
 		case DrainError:
 			return true
+		case ChecksumError:
+			return true
 		default:
 			continue
 		}
@@ -123,6 +256,17 @@ func (err Error) ContainsUnlockOrCleanupOrArtifactDirExists() bool {
 	return false
 }
 
+// containsChecksumError reports whether err, or any of the errors it is made
+// of, is a ChecksumError.
+func containsChecksumError(err error) bool {
+	for _, e := range flattenErrors([]error{err}) {
+		if _, ok := e.(ChecksumError); ok {
+			return true
+		}
+	}
+	return false
+}
+
 func (err Error) ContainsArtifactDirError() bool {
 	for _, e := range err {
 		_, ok := e.(ArtifactDirError)
@@ -165,21 +309,165 @@ func (err Error) IsNil() bool {
 	return len(err) == 0
 }
 
+// ErrorCategory classifies an error by what an operator has to do about it,
+// and is reflected in the exit code of bbr.
+type ErrorCategory string
+
+const (
+	DiscoveryCategory         ErrorCategory = "discovery"
+	PreCheckCategory          ErrorCategory = "pre-check"
+	LockCategory              ErrorCategory = "lock"
+	BackupScriptCategory      ErrorCategory = "backup-script"
+	TransferCategory          ErrorCategory = "transfer"
+	ChecksumCategory          ErrorCategory = "checksum"
+	UnlockCategory            ErrorCategory = "unlock"
+	RestoreScriptCategory     ErrorCategory = "restore-script"
+	CleanupCategory           ErrorCategory = "cleanup"
+	ArtifactDirExistsCategory ErrorCategory = "artifact-dir-exists"
+	HookCategory              ErrorCategory = "hook"
+	AbortCategory             ErrorCategory = "abort"
+	OtherCategory             ErrorCategory = "other"
+)
+
+// ErrorCategories lists every value that Category can return.
+var ErrorCategories = []ErrorCategory{
+	DiscoveryCategory,
+	PreCheckCategory,
+	LockCategory,
+	BackupScriptCategory,
+	TransferCategory,
+	ChecksumCategory,
+	UnlockCategory,
+	RestoreScriptCategory,
+	CleanupCategory,
+	ArtifactDirExistsCategory,
+	HookCategory,
+	AbortCategory,
+	OtherCategory,
+}
+
+// Category classifies err. Errors that bbr did not classify are OtherCategory.
+func Category(err error) ErrorCategory {
+	switch err.(type) {
+	case DiscoveryError:
+		return DiscoveryCategory
+	case PreCheckError, DiskSpaceError:
+		return PreCheckCategory
+	case LockError:
+		return LockCategory
+	case BackupError:
+		return BackupScriptCategory
+	case DrainError, TransferError:
+		return TransferCategory
+	case ChecksumError:
+		return ChecksumCategory
+	case UnlockError:
+		return UnlockCategory
+	case RestoreError:
+		return RestoreScriptCategory
+	case CleanupError:
+		return CleanupCategory
+	case ArtifactDirError:
+		return ArtifactDirExistsCategory
+	case HookError:
+		return HookCategory
+	case AbortError:
+		return AbortCategory
+	default:
+		return OtherCategory
+	}
+}
+
+// Exit codes are a bit field, so that every kind of failure in a run can be
+// read back from them, and stay below 128 so that they are not mistaken for
+// the exit code of a process killed by a signal:
+//
+//	bit 0        (1)  the backup or restore failed
+//	bits 1, 5-6  (2, 32, 64)  why it failed, see exitCodeCategoryBits
+//	bit 2        (4)  a lock script failed
+//	bit 3        (8)  an unlock script failed
+//	bit 4        (16) cleanup failed
+//
+// When failures of several categories occurred, the exit code records the
+// first of them in failureCategoryPrecedence. For example a backup script
+// failing followed by a failed cleanup exits with 1 | 2 | 32 | 16 = 51.
+const (
+	ExitCodeFailed  = 1
+	ExitCodeLock    = 1 << 2
+	ExitCodeUnlock  = 1 << 3
+	ExitCodeCleanup = 1 << 4
+)
+
+// exitCodeCategoryBits are the bits that record each category of failure.
+// They must never change. Categories that are not listed, such as
+// OtherCategory and HookCategory, set none of them. Transfer and checksum
+// failures share their bits, as both are recovered from by cleaning up and
+// trying again. Every combination of the category bits is taken, so these
+// pairs can only be told apart by the category in the error report.
+var exitCodeCategoryBits = map[ErrorCategory]int{
+	DiscoveryCategory:         1 << 1,
+	PreCheckCategory:          1 << 5,
+	BackupScriptCategory:      1<<5 | 1<<1,
+	TransferCategory:          1 << 6,
+	ChecksumCategory:          1 << 6,
+	RestoreScriptCategory:     1<<6 | 1<<1,
+	ArtifactDirExistsCategory: 1<<6 | 1<<5,
+	AbortCategory:             1<<6 | 1<<5 | 1<<1,
+}
+
+// failureCategoryPrecedence puts a stop requested by the operator first, then
+// the failures that prevented bbr from doing anything ahead of those that
+// happened part way through.
+var failureCategoryPrecedence = []ErrorCategory{
+	AbortCategory,
+	DiscoveryCategory,
+	ArtifactDirExistsCategory,
+	PreCheckCategory,
+	BackupScriptCategory,
+	RestoreScriptCategory,
+	TransferCategory,
+	ChecksumCategory,
+	HookCategory,
+	OtherCategory,
+}
+
+// FailureCategory returns the category of the failure that the exit code of
+// errs records, and false if none of errs failed the backup or restore.
+func FailureCategory(errs Error) (ErrorCategory, bool) {
+	failedCategories := map[ErrorCategory]bool{}
+	for _, err := range errs {
+		switch category := Category(err); category {
+		case LockCategory, UnlockCategory, CleanupCategory:
+		default:
+			failedCategories[category] = true
+		}
+	}
+
+	for _, category := range failureCategoryPrecedence {
+		if failedCategories[category] {
+			return category, true
+		}
+	}
+	return "", false
+}
+
 func BuildExitCode(errs Error) int {
 	exitCode := 0
 
 	for _, err := range errs {
-		switch err.(type) {
-		case LockError:
-			exitCode = exitCode | 1<<2
-		case UnlockError:
-			exitCode = exitCode | 1<<3
-		case CleanupError:
-			exitCode = exitCode | 1<<4
-		default:
-			exitCode = exitCode | 1
+		switch Category(err) {
+		case LockCategory:
+			exitCode = exitCode | ExitCodeLock
+		case UnlockCategory:
+			exitCode = exitCode | ExitCodeUnlock
+		case CleanupCategory:
+			exitCode = exitCode | ExitCodeCleanup
 		}
 	}
 
+	if category, failed := FailureCategory(errs); failed {
+		exitCode = exitCode | ExitCodeFailed | exitCodeCategoryBits[category]
+	}
+
 	return exitCode
 }
@@ -13,6 +13,17 @@ import (
 	. "github.com/onsi/gomega"
 )
 
+type scriptExitError struct {
+	stderr   string
+	exitCode int
+}
+
+func (e scriptExitError) Error() string {
+	return fmt.Sprintf("%s - exit code %d", e.stderr, e.exitCode)
+}
+func (e scriptExitError) ExitCode() int  { return e.exitCode }
+func (e scriptExitError) Stderr() string { return e.stderr }
+
 type ErrorCase struct {
 	name             string
 	errors           []error
@@ -108,7 +119,17 @@ var _ = Describe("Error", func() {
 		Context("errors", func() {
 			errorCases := []ErrorCase{
 				{"genericError", []error{genericError}, 1},
-				{"backupError", []error{backupError}, 1},
+				{"discoveryError", []error{orchestrator.NewDiscoveryError("DISCOVERY_ERROR")}, 3},
+				{"preCheckError", []error{orchestrator.NewPreCheckError("PRE_CHECK_ERROR")}, 33},
+				{"diskSpaceError", []error{orchestrator.NewDiskSpaceError("DISK_SPACE_ERROR")}, 33},
+				{"backupError", []error{backupError}, 35},
+				{"drainError", []error{orchestrator.NewDrainError("DRAIN_ERROR")}, 65},
+				{"transferError", []error{orchestrator.NewTransferError("TRANSFER_ERROR")}, 65},
+				{"checksumError", []error{orchestrator.NewChecksumError("CHECKSUM_ERROR")}, 65},
+				{"restoreError", []error{orchestrator.NewRestoreError("RESTORE_ERROR")}, 67},
+				{"artifactDirError", []error{orchestrator.NewArtifactDirError("ARTIFACT_DIR_ERROR")}, 97},
+				{"abortError", []error{orchestrator.NewAbortError("ABORT_ERROR")}, 99},
+				{"hookError", []error{orchestrator.NewHookError("HOOK_ERROR")}, 1},
 				{"lockError", []error{lockError}, 4},
 				{"unlockError", []error{postBackupUnlockError}, 8},
 				{"cleanupError", []error{cleanupError}, 16},
@@ -137,9 +158,26 @@ var _ = Describe("Error", func() {
 		})
 
 		Context("when there is a backup error and a cleanup error", func() {
-			It("returns exit code 17 (16 | 1)", func() {
+			It("returns exit code 51 (16 | 32 | 2 | 1)", func() {
 				exitCode := orchestrator.BuildExitCode([]error{cleanupError, backupError})
-				Expect(exitCode).To(Equal(17))
+				Expect(exitCode).To(Equal(51))
+			})
+		})
+
+		Context("when there is a lock error, a backup error and an unlock error", func() {
+			It("returns exit code 47 (8 | 4 | 32 | 2 | 1)", func() {
+				exitCode := orchestrator.BuildExitCode([]error{lockError, backupError, postBackupUnlockError})
+				Expect(exitCode).To(Equal(47))
+			})
+		})
+
+		Context("when there are fatal errors of several categories", func() {
+			It("records the category that takes precedence, whatever order they occurred in", func() {
+				checksumError := orchestrator.NewChecksumError("CHECKSUM_ERROR")
+				Expect(orchestrator.BuildExitCode([]error{checksumError, genericError, backupError})).To(Equal(35))
+				Expect(orchestrator.BuildExitCode([]error{backupError, checksumError})).To(Equal(35))
+				Expect(orchestrator.BuildExitCode([]error{genericError, checksumError})).To(Equal(65))
+				Expect(orchestrator.BuildExitCode([]error{backupError, orchestrator.NewAbortError("ABORT_ERROR")})).To(Equal(99))
 			})
 		})
 
@@ -158,6 +196,95 @@ var _ = Describe("Error", func() {
 		})
 	})
 
+	Describe("Category", func() {
+		It("classifies each kind of error", func() {
+			Expect(orchestrator.Category(orchestrator.NewDiscoveryError("DISCOVERY_ERROR"))).To(Equal(orchestrator.DiscoveryCategory))
+			Expect(orchestrator.Category(orchestrator.NewPreCheckError("PRE_CHECK_ERROR"))).To(Equal(orchestrator.PreCheckCategory))
+			Expect(orchestrator.Category(orchestrator.NewDiskSpaceError("DISK_SPACE_ERROR"))).To(Equal(orchestrator.PreCheckCategory))
+			Expect(orchestrator.Category(lockError)).To(Equal(orchestrator.LockCategory))
+			Expect(orchestrator.Category(backupError)).To(Equal(orchestrator.BackupScriptCategory))
+			Expect(orchestrator.Category(orchestrator.NewDrainError("DRAIN_ERROR"))).To(Equal(orchestrator.TransferCategory))
+			Expect(orchestrator.Category(orchestrator.NewTransferError("TRANSFER_ERROR"))).To(Equal(orchestrator.TransferCategory))
+			Expect(orchestrator.Category(orchestrator.NewChecksumError("CHECKSUM_ERROR"))).To(Equal(orchestrator.ChecksumCategory))
+			Expect(orchestrator.Category(postBackupUnlockError)).To(Equal(orchestrator.UnlockCategory))
+			Expect(orchestrator.Category(orchestrator.NewRestoreError("RESTORE_ERROR"))).To(Equal(orchestrator.RestoreScriptCategory))
+			Expect(orchestrator.Category(cleanupError)).To(Equal(orchestrator.CleanupCategory))
+			Expect(orchestrator.Category(orchestrator.NewArtifactDirError("ARTIFACT_DIR_ERROR"))).To(Equal(orchestrator.ArtifactDirExistsCategory))
+			Expect(orchestrator.Category(orchestrator.NewHookError("HOOK_ERROR"))).To(Equal(orchestrator.HookCategory))
+			Expect(orchestrator.Category(orchestrator.NewAbortError("ABORT_ERROR"))).To(Equal(orchestrator.AbortCategory))
+			Expect(orchestrator.Category(genericError)).To(Equal(orchestrator.OtherCategory))
+		})
+	})
+
+	Describe("FailureCategory", func() {
+		It("returns the category that the exit code records", func() {
+			category, failed := orchestrator.FailureCategory(orchestrator.Error{cleanupError, backupError})
+			Expect(failed).To(BeTrue())
+			Expect(category).To(Equal(orchestrator.BackupScriptCategory))
+
+			category, failed = orchestrator.FailureCategory(orchestrator.Error{orchestrator.NewChecksumError("CHECKSUM_ERROR")})
+			Expect(failed).To(BeTrue())
+			Expect(category).To(Equal(orchestrator.ChecksumCategory))
+		})
+
+		It("returns false when the errors did not fail the backup or restore", func() {
+			_, failed := orchestrator.FailureCategory(orchestrator.Error{lockError, postBackupUnlockError, cleanupError})
+			Expect(failed).To(BeFalse())
+		})
+	})
+
+	Describe("exit codes", func() {
+		It("are below 128, whatever failed", func() {
+			allErrors := orchestrator.Error{
+				orchestrator.NewAbortError("ABORT_ERROR"),
+				lockError,
+				postBackupUnlockError,
+				cleanupError,
+			}
+			Expect(orchestrator.BuildExitCode(allErrors)).To(Equal(127))
+		})
+	})
+
+	Describe("formatting typed errors", func() {
+		It("prints the message with %v", func() {
+			Expect(fmt.Sprintf("%v", orchestrator.NewBackupError("backup failed"))).To(Equal("backup failed"))
+		})
+
+		It("prints the stack trace with %+v", func() {
+			Expect(fmt.Sprintf("%+v", orchestrator.NewBackupError("backup failed"))).To(ContainSubstring("error_test.go"))
+			Expect(fmt.Sprintf("%+v", orchestrator.NewDiscoveryErrorFrom(goerr.New("no director")))).To(ContainSubstring("error_test.go"))
+		})
+	})
+
+	Describe("FailedScripts", func() {
+		var scriptError orchestrator.ScriptError
+
+		BeforeEach(func() {
+			scriptError = orchestrator.NewScriptError("redis/0", "redis-server", "backup",
+				goerr.Wrap(scriptExitError{stderr: "disk full", exitCode: 3}, "backup failed"))
+		})
+
+		It("records the exit code and stderr of the script", func() {
+			Expect(scriptError.ExitCode).To(Equal(3))
+			Expect(scriptError.Stderr).To(Equal("disk full"))
+			Expect(scriptError.Error()).To(Equal("backup failed: disk full - exit code 3"))
+		})
+
+		It("finds failed scripts however deeply they are wrapped", func() {
+			err := orchestrator.NewError(
+				errors.New("unrelated"),
+				goerr.Wrap(orchestrator.NewError(scriptError), "wrapped"),
+			)
+
+			Expect(orchestrator.FailedScripts(err)).To(ConsistOf(scriptError))
+		})
+
+		It("returns nothing when no script failed", func() {
+			Expect(orchestrator.FailedScripts(orchestrator.NewBackupError("no scripts"))).To(BeEmpty())
+			Expect(orchestrator.FailedScripts(nil)).To(BeEmpty())
+		})
+	})
+
 	Describe("ConvertErrors", func() {
 		var errorOne = errors.New("error one")
 		var errorTwo = errors.New("error two")
@@ -0,0 +1,199 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	"sync"
+
+	"github.com/cloudfoundry/bosh-backup-and-restore/orchestrator"
+)
+
+type FakeArtifactSpace struct {
+	FreeSpaceInBytesStub        func(string) (int, error)
+	freeSpaceInBytesMutex       sync.RWMutex
+	freeSpaceInBytesArgsForCall []struct {
+		arg1 string
+	}
+	freeSpaceInBytesReturns struct {
+		result1 int
+		result2 error
+	}
+	freeSpaceInBytesReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
+	LatestStub        func(string, string, orchestrator.Logger) (orchestrator.Backup, error)
+	latestMutex       sync.RWMutex
+	latestArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 orchestrator.Logger
+	}
+	latestReturns struct {
+		result1 orchestrator.Backup
+		result2 error
+	}
+	latestReturnsOnCall map[int]struct {
+		result1 orchestrator.Backup
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeArtifactSpace) FreeSpaceInBytes(arg1 string) (int, error) {
+	fake.freeSpaceInBytesMutex.Lock()
+	ret, specificReturn := fake.freeSpaceInBytesReturnsOnCall[len(fake.freeSpaceInBytesArgsForCall)]
+	fake.freeSpaceInBytesArgsForCall = append(fake.freeSpaceInBytesArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.FreeSpaceInBytesStub
+	fakeReturns := fake.freeSpaceInBytesReturns
+	fake.recordInvocation("FreeSpaceInBytes", []interface{}{arg1})
+	fake.freeSpaceInBytesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeArtifactSpace) FreeSpaceInBytesCallCount() int {
+	fake.freeSpaceInBytesMutex.RLock()
+	defer fake.freeSpaceInBytesMutex.RUnlock()
+	return len(fake.freeSpaceInBytesArgsForCall)
+}
+
+func (fake *FakeArtifactSpace) FreeSpaceInBytesCalls(stub func(string) (int, error)) {
+	fake.freeSpaceInBytesMutex.Lock()
+	defer fake.freeSpaceInBytesMutex.Unlock()
+	fake.FreeSpaceInBytesStub = stub
+}
+
+func (fake *FakeArtifactSpace) FreeSpaceInBytesArgsForCall(i int) string {
+	fake.freeSpaceInBytesMutex.RLock()
+	defer fake.freeSpaceInBytesMutex.RUnlock()
+	argsForCall := fake.freeSpaceInBytesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeArtifactSpace) FreeSpaceInBytesReturns(result1 int, result2 error) {
+	fake.freeSpaceInBytesMutex.Lock()
+	defer fake.freeSpaceInBytesMutex.Unlock()
+	fake.FreeSpaceInBytesStub = nil
+	fake.freeSpaceInBytesReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeArtifactSpace) FreeSpaceInBytesReturnsOnCall(i int, result1 int, result2 error) {
+	fake.freeSpaceInBytesMutex.Lock()
+	defer fake.freeSpaceInBytesMutex.Unlock()
+	fake.FreeSpaceInBytesStub = nil
+	if fake.freeSpaceInBytesReturnsOnCall == nil {
+		fake.freeSpaceInBytesReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.freeSpaceInBytesReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeArtifactSpace) Latest(arg1 string, arg2 string, arg3 orchestrator.Logger) (orchestrator.Backup, error) {
+	fake.latestMutex.Lock()
+	ret, specificReturn := fake.latestReturnsOnCall[len(fake.latestArgsForCall)]
+	fake.latestArgsForCall = append(fake.latestArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 orchestrator.Logger
+	}{arg1, arg2, arg3})
+	stub := fake.LatestStub
+	fakeReturns := fake.latestReturns
+	fake.recordInvocation("Latest", []interface{}{arg1, arg2, arg3})
+	fake.latestMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeArtifactSpace) LatestCallCount() int {
+	fake.latestMutex.RLock()
+	defer fake.latestMutex.RUnlock()
+	return len(fake.latestArgsForCall)
+}
+
+func (fake *FakeArtifactSpace) LatestCalls(stub func(string, string, orchestrator.Logger) (orchestrator.Backup, error)) {
+	fake.latestMutex.Lock()
+	defer fake.latestMutex.Unlock()
+	fake.LatestStub = stub
+}
+
+func (fake *FakeArtifactSpace) LatestArgsForCall(i int) (string, string, orchestrator.Logger) {
+	fake.latestMutex.RLock()
+	defer fake.latestMutex.RUnlock()
+	argsForCall := fake.latestArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeArtifactSpace) LatestReturns(result1 orchestrator.Backup, result2 error) {
+	fake.latestMutex.Lock()
+	defer fake.latestMutex.Unlock()
+	fake.LatestStub = nil
+	fake.latestReturns = struct {
+		result1 orchestrator.Backup
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeArtifactSpace) LatestReturnsOnCall(i int, result1 orchestrator.Backup, result2 error) {
+	fake.latestMutex.Lock()
+	defer fake.latestMutex.Unlock()
+	fake.LatestStub = nil
+	if fake.latestReturnsOnCall == nil {
+		fake.latestReturnsOnCall = make(map[int]struct {
+			result1 orchestrator.Backup
+			result2 error
+		})
+	}
+	fake.latestReturnsOnCall[i] = struct {
+		result1 orchestrator.Backup
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeArtifactSpace) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.freeSpaceInBytesMutex.RLock()
+	defer fake.freeSpaceInBytesMutex.RUnlock()
+	fake.latestMutex.RLock()
+	defer fake.latestMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeArtifactSpace) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ orchestrator.ArtifactSpace = new(FakeArtifactSpace)
@@ -10,6 +10,18 @@ import (
 )
 
 type FakeBackup struct {
+	AddArtifactTransferStub        func(orchestrator.ArtifactIdentifier, orchestrator.ArtifactTransfer) error
+	addArtifactTransferMutex       sync.RWMutex
+	addArtifactTransferArgsForCall []struct {
+		arg1 orchestrator.ArtifactIdentifier
+		arg2 orchestrator.ArtifactTransfer
+	}
+	addArtifactTransferReturns struct {
+		result1 error
+	}
+	addArtifactTransferReturnsOnCall map[int]struct {
+		result1 error
+	}
 	AddChecksumStub        func(orchestrator.ArtifactIdentifier, orchestrator.BackupChecksum) error
 	addChecksumMutex       sync.RWMutex
 	addChecksumArgsForCall []struct {
@@ -33,6 +45,28 @@ type FakeBackup struct {
 	addFinishTimeReturnsOnCall map[int]struct {
 		result1 error
 	}
+	AddOriginStub        func(orchestrator.Origin) error
+	addOriginMutex       sync.RWMutex
+	addOriginArgsForCall []struct {
+		arg1 orchestrator.Origin
+	}
+	addOriginReturns struct {
+		result1 error
+	}
+	addOriginReturnsOnCall map[int]struct {
+		result1 error
+	}
+	AddPhaseTimingsStub        func([]orchestrator.PhaseTiming) error
+	addPhaseTimingsMutex       sync.RWMutex
+	addPhaseTimingsArgsForCall []struct {
+		arg1 []orchestrator.PhaseTiming
+	}
+	addPhaseTimingsReturns struct {
+		result1 error
+	}
+	addPhaseTimingsReturnsOnCall map[int]struct {
+		result1 error
+	}
 	CalculateChecksumStub        func(orchestrator.ArtifactIdentifier) (orchestrator.BackupChecksum, error)
 	calculateChecksumMutex       sync.RWMutex
 	calculateChecksumArgsForCall []struct {
@@ -59,6 +93,20 @@ type FakeBackup struct {
 		result1 io.WriteCloser
 		result2 error
 	}
+	CreateArtifactPartStub        func(orchestrator.ArtifactIdentifier, int) (io.WriteCloser, error)
+	createArtifactPartMutex       sync.RWMutex
+	createArtifactPartArgsForCall []struct {
+		arg1 orchestrator.ArtifactIdentifier
+		arg2 int
+	}
+	createArtifactPartReturns struct {
+		result1 io.WriteCloser
+		result2 error
+	}
+	createArtifactPartReturnsOnCall map[int]struct {
+		result1 io.WriteCloser
+		result2 error
+	}
 	CreateMetadataFileWithStartTimeStub        func(time.Time) error
 	createMetadataFileWithStartTimeMutex       sync.RWMutex
 	createMetadataFileWithStartTimeArgsForCall []struct {
@@ -84,6 +132,19 @@ type FakeBackup struct {
 		result1 bool
 		result2 error
 	}
+	FetchArtifactSizeInBytesStub        func(orchestrator.ArtifactIdentifier) (int, error)
+	fetchArtifactSizeInBytesMutex       sync.RWMutex
+	fetchArtifactSizeInBytesArgsForCall []struct {
+		arg1 orchestrator.ArtifactIdentifier
+	}
+	fetchArtifactSizeInBytesReturns struct {
+		result1 int
+		result2 error
+	}
+	fetchArtifactSizeInBytesReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
 	FetchChecksumStub        func(orchestrator.ArtifactIdentifier) (orchestrator.BackupChecksum, error)
 	fetchChecksumMutex       sync.RWMutex
 	fetchChecksumArgsForCall []struct {
@@ -97,6 +158,18 @@ type FakeBackup struct {
 		result1 orchestrator.BackupChecksum
 		result2 error
 	}
+	FetchOriginStub        func() (orchestrator.Origin, error)
+	fetchOriginMutex       sync.RWMutex
+	fetchOriginArgsForCall []struct {
+	}
+	fetchOriginReturns struct {
+		result1 orchestrator.Origin
+		result2 error
+	}
+	fetchOriginReturnsOnCall map[int]struct {
+		result1 orchestrator.Origin
+		result2 error
+	}
 	GetArtifactByteSizeStub        func(orchestrator.ArtifactIdentifier) (int, error)
 	getArtifactByteSizeMutex       sync.RWMutex
 	getArtifactByteSizeArgsForCall []struct {
@@ -123,17 +196,17 @@ type FakeBackup struct {
 		result1 string
 		result2 error
 	}
-	ReadArtifactStub        func(orchestrator.ArtifactIdentifier) (io.ReadCloser, error)
-	readArtifactMutex       sync.RWMutex
-	readArtifactArgsForCall []struct {
+	ReadArtifactPartsStub        func(orchestrator.ArtifactIdentifier) ([]orchestrator.ArtifactPart, error)
+	readArtifactPartsMutex       sync.RWMutex
+	readArtifactPartsArgsForCall []struct {
 		arg1 orchestrator.ArtifactIdentifier
 	}
-	readArtifactReturns struct {
-		result1 io.ReadCloser
+	readArtifactPartsReturns struct {
+		result1 []orchestrator.ArtifactPart
 		result2 error
 	}
-	readArtifactReturnsOnCall map[int]struct {
-		result1 io.ReadCloser
+	readArtifactPartsReturnsOnCall map[int]struct {
+		result1 []orchestrator.ArtifactPart
 		result2 error
 	}
 	SaveManifestStub        func(string) error
@@ -163,6 +236,68 @@ type FakeBackup struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeBackup) AddArtifactTransfer(arg1 orchestrator.ArtifactIdentifier, arg2 orchestrator.ArtifactTransfer) error {
+	fake.addArtifactTransferMutex.Lock()
+	ret, specificReturn := fake.addArtifactTransferReturnsOnCall[len(fake.addArtifactTransferArgsForCall)]
+	fake.addArtifactTransferArgsForCall = append(fake.addArtifactTransferArgsForCall, struct {
+		arg1 orchestrator.ArtifactIdentifier
+		arg2 orchestrator.ArtifactTransfer
+	}{arg1, arg2})
+	stub := fake.AddArtifactTransferStub
+	fakeReturns := fake.addArtifactTransferReturns
+	fake.recordInvocation("AddArtifactTransfer", []interface{}{arg1, arg2})
+	fake.addArtifactTransferMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBackup) AddArtifactTransferCallCount() int {
+	fake.addArtifactTransferMutex.RLock()
+	defer fake.addArtifactTransferMutex.RUnlock()
+	return len(fake.addArtifactTransferArgsForCall)
+}
+
+func (fake *FakeBackup) AddArtifactTransferCalls(stub func(orchestrator.ArtifactIdentifier, orchestrator.ArtifactTransfer) error) {
+	fake.addArtifactTransferMutex.Lock()
+	defer fake.addArtifactTransferMutex.Unlock()
+	fake.AddArtifactTransferStub = stub
+}
+
+func (fake *FakeBackup) AddArtifactTransferArgsForCall(i int) (orchestrator.ArtifactIdentifier, orchestrator.ArtifactTransfer) {
+	fake.addArtifactTransferMutex.RLock()
+	defer fake.addArtifactTransferMutex.RUnlock()
+	argsForCall := fake.addArtifactTransferArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBackup) AddArtifactTransferReturns(result1 error) {
+	fake.addArtifactTransferMutex.Lock()
+	defer fake.addArtifactTransferMutex.Unlock()
+	fake.AddArtifactTransferStub = nil
+	fake.addArtifactTransferReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBackup) AddArtifactTransferReturnsOnCall(i int, result1 error) {
+	fake.addArtifactTransferMutex.Lock()
+	defer fake.addArtifactTransferMutex.Unlock()
+	fake.AddArtifactTransferStub = nil
+	if fake.addArtifactTransferReturnsOnCall == nil {
+		fake.addArtifactTransferReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.addArtifactTransferReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBackup) AddChecksum(arg1 orchestrator.ArtifactIdentifier, arg2 orchestrator.BackupChecksum) error {
 	fake.addChecksumMutex.Lock()
 	ret, specificReturn := fake.addChecksumReturnsOnCall[len(fake.addChecksumArgsForCall)]
@@ -286,6 +421,133 @@ func (fake *FakeBackup) AddFinishTimeReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeBackup) AddOrigin(arg1 orchestrator.Origin) error {
+	fake.addOriginMutex.Lock()
+	ret, specificReturn := fake.addOriginReturnsOnCall[len(fake.addOriginArgsForCall)]
+	fake.addOriginArgsForCall = append(fake.addOriginArgsForCall, struct {
+		arg1 orchestrator.Origin
+	}{arg1})
+	stub := fake.AddOriginStub
+	fakeReturns := fake.addOriginReturns
+	fake.recordInvocation("AddOrigin", []interface{}{arg1})
+	fake.addOriginMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBackup) AddOriginCallCount() int {
+	fake.addOriginMutex.RLock()
+	defer fake.addOriginMutex.RUnlock()
+	return len(fake.addOriginArgsForCall)
+}
+
+func (fake *FakeBackup) AddOriginCalls(stub func(orchestrator.Origin) error) {
+	fake.addOriginMutex.Lock()
+	defer fake.addOriginMutex.Unlock()
+	fake.AddOriginStub = stub
+}
+
+func (fake *FakeBackup) AddOriginArgsForCall(i int) orchestrator.Origin {
+	fake.addOriginMutex.RLock()
+	defer fake.addOriginMutex.RUnlock()
+	argsForCall := fake.addOriginArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBackup) AddOriginReturns(result1 error) {
+	fake.addOriginMutex.Lock()
+	defer fake.addOriginMutex.Unlock()
+	fake.AddOriginStub = nil
+	fake.addOriginReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBackup) AddOriginReturnsOnCall(i int, result1 error) {
+	fake.addOriginMutex.Lock()
+	defer fake.addOriginMutex.Unlock()
+	fake.AddOriginStub = nil
+	if fake.addOriginReturnsOnCall == nil {
+		fake.addOriginReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.addOriginReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBackup) AddPhaseTimings(arg1 []orchestrator.PhaseTiming) error {
+	var arg1Copy []orchestrator.PhaseTiming
+	if arg1 != nil {
+		arg1Copy = make([]orchestrator.PhaseTiming, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.addPhaseTimingsMutex.Lock()
+	ret, specificReturn := fake.addPhaseTimingsReturnsOnCall[len(fake.addPhaseTimingsArgsForCall)]
+	fake.addPhaseTimingsArgsForCall = append(fake.addPhaseTimingsArgsForCall, struct {
+		arg1 []orchestrator.PhaseTiming
+	}{arg1Copy})
+	stub := fake.AddPhaseTimingsStub
+	fakeReturns := fake.addPhaseTimingsReturns
+	fake.recordInvocation("AddPhaseTimings", []interface{}{arg1Copy})
+	fake.addPhaseTimingsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBackup) AddPhaseTimingsCallCount() int {
+	fake.addPhaseTimingsMutex.RLock()
+	defer fake.addPhaseTimingsMutex.RUnlock()
+	return len(fake.addPhaseTimingsArgsForCall)
+}
+
+func (fake *FakeBackup) AddPhaseTimingsCalls(stub func([]orchestrator.PhaseTiming) error) {
+	fake.addPhaseTimingsMutex.Lock()
+	defer fake.addPhaseTimingsMutex.Unlock()
+	fake.AddPhaseTimingsStub = stub
+}
+
+func (fake *FakeBackup) AddPhaseTimingsArgsForCall(i int) []orchestrator.PhaseTiming {
+	fake.addPhaseTimingsMutex.RLock()
+	defer fake.addPhaseTimingsMutex.RUnlock()
+	argsForCall := fake.addPhaseTimingsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBackup) AddPhaseTimingsReturns(result1 error) {
+	fake.addPhaseTimingsMutex.Lock()
+	defer fake.addPhaseTimingsMutex.Unlock()
+	fake.AddPhaseTimingsStub = nil
+	fake.addPhaseTimingsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBackup) AddPhaseTimingsReturnsOnCall(i int, result1 error) {
+	fake.addPhaseTimingsMutex.Lock()
+	defer fake.addPhaseTimingsMutex.Unlock()
+	fake.AddPhaseTimingsStub = nil
+	if fake.addPhaseTimingsReturnsOnCall == nil {
+		fake.addPhaseTimingsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.addPhaseTimingsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBackup) CalculateChecksum(arg1 orchestrator.ArtifactIdentifier) (orchestrator.BackupChecksum, error) {
 	fake.calculateChecksumMutex.Lock()
 	ret, specificReturn := fake.calculateChecksumReturnsOnCall[len(fake.calculateChecksumArgsForCall)]
@@ -414,6 +676,71 @@ func (fake *FakeBackup) CreateArtifactReturnsOnCall(i int, result1 io.WriteClose
 	}{result1, result2}
 }
 
+func (fake *FakeBackup) CreateArtifactPart(arg1 orchestrator.ArtifactIdentifier, arg2 int) (io.WriteCloser, error) {
+	fake.createArtifactPartMutex.Lock()
+	ret, specificReturn := fake.createArtifactPartReturnsOnCall[len(fake.createArtifactPartArgsForCall)]
+	fake.createArtifactPartArgsForCall = append(fake.createArtifactPartArgsForCall, struct {
+		arg1 orchestrator.ArtifactIdentifier
+		arg2 int
+	}{arg1, arg2})
+	stub := fake.CreateArtifactPartStub
+	fakeReturns := fake.createArtifactPartReturns
+	fake.recordInvocation("CreateArtifactPart", []interface{}{arg1, arg2})
+	fake.createArtifactPartMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBackup) CreateArtifactPartCallCount() int {
+	fake.createArtifactPartMutex.RLock()
+	defer fake.createArtifactPartMutex.RUnlock()
+	return len(fake.createArtifactPartArgsForCall)
+}
+
+func (fake *FakeBackup) CreateArtifactPartCalls(stub func(orchestrator.ArtifactIdentifier, int) (io.WriteCloser, error)) {
+	fake.createArtifactPartMutex.Lock()
+	defer fake.createArtifactPartMutex.Unlock()
+	fake.CreateArtifactPartStub = stub
+}
+
+func (fake *FakeBackup) CreateArtifactPartArgsForCall(i int) (orchestrator.ArtifactIdentifier, int) {
+	fake.createArtifactPartMutex.RLock()
+	defer fake.createArtifactPartMutex.RUnlock()
+	argsForCall := fake.createArtifactPartArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBackup) CreateArtifactPartReturns(result1 io.WriteCloser, result2 error) {
+	fake.createArtifactPartMutex.Lock()
+	defer fake.createArtifactPartMutex.Unlock()
+	fake.CreateArtifactPartStub = nil
+	fake.createArtifactPartReturns = struct {
+		result1 io.WriteCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBackup) CreateArtifactPartReturnsOnCall(i int, result1 io.WriteCloser, result2 error) {
+	fake.createArtifactPartMutex.Lock()
+	defer fake.createArtifactPartMutex.Unlock()
+	fake.CreateArtifactPartStub = nil
+	if fake.createArtifactPartReturnsOnCall == nil {
+		fake.createArtifactPartReturnsOnCall = make(map[int]struct {
+			result1 io.WriteCloser
+			result2 error
+		})
+	}
+	fake.createArtifactPartReturnsOnCall[i] = struct {
+		result1 io.WriteCloser
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBackup) CreateMetadataFileWithStartTime(arg1 time.Time) error {
 	fake.createMetadataFileWithStartTimeMutex.Lock()
 	ret, specificReturn := fake.createMetadataFileWithStartTimeReturnsOnCall[len(fake.createMetadataFileWithStartTimeArgsForCall)]
@@ -545,6 +872,70 @@ func (fake *FakeBackup) DeploymentMatchesReturnsOnCall(i int, result1 bool, resu
 	}{result1, result2}
 }
 
+func (fake *FakeBackup) FetchArtifactSizeInBytes(arg1 orchestrator.ArtifactIdentifier) (int, error) {
+	fake.fetchArtifactSizeInBytesMutex.Lock()
+	ret, specificReturn := fake.fetchArtifactSizeInBytesReturnsOnCall[len(fake.fetchArtifactSizeInBytesArgsForCall)]
+	fake.fetchArtifactSizeInBytesArgsForCall = append(fake.fetchArtifactSizeInBytesArgsForCall, struct {
+		arg1 orchestrator.ArtifactIdentifier
+	}{arg1})
+	stub := fake.FetchArtifactSizeInBytesStub
+	fakeReturns := fake.fetchArtifactSizeInBytesReturns
+	fake.recordInvocation("FetchArtifactSizeInBytes", []interface{}{arg1})
+	fake.fetchArtifactSizeInBytesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBackup) FetchArtifactSizeInBytesCallCount() int {
+	fake.fetchArtifactSizeInBytesMutex.RLock()
+	defer fake.fetchArtifactSizeInBytesMutex.RUnlock()
+	return len(fake.fetchArtifactSizeInBytesArgsForCall)
+}
+
+func (fake *FakeBackup) FetchArtifactSizeInBytesCalls(stub func(orchestrator.ArtifactIdentifier) (int, error)) {
+	fake.fetchArtifactSizeInBytesMutex.Lock()
+	defer fake.fetchArtifactSizeInBytesMutex.Unlock()
+	fake.FetchArtifactSizeInBytesStub = stub
+}
+
+func (fake *FakeBackup) FetchArtifactSizeInBytesArgsForCall(i int) orchestrator.ArtifactIdentifier {
+	fake.fetchArtifactSizeInBytesMutex.RLock()
+	defer fake.fetchArtifactSizeInBytesMutex.RUnlock()
+	argsForCall := fake.fetchArtifactSizeInBytesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBackup) FetchArtifactSizeInBytesReturns(result1 int, result2 error) {
+	fake.fetchArtifactSizeInBytesMutex.Lock()
+	defer fake.fetchArtifactSizeInBytesMutex.Unlock()
+	fake.FetchArtifactSizeInBytesStub = nil
+	fake.fetchArtifactSizeInBytesReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBackup) FetchArtifactSizeInBytesReturnsOnCall(i int, result1 int, result2 error) {
+	fake.fetchArtifactSizeInBytesMutex.Lock()
+	defer fake.fetchArtifactSizeInBytesMutex.Unlock()
+	fake.FetchArtifactSizeInBytesStub = nil
+	if fake.fetchArtifactSizeInBytesReturnsOnCall == nil {
+		fake.fetchArtifactSizeInBytesReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.fetchArtifactSizeInBytesReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBackup) FetchChecksum(arg1 orchestrator.ArtifactIdentifier) (orchestrator.BackupChecksum, error) {
 	fake.fetchChecksumMutex.Lock()
 	ret, specificReturn := fake.fetchChecksumReturnsOnCall[len(fake.fetchChecksumArgsForCall)]
@@ -609,6 +1000,62 @@ func (fake *FakeBackup) FetchChecksumReturnsOnCall(i int, result1 orchestrator.B
 	}{result1, result2}
 }
 
+func (fake *FakeBackup) FetchOrigin() (orchestrator.Origin, error) {
+	fake.fetchOriginMutex.Lock()
+	ret, specificReturn := fake.fetchOriginReturnsOnCall[len(fake.fetchOriginArgsForCall)]
+	fake.fetchOriginArgsForCall = append(fake.fetchOriginArgsForCall, struct {
+	}{})
+	stub := fake.FetchOriginStub
+	fakeReturns := fake.fetchOriginReturns
+	fake.recordInvocation("FetchOrigin", []interface{}{})
+	fake.fetchOriginMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBackup) FetchOriginCallCount() int {
+	fake.fetchOriginMutex.RLock()
+	defer fake.fetchOriginMutex.RUnlock()
+	return len(fake.fetchOriginArgsForCall)
+}
+
+func (fake *FakeBackup) FetchOriginCalls(stub func() (orchestrator.Origin, error)) {
+	fake.fetchOriginMutex.Lock()
+	defer fake.fetchOriginMutex.Unlock()
+	fake.FetchOriginStub = stub
+}
+
+func (fake *FakeBackup) FetchOriginReturns(result1 orchestrator.Origin, result2 error) {
+	fake.fetchOriginMutex.Lock()
+	defer fake.fetchOriginMutex.Unlock()
+	fake.FetchOriginStub = nil
+	fake.fetchOriginReturns = struct {
+		result1 orchestrator.Origin
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBackup) FetchOriginReturnsOnCall(i int, result1 orchestrator.Origin, result2 error) {
+	fake.fetchOriginMutex.Lock()
+	defer fake.fetchOriginMutex.Unlock()
+	fake.FetchOriginStub = nil
+	if fake.fetchOriginReturnsOnCall == nil {
+		fake.fetchOriginReturnsOnCall = make(map[int]struct {
+			result1 orchestrator.Origin
+			result2 error
+		})
+	}
+	fake.fetchOriginReturnsOnCall[i] = struct {
+		result1 orchestrator.Origin
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBackup) GetArtifactByteSize(arg1 orchestrator.ArtifactIdentifier) (int, error) {
 	fake.getArtifactByteSizeMutex.Lock()
 	ret, specificReturn := fake.getArtifactByteSizeReturnsOnCall[len(fake.getArtifactByteSizeArgsForCall)]
@@ -737,16 +1184,16 @@ func (fake *FakeBackup) GetArtifactSizeReturnsOnCall(i int, result1 string, resu
 	}{result1, result2}
 }
 
-func (fake *FakeBackup) ReadArtifact(arg1 orchestrator.ArtifactIdentifier) (io.ReadCloser, error) {
-	fake.readArtifactMutex.Lock()
-	ret, specificReturn := fake.readArtifactReturnsOnCall[len(fake.readArtifactArgsForCall)]
-	fake.readArtifactArgsForCall = append(fake.readArtifactArgsForCall, struct {
+func (fake *FakeBackup) ReadArtifactParts(arg1 orchestrator.ArtifactIdentifier) ([]orchestrator.ArtifactPart, error) {
+	fake.readArtifactPartsMutex.Lock()
+	ret, specificReturn := fake.readArtifactPartsReturnsOnCall[len(fake.readArtifactPartsArgsForCall)]
+	fake.readArtifactPartsArgsForCall = append(fake.readArtifactPartsArgsForCall, struct {
 		arg1 orchestrator.ArtifactIdentifier
 	}{arg1})
-	stub := fake.ReadArtifactStub
-	fakeReturns := fake.readArtifactReturns
-	fake.recordInvocation("ReadArtifact", []interface{}{arg1})
-	fake.readArtifactMutex.Unlock()
+	stub := fake.ReadArtifactPartsStub
+	fakeReturns := fake.readArtifactPartsReturns
+	fake.recordInvocation("ReadArtifactParts", []interface{}{arg1})
+	fake.readArtifactPartsMutex.Unlock()
 	if stub != nil {
 		return stub(arg1)
 	}
@@ -756,47 +1203,47 @@ func (fake *FakeBackup) ReadArtifact(arg1 orchestrator.ArtifactIdentifier) (io.R
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeBackup) ReadArtifactCallCount() int {
-	fake.readArtifactMutex.RLock()
-	defer fake.readArtifactMutex.RUnlock()
-	return len(fake.readArtifactArgsForCall)
+func (fake *FakeBackup) ReadArtifactPartsCallCount() int {
+	fake.readArtifactPartsMutex.RLock()
+	defer fake.readArtifactPartsMutex.RUnlock()
+	return len(fake.readArtifactPartsArgsForCall)
 }
 
-func (fake *FakeBackup) ReadArtifactCalls(stub func(orchestrator.ArtifactIdentifier) (io.ReadCloser, error)) {
-	fake.readArtifactMutex.Lock()
-	defer fake.readArtifactMutex.Unlock()
-	fake.ReadArtifactStub = stub
+func (fake *FakeBackup) ReadArtifactPartsCalls(stub func(orchestrator.ArtifactIdentifier) ([]orchestrator.ArtifactPart, error)) {
+	fake.readArtifactPartsMutex.Lock()
+	defer fake.readArtifactPartsMutex.Unlock()
+	fake.ReadArtifactPartsStub = stub
 }
 
-func (fake *FakeBackup) ReadArtifactArgsForCall(i int) orchestrator.ArtifactIdentifier {
-	fake.readArtifactMutex.RLock()
-	defer fake.readArtifactMutex.RUnlock()
-	argsForCall := fake.readArtifactArgsForCall[i]
+func (fake *FakeBackup) ReadArtifactPartsArgsForCall(i int) orchestrator.ArtifactIdentifier {
+	fake.readArtifactPartsMutex.RLock()
+	defer fake.readArtifactPartsMutex.RUnlock()
+	argsForCall := fake.readArtifactPartsArgsForCall[i]
 	return argsForCall.arg1
 }
 
-func (fake *FakeBackup) ReadArtifactReturns(result1 io.ReadCloser, result2 error) {
-	fake.readArtifactMutex.Lock()
-	defer fake.readArtifactMutex.Unlock()
-	fake.ReadArtifactStub = nil
-	fake.readArtifactReturns = struct {
-		result1 io.ReadCloser
+func (fake *FakeBackup) ReadArtifactPartsReturns(result1 []orchestrator.ArtifactPart, result2 error) {
+	fake.readArtifactPartsMutex.Lock()
+	defer fake.readArtifactPartsMutex.Unlock()
+	fake.ReadArtifactPartsStub = nil
+	fake.readArtifactPartsReturns = struct {
+		result1 []orchestrator.ArtifactPart
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeBackup) ReadArtifactReturnsOnCall(i int, result1 io.ReadCloser, result2 error) {
-	fake.readArtifactMutex.Lock()
-	defer fake.readArtifactMutex.Unlock()
-	fake.ReadArtifactStub = nil
-	if fake.readArtifactReturnsOnCall == nil {
-		fake.readArtifactReturnsOnCall = make(map[int]struct {
-			result1 io.ReadCloser
+func (fake *FakeBackup) ReadArtifactPartsReturnsOnCall(i int, result1 []orchestrator.ArtifactPart, result2 error) {
+	fake.readArtifactPartsMutex.Lock()
+	defer fake.readArtifactPartsMutex.Unlock()
+	fake.ReadArtifactPartsStub = nil
+	if fake.readArtifactPartsReturnsOnCall == nil {
+		fake.readArtifactPartsReturnsOnCall = make(map[int]struct {
+			result1 []orchestrator.ArtifactPart
 			result2 error
 		})
 	}
-	fake.readArtifactReturnsOnCall[i] = struct {
-		result1 io.ReadCloser
+	fake.readArtifactPartsReturnsOnCall[i] = struct {
+		result1 []orchestrator.ArtifactPart
 		result2 error
 	}{result1, result2}
 }
@@ -921,26 +1368,38 @@ func (fake *FakeBackup) ValidReturnsOnCall(i int, result1 bool, result2 error) {
 func (fake *FakeBackup) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.addArtifactTransferMutex.RLock()
+	defer fake.addArtifactTransferMutex.RUnlock()
 	fake.addChecksumMutex.RLock()
 	defer fake.addChecksumMutex.RUnlock()
 	fake.addFinishTimeMutex.RLock()
 	defer fake.addFinishTimeMutex.RUnlock()
+	fake.addOriginMutex.RLock()
+	defer fake.addOriginMutex.RUnlock()
+	fake.addPhaseTimingsMutex.RLock()
+	defer fake.addPhaseTimingsMutex.RUnlock()
 	fake.calculateChecksumMutex.RLock()
 	defer fake.calculateChecksumMutex.RUnlock()
 	fake.createArtifactMutex.RLock()
 	defer fake.createArtifactMutex.RUnlock()
+	fake.createArtifactPartMutex.RLock()
+	defer fake.createArtifactPartMutex.RUnlock()
 	fake.createMetadataFileWithStartTimeMutex.RLock()
 	defer fake.createMetadataFileWithStartTimeMutex.RUnlock()
 	fake.deploymentMatchesMutex.RLock()
 	defer fake.deploymentMatchesMutex.RUnlock()
+	fake.fetchArtifactSizeInBytesMutex.RLock()
+	defer fake.fetchArtifactSizeInBytesMutex.RUnlock()
 	fake.fetchChecksumMutex.RLock()
 	defer fake.fetchChecksumMutex.RUnlock()
+	fake.fetchOriginMutex.RLock()
+	defer fake.fetchOriginMutex.RUnlock()
 	fake.getArtifactByteSizeMutex.RLock()
 	defer fake.getArtifactByteSizeMutex.RUnlock()
 	fake.getArtifactSizeMutex.RLock()
 	defer fake.getArtifactSizeMutex.RUnlock()
-	fake.readArtifactMutex.RLock()
-	defer fake.readArtifactMutex.RUnlock()
+	fake.readArtifactPartsMutex.RLock()
+	defer fake.readArtifactPartsMutex.RUnlock()
 	fake.saveManifestMutex.RLock()
 	defer fake.saveManifestMutex.RUnlock()
 	fake.validMutex.RLock()
@@ -2,6 +2,7 @@
 package fakes
 
 import (
+	"context"
 	"io"
 	"sync"
 
@@ -9,9 +10,10 @@ import (
 )
 
 type FakeBackupArtifact struct {
-	ChecksumStub        func() (orchestrator.BackupChecksum, error)
+	ChecksumStub        func(context.Context) (orchestrator.BackupChecksum, error)
 	checksumMutex       sync.RWMutex
 	checksumArgsForCall []struct {
+		arg1 context.Context
 	}
 	checksumReturns struct {
 		result1 orchestrator.BackupChecksum
@@ -21,9 +23,10 @@ type FakeBackupArtifact struct {
 		result1 orchestrator.BackupChecksum
 		result2 error
 	}
-	DeleteStub        func() error
+	DeleteStub        func(context.Context) error
 	deleteMutex       sync.RWMutex
 	deleteArgsForCall []struct {
+		arg1 context.Context
 	}
 	deleteReturns struct {
 		result1 error
@@ -31,6 +34,19 @@ type FakeBackupArtifact struct {
 	deleteReturnsOnCall map[int]struct {
 		result1 error
 	}
+	FileSizesInBytesStub        func(context.Context) (map[string]int, error)
+	fileSizesInBytesMutex       sync.RWMutex
+	fileSizesInBytesArgsForCall []struct {
+		arg1 context.Context
+	}
+	fileSizesInBytesReturns struct {
+		result1 map[string]int
+		result2 error
+	}
+	fileSizesInBytesReturnsOnCall map[int]struct {
+		result1 map[string]int
+		result2 error
+	}
 	HasCustomNameStub        func() bool
 	hasCustomNameMutex       sync.RWMutex
 	hasCustomNameArgsForCall []struct {
@@ -81,9 +97,10 @@ type FakeBackupArtifact struct {
 	nameReturnsOnCall map[int]struct {
 		result1 string
 	}
-	SizeStub        func() (string, error)
+	SizeStub        func(context.Context) (string, error)
 	sizeMutex       sync.RWMutex
 	sizeArgsForCall []struct {
+		arg1 context.Context
 	}
 	sizeReturns struct {
 		result1 string
@@ -93,9 +110,10 @@ type FakeBackupArtifact struct {
 		result1 string
 		result2 error
 	}
-	SizeInBytesStub        func() (int, error)
+	SizeInBytesStub        func(context.Context) (int, error)
 	sizeInBytesMutex       sync.RWMutex
 	sizeInBytesArgsForCall []struct {
+		arg1 context.Context
 	}
 	sizeInBytesReturns struct {
 		result1 int
@@ -105,10 +123,24 @@ type FakeBackupArtifact struct {
 		result1 int
 		result2 error
 	}
-	StreamFromRemoteStub        func(io.Writer) error
+	StreamFilesFromRemoteStub        func(context.Context, []string, io.Writer) error
+	streamFilesFromRemoteMutex       sync.RWMutex
+	streamFilesFromRemoteArgsForCall []struct {
+		arg1 context.Context
+		arg2 []string
+		arg3 io.Writer
+	}
+	streamFilesFromRemoteReturns struct {
+		result1 error
+	}
+	streamFilesFromRemoteReturnsOnCall map[int]struct {
+		result1 error
+	}
+	StreamFromRemoteStub        func(context.Context, io.Writer) error
 	streamFromRemoteMutex       sync.RWMutex
 	streamFromRemoteArgsForCall []struct {
-		arg1 io.Writer
+		arg1 context.Context
+		arg2 io.Writer
 	}
 	streamFromRemoteReturns struct {
 		result1 error
@@ -116,10 +148,11 @@ type FakeBackupArtifact struct {
 	streamFromRemoteReturnsOnCall map[int]struct {
 		result1 error
 	}
-	StreamToRemoteStub        func(io.Reader) error
+	StreamToRemoteStub        func(context.Context, io.Reader) error
 	streamToRemoteMutex       sync.RWMutex
 	streamToRemoteArgsForCall []struct {
-		arg1 io.Reader
+		arg1 context.Context
+		arg2 io.Reader
 	}
 	streamToRemoteReturns struct {
 		result1 error
@@ -131,17 +164,18 @@ type FakeBackupArtifact struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeBackupArtifact) Checksum() (orchestrator.BackupChecksum, error) {
+func (fake *FakeBackupArtifact) Checksum(arg1 context.Context) (orchestrator.BackupChecksum, error) {
 	fake.checksumMutex.Lock()
 	ret, specificReturn := fake.checksumReturnsOnCall[len(fake.checksumArgsForCall)]
 	fake.checksumArgsForCall = append(fake.checksumArgsForCall, struct {
-	}{})
+		arg1 context.Context
+	}{arg1})
 	stub := fake.ChecksumStub
 	fakeReturns := fake.checksumReturns
-	fake.recordInvocation("Checksum", []interface{}{})
+	fake.recordInvocation("Checksum", []interface{}{arg1})
 	fake.checksumMutex.Unlock()
 	if stub != nil {
-		return stub()
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -155,12 +189,19 @@ func (fake *FakeBackupArtifact) ChecksumCallCount() int {
 	return len(fake.checksumArgsForCall)
 }
 
-func (fake *FakeBackupArtifact) ChecksumCalls(stub func() (orchestrator.BackupChecksum, error)) {
+func (fake *FakeBackupArtifact) ChecksumCalls(stub func(context.Context) (orchestrator.BackupChecksum, error)) {
 	fake.checksumMutex.Lock()
 	defer fake.checksumMutex.Unlock()
 	fake.ChecksumStub = stub
 }
 
+func (fake *FakeBackupArtifact) ChecksumArgsForCall(i int) context.Context {
+	fake.checksumMutex.RLock()
+	defer fake.checksumMutex.RUnlock()
+	argsForCall := fake.checksumArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeBackupArtifact) ChecksumReturns(result1 orchestrator.BackupChecksum, result2 error) {
 	fake.checksumMutex.Lock()
 	defer fake.checksumMutex.Unlock()
@@ -187,17 +228,18 @@ func (fake *FakeBackupArtifact) ChecksumReturnsOnCall(i int, result1 orchestrato
 	}{result1, result2}
 }
 
-func (fake *FakeBackupArtifact) Delete() error {
+func (fake *FakeBackupArtifact) Delete(arg1 context.Context) error {
 	fake.deleteMutex.Lock()
 	ret, specificReturn := fake.deleteReturnsOnCall[len(fake.deleteArgsForCall)]
 	fake.deleteArgsForCall = append(fake.deleteArgsForCall, struct {
-	}{})
+		arg1 context.Context
+	}{arg1})
 	stub := fake.DeleteStub
 	fakeReturns := fake.deleteReturns
-	fake.recordInvocation("Delete", []interface{}{})
+	fake.recordInvocation("Delete", []interface{}{arg1})
 	fake.deleteMutex.Unlock()
 	if stub != nil {
-		return stub()
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
@@ -211,12 +253,19 @@ func (fake *FakeBackupArtifact) DeleteCallCount() int {
 	return len(fake.deleteArgsForCall)
 }
 
-func (fake *FakeBackupArtifact) DeleteCalls(stub func() error) {
+func (fake *FakeBackupArtifact) DeleteCalls(stub func(context.Context) error) {
 	fake.deleteMutex.Lock()
 	defer fake.deleteMutex.Unlock()
 	fake.DeleteStub = stub
 }
 
+func (fake *FakeBackupArtifact) DeleteArgsForCall(i int) context.Context {
+	fake.deleteMutex.RLock()
+	defer fake.deleteMutex.RUnlock()
+	argsForCall := fake.deleteArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeBackupArtifact) DeleteReturns(result1 error) {
 	fake.deleteMutex.Lock()
 	defer fake.deleteMutex.Unlock()
@@ -240,6 +289,70 @@ func (fake *FakeBackupArtifact) DeleteReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeBackupArtifact) FileSizesInBytes(arg1 context.Context) (map[string]int, error) {
+	fake.fileSizesInBytesMutex.Lock()
+	ret, specificReturn := fake.fileSizesInBytesReturnsOnCall[len(fake.fileSizesInBytesArgsForCall)]
+	fake.fileSizesInBytesArgsForCall = append(fake.fileSizesInBytesArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.FileSizesInBytesStub
+	fakeReturns := fake.fileSizesInBytesReturns
+	fake.recordInvocation("FileSizesInBytes", []interface{}{arg1})
+	fake.fileSizesInBytesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBackupArtifact) FileSizesInBytesCallCount() int {
+	fake.fileSizesInBytesMutex.RLock()
+	defer fake.fileSizesInBytesMutex.RUnlock()
+	return len(fake.fileSizesInBytesArgsForCall)
+}
+
+func (fake *FakeBackupArtifact) FileSizesInBytesCalls(stub func(context.Context) (map[string]int, error)) {
+	fake.fileSizesInBytesMutex.Lock()
+	defer fake.fileSizesInBytesMutex.Unlock()
+	fake.FileSizesInBytesStub = stub
+}
+
+func (fake *FakeBackupArtifact) FileSizesInBytesArgsForCall(i int) context.Context {
+	fake.fileSizesInBytesMutex.RLock()
+	defer fake.fileSizesInBytesMutex.RUnlock()
+	argsForCall := fake.fileSizesInBytesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBackupArtifact) FileSizesInBytesReturns(result1 map[string]int, result2 error) {
+	fake.fileSizesInBytesMutex.Lock()
+	defer fake.fileSizesInBytesMutex.Unlock()
+	fake.FileSizesInBytesStub = nil
+	fake.fileSizesInBytesReturns = struct {
+		result1 map[string]int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBackupArtifact) FileSizesInBytesReturnsOnCall(i int, result1 map[string]int, result2 error) {
+	fake.fileSizesInBytesMutex.Lock()
+	defer fake.fileSizesInBytesMutex.Unlock()
+	fake.FileSizesInBytesStub = nil
+	if fake.fileSizesInBytesReturnsOnCall == nil {
+		fake.fileSizesInBytesReturnsOnCall = make(map[int]struct {
+			result1 map[string]int
+			result2 error
+		})
+	}
+	fake.fileSizesInBytesReturnsOnCall[i] = struct {
+		result1 map[string]int
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBackupArtifact) HasCustomName() bool {
 	fake.hasCustomNameMutex.Lock()
 	ret, specificReturn := fake.hasCustomNameReturnsOnCall[len(fake.hasCustomNameArgsForCall)]
@@ -505,17 +618,18 @@ func (fake *FakeBackupArtifact) NameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
-func (fake *FakeBackupArtifact) Size() (string, error) {
+func (fake *FakeBackupArtifact) Size(arg1 context.Context) (string, error) {
 	fake.sizeMutex.Lock()
 	ret, specificReturn := fake.sizeReturnsOnCall[len(fake.sizeArgsForCall)]
 	fake.sizeArgsForCall = append(fake.sizeArgsForCall, struct {
-	}{})
+		arg1 context.Context
+	}{arg1})
 	stub := fake.SizeStub
 	fakeReturns := fake.sizeReturns
-	fake.recordInvocation("Size", []interface{}{})
+	fake.recordInvocation("Size", []interface{}{arg1})
 	fake.sizeMutex.Unlock()
 	if stub != nil {
-		return stub()
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -529,12 +643,19 @@ func (fake *FakeBackupArtifact) SizeCallCount() int {
 	return len(fake.sizeArgsForCall)
 }
 
-func (fake *FakeBackupArtifact) SizeCalls(stub func() (string, error)) {
+func (fake *FakeBackupArtifact) SizeCalls(stub func(context.Context) (string, error)) {
 	fake.sizeMutex.Lock()
 	defer fake.sizeMutex.Unlock()
 	fake.SizeStub = stub
 }
 
+func (fake *FakeBackupArtifact) SizeArgsForCall(i int) context.Context {
+	fake.sizeMutex.RLock()
+	defer fake.sizeMutex.RUnlock()
+	argsForCall := fake.sizeArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeBackupArtifact) SizeReturns(result1 string, result2 error) {
 	fake.sizeMutex.Lock()
 	defer fake.sizeMutex.Unlock()
@@ -561,17 +682,18 @@ func (fake *FakeBackupArtifact) SizeReturnsOnCall(i int, result1 string, result2
 	}{result1, result2}
 }
 
-func (fake *FakeBackupArtifact) SizeInBytes() (int, error) {
+func (fake *FakeBackupArtifact) SizeInBytes(arg1 context.Context) (int, error) {
 	fake.sizeInBytesMutex.Lock()
 	ret, specificReturn := fake.sizeInBytesReturnsOnCall[len(fake.sizeInBytesArgsForCall)]
 	fake.sizeInBytesArgsForCall = append(fake.sizeInBytesArgsForCall, struct {
-	}{})
+		arg1 context.Context
+	}{arg1})
 	stub := fake.SizeInBytesStub
 	fakeReturns := fake.sizeInBytesReturns
-	fake.recordInvocation("SizeInBytes", []interface{}{})
+	fake.recordInvocation("SizeInBytes", []interface{}{arg1})
 	fake.sizeInBytesMutex.Unlock()
 	if stub != nil {
-		return stub()
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -585,12 +707,19 @@ func (fake *FakeBackupArtifact) SizeInBytesCallCount() int {
 	return len(fake.sizeInBytesArgsForCall)
 }
 
-func (fake *FakeBackupArtifact) SizeInBytesCalls(stub func() (int, error)) {
+func (fake *FakeBackupArtifact) SizeInBytesCalls(stub func(context.Context) (int, error)) {
 	fake.sizeInBytesMutex.Lock()
 	defer fake.sizeInBytesMutex.Unlock()
 	fake.SizeInBytesStub = stub
 }
 
+func (fake *FakeBackupArtifact) SizeInBytesArgsForCall(i int) context.Context {
+	fake.sizeInBytesMutex.RLock()
+	defer fake.sizeInBytesMutex.RUnlock()
+	argsForCall := fake.sizeInBytesArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeBackupArtifact) SizeInBytesReturns(result1 int, result2 error) {
 	fake.sizeInBytesMutex.Lock()
 	defer fake.sizeInBytesMutex.Unlock()
@@ -617,18 +746,87 @@ func (fake *FakeBackupArtifact) SizeInBytesReturnsOnCall(i int, result1 int, res
 	}{result1, result2}
 }
 
-func (fake *FakeBackupArtifact) StreamFromRemote(arg1 io.Writer) error {
+func (fake *FakeBackupArtifact) StreamFilesFromRemote(arg1 context.Context, arg2 []string, arg3 io.Writer) error {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.streamFilesFromRemoteMutex.Lock()
+	ret, specificReturn := fake.streamFilesFromRemoteReturnsOnCall[len(fake.streamFilesFromRemoteArgsForCall)]
+	fake.streamFilesFromRemoteArgsForCall = append(fake.streamFilesFromRemoteArgsForCall, struct {
+		arg1 context.Context
+		arg2 []string
+		arg3 io.Writer
+	}{arg1, arg2Copy, arg3})
+	stub := fake.StreamFilesFromRemoteStub
+	fakeReturns := fake.streamFilesFromRemoteReturns
+	fake.recordInvocation("StreamFilesFromRemote", []interface{}{arg1, arg2Copy, arg3})
+	fake.streamFilesFromRemoteMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBackupArtifact) StreamFilesFromRemoteCallCount() int {
+	fake.streamFilesFromRemoteMutex.RLock()
+	defer fake.streamFilesFromRemoteMutex.RUnlock()
+	return len(fake.streamFilesFromRemoteArgsForCall)
+}
+
+func (fake *FakeBackupArtifact) StreamFilesFromRemoteCalls(stub func(context.Context, []string, io.Writer) error) {
+	fake.streamFilesFromRemoteMutex.Lock()
+	defer fake.streamFilesFromRemoteMutex.Unlock()
+	fake.StreamFilesFromRemoteStub = stub
+}
+
+func (fake *FakeBackupArtifact) StreamFilesFromRemoteArgsForCall(i int) (context.Context, []string, io.Writer) {
+	fake.streamFilesFromRemoteMutex.RLock()
+	defer fake.streamFilesFromRemoteMutex.RUnlock()
+	argsForCall := fake.streamFilesFromRemoteArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeBackupArtifact) StreamFilesFromRemoteReturns(result1 error) {
+	fake.streamFilesFromRemoteMutex.Lock()
+	defer fake.streamFilesFromRemoteMutex.Unlock()
+	fake.StreamFilesFromRemoteStub = nil
+	fake.streamFilesFromRemoteReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBackupArtifact) StreamFilesFromRemoteReturnsOnCall(i int, result1 error) {
+	fake.streamFilesFromRemoteMutex.Lock()
+	defer fake.streamFilesFromRemoteMutex.Unlock()
+	fake.StreamFilesFromRemoteStub = nil
+	if fake.streamFilesFromRemoteReturnsOnCall == nil {
+		fake.streamFilesFromRemoteReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.streamFilesFromRemoteReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBackupArtifact) StreamFromRemote(arg1 context.Context, arg2 io.Writer) error {
 	fake.streamFromRemoteMutex.Lock()
 	ret, specificReturn := fake.streamFromRemoteReturnsOnCall[len(fake.streamFromRemoteArgsForCall)]
 	fake.streamFromRemoteArgsForCall = append(fake.streamFromRemoteArgsForCall, struct {
-		arg1 io.Writer
-	}{arg1})
+		arg1 context.Context
+		arg2 io.Writer
+	}{arg1, arg2})
 	stub := fake.StreamFromRemoteStub
 	fakeReturns := fake.streamFromRemoteReturns
-	fake.recordInvocation("StreamFromRemote", []interface{}{arg1})
+	fake.recordInvocation("StreamFromRemote", []interface{}{arg1, arg2})
 	fake.streamFromRemoteMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
@@ -642,17 +840,17 @@ func (fake *FakeBackupArtifact) StreamFromRemoteCallCount() int {
 	return len(fake.streamFromRemoteArgsForCall)
 }
 
-func (fake *FakeBackupArtifact) StreamFromRemoteCalls(stub func(io.Writer) error) {
+func (fake *FakeBackupArtifact) StreamFromRemoteCalls(stub func(context.Context, io.Writer) error) {
 	fake.streamFromRemoteMutex.Lock()
 	defer fake.streamFromRemoteMutex.Unlock()
 	fake.StreamFromRemoteStub = stub
 }
 
-func (fake *FakeBackupArtifact) StreamFromRemoteArgsForCall(i int) io.Writer {
+func (fake *FakeBackupArtifact) StreamFromRemoteArgsForCall(i int) (context.Context, io.Writer) {
 	fake.streamFromRemoteMutex.RLock()
 	defer fake.streamFromRemoteMutex.RUnlock()
 	argsForCall := fake.streamFromRemoteArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeBackupArtifact) StreamFromRemoteReturns(result1 error) {
@@ -678,18 +876,19 @@ func (fake *FakeBackupArtifact) StreamFromRemoteReturnsOnCall(i int, result1 err
 	}{result1}
 }
 
-func (fake *FakeBackupArtifact) StreamToRemote(arg1 io.Reader) error {
+func (fake *FakeBackupArtifact) StreamToRemote(arg1 context.Context, arg2 io.Reader) error {
 	fake.streamToRemoteMutex.Lock()
 	ret, specificReturn := fake.streamToRemoteReturnsOnCall[len(fake.streamToRemoteArgsForCall)]
 	fake.streamToRemoteArgsForCall = append(fake.streamToRemoteArgsForCall, struct {
-		arg1 io.Reader
-	}{arg1})
+		arg1 context.Context
+		arg2 io.Reader
+	}{arg1, arg2})
 	stub := fake.StreamToRemoteStub
 	fakeReturns := fake.streamToRemoteReturns
-	fake.recordInvocation("StreamToRemote", []interface{}{arg1})
+	fake.recordInvocation("StreamToRemote", []interface{}{arg1, arg2})
 	fake.streamToRemoteMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
@@ -703,17 +902,17 @@ func (fake *FakeBackupArtifact) StreamToRemoteCallCount() int {
 	return len(fake.streamToRemoteArgsForCall)
 }
 
-func (fake *FakeBackupArtifact) StreamToRemoteCalls(stub func(io.Reader) error) {
+func (fake *FakeBackupArtifact) StreamToRemoteCalls(stub func(context.Context, io.Reader) error) {
 	fake.streamToRemoteMutex.Lock()
 	defer fake.streamToRemoteMutex.Unlock()
 	fake.StreamToRemoteStub = stub
 }
 
-func (fake *FakeBackupArtifact) StreamToRemoteArgsForCall(i int) io.Reader {
+func (fake *FakeBackupArtifact) StreamToRemoteArgsForCall(i int) (context.Context, io.Reader) {
 	fake.streamToRemoteMutex.RLock()
 	defer fake.streamToRemoteMutex.RUnlock()
 	argsForCall := fake.streamToRemoteArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeBackupArtifact) StreamToRemoteReturns(result1 error) {
@@ -746,6 +945,8 @@ func (fake *FakeBackupArtifact) Invocations() map[string][][]interface{} {
 	defer fake.checksumMutex.RUnlock()
 	fake.deleteMutex.RLock()
 	defer fake.deleteMutex.RUnlock()
+	fake.fileSizesInBytesMutex.RLock()
+	defer fake.fileSizesInBytesMutex.RUnlock()
 	fake.hasCustomNameMutex.RLock()
 	defer fake.hasCustomNameMutex.RUnlock()
 	fake.instanceIDMutex.RLock()
@@ -760,6 +961,8 @@ func (fake *FakeBackupArtifact) Invocations() map[string][][]interface{} {
 	defer fake.sizeMutex.RUnlock()
 	fake.sizeInBytesMutex.RLock()
 	defer fake.sizeInBytesMutex.RUnlock()
+	fake.streamFilesFromRemoteMutex.RLock()
+	defer fake.streamFilesFromRemoteMutex.RUnlock()
 	fake.streamFromRemoteMutex.RLock()
 	defer fake.streamFromRemoteMutex.RUnlock()
 	fake.streamToRemoteMutex.RLock()
@@ -2,6 +2,7 @@
 package fakes
 
 import (
+	"context"
 	"sync"
 
 	"github.com/cloudfoundry/bosh-backup-and-restore/executor"
@@ -9,10 +10,11 @@ import (
 )
 
 type FakeDeployment struct {
-	BackupStub        func(executor.Executor) error
+	BackupStub        func(context.Context, executor.Executor) error
 	backupMutex       sync.RWMutex
 	backupArgsForCall []struct {
-		arg1 executor.Executor
+		arg1 context.Context
+		arg2 executor.Executor
 	}
 	backupReturns struct {
 		result1 error
@@ -30,9 +32,10 @@ type FakeDeployment struct {
 	backupableInstancesReturnsOnCall map[int]struct {
 		result1 []orchestrator.Instance
 	}
-	CheckArtifactDirStub        func() error
+	CheckArtifactDirStub        func(context.Context) error
 	checkArtifactDirMutex       sync.RWMutex
 	checkArtifactDirArgsForCall []struct {
+		arg1 context.Context
 	}
 	checkArtifactDirReturns struct {
 		result1 error
@@ -40,9 +43,10 @@ type FakeDeployment struct {
 	checkArtifactDirReturnsOnCall map[int]struct {
 		result1 error
 	}
-	CleanupStub        func() error
+	CleanupStub        func(context.Context) error
 	cleanupMutex       sync.RWMutex
 	cleanupArgsForCall []struct {
+		arg1 context.Context
 	}
 	cleanupReturns struct {
 		result1 error
@@ -50,9 +54,10 @@ type FakeDeployment struct {
 	cleanupReturnsOnCall map[int]struct {
 		result1 error
 	}
-	CleanupPreviousStub        func() error
+	CleanupPreviousStub        func(context.Context) error
 	cleanupPreviousMutex       sync.RWMutex
 	cleanupPreviousArgsForCall []struct {
+		arg1 context.Context
 	}
 	cleanupPreviousReturns struct {
 		result1 error
@@ -90,12 +95,13 @@ type FakeDeployment struct {
 	isRestorableReturnsOnCall map[int]struct {
 		result1 bool
 	}
-	PostBackupUnlockStub        func(bool, orchestrator.LockOrderer, executor.Executor) error
+	PostBackupUnlockStub        func(context.Context, bool, orchestrator.LockOrderer, executor.Executor) error
 	postBackupUnlockMutex       sync.RWMutex
 	postBackupUnlockArgsForCall []struct {
-		arg1 bool
-		arg2 orchestrator.LockOrderer
-		arg3 executor.Executor
+		arg1 context.Context
+		arg2 bool
+		arg3 orchestrator.LockOrderer
+		arg4 executor.Executor
 	}
 	postBackupUnlockReturns struct {
 		result1 error
@@ -103,11 +109,12 @@ type FakeDeployment struct {
 	postBackupUnlockReturnsOnCall map[int]struct {
 		result1 error
 	}
-	PostRestoreUnlockStub        func(orchestrator.LockOrderer, executor.Executor) error
+	PostRestoreUnlockStub        func(context.Context, orchestrator.LockOrderer, executor.Executor) error
 	postRestoreUnlockMutex       sync.RWMutex
 	postRestoreUnlockArgsForCall []struct {
-		arg1 orchestrator.LockOrderer
-		arg2 executor.Executor
+		arg1 context.Context
+		arg2 orchestrator.LockOrderer
+		arg3 executor.Executor
 	}
 	postRestoreUnlockReturns struct {
 		result1 error
@@ -115,11 +122,12 @@ type FakeDeployment struct {
 	postRestoreUnlockReturnsOnCall map[int]struct {
 		result1 error
 	}
-	PreBackupLockStub        func(orchestrator.LockOrderer, executor.Executor) error
+	PreBackupLockStub        func(context.Context, orchestrator.LockOrderer, executor.Executor) error
 	preBackupLockMutex       sync.RWMutex
 	preBackupLockArgsForCall []struct {
-		arg1 orchestrator.LockOrderer
-		arg2 executor.Executor
+		arg1 context.Context
+		arg2 orchestrator.LockOrderer
+		arg3 executor.Executor
 	}
 	preBackupLockReturns struct {
 		result1 error
@@ -127,11 +135,12 @@ type FakeDeployment struct {
 	preBackupLockReturnsOnCall map[int]struct {
 		result1 error
 	}
-	PreRestoreLockStub        func(orchestrator.LockOrderer, executor.Executor) error
+	PreRestoreLockStub        func(context.Context, orchestrator.LockOrderer, executor.Executor) error
 	preRestoreLockMutex       sync.RWMutex
 	preRestoreLockArgsForCall []struct {
-		arg1 orchestrator.LockOrderer
-		arg2 executor.Executor
+		arg1 context.Context
+		arg2 orchestrator.LockOrderer
+		arg3 executor.Executor
 	}
 	preRestoreLockReturns struct {
 		result1 error
@@ -149,9 +158,10 @@ type FakeDeployment struct {
 	restorableInstancesReturnsOnCall map[int]struct {
 		result1 []orchestrator.Instance
 	}
-	RestoreStub        func() error
+	RestoreStub        func(context.Context) error
 	restoreMutex       sync.RWMutex
 	restoreArgsForCall []struct {
+		arg1 context.Context
 	}
 	restoreReturns struct {
 		result1 error
@@ -159,6 +169,16 @@ type FakeDeployment struct {
 	restoreReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ValidateBackupOneRestoreAllSourcesStub        func() error
+	validateBackupOneRestoreAllSourcesMutex       sync.RWMutex
+	validateBackupOneRestoreAllSourcesArgsForCall []struct {
+	}
+	validateBackupOneRestoreAllSourcesReturns struct {
+		result1 error
+	}
+	validateBackupOneRestoreAllSourcesReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ValidateLockingDependenciesStub        func(orchestrator.LockOrderer) error
 	validateLockingDependenciesMutex       sync.RWMutex
 	validateLockingDependenciesArgsForCall []struct {
@@ -174,18 +194,19 @@ type FakeDeployment struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeDeployment) Backup(arg1 executor.Executor) error {
+func (fake *FakeDeployment) Backup(arg1 context.Context, arg2 executor.Executor) error {
 	fake.backupMutex.Lock()
 	ret, specificReturn := fake.backupReturnsOnCall[len(fake.backupArgsForCall)]
 	fake.backupArgsForCall = append(fake.backupArgsForCall, struct {
-		arg1 executor.Executor
-	}{arg1})
+		arg1 context.Context
+		arg2 executor.Executor
+	}{arg1, arg2})
 	stub := fake.BackupStub
 	fakeReturns := fake.backupReturns
-	fake.recordInvocation("Backup", []interface{}{arg1})
+	fake.recordInvocation("Backup", []interface{}{arg1, arg2})
 	fake.backupMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
@@ -199,17 +220,17 @@ func (fake *FakeDeployment) BackupCallCount() int {
 	return len(fake.backupArgsForCall)
 }
 
-func (fake *FakeDeployment) BackupCalls(stub func(executor.Executor) error) {
+func (fake *FakeDeployment) BackupCalls(stub func(context.Context, executor.Executor) error) {
 	fake.backupMutex.Lock()
 	defer fake.backupMutex.Unlock()
 	fake.BackupStub = stub
 }
 
-func (fake *FakeDeployment) BackupArgsForCall(i int) executor.Executor {
+func (fake *FakeDeployment) BackupArgsForCall(i int) (context.Context, executor.Executor) {
 	fake.backupMutex.RLock()
 	defer fake.backupMutex.RUnlock()
 	argsForCall := fake.backupArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeDeployment) BackupReturns(result1 error) {
@@ -288,17 +309,18 @@ func (fake *FakeDeployment) BackupableInstancesReturnsOnCall(i int, result1 []or
 	}{result1}
 }
 
-func (fake *FakeDeployment) CheckArtifactDir() error {
+func (fake *FakeDeployment) CheckArtifactDir(arg1 context.Context) error {
 	fake.checkArtifactDirMutex.Lock()
 	ret, specificReturn := fake.checkArtifactDirReturnsOnCall[len(fake.checkArtifactDirArgsForCall)]
 	fake.checkArtifactDirArgsForCall = append(fake.checkArtifactDirArgsForCall, struct {
-	}{})
+		arg1 context.Context
+	}{arg1})
 	stub := fake.CheckArtifactDirStub
 	fakeReturns := fake.checkArtifactDirReturns
-	fake.recordInvocation("CheckArtifactDir", []interface{}{})
+	fake.recordInvocation("CheckArtifactDir", []interface{}{arg1})
 	fake.checkArtifactDirMutex.Unlock()
 	if stub != nil {
-		return stub()
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
@@ -312,12 +334,19 @@ func (fake *FakeDeployment) CheckArtifactDirCallCount() int {
 	return len(fake.checkArtifactDirArgsForCall)
 }
 
-func (fake *FakeDeployment) CheckArtifactDirCalls(stub func() error) {
+func (fake *FakeDeployment) CheckArtifactDirCalls(stub func(context.Context) error) {
 	fake.checkArtifactDirMutex.Lock()
 	defer fake.checkArtifactDirMutex.Unlock()
 	fake.CheckArtifactDirStub = stub
 }
 
+func (fake *FakeDeployment) CheckArtifactDirArgsForCall(i int) context.Context {
+	fake.checkArtifactDirMutex.RLock()
+	defer fake.checkArtifactDirMutex.RUnlock()
+	argsForCall := fake.checkArtifactDirArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeDeployment) CheckArtifactDirReturns(result1 error) {
 	fake.checkArtifactDirMutex.Lock()
 	defer fake.checkArtifactDirMutex.Unlock()
@@ -341,17 +370,18 @@ func (fake *FakeDeployment) CheckArtifactDirReturnsOnCall(i int, result1 error)
 	}{result1}
 }
 
-func (fake *FakeDeployment) Cleanup() error {
+func (fake *FakeDeployment) Cleanup(arg1 context.Context) error {
 	fake.cleanupMutex.Lock()
 	ret, specificReturn := fake.cleanupReturnsOnCall[len(fake.cleanupArgsForCall)]
 	fake.cleanupArgsForCall = append(fake.cleanupArgsForCall, struct {
-	}{})
+		arg1 context.Context
+	}{arg1})
 	stub := fake.CleanupStub
 	fakeReturns := fake.cleanupReturns
-	fake.recordInvocation("Cleanup", []interface{}{})
+	fake.recordInvocation("Cleanup", []interface{}{arg1})
 	fake.cleanupMutex.Unlock()
 	if stub != nil {
-		return stub()
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
@@ -365,12 +395,19 @@ func (fake *FakeDeployment) CleanupCallCount() int {
 	return len(fake.cleanupArgsForCall)
 }
 
-func (fake *FakeDeployment) CleanupCalls(stub func() error) {
+func (fake *FakeDeployment) CleanupCalls(stub func(context.Context) error) {
 	fake.cleanupMutex.Lock()
 	defer fake.cleanupMutex.Unlock()
 	fake.CleanupStub = stub
 }
 
+func (fake *FakeDeployment) CleanupArgsForCall(i int) context.Context {
+	fake.cleanupMutex.RLock()
+	defer fake.cleanupMutex.RUnlock()
+	argsForCall := fake.cleanupArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeDeployment) CleanupReturns(result1 error) {
 	fake.cleanupMutex.Lock()
 	defer fake.cleanupMutex.Unlock()
@@ -394,17 +431,18 @@ func (fake *FakeDeployment) CleanupReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeDeployment) CleanupPrevious() error {
+func (fake *FakeDeployment) CleanupPrevious(arg1 context.Context) error {
 	fake.cleanupPreviousMutex.Lock()
 	ret, specificReturn := fake.cleanupPreviousReturnsOnCall[len(fake.cleanupPreviousArgsForCall)]
 	fake.cleanupPreviousArgsForCall = append(fake.cleanupPreviousArgsForCall, struct {
-	}{})
+		arg1 context.Context
+	}{arg1})
 	stub := fake.CleanupPreviousStub
 	fakeReturns := fake.cleanupPreviousReturns
-	fake.recordInvocation("CleanupPrevious", []interface{}{})
+	fake.recordInvocation("CleanupPrevious", []interface{}{arg1})
 	fake.cleanupPreviousMutex.Unlock()
 	if stub != nil {
-		return stub()
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
@@ -418,12 +456,19 @@ func (fake *FakeDeployment) CleanupPreviousCallCount() int {
 	return len(fake.cleanupPreviousArgsForCall)
 }
 
-func (fake *FakeDeployment) CleanupPreviousCalls(stub func() error) {
+func (fake *FakeDeployment) CleanupPreviousCalls(stub func(context.Context) error) {
 	fake.cleanupPreviousMutex.Lock()
 	defer fake.cleanupPreviousMutex.Unlock()
 	fake.CleanupPreviousStub = stub
 }
 
+func (fake *FakeDeployment) CleanupPreviousArgsForCall(i int) context.Context {
+	fake.cleanupPreviousMutex.RLock()
+	defer fake.cleanupPreviousMutex.RUnlock()
+	argsForCall := fake.cleanupPreviousArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeDeployment) CleanupPreviousReturns(result1 error) {
 	fake.cleanupPreviousMutex.Lock()
 	defer fake.cleanupPreviousMutex.Unlock()
@@ -606,20 +651,21 @@ func (fake *FakeDeployment) IsRestorableReturnsOnCall(i int, result1 bool) {
 	}{result1}
 }
 
-func (fake *FakeDeployment) PostBackupUnlock(arg1 bool, arg2 orchestrator.LockOrderer, arg3 executor.Executor) error {
+func (fake *FakeDeployment) PostBackupUnlock(arg1 context.Context, arg2 bool, arg3 orchestrator.LockOrderer, arg4 executor.Executor) error {
 	fake.postBackupUnlockMutex.Lock()
 	ret, specificReturn := fake.postBackupUnlockReturnsOnCall[len(fake.postBackupUnlockArgsForCall)]
 	fake.postBackupUnlockArgsForCall = append(fake.postBackupUnlockArgsForCall, struct {
-		arg1 bool
-		arg2 orchestrator.LockOrderer
-		arg3 executor.Executor
-	}{arg1, arg2, arg3})
+		arg1 context.Context
+		arg2 bool
+		arg3 orchestrator.LockOrderer
+		arg4 executor.Executor
+	}{arg1, arg2, arg3, arg4})
 	stub := fake.PostBackupUnlockStub
 	fakeReturns := fake.postBackupUnlockReturns
-	fake.recordInvocation("PostBackupUnlock", []interface{}{arg1, arg2, arg3})
+	fake.recordInvocation("PostBackupUnlock", []interface{}{arg1, arg2, arg3, arg4})
 	fake.postBackupUnlockMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3)
+		return stub(arg1, arg2, arg3, arg4)
 	}
 	if specificReturn {
 		return ret.result1
@@ -633,17 +679,17 @@ func (fake *FakeDeployment) PostBackupUnlockCallCount() int {
 	return len(fake.postBackupUnlockArgsForCall)
 }
 
-func (fake *FakeDeployment) PostBackupUnlockCalls(stub func(bool, orchestrator.LockOrderer, executor.Executor) error) {
+func (fake *FakeDeployment) PostBackupUnlockCalls(stub func(context.Context, bool, orchestrator.LockOrderer, executor.Executor) error) {
 	fake.postBackupUnlockMutex.Lock()
 	defer fake.postBackupUnlockMutex.Unlock()
 	fake.PostBackupUnlockStub = stub
 }
 
-func (fake *FakeDeployment) PostBackupUnlockArgsForCall(i int) (bool, orchestrator.LockOrderer, executor.Executor) {
+func (fake *FakeDeployment) PostBackupUnlockArgsForCall(i int) (context.Context, bool, orchestrator.LockOrderer, executor.Executor) {
 	fake.postBackupUnlockMutex.RLock()
 	defer fake.postBackupUnlockMutex.RUnlock()
 	argsForCall := fake.postBackupUnlockArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
 }
 
 func (fake *FakeDeployment) PostBackupUnlockReturns(result1 error) {
@@ -669,19 +715,20 @@ func (fake *FakeDeployment) PostBackupUnlockReturnsOnCall(i int, result1 error)
 	}{result1}
 }
 
-func (fake *FakeDeployment) PostRestoreUnlock(arg1 orchestrator.LockOrderer, arg2 executor.Executor) error {
+func (fake *FakeDeployment) PostRestoreUnlock(arg1 context.Context, arg2 orchestrator.LockOrderer, arg3 executor.Executor) error {
 	fake.postRestoreUnlockMutex.Lock()
 	ret, specificReturn := fake.postRestoreUnlockReturnsOnCall[len(fake.postRestoreUnlockArgsForCall)]
 	fake.postRestoreUnlockArgsForCall = append(fake.postRestoreUnlockArgsForCall, struct {
-		arg1 orchestrator.LockOrderer
-		arg2 executor.Executor
-	}{arg1, arg2})
+		arg1 context.Context
+		arg2 orchestrator.LockOrderer
+		arg3 executor.Executor
+	}{arg1, arg2, arg3})
 	stub := fake.PostRestoreUnlockStub
 	fakeReturns := fake.postRestoreUnlockReturns
-	fake.recordInvocation("PostRestoreUnlock", []interface{}{arg1, arg2})
+	fake.recordInvocation("PostRestoreUnlock", []interface{}{arg1, arg2, arg3})
 	fake.postRestoreUnlockMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1
@@ -695,17 +742,17 @@ func (fake *FakeDeployment) PostRestoreUnlockCallCount() int {
 	return len(fake.postRestoreUnlockArgsForCall)
 }
 
-func (fake *FakeDeployment) PostRestoreUnlockCalls(stub func(orchestrator.LockOrderer, executor.Executor) error) {
+func (fake *FakeDeployment) PostRestoreUnlockCalls(stub func(context.Context, orchestrator.LockOrderer, executor.Executor) error) {
 	fake.postRestoreUnlockMutex.Lock()
 	defer fake.postRestoreUnlockMutex.Unlock()
 	fake.PostRestoreUnlockStub = stub
 }
 
-func (fake *FakeDeployment) PostRestoreUnlockArgsForCall(i int) (orchestrator.LockOrderer, executor.Executor) {
+func (fake *FakeDeployment) PostRestoreUnlockArgsForCall(i int) (context.Context, orchestrator.LockOrderer, executor.Executor) {
 	fake.postRestoreUnlockMutex.RLock()
 	defer fake.postRestoreUnlockMutex.RUnlock()
 	argsForCall := fake.postRestoreUnlockArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeDeployment) PostRestoreUnlockReturns(result1 error) {
@@ -731,19 +778,20 @@ func (fake *FakeDeployment) PostRestoreUnlockReturnsOnCall(i int, result1 error)
 	}{result1}
 }
 
-func (fake *FakeDeployment) PreBackupLock(arg1 orchestrator.LockOrderer, arg2 executor.Executor) error {
+func (fake *FakeDeployment) PreBackupLock(arg1 context.Context, arg2 orchestrator.LockOrderer, arg3 executor.Executor) error {
 	fake.preBackupLockMutex.Lock()
 	ret, specificReturn := fake.preBackupLockReturnsOnCall[len(fake.preBackupLockArgsForCall)]
 	fake.preBackupLockArgsForCall = append(fake.preBackupLockArgsForCall, struct {
-		arg1 orchestrator.LockOrderer
-		arg2 executor.Executor
-	}{arg1, arg2})
+		arg1 context.Context
+		arg2 orchestrator.LockOrderer
+		arg3 executor.Executor
+	}{arg1, arg2, arg3})
 	stub := fake.PreBackupLockStub
 	fakeReturns := fake.preBackupLockReturns
-	fake.recordInvocation("PreBackupLock", []interface{}{arg1, arg2})
+	fake.recordInvocation("PreBackupLock", []interface{}{arg1, arg2, arg3})
 	fake.preBackupLockMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1
@@ -757,17 +805,17 @@ func (fake *FakeDeployment) PreBackupLockCallCount() int {
 	return len(fake.preBackupLockArgsForCall)
 }
 
-func (fake *FakeDeployment) PreBackupLockCalls(stub func(orchestrator.LockOrderer, executor.Executor) error) {
+func (fake *FakeDeployment) PreBackupLockCalls(stub func(context.Context, orchestrator.LockOrderer, executor.Executor) error) {
 	fake.preBackupLockMutex.Lock()
 	defer fake.preBackupLockMutex.Unlock()
 	fake.PreBackupLockStub = stub
 }
 
-func (fake *FakeDeployment) PreBackupLockArgsForCall(i int) (orchestrator.LockOrderer, executor.Executor) {
+func (fake *FakeDeployment) PreBackupLockArgsForCall(i int) (context.Context, orchestrator.LockOrderer, executor.Executor) {
 	fake.preBackupLockMutex.RLock()
 	defer fake.preBackupLockMutex.RUnlock()
 	argsForCall := fake.preBackupLockArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeDeployment) PreBackupLockReturns(result1 error) {
@@ -793,19 +841,20 @@ func (fake *FakeDeployment) PreBackupLockReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeDeployment) PreRestoreLock(arg1 orchestrator.LockOrderer, arg2 executor.Executor) error {
+func (fake *FakeDeployment) PreRestoreLock(arg1 context.Context, arg2 orchestrator.LockOrderer, arg3 executor.Executor) error {
 	fake.preRestoreLockMutex.Lock()
 	ret, specificReturn := fake.preRestoreLockReturnsOnCall[len(fake.preRestoreLockArgsForCall)]
 	fake.preRestoreLockArgsForCall = append(fake.preRestoreLockArgsForCall, struct {
-		arg1 orchestrator.LockOrderer
-		arg2 executor.Executor
-	}{arg1, arg2})
+		arg1 context.Context
+		arg2 orchestrator.LockOrderer
+		arg3 executor.Executor
+	}{arg1, arg2, arg3})
 	stub := fake.PreRestoreLockStub
 	fakeReturns := fake.preRestoreLockReturns
-	fake.recordInvocation("PreRestoreLock", []interface{}{arg1, arg2})
+	fake.recordInvocation("PreRestoreLock", []interface{}{arg1, arg2, arg3})
 	fake.preRestoreLockMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1
@@ -819,17 +868,17 @@ func (fake *FakeDeployment) PreRestoreLockCallCount() int {
 	return len(fake.preRestoreLockArgsForCall)
 }
 
-func (fake *FakeDeployment) PreRestoreLockCalls(stub func(orchestrator.LockOrderer, executor.Executor) error) {
+func (fake *FakeDeployment) PreRestoreLockCalls(stub func(context.Context, orchestrator.LockOrderer, executor.Executor) error) {
 	fake.preRestoreLockMutex.Lock()
 	defer fake.preRestoreLockMutex.Unlock()
 	fake.PreRestoreLockStub = stub
 }
 
-func (fake *FakeDeployment) PreRestoreLockArgsForCall(i int) (orchestrator.LockOrderer, executor.Executor) {
+func (fake *FakeDeployment) PreRestoreLockArgsForCall(i int) (context.Context, orchestrator.LockOrderer, executor.Executor) {
 	fake.preRestoreLockMutex.RLock()
 	defer fake.preRestoreLockMutex.RUnlock()
 	argsForCall := fake.preRestoreLockArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeDeployment) PreRestoreLockReturns(result1 error) {
@@ -908,17 +957,18 @@ func (fake *FakeDeployment) RestorableInstancesReturnsOnCall(i int, result1 []or
 	}{result1}
 }
 
-func (fake *FakeDeployment) Restore() error {
+func (fake *FakeDeployment) Restore(arg1 context.Context) error {
 	fake.restoreMutex.Lock()
 	ret, specificReturn := fake.restoreReturnsOnCall[len(fake.restoreArgsForCall)]
 	fake.restoreArgsForCall = append(fake.restoreArgsForCall, struct {
-	}{})
+		arg1 context.Context
+	}{arg1})
 	stub := fake.RestoreStub
 	fakeReturns := fake.restoreReturns
-	fake.recordInvocation("Restore", []interface{}{})
+	fake.recordInvocation("Restore", []interface{}{arg1})
 	fake.restoreMutex.Unlock()
 	if stub != nil {
-		return stub()
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
@@ -932,12 +982,19 @@ func (fake *FakeDeployment) RestoreCallCount() int {
 	return len(fake.restoreArgsForCall)
 }
 
-func (fake *FakeDeployment) RestoreCalls(stub func() error) {
+func (fake *FakeDeployment) RestoreCalls(stub func(context.Context) error) {
 	fake.restoreMutex.Lock()
 	defer fake.restoreMutex.Unlock()
 	fake.RestoreStub = stub
 }
 
+func (fake *FakeDeployment) RestoreArgsForCall(i int) context.Context {
+	fake.restoreMutex.RLock()
+	defer fake.restoreMutex.RUnlock()
+	argsForCall := fake.restoreArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeDeployment) RestoreReturns(result1 error) {
 	fake.restoreMutex.Lock()
 	defer fake.restoreMutex.Unlock()
@@ -961,6 +1018,59 @@ func (fake *FakeDeployment) RestoreReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeDeployment) ValidateBackupOneRestoreAllSources() error {
+	fake.validateBackupOneRestoreAllSourcesMutex.Lock()
+	ret, specificReturn := fake.validateBackupOneRestoreAllSourcesReturnsOnCall[len(fake.validateBackupOneRestoreAllSourcesArgsForCall)]
+	fake.validateBackupOneRestoreAllSourcesArgsForCall = append(fake.validateBackupOneRestoreAllSourcesArgsForCall, struct {
+	}{})
+	stub := fake.ValidateBackupOneRestoreAllSourcesStub
+	fakeReturns := fake.validateBackupOneRestoreAllSourcesReturns
+	fake.recordInvocation("ValidateBackupOneRestoreAllSources", []interface{}{})
+	fake.validateBackupOneRestoreAllSourcesMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeDeployment) ValidateBackupOneRestoreAllSourcesCallCount() int {
+	fake.validateBackupOneRestoreAllSourcesMutex.RLock()
+	defer fake.validateBackupOneRestoreAllSourcesMutex.RUnlock()
+	return len(fake.validateBackupOneRestoreAllSourcesArgsForCall)
+}
+
+func (fake *FakeDeployment) ValidateBackupOneRestoreAllSourcesCalls(stub func() error) {
+	fake.validateBackupOneRestoreAllSourcesMutex.Lock()
+	defer fake.validateBackupOneRestoreAllSourcesMutex.Unlock()
+	fake.ValidateBackupOneRestoreAllSourcesStub = stub
+}
+
+func (fake *FakeDeployment) ValidateBackupOneRestoreAllSourcesReturns(result1 error) {
+	fake.validateBackupOneRestoreAllSourcesMutex.Lock()
+	defer fake.validateBackupOneRestoreAllSourcesMutex.Unlock()
+	fake.ValidateBackupOneRestoreAllSourcesStub = nil
+	fake.validateBackupOneRestoreAllSourcesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDeployment) ValidateBackupOneRestoreAllSourcesReturnsOnCall(i int, result1 error) {
+	fake.validateBackupOneRestoreAllSourcesMutex.Lock()
+	defer fake.validateBackupOneRestoreAllSourcesMutex.Unlock()
+	fake.ValidateBackupOneRestoreAllSourcesStub = nil
+	if fake.validateBackupOneRestoreAllSourcesReturnsOnCall == nil {
+		fake.validateBackupOneRestoreAllSourcesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.validateBackupOneRestoreAllSourcesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeDeployment) ValidateLockingDependencies(arg1 orchestrator.LockOrderer) error {
 	fake.validateLockingDependenciesMutex.Lock()
 	ret, specificReturn := fake.validateLockingDependenciesReturnsOnCall[len(fake.validateLockingDependenciesArgsForCall)]
@@ -1053,6 +1163,8 @@ func (fake *FakeDeployment) Invocations() map[string][][]interface{} {
 	defer fake.restorableInstancesMutex.RUnlock()
 	fake.restoreMutex.RLock()
 	defer fake.restoreMutex.RUnlock()
+	fake.validateBackupOneRestoreAllSourcesMutex.RLock()
+	defer fake.validateBackupOneRestoreAllSourcesMutex.RUnlock()
 	fake.validateLockingDependenciesMutex.RLock()
 	defer fake.validateLockingDependenciesMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
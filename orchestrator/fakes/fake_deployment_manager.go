@@ -2,16 +2,18 @@
 package fakes
 
 import (
+	"context"
 	"sync"
 
 	"github.com/cloudfoundry/bosh-backup-and-restore/orchestrator"
 )
 
 type FakeDeploymentManager struct {
-	FindStub        func(string) (orchestrator.Deployment, error)
+	FindStub        func(context.Context, string) (orchestrator.Deployment, error)
 	findMutex       sync.RWMutex
 	findArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
+		arg2 string
 	}
 	findReturns struct {
 		result1 orchestrator.Deployment
@@ -37,18 +39,19 @@ type FakeDeploymentManager struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeDeploymentManager) Find(arg1 string) (orchestrator.Deployment, error) {
+func (fake *FakeDeploymentManager) Find(arg1 context.Context, arg2 string) (orchestrator.Deployment, error) {
 	fake.findMutex.Lock()
 	ret, specificReturn := fake.findReturnsOnCall[len(fake.findArgsForCall)]
 	fake.findArgsForCall = append(fake.findArgsForCall, struct {
-		arg1 string
-	}{arg1})
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
 	stub := fake.FindStub
 	fakeReturns := fake.findReturns
-	fake.recordInvocation("Find", []interface{}{arg1})
+	fake.recordInvocation("Find", []interface{}{arg1, arg2})
 	fake.findMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -62,17 +65,17 @@ func (fake *FakeDeploymentManager) FindCallCount() int {
 	return len(fake.findArgsForCall)
 }
 
-func (fake *FakeDeploymentManager) FindCalls(stub func(string) (orchestrator.Deployment, error)) {
+func (fake *FakeDeploymentManager) FindCalls(stub func(context.Context, string) (orchestrator.Deployment, error)) {
 	fake.findMutex.Lock()
 	defer fake.findMutex.Unlock()
 	fake.FindStub = stub
 }
 
-func (fake *FakeDeploymentManager) FindArgsForCall(i int) string {
+func (fake *FakeDeploymentManager) FindArgsForCall(i int) (context.Context, string) {
 	fake.findMutex.RLock()
 	defer fake.findMutex.RUnlock()
 	argsForCall := fake.findArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeDeploymentManager) FindReturns(result1 orchestrator.Deployment, result2 error) {
@@ -2,6 +2,7 @@
 package fakes
 
 import (
+	"context"
 	"sync"
 
 	"github.com/cloudfoundry/bosh-backup-and-restore/orchestrator"
@@ -18,9 +19,10 @@ type FakeInstance struct {
 	artifactDirCreatedReturnsOnCall map[int]struct {
 		result1 bool
 	}
-	ArtifactDirExistsStub        func() (bool, error)
+	ArtifactDirExistsStub        func(context.Context) (bool, error)
 	artifactDirExistsMutex       sync.RWMutex
 	artifactDirExistsArgsForCall []struct {
+		arg1 context.Context
 	}
 	artifactDirExistsReturns struct {
 		result1 bool
@@ -50,9 +52,10 @@ type FakeInstance struct {
 	artifactsToRestoreReturnsOnCall map[int]struct {
 		result1 []orchestrator.BackupArtifact
 	}
-	BackupStub        func() error
+	BackupStub        func(context.Context) error
 	backupMutex       sync.RWMutex
 	backupArgsForCall []struct {
+		arg1 context.Context
 	}
 	backupReturns struct {
 		result1 error
@@ -60,9 +63,10 @@ type FakeInstance struct {
 	backupReturnsOnCall map[int]struct {
 		result1 error
 	}
-	CleanupStub        func() error
+	CleanupStub        func(context.Context) error
 	cleanupMutex       sync.RWMutex
 	cleanupArgsForCall []struct {
+		arg1 context.Context
 	}
 	cleanupReturns struct {
 		result1 error
@@ -70,9 +74,10 @@ type FakeInstance struct {
 	cleanupReturnsOnCall map[int]struct {
 		result1 error
 	}
-	CleanupPreviousStub        func() error
+	CleanupPreviousStub        func(context.Context) error
 	cleanupPreviousMutex       sync.RWMutex
 	cleanupPreviousArgsForCall []struct {
+		arg1 context.Context
 	}
 	cleanupPreviousReturns struct {
 		result1 error
@@ -80,6 +85,19 @@ type FakeInstance struct {
 	cleanupPreviousReturnsOnCall map[int]struct {
 		result1 error
 	}
+	FreeSpaceInBytesStub        func(context.Context) (int, error)
+	freeSpaceInBytesMutex       sync.RWMutex
+	freeSpaceInBytesArgsForCall []struct {
+		arg1 context.Context
+	}
+	freeSpaceInBytesReturns struct {
+		result1 int
+		result2 error
+	}
+	freeSpaceInBytesReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
 	HasMetadataRestoreNamesStub        func() bool
 	hasMetadataRestoreNamesMutex       sync.RWMutex
 	hasMetadataRestoreNamesArgsForCall []struct {
@@ -154,9 +172,10 @@ type FakeInstance struct {
 	nameReturnsOnCall map[int]struct {
 		result1 string
 	}
-	RestoreStub        func() error
+	RestoreStub        func(context.Context) error
 	restoreMutex       sync.RWMutex
 	restoreArgsForCall []struct {
+		arg1 context.Context
 	}
 	restoreReturns struct {
 		result1 error
@@ -221,17 +240,18 @@ func (fake *FakeInstance) ArtifactDirCreatedReturnsOnCall(i int, result1 bool) {
 	}{result1}
 }
 
-func (fake *FakeInstance) ArtifactDirExists() (bool, error) {
+func (fake *FakeInstance) ArtifactDirExists(arg1 context.Context) (bool, error) {
 	fake.artifactDirExistsMutex.Lock()
 	ret, specificReturn := fake.artifactDirExistsReturnsOnCall[len(fake.artifactDirExistsArgsForCall)]
 	fake.artifactDirExistsArgsForCall = append(fake.artifactDirExistsArgsForCall, struct {
-	}{})
+		arg1 context.Context
+	}{arg1})
 	stub := fake.ArtifactDirExistsStub
 	fakeReturns := fake.artifactDirExistsReturns
-	fake.recordInvocation("ArtifactDirExists", []interface{}{})
+	fake.recordInvocation("ArtifactDirExists", []interface{}{arg1})
 	fake.artifactDirExistsMutex.Unlock()
 	if stub != nil {
-		return stub()
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -245,12 +265,19 @@ func (fake *FakeInstance) ArtifactDirExistsCallCount() int {
 	return len(fake.artifactDirExistsArgsForCall)
 }
 
-func (fake *FakeInstance) ArtifactDirExistsCalls(stub func() (bool, error)) {
+func (fake *FakeInstance) ArtifactDirExistsCalls(stub func(context.Context) (bool, error)) {
 	fake.artifactDirExistsMutex.Lock()
 	defer fake.artifactDirExistsMutex.Unlock()
 	fake.ArtifactDirExistsStub = stub
 }
 
+func (fake *FakeInstance) ArtifactDirExistsArgsForCall(i int) context.Context {
+	fake.artifactDirExistsMutex.RLock()
+	defer fake.artifactDirExistsMutex.RUnlock()
+	argsForCall := fake.artifactDirExistsArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeInstance) ArtifactDirExistsReturns(result1 bool, result2 error) {
 	fake.artifactDirExistsMutex.Lock()
 	defer fake.artifactDirExistsMutex.Unlock()
@@ -383,17 +410,18 @@ func (fake *FakeInstance) ArtifactsToRestoreReturnsOnCall(i int, result1 []orche
 	}{result1}
 }
 
-func (fake *FakeInstance) Backup() error {
+func (fake *FakeInstance) Backup(arg1 context.Context) error {
 	fake.backupMutex.Lock()
 	ret, specificReturn := fake.backupReturnsOnCall[len(fake.backupArgsForCall)]
 	fake.backupArgsForCall = append(fake.backupArgsForCall, struct {
-	}{})
+		arg1 context.Context
+	}{arg1})
 	stub := fake.BackupStub
 	fakeReturns := fake.backupReturns
-	fake.recordInvocation("Backup", []interface{}{})
+	fake.recordInvocation("Backup", []interface{}{arg1})
 	fake.backupMutex.Unlock()
 	if stub != nil {
-		return stub()
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
@@ -407,12 +435,19 @@ func (fake *FakeInstance) BackupCallCount() int {
 	return len(fake.backupArgsForCall)
 }
 
-func (fake *FakeInstance) BackupCalls(stub func() error) {
+func (fake *FakeInstance) BackupCalls(stub func(context.Context) error) {
 	fake.backupMutex.Lock()
 	defer fake.backupMutex.Unlock()
 	fake.BackupStub = stub
 }
 
+func (fake *FakeInstance) BackupArgsForCall(i int) context.Context {
+	fake.backupMutex.RLock()
+	defer fake.backupMutex.RUnlock()
+	argsForCall := fake.backupArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeInstance) BackupReturns(result1 error) {
 	fake.backupMutex.Lock()
 	defer fake.backupMutex.Unlock()
@@ -436,17 +471,18 @@ func (fake *FakeInstance) BackupReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeInstance) Cleanup() error {
+func (fake *FakeInstance) Cleanup(arg1 context.Context) error {
 	fake.cleanupMutex.Lock()
 	ret, specificReturn := fake.cleanupReturnsOnCall[len(fake.cleanupArgsForCall)]
 	fake.cleanupArgsForCall = append(fake.cleanupArgsForCall, struct {
-	}{})
+		arg1 context.Context
+	}{arg1})
 	stub := fake.CleanupStub
 	fakeReturns := fake.cleanupReturns
-	fake.recordInvocation("Cleanup", []interface{}{})
+	fake.recordInvocation("Cleanup", []interface{}{arg1})
 	fake.cleanupMutex.Unlock()
 	if stub != nil {
-		return stub()
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
@@ -460,12 +496,19 @@ func (fake *FakeInstance) CleanupCallCount() int {
 	return len(fake.cleanupArgsForCall)
 }
 
-func (fake *FakeInstance) CleanupCalls(stub func() error) {
+func (fake *FakeInstance) CleanupCalls(stub func(context.Context) error) {
 	fake.cleanupMutex.Lock()
 	defer fake.cleanupMutex.Unlock()
 	fake.CleanupStub = stub
 }
 
+func (fake *FakeInstance) CleanupArgsForCall(i int) context.Context {
+	fake.cleanupMutex.RLock()
+	defer fake.cleanupMutex.RUnlock()
+	argsForCall := fake.cleanupArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeInstance) CleanupReturns(result1 error) {
 	fake.cleanupMutex.Lock()
 	defer fake.cleanupMutex.Unlock()
@@ -489,17 +532,18 @@ func (fake *FakeInstance) CleanupReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeInstance) CleanupPrevious() error {
+func (fake *FakeInstance) CleanupPrevious(arg1 context.Context) error {
 	fake.cleanupPreviousMutex.Lock()
 	ret, specificReturn := fake.cleanupPreviousReturnsOnCall[len(fake.cleanupPreviousArgsForCall)]
 	fake.cleanupPreviousArgsForCall = append(fake.cleanupPreviousArgsForCall, struct {
-	}{})
+		arg1 context.Context
+	}{arg1})
 	stub := fake.CleanupPreviousStub
 	fakeReturns := fake.cleanupPreviousReturns
-	fake.recordInvocation("CleanupPrevious", []interface{}{})
+	fake.recordInvocation("CleanupPrevious", []interface{}{arg1})
 	fake.cleanupPreviousMutex.Unlock()
 	if stub != nil {
-		return stub()
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
@@ -513,12 +557,19 @@ func (fake *FakeInstance) CleanupPreviousCallCount() int {
 	return len(fake.cleanupPreviousArgsForCall)
 }
 
-func (fake *FakeInstance) CleanupPreviousCalls(stub func() error) {
+func (fake *FakeInstance) CleanupPreviousCalls(stub func(context.Context) error) {
 	fake.cleanupPreviousMutex.Lock()
 	defer fake.cleanupPreviousMutex.Unlock()
 	fake.CleanupPreviousStub = stub
 }
 
+func (fake *FakeInstance) CleanupPreviousArgsForCall(i int) context.Context {
+	fake.cleanupPreviousMutex.RLock()
+	defer fake.cleanupPreviousMutex.RUnlock()
+	argsForCall := fake.cleanupPreviousArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeInstance) CleanupPreviousReturns(result1 error) {
 	fake.cleanupPreviousMutex.Lock()
 	defer fake.cleanupPreviousMutex.Unlock()
@@ -542,6 +593,70 @@ func (fake *FakeInstance) CleanupPreviousReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeInstance) FreeSpaceInBytes(arg1 context.Context) (int, error) {
+	fake.freeSpaceInBytesMutex.Lock()
+	ret, specificReturn := fake.freeSpaceInBytesReturnsOnCall[len(fake.freeSpaceInBytesArgsForCall)]
+	fake.freeSpaceInBytesArgsForCall = append(fake.freeSpaceInBytesArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.FreeSpaceInBytesStub
+	fakeReturns := fake.freeSpaceInBytesReturns
+	fake.recordInvocation("FreeSpaceInBytes", []interface{}{arg1})
+	fake.freeSpaceInBytesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeInstance) FreeSpaceInBytesCallCount() int {
+	fake.freeSpaceInBytesMutex.RLock()
+	defer fake.freeSpaceInBytesMutex.RUnlock()
+	return len(fake.freeSpaceInBytesArgsForCall)
+}
+
+func (fake *FakeInstance) FreeSpaceInBytesCalls(stub func(context.Context) (int, error)) {
+	fake.freeSpaceInBytesMutex.Lock()
+	defer fake.freeSpaceInBytesMutex.Unlock()
+	fake.FreeSpaceInBytesStub = stub
+}
+
+func (fake *FakeInstance) FreeSpaceInBytesArgsForCall(i int) context.Context {
+	fake.freeSpaceInBytesMutex.RLock()
+	defer fake.freeSpaceInBytesMutex.RUnlock()
+	argsForCall := fake.freeSpaceInBytesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeInstance) FreeSpaceInBytesReturns(result1 int, result2 error) {
+	fake.freeSpaceInBytesMutex.Lock()
+	defer fake.freeSpaceInBytesMutex.Unlock()
+	fake.FreeSpaceInBytesStub = nil
+	fake.freeSpaceInBytesReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeInstance) FreeSpaceInBytesReturnsOnCall(i int, result1 int, result2 error) {
+	fake.freeSpaceInBytesMutex.Lock()
+	defer fake.freeSpaceInBytesMutex.Unlock()
+	fake.FreeSpaceInBytesStub = nil
+	if fake.freeSpaceInBytesReturnsOnCall == nil {
+		fake.freeSpaceInBytesReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.freeSpaceInBytesReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeInstance) HasMetadataRestoreNames() bool {
 	fake.hasMetadataRestoreNamesMutex.Lock()
 	ret, specificReturn := fake.hasMetadataRestoreNamesReturnsOnCall[len(fake.hasMetadataRestoreNamesArgsForCall)]
@@ -937,17 +1052,18 @@ func (fake *FakeInstance) NameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
-func (fake *FakeInstance) Restore() error {
+func (fake *FakeInstance) Restore(arg1 context.Context) error {
 	fake.restoreMutex.Lock()
 	ret, specificReturn := fake.restoreReturnsOnCall[len(fake.restoreArgsForCall)]
 	fake.restoreArgsForCall = append(fake.restoreArgsForCall, struct {
-	}{})
+		arg1 context.Context
+	}{arg1})
 	stub := fake.RestoreStub
 	fakeReturns := fake.restoreReturns
-	fake.recordInvocation("Restore", []interface{}{})
+	fake.recordInvocation("Restore", []interface{}{arg1})
 	fake.restoreMutex.Unlock()
 	if stub != nil {
-		return stub()
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
@@ -961,12 +1077,19 @@ func (fake *FakeInstance) RestoreCallCount() int {
 	return len(fake.restoreArgsForCall)
 }
 
-func (fake *FakeInstance) RestoreCalls(stub func() error) {
+func (fake *FakeInstance) RestoreCalls(stub func(context.Context) error) {
 	fake.restoreMutex.Lock()
 	defer fake.restoreMutex.Unlock()
 	fake.RestoreStub = stub
 }
 
+func (fake *FakeInstance) RestoreArgsForCall(i int) context.Context {
+	fake.restoreMutex.RLock()
+	defer fake.restoreMutex.RUnlock()
+	argsForCall := fake.restoreArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeInstance) RestoreReturns(result1 error) {
 	fake.restoreMutex.Lock()
 	defer fake.restoreMutex.Unlock()
@@ -1007,6 +1130,8 @@ func (fake *FakeInstance) Invocations() map[string][][]interface{} {
 	defer fake.cleanupMutex.RUnlock()
 	fake.cleanupPreviousMutex.RLock()
 	defer fake.cleanupPreviousMutex.RUnlock()
+	fake.freeSpaceInBytesMutex.RLock()
+	defer fake.freeSpaceInBytesMutex.RUnlock()
 	fake.hasMetadataRestoreNamesMutex.RLock()
 	defer fake.hasMetadataRestoreNamesMutex.RUnlock()
 	fake.iDMutex.RLock()
@@ -2,15 +2,17 @@
 package fakes
 
 import (
+	"context"
 	"sync"
 
 	"github.com/cloudfoundry/bosh-backup-and-restore/orchestrator"
 )
 
 type FakeJob struct {
-	BackupStub        func() error
+	BackupStub        func(context.Context) error
 	backupMutex       sync.RWMutex
 	backupArgsForCall []struct {
+		arg1 context.Context
 	}
 	backupReturns struct {
 		result1 error
@@ -48,6 +50,19 @@ type FakeJob struct {
 	backupShouldBeLockedBeforeReturnsOnCall map[int]struct {
 		result1 []orchestrator.JobSpecifier
 	}
+	BackupSizeStub        func(context.Context) (int, error)
+	backupSizeMutex       sync.RWMutex
+	backupSizeArgsForCall []struct {
+		arg1 context.Context
+	}
+	backupSizeReturns struct {
+		result1 int
+		result2 error
+	}
+	backupSizeReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
 	HasBackupStub        func() bool
 	hasBackupMutex       sync.RWMutex
 	hasBackupArgsForCall []struct {
@@ -58,6 +73,16 @@ type FakeJob struct {
 	hasBackupReturnsOnCall map[int]struct {
 		result1 bool
 	}
+	HasBackupSizeStub        func() bool
+	hasBackupSizeMutex       sync.RWMutex
+	hasBackupSizeArgsForCall []struct {
+	}
+	hasBackupSizeReturns struct {
+		result1 bool
+	}
+	hasBackupSizeReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	HasMetadataRestoreNameStub        func() bool
 	hasMetadataRestoreNameMutex       sync.RWMutex
 	hasMetadataRestoreNameArgsForCall []struct {
@@ -108,6 +133,16 @@ type FakeJob struct {
 	instanceIdentifierReturnsOnCall map[int]struct {
 		result1 string
 	}
+	IsBackupOneRestoreAllStub        func() bool
+	isBackupOneRestoreAllMutex       sync.RWMutex
+	isBackupOneRestoreAllArgsForCall []struct {
+	}
+	isBackupOneRestoreAllReturns struct {
+		result1 bool
+	}
+	isBackupOneRestoreAllReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	NameStub        func() string
 	nameMutex       sync.RWMutex
 	nameArgsForCall []struct {
@@ -118,10 +153,11 @@ type FakeJob struct {
 	nameReturnsOnCall map[int]struct {
 		result1 string
 	}
-	PostBackupUnlockStub        func(bool) error
+	PostBackupUnlockStub        func(context.Context, bool) error
 	postBackupUnlockMutex       sync.RWMutex
 	postBackupUnlockArgsForCall []struct {
-		arg1 bool
+		arg1 context.Context
+		arg2 bool
 	}
 	postBackupUnlockReturns struct {
 		result1 error
@@ -129,9 +165,10 @@ type FakeJob struct {
 	postBackupUnlockReturnsOnCall map[int]struct {
 		result1 error
 	}
-	PostRestoreUnlockStub        func() error
+	PostRestoreUnlockStub        func(context.Context) error
 	postRestoreUnlockMutex       sync.RWMutex
 	postRestoreUnlockArgsForCall []struct {
+		arg1 context.Context
 	}
 	postRestoreUnlockReturns struct {
 		result1 error
@@ -139,9 +176,10 @@ type FakeJob struct {
 	postRestoreUnlockReturnsOnCall map[int]struct {
 		result1 error
 	}
-	PreBackupLockStub        func() error
+	PreBackupLockStub        func(context.Context) error
 	preBackupLockMutex       sync.RWMutex
 	preBackupLockArgsForCall []struct {
+		arg1 context.Context
 	}
 	preBackupLockReturns struct {
 		result1 error
@@ -149,9 +187,10 @@ type FakeJob struct {
 	preBackupLockReturnsOnCall map[int]struct {
 		result1 error
 	}
-	PreRestoreLockStub        func() error
+	PreRestoreLockStub        func(context.Context) error
 	preRestoreLockMutex       sync.RWMutex
 	preRestoreLockArgsForCall []struct {
+		arg1 context.Context
 	}
 	preRestoreLockReturns struct {
 		result1 error
@@ -169,9 +208,10 @@ type FakeJob struct {
 	releaseReturnsOnCall map[int]struct {
 		result1 string
 	}
-	RestoreStub        func() error
+	RestoreStub        func(context.Context) error
 	restoreMutex       sync.RWMutex
 	restoreArgsForCall []struct {
+		arg1 context.Context
 	}
 	restoreReturns struct {
 		result1 error
@@ -213,17 +253,18 @@ type FakeJob struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeJob) Backup() error {
+func (fake *FakeJob) Backup(arg1 context.Context) error {
 	fake.backupMutex.Lock()
 	ret, specificReturn := fake.backupReturnsOnCall[len(fake.backupArgsForCall)]
 	fake.backupArgsForCall = append(fake.backupArgsForCall, struct {
-	}{})
+		arg1 context.Context
+	}{arg1})
 	stub := fake.BackupStub
 	fakeReturns := fake.backupReturns
-	fake.recordInvocation("Backup", []interface{}{})
+	fake.recordInvocation("Backup", []interface{}{arg1})
 	fake.backupMutex.Unlock()
 	if stub != nil {
-		return stub()
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
@@ -237,12 +278,19 @@ func (fake *FakeJob) BackupCallCount() int {
 	return len(fake.backupArgsForCall)
 }
 
-func (fake *FakeJob) BackupCalls(stub func() error) {
+func (fake *FakeJob) BackupCalls(stub func(context.Context) error) {
 	fake.backupMutex.Lock()
 	defer fake.backupMutex.Unlock()
 	fake.BackupStub = stub
 }
 
+func (fake *FakeJob) BackupArgsForCall(i int) context.Context {
+	fake.backupMutex.RLock()
+	defer fake.backupMutex.RUnlock()
+	argsForCall := fake.backupArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeJob) BackupReturns(result1 error) {
 	fake.backupMutex.Lock()
 	defer fake.backupMutex.Unlock()
@@ -425,6 +473,70 @@ func (fake *FakeJob) BackupShouldBeLockedBeforeReturnsOnCall(i int, result1 []or
 	}{result1}
 }
 
+func (fake *FakeJob) BackupSize(arg1 context.Context) (int, error) {
+	fake.backupSizeMutex.Lock()
+	ret, specificReturn := fake.backupSizeReturnsOnCall[len(fake.backupSizeArgsForCall)]
+	fake.backupSizeArgsForCall = append(fake.backupSizeArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.BackupSizeStub
+	fakeReturns := fake.backupSizeReturns
+	fake.recordInvocation("BackupSize", []interface{}{arg1})
+	fake.backupSizeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeJob) BackupSizeCallCount() int {
+	fake.backupSizeMutex.RLock()
+	defer fake.backupSizeMutex.RUnlock()
+	return len(fake.backupSizeArgsForCall)
+}
+
+func (fake *FakeJob) BackupSizeCalls(stub func(context.Context) (int, error)) {
+	fake.backupSizeMutex.Lock()
+	defer fake.backupSizeMutex.Unlock()
+	fake.BackupSizeStub = stub
+}
+
+func (fake *FakeJob) BackupSizeArgsForCall(i int) context.Context {
+	fake.backupSizeMutex.RLock()
+	defer fake.backupSizeMutex.RUnlock()
+	argsForCall := fake.backupSizeArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeJob) BackupSizeReturns(result1 int, result2 error) {
+	fake.backupSizeMutex.Lock()
+	defer fake.backupSizeMutex.Unlock()
+	fake.BackupSizeStub = nil
+	fake.backupSizeReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) BackupSizeReturnsOnCall(i int, result1 int, result2 error) {
+	fake.backupSizeMutex.Lock()
+	defer fake.backupSizeMutex.Unlock()
+	fake.BackupSizeStub = nil
+	if fake.backupSizeReturnsOnCall == nil {
+		fake.backupSizeReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.backupSizeReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeJob) HasBackup() bool {
 	fake.hasBackupMutex.Lock()
 	ret, specificReturn := fake.hasBackupReturnsOnCall[len(fake.hasBackupArgsForCall)]
@@ -478,6 +590,59 @@ func (fake *FakeJob) HasBackupReturnsOnCall(i int, result1 bool) {
 	}{result1}
 }
 
+func (fake *FakeJob) HasBackupSize() bool {
+	fake.hasBackupSizeMutex.Lock()
+	ret, specificReturn := fake.hasBackupSizeReturnsOnCall[len(fake.hasBackupSizeArgsForCall)]
+	fake.hasBackupSizeArgsForCall = append(fake.hasBackupSizeArgsForCall, struct {
+	}{})
+	stub := fake.HasBackupSizeStub
+	fakeReturns := fake.hasBackupSizeReturns
+	fake.recordInvocation("HasBackupSize", []interface{}{})
+	fake.hasBackupSizeMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeJob) HasBackupSizeCallCount() int {
+	fake.hasBackupSizeMutex.RLock()
+	defer fake.hasBackupSizeMutex.RUnlock()
+	return len(fake.hasBackupSizeArgsForCall)
+}
+
+func (fake *FakeJob) HasBackupSizeCalls(stub func() bool) {
+	fake.hasBackupSizeMutex.Lock()
+	defer fake.hasBackupSizeMutex.Unlock()
+	fake.HasBackupSizeStub = stub
+}
+
+func (fake *FakeJob) HasBackupSizeReturns(result1 bool) {
+	fake.hasBackupSizeMutex.Lock()
+	defer fake.hasBackupSizeMutex.Unlock()
+	fake.HasBackupSizeStub = nil
+	fake.hasBackupSizeReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeJob) HasBackupSizeReturnsOnCall(i int, result1 bool) {
+	fake.hasBackupSizeMutex.Lock()
+	defer fake.hasBackupSizeMutex.Unlock()
+	fake.HasBackupSizeStub = nil
+	if fake.hasBackupSizeReturnsOnCall == nil {
+		fake.hasBackupSizeReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.hasBackupSizeReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *FakeJob) HasMetadataRestoreName() bool {
 	fake.hasMetadataRestoreNameMutex.Lock()
 	ret, specificReturn := fake.hasMetadataRestoreNameReturnsOnCall[len(fake.hasMetadataRestoreNameArgsForCall)]
@@ -743,6 +908,59 @@ func (fake *FakeJob) InstanceIdentifierReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeJob) IsBackupOneRestoreAll() bool {
+	fake.isBackupOneRestoreAllMutex.Lock()
+	ret, specificReturn := fake.isBackupOneRestoreAllReturnsOnCall[len(fake.isBackupOneRestoreAllArgsForCall)]
+	fake.isBackupOneRestoreAllArgsForCall = append(fake.isBackupOneRestoreAllArgsForCall, struct {
+	}{})
+	stub := fake.IsBackupOneRestoreAllStub
+	fakeReturns := fake.isBackupOneRestoreAllReturns
+	fake.recordInvocation("IsBackupOneRestoreAll", []interface{}{})
+	fake.isBackupOneRestoreAllMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeJob) IsBackupOneRestoreAllCallCount() int {
+	fake.isBackupOneRestoreAllMutex.RLock()
+	defer fake.isBackupOneRestoreAllMutex.RUnlock()
+	return len(fake.isBackupOneRestoreAllArgsForCall)
+}
+
+func (fake *FakeJob) IsBackupOneRestoreAllCalls(stub func() bool) {
+	fake.isBackupOneRestoreAllMutex.Lock()
+	defer fake.isBackupOneRestoreAllMutex.Unlock()
+	fake.IsBackupOneRestoreAllStub = stub
+}
+
+func (fake *FakeJob) IsBackupOneRestoreAllReturns(result1 bool) {
+	fake.isBackupOneRestoreAllMutex.Lock()
+	defer fake.isBackupOneRestoreAllMutex.Unlock()
+	fake.IsBackupOneRestoreAllStub = nil
+	fake.isBackupOneRestoreAllReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeJob) IsBackupOneRestoreAllReturnsOnCall(i int, result1 bool) {
+	fake.isBackupOneRestoreAllMutex.Lock()
+	defer fake.isBackupOneRestoreAllMutex.Unlock()
+	fake.IsBackupOneRestoreAllStub = nil
+	if fake.isBackupOneRestoreAllReturnsOnCall == nil {
+		fake.isBackupOneRestoreAllReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.isBackupOneRestoreAllReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *FakeJob) Name() string {
 	fake.nameMutex.Lock()
 	ret, specificReturn := fake.nameReturnsOnCall[len(fake.nameArgsForCall)]
@@ -796,18 +1014,19 @@ func (fake *FakeJob) NameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
-func (fake *FakeJob) PostBackupUnlock(arg1 bool) error {
+func (fake *FakeJob) PostBackupUnlock(arg1 context.Context, arg2 bool) error {
 	fake.postBackupUnlockMutex.Lock()
 	ret, specificReturn := fake.postBackupUnlockReturnsOnCall[len(fake.postBackupUnlockArgsForCall)]
 	fake.postBackupUnlockArgsForCall = append(fake.postBackupUnlockArgsForCall, struct {
-		arg1 bool
-	}{arg1})
+		arg1 context.Context
+		arg2 bool
+	}{arg1, arg2})
 	stub := fake.PostBackupUnlockStub
 	fakeReturns := fake.postBackupUnlockReturns
-	fake.recordInvocation("PostBackupUnlock", []interface{}{arg1})
+	fake.recordInvocation("PostBackupUnlock", []interface{}{arg1, arg2})
 	fake.postBackupUnlockMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
@@ -821,17 +1040,17 @@ func (fake *FakeJob) PostBackupUnlockCallCount() int {
 	return len(fake.postBackupUnlockArgsForCall)
 }
 
-func (fake *FakeJob) PostBackupUnlockCalls(stub func(bool) error) {
+func (fake *FakeJob) PostBackupUnlockCalls(stub func(context.Context, bool) error) {
 	fake.postBackupUnlockMutex.Lock()
 	defer fake.postBackupUnlockMutex.Unlock()
 	fake.PostBackupUnlockStub = stub
 }
 
-func (fake *FakeJob) PostBackupUnlockArgsForCall(i int) bool {
+func (fake *FakeJob) PostBackupUnlockArgsForCall(i int) (context.Context, bool) {
 	fake.postBackupUnlockMutex.RLock()
 	defer fake.postBackupUnlockMutex.RUnlock()
 	argsForCall := fake.postBackupUnlockArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeJob) PostBackupUnlockReturns(result1 error) {
@@ -857,17 +1076,18 @@ func (fake *FakeJob) PostBackupUnlockReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeJob) PostRestoreUnlock() error {
+func (fake *FakeJob) PostRestoreUnlock(arg1 context.Context) error {
 	fake.postRestoreUnlockMutex.Lock()
 	ret, specificReturn := fake.postRestoreUnlockReturnsOnCall[len(fake.postRestoreUnlockArgsForCall)]
 	fake.postRestoreUnlockArgsForCall = append(fake.postRestoreUnlockArgsForCall, struct {
-	}{})
+		arg1 context.Context
+	}{arg1})
 	stub := fake.PostRestoreUnlockStub
 	fakeReturns := fake.postRestoreUnlockReturns
-	fake.recordInvocation("PostRestoreUnlock", []interface{}{})
+	fake.recordInvocation("PostRestoreUnlock", []interface{}{arg1})
 	fake.postRestoreUnlockMutex.Unlock()
 	if stub != nil {
-		return stub()
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
@@ -881,12 +1101,19 @@ func (fake *FakeJob) PostRestoreUnlockCallCount() int {
 	return len(fake.postRestoreUnlockArgsForCall)
 }
 
-func (fake *FakeJob) PostRestoreUnlockCalls(stub func() error) {
+func (fake *FakeJob) PostRestoreUnlockCalls(stub func(context.Context) error) {
 	fake.postRestoreUnlockMutex.Lock()
 	defer fake.postRestoreUnlockMutex.Unlock()
 	fake.PostRestoreUnlockStub = stub
 }
 
+func (fake *FakeJob) PostRestoreUnlockArgsForCall(i int) context.Context {
+	fake.postRestoreUnlockMutex.RLock()
+	defer fake.postRestoreUnlockMutex.RUnlock()
+	argsForCall := fake.postRestoreUnlockArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeJob) PostRestoreUnlockReturns(result1 error) {
 	fake.postRestoreUnlockMutex.Lock()
 	defer fake.postRestoreUnlockMutex.Unlock()
@@ -910,17 +1137,18 @@ func (fake *FakeJob) PostRestoreUnlockReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeJob) PreBackupLock() error {
+func (fake *FakeJob) PreBackupLock(arg1 context.Context) error {
 	fake.preBackupLockMutex.Lock()
 	ret, specificReturn := fake.preBackupLockReturnsOnCall[len(fake.preBackupLockArgsForCall)]
 	fake.preBackupLockArgsForCall = append(fake.preBackupLockArgsForCall, struct {
-	}{})
+		arg1 context.Context
+	}{arg1})
 	stub := fake.PreBackupLockStub
 	fakeReturns := fake.preBackupLockReturns
-	fake.recordInvocation("PreBackupLock", []interface{}{})
+	fake.recordInvocation("PreBackupLock", []interface{}{arg1})
 	fake.preBackupLockMutex.Unlock()
 	if stub != nil {
-		return stub()
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
@@ -934,12 +1162,19 @@ func (fake *FakeJob) PreBackupLockCallCount() int {
 	return len(fake.preBackupLockArgsForCall)
 }
 
-func (fake *FakeJob) PreBackupLockCalls(stub func() error) {
+func (fake *FakeJob) PreBackupLockCalls(stub func(context.Context) error) {
 	fake.preBackupLockMutex.Lock()
 	defer fake.preBackupLockMutex.Unlock()
 	fake.PreBackupLockStub = stub
 }
 
+func (fake *FakeJob) PreBackupLockArgsForCall(i int) context.Context {
+	fake.preBackupLockMutex.RLock()
+	defer fake.preBackupLockMutex.RUnlock()
+	argsForCall := fake.preBackupLockArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeJob) PreBackupLockReturns(result1 error) {
 	fake.preBackupLockMutex.Lock()
 	defer fake.preBackupLockMutex.Unlock()
@@ -963,17 +1198,18 @@ func (fake *FakeJob) PreBackupLockReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeJob) PreRestoreLock() error {
+func (fake *FakeJob) PreRestoreLock(arg1 context.Context) error {
 	fake.preRestoreLockMutex.Lock()
 	ret, specificReturn := fake.preRestoreLockReturnsOnCall[len(fake.preRestoreLockArgsForCall)]
 	fake.preRestoreLockArgsForCall = append(fake.preRestoreLockArgsForCall, struct {
-	}{})
+		arg1 context.Context
+	}{arg1})
 	stub := fake.PreRestoreLockStub
 	fakeReturns := fake.preRestoreLockReturns
-	fake.recordInvocation("PreRestoreLock", []interface{}{})
+	fake.recordInvocation("PreRestoreLock", []interface{}{arg1})
 	fake.preRestoreLockMutex.Unlock()
 	if stub != nil {
-		return stub()
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
@@ -987,12 +1223,19 @@ func (fake *FakeJob) PreRestoreLockCallCount() int {
 	return len(fake.preRestoreLockArgsForCall)
 }
 
-func (fake *FakeJob) PreRestoreLockCalls(stub func() error) {
+func (fake *FakeJob) PreRestoreLockCalls(stub func(context.Context) error) {
 	fake.preRestoreLockMutex.Lock()
 	defer fake.preRestoreLockMutex.Unlock()
 	fake.PreRestoreLockStub = stub
 }
 
+func (fake *FakeJob) PreRestoreLockArgsForCall(i int) context.Context {
+	fake.preRestoreLockMutex.RLock()
+	defer fake.preRestoreLockMutex.RUnlock()
+	argsForCall := fake.preRestoreLockArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeJob) PreRestoreLockReturns(result1 error) {
 	fake.preRestoreLockMutex.Lock()
 	defer fake.preRestoreLockMutex.Unlock()
@@ -1069,17 +1312,18 @@ func (fake *FakeJob) ReleaseReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
-func (fake *FakeJob) Restore() error {
+func (fake *FakeJob) Restore(arg1 context.Context) error {
 	fake.restoreMutex.Lock()
 	ret, specificReturn := fake.restoreReturnsOnCall[len(fake.restoreArgsForCall)]
 	fake.restoreArgsForCall = append(fake.restoreArgsForCall, struct {
-	}{})
+		arg1 context.Context
+	}{arg1})
 	stub := fake.RestoreStub
 	fakeReturns := fake.restoreReturns
-	fake.recordInvocation("Restore", []interface{}{})
+	fake.recordInvocation("Restore", []interface{}{arg1})
 	fake.restoreMutex.Unlock()
 	if stub != nil {
-		return stub()
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
@@ -1093,12 +1337,19 @@ func (fake *FakeJob) RestoreCallCount() int {
 	return len(fake.restoreArgsForCall)
 }
 
-func (fake *FakeJob) RestoreCalls(stub func() error) {
+func (fake *FakeJob) RestoreCalls(stub func(context.Context) error) {
 	fake.restoreMutex.Lock()
 	defer fake.restoreMutex.Unlock()
 	fake.RestoreStub = stub
 }
 
+func (fake *FakeJob) RestoreArgsForCall(i int) context.Context {
+	fake.restoreMutex.RLock()
+	defer fake.restoreMutex.RUnlock()
+	argsForCall := fake.restoreArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeJob) RestoreReturns(result1 error) {
 	fake.restoreMutex.Lock()
 	defer fake.restoreMutex.Unlock()
@@ -1292,8 +1543,12 @@ func (fake *FakeJob) Invocations() map[string][][]interface{} {
 	defer fake.backupArtifactNameMutex.RUnlock()
 	fake.backupShouldBeLockedBeforeMutex.RLock()
 	defer fake.backupShouldBeLockedBeforeMutex.RUnlock()
+	fake.backupSizeMutex.RLock()
+	defer fake.backupSizeMutex.RUnlock()
 	fake.hasBackupMutex.RLock()
 	defer fake.hasBackupMutex.RUnlock()
+	fake.hasBackupSizeMutex.RLock()
+	defer fake.hasBackupSizeMutex.RUnlock()
 	fake.hasMetadataRestoreNameMutex.RLock()
 	defer fake.hasMetadataRestoreNameMutex.RUnlock()
 	fake.hasNamedBackupArtifactMutex.RLock()
@@ -1304,6 +1559,8 @@ func (fake *FakeJob) Invocations() map[string][][]interface{} {
 	defer fake.hasRestoreMutex.RUnlock()
 	fake.instanceIdentifierMutex.RLock()
 	defer fake.instanceIdentifierMutex.RUnlock()
+	fake.isBackupOneRestoreAllMutex.RLock()
+	defer fake.isBackupOneRestoreAllMutex.RUnlock()
 	fake.nameMutex.RLock()
 	defer fake.nameMutex.RUnlock()
 	fake.postBackupUnlockMutex.RLock()
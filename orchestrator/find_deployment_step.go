@@ -11,9 +11,9 @@ func NewFindDeploymentStep(deploymentManager DeploymentManager, logger Logger) S
 
 func (s *FindDeploymentStep) Run(session *Session) error {
 	s.logger.Info("bbr", "Looking for scripts")
-	deployment, err := s.deploymentManager.Find(session.DeploymentName())
+	deployment, err := s.deploymentManager.Find(session.Context(), session.DeploymentName())
 	if err != nil {
-		return err
+		return NewDiscoveryErrorFrom(err)
 	}
 
 	session.SetCurrentDeployment(deployment)
@@ -0,0 +1,69 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+)
+
+const (
+	PreBackupHook   = "pre-backup"
+	PostLockHook    = "post-lock"
+	PostBackupHook  = "post-backup"
+	PreRestoreHook  = "pre-restore"
+	PostRestoreHook = "post-restore"
+)
+
+// Hooks holds the operator-defined commands to run on the jumpbox at each
+// point of the backup and restore workflows.
+type Hooks struct {
+	PreBackup   []string
+	PostLock    []string
+	PostBackup  []string
+	PreRestore  []string
+	PostRestore []string
+}
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+//counterfeiter:generate -o fakes/fake_hook_runner.go . HookRunner
+type HookRunner interface {
+	Run(ctx context.Context, command string, env []string) error
+}
+
+type HookStep struct {
+	hook      string
+	operation string
+	commands  []string
+	runner    HookRunner
+	logger    Logger
+}
+
+func NewHookStep(hook, operation string, commands []string, runner HookRunner, logger Logger) Step {
+	return &HookStep{
+		hook:      hook,
+		operation: operation,
+		commands:  commands,
+		runner:    runner,
+		logger:    logger,
+	}
+}
+
+// Run stops at the first failing command, so later commands can rely on the
+// earlier ones having succeeded.
+func (s *HookStep) Run(session *Session) error {
+	env := []string{
+		"BBR_HOOK=" + s.hook,
+		"BBR_OPERATION=" + s.operation,
+		"BBR_DEPLOYMENT=" + session.DeploymentName(),
+		"BBR_ARTIFACT_PATH=" + session.ArtifactDirectory(),
+	}
+
+	for _, command := range s.commands {
+		s.logger.Info("bbr", "Running %s hook for %s: %s", s.hook, session.DeploymentName(), command)
+
+		if err := s.runner.Run(session.Context(), command, env); err != nil {
+			return NewHookError(fmt.Sprintf("%s hook `%s` failed: %s", s.hook, command, err.Error()))
+		}
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package orchestrator
 
 import (
+	"context"
 	"io"
 )
 
@@ -15,14 +16,15 @@ type InstanceIdentifer interface {
 type Instance interface {
 	InstanceIdentifer
 	IsBackupable() bool
-	ArtifactDirExists() (bool, error)
+	ArtifactDirExists(context.Context) (bool, error)
 	ArtifactDirCreated() bool
+	FreeSpaceInBytes(context.Context) (int, error)
 	MarkArtifactDirCreated()
 	IsRestorable() bool
-	Backup() error
-	Restore() error
-	Cleanup() error
-	CleanupPrevious() error
+	Backup(context.Context) error
+	Restore(context.Context) error
+	Cleanup(context.Context) error
+	CleanupPrevious(context.Context) error
 	ArtifactsToBackup() []BackupArtifact
 	ArtifactsToRestore() []BackupArtifact
 	HasMetadataRestoreNames() bool
@@ -32,18 +34,21 @@ type Instance interface {
 //counterfeiter:generate -o fakes/fake_job.go . Job
 type Job interface {
 	HasBackup() bool
+	HasBackupSize() bool
 	HasRestore() bool
 	HasNamedBackupArtifact() bool
 	HasNamedRestoreArtifact() bool
+	IsBackupOneRestoreAll() bool
 	BackupArtifactName() string
 	RestoreArtifactName() string
 	HasMetadataRestoreName() bool
-	Backup() error
-	PreBackupLock() error
-	PostBackupUnlock(afterSuccessfulBackup bool) error
-	PreRestoreLock() error
-	Restore() error
-	PostRestoreUnlock() error
+	Backup(context.Context) error
+	BackupSize(context.Context) (int, error)
+	PreBackupLock(context.Context) error
+	PostBackupUnlock(ctx context.Context, afterSuccessfulBackup bool) error
+	PreRestoreLock(context.Context) error
+	Restore(context.Context) error
+	PostRestoreUnlock(context.Context) error
 	Name() string
 	Release() string
 	InstanceIdentifier() string
@@ -69,12 +74,14 @@ type ArtifactIdentifier interface {
 //counterfeiter:generate -o fakes/fake_backup_artifact.go . BackupArtifact
 type BackupArtifact interface {
 	ArtifactIdentifier
-	Size() (string, error)
-	SizeInBytes() (int, error)
-	Checksum() (BackupChecksum, error)
-	StreamFromRemote(io.Writer) error
-	Delete() error
-	StreamToRemote(io.Reader) error
+	Size(context.Context) (string, error)
+	SizeInBytes(context.Context) (int, error)
+	FileSizesInBytes(context.Context) (map[string]int, error)
+	Checksum(context.Context) (BackupChecksum, error)
+	StreamFromRemote(context.Context, io.Writer) error
+	StreamFilesFromRemote(context.Context, []string, io.Writer) error
+	Delete(context.Context) error
+	StreamToRemote(context.Context, io.Reader) error
 }
 
 type instances []Instance
@@ -125,29 +132,29 @@ func (is instances) AllBackupableOrRestorable() instances {
 	return instances
 }
 
-func (is instances) Cleanup() error {
+func (is instances) Cleanup(ctx context.Context) error {
 	var cleanupErrors []error
 	for _, instance := range is {
-		if err := instance.Cleanup(); err != nil {
+		if err := instance.Cleanup(ctx); err != nil {
 			cleanupErrors = append(cleanupErrors, err)
 		}
 	}
 	return ConvertErrors(cleanupErrors)
 }
 
-func (is instances) CleanupPrevious() error {
+func (is instances) CleanupPrevious(ctx context.Context) error {
 	var cleanupPreviousErrors []error
 	for _, instance := range is {
-		if err := instance.CleanupPrevious(); err != nil {
+		if err := instance.CleanupPrevious(ctx); err != nil {
 			cleanupPreviousErrors = append(cleanupPreviousErrors, err)
 		}
 	}
 	return ConvertErrors(cleanupPreviousErrors)
 }
 
-func (is instances) Backup() error {
+func (is instances) Backup(ctx context.Context) error {
 	for _, instance := range is {
-		err := instance.Backup()
+		err := instance.Backup(ctx)
 		if err != nil {
 			return err
 		}
@@ -155,9 +162,9 @@ func (is instances) Backup() error {
 	return nil
 }
 
-func (is instances) Restore() error {
+func (is instances) Restore(ctx context.Context) error {
 	for _, instance := range is {
-		err := instance.Restore()
+		err := instance.Restore(ctx)
 		if err != nil {
 			return err
 		}
@@ -1,6 +1,10 @@
 package orchestrator
 
-import "github.com/cloudfoundry/bosh-backup-and-restore/executor"
+import (
+	"context"
+
+	"github.com/cloudfoundry/bosh-backup-and-restore/executor"
+)
 
 type JobPreBackupLockExecutor struct {
 	Job
@@ -10,8 +14,8 @@ func NewJobPreBackupLockExecutable(job Job) executor.Executable {
 	return JobPreBackupLockExecutor{job}
 }
 
-func (j JobPreBackupLockExecutor) Execute() error {
-	return j.PreBackupLock()
+func (j JobPreBackupLockExecutor) Execute(ctx context.Context) error {
+	return j.PreBackupLock(ctx)
 }
 
 type JobPostBackupUnlockExecutor struct {
@@ -33,8 +37,8 @@ func NewJobPostFailedBackupUnlockExecutable(job Job) executor.Executable {
 	}
 }
 
-func (j JobPostBackupUnlockExecutor) Execute() error {
-	return j.PostBackupUnlock(j.afterSuccessfulBackup)
+func (j JobPostBackupUnlockExecutor) Execute(ctx context.Context) error {
+	return j.PostBackupUnlock(ctx, j.afterSuccessfulBackup)
 }
 
 type JobPreRestoreLockExecutor struct {
@@ -45,8 +49,8 @@ func NewJobPreRestoreLockExecutable(job Job) executor.Executable {
 	return JobPreRestoreLockExecutor{job}
 }
 
-func (j JobPreRestoreLockExecutor) Execute() error {
-	return j.PreRestoreLock()
+func (j JobPreRestoreLockExecutor) Execute(ctx context.Context) error {
+	return j.PreRestoreLock(ctx)
 }
 
 type JobPostRestoreUnlockExecutor struct {
@@ -57,6 +61,6 @@ func NewJobPostRestoreUnlockExecutable(job Job) executor.Executable {
 	return JobPostRestoreUnlockExecutor{job}
 }
 
-func (j JobPostRestoreUnlockExecutor) Execute() error {
-	return j.PostRestoreUnlock()
+func (j JobPostRestoreUnlockExecutor) Execute(ctx context.Context) error {
+	return j.PostRestoreUnlock(ctx)
 }
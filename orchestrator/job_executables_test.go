@@ -1,6 +1,7 @@
 package orchestrator_test
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/cloudfoundry/bosh-backup-and-restore/executor"
@@ -12,12 +13,14 @@ import (
 
 var _ = Describe("JobExecutables", func() {
 	var (
+		ctx        context.Context
 		fakeJob    *fakes.FakeJob
 		err        error
 		executable executor.Executable
 	)
 
 	BeforeEach(func() {
+		ctx = context.Background()
 		fakeJob = new(fakes.FakeJob)
 	})
 
@@ -26,11 +29,12 @@ var _ = Describe("JobExecutables", func() {
 			executable = orchestrator.NewJobPreBackupLockExecutable(fakeJob)
 		})
 		JustBeforeEach(func() {
-			err = executable.Execute()
+			err = executable.Execute(ctx)
 		})
 
 		It("executes pre backup lock", func() {
 			Expect(fakeJob.PreBackupLockCallCount()).To(Equal(1))
+			Expect(fakeJob.PreBackupLockArgsForCall(0)).To(Equal(ctx))
 		})
 
 		Context("when the pre backup lock fails", func() {
@@ -49,7 +53,7 @@ var _ = Describe("JobExecutables", func() {
 			executable = orchestrator.NewJobPostSuccessfulBackupUnlockExecutable(fakeJob)
 		})
 		JustBeforeEach(func() {
-			err = executable.Execute()
+			err = executable.Execute(ctx)
 		})
 
 		It("executes pre backup lock", func() {
@@ -73,7 +77,7 @@ var _ = Describe("JobExecutables", func() {
 			executable = orchestrator.NewJobPreRestoreLockExecutable(fakeJob)
 		})
 		JustBeforeEach(func() {
-			err = executable.Execute()
+			err = executable.Execute(ctx)
 		})
 
 		It("executes pre backup lock", func() {
@@ -97,7 +101,7 @@ var _ = Describe("JobExecutables", func() {
 			executable = orchestrator.NewJobPostRestoreUnlockExecutable(fakeJob)
 		})
 		JustBeforeEach(func() {
-			err = executable.Execute()
+			err = executable.Execute(ctx)
 		})
 
 		It("executes pre backup lock", func() {
@@ -8,9 +8,14 @@ type LockStep struct {
 }
 
 func (s *LockStep) Run(session *Session) error {
-	err := session.CurrentDeployment().PreBackupLock(s.lockOrderer, s.executor)
+	timedExecutor := newTimingExecutor(LockPhase, newTracingExecutor(session, "pre-backup-lock", s.executor))
+	err := session.CurrentDeployment().PreBackupLock(session.Context(), s.lockOrderer, timedExecutor)
+	timingsErr := timedExecutor.saveTo(session.CurrentArtifact())
 	if err != nil {
-		return NewLockError(err.Error())
+		return newLockErrorFrom(err)
+	}
+	if timingsErr != nil {
+		return NewLockError(timingsErr.Error())
 	}
 	return nil
 }
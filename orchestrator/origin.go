@@ -0,0 +1,39 @@
+package orchestrator
+
+// Origin identifies the machine a backup was taken from.
+type Origin struct {
+	Hostname           string
+	HostKeyFingerprint string
+	DirectorUUID       string
+}
+
+func (o Origin) IsZero() bool {
+	return o == Origin{}
+}
+
+// OriginVerifier is implemented by deployment managers that can tell whether
+// a backup was taken from the deployment they found, so that it is not
+// restored onto the wrong machine.
+type OriginVerifier interface {
+	VerifyOrigin(deploymentName string, backup Backup) error
+}
+
+type VerifyOriginStep struct {
+	deploymentManager DeploymentManager
+}
+
+func NewVerifyOriginStep(deploymentManager DeploymentManager) Step {
+	return &VerifyOriginStep{deploymentManager: deploymentManager}
+}
+
+func (s *VerifyOriginStep) Run(session *Session) error {
+	verifier, ok := s.deploymentManager.(OriginVerifier)
+	if !ok {
+		return nil
+	}
+
+	if err := verifier.VerifyOrigin(session.DeploymentName(), session.CurrentArtifact()); err != nil {
+		return NewPreCheckError(err.Error())
+	}
+	return nil
+}
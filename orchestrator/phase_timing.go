@@ -0,0 +1,97 @@
+package orchestrator
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/cloudfoundry/bosh-backup-and-restore/executor"
+)
+
+const (
+	LockPhase   = "lock"
+	BackupPhase = "backup"
+	UnlockPhase = "unlock"
+	DrainPhase  = "drain"
+)
+
+// PhaseTiming records how long a job took in a phase. Drain timings are
+// recorded per artifact instead, as an artifact with a custom name need not
+// be named after its job.
+type PhaseTiming struct {
+	Phase        string
+	JobName      string
+	ArtifactName string
+	Instance     string
+	StartTime    time.Time
+	FinishTime   time.Time
+}
+
+type ArtifactTransfer struct {
+	SizeInBytes int
+	Duration    time.Duration
+}
+
+func (t ArtifactTransfer) BytesPerSecond() int64 {
+	if t.Duration <= 0 {
+		return 0
+	}
+	return int64(float64(t.SizeInBytes) / t.Duration.Seconds())
+}
+
+type timingExecutor struct {
+	executor.Executor
+	phase   string
+	timings []PhaseTiming
+	sync.Mutex
+}
+
+func newTimingExecutor(phase string, exe executor.Executor) *timingExecutor {
+	return &timingExecutor{Executor: exe, phase: phase}
+}
+
+func (e *timingExecutor) Run(ctx context.Context, executablesList [][]executor.Executable) []error {
+	return e.Executor.Run(ctx, wrapExecutables(executablesList, func(executable executor.Executable) executor.Executable {
+		return timedExecutable{Executable: executable, recorder: e}
+	}))
+}
+
+func (e *timingExecutor) record(timing PhaseTiming) {
+	e.Lock()
+	defer e.Unlock()
+
+	timing.Phase = e.phase
+	e.timings = append(e.timings, timing)
+}
+
+func (e *timingExecutor) saveTo(backup Backup) error {
+	if backup == nil || len(e.timings) == 0 {
+		return nil
+	}
+	return backup.AddPhaseTimings(e.timings)
+}
+
+type timedExecutable struct {
+	executor.Executable
+	recorder *timingExecutor
+}
+
+func (e timedExecutable) Execute(ctx context.Context) error {
+	startTime := time.Now()
+	err := e.Executable.Execute(ctx)
+
+	if job, ok := asJob(e.Executable); ok {
+		e.recorder.record(PhaseTiming{
+			JobName:    job.Name(),
+			Instance:   job.InstanceIdentifier(),
+			StartTime:  startTime,
+			FinishTime: time.Now(),
+		})
+	}
+
+	return err
+}
+
+func (e timedExecutable) unwrap() executor.Executable {
+	return e.Executable
+}
@@ -17,9 +17,14 @@ func NewPostBackupUnlockStep(afterSuccessfulBackup bool, lockOrderer LockOrderer
 }
 
 func (s *PostBackupUnlockStep) Run(session *Session) error {
-	err := session.CurrentDeployment().PostBackupUnlock(s.afterSuccessfulBackup, s.lockOrderer, s.executor)
+	timedExecutor := newTimingExecutor(UnlockPhase, newTracingExecutor(session, "post-backup-unlock", s.executor))
+	err := session.CurrentDeployment().PostBackupUnlock(session.Context(), s.afterSuccessfulBackup, s.lockOrderer, timedExecutor)
+	timingsErr := timedExecutor.saveTo(session.CurrentArtifact())
 	if err != nil {
-		return NewPostUnlockError(err.Error())
+		return newPostUnlockErrorFrom(err)
+	}
+	if timingsErr != nil {
+		return NewPostUnlockError(timingsErr.Error())
 	}
 	return nil
 }
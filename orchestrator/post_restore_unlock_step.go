@@ -15,10 +15,10 @@ func NewPostRestoreUnlockStep(lockOrderer LockOrderer, executor executor.Executo
 }
 
 func (s *PostRestoreUnlockStep) Run(session *Session) error {
-	err := session.CurrentDeployment().PostRestoreUnlock(s.lockOrderer, s.executor)
+	err := session.CurrentDeployment().PostRestoreUnlock(session.Context(), s.lockOrderer, newTracingExecutor(session, "post-restore-unlock", s.executor))
 
 	if err != nil {
-		return NewPostUnlockError(err.Error())
+		return newPostUnlockErrorFrom(err)
 	}
 
 	return nil
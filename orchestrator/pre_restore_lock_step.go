@@ -18,10 +18,10 @@ func NewPreRestoreLockStep(lockOrderer LockOrderer, executor executor.Executor)
 }
 
 func (s *PreRestoreLockStep) Run(session *Session) error {
-	err := session.CurrentDeployment().PreRestoreLock(s.lockOrderer, s.executor)
+	err := session.CurrentDeployment().PreRestoreLock(session.Context(), s.lockOrderer, newTracingExecutor(session, "pre-restore-lock", s.executor))
 
 	if err != nil {
-		return errors.Wrap(err, "pre-restore-lock failed")
+		return newLockErrorFrom(errors.Wrap(err, "pre-restore-lock failed"))
 	}
 	return nil
 }
@@ -24,27 +24,27 @@ func (s *RestorableStep) Run(session *Session) error {
 		if instance.HasMetadataRestoreNames() {
 			errMsg := fmt.Sprintf("discontinued metadata keys backup_name/restore_name found on instance %s. bbr cannot restore this backup artifact.", instance.Name())
 			s.logger.Error("bbr", errMsg)
-			return errors.New(errMsg)
+			return NewPreCheckError(errMsg)
 		}
 	}
 
 	if !session.CurrentDeployment().IsRestorable() {
-		return errors.Errorf("Deployment '%s' has no restore scripts", session.DeploymentName())
+		return NewPreCheckError(fmt.Sprintf("Deployment '%s' has no restore scripts", session.DeploymentName()))
 	}
 
 	if match, err := session.CurrentArtifact().DeploymentMatches(session.DeploymentName(), session.CurrentDeployment().Instances()); err != nil {
-		return errors.Errorf("Unable to check if deployment '%s' matches the structure of the provided backup", session.DeploymentName())
+		return NewPreCheckError(fmt.Sprintf("Unable to check if deployment '%s' matches the structure of the provided backup", session.DeploymentName()))
 	} else if match != true { //nolint:staticcheck
-		return errors.Errorf("Deployment '%s' does not match the structure of the provided backup", session.DeploymentName())
+		return NewPreCheckError(fmt.Sprintf("Deployment '%s' does not match the structure of the provided backup", session.DeploymentName()))
 	}
 
-	err := session.CurrentDeployment().CheckArtifactDir()
+	err := session.CurrentDeployment().CheckArtifactDir(session.Context())
 	if err != nil {
-		return errors.Wrap(err, "Check artifact dir failed")
+		return NewArtifactDirError(errors.Wrap(err, "Check artifact dir failed").Error())
 	}
 
 	if err := session.CurrentDeployment().ValidateLockingDependencies(s.lockOrderer); err != nil {
-		return err
+		return NewPreCheckError(err.Error())
 	}
 
 	return nil
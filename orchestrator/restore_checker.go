@@ -0,0 +1,43 @@
+package orchestrator
+
+import "context"
+
+// RestoreChecker runs the checks that a restore starts with, without locking
+// the deployment or copying anything to it.
+type RestoreChecker struct {
+	*Workflow
+}
+
+func NewRestoreChecker(backupManager BackupManager, logger Logger, deploymentManager DeploymentManager, lockOrderer LockOrderer) *RestoreChecker {
+	validateArtifact := NewValidateArtifactStep(logger, backupManager)
+	findDeployment := NewFindDeploymentStep(deploymentManager, logger)
+	verifyOrigin := NewVerifyOriginStep(deploymentManager)
+	restorable := NewRestorableStep(lockOrderer, logger)
+	diskSpace := NewRestoreDiskSpaceStep(logger)
+	cleanup := NewCleanupStep()
+	workflow := NewWorkflow()
+
+	workflow.StartWith(validateArtifact).OnSuccess(findDeployment)
+	workflow.Add(findDeployment).OnSuccess(verifyOrigin)
+	workflow.Add(verifyOrigin).OnSuccessOrFailure(restorable)
+	workflow.Add(restorable).OnSuccessOrFailure(diskSpace)
+	workflow.Add(diskSpace).OnSuccessOrFailure(cleanup)
+	workflow.Add(cleanup)
+
+	return &RestoreChecker{
+		Workflow: workflow,
+	}
+}
+
+func (r RestoreChecker) Check(deploymentName, artifactPath string) Error {
+	return r.CheckWithContext(context.Background(), deploymentName, artifactPath)
+}
+
+// CheckWithContext stops checking the deployment when ctx is cancelled.
+func (r RestoreChecker) CheckWithContext(ctx context.Context, deploymentName, artifactPath string) Error {
+	session := NewSession(deploymentName)
+	session.SetCurrentArtifactPath(artifactPath)
+	session.SetArtifactDirectory(artifactPath)
+
+	return r.Workflow.Run(ctx, session) //nolint:staticcheck
+}
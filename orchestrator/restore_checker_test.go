@@ -0,0 +1,137 @@
+package orchestrator_test
+
+import (
+	"fmt"
+
+	"github.com/cloudfoundry/bosh-backup-and-restore/orchestrator"
+	"github.com/cloudfoundry/bosh-backup-and-restore/orchestrator/fakes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RestoreChecker", func() {
+	var (
+		checker           *orchestrator.RestoreChecker
+		backupManager     *fakes.FakeBackupManager
+		backup            *fakes.FakeBackup
+		deploymentManager *fakes.FakeDeploymentManager
+		deployment        *fakes.FakeDeployment
+		lockOrderer       *fakes.FakeLockOrderer
+		logger            *fakes.FakeLogger
+		instance          *fakes.FakeInstance
+		artifact          *fakes.FakeBackupArtifact
+		deploymentName    = "deployment-to-restore"
+		artifactPath      = "/some/path"
+		checkError        orchestrator.Error
+	)
+
+	BeforeEach(func() {
+		backupManager = new(fakes.FakeBackupManager)
+		backup = new(fakes.FakeBackup)
+		deploymentManager = new(fakes.FakeDeploymentManager)
+		deployment = new(fakes.FakeDeployment)
+		lockOrderer = new(fakes.FakeLockOrderer)
+		logger = new(fakes.FakeLogger)
+
+		artifact = new(fakes.FakeBackupArtifact)
+		artifact.NameReturns("redis")
+		instance = new(fakes.FakeInstance)
+		instance.NameReturns("redis-server")
+		instance.IDReturns("redis-server-id")
+		instance.ArtifactsToRestoreReturns([]orchestrator.BackupArtifact{artifact})
+		instance.FreeSpaceInBytesReturns(4096, nil)
+
+		backupManager.OpenReturns(backup, nil)
+		backup.ValidReturns(true, nil)
+		backup.DeploymentMatchesReturns(true, nil)
+		backup.GetArtifactByteSizeReturns(2048, nil)
+		deploymentManager.FindReturns(deployment, nil)
+		deployment.IsRestorableReturns(true)
+		deployment.InstancesReturns([]orchestrator.Instance{instance})
+		deployment.RestorableInstancesReturns([]orchestrator.Instance{instance})
+	})
+
+	JustBeforeEach(func() {
+		checker = orchestrator.NewRestoreChecker(backupManager, logger, deploymentManager, lockOrderer)
+		checkError = checker.Check(deploymentName, artifactPath)
+	})
+
+	It("succeeds", func() {
+		Expect(checkError).NotTo(HaveOccurred())
+	})
+
+	It("validates the artifact and checks the deployment can be restored from it", func() {
+		openedPath, _ := backupManager.OpenArgsForCall(0)
+		Expect(openedPath).To(Equal(artifactPath))
+		Expect(backup.ValidCallCount()).To(Equal(1))
+		_, name := deploymentManager.FindArgsForCall(0)
+		Expect(name).To(Equal(deploymentName))
+		Expect(deployment.IsRestorableCallCount()).To(Equal(1))
+		Expect(backup.DeploymentMatchesCallCount()).To(Equal(1))
+		Expect(deployment.CheckArtifactDirCallCount()).To(Equal(1))
+		Expect(deployment.ValidateLockingDependenciesCallCount()).To(Equal(1))
+	})
+
+	It("checks each instance has room for its artifacts", func() {
+		Expect(backup.GetArtifactByteSizeArgsForCall(0)).To(Equal(artifact))
+		Expect(instance.FreeSpaceInBytesCallCount()).To(Equal(1))
+	})
+
+	It("does not lock, copy to or restore the deployment", func() {
+		Expect(deployment.PreRestoreLockCallCount()).To(BeZero())
+		Expect(deployment.RestoreCallCount()).To(BeZero())
+		Expect(deployment.PostRestoreUnlockCallCount()).To(BeZero())
+		Expect(artifact.StreamToRemoteCallCount()).To(BeZero())
+	})
+
+	It("cleans up the deployment", func() {
+		Expect(deployment.CleanupCallCount()).To(Equal(1))
+	})
+
+	Context("when the artifact is not valid", func() {
+		BeforeEach(func() {
+			backup.ValidReturns(false, nil)
+		})
+
+		It("stops before finding the deployment", func() {
+			Expect(checkError).To(MatchError(ContainSubstring("Backup is corrupted")))
+			Expect(deploymentManager.FindCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when an instance does not have room for its artifacts", func() {
+		BeforeEach(func() {
+			instance.FreeSpaceInBytesReturns(1024, nil)
+		})
+
+		It("reports the instance", func() {
+			Expect(checkError).To(MatchError(ContainSubstring("Instance redis-server/redis-server-id needs 2.0K in /var/vcap/store/bbr-backup but only 1.0K is free")))
+			Expect(deployment.CleanupCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when the deployment does not match the backup and does not have room for it", func() {
+		BeforeEach(func() {
+			backup.DeploymentMatchesReturns(false, nil)
+			instance.FreeSpaceInBytesReturns(1024, nil)
+		})
+
+		It("reports both problems", func() {
+			Expect(checkError).To(HaveLen(2))
+			Expect(checkError).To(MatchError(ContainSubstring("Deployment 'deployment-to-restore' does not match the structure of the provided backup")))
+			Expect(checkError).To(MatchError(ContainSubstring("needs 2.0K")))
+		})
+	})
+
+	Context("when the size of an artifact is unknown", func() {
+		BeforeEach(func() {
+			backup.GetArtifactByteSizeReturns(0, fmt.Errorf("no such file"))
+		})
+
+		It("warns and does not check that instance", func() {
+			Expect(checkError).NotTo(HaveOccurred())
+			Expect(instance.FreeSpaceInBytesCallCount()).To(BeZero())
+			Expect(logger.WarnCallCount()).To(Equal(1))
+		})
+	})
+})
@@ -1,6 +1,10 @@
 package orchestrator
 
-import "github.com/cloudfoundry/bosh-backup-and-restore/executor"
+import (
+	"context"
+
+	"github.com/cloudfoundry/bosh-backup-and-restore/executor"
+)
 
 func NewRestoreCleaner(logger Logger, deploymentManager DeploymentManager, lockOrderer LockOrderer, executor executor.Executor) *RestoreCleaner {
 	workflow := NewWorkflow()
@@ -24,8 +28,13 @@ type RestoreCleaner struct {
 }
 
 func (c RestoreCleaner) Cleanup(deploymentName string) Error {
+	return c.CleanupWithContext(context.Background(), deploymentName)
+}
+
+// CleanupWithContext stops cleaning up the deployment when ctx is cancelled.
+func (c RestoreCleaner) CleanupWithContext(ctx context.Context, deploymentName string) Error {
 	session := NewSession(deploymentName)
-	currentError := c.Workflow.Run(session) //nolint:staticcheck
+	currentError := c.Workflow.Run(ctx, session) //nolint:staticcheck
 
 	if len(currentError) == 0 {
 		c.Logger.Info("bbr", "'%s' cleaned up\n", deploymentName) //nolint:staticcheck
@@ -11,10 +11,10 @@ func NewRestoreStep(logger Logger) Step {
 }
 
 func (s *RestoreStep) Run(session *Session) error {
-	err := session.CurrentDeployment().Restore()
+	err := session.CurrentDeployment().Restore(session.Context())
 
 	if err != nil {
-		return errors.Wrap(err, "Failed to restore")
+		return newRestoreErrorFrom(errors.Wrap(err, "Failed to restore"))
 	}
 
 	s.logger.Info("bbr", "Completed restore of %s\n", session.DeploymentName())
@@ -1,39 +1,63 @@
 package orchestrator
 
-import "github.com/cloudfoundry/bosh-backup-and-restore/executor"
+import (
+	"context"
+
+	"github.com/cloudfoundry/bosh-backup-and-restore/executor"
+	"github.com/cloudfoundry/bosh-backup-and-restore/tracing"
+)
 
 type Restorer struct {
 	workflow *Workflow
 }
 
 func NewRestorer(backupManager BackupManager, logger Logger, deploymentManager DeploymentManager,
-	lockOrderer LockOrderer, executor executor.Executor, artifactCopier ArtifactCopier) *Restorer {
+	lockOrderer LockOrderer, executor executor.Executor, artifactCopier ArtifactCopier, hooks Hooks, hookRunner HookRunner) *Restorer {
 	workflow := NewWorkflow()
 	validateArtifactStep := NewValidateArtifactStep(logger, backupManager)
 	findDeploymentStep := NewFindDeploymentStep(deploymentManager, logger)
+	verifyOriginStep := NewVerifyOriginStep(deploymentManager)
 	restorableStep := NewRestorableStep(lockOrderer, logger)
 	cleanupStep := NewCleanupStep()
 	copyToRemoteStep := NewCopyToRemoteStep(artifactCopier)
 	preRestoreLockStep := NewPreRestoreLockStep(lockOrderer, executor)
 	restoreStep := NewRestoreStep(logger)
-	postRestoreUnlockStep := NewPostRestoreUnlockStep(lockOrderer, executor)
+	postRestoreUnlockAfterSuccessfulRestore := NewPostRestoreUnlockStep(lockOrderer, executor)
+	postRestoreUnlockAfterFailedRestore := NewPostRestoreUnlockStep(lockOrderer, executor)
+	preRestoreHook := NewHookStep(PreRestoreHook, "restore", hooks.PreRestore, hookRunner, logger)
+	postRestoreHook := NewHookStep(PostRestoreHook, "restore", hooks.PostRestore, hookRunner, logger)
 
 	workflow.StartWith(validateArtifactStep).OnSuccess(findDeploymentStep)
-	workflow.Add(findDeploymentStep).OnSuccess(restorableStep)
-	workflow.Add(restorableStep).OnSuccess(copyToRemoteStep).OnFailure(cleanupStep)
+	workflow.Add(findDeploymentStep).OnSuccess(verifyOriginStep)
+	workflow.Add(verifyOriginStep).OnSuccess(restorableStep).OnFailure(cleanupStep)
+	workflow.Add(restorableStep).OnSuccess(preRestoreHook).OnFailure(cleanupStep)
+	workflow.Add(preRestoreHook).OnSuccess(copyToRemoteStep).OnFailure(cleanupStep)
 	workflow.Add(copyToRemoteStep).OnSuccess(preRestoreLockStep).OnFailure(cleanupStep)
-	workflow.Add(preRestoreLockStep).OnSuccess(restoreStep).OnFailure(postRestoreUnlockStep)
-	workflow.Add(restoreStep).OnSuccess(postRestoreUnlockStep).OnFailure(postRestoreUnlockStep)
-	workflow.Add(postRestoreUnlockStep).OnSuccessOrFailure(cleanupStep)
-	workflow.Add(cleanupStep)
+	workflow.Add(preRestoreLockStep).OnSuccess(restoreStep).OnFailure(postRestoreUnlockAfterFailedRestore)
+	workflow.Add(restoreStep).OnSuccess(postRestoreUnlockAfterSuccessfulRestore).OnFailure(postRestoreUnlockAfterFailedRestore)
+	workflow.Add(postRestoreUnlockAfterSuccessfulRestore).OnSuccess(postRestoreHook).OnFailure(cleanupStep).RunWhenAborted()
+	workflow.Add(postRestoreUnlockAfterFailedRestore).OnSuccessOrFailure(cleanupStep).RunWhenAborted()
+	workflow.Add(postRestoreHook).OnSuccessOrFailure(cleanupStep)
+	workflow.Add(cleanupStep).RunWhenAborted()
 	return &Restorer{
 		workflow: workflow,
 	}
 }
 
 func (r Restorer) Restore(deploymentName, backupPath string) Error {
+	return r.RestoreWithContext(context.Background(), deploymentName, backupPath)
+}
+
+// RestoreWithContext aborts the restore when ctx is cancelled, unlocking and
+// cleaning up the deployment before returning.
+func (r Restorer) RestoreWithContext(ctx context.Context, deploymentName, backupPath string) Error {
 	session := NewSession(deploymentName)
 	session.SetCurrentArtifactPath(backupPath)
+	session.SetArtifactDirectory(backupPath)
+
+	ctx, span := tracing.Start(ctx, "restore", tracing.DeploymentKey.String(deploymentName))
+	err := r.workflow.Run(ctx, session)
+	tracing.End(span, ConvertErrors(err))
 
-	return r.workflow.Run(session)
+	return err
 }
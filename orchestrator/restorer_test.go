@@ -1,6 +1,7 @@
 package orchestrator_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
@@ -23,10 +24,14 @@ var _ = Describe("restorer", func() {
 			b                 *orchestrator.Restorer
 			deploymentName    string
 			deploymentManager *fakes.FakeDeploymentManager
+			restoreManager    orchestrator.DeploymentManager
 			deployment        *fakes.FakeDeployment
 			artifactPath      string
 			lockOrderer       *fakes.FakeLockOrderer
 			artifactCopier    *fakes.FakeArtifactCopier
+			hooks             orchestrator.Hooks
+			hookRunner        *fakes.FakeHookRunner
+			ctx               context.Context
 		)
 
 		BeforeEach(func() {
@@ -35,9 +40,12 @@ var _ = Describe("restorer", func() {
 			artifactManager = new(fakes.FakeBackupManager)
 			artifact = new(fakes.FakeBackup)
 			deploymentManager = new(fakes.FakeDeploymentManager)
+			restoreManager = deploymentManager
 			deployment = new(fakes.FakeDeployment)
 			lockOrderer = new(fakes.FakeLockOrderer)
 			artifactCopier = new(fakes.FakeArtifactCopier)
+			hooks = orchestrator.Hooks{}
+			hookRunner = new(fakes.FakeHookRunner)
 
 			artifactManager.OpenReturns(artifact, nil)
 			deploymentManager.FindReturns(deployment, nil)
@@ -46,14 +54,14 @@ var _ = Describe("restorer", func() {
 			artifact.DeploymentMatchesReturns(true, nil)
 			artifact.ValidReturns(true, nil)
 
-			b = orchestrator.NewRestorer(artifactManager, logger, deploymentManager, lockOrderer, executor.NewSerialExecutor(), artifactCopier)
-
 			deploymentName = "deployment-to-restore"
 			artifactPath = "/some/path"
+			ctx = context.Background()
 		})
 
 		JustBeforeEach(func() {
-			restoreError = b.Restore(deploymentName, artifactPath)
+			b = orchestrator.NewRestorer(artifactManager, logger, restoreManager, lockOrderer, executor.NewSerialExecutor(), artifactCopier, hooks, hookRunner)
+			restoreError = b.RestoreWithContext(ctx, deploymentName, artifactPath)
 		})
 
 		It("does not fail", func() {
@@ -76,7 +84,8 @@ var _ = Describe("restorer", func() {
 
 		It("finds the deployment", func() {
 			Expect(deploymentManager.FindCallCount()).To(Equal(1))
-			Expect(deploymentManager.FindArgsForCall(0)).To(Equal(deploymentName))
+			_, name := deploymentManager.FindArgsForCall(0)
+			Expect(name).To(Equal(deploymentName))
 		})
 
 		It("checks if the deployment is restorable", func() {
@@ -95,7 +104,7 @@ var _ = Describe("restorer", func() {
 		It("streams the local backup to the deployment", func() {
 			Expect(artifactCopier.UploadBackupToDeploymentCallCount()).To(Equal(1))
 
-			uploadedArtifact, uploadedToDeployment := artifactCopier.UploadBackupToDeploymentArgsForCall(0)
+			_, uploadedArtifact, uploadedToDeployment := artifactCopier.UploadBackupToDeploymentArgsForCall(0)
 			Expect(uploadedArtifact).To(Equal(artifact))
 			Expect(uploadedToDeployment).To(Equal(deployment))
 		})
@@ -112,6 +121,115 @@ var _ = Describe("restorer", func() {
 			Expect(deployment.PostRestoreUnlockCallCount()).To(Equal(1))
 		})
 
+		Context("with hooks", func() {
+			var runOrder []string
+
+			BeforeEach(func() {
+				runOrder = nil
+				hooks = orchestrator.Hooks{
+					PreRestore:  []string{"pause-scheduler"},
+					PostRestore: []string{"resume-scheduler"},
+				}
+				hookRunner.RunStub = func(_ context.Context, command string, _ []string) error {
+					runOrder = append(runOrder, command)
+					return nil
+				}
+				artifactCopier.UploadBackupToDeploymentStub = func(context.Context, orchestrator.Backup, orchestrator.Deployment) error {
+					runOrder = append(runOrder, "upload")
+					return nil
+				}
+				deployment.PostRestoreUnlockStub = func(context.Context, orchestrator.LockOrderer, executor.Executor) error {
+					runOrder = append(runOrder, "unlock")
+					return nil
+				}
+			})
+
+			It("runs each hook at its point in the workflow", func() {
+				Expect(restoreError).NotTo(HaveOccurred())
+				Expect(runOrder).To(Equal([]string{"pause-scheduler", "upload", "unlock", "resume-scheduler"}))
+			})
+
+			It("describes the deployment and artifact to the hooks", func() {
+				_, _, env := hookRunner.RunArgsForCall(1)
+				Expect(env).To(ConsistOf(
+					"BBR_HOOK=post-restore",
+					"BBR_OPERATION=restore",
+					"BBR_DEPLOYMENT="+deploymentName,
+					"BBR_ARTIFACT_PATH="+artifactPath,
+				))
+			})
+
+			Context("if the restore fails", func() {
+				BeforeEach(func() {
+					deployment.RestoreReturns(fmt.Errorf("I will not restore this thing"))
+				})
+
+				It("does not run the post-restore hook", func() {
+					Expect(runOrder).To(Equal([]string{"pause-scheduler", "upload", "unlock"}))
+				})
+			})
+
+			Context("if the pre-restore hook fails", func() {
+				BeforeEach(func() {
+					hookRunner.RunReturns(fmt.Errorf("scheduler unreachable"))
+					hookRunner.RunStub = nil
+				})
+
+				It("returns a hook error", func() {
+					Expect(restoreError).To(ConsistOf(BeAssignableToTypeOf(orchestrator.HookError{})))
+					Expect(restoreError).To(MatchError(ContainSubstring("pre-restore hook `pause-scheduler` failed: scheduler unreachable")))
+				})
+
+				It("does not touch the deployment and cleans up", func() {
+					Expect(artifactCopier.UploadBackupToDeploymentCallCount()).To(BeZero())
+					Expect(deployment.PreRestoreLockCallCount()).To(BeZero())
+					Expect(deployment.CleanupCallCount()).To(Equal(1))
+				})
+			})
+
+			Context("if the post-restore hook fails", func() {
+				BeforeEach(func() {
+					hookRunner.RunStub = func(_ context.Context, command string, _ []string) error {
+						if command == "resume-scheduler" {
+							return fmt.Errorf("scheduler unreachable")
+						}
+						return nil
+					}
+				})
+
+				It("returns a hook error and cleans up", func() {
+					Expect(restoreError).To(ConsistOf(BeAssignableToTypeOf(orchestrator.HookError{})))
+					Expect(deployment.CleanupCallCount()).To(Equal(1))
+				})
+			})
+		})
+
+		Context("when the restore is aborted while locking", func() {
+			BeforeEach(func() {
+				var cancel context.CancelCauseFunc
+				ctx, cancel = context.WithCancelCause(context.Background())
+
+				deployment.PreRestoreLockStub = func(context.Context, orchestrator.LockOrderer, executor.Executor) error {
+					cancel(errors.New("received interrupt"))
+					return nil
+				}
+			})
+
+			It("returns an abort error", func() {
+				Expect(restoreError).To(ConsistOf(BeAssignableToTypeOf(orchestrator.AbortError{})))
+				Expect(restoreError).To(MatchError(ContainSubstring("Aborted: received interrupt")))
+			})
+
+			It("does not restore the deployment", func() {
+				Expect(deployment.RestoreCallCount()).To(BeZero())
+			})
+
+			It("unlocks and cleans up the deployment", func() {
+				Expect(deployment.PostRestoreUnlockCallCount()).To(Equal(1))
+				Expect(deployment.CleanupCallCount()).To(Equal(1))
+			})
+		})
+
 		Describe("failures", func() {
 
 			var assertCleanupError = func() {
@@ -154,6 +272,7 @@ var _ = Describe("restorer", func() {
 				})
 				It("returns an error", func() {
 					Expect(restoreError).To(MatchError(ContainSubstring("Backup is corrupted")))
+					Expect(restoreError).To(ContainElement(BeAssignableToTypeOf(orchestrator.ChecksumError{})))
 				})
 			})
 
@@ -188,6 +307,36 @@ var _ = Describe("restorer", func() {
 				})
 			})
 
+			Context("if the deployment manager refuses the origin of the backup", func() {
+				var verifier *originVerifyingDeploymentManager
+
+				BeforeEach(func() {
+					verifier = &originVerifyingDeploymentManager{
+						FakeDeploymentManager: deploymentManager,
+						err:                   fmt.Errorf("taken from a different director"),
+					}
+					restoreManager = verifier
+				})
+
+				It("verifies the origin of the opened backup", func() {
+					Expect(verifier.deploymentName).To(Equal(deploymentName))
+					Expect(verifier.backup).To(Equal(artifact))
+				})
+
+				It("returns an error", func() {
+					Expect(restoreError).To(MatchError(ContainSubstring("taken from a different director")))
+				})
+
+				It("does not restore", func() {
+					Expect(artifactCopier.UploadBackupToDeploymentCallCount()).To(BeZero())
+					Expect(deployment.RestoreCallCount()).To(BeZero())
+				})
+
+				It("should cleanup", func() {
+					Expect(deployment.CleanupCallCount()).To(Equal(1))
+				})
+			})
+
 			Context("if deployment not restorable", func() {
 				BeforeEach(func() {
 					deployment.IsRestorableReturns(false)
@@ -246,6 +395,7 @@ var _ = Describe("restorer", func() {
 
 				It("returns an error with the name of the instance with the extant backup artifact", func() {
 					Expect(restoreError).To(MatchError(ContainSubstring("this is a problem")))
+					Expect(restoreError).To(ContainElement(BeAssignableToTypeOf(orchestrator.ArtifactDirError{})))
 				})
 
 				It("cleans up", func() {
@@ -261,6 +411,7 @@ var _ = Describe("restorer", func() {
 
 				It("returns an error", func() {
 					Expect(restoreError).To(MatchError(ContainSubstring("Unable to send backup to remote machine. Got error: Broken pipe")))
+					Expect(restoreError).To(ContainElement(BeAssignableToTypeOf(orchestrator.TransferError{})))
 				})
 
 				It("should cleanup", func() {
@@ -279,6 +430,7 @@ var _ = Describe("restorer", func() {
 
 				It("returns an error", func() {
 					Expect(restoreError).To(MatchError(ContainSubstring(expectedPreRestoreLockError.Error())))
+					Expect(restoreError).To(ContainElement(BeAssignableToTypeOf(orchestrator.LockError{})))
 				})
 
 				It("should run post-restore-unlock script", func() {
@@ -310,6 +462,7 @@ var _ = Describe("restorer", func() {
 
 				It("returns an error", func() {
 					Expect(restoreError).To(MatchError(ContainSubstring("Failed to restore: I will not restore this thing")))
+					Expect(restoreError).To(ContainElement(BeAssignableToTypeOf(orchestrator.RestoreError{})))
 				})
 
 				It("should cleanup", func() {
@@ -338,3 +491,16 @@ var _ = Describe("restorer", func() {
 		})
 	})
 })
+
+type originVerifyingDeploymentManager struct {
+	*fakes.FakeDeploymentManager
+	err            error
+	deploymentName string
+	backup         orchestrator.Backup
+}
+
+func (m *originVerifyingDeploymentManager) VerifyOrigin(deploymentName string, backup orchestrator.Backup) error {
+	m.deploymentName = deploymentName
+	m.backup = backup
+	return m.err
+}
@@ -1,14 +1,18 @@
 package orchestrator
 
+import "context"
+
 type Session struct {
+	ctx                 context.Context
 	deploymentName      string
 	deployment          Deployment
 	currentArtifact     Backup
 	currentArtifactPath string
+	artifactDirectory   string
 }
 
 func NewSession(deploymentName string) *Session {
-	return &Session{deploymentName: deploymentName}
+	return &Session{ctx: context.Background(), deploymentName: deploymentName}
 }
 
 func (session *Session) SetCurrentArtifact(artifact Backup) {
@@ -38,3 +42,24 @@ func (session *Session) SetCurrentArtifactPath(artifactPath string) {
 func (session *Session) CurrentArtifactPath() string {
 	return session.currentArtifactPath
 }
+
+func (session *Session) SetArtifactDirectory(artifactDirectory string) {
+	session.artifactDirectory = artifactDirectory
+}
+
+// ArtifactDirectory is the directory holding the artifact being backed up or
+// restored, whereas CurrentArtifactPath is the path requested by the user.
+func (session *Session) ArtifactDirectory() string {
+	return session.artifactDirectory
+}
+
+// Context carries the span of the workflow step that is currently running, so
+// that work done by the step is traced as part of it. Cancelling it aborts the
+// workflow.
+func (session *Session) Context() context.Context {
+	return session.ctx
+}
+
+func (session *Session) SetContext(ctx context.Context) {
+	session.ctx = ctx
+}
@@ -0,0 +1,27 @@
+package orchestrator
+
+import "context"
+
+// StepObserver is told about the steps of the workflows run with it in their
+// context, which it must not block.
+//
+//counterfeiter:generate -o fakes/fake_step_observer.go . StepObserver
+type StepObserver interface {
+	StepStarted(deployment, step string)
+	StepFinished(deployment, step string, err error)
+}
+
+type stepObserverKey struct{}
+
+// ContextWithStepObserver returns a copy of ctx that reports the steps of
+// the workflows run with it to observer.
+func ContextWithStepObserver(ctx context.Context, observer StepObserver) context.Context {
+	return context.WithValue(ctx, stepObserverKey{}, observer)
+}
+
+func stepObserverFrom(ctx context.Context) StepObserver {
+	if observer, ok := ctx.Value(stepObserverKey{}).(StepObserver); ok {
+		return observer
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudfoundry/bosh-backup-and-restore/executor"
+	"github.com/cloudfoundry/bosh-backup-and-restore/tracing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// jobIdentifier is implemented by the executables that run a job's scripts.
+type jobIdentifier interface {
+	Name() string
+	InstanceIdentifier() string
+}
+
+type wrappedExecutable interface {
+	unwrap() executor.Executable
+}
+
+// asJob finds the job run by executable, looking through any executors that
+// have decorated it.
+func asJob(executable executor.Executable) (jobIdentifier, bool) {
+	for {
+		if job, ok := executable.(jobIdentifier); ok {
+			return job, true
+		}
+		wrapped, ok := executable.(wrappedExecutable)
+		if !ok {
+			return nil, false
+		}
+		executable = wrapped.unwrap()
+	}
+}
+
+func wrapExecutables(executablesList [][]executor.Executable, wrap func(executor.Executable) executor.Executable) [][]executor.Executable {
+	var wrappedExecutablesList [][]executor.Executable
+	for _, executables := range executablesList {
+		var wrappedExecutables []executor.Executable
+		for _, executable := range executables {
+			wrappedExecutables = append(wrappedExecutables, wrap(executable))
+		}
+		wrappedExecutablesList = append(wrappedExecutablesList, wrappedExecutables)
+	}
+	return wrappedExecutablesList
+}
+
+type tracingExecutor struct {
+	executor.Executor
+	spanName   string
+	deployment string
+}
+
+// newTracingExecutor returns an executor that traces each executable as a
+// child of the span in the context it is run with, which is usually that of
+// the step running in session.
+func newTracingExecutor(session *Session, spanName string, exe executor.Executor) tracingExecutor {
+	return tracingExecutor{
+		Executor:   exe,
+		spanName:   spanName,
+		deployment: session.DeploymentName(),
+	}
+}
+
+func (e tracingExecutor) Run(ctx context.Context, executablesList [][]executor.Executable) []error {
+	return e.Executor.Run(ctx, wrapExecutables(executablesList, func(executable executor.Executable) executor.Executable {
+		return tracedExecutable{Executable: executable, tracer: e}
+	}))
+}
+
+type tracedExecutable struct {
+	executor.Executable
+	tracer tracingExecutor
+}
+
+func (e tracedExecutable) Execute(ctx context.Context) error {
+	attributes := []attribute.KeyValue{tracing.DeploymentKey.String(e.tracer.deployment)}
+	if job, ok := asJob(e.Executable); ok {
+		attributes = append(attributes, tracing.JobKey.String(job.Name()), tracing.InstanceKey.String(job.InstanceIdentifier()))
+	}
+
+	ctx, span := tracing.Start(ctx, e.tracer.spanName, attributes...)
+	err := e.Executable.Execute(ctx)
+	tracing.End(span, err)
+
+	return err
+}
+
+func (e tracedExecutable) unwrap() executor.Executable {
+	return e.Executable
+}
+
+func artifactAttributes(artifact ArtifactIdentifier) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		tracing.ArtifactKey.String(artifact.Name()),
+		tracing.InstanceKey.String(fmt.Sprintf("%s/%s", artifact.InstanceName(), artifact.InstanceID())),
+	}
+}
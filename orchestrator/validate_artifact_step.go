@@ -25,7 +25,7 @@ func (s *ValidateArtifactStep) Run(session *Session) error {
 	if valid, err := backup.Valid(); err != nil {
 		return errors.Wrap(err, "Could not validate backup")
 	} else if !valid {
-		return errors.Errorf("Backup is corrupted")
+		return NewChecksumError("Backup is corrupted")
 	}
 	return nil
 }
@@ -1,5 +1,13 @@
 package orchestrator
 
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/cloudfoundry/bosh-backup-and-restore/tracing"
+)
+
 type Workflow struct {
 	StartingNode *Node
 	Nodes        []*Node
@@ -9,13 +17,38 @@ func NewWorkflow() *Workflow {
 	return &Workflow{}
 }
 
-func (workflow *Workflow) Run(session *Session) Error {
+// Run runs the steps of the workflow with ctx. Once ctx is cancelled, only
+// the nodes that run when aborted are run, by following the failure
+// transitions of the nodes that are skipped. Those nodes run with a context
+// that is no longer cancelled, so that they can unlock and clean up the
+// deployment. The error of a step that fails once ctx is cancelled is kept
+// alongside the abort, which decides the exit code.
+func (workflow *Workflow) Run(ctx context.Context, session *Session) Error {
 	var errs Error
+	aborted := false
+	abort := func() {
+		errs = append(errs, NewAbortError(fmt.Sprintf("Aborted: %s", context.Cause(ctx))))
+		aborted = true
+		session.SetContext(context.WithoutCancel(session.Context()))
+	}
+
 	currentNode := workflow.StartingNode
+	session.SetContext(ctx)
 
 	for currentNode != nil {
-		err := currentNode.step.Run(session)
+		if !aborted && ctx.Err() != nil {
+			abort()
+		}
+		if aborted && !currentNode.runWhenAborted {
+			currentNode = workflow.findNode(currentNode.failStep)
+			continue
+		}
+
+		err := runTracedStep(currentNode.step, session)
 		if err != nil {
+			if !aborted && ctx.Err() != nil {
+				abort()
+			}
 			errs = append(errs, err)
 			currentNode = workflow.findNode(currentNode.failStep)
 		} else {
@@ -26,6 +59,33 @@ func (workflow *Workflow) Run(session *Session) Error {
 	return errs
 }
 
+func runTracedStep(step Step, session *Session) error {
+	parentCtx := session.Context()
+	stepCtx, span := tracing.Start(parentCtx, stepName(step), tracing.DeploymentKey.String(session.DeploymentName()))
+	observer := stepObserverFrom(parentCtx)
+	if observer != nil {
+		observer.StepStarted(session.DeploymentName(), stepName(step))
+	}
+
+	session.SetContext(stepCtx)
+	err := step.Run(session)
+	session.SetContext(parentCtx)
+
+	if observer != nil {
+		observer.StepFinished(session.DeploymentName(), stepName(step), err)
+	}
+	tracing.End(span, err)
+	return err
+}
+
+func stepName(step Step) string {
+	stepType := reflect.TypeOf(step)
+	if stepType.Kind() == reflect.Ptr {
+		stepType = stepType.Elem()
+	}
+	return stepType.Name()
+}
+
 func (workflow *Workflow) findNode(step Step) *Node {
 	if step == nil {
 		return nil
@@ -56,9 +116,10 @@ type Step interface {
 }
 
 type Node struct {
-	step        Step
-	successStep Step
-	failStep    Step
+	step           Step
+	successStep    Step
+	failStep       Step
+	runWhenAborted bool
 }
 
 func NewNode(step Step) *Node {
@@ -77,3 +138,10 @@ func (node *Node) OnSuccess(successStep Step) *Node {
 	node.successStep = successStep
 	return node
 }
+
+// RunWhenAborted keeps the node running after the workflow is aborted, for
+// steps that leave the deployment unlocked and clean.
+func (node *Node) RunWhenAborted() *Node {
+	node.runWhenAborted = true
+	return node
+}
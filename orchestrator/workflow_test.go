@@ -0,0 +1,96 @@
+package orchestrator_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/cloudfoundry/bosh-backup-and-restore/orchestrator"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type funcStep struct {
+	run func(*orchestrator.Session) error
+}
+
+func (s *funcStep) Run(session *orchestrator.Session) error {
+	return s.run(session)
+}
+
+var _ = Describe("Workflow", func() {
+	var (
+		ctx       context.Context
+		cancel    context.CancelCauseFunc
+		ranSteps  []string
+		lockStep  *funcStep
+		backup    *funcStep
+		cleanup   *funcStep
+		workflow  *orchestrator.Workflow
+		runErrors orchestrator.Error
+	)
+
+	step := func(name string, err error) *funcStep {
+		return &funcStep{run: func(*orchestrator.Session) error {
+			ranSteps = append(ranSteps, name)
+			return err
+		}}
+	}
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancelCause(context.Background())
+		ranSteps = nil
+		backup = step("backup", nil)
+		cleanup = step("cleanup", nil)
+		lockStep = step("lock", nil)
+	})
+
+	JustBeforeEach(func() {
+		workflow = orchestrator.NewWorkflow()
+		workflow.StartWith(lockStep).OnSuccess(backup).OnFailure(cleanup)
+		workflow.Add(backup).OnSuccessOrFailure(cleanup)
+		workflow.Add(cleanup).RunWhenAborted()
+
+		runErrors = workflow.Run(ctx, orchestrator.NewSession("redis"))
+	})
+
+	Context("when a step fails while the context is cancelled", func() {
+		BeforeEach(func() {
+			lockStep = &funcStep{run: func(*orchestrator.Session) error {
+				ranSteps = append(ranSteps, "lock")
+				cancel(errors.New("received terminated"))
+				return orchestrator.NewLockError("lock script failed on redis/0")
+			}}
+		})
+
+		It("reports the failure of the step alongside the abort", func() {
+			Expect(runErrors).To(ConsistOf(
+				MatchError("Aborted: received terminated"),
+				MatchError("lock script failed on redis/0"),
+			))
+			Expect(runErrors[1]).To(BeAssignableToTypeOf(orchestrator.LockError{}))
+			category, _ := orchestrator.FailureCategory(runErrors)
+			Expect(category).To(Equal(orchestrator.AbortCategory))
+			Expect(orchestrator.BuildExitCode(runErrors)).To(Equal(99 | orchestrator.ExitCodeLock))
+		})
+
+		It("only runs the steps that run when aborted", func() {
+			Expect(ranSteps).To(Equal([]string{"lock", "cleanup"}))
+		})
+	})
+
+	Context("when the context is cancelled between steps", func() {
+		BeforeEach(func() {
+			lockStep = &funcStep{run: func(*orchestrator.Session) error {
+				ranSteps = append(ranSteps, "lock")
+				cancel(errors.New("received terminated"))
+				return nil
+			}}
+		})
+
+		It("only reports the abort", func() {
+			Expect(runErrors).To(ConsistOf(MatchError("Aborted: received terminated")))
+			Expect(ranSteps).To(Equal([]string{"lock", "cleanup"}))
+		})
+	})
+})
@@ -0,0 +1,26 @@
+package readwriter
+
+import (
+	"fmt"
+	"math"
+)
+
+// HumanReadableSize formats size like du -h does, e.g. 1.5M or 12G.
+func HumanReadableSize(size int) string {
+	units := []string{"B", "K", "M", "G", "T", "P"}
+
+	value := float64(size)
+	unit := 0
+	for value >= 1024 && unit < len(units)-1 {
+		value /= 1024
+		unit++
+	}
+
+	if unit == 0 {
+		return fmt.Sprintf("%d%s", size, units[unit])
+	}
+	if value < 10 {
+		return fmt.Sprintf("%.1f%s", math.Ceil(value*10)/10, units[unit])
+	}
+	return fmt.Sprintf("%.0f%s", math.Ceil(value), units[unit])
+}
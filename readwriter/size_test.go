@@ -0,0 +1,20 @@
+package readwriter_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/cloudfoundry/bosh-backup-and-restore/readwriter"
+)
+
+var _ = Describe("HumanReadableSize", func() {
+	DescribeTable("formats sizes like du -h",
+		func(size int, expected string) {
+			Expect(readwriter.HumanReadableSize(size)).To(Equal(expected))
+		},
+		Entry("bytes", 512, "512B"),
+		Entry("exact kibibytes", 2048, "2.0K"),
+		Entry("rounding up", 1536*1024+1, "1.6M"),
+		Entry("ten or more of a unit", 12*1024*1024*1024, "12G"),
+	)
+})
@@ -1,13 +1,21 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"fmt"
+	"io"
+	"io/fs"
 	"os"
+	"sort"
 
+	"github.com/cloudfoundry/bosh-backup-and-restore/s3-config-validator/src/internal/azure"
 	"github.com/cloudfoundry/bosh-backup-and-restore/s3-config-validator/src/internal/config"
 	"github.com/cloudfoundry/bosh-backup-and-restore/s3-config-validator/src/internal/configPrinter"
 	"github.com/cloudfoundry/bosh-backup-and-restore/s3-config-validator/src/internal/flags"
+	"github.com/cloudfoundry/bosh-backup-and-restore/s3-config-validator/src/internal/gcs"
+	"github.com/cloudfoundry/bosh-backup-and-restore/s3-config-validator/src/internal/probe"
+	"github.com/cloudfoundry/bosh-backup-and-restore/s3-config-validator/src/internal/report"
 	"github.com/cloudfoundry/bosh-backup-and-restore/s3-config-validator/src/internal/runner"
 )
 
@@ -15,54 +23,145 @@ const (
 	ConfigPathEnv     = "BBR_S3_BUCKETS_CONFIG"
 	UnversionedConfig = "/var/vcap/jobs/s3-unversioned-blobstore-backup-restorer/config/buckets.json"
 	VersionedConfig   = "/var/vcap/jobs/s3-versioned-blobstore-backup-restorer/config/buckets.json"
+
+	GCSConfigPathEnv        = "BBR_GCS_BUCKETS_CONFIG"
+	GCSServiceAccountKeyEnv = "BBR_GCS_SERVICE_ACCOUNT_KEY"
+	GCSConfig               = "/var/vcap/jobs/gcs-blobstore-backup-restorer/config/buckets.json"
+	GCSServiceAccountKey    = "/var/vcap/jobs/gcs-blobstore-backup-restorer/config/gcp-service-account-key.json"
+
+	AzureConfigPathEnv = "BBR_AZURE_CONTAINERS_CONFIG"
+	AzureConfig        = "/var/vcap/jobs/azure-blobstore-backup-restorer/config/containers.json"
+)
+
+// Exit codes, so that CI can tell a configuration that could not be used at
+// all from buckets that the configured credentials cannot back up.
+const (
+	ExitValid       = 0
+	ExitProbeFailed = 1
+	ExitConfigError = 2
 )
 
 type CommandParams struct {
-	ReadOnlyValidation bool
-	Versioned          bool
-	ConfigPath         string
+	Blobstore             string
+	ReadOnlyValidation    bool
+	Versioned             bool
+	ConfigPath            string
+	ServiceAccountKeyPath string
+	Endpoint              string
+	RetentionDays         int
+	Format                string
+	// Output receives the human-readable progress, which is discarded when
+	// a report is written in another format.
+	Output io.Writer
+}
+
+// validator reads and prints the configuration of one kind of blobstore
+// backup-restorer job, and builds the probe runners for the buckets in it.
+type validator struct {
+	description  func(commandParams CommandParams) string
+	probeRunners func(commandParams CommandParams) ([]runner.ProbeRunner, error)
+}
+
+var reportWriters = map[string]func(report.Report, io.Writer) error{
+	"text":  nil,
+	"json":  report.Report.WriteJSON,
+	"junit": report.Report.WriteJUnit,
+}
+
+var validators = map[string]validator{
+	"s3": {
+		description: func(commandParams CommandParams) string {
+			if commandParams.Versioned {
+				return "versioned S3 buckets"
+			}
+			return "unversioned S3 buckets"
+		},
+		probeRunners: s3ProbeRunners,
+	},
+	"gcs": {
+		description:  func(CommandParams) string { return "GCS buckets" },
+		probeRunners: gcsProbeRunners,
+	},
+	"azure": {
+		description:  func(CommandParams) string { return "Azure containers" },
+		probeRunners: azureProbeRunners,
+	},
 }
 
 func main() {
 	commandParams := parseParams()
 
-	validatedConfig, err := config.Read(commandParams.ConfigPath, commandParams.Versioned)
+	if _, ok := reportWriters[commandParams.Format]; !ok {
+		fmt.Printf("unknown format %q, expected one of text, json or junit\n", commandParams.Format)
+		os.Exit(ExitConfigError)
+	}
+
+	os.Exit(run(commandParams))
+}
+
+func run(commandParams CommandParams) int {
+	output := commandParams.Output
 
-	printHeader(commandParams, commandParams.Versioned)
+	validator, ok := validators[commandParams.Blobstore]
+	if !ok {
+		err := fmt.Errorf("unknown blobstore %q, expected one of s3, gcs or azure", commandParams.Blobstore)
+		fmt.Fprintln(output, err)
+		return writeReport(commandParams, nil, err)
+	}
 
-	configPrinter.PrintConfig(os.Stdout, validatedConfig)
+	printHeader(commandParams, validator.description(commandParams))
 
+	probeRunners, err := validator.probeRunners(commandParams)
 	if err != nil {
-		fmt.Printf("%v\n", err.Error())
-		fmt.Println("Bad config")
+		fmt.Fprintf(output, "%v\n", err.Error())
+		fmt.Fprintln(output, "Bad config")
 		printHints(commandParams)
-		os.Exit(1)
+		return writeReport(commandParams, nil, err)
 	}
 
-	if !isValid(validatedConfig, commandParams.ReadOnlyValidation, commandParams.Versioned) {
-		fmt.Println("Bad config")
+	results := validate(probeRunners, output)
+	removeTestObjects(probeRunners, results, output)
+
+	if !succeeded(results) {
+		fmt.Fprintln(output, "Bad config")
 		printHints(commandParams)
-		os.Exit(1)
+		return writeReport(commandParams, results, nil)
 	}
 
-	fmt.Println("Good config")
+	fmt.Fprintln(output, "Good config")
 	printHints(commandParams)
+	return writeReport(commandParams, results, nil)
 }
 
-func printHeader(commandParams CommandParams, versioned bool) {
-	fmt.Printf("\n%s\n\n", flags.RunLocationHint)
+// writeReport writes the report in the requested format, unless that is
+// text which has already been written, and returns the exit code for it.
+func writeReport(commandParams CommandParams, results []runner.Result, err error) int {
+	validationReport := report.New(commandParams.Blobstore, commandParams.ConfigPath, commandParams.ReadOnlyValidation, results, err)
 
-	if versioned {
-		fmt.Printf("Validating versioned S3 buckets configuration at:\n\n  %s\n\n", commandParams.ConfigPath)
-	} else {
-		fmt.Printf("Validating unversioned S3 buckets configuration at:\n\n  %s\n\n", commandParams.ConfigPath)
+	if write := reportWriters[commandParams.Format]; write != nil {
+		if writeErr := write(validationReport, os.Stdout); writeErr != nil {
+			fmt.Fprintf(os.Stderr, "could not write report: %s\n", writeErr)
+		}
 	}
 
+	switch {
+	case err != nil:
+		return ExitConfigError
+	case !validationReport.Valid:
+		return ExitProbeFailed
+	}
+	return ExitValid
+}
+
+func printHeader(commandParams CommandParams, description string) {
+	fmt.Fprintf(commandParams.Output, "\n%s\n\n", flags.RunLocationHint)
+
+	fmt.Fprintf(commandParams.Output, "Validating %s configuration at:\n\n  %s\n\n", description, commandParams.ConfigPath)
 }
 
 func printHints(commandParams CommandParams) {
 	if commandParams.ReadOnlyValidation {
-		fmt.Printf("\n%s\n\n", flags.ReadOnlyValidationHint)
+		fmt.Fprintf(commandParams.Output, "\n%s\n\n", flags.ReadOnlyValidationHint)
 	}
 }
 
@@ -70,45 +169,177 @@ func parseParams() CommandParams {
 	var (
 		validatePutObject bool
 		unversioned       bool
+		blobstore         string
+		endpoint          string
+		retentionDays     int
+		format            string
 	)
 	flags.OverrideDefaultHelpFlag(flags.HelpMessage)
-	flag.BoolVar(&validatePutObject, "validate-put-object", false, "Test writing objects to the buckets. Disclaimer: This will write test files to the buckets!")
+	flag.BoolVar(&validatePutObject, "validate-put-object", false, "Test writing and deleting objects in the buckets. Test objects are removed once validation has finished.")
 	flag.BoolVar(&unversioned, "unversioned", false, "Validate unversioned bucket configuration.")
+	flag.StringVar(&blobstore, "blobstore", "s3", "Blobstore whose configuration to validate: s3, gcs or azure.")
+	flag.StringVar(&endpoint, "endpoint", "", "Storage endpoint to validate GCS or Azure configuration against, e.g. a local emulator.")
+	flag.IntVar(&retentionDays, "retention-days", probe.DefaultRetentionDays, "Fail S3 buckets whose lifecycle rules delete backups sooner than this many days.")
+	flag.StringVar(&format, "format", "text", "Output format: text, json or junit.")
 	flag.Parse()
 
+	var output io.Writer = os.Stdout
+	if format != "text" {
+		output = io.Discard
+	}
+
 	return CommandParams{
-		ReadOnlyValidation: !validatePutObject,
-		Versioned:          !unversioned,
-		ConfigPath:         getConfigPath(!unversioned),
+		Blobstore:             blobstore,
+		ReadOnlyValidation:    !validatePutObject,
+		Versioned:             !unversioned,
+		ConfigPath:            getConfigPath(blobstore, !unversioned),
+		ServiceAccountKeyPath: envOrDefault(GCSServiceAccountKeyEnv, GCSServiceAccountKey),
+		Endpoint:              endpoint,
+		RetentionDays:         retentionDays,
+		Format:                format,
+		Output:                output,
 	}
 }
 
-func getConfigPath(versioned bool) string {
-	configPath := os.Getenv(ConfigPathEnv)
-	if configPath == "" {
-		if versioned {
-			configPath = VersionedConfig
-		} else {
-			configPath = UnversionedConfig
-		}
+func getConfigPath(blobstore string, versioned bool) string {
+	switch blobstore {
+	case "gcs":
+		return envOrDefault(GCSConfigPathEnv, GCSConfig)
+	case "azure":
+		return envOrDefault(AzureConfigPathEnv, AzureConfig)
+	}
+
+	if versioned {
+		return envOrDefault(ConfigPathEnv, VersionedConfig)
+	}
+	return envOrDefault(ConfigPathEnv, UnversionedConfig)
+}
+
+func envOrDefault(env, defaultValue string) string {
+	if value := os.Getenv(env); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+func s3ProbeRunners(commandParams CommandParams) ([]runner.ProbeRunner, error) {
+	validatedConfig, err := config.Read(commandParams.ConfigPath, commandParams.Versioned)
+
+	configPrinter.PrintConfig(commandParams.Output, validatedConfig)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var probeRunners []runner.ProbeRunner
+	for _, resource := range sortedKeys(validatedConfig.Buckets) {
+		bucket := validatedConfig.Buckets[resource]
+		probeRunners = append(probeRunners, runner.NewProbeRunnersWithRetention(resource, bucket, commandParams.ReadOnlyValidation, commandParams.Versioned, commandParams.RetentionDays)...)
 	}
-	return configPath
+
+	return probeRunners, nil
 }
 
-func isValid(config config.Config, readOnly, versioned bool) (isValidConfig bool) {
-	isValidConfig = true
+func gcsProbeRunners(commandParams CommandParams) ([]runner.ProbeRunner, error) {
+	validatedConfig, err := config.ReadGCS(commandParams.ConfigPath)
+
+	configPrinter.PrintGCSConfig(commandParams.Output, validatedConfig)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// emulators do not check credentials, so the key is optional with an endpoint
+	serviceAccountKey, err := os.ReadFile(commandParams.ServiceAccountKeyPath)
+	if err != nil && !(commandParams.Endpoint != "" && errors.Is(err, fs.ErrNotExist)) {
+		return nil, err
+	}
+
+	client, err := gcs.NewGCSClient(serviceAccountKey, commandParams.Endpoint)
+	if err != nil {
+		return nil, err
+	}
 
 	var probeRunners []runner.ProbeRunner
+	for _, resource := range sortedKeys(validatedConfig.Buckets) {
+		bucket := validatedConfig.Buckets[resource]
+		probeRunners = append(probeRunners, runner.NewGCSProbeRunners(resource, bucket, client, commandParams.ReadOnlyValidation)...)
+	}
+
+	return probeRunners, nil
+}
+
+func azureProbeRunners(commandParams CommandParams) ([]runner.ProbeRunner, error) {
+	validatedConfig, err := config.ReadAzure(commandParams.ConfigPath)
 
-	for resource, bucket := range config.Buckets {
-		probeRunners = append(probeRunners, runner.NewProbeRunners(resource, bucket, readOnly, versioned)...)
+	configPrinter.PrintAzureConfig(commandParams.Output, validatedConfig)
+
+	if err != nil {
+		return nil, err
 	}
 
+	var probeRunners []runner.ProbeRunner
+	for _, resource := range sortedKeys(validatedConfig.Containers) {
+		container := validatedConfig.Containers[resource]
+		client, err := azure.NewAzureClient(container.StorageAccount, container.StorageKey, container.EndpointSuffix(), commandParams.Endpoint)
+		if err != nil {
+			return nil, err
+		}
+
+		probeRunners = append(probeRunners, runner.NewAzureProbeRunners(resource, container, client, commandParams.ReadOnlyValidation)...)
+	}
+
+	return probeRunners, nil
+}
+
+// sortedKeys orders the resources of a config, so that they are validated and
+// reported in the same order on every run.
+func sortedKeys[V any](resources map[string]V) []string {
+	keys := make([]string, 0, len(resources))
+	for key := range resources {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func validate(probeRunners []runner.ProbeRunner, output io.Writer) []runner.Result {
+	var results []runner.Result
+
 	for _, probeRunner := range probeRunners {
-		if !probeRunner.Run() {
-			isValidConfig = false
+		probeRunner.Writer = output
+		results = append(results, probeRunner.Validate())
+	}
+
+	return results
+}
+
+// removeTestObjects runs once every bucket has been validated, as the probes
+// of backup buckets may read test objects from their live buckets.
+func removeTestObjects(probeRunners []runner.ProbeRunner, results []runner.Result, output io.Writer) {
+	cleanedUp := false
+
+	for i, probeRunner := range probeRunners {
+		if probeRunner.Cleanup == nil {
+			continue
+		}
+
+		probeRunner.Writer = output
+		results[i].CleanupErr = probeRunner.RemoveTestObjects()
+		cleanedUp = true
+	}
+
+	if cleanedUp {
+		fmt.Fprintln(output)
+	}
+}
+
+func succeeded(results []runner.Result) bool {
+	for _, result := range results {
+		if !result.Succeeded() {
+			return false
 		}
 	}
 
-	return isValidConfig
+	return true
 }
@@ -0,0 +1,244 @@
+package azure
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/cloudfoundry/bosh-backup-and-restore/s3-config-validator/src/internal/testobject"
+)
+
+// AzureClient talks to the Blob service of a single storage account.
+type AzureClient struct {
+	HTTPClient *http.Client
+	ServiceURL string
+	Account    string
+	key        []byte
+}
+
+// NewAzureClient builds a client for the account's Blob service under
+// endpointSuffix, e.g. https://<account>.blob.core.windows.net. When endpoint
+// is set, as for the Azurite emulator, the account is addressed by path
+// instead: <endpoint>/<account>.
+func NewAzureClient(account, key, endpointSuffix, endpoint string) (*AzureClient, error) {
+	decodedKey, err := decodeKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	serviceURL := fmt.Sprintf("https://%s.blob.%s", account, endpointSuffix)
+	if endpoint != "" {
+		serviceURL = strings.TrimSuffix(endpoint, "/") + "/" + account
+	}
+
+	return &AzureClient{
+		HTTPClient: &http.Client{},
+		ServiceURL: serviceURL,
+		Account:    account,
+		key:        decodedKey,
+	}, nil
+}
+
+func (c *AzureClient) IsSoftDeleteEnabled(container string) error {
+	var properties struct {
+		DeleteRetentionPolicy struct {
+			Enabled bool `xml:"Enabled"`
+		} `xml:"DeleteRetentionPolicy"`
+	}
+
+	err := c.do(http.MethodGet, c.ServiceURL+"/?restype=service&comp=properties", nil, nil, &properties)
+	if err != nil {
+		return fmt.Errorf("could not check if soft delete is enabled for container %s: %s", container, err)
+	}
+
+	if !properties.DeleteRetentionPolicy.Enabled {
+		return fmt.Errorf("soft delete is not enabled for storage account %s", c.Account)
+	}
+
+	return nil
+}
+
+type blobList struct {
+	Blobs []struct {
+		Name string `xml:"Name"`
+	} `xml:"Blobs>Blob"`
+	NextMarker string `xml:"NextMarker"`
+}
+
+func (c *AzureClient) CanListBlobs(container string) error {
+	err := c.forEachBlob(container, func(string) error { return nil })
+	if err != nil {
+		return fmt.Errorf("could not list blobs in container %s: %s", container, err)
+	}
+
+	return nil
+}
+
+func (c *AzureClient) CanGetBlobs(container string) error {
+	err := c.forEachBlob(container, func(name string) error {
+		return c.do(http.MethodHead, c.blobURL(container, name), nil, nil, nil)
+	})
+	if err != nil {
+		return fmt.Errorf("could not get all blobs from container %s", container)
+	}
+
+	return nil
+}
+
+func (c *AzureClient) CanPutBlobs(container string) error {
+	err := c.putTestBlob(container, testobject.Key("put"))
+	if err != nil {
+		return fmt.Errorf("could not put blob into container %s: %s", container, err)
+	}
+
+	return nil
+}
+
+// CanDeleteBlobs writes a test blob of its own and deletes it again.
+func (c *AzureClient) CanDeleteBlobs(container string) error {
+	name := testobject.Key("delete")
+
+	if err := c.putTestBlob(container, name); err != nil {
+		return fmt.Errorf("could not put blob to delete into container %s: %s", container, err)
+	}
+
+	if err := c.deleteBlob(container, name); err != nil {
+		return fmt.Errorf("could not delete blobs from container %s: %s", container, err)
+	}
+
+	return nil
+}
+
+// RemoveTestBlobs deletes the blobs that this run wrote into the container.
+// With soft delete enabled, the account keeps them until its retention
+// period has passed.
+func (c *AzureClient) RemoveTestBlobs(container string) error {
+	var names []string
+	err := c.forEachBlobIn(container, url.Values{"prefix": {testobject.Prefix}}, func(name string) error {
+		names = append(names, name)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("could not list test blobs in container %s: %s", container, err)
+	}
+
+	var leftovers []string
+	var lastErr error
+	for _, name := range names {
+		if err := c.deleteBlob(container, name); err != nil {
+			leftovers = append(leftovers, name)
+			lastErr = err
+		}
+	}
+
+	if len(leftovers) > 0 {
+		return fmt.Errorf("could not delete %s from container %s: %s", strings.Join(leftovers, ", "), container, lastErr)
+	}
+
+	return nil
+}
+
+func (c *AzureClient) putTestBlob(container, name string) error {
+	headers := http.Header{
+		"Content-Type":   {"text/plain"},
+		"X-Ms-Blob-Type": {"BlockBlob"},
+	}
+
+	return c.do(http.MethodPut, c.blobURL(container, name), strings.NewReader(testobject.Content), headers, nil)
+}
+
+func (c *AzureClient) deleteBlob(container, name string) error {
+	headers := http.Header{"X-Ms-Delete-Snapshots": {"include"}}
+
+	return c.do(http.MethodDelete, c.blobURL(container, name), nil, headers, nil)
+}
+
+func (c *AzureClient) forEachBlob(container string, fn func(name string) error) error {
+	return c.forEachBlobIn(container, url.Values{}, fn)
+}
+
+func (c *AzureClient) forEachBlobIn(container string, query url.Values, fn func(name string) error) error {
+	marker := ""
+	for {
+		query.Set("restype", "container")
+		query.Set("comp", "list")
+		if marker != "" {
+			query.Set("marker", marker)
+		}
+
+		var page blobList
+		if err := c.do(http.MethodGet, c.containerURL(container)+"?"+query.Encode(), nil, nil, &page); err != nil {
+			return err
+		}
+
+		for _, blob := range page.Blobs {
+			if err := fn(blob.Name); err != nil {
+				return err
+			}
+		}
+
+		if page.NextMarker == "" {
+			return nil
+		}
+		marker = page.NextMarker
+	}
+}
+
+func (c *AzureClient) containerURL(container string) string {
+	return c.ServiceURL + "/" + url.PathEscape(container)
+}
+
+func (c *AzureClient) blobURL(container, blob string) string {
+	return c.containerURL(container) + "/" + url.PathEscape(blob)
+}
+
+func (c *AzureClient) do(method, requestURL string, body io.Reader, headers http.Header, result interface{}) error {
+	request, err := http.NewRequest(method, requestURL, body)
+	if err != nil {
+		return err
+	}
+	for name, values := range headers {
+		request.Header[name] = values
+	}
+
+	if err := c.sign(request); err != nil {
+		return err
+	}
+
+	response, err := c.HTTPClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close() //nolint:errcheck
+
+	if response.StatusCode/100 != 2 {
+		return responseError(response)
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	return xml.NewDecoder(response.Body).Decode(result)
+}
+
+func responseError(response *http.Response) error {
+	var errorResponse struct {
+		Code string `xml:"Code"`
+	}
+
+	body, _ := io.ReadAll(response.Body)
+	if xml.Unmarshal(body, &errorResponse) == nil && errorResponse.Code != "" {
+		return fmt.Errorf("%s: %s", response.Status, errorResponse.Code)
+	}
+
+	if code := response.Header.Get("X-Ms-Error-Code"); code != "" {
+		return fmt.Errorf("%s: %s", response.Status, code)
+	}
+
+	return errors.New(response.Status)
+}
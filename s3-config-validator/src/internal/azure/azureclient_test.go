@@ -0,0 +1,258 @@
+package azure_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/cloudfoundry/bosh-backup-and-restore/s3-config-validator/src/internal/azure"
+	"github.com/cloudfoundry/bosh-backup-and-restore/s3-config-validator/src/internal/testobject"
+)
+
+const (
+	testAccount = "devstoreaccount1"
+	testKey     = "dGVzdC1rZXk="
+
+	ListBlobsResponse = `<?xml version="1.0" encoding="utf-8"?>
+<EnumerationResults ContainerName="test-container">
+  <Blobs>
+    <Blob><Name>dir/1.mp4</Name></Blob>
+    <Blob><Name>2.mp4</Name></Blob>
+  </Blobs>
+  <NextMarker />
+</EnumerationResults>`
+	AuthorizationFailureResponse = `<?xml version="1.0" encoding="utf-8"?>
+<Error><Code>AuthorizationFailure</Code><Message>This request is not authorized to perform this operation.</Message></Error>`
+)
+
+var _ = Describe("AzureClient", func() {
+	var (
+		fakeAzureServer *ghttp.Server
+		client          *azure.AzureClient
+	)
+
+	BeforeEach(func() {
+		fakeAzureServer = ghttp.NewServer()
+
+		var err error
+		client, err = azure.NewAzureClient(testAccount, testKey, "core.windows.net", fakeAzureServer.URL())
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		fakeAzureServer.Close()
+	})
+
+	It("addresses the account by host name by default", func() {
+		client, err := azure.NewAzureClient("account", testKey, "core.chinacloudapi.cn", "")
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(client.ServiceURL).To(Equal("https://account.blob.core.chinacloudapi.cn"))
+	})
+
+	It("addresses the account by path on an emulator", func() {
+		Expect(client.ServiceURL).To(Equal(fakeAzureServer.URL() + "/devstoreaccount1"))
+	})
+
+	It("fails when the key is not base64 encoded", func() {
+		_, err := azure.NewAzureClient(testAccount, "not base64!", "core.windows.net", "")
+
+		Expect(err).To(MatchError("azure_storage_key is not base64 encoded"))
+	})
+
+	It("signs requests with the shared key", func() {
+		fakeAzureServer.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", "/devstoreaccount1/test-container", "comp=list&restype=container"),
+			ghttp.VerifyHeaderKV("X-Ms-Version", "2020-10-02"),
+			func(_ http.ResponseWriter, request *http.Request) {
+				stringToSign := "GET" + strings.Repeat("\n", 12) +
+					"x-ms-date:" + request.Header.Get("X-Ms-Date") + "\n" +
+					"x-ms-version:2020-10-02\n" +
+					"/devstoreaccount1/devstoreaccount1/test-container\n" +
+					"comp:list\n" +
+					"restype:container"
+
+				key, _ := base64.StdEncoding.DecodeString(testKey)
+				mac := hmac.New(sha256.New, key)
+				mac.Write([]byte(stringToSign))
+
+				Expect(request.Header.Get("Authorization")).To(Equal(
+					"SharedKey devstoreaccount1:" + base64.StdEncoding.EncodeToString(mac.Sum(nil)),
+				))
+			},
+			ghttp.RespondWith(http.StatusOK, ListBlobsResponse),
+		))
+
+		Expect(client.CanListBlobs("test-container")).To(Succeed())
+	})
+
+	Context("Soft Delete", func() {
+		It("succeeds when the account keeps deleted blobs", func() {
+			fakeAzureServer.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/devstoreaccount1/", "comp=properties&restype=service"),
+				ghttp.RespondWith(http.StatusOK, `<StorageServiceProperties><DeleteRetentionPolicy><Enabled>true</Enabled><Days>7</Days></DeleteRetentionPolicy></StorageServiceProperties>`),
+			))
+
+			Expect(client.IsSoftDeleteEnabled("test-container")).To(Succeed())
+		})
+
+		It("fails when soft delete is disabled", func() {
+			fakeAzureServer.AppendHandlers(
+				ghttp.RespondWith(http.StatusOK, `<StorageServiceProperties><DeleteRetentionPolicy><Enabled>false</Enabled></DeleteRetentionPolicy></StorageServiceProperties>`),
+			)
+
+			Expect(client.IsSoftDeleteEnabled("test-container")).To(MatchError("soft delete is not enabled for storage account devstoreaccount1"))
+		})
+
+		It("reports why the properties could not be read", func() {
+			fakeAzureServer.AppendHandlers(ghttp.RespondWith(http.StatusForbidden, AuthorizationFailureResponse))
+
+			Expect(client.IsSoftDeleteEnabled("test-container")).To(MatchError(
+				"could not check if soft delete is enabled for container test-container: 403 Forbidden: AuthorizationFailure",
+			))
+		})
+	})
+
+	Context("List Blobs", func() {
+		It("lists every page", func() {
+			fakeAzureServer.AppendHandlers(
+				ghttp.RespondWith(http.StatusOK, `<EnumerationResults><Blobs /><NextMarker>next</NextMarker></EnumerationResults>`),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/devstoreaccount1/test-container", "comp=list&marker=next&restype=container"),
+					ghttp.RespondWith(http.StatusOK, ListBlobsResponse),
+				),
+			)
+
+			Expect(client.CanListBlobs("test-container")).To(Succeed())
+			Expect(fakeAzureServer.ReceivedRequests()).To(HaveLen(2))
+		})
+
+		It("reports why listing failed", func() {
+			fakeAzureServer.AppendHandlers(ghttp.RespondWith(http.StatusForbidden, AuthorizationFailureResponse))
+
+			Expect(client.CanListBlobs("test-container")).To(MatchError(
+				"could not list blobs in container test-container: 403 Forbidden: AuthorizationFailure",
+			))
+		})
+	})
+
+	Context("Get Blobs", func() {
+		BeforeEach(func() {
+			fakeAzureServer.AppendHandlers(
+				ghttp.RespondWith(http.StatusOK, ListBlobsResponse),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("HEAD", "/devstoreaccount1/test-container/dir/1.mp4"),
+					func(_ http.ResponseWriter, request *http.Request) {
+						Expect(request.URL.EscapedPath()).To(Equal("/devstoreaccount1/test-container/dir%2F1.mp4"))
+					},
+				),
+			)
+		})
+
+		It("gets the properties of every blob", func() {
+			fakeAzureServer.AppendHandlers(ghttp.VerifyRequest("HEAD", "/devstoreaccount1/test-container/2.mp4"))
+
+			Expect(client.CanGetBlobs("test-container")).To(Succeed())
+		})
+
+		It("fails when a blob cannot be read", func() {
+			fakeAzureServer.AppendHandlers(ghttp.RespondWith(http.StatusForbidden, ""))
+
+			Expect(client.CanGetBlobs("test-container")).To(MatchError("could not get all blobs from container test-container"))
+		})
+	})
+
+	Context("Put Blob", func() {
+		It("uploads a test block blob", func() {
+			fakeAzureServer.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("PUT", "/devstoreaccount1/test-container/"+testobject.Key("put")),
+				ghttp.VerifyHeaderKV("X-Ms-Blob-Type", "BlockBlob"),
+				ghttp.VerifyBody([]byte(testobject.Content)),
+				ghttp.RespondWith(http.StatusCreated, ""),
+			))
+
+			Expect(client.CanPutBlobs("test-container")).To(Succeed())
+		})
+
+		It("reports why uploading failed", func() {
+			fakeAzureServer.AppendHandlers(ghttp.RespondWith(http.StatusForbidden, "", http.Header{"X-Ms-Error-Code": {"AuthorizationPermissionMismatch"}}))
+
+			Expect(client.CanPutBlobs("test-container")).To(MatchError(
+				"could not put blob into container test-container: 403 Forbidden: AuthorizationPermissionMismatch",
+			))
+		})
+	})
+
+	Context("Delete Blob", func() {
+		It("uploads a test blob and deletes it with its snapshots", func() {
+			fakeAzureServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/devstoreaccount1/test-container/"+testobject.Key("delete")),
+					ghttp.RespondWith(http.StatusCreated, ""),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("DELETE", "/devstoreaccount1/test-container/"+testobject.Key("delete")),
+					ghttp.VerifyHeaderKV("X-Ms-Delete-Snapshots", "include"),
+					ghttp.RespondWith(http.StatusAccepted, ""),
+				),
+			)
+
+			Expect(client.CanDeleteBlobs("test-container")).To(Succeed())
+		})
+
+		It("reports why deleting failed", func() {
+			fakeAzureServer.AppendHandlers(
+				ghttp.RespondWith(http.StatusCreated, ""),
+				ghttp.RespondWith(http.StatusForbidden, "", http.Header{"X-Ms-Error-Code": {"AuthorizationPermissionMismatch"}}),
+			)
+
+			Expect(client.CanDeleteBlobs("test-container")).To(MatchError(
+				"could not delete blobs from container test-container: 403 Forbidden: AuthorizationPermissionMismatch",
+			))
+		})
+	})
+
+	Context("Remove Test Blobs", func() {
+		BeforeEach(func() {
+			fakeAzureServer.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/devstoreaccount1/test-container", url.Values{
+					"restype": {"container"},
+					"comp":    {"list"},
+					"prefix":  {testobject.Prefix},
+				}.Encode()),
+				ghttp.RespondWith(http.StatusOK, `<EnumerationResults><Blobs>
+					<Blob><Name>`+testobject.Key("put")+`</Name></Blob>
+					<Blob><Name>`+testobject.Key("delete")+`</Name></Blob>
+				</Blobs></EnumerationResults>`),
+			))
+		})
+
+		It("deletes the blobs under this run's prefix", func() {
+			fakeAzureServer.AppendHandlers(
+				ghttp.VerifyRequest("DELETE", "/devstoreaccount1/test-container/"+testobject.Key("put")),
+				ghttp.VerifyRequest("DELETE", "/devstoreaccount1/test-container/"+testobject.Key("delete")),
+			)
+
+			Expect(client.RemoveTestBlobs("test-container")).To(Succeed())
+			Expect(fakeAzureServer.ReceivedRequests()).To(HaveLen(3))
+		})
+
+		It("reports the blobs it could not delete", func() {
+			fakeAzureServer.AppendHandlers(
+				ghttp.RespondWith(http.StatusForbidden, ""),
+				ghttp.RespondWith(http.StatusAccepted, ""),
+			)
+
+			Expect(client.RemoveTestBlobs("test-container")).To(MatchError(
+				"could not delete " + testobject.Key("put") + " from container test-container: 403 Forbidden",
+			))
+		})
+	})
+})
@@ -0,0 +1,12 @@
+package azure
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 . Client
+
+type Client interface {
+	IsSoftDeleteEnabled(container string) error
+	CanListBlobs(container string) error
+	CanGetBlobs(container string) error
+	CanPutBlobs(container string) error
+	CanDeleteBlobs(container string) error
+	RemoveTestBlobs(container string) error
+}
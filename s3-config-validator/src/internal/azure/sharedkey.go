@@ -0,0 +1,104 @@
+package azure
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const storageAPIVersion = "2020-10-02"
+
+func decodeKey(key string) ([]byte, error) {
+	decoded, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return nil, errors.New("azure_storage_key is not base64 encoded")
+	}
+
+	return decoded, nil
+}
+
+// sign authorizes the request with the storage account key, following
+// https://learn.microsoft.com/en-us/rest/api/storageservices/authorize-with-shared-key
+func (c *AzureClient) sign(request *http.Request) error {
+	request.Header.Set("X-Ms-Date", time.Now().UTC().Format(http.TimeFormat))
+	request.Header.Set("X-Ms-Version", storageAPIVersion)
+
+	mac := hmac.New(sha256.New, c.key)
+	if _, err := mac.Write([]byte(c.stringToSign(request))); err != nil {
+		return err
+	}
+
+	request.Header.Set("Authorization", "SharedKey "+c.Account+":"+base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+	return nil
+}
+
+func (c *AzureClient) stringToSign(request *http.Request) string {
+	contentLength := ""
+	if request.ContentLength > 0 {
+		contentLength = strconv.FormatInt(request.ContentLength, 10)
+	}
+
+	return strings.Join([]string{
+		request.Method,
+		request.Header.Get("Content-Encoding"),
+		request.Header.Get("Content-Language"),
+		contentLength,
+		request.Header.Get("Content-Md5"),
+		request.Header.Get("Content-Type"),
+		"", // Date, superseded by x-ms-date
+		request.Header.Get("If-Modified-Since"),
+		request.Header.Get("If-Match"),
+		request.Header.Get("If-None-Match"),
+		request.Header.Get("If-Unmodified-Since"),
+		request.Header.Get("Range"),
+		canonicalizedHeaders(request.Header),
+		c.canonicalizedResource(request),
+	}, "\n")
+}
+
+func canonicalizedHeaders(headers http.Header) string {
+	var names []string
+	for name := range headers {
+		if strings.HasPrefix(strings.ToLower(name), "x-ms-") {
+			names = append(names, name)
+		}
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return strings.ToLower(names[i]) < strings.ToLower(names[j])
+	})
+
+	lines := make([]string, len(names))
+	for i, name := range names {
+		lines[i] = strings.ToLower(name) + ":" + strings.Join(headers[name], ",")
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func (c *AzureClient) canonicalizedResource(request *http.Request) string {
+	resource := "/" + c.Account + request.URL.EscapedPath()
+	if request.URL.Path == "" {
+		resource += "/"
+	}
+
+	query := request.URL.Query()
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		values := query[name]
+		sort.Strings(values)
+		resource += "\n" + strings.ToLower(name) + ":" + strings.Join(values, ",")
+	}
+
+	return resource
+}
@@ -0,0 +1,107 @@
+package config
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+)
+
+// AzureConfig is the containers.json of the azure-blobstore-backup-restorer
+// job.
+type AzureConfig struct {
+	Containers map[string]AzureContainer
+}
+
+type AzureContainer struct {
+	Name           string `json:"name"`
+	StorageAccount string `json:"azure_storage_account"`
+	StorageKey     string `json:"azure_storage_key"`
+	Environment    string `json:"environment,omitempty"`
+}
+
+// azureEndpointSuffixes maps the environments accepted by the
+// azure-blobstore-backup-restorer job to their storage endpoint suffixes.
+var azureEndpointSuffixes = map[string]string{
+	"AzureCloud":        "core.windows.net",
+	"AzureChinaCloud":   "core.chinacloudapi.cn",
+	"AzureUSGovernment": "core.usgovcloudapi.net",
+	"AzureGermanCloud":  "core.cloudapi.de",
+}
+
+// EndpointSuffix returns the storage endpoint suffix of the container's
+// environment, defaulting to the public Azure cloud.
+func (c AzureContainer) EndpointSuffix() string {
+	if c.Environment == "" {
+		return azureEndpointSuffixes["AzureCloud"]
+	}
+
+	return azureEndpointSuffixes[c.Environment]
+}
+
+func ReadAzure(filePath string) (AzureConfig, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return AzureConfig{}, err
+	}
+
+	return readAzureConfig(data)
+}
+
+func readAzureConfig(jsonFile []byte) (AzureConfig, error) {
+	var containers map[string]AzureContainer
+
+	if err := json.Unmarshal(jsonFile, &containers); err != nil {
+		return AzureConfig{}, err
+	}
+
+	if len(containers) == 0 {
+		return AzureConfig{}, errEmptyJSON
+	}
+
+	var emptyFieldNames []string
+	var invalidKeys []string
+	var unknownEnvironments []string
+
+	for resource, container := range containers {
+		if container.Name == "" {
+			emptyFieldNames = append(emptyFieldNames, resource+".name")
+		}
+
+		if container.StorageAccount == "" {
+			emptyFieldNames = append(emptyFieldNames, resource+".azure_storage_account")
+		}
+
+		if container.StorageKey == "" {
+			emptyFieldNames = append(emptyFieldNames, resource+".azure_storage_key")
+		} else if _, err := base64.StdEncoding.DecodeString(container.StorageKey); err != nil {
+			invalidKeys = append(invalidKeys, resource)
+		}
+
+		if container.EndpointSuffix() == "" {
+			unknownEnvironments = append(unknownEnvironments, resource)
+		}
+	}
+
+	var errs []error
+	if len(emptyFieldNames) > 0 {
+		sort.Strings(emptyFieldNames)
+		errs = append(errs, fmt.Errorf("invalid config: fields %v are empty\n", emptyFieldNames))
+	}
+	if len(invalidKeys) > 0 {
+		sort.Strings(invalidKeys)
+		errs = append(errs, fmt.Errorf("invalid config: azure_storage_key must be base64 encoded in the following containers: %v\n", invalidKeys))
+	}
+	if len(unknownEnvironments) > 0 {
+		sort.Strings(unknownEnvironments)
+		errs = append(errs, fmt.Errorf("invalid config: environment must be one of AzureCloud, AzureChinaCloud, AzureUSGovernment or AzureGermanCloud in the following containers: %v\n", unknownEnvironments))
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return AzureConfig{}, err
+	}
+
+	return AzureConfig{Containers: containers}, nil
+}
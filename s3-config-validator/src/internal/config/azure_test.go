@@ -0,0 +1,72 @@
+package config_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/cloudfoundry/bosh-backup-and-restore/s3-config-validator/src/internal/config"
+)
+
+var _ = Describe("Azure config", func() {
+	It("reads the containers", func() {
+		filePath := writeConfig(`{
+    "droplets": {
+        "name": "test_container",
+        "azure_storage_account": "test_account",
+        "azure_storage_key": "dGVzdF9rZXk=",
+        "environment": "AzureChinaCloud"
+    }
+}`)
+
+		conf, err := config.ReadAzure(filePath)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(conf).To(Equal(config.AzureConfig{
+			Containers: map[string]config.AzureContainer{
+				"droplets": {
+					Name:           "test_container",
+					StorageAccount: "test_account",
+					StorageKey:     "dGVzdF9rZXk=",
+					Environment:    "AzureChinaCloud",
+				},
+			},
+		}))
+		Expect(conf.Containers["droplets"].EndpointSuffix()).To(Equal("core.chinacloudapi.cn"))
+	})
+
+	It("defaults to the public Azure cloud", func() {
+		Expect(config.AzureContainer{}.EndpointSuffix()).To(Equal("core.windows.net"))
+	})
+
+	When("given an empty json", func() {
+		It("returns an error", func() {
+			conf, err := config.ReadAzure(writeConfig("{}"))
+
+			Expect(err).To(MatchError("invalid config: json was empty"))
+			Expect(conf).To(Equal(config.AzureConfig{}))
+		})
+	})
+
+	When("the config has several problems", func() {
+		It("reports all of them", func() {
+			conf, err := config.ReadAzure(writeConfig(`{
+    "droplets": {
+        "name": "",
+        "azure_storage_account": "test_account",
+        "azure_storage_key": ""
+    },
+    "packages": {
+        "name": "test_container",
+        "azure_storage_account": "test_account",
+        "azure_storage_key": "not base64!",
+        "environment": "AzureMoon"
+    }
+}`))
+
+			Expect(err).To(MatchError(ContainSubstring("invalid config: fields [droplets.azure_storage_key droplets.name] are empty\n")))
+			Expect(err).To(MatchError(ContainSubstring("invalid config: azure_storage_key must be base64 encoded in the following containers: [packages]\n")))
+			Expect(err).To(MatchError(ContainSubstring("invalid config: environment must be one of AzureCloud, AzureChinaCloud, AzureUSGovernment or AzureGermanCloud in the following containers: [packages]\n")))
+			Expect(conf).To(Equal(config.AzureConfig{}))
+		})
+	})
+})
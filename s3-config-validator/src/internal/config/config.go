@@ -26,6 +26,9 @@ type LiveBucket struct {
 	Endpoint          string        `json:"endpoint"`
 	Backup            *BackupBucket `json:"backup,omitempty"`
 	UseIAMProfile     bool          `json:"use_iam_profile"`
+	// ForcePathStyle addresses the bucket as <endpoint>/<bucket> rather than
+	// <bucket>.<endpoint>, as most S3-compatible stores and emulators expect.
+	ForcePathStyle bool `json:"force_path_style,omitempty"`
 }
 
 type BackupBucket struct {
@@ -0,0 +1,57 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+)
+
+// GCSConfig is the buckets.json of the gcs-blobstore-backup-restorer job.
+type GCSConfig struct {
+	Buckets map[string]GCSBucket
+}
+
+type GCSBucket struct {
+	Name       string `json:"bucket_name"`
+	BackupName string `json:"backup_bucket_name"`
+}
+
+func ReadGCS(filePath string) (GCSConfig, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return GCSConfig{}, err
+	}
+
+	return readGCSConfig(data)
+}
+
+func readGCSConfig(jsonFile []byte) (GCSConfig, error) {
+	var buckets map[string]GCSBucket
+
+	if err := json.Unmarshal(jsonFile, &buckets); err != nil {
+		return GCSConfig{}, err
+	}
+
+	if len(buckets) == 0 {
+		return GCSConfig{}, errEmptyJSON
+	}
+
+	var emptyFieldNames []string
+	for resource, bucket := range buckets {
+		if bucket.Name == "" {
+			emptyFieldNames = append(emptyFieldNames, resource+".bucket_name")
+		}
+
+		if bucket.BackupName == "" {
+			emptyFieldNames = append(emptyFieldNames, resource+".backup_bucket_name")
+		}
+	}
+
+	if len(emptyFieldNames) > 0 {
+		sort.Strings(emptyFieldNames)
+		return GCSConfig{}, fmt.Errorf("invalid config: fields %v are empty\n", emptyFieldNames)
+	}
+
+	return GCSConfig{Buckets: buckets}, nil
+}
@@ -0,0 +1,56 @@
+package config_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/cloudfoundry/bosh-backup-and-restore/s3-config-validator/src/internal/config"
+)
+
+var _ = Describe("GCS config", func() {
+	It("reads the buckets", func() {
+		filePath := writeConfig(`{
+    "droplets": {
+        "bucket_name": "test_name",
+        "backup_bucket_name": "test_backup_name"
+    }
+}`)
+
+		conf, err := config.ReadGCS(filePath)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(conf).To(Equal(config.GCSConfig{
+			Buckets: map[string]config.GCSBucket{
+				"droplets": {Name: "test_name", BackupName: "test_backup_name"},
+			},
+		}))
+	})
+
+	When("given an empty json", func() {
+		It("returns an error", func() {
+			conf, err := config.ReadGCS(writeConfig("{}"))
+
+			Expect(err).To(MatchError("invalid config: json was empty"))
+			Expect(conf).To(Equal(config.GCSConfig{}))
+		})
+	})
+
+	When("fields are empty", func() {
+		It("returns an error naming them", func() {
+			conf, err := config.ReadGCS(writeConfig(`{"droplets": {}, "packages": {"bucket_name": "test_name"}}`))
+
+			Expect(err).To(MatchError("invalid config: fields" +
+				" [droplets.backup_bucket_name droplets.bucket_name packages.backup_bucket_name]" +
+				" are empty\n"))
+			Expect(conf).To(Equal(config.GCSConfig{}))
+		})
+	})
+
+	When("the file does not exist", func() {
+		It("returns an error", func() {
+			_, err := config.ReadGCS("/this/file/does/not.exist")
+
+			Expect(err).To(MatchError(ContainSubstring("no such file")))
+		})
+	})
+})
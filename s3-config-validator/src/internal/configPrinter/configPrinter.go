@@ -9,13 +9,25 @@ import (
 )
 
 func PrintConfig(writer io.Writer, config config.Config) {
+	printBuckets(writer, config.Buckets)
+}
+
+func PrintGCSConfig(writer io.Writer, config config.GCSConfig) {
+	printBuckets(writer, config.Buckets)
+}
+
+func PrintAzureConfig(writer io.Writer, config config.AzureConfig) {
+	printBuckets(writer, config.Containers)
+}
+
+func printBuckets(writer io.Writer, buckets interface{}) {
 	fmt.Fprintf(writer, "Configuration:\n\n")
 
-	if config.Buckets == nil {
+	jsonOutput, _ := json.MarshalIndent(buckets, "  ", "  ")
+
+	if string(jsonOutput) == "null" {
 		fmt.Fprintf(writer, "  {}\n\n")
 	}
 
-	jsonOutput, _ := json.MarshalIndent(config.Buckets, "  ", "  ")
-
 	fmt.Fprintf(writer, "  %s\n\n", string(jsonOutput))
 }
@@ -226,3 +226,54 @@ var _ = Describe("PrintConfig", func() {
 	})
 
 })
+
+var _ = Describe("PrintGCSConfig", func() {
+	It("Prints the buckets as prettified JSON with Configuration heading", func() {
+		writer := gbytes.NewBuffer()
+
+		PrintGCSConfig(writer, GCSConfig{
+			Buckets: map[string]GCSBucket{
+				"Test Resource": {Name: "testName", BackupName: "testBackupName"},
+			},
+		})
+
+		Eventually(writer).Should(gbytes.Say(`Configuration:
+
+  {
+    "Test Resource": {
+      "bucket_name": "testName",
+      "backup_bucket_name": "testBackupName"
+    }
+  }`))
+	})
+})
+
+var _ = Describe("PrintAzureConfig", func() {
+	It("Prints the containers as prettified JSON with Configuration heading", func() {
+		writer := gbytes.NewBuffer()
+
+		PrintAzureConfig(writer, AzureConfig{
+			Containers: map[string]AzureContainer{
+				"Test Resource": {Name: "testName", StorageAccount: "testAccount", StorageKey: "testKey"},
+			},
+		})
+
+		Eventually(writer).Should(gbytes.Say(`Configuration:
+
+  {
+    "Test Resource": {
+      "name": "testName",
+      "azure_storage_account": "testAccount",
+      "azure_storage_key": "testKey"
+    }
+  }`))
+	})
+
+	It("Prints an empty config", func() {
+		writer := gbytes.NewBuffer()
+
+		PrintAzureConfig(writer, AzureConfig{})
+
+		Eventually(writer).Should(gbytes.Say("{}"))
+	})
+})
@@ -8,30 +8,52 @@ import (
 
 const HelpMessage = `
 Validates a BOSH backup and restore bucket configuration.
-By default it will assume versioned buckets unless specified otherwise.
+By default it will assume versioned S3 buckets unless specified otherwise.
 
 The default config file locations are:
 
- * versioned: /var/vcap/jobs/s3-versioned-blobstore-backup-restorer/config/buckets.json
- * unversioned: /var/vcap/jobs/s3-unversioned-blobstore-backup-restorer/config/buckets.json
+ * s3 versioned: /var/vcap/jobs/s3-versioned-blobstore-backup-restorer/config/buckets.json
+ * s3 unversioned: /var/vcap/jobs/s3-unversioned-blobstore-backup-restorer/config/buckets.json
+ * gcs: /var/vcap/jobs/gcs-blobstore-backup-restorer/config/buckets.json
+ * azure: /var/vcap/jobs/azure-blobstore-backup-restorer/config/containers.json
 
 Make sure to run this on the ‘backup_restore’ VM.
 
 USAGE:
-  bbr-s3-config-validator [--validate-put-object]
+  bbr-s3-config-validator [--blobstore s3|gcs|azure] [--validate-put-object] [--format text|json|junit]
 
 OPTIONS:
   --help                        Show usage.
-  --unversioned                 Validate unversioned bucket configuration.
-  --validate-put-object         Test writing objects to the buckets. Disclaimer: This will write test files to the buckets.
+  --blobstore <type>            Blobstore to validate: s3 (default), gcs or azure.
+  --unversioned                 Validate unversioned S3 bucket configuration.
+  --endpoint <url>              Validate GCS or Azure configuration against this endpoint, e.g. a local emulator.
+                                For Azure the storage account is appended to the path, as emulators expect.
+  --retention-days <days>       Fail S3 buckets whose lifecycle rules delete backups sooner than this (default 7).
+  --validate-put-object         Test writing and deleting objects in the buckets. Test objects are written under
+                                bbr-config-validator-test-objects/<run>/ and removed once validation has finished.
+                                For unversioned S3 buckets this also copies a test object into the backup bucket.
+  --format <format>             Output format: text (default), json or junit. JSON and JUnit reports are written
+                                to stdout in place of the text output.
 
 ENVIRONMENT VARIABLES:
-  BBR_S3_BUCKETS_CONFIG=<path>  Override the default bucket configuration file location
+  BBR_S3_BUCKETS_CONFIG=<path>        Override the default S3 bucket configuration file location
+  BBR_GCS_BUCKETS_CONFIG=<path>       Override the default GCS bucket configuration file location
+  BBR_GCS_SERVICE_ACCOUNT_KEY=<path>  Override the default GCS service account key location
+                                      (/var/vcap/jobs/gcs-blobstore-backup-restorer/config/gcp-service-account-key.json)
+  BBR_AZURE_CONTAINERS_CONFIG=<path>  Override the default Azure container configuration file location
+
+EXIT CODES:
+  0  All buckets are valid.
+  1  A probe failed for at least one bucket.
+  2  The configuration or flags could not be used, so no buckets were validated.
+
+S3-COMPATIBLE STORES:
+  Set "force_path_style": true on a bucket to address it as <endpoint>/<bucket> rather than <bucket>.<endpoint>.
 `
 
 const RunLocationHint = `Make sure to run this on your 'backup & restore' VM.`
 
-const ReadOnlyValidationHint = `Run with --validate-put-object to test writing objects to the buckets. Disclaimer: This will write test files to the buckets.`
+const ReadOnlyValidationHint = `Run with --validate-put-object to test writing and deleting objects in the buckets. Test objects are removed once validation has finished.`
 
 func OverrideDefaultHelpFlag(message string) {
 	flag.Usage = func() {
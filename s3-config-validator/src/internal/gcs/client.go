@@ -0,0 +1,11 @@
+package gcs
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 . Client
+
+type Client interface {
+	CanListObjects(bucket string) error
+	CanGetObjects(bucket string) error
+	CanPutObjects(bucket string) error
+	CanDeleteObjects(bucket string) error
+	RemoveTestObjects(bucket string) error
+}
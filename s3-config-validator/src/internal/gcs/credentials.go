@@ -0,0 +1,179 @@
+package gcs
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultTokenURI = "https://oauth2.googleapis.com/token"
+	readWriteScope  = "https://www.googleapis.com/auth/devstorage.read_write"
+	jwtBearerGrant  = "urn:ietf:params:oauth:grant-type:jwt-bearer"
+)
+
+type serviceAccountKey struct {
+	ClientEmail string `json:"client_email"`
+	PrivateKey  string `json:"private_key"`
+	TokenURI    string `json:"token_uri"`
+}
+
+// serviceAccountTransport signs a JWT with the service account's private key
+// and exchanges it for an access token, which it caches until shortly before
+// it expires and adds to every request.
+type serviceAccountTransport struct {
+	base       http.RoundTripper
+	email      string
+	tokenURI   string
+	privateKey *rsa.PrivateKey
+
+	mutex  sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+func newServiceAccountTransport(keyJSON []byte, base http.RoundTripper) (*serviceAccountTransport, error) {
+	var key serviceAccountKey
+	if err := json.Unmarshal(keyJSON, &key); err != nil {
+		return nil, fmt.Errorf("could not parse service account key: %s", err)
+	}
+
+	if key.ClientEmail == "" || key.PrivateKey == "" {
+		return nil, errors.New("could not parse service account key: client_email and private_key must be set")
+	}
+
+	privateKey, err := parsePrivateKey(key.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse service account key: %s", err)
+	}
+
+	tokenURI := key.TokenURI
+	if tokenURI == "" {
+		tokenURI = defaultTokenURI
+	}
+
+	return &serviceAccountTransport{
+		base:       base,
+		email:      key.ClientEmail,
+		tokenURI:   tokenURI,
+		privateKey: privateKey,
+	}, nil
+}
+
+func parsePrivateKey(pemKey string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(pemKey))
+	if block == nil {
+		return nil, errors.New("private_key is not PEM encoded")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("private_key is not an RSA key")
+	}
+
+	return rsaKey, nil
+}
+
+func (t *serviceAccountTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	token, err := t.accessToken()
+	if err != nil {
+		return nil, err
+	}
+
+	authorized := request.Clone(request.Context())
+	authorized.Header.Set("Authorization", "Bearer "+token)
+
+	return t.base.RoundTrip(authorized)
+}
+
+func (t *serviceAccountTransport) accessToken() (string, error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.token != "" && time.Now().Before(t.expiry) {
+		return t.token, nil
+	}
+
+	assertion, err := t.signedJWT(time.Now())
+	if err != nil {
+		return "", err
+	}
+
+	response, err := (&http.Client{Transport: t.base}).PostForm(t.tokenURI, url.Values{
+		"grant_type": {jwtBearerGrant},
+		"assertion":  {assertion},
+	})
+	if err != nil {
+		return "", fmt.Errorf("could not get an access token for %s: %s", t.email, err)
+	}
+	defer response.Body.Close() //nolint:errcheck
+
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("could not get an access token for %s: %s", t.email, response.Status)
+	}
+
+	var tokenResponse struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(response.Body).Decode(&tokenResponse); err != nil {
+		return "", fmt.Errorf("could not get an access token for %s: %s", t.email, err)
+	}
+
+	t.token = tokenResponse.AccessToken
+	t.expiry = time.Now().Add(time.Duration(tokenResponse.ExpiresIn)*time.Second - time.Minute)
+
+	return t.token, nil
+}
+
+func (t *serviceAccountTransport) signedJWT(now time.Time) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+
+	claims, err := json.Marshal(map[string]interface{}{
+		"iss":   t.email,
+		"scope": readWriteScope,
+		"aud":   t.tokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := strings.Join([]string{
+		base64.RawURLEncoding.EncodeToString(header),
+		base64.RawURLEncoding.EncodeToString(claims),
+	}, ".")
+
+	digest := sha256.Sum256([]byte(unsigned))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, t.privateKey, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", err
+	}
+
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
@@ -0,0 +1,226 @@
+package gcs
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/cloudfoundry/bosh-backup-and-restore/s3-config-validator/src/internal/testobject"
+)
+
+const DefaultEndpoint = "https://storage.googleapis.com"
+
+// GCSClient talks to the Cloud Storage JSON API. Setting Endpoint points it
+// at an emulator such as fake-gcs-server instead.
+type GCSClient struct {
+	HTTPClient *http.Client
+	Endpoint   string
+}
+
+// NewGCSClient authenticates as the service account in serviceAccountKey, the
+// JSON key that the gcs-blobstore-backup-restorer job is deployed with. The
+// key may only be left out when endpoint names an emulator, which does not
+// check credentials.
+func NewGCSClient(serviceAccountKey []byte, endpoint string) (*GCSClient, error) {
+	if endpoint == "" {
+		endpoint = DefaultEndpoint
+	}
+
+	httpClient := &http.Client{}
+	if len(bytes.TrimSpace(serviceAccountKey)) == 0 {
+		if endpoint == DefaultEndpoint {
+			return nil, errors.New("a service account key is required to validate buckets on Google Cloud Storage")
+		}
+	} else {
+		transport, err := newServiceAccountTransport(serviceAccountKey, http.DefaultTransport)
+		if err != nil {
+			return nil, err
+		}
+		httpClient.Transport = transport
+	}
+
+	return &GCSClient{
+		HTTPClient: httpClient,
+		Endpoint:   strings.TrimSuffix(endpoint, "/"),
+	}, nil
+}
+
+type object struct {
+	Name       string `json:"name"`
+	Generation string `json:"generation"`
+}
+
+type objectList struct {
+	Items         []object `json:"items"`
+	NextPageToken string   `json:"nextPageToken"`
+}
+
+func (c *GCSClient) CanListObjects(bucket string) error {
+	err := c.forEachObject(bucket, func(object) error { return nil })
+	if err != nil {
+		return fmt.Errorf("could not list objects in bucket %s: %s", bucket, err)
+	}
+
+	return nil
+}
+
+func (c *GCSClient) CanGetObjects(bucket string) error {
+	err := c.forEachObject(bucket, func(o object) error {
+		return c.do(http.MethodGet, c.objectURL(bucket, o.Name), nil, nil)
+	})
+	if err != nil {
+		return fmt.Errorf("could not get all objects from bucket %s", bucket)
+	}
+
+	return nil
+}
+
+func (c *GCSClient) CanPutObjects(bucket string) error {
+	err := c.putTestObject(bucket, testobject.Key("put"))
+	if err != nil {
+		return fmt.Errorf("could not put object into bucket %s: %s", bucket, err)
+	}
+
+	return nil
+}
+
+// CanDeleteObjects writes a test object of its own and deletes it again.
+func (c *GCSClient) CanDeleteObjects(bucket string) error {
+	name := testobject.Key("delete")
+
+	if err := c.putTestObject(bucket, name); err != nil {
+		return fmt.Errorf("could not put object to delete into bucket %s: %s", bucket, err)
+	}
+
+	if err := c.do(http.MethodDelete, c.objectURL(bucket, name), nil, nil); err != nil {
+		return fmt.Errorf("could not delete objects from bucket %s: %s", bucket, err)
+	}
+
+	return nil
+}
+
+// RemoveTestObjects deletes every generation of the objects that this run
+// wrote into the bucket, including noncurrent ones kept by object versioning.
+func (c *GCSClient) RemoveTestObjects(bucket string) error {
+	var testObjects []object
+	err := c.forEachObjectIn(bucket, url.Values{"prefix": {testobject.Prefix}, "versions": {"true"}}, func(o object) error {
+		testObjects = append(testObjects, o)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("could not list test objects in bucket %s: %s", bucket, err)
+	}
+
+	var leftovers []string
+	var lastErr error
+	for _, o := range testObjects {
+		deleteURL := c.objectURL(bucket, o.Name)
+		if o.Generation != "" {
+			deleteURL += "?" + url.Values{"generation": {o.Generation}}.Encode()
+		}
+
+		if err := c.do(http.MethodDelete, deleteURL, nil, nil); err != nil {
+			leftovers = append(leftovers, o.Name)
+			lastErr = err
+		}
+	}
+
+	if len(leftovers) > 0 {
+		return fmt.Errorf("could not delete %s from bucket %s: %s", strings.Join(leftovers, ", "), bucket, lastErr)
+	}
+
+	return nil
+}
+
+func (c *GCSClient) putTestObject(bucket, name string) error {
+	query := url.Values{
+		"uploadType": {"media"},
+		"name":       {name},
+	}
+	uploadURL := fmt.Sprintf("%s/upload/storage/v1/b/%s/o?%s", c.Endpoint, url.PathEscape(bucket), query.Encode())
+
+	return c.do(http.MethodPost, uploadURL, strings.NewReader(testobject.Content), nil)
+}
+
+func (c *GCSClient) forEachObject(bucket string, fn func(object) error) error {
+	return c.forEachObjectIn(bucket, url.Values{}, fn)
+}
+
+func (c *GCSClient) forEachObjectIn(bucket string, query url.Values, fn func(object) error) error {
+	pageToken := ""
+	for {
+		listURL := fmt.Sprintf("%s/storage/v1/b/%s/o", c.Endpoint, url.PathEscape(bucket))
+		if pageToken != "" {
+			query.Set("pageToken", pageToken)
+		}
+		if len(query) > 0 {
+			listURL += "?" + query.Encode()
+		}
+
+		var page objectList
+		if err := c.do(http.MethodGet, listURL, nil, &page); err != nil {
+			return err
+		}
+
+		for _, o := range page.Items {
+			if err := fn(o); err != nil {
+				return err
+			}
+		}
+
+		if page.NextPageToken == "" {
+			return nil
+		}
+		pageToken = page.NextPageToken
+	}
+}
+
+func (c *GCSClient) objectURL(bucket, name string) string {
+	return fmt.Sprintf("%s/storage/v1/b/%s/o/%s", c.Endpoint, url.PathEscape(bucket), url.PathEscape(name))
+}
+
+func (c *GCSClient) do(method, requestURL string, body io.Reader, result interface{}) error {
+	request, err := http.NewRequest(method, requestURL, body)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		request.Header.Set("Content-Type", "text/plain")
+	}
+
+	response, err := c.HTTPClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close() //nolint:errcheck
+
+	if response.StatusCode/100 != 2 {
+		return responseError(response)
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	return json.NewDecoder(response.Body).Decode(result)
+}
+
+func responseError(response *http.Response) error {
+	var errorResponse struct {
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+
+	body, _ := io.ReadAll(response.Body)
+	if json.Unmarshal(body, &errorResponse) == nil && errorResponse.Error.Message != "" {
+		return fmt.Errorf("%s: %s", response.Status, errorResponse.Error.Message)
+	}
+
+	return errors.New(response.Status)
+}
@@ -0,0 +1,268 @@
+package gcs_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/url"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/cloudfoundry/bosh-backup-and-restore/s3-config-validator/src/internal/gcs"
+	"github.com/cloudfoundry/bosh-backup-and-restore/s3-config-validator/src/internal/testobject"
+)
+
+var _ = Describe("GCSClient", func() {
+	var fakeGCSServer *ghttp.Server
+
+	BeforeEach(func() {
+		fakeGCSServer = ghttp.NewServer()
+	})
+
+	AfterEach(func() {
+		fakeGCSServer.Close()
+	})
+
+	When("there is no service account key", func() {
+		It("fails against Google Cloud Storage", func() {
+			_, err := gcs.NewGCSClient(nil, "")
+
+			Expect(err).To(MatchError("a service account key is required to validate buckets on Google Cloud Storage"))
+		})
+
+		It("does not authenticate against an emulator", func() {
+			fakeGCSServer.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/storage/v1/b/test-bucket/o"),
+				func(_ http.ResponseWriter, request *http.Request) {
+					Expect(request.Header.Get("Authorization")).To(BeEmpty())
+				},
+				ghttp.RespondWith(http.StatusOK, `{}`),
+			))
+
+			client, err := gcs.NewGCSClient(nil, fakeGCSServer.URL()+"/")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(client.CanListObjects("test-bucket")).To(Succeed())
+		})
+	})
+
+	Context("given an emulator", func() {
+		var client *gcs.GCSClient
+
+		BeforeEach(func() {
+			var err error
+			client, err = gcs.NewGCSClient(nil, fakeGCSServer.URL())
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("List Objects", func() {
+			It("lists every page", func() {
+				fakeGCSServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/storage/v1/b/test-bucket/o", ""),
+						ghttp.RespondWith(http.StatusOK, `{"items": [{"name": "1.mp4"}], "nextPageToken": "next"}`),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/storage/v1/b/test-bucket/o", "pageToken=next"),
+						ghttp.RespondWith(http.StatusOK, `{"items": [{"name": "2.mp4"}]}`),
+					),
+				)
+
+				Expect(client.CanListObjects("test-bucket")).To(Succeed())
+				Expect(fakeGCSServer.ReceivedRequests()).To(HaveLen(2))
+			})
+
+			It("reports why listing failed", func() {
+				fakeGCSServer.AppendHandlers(ghttp.RespondWith(http.StatusForbidden, `{"error": {"code": 403, "message": "no storage.objects.list access"}}`))
+
+				Expect(client.CanListObjects("test-bucket")).To(MatchError(
+					"could not list objects in bucket test-bucket: 403 Forbidden: no storage.objects.list access",
+				))
+			})
+		})
+
+		Context("Get Objects", func() {
+			BeforeEach(func() {
+				fakeGCSServer.AppendHandlers(
+					ghttp.RespondWith(http.StatusOK, `{"items": [{"name": "dir/1.mp4"}, {"name": "2.mp4"}]}`),
+					func(_ http.ResponseWriter, request *http.Request) {
+						Expect(request.URL.EscapedPath()).To(Equal("/storage/v1/b/test-bucket/o/dir%2F1.mp4"))
+					},
+				)
+			})
+
+			It("gets the metadata of every object", func() {
+				fakeGCSServer.AppendHandlers(ghttp.VerifyRequest("GET", "/storage/v1/b/test-bucket/o/2.mp4"))
+
+				Expect(client.CanGetObjects("test-bucket")).To(Succeed())
+				Expect(fakeGCSServer.ReceivedRequests()).To(HaveLen(3))
+			})
+
+			It("fails when an object cannot be read", func() {
+				fakeGCSServer.AppendHandlers(ghttp.RespondWith(http.StatusForbidden, ""))
+
+				Expect(client.CanGetObjects("test-bucket")).To(MatchError("could not get all objects from bucket test-bucket"))
+			})
+		})
+
+		Context("Put Object", func() {
+			It("uploads a test object", func() {
+				fakeGCSServer.AppendHandlers(ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/upload/storage/v1/b/test-bucket/o", url.Values{
+						"name":       {testobject.Key("put")},
+						"uploadType": {"media"},
+					}.Encode()),
+					ghttp.VerifyBody([]byte(testobject.Content)),
+					ghttp.RespondWith(http.StatusOK, `{}`),
+				))
+
+				Expect(client.CanPutObjects("test-bucket")).To(Succeed())
+			})
+
+			It("reports why uploading failed", func() {
+				fakeGCSServer.AppendHandlers(ghttp.RespondWith(http.StatusForbidden, "not json"))
+
+				Expect(client.CanPutObjects("test-bucket")).To(MatchError("could not put object into bucket test-bucket: 403 Forbidden"))
+			})
+		})
+
+		Context("Delete Object", func() {
+			It("uploads a test object and deletes it", func() {
+				fakeGCSServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", "/upload/storage/v1/b/test-bucket/o", url.Values{
+							"name":       {testobject.Key("delete")},
+							"uploadType": {"media"},
+						}.Encode()),
+						ghttp.RespondWith(http.StatusOK, `{}`),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("DELETE", "/storage/v1/b/test-bucket/o/"+testobject.Key("delete")),
+						ghttp.RespondWith(http.StatusNoContent, ""),
+					),
+				)
+
+				Expect(client.CanDeleteObjects("test-bucket")).To(Succeed())
+			})
+
+			It("reports why deleting failed", func() {
+				fakeGCSServer.AppendHandlers(
+					ghttp.RespondWith(http.StatusOK, `{}`),
+					ghttp.RespondWith(http.StatusForbidden, `{"error": {"message": "no storage.objects.delete permission"}}`),
+				)
+
+				Expect(client.CanDeleteObjects("test-bucket")).To(MatchError(
+					"could not delete objects from bucket test-bucket: 403 Forbidden: no storage.objects.delete permission",
+				))
+			})
+		})
+
+		Context("Remove Test Objects", func() {
+			BeforeEach(func() {
+				fakeGCSServer.AppendHandlers(ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/storage/v1/b/test-bucket/o", url.Values{
+						"prefix":   {testobject.Prefix},
+						"versions": {"true"},
+					}.Encode()),
+					ghttp.RespondWith(http.StatusOK, `{"items": [
+						{"name": "`+testobject.Key("put")+`", "generation": "1"},
+						{"name": "`+testobject.Key("put")+`", "generation": "2"}
+					]}`),
+				))
+			})
+
+			It("deletes every generation of the objects under this run's prefix", func() {
+				fakeGCSServer.AppendHandlers(
+					ghttp.VerifyRequest("DELETE", "/storage/v1/b/test-bucket/o/"+testobject.Key("put"), "generation=1"),
+					ghttp.VerifyRequest("DELETE", "/storage/v1/b/test-bucket/o/"+testobject.Key("put"), "generation=2"),
+				)
+
+				Expect(client.RemoveTestObjects("test-bucket")).To(Succeed())
+				Expect(fakeGCSServer.ReceivedRequests()).To(HaveLen(3))
+			})
+
+			It("reports the objects it could not delete", func() {
+				fakeGCSServer.AppendHandlers(
+					ghttp.RespondWith(http.StatusNoContent, ""),
+					ghttp.RespondWith(http.StatusForbidden, ""),
+				)
+
+				Expect(client.RemoveTestObjects("test-bucket")).To(MatchError(
+					"could not delete " + testobject.Key("put") + " from bucket test-bucket: 403 Forbidden",
+				))
+			})
+		})
+	})
+
+	Context("given a service account key", func() {
+		var serviceAccountKey []byte
+
+		BeforeEach(func() {
+			privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+			Expect(err).NotTo(HaveOccurred())
+			pkcs8, err := x509.MarshalPKCS8PrivateKey(privateKey)
+			Expect(err).NotTo(HaveOccurred())
+
+			serviceAccountKey, err = json.Marshal(map[string]string{
+				"type":         "service_account",
+				"client_email": "validator@project.iam.gserviceaccount.com",
+				"private_key":  string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8})),
+				"token_uri":    fakeGCSServer.URL() + "/token",
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("exchanges a signed JWT for an access token and reuses it", func() {
+			fakeGCSServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/token"),
+					func(_ http.ResponseWriter, request *http.Request) {
+						Expect(request.ParseForm()).To(Succeed())
+						Expect(request.PostForm.Get("grant_type")).To(Equal("urn:ietf:params:oauth:grant-type:jwt-bearer"))
+						Expect(strings.Split(request.PostForm.Get("assertion"), ".")).To(HaveLen(3))
+					},
+					ghttp.RespondWith(http.StatusOK, `{"access_token": "test-token", "expires_in": 3600}`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/storage/v1/b/test-bucket/o"),
+					ghttp.VerifyHeaderKV("Authorization", "Bearer test-token"),
+					ghttp.RespondWith(http.StatusOK, `{}`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/storage/v1/b/test-bucket/o"),
+					ghttp.VerifyHeaderKV("Authorization", "Bearer test-token"),
+					ghttp.RespondWith(http.StatusOK, `{}`),
+				),
+			)
+
+			client, err := gcs.NewGCSClient(serviceAccountKey, fakeGCSServer.URL())
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(client.CanListObjects("test-bucket")).To(Succeed())
+			Expect(client.CanListObjects("test-bucket")).To(Succeed())
+		})
+
+		It("fails the probe when the token is refused", func() {
+			fakeGCSServer.AppendHandlers(ghttp.RespondWith(http.StatusBadRequest, `{"error": "invalid_grant"}`))
+
+			client, err := gcs.NewGCSClient(serviceAccountKey, fakeGCSServer.URL())
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(client.CanListObjects("test-bucket")).To(MatchError(ContainSubstring(
+				"could not get an access token for validator@project.iam.gserviceaccount.com: 400 Bad Request",
+			)))
+		})
+	})
+
+	It("fails when the service account key cannot be parsed", func() {
+		_, err := gcs.NewGCSClient([]byte(`{"client_email": "validator@project.iam.gserviceaccount.com", "private_key": "not pem"}`), "")
+
+		Expect(err).To(MatchError("could not parse service account key: private_key is not PEM encoded"))
+	})
+})
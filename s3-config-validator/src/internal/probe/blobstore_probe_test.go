@@ -0,0 +1,74 @@
+package probe_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/cloudfoundry/bosh-backup-and-restore/s3-config-validator/src/internal/azure/azurefakes"
+	"github.com/cloudfoundry/bosh-backup-and-restore/s3-config-validator/src/internal/gcs/gcsfakes"
+	. "github.com/cloudfoundry/bosh-backup-and-restore/s3-config-validator/src/internal/probe"
+)
+
+var _ = Describe("GCS ProbeSet", func() {
+	var fakeGCSClient *gcsfakes.FakeClient
+
+	BeforeEach(func() {
+		fakeGCSClient = new(gcsfakes.FakeClient)
+	})
+
+	It("runs just the read-only probes", func() {
+		fakeGCSClient.CanGetObjectsReturns(errors.New("forbidden"))
+
+		Expect(runAllProbesAgainstBucket(NewGCSSet(fakeGCSClient, true), "test-bucket")).To(Equal([]ProbeResult{
+			{Name: "Can list objects", Succeeded: true},
+			{Name: "Can get objects", Succeeded: false},
+		}))
+		Expect(fakeGCSClient.CanListObjectsArgsForCall(0)).To(Equal("test-bucket"))
+		Expect(fakeGCSClient.CanGetObjectsArgsForCall(0)).To(Equal("test-bucket"))
+		Expect(fakeGCSClient.CanPutObjectsCallCount()).To(BeZero())
+		Expect(fakeGCSClient.CanDeleteObjectsCallCount()).To(BeZero())
+	})
+
+	It("also checks writing when not read-only", func() {
+		Expect(runAllProbesAgainstBucket(NewGCSSet(fakeGCSClient, false), "test-bucket")).To(ContainElements(
+			ProbeResult{Name: "Can put objects", Succeeded: true},
+			ProbeResult{Name: "Can delete objects", Succeeded: true},
+		))
+		Expect(fakeGCSClient.CanPutObjectsArgsForCall(0)).To(Equal("test-bucket"))
+		Expect(fakeGCSClient.CanDeleteObjectsArgsForCall(0)).To(Equal("test-bucket"))
+	})
+})
+
+var _ = Describe("Azure ProbeSet", func() {
+	var fakeAzureClient *azurefakes.FakeClient
+
+	BeforeEach(func() {
+		fakeAzureClient = new(azurefakes.FakeClient)
+	})
+
+	It("runs just the read-only probes", func() {
+		fakeAzureClient.IsSoftDeleteEnabledReturns(errors.New("soft delete is not enabled"))
+
+		Expect(runAllProbesAgainstBucket(NewAzureSet(fakeAzureClient, true), "test-container")).To(Equal([]ProbeResult{
+			{Name: "Soft delete is enabled", Succeeded: false},
+			{Name: "Can list blobs", Succeeded: true},
+			{Name: "Can get blobs", Succeeded: true},
+		}))
+		Expect(fakeAzureClient.IsSoftDeleteEnabledArgsForCall(0)).To(Equal("test-container"))
+		Expect(fakeAzureClient.CanListBlobsArgsForCall(0)).To(Equal("test-container"))
+		Expect(fakeAzureClient.CanGetBlobsArgsForCall(0)).To(Equal("test-container"))
+		Expect(fakeAzureClient.CanPutBlobsCallCount()).To(BeZero())
+		Expect(fakeAzureClient.CanDeleteBlobsCallCount()).To(BeZero())
+	})
+
+	It("also checks writing when not read-only", func() {
+		Expect(runAllProbesAgainstBucket(NewAzureSet(fakeAzureClient, false), "test-container")).To(ContainElements(
+			ProbeResult{Name: "Can put blobs", Succeeded: true},
+			ProbeResult{Name: "Can delete blobs", Succeeded: true},
+		))
+		Expect(fakeAzureClient.CanPutBlobsArgsForCall(0)).To(Equal("test-container"))
+		Expect(fakeAzureClient.CanDeleteBlobsArgsForCall(0)).To(Equal("test-container"))
+	})
+})
@@ -0,0 +1,100 @@
+package probe_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-backup-and-restore/s3-config-validator/src/internal/probe"
+	"github.com/cloudfoundry/bosh-backup-and-restore/s3-config-validator/src/internal/s3/s3fakes"
+)
+
+var _ = Describe("ProbeSet with checks", func() {
+	var (
+		bucket       = "test-bucket"
+		fakeS3Client *s3fakes.FakeClient
+	)
+
+	BeforeEach(func() {
+		fakeS3Client = new(s3fakes.FakeClient)
+	})
+
+	Context("versioned live bucket", func() {
+		It("also checks lifecycle rules, object lock and the KMS key", func() {
+			fakeS3Client.KeepsNoncurrentVersionsForReturns(errors.New("expires too soon"))
+
+			probeSet := NewSetWithChecks(fakeS3Client, Checks{ReadOnly: true, Versioned: true, RetentionDays: 14})
+
+			Expect(runAllProbesAgainstBucket(probeSet, bucket)).To(Equal([]ProbeResult{
+				{Name: "Bucket is versioned", Succeeded: true},
+				{Name: "Can list object versions", Succeeded: true},
+				{Name: "Can get object versions", Succeeded: true},
+				{Name: "Lifecycle rules keep noncurrent versions for 14 days", Succeeded: false},
+				{Name: "Object lock allows deletion", Succeeded: true},
+				{Name: "Can use the bucket's KMS key", Succeeded: true},
+			}))
+
+			actualBucket, days := fakeS3Client.KeepsNoncurrentVersionsForArgsForCall(0)
+			Expect(actualBucket).To(Equal(bucket))
+			Expect(days).To(Equal(14))
+			Expect(fakeS3Client.AllowsDeletionArgsForCall(0)).To(Equal(bucket))
+			Expect(fakeS3Client.CanUseEncryptionKeyArgsForCall(0)).To(Equal(bucket))
+		})
+	})
+
+	Context("unversioned live bucket", func() {
+		It("does not check lifecycle rules", func() {
+			probeSet := NewSetWithChecks(fakeS3Client, Checks{ReadOnly: false, RetentionDays: 14})
+
+			Expect(runAllProbesAgainstBucket(probeSet, bucket)).To(Equal([]ProbeResult{
+				{Name: "Bucket is not versioned", Succeeded: true},
+				{Name: "Can list objects", Succeeded: true},
+				{Name: "Can get objects", Succeeded: true},
+				{Name: "Can put objects", Succeeded: true},
+				{Name: "Object lock allows deletion", Succeeded: true},
+				{Name: "Can use the bucket's KMS key", Succeeded: true},
+				{Name: "Can delete objects", Succeeded: true},
+			}))
+			Expect(fakeS3Client.KeepsObjectsForCallCount()).To(BeZero())
+			Expect(fakeS3Client.CanCopyObjectsFromCallCount()).To(BeZero())
+		})
+	})
+
+	Context("unversioned backup bucket", func() {
+		It("checks expiry of backups and copying from the live bucket", func() {
+			fakeS3Client.CanCopyObjectsFromReturns(errors.New("access denied"))
+
+			probeSet := NewSetWithChecks(fakeS3Client, Checks{ReadOnly: false, RetentionDays: 14, CopySource: "live-bucket"})
+
+			Expect(runAllProbesAgainstBucket(probeSet, bucket)).To(Equal([]ProbeResult{
+				{Name: "Bucket is not versioned", Succeeded: true},
+				{Name: "Can list objects", Succeeded: true},
+				{Name: "Can get objects", Succeeded: true},
+				{Name: "Can put objects", Succeeded: true},
+				{Name: "Lifecycle rules keep objects for 14 days", Succeeded: true},
+				{Name: "Can use the bucket's KMS key", Succeeded: true},
+				{Name: "Can copy objects from bucket live-bucket", Succeeded: false},
+				{Name: "Can delete objects", Succeeded: true},
+			}))
+
+			actualBucket, days := fakeS3Client.KeepsObjectsForArgsForCall(0)
+			Expect(actualBucket).To(Equal(bucket))
+			Expect(days).To(Equal(14))
+			source, destination := fakeS3Client.CanCopyObjectsFromArgsForCall(0)
+			Expect(source).To(Equal("live-bucket"))
+			Expect(destination).To(Equal(bucket))
+			Expect(fakeS3Client.AllowsDeletionCallCount()).To(BeZero())
+			Expect(fakeS3Client.CanDeleteObjectsArgsForCall(0)).To(Equal(bucket))
+		})
+
+		It("does not copy when read-only", func() {
+			probeSet := NewSetWithChecks(fakeS3Client, Checks{ReadOnly: true, CopySource: "live-bucket"})
+
+			runAllProbesAgainstBucket(probeSet, bucket)
+
+			Expect(fakeS3Client.CanCopyObjectsFromCallCount()).To(BeZero())
+			Expect(fakeS3Client.CanDeleteObjectsCallCount()).To(BeZero())
+		})
+	})
+})
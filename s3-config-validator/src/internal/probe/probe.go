@@ -1,6 +1,10 @@
 package probe
 
 import (
+	"fmt"
+
+	"github.com/cloudfoundry/bosh-backup-and-restore/s3-config-validator/src/internal/azure"
+	"github.com/cloudfoundry/bosh-backup-and-restore/s3-config-validator/src/internal/gcs"
 	"github.com/cloudfoundry/bosh-backup-and-restore/s3-config-validator/src/internal/s3"
 )
 
@@ -13,6 +17,75 @@ type NamedProbe struct {
 
 type Set []NamedProbe
 
+// DefaultRetentionDays is how long backups must stay restorable unless
+// configured otherwise.
+const DefaultRetentionDays = 7
+
+// Checks describes a bucket and what to validate about it.
+type Checks struct {
+	ReadOnly  bool
+	Versioned bool
+	// RetentionDays is how long backups must stay restorable; lifecycle
+	// rules that delete them sooner fail validation.
+	RetentionDays int
+	// CopySource is the live bucket that an unversioned backup bucket is
+	// copied from. It is empty for live buckets.
+	CopySource string
+}
+
+// NewSetWithChecks adds the bucket's lifecycle, object lock and encryption
+// settings to the probes of NewSet, copying from the live bucket for
+// unversioned backup buckets, and deleting objects unless read-only.
+func NewSetWithChecks(s3 s3.Client, checks Checks) Set {
+	probeSet := NewSet(s3, checks.ReadOnly, checks.Versioned)
+
+	if checks.Versioned {
+		probeSet = append(probeSet, NamedProbe{
+			Name: fmt.Sprintf("Lifecycle rules keep noncurrent versions for %d days", checks.RetentionDays),
+			Probe: func(bucket string) error {
+				return s3.KeepsNoncurrentVersionsFor(bucket, checks.RetentionDays)
+			},
+		})
+	} else if checks.CopySource != "" {
+		probeSet = append(probeSet, NamedProbe{
+			Name: fmt.Sprintf("Lifecycle rules keep objects for %d days", checks.RetentionDays),
+			Probe: func(bucket string) error {
+				return s3.KeepsObjectsFor(bucket, checks.RetentionDays)
+			},
+		})
+	}
+
+	if checks.CopySource == "" {
+		probeSet = append(probeSet, NamedProbe{
+			Name:  "Object lock allows deletion",
+			Probe: s3.AllowsDeletion,
+		})
+	}
+
+	probeSet = append(probeSet, NamedProbe{
+		Name:  "Can use the bucket's KMS key",
+		Probe: s3.CanUseEncryptionKey,
+	})
+
+	if !checks.ReadOnly && checks.CopySource != "" {
+		probeSet = append(probeSet, NamedProbe{
+			Name: fmt.Sprintf("Can copy objects from bucket %s", checks.CopySource),
+			Probe: func(bucket string) error {
+				return s3.CanCopyObjectsFrom(checks.CopySource, bucket)
+			},
+		})
+	}
+
+	if !checks.ReadOnly {
+		probeSet = append(probeSet, NamedProbe{
+			Name:  "Can delete objects",
+			Probe: s3.CanDeleteObjects,
+		})
+	}
+
+	return probeSet
+}
+
 func NewSet(s3 s3.Client, readOnly bool, versioned bool) Set {
 
 	var probeSet Set
@@ -61,3 +134,63 @@ func NewSet(s3 s3.Client, readOnly bool, versioned bool) Set {
 
 	return probeSet
 }
+
+func NewGCSSet(gcs gcs.Client, readOnly bool) Set {
+	probeSet := Set{
+		{
+			Name:  "Can list objects",
+			Probe: gcs.CanListObjects,
+		},
+		{
+			Name:  "Can get objects",
+			Probe: gcs.CanGetObjects,
+		},
+	}
+
+	if !readOnly {
+		probeSet = append(probeSet,
+			NamedProbe{
+				Name:  "Can put objects",
+				Probe: gcs.CanPutObjects,
+			},
+			NamedProbe{
+				Name:  "Can delete objects",
+				Probe: gcs.CanDeleteObjects,
+			},
+		)
+	}
+
+	return probeSet
+}
+
+func NewAzureSet(azure azure.Client, readOnly bool) Set {
+	probeSet := Set{
+		{
+			Name:  "Soft delete is enabled",
+			Probe: azure.IsSoftDeleteEnabled,
+		},
+		{
+			Name:  "Can list blobs",
+			Probe: azure.CanListBlobs,
+		},
+		{
+			Name:  "Can get blobs",
+			Probe: azure.CanGetBlobs,
+		},
+	}
+
+	if !readOnly {
+		probeSet = append(probeSet,
+			NamedProbe{
+				Name:  "Can put blobs",
+				Probe: azure.CanPutBlobs,
+			},
+			NamedProbe{
+				Name:  "Can delete blobs",
+				Probe: azure.CanDeleteBlobs,
+			},
+		)
+	}
+
+	return probeSet
+}
@@ -0,0 +1,204 @@
+package report
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+
+	"github.com/cloudfoundry/bosh-backup-and-restore/s3-config-validator/src/internal/runner"
+)
+
+// Report describes a whole validation run, so that it can be consumed by CI
+// and dashboards rather than read off the terminal.
+type Report struct {
+	Blobstore  string   `json:"blobstore"`
+	ConfigPath string   `json:"config_path"`
+	ReadOnly   bool     `json:"read_only"`
+	Valid      bool     `json:"valid"`
+	Error      string   `json:"error,omitempty"`
+	Buckets    []Bucket `json:"buckets"`
+}
+
+type Bucket struct {
+	Resource string  `json:"resource"`
+	Name     string  `json:"name"`
+	Type     string  `json:"type"`
+	Valid    bool    `json:"valid"`
+	Probes   []Probe `json:"probes"`
+	// CleanupError lists the test objects that were left in the bucket.
+	CleanupError string `json:"cleanup_error,omitempty"`
+}
+
+type Probe struct {
+	Name            string  `json:"name"`
+	Passed          bool    `json:"passed"`
+	DurationSeconds float64 `json:"duration_seconds"`
+	Error           string  `json:"error,omitempty"`
+}
+
+// New builds the report of a run. err is set when the configuration could
+// not be read, in which case no probes were run.
+func New(blobstore, configPath string, readOnly bool, results []runner.Result, err error) Report {
+	report := Report{
+		Blobstore:  blobstore,
+		ConfigPath: configPath,
+		ReadOnly:   readOnly,
+		Valid:      err == nil,
+		Buckets:    []Bucket{},
+	}
+
+	if err != nil {
+		report.Error = err.Error()
+	}
+
+	for _, result := range results {
+		bucket := Bucket{
+			Resource: result.Bucket.Resource,
+			Name:     result.Bucket.Name,
+			Type:     string(result.Bucket.Type),
+			Valid:    result.Succeeded(),
+			Probes:   []Probe{},
+		}
+
+		for _, probeResult := range result.Probes {
+			probe := Probe{
+				Name:            probeResult.Name,
+				Passed:          probeResult.Err == nil,
+				DurationSeconds: probeResult.Duration.Seconds(),
+			}
+			if probeResult.Err != nil {
+				probe.Error = probeResult.Err.Error()
+			}
+
+			bucket.Probes = append(bucket.Probes, probe)
+		}
+
+		if result.CleanupErr != nil {
+			bucket.CleanupError = result.CleanupErr.Error()
+		}
+
+		if !bucket.Valid {
+			report.Valid = false
+		}
+
+		report.Buckets = append(report.Buckets, bucket)
+	}
+
+	return report
+}
+
+func (r Report) WriteJSON(writer io.Writer) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(r)
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Errors   int             `xml:"errors,attr"`
+	Time     string          `xml:"time,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+	// SystemErr carries cleanup failures, which do not fail a test case.
+	SystemErr string `xml:"system-err,omitempty"`
+}
+
+type junitTestCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitProblem `xml:"failure,omitempty"`
+	Error     *junitProblem `xml:"error,omitempty"`
+}
+
+type junitProblem struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteJUnit writes a test suite per bucket with a test case per probe. A
+// configuration that could not be read is reported as an erroring test case.
+func (r Report) WriteJUnit(writer io.Writer) error {
+	suites := junitTestSuites{Name: "bbr-s3-config-validator"}
+
+	if r.Error != "" {
+		suites.Suites = append(suites.Suites, junitTestSuite{
+			Name:   r.Blobstore + " configuration",
+			Tests:  1,
+			Errors: 1,
+			Time:   seconds(0),
+			Cases: []junitTestCase{{
+				ClassName: r.Blobstore,
+				Name:      "Configuration at " + r.ConfigPath + " is valid",
+				Time:      seconds(0),
+				Error:     &junitProblem{Message: r.Error, Text: r.Error},
+			}},
+		})
+	}
+
+	var totalSeconds float64
+	for _, bucket := range r.Buckets {
+		suite := junitTestSuite{
+			Name:      fmt.Sprintf("%s %s bucket %s", bucket.Resource, bucket.Type, bucket.Name),
+			Tests:     len(bucket.Probes),
+			SystemErr: bucket.CleanupError,
+		}
+
+		var suiteSeconds float64
+		for _, probe := range bucket.Probes {
+			testCase := junitTestCase{
+				ClassName: fmt.Sprintf("%s.%s.%s", bucket.Resource, bucket.Type, bucket.Name),
+				Name:      probe.Name,
+				Time:      seconds(probe.DurationSeconds),
+			}
+			if !probe.Passed {
+				testCase.Failure = &junitProblem{Message: probe.Error, Text: probe.Error}
+				suite.Failures++
+			}
+
+			suiteSeconds += probe.DurationSeconds
+			suite.Cases = append(suite.Cases, testCase)
+		}
+		suite.Time = seconds(suiteSeconds)
+
+		totalSeconds += suiteSeconds
+		suites.Suites = append(suites.Suites, suite)
+	}
+
+	for _, suite := range suites.Suites {
+		suites.Tests += suite.Tests
+		suites.Failures += suite.Failures
+		suites.Errors += suite.Errors
+	}
+	suites.Time = seconds(totalSeconds)
+
+	if _, err := io.WriteString(writer, xml.Header); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(writer)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(suites); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(writer, "\n")
+	return err
+}
+
+func seconds(seconds float64) string {
+	return fmt.Sprintf("%.3f", seconds)
+}
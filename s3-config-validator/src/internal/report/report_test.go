@@ -0,0 +1,231 @@
+package report_test
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/cloudfoundry/bosh-backup-and-restore/s3-config-validator/src/internal/report"
+	"github.com/cloudfoundry/bosh-backup-and-restore/s3-config-validator/src/internal/runner"
+)
+
+var _ = Describe("Report", func() {
+	var results []runner.Result
+
+	BeforeEach(func() {
+		results = []runner.Result{
+			{
+				Bucket: runner.Bucket{Resource: "droplets", Name: "droplets-live", Type: runner.Live},
+				Probes: []runner.ProbeResult{
+					{Name: "Can list objects", Duration: 1500 * time.Millisecond},
+					{Name: "Can get objects", Duration: 250 * time.Millisecond, Err: errors.New("access denied")},
+				},
+			},
+			{
+				Bucket: runner.Bucket{Resource: "droplets", Name: "droplets-backup", Type: runner.Backup},
+				Probes: []runner.ProbeResult{
+					{Name: "Can list objects", Duration: 500 * time.Millisecond},
+				},
+			},
+		}
+	})
+
+	Describe("New", func() {
+		It("records every bucket and probe", func() {
+			validationReport := report.New("s3", "/path/to/buckets.json", true, results, nil)
+
+			Expect(validationReport).To(Equal(report.Report{
+				Blobstore:  "s3",
+				ConfigPath: "/path/to/buckets.json",
+				ReadOnly:   true,
+				Valid:      false,
+				Buckets: []report.Bucket{
+					{
+						Resource: "droplets",
+						Name:     "droplets-live",
+						Type:     "live",
+						Valid:    false,
+						Probes: []report.Probe{
+							{Name: "Can list objects", Passed: true, DurationSeconds: 1.5},
+							{Name: "Can get objects", Passed: false, DurationSeconds: 0.25, Error: "access denied"},
+						},
+					},
+					{
+						Resource: "droplets",
+						Name:     "droplets-backup",
+						Type:     "backup",
+						Valid:    true,
+						Probes: []report.Probe{
+							{Name: "Can list objects", Passed: true, DurationSeconds: 0.5},
+						},
+					},
+				},
+			}))
+		})
+
+		It("is valid when every probe passed", func() {
+			validationReport := report.New("s3", "/path/to/buckets.json", true, results[1:], nil)
+
+			Expect(validationReport.Valid).To(BeTrue())
+		})
+
+		It("records the test objects left in a bucket without failing it", func() {
+			results[1].CleanupErr = errors.New("could not delete put from bucket droplets-backup")
+
+			validationReport := report.New("s3", "/path/to/buckets.json", false, results[1:], nil)
+
+			Expect(validationReport.Valid).To(BeTrue())
+			Expect(validationReport.Buckets[0].CleanupError).To(Equal("could not delete put from bucket droplets-backup"))
+		})
+
+		It("is invalid when the configuration could not be read", func() {
+			validationReport := report.New("gcs", "/path/to/buckets.json", false, nil, errors.New("no such file"))
+
+			Expect(validationReport.Valid).To(BeFalse())
+			Expect(validationReport.Error).To(Equal("no such file"))
+			Expect(validationReport.Buckets).To(BeEmpty())
+		})
+	})
+
+	Describe("WriteJSON", func() {
+		It("writes the report as JSON", func() {
+			buffer := &bytes.Buffer{}
+
+			err := report.New("s3", "/path/to/buckets.json", true, results[1:], nil).WriteJSON(buffer)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(buffer.String()).To(MatchJSON(`{
+				"blobstore": "s3",
+				"config_path": "/path/to/buckets.json",
+				"read_only": true,
+				"valid": true,
+				"buckets": [
+					{
+						"resource": "droplets",
+						"name": "droplets-backup",
+						"type": "backup",
+						"valid": true,
+						"probes": [
+							{"name": "Can list objects", "passed": true, "duration_seconds": 0.5}
+						]
+					}
+				]
+			}`))
+		})
+
+		It("includes the error when the configuration could not be read", func() {
+			buffer := &bytes.Buffer{}
+
+			err := report.New("s3", "/path/to/buckets.json", true, nil, errors.New("no such file")).WriteJSON(buffer)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(buffer.String()).To(MatchJSON(`{
+				"blobstore": "s3",
+				"config_path": "/path/to/buckets.json",
+				"read_only": true,
+				"valid": false,
+				"error": "no such file",
+				"buckets": []
+			}`))
+		})
+	})
+
+	Describe("WriteJUnit", func() {
+		type testCase struct {
+			ClassName string `xml:"classname,attr"`
+			Name      string `xml:"name,attr"`
+			Time      string `xml:"time,attr"`
+			Failure   *struct {
+				Message string `xml:"message,attr"`
+			} `xml:"failure"`
+			Error *struct {
+				Message string `xml:"message,attr"`
+			} `xml:"error"`
+		}
+
+		type testSuites struct {
+			Tests    int    `xml:"tests,attr"`
+			Failures int    `xml:"failures,attr"`
+			Errors   int    `xml:"errors,attr"`
+			Time     string `xml:"time,attr"`
+			Suites   []struct {
+				Name     string     `xml:"name,attr"`
+				Tests    int        `xml:"tests,attr"`
+				Failures int        `xml:"failures,attr"`
+				Time     string     `xml:"time,attr"`
+				Cases    []testCase `xml:"testcase"`
+			} `xml:"testsuite"`
+		}
+
+		It("writes a test suite per bucket and a test case per probe", func() {
+			buffer := &bytes.Buffer{}
+
+			err := report.New("s3", "/path/to/buckets.json", true, results, nil).WriteJUnit(buffer)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(buffer.String()).To(HavePrefix(xml.Header))
+
+			var suites testSuites
+			Expect(xml.Unmarshal(buffer.Bytes(), &suites)).To(Succeed())
+
+			Expect(suites.Tests).To(Equal(3))
+			Expect(suites.Failures).To(Equal(1))
+			Expect(suites.Errors).To(Equal(0))
+			Expect(suites.Time).To(Equal("2.250"))
+
+			Expect(suites.Suites).To(HaveLen(2))
+			Expect(suites.Suites[0].Name).To(Equal("droplets live bucket droplets-live"))
+			Expect(suites.Suites[0].Tests).To(Equal(2))
+			Expect(suites.Suites[0].Failures).To(Equal(1))
+			Expect(suites.Suites[0].Time).To(Equal("1.750"))
+
+			Expect(suites.Suites[0].Cases[0].ClassName).To(Equal("droplets.live.droplets-live"))
+			Expect(suites.Suites[0].Cases[0].Name).To(Equal("Can list objects"))
+			Expect(suites.Suites[0].Cases[0].Time).To(Equal("1.500"))
+			Expect(suites.Suites[0].Cases[0].Failure).To(BeNil())
+
+			Expect(suites.Suites[0].Cases[1].Failure).NotTo(BeNil())
+			Expect(suites.Suites[0].Cases[1].Failure.Message).To(Equal("access denied"))
+
+			Expect(suites.Suites[1].Name).To(Equal("droplets backup bucket droplets-backup"))
+		})
+
+		It("writes test objects left in a bucket to the suite's system-err", func() {
+			results[0].CleanupErr = errors.New("could not delete put from bucket droplets-live")
+			buffer := &bytes.Buffer{}
+
+			err := report.New("s3", "/path/to/buckets.json", false, results, nil).WriteJUnit(buffer)
+			Expect(err).NotTo(HaveOccurred())
+
+			var suites struct {
+				Suites []struct {
+					SystemErr string `xml:"system-err"`
+				} `xml:"testsuite"`
+			}
+			Expect(xml.Unmarshal(buffer.Bytes(), &suites)).To(Succeed())
+
+			Expect(suites.Suites[0].SystemErr).To(Equal("could not delete put from bucket droplets-live"))
+			Expect(suites.Suites[1].SystemErr).To(BeEmpty())
+		})
+
+		It("reports a configuration that could not be read as an error", func() {
+			buffer := &bytes.Buffer{}
+
+			err := report.New("azure", "/path/to/containers.json", true, nil, errors.New("no such file")).WriteJUnit(buffer)
+			Expect(err).NotTo(HaveOccurred())
+
+			var suites testSuites
+			Expect(xml.Unmarshal(buffer.Bytes(), &suites)).To(Succeed())
+
+			Expect(suites.Tests).To(Equal(1))
+			Expect(suites.Errors).To(Equal(1))
+			Expect(suites.Suites).To(HaveLen(1))
+			Expect(suites.Suites[0].Cases[0].Name).To(Equal("Configuration at /path/to/containers.json is valid"))
+			Expect(suites.Suites[0].Cases[0].Error).NotTo(BeNil())
+			Expect(suites.Suites[0].Cases[0].Error.Message).To(Equal("no such file"))
+		})
+	})
+})
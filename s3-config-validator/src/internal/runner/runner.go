@@ -5,8 +5,11 @@ import (
 	"io"
 	"os"
 	"strings"
+	"time"
 
+	"github.com/cloudfoundry/bosh-backup-and-restore/s3-config-validator/src/internal/azure"
 	"github.com/cloudfoundry/bosh-backup-and-restore/s3-config-validator/src/internal/config"
+	"github.com/cloudfoundry/bosh-backup-and-restore/s3-config-validator/src/internal/gcs"
 	"github.com/cloudfoundry/bosh-backup-and-restore/s3-config-validator/src/internal/probe"
 	"github.com/cloudfoundry/bosh-backup-and-restore/s3-config-validator/src/internal/s3"
 )
@@ -19,6 +22,9 @@ type ProbeRunner struct {
 	Bucket   Bucket
 	ProbeSet probe.Set
 	Writer   io.Writer
+	// Cleanup removes the test objects that the probes wrote into the bucket.
+	// It is nil when the probes are read-only.
+	Cleanup probe.Probe
 }
 
 type BucketType string
@@ -42,19 +48,55 @@ func (b Bucket) String() string {
 	return fmt.Sprintf("%s's %s bucket %s", b.Resource, b.Type, b.Name)
 }
 
-func (r *ProbeRunner) Run() (succeeded bool) {
-	succeeded = true
+// ProbeResult is the outcome of a single probe. Err is nil if it passed.
+type ProbeResult struct {
+	Name     string
+	Duration time.Duration
+	Err      error
+}
+
+// Result is the outcome of every probe run against a bucket, and of removing
+// the test objects they wrote.
+type Result struct {
+	Bucket     Bucket
+	Probes     []ProbeResult
+	CleanupErr error
+}
+
+func (r Result) Succeeded() bool {
+	for _, probeResult := range r.Probes {
+		if probeResult.Err != nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (r *ProbeRunner) Run() bool {
+	return r.Validate().Succeeded()
+}
+
+// Validate runs the probes in order, writing each outcome to the runner's
+// writer as it completes, and returns all of them.
+func (r *ProbeRunner) Validate() Result {
+	result := Result{Bucket: r.Bucket}
 
 	_, _ = fmt.Fprintf(r.Writer, "Validating %s ...\n", r.Bucket)
 
 	for _, namedProbe := range r.ProbeSet {
 		_, _ = fmt.Fprintf(r.Writer, " * %s ... ", namedProbe.Name)
 
+		start := time.Now()
 		err := namedProbe.Probe(r.Bucket.Name)
 
-		if err != nil {
-			succeeded = false
+		result.Probes = append(result.Probes, ProbeResult{
+			Name:     namedProbe.Name,
+			Duration: time.Since(start),
+			Err:      err,
+		})
 
+		if err != nil {
 			_, _ = fmt.Fprintf(r.Writer, "No [reason: %s]\n", err.Error())
 		} else {
 			_, _ = fmt.Fprint(r.Writer, "Yes\n")
@@ -63,10 +105,36 @@ func (r *ProbeRunner) Run() (succeeded bool) {
 
 	_, _ = fmt.Fprintf(r.Writer, "\n")
 
-	return
+	return result
+}
+
+// RemoveTestObjects runs the cleanup, if any, and writes its outcome. It is
+// kept apart from Validate because a backup bucket's probes copy the test
+// objects of its live bucket.
+func (r *ProbeRunner) RemoveTestObjects() error {
+	if r.Cleanup == nil {
+		return nil
+	}
+
+	_, _ = fmt.Fprintf(r.Writer, "Removing test objects from %s ... ", r.Bucket)
+
+	err := r.Cleanup(r.Bucket.Name)
+	if err != nil {
+		_, _ = fmt.Fprintf(r.Writer, "Failed [reason: %s]\n", err.Error())
+	} else {
+		_, _ = fmt.Fprint(r.Writer, "Done\n")
+	}
+
+	return err
 }
 
 func NewProbeRunners(resource string, bucket config.LiveBucket, readOnly, versioned bool) []ProbeRunner {
+	return NewProbeRunnersWithRetention(resource, bucket, readOnly, versioned, probe.DefaultRetentionDays)
+}
+
+// NewProbeRunnersWithRetention also checks that lifecycle rules keep backups
+// restorable for retentionDays.
+func NewProbeRunnersWithRetention(resource string, bucket config.LiveBucket, readOnly, versioned bool, retentionDays int) []ProbeRunner {
 	// the s3 clients for the runners are meant to be constructed the same way as the BBR SDK is constructing them;
 	// see https://github.com/cloudfoundry/backup-and-restore-sdk-release/blob/59d6a95963d0a81e77b666f44338833c45452d37/src/s3-blobstore-backup-restore/unversioned/config.go#L32-L61
 	// while the respective regions are being used, the endpoint is the same for both.
@@ -79,9 +147,13 @@ func NewProbeRunners(resource string, bucket config.LiveBucket, readOnly, versio
 			Name:     bucket.Name,
 			Type:     Live,
 		},
-		readOnly,
-		versioned,
+		probe.Checks{
+			ReadOnly:      readOnly,
+			Versioned:     versioned,
+			RetentionDays: retentionDays,
+		},
 		bucket.UseIAMProfile,
+		bucket.ForcePathStyle,
 	)
 
 	if !versioned {
@@ -92,9 +164,13 @@ func NewProbeRunners(resource string, bucket config.LiveBucket, readOnly, versio
 				Name:     bucket.Backup.Name,
 				Type:     Backup,
 			},
-			readOnly,
-			false,
+			probe.Checks{
+				ReadOnly:      readOnly,
+				RetentionDays: retentionDays,
+				CopySource:    bucket.Name,
+			},
 			bucket.UseIAMProfile,
+			bucket.ForcePathStyle,
 		)
 		return []ProbeRunner{liveProbeRunner, backupProbeRunner}
 	}
@@ -103,20 +179,67 @@ func NewProbeRunners(resource string, bucket config.LiveBucket, readOnly, versio
 
 }
 
+// NewGCSProbeRunners validates a bucket and the backup bucket that the
+// gcs-blobstore-backup-restorer job copies it to. Both are reached with the
+// same service account.
+func NewGCSProbeRunners(resource string, bucket config.GCSBucket, client gcs.Client, readOnly bool) []ProbeRunner {
+	probeSet := probe.NewGCSSet(client, readOnly)
+
+	var cleanup probe.Probe
+	if !readOnly {
+		cleanup = client.RemoveTestObjects
+	}
+
+	return []ProbeRunner{
+		{
+			Bucket:   Bucket{Resource: resource, Name: bucket.Name, Type: Live},
+			ProbeSet: probeSet,
+			Writer:   os.Stdout,
+			Cleanup:  cleanup,
+		},
+		{
+			Bucket:   Bucket{Resource: resource, Name: bucket.BackupName, Type: Backup},
+			ProbeSet: probeSet,
+			Writer:   os.Stdout,
+			Cleanup:  cleanup,
+		},
+	}
+}
+
+// NewAzureProbeRunners validates a container of the azure-blobstore-backup-restorer
+// job, which is backed up in place with soft delete rather than copied.
+func NewAzureProbeRunners(resource string, container config.AzureContainer, client azure.Client, readOnly bool) []ProbeRunner {
+	probeRunner := ProbeRunner{
+		Bucket:   Bucket{Resource: resource, Name: container.Name, Type: Live},
+		ProbeSet: probe.NewAzureSet(client, readOnly),
+		Writer:   os.Stdout,
+	}
+	if !readOnly {
+		probeRunner.Cleanup = client.RemoveTestBlobs
+	}
+
+	return []ProbeRunner{probeRunner}
+}
+
 var injectableS3Client = newS3Client
 
-func NewProbeRunner(region, endpoint, id, secret, role string, bucket Bucket, readOnly, versioned, useIAMProfile bool) ProbeRunner {
-	s3Client, _ := injectableS3Client(region, endpoint, id, secret, role, useIAMProfile)
+func NewProbeRunner(region, endpoint, id, secret, role string, bucket Bucket, checks probe.Checks, useIAMProfile, forcePathStyle bool) ProbeRunner {
+	s3Client, _ := injectableS3Client(region, endpoint, id, secret, role, useIAMProfile, forcePathStyle)
 
-	probeSet := probe.NewSet(s3Client, readOnly, versioned)
+	probeSet := probe.NewSetWithChecks(s3Client, checks)
 
-	return ProbeRunner{
+	probeRunner := ProbeRunner{
 		ProbeSet: probeSet,
 		Writer:   os.Stdout,
 		Bucket:   bucket,
 	}
+	if !checks.ReadOnly {
+		probeRunner.Cleanup = s3Client.RemoveTestObjects
+	}
+
+	return probeRunner
 }
 
-func newS3Client(region, endpoint, id, secret, role string, useIAMProfile bool) (*s3.S3Client, error) {
-	return s3.NewS3ClientWithRoleARN(region, endpoint, id, secret, role, useIAMProfile), nil
+func newS3Client(region, endpoint, id, secret, role string, useIAMProfile, forcePathStyle bool) (*s3.S3Client, error) {
+	return s3.NewS3ClientWithRoleARN(region, endpoint, id, secret, role, useIAMProfile, s3.WithPathStyle(forcePathStyle)), nil
 }
@@ -3,12 +3,15 @@ package runner_test
 import (
 	"errors"
 	"io"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/gbytes"
 
+	"github.com/cloudfoundry/bosh-backup-and-restore/s3-config-validator/src/internal/azure/azurefakes"
 	"github.com/cloudfoundry/bosh-backup-and-restore/s3-config-validator/src/internal/config"
+	"github.com/cloudfoundry/bosh-backup-and-restore/s3-config-validator/src/internal/gcs/gcsfakes"
 	"github.com/cloudfoundry/bosh-backup-and-restore/s3-config-validator/src/internal/probe"
 	. "github.com/cloudfoundry/bosh-backup-and-restore/s3-config-validator/src/internal/runner"
 	"github.com/cloudfoundry/bosh-backup-and-restore/s3-config-validator/src/internal/s3"
@@ -101,9 +104,102 @@ var _ = Describe("ProbeRunner", func() {
 	})
 })
 
+var _ = Describe("ProbeRunner Validate", func() {
+	It("returns the outcome and duration of every probe", func() {
+		writer := gbytes.NewBuffer()
+		bucket := Bucket{
+			Resource: "test-resource",
+			Name:     "test-bucket",
+			Type:     Live,
+		}
+
+		probeRunner := ProbeRunner{
+			Bucket: bucket,
+			ProbeSet: []probe.NamedProbe{
+				{Name: "Probe one", Probe: FailingProbe},
+				{Name: "Probe two", Probe: func(string) error {
+					time.Sleep(10 * time.Millisecond)
+					return nil
+				}},
+			},
+			Writer: writer,
+		}
+
+		result := probeRunner.Validate()
+
+		Expect(result.Bucket).To(Equal(bucket))
+		Expect(result.Succeeded()).To(BeFalse())
+		Expect(result.Probes).To(HaveLen(2))
+		Expect(result.Probes[0].Name).To(Equal("Probe one"))
+		Expect(result.Probes[0].Err).To(MatchError("FailingProbe"))
+		Expect(result.Probes[1].Name).To(Equal("Probe two"))
+		Expect(result.Probes[1].Err).NotTo(HaveOccurred())
+		Expect(result.Probes[1].Duration).To(BeNumerically(">=", 10*time.Millisecond))
+
+		Eventually(writer).Should(gbytes.Say(` \* Probe one ... No \[reason: FailingProbe\]`))
+		Eventually(writer).Should(gbytes.Say(` \* Probe two ... Yes`))
+	})
+
+	It("succeeds when every probe passed", func() {
+		probeRunner := ProbeRunner{
+			Bucket:   Bucket{Resource: "test-resource", Name: "test-bucket", Type: Live},
+			ProbeSet: []probe.NamedProbe{{Name: "Probe one", Probe: SucceedingProbe}},
+			Writer:   io.Discard,
+		}
+
+		Expect(probeRunner.Validate().Succeeded()).To(BeTrue())
+	})
+})
+
+var _ = Describe("ProbeRunner RemoveTestObjects", func() {
+	var writer *gbytes.Buffer
+
+	BeforeEach(func() {
+		writer = gbytes.NewBuffer()
+	})
+
+	It("runs the cleanup against the bucket and writes its outcome", func() {
+		var cleanedBucket string
+		probeRunner := ProbeRunner{
+			Bucket: Bucket{Resource: "test-resource", Name: "test-bucket", Type: Live},
+			Writer: writer,
+			Cleanup: func(bucket string) error {
+				cleanedBucket = bucket
+				return nil
+			},
+		}
+
+		Expect(probeRunner.RemoveTestObjects()).To(Succeed())
+		Expect(cleanedBucket).To(Equal("test-bucket"))
+		Eventually(writer).Should(gbytes.Say("Removing test objects from test-resource's live bucket test-bucket ... Done"))
+	})
+
+	It("returns and writes why the cleanup failed", func() {
+		probeRunner := ProbeRunner{
+			Bucket:  Bucket{Resource: "test-resource", Name: "test-bucket", Type: Live},
+			Writer:  writer,
+			Cleanup: FailingProbe,
+		}
+
+		Expect(probeRunner.RemoveTestObjects()).To(MatchError("FailingProbe"))
+		Eventually(writer).Should(gbytes.Say(`Removing test objects from test-resource's live bucket test-bucket ... Failed \[reason: FailingProbe\]`))
+	})
+
+	It("does nothing without a cleanup", func() {
+		probeRunner := ProbeRunner{
+			Bucket: Bucket{Resource: "test-resource", Name: "test-bucket", Type: Live},
+			Writer: writer,
+		}
+
+		Expect(probeRunner.RemoveTestObjects()).To(Succeed())
+		Expect(writer.Contents()).To(BeEmpty())
+	})
+})
+
 type NewS3ClientArgs struct {
 	Region, Endpoint, Id, Secret string
 	UseIAMProfile                bool
+	ForcePathStyle               bool
 }
 
 var _ = Describe("Versioned", func() {
@@ -111,15 +207,16 @@ var _ = Describe("Versioned", func() {
 		It("should construct a live probe runner with bucket secrets", func() {
 			var newS3ClientArgs []NewS3ClientArgs
 
-			SetNewS3Client(func(region, endpoint, id, secret, role string, useIAMProfile bool) (*s3.S3Client, error) {
+			SetNewS3Client(func(region, endpoint, id, secret, role string, useIAMProfile, forcePathStyle bool) (*s3.S3Client, error) {
 				newS3ClientArgs = append(newS3ClientArgs, NewS3ClientArgs{
-					Region:        region,
-					Endpoint:      endpoint,
-					Id:            id,
-					Secret:        secret,
-					UseIAMProfile: useIAMProfile,
+					Region:         region,
+					Endpoint:       endpoint,
+					Id:             id,
+					Secret:         secret,
+					UseIAMProfile:  useIAMProfile,
+					ForcePathStyle: forcePathStyle,
 				})
-				return NewS3ClientImpl(region, endpoint, id, secret, role, useIAMProfile)
+				return NewS3ClientImpl(region, endpoint, id, secret, role, useIAMProfile, forcePathStyle)
 			})
 
 			probeRunners := NewProbeRunners(
@@ -162,15 +259,16 @@ var _ = Describe("Versioned", func() {
 		It("should construct a live probe runner without bucket secrets", func() {
 			var newS3ClientArgs []NewS3ClientArgs
 
-			SetNewS3Client(func(region, endpoint, id, secret, role string, useIAMProfile bool) (*s3.S3Client, error) {
+			SetNewS3Client(func(region, endpoint, id, secret, role string, useIAMProfile, forcePathStyle bool) (*s3.S3Client, error) {
 				newS3ClientArgs = append(newS3ClientArgs, NewS3ClientArgs{
-					Region:        region,
-					Endpoint:      endpoint,
-					Id:            id,
-					Secret:        secret,
-					UseIAMProfile: useIAMProfile,
+					Region:         region,
+					Endpoint:       endpoint,
+					Id:             id,
+					Secret:         secret,
+					UseIAMProfile:  useIAMProfile,
+					ForcePathStyle: forcePathStyle,
 				})
-				return NewS3ClientImpl(region, endpoint, id, secret, role, useIAMProfile)
+				return NewS3ClientImpl(region, endpoint, id, secret, role, useIAMProfile, forcePathStyle)
 			})
 
 			NewProbeRunners(
@@ -205,15 +303,16 @@ var _ = Describe("Unversioned", func() {
 	It("should construct a live and a backup bucket probe runner", func() {
 		var newS3ClientArgs []NewS3ClientArgs
 
-		SetNewS3Client(func(region, endpoint, id, secret, role string, useIAMProfile bool) (*s3.S3Client, error) {
+		SetNewS3Client(func(region, endpoint, id, secret, role string, useIAMProfile, forcePathStyle bool) (*s3.S3Client, error) {
 			newS3ClientArgs = append(newS3ClientArgs, NewS3ClientArgs{
-				Region:        region,
-				Endpoint:      endpoint,
-				Id:            id,
-				Secret:        secret,
-				UseIAMProfile: useIAMProfile,
+				Region:         region,
+				Endpoint:       endpoint,
+				Id:             id,
+				Secret:         secret,
+				UseIAMProfile:  useIAMProfile,
+				ForcePathStyle: forcePathStyle,
 			})
-			return NewS3ClientImpl(region, endpoint, id, secret, role, useIAMProfile)
+			return NewS3ClientImpl(region, endpoint, id, secret, role, useIAMProfile, forcePathStyle)
 		})
 
 		probeRunners := NewProbeRunners(
@@ -228,6 +327,7 @@ var _ = Describe("Unversioned", func() {
 					Name:   "test-backup-bucket",
 					Region: "test-backup-region",
 				},
+				ForcePathStyle: true,
 			},
 			true,
 			false,
@@ -235,16 +335,18 @@ var _ = Describe("Unversioned", func() {
 
 		Expect(newS3ClientArgs).To(ConsistOf(
 			NewS3ClientArgs{
-				Region:   "test-live-region",
-				Endpoint: "test-live-endpoint",
-				Id:       "test-id",
-				Secret:   "test-secret",
+				Region:         "test-live-region",
+				Endpoint:       "test-live-endpoint",
+				Id:             "test-id",
+				Secret:         "test-secret",
+				ForcePathStyle: true,
 			},
 			NewS3ClientArgs{
-				Region:   "test-backup-region",
-				Endpoint: "test-live-endpoint",
-				Id:       "test-id",
-				Secret:   "test-secret",
+				Region:         "test-backup-region",
+				Endpoint:       "test-live-endpoint",
+				Id:             "test-id",
+				Secret:         "test-secret",
+				ForcePathStyle: true,
 			}))
 
 		var buckets []Bucket
@@ -264,3 +366,116 @@ var _ = Describe("Unversioned", func() {
 			}))
 	})
 })
+
+var _ = Describe("Retention and copying", func() {
+	probeNames := func(probeRunner ProbeRunner) []string {
+		var names []string
+		for _, namedProbe := range probeRunner.ProbeSet {
+			names = append(names, namedProbe.Name)
+		}
+		return names
+	}
+
+	It("checks the live bucket's settings and copying into the backup bucket", func() {
+		probeRunners := NewProbeRunnersWithRetention(
+			"test-unversioned-resource",
+			config.LiveBucket{
+				Region: "test-live-region",
+				Name:   "test-live-bucket",
+				Backup: &config.BackupBucket{
+					Name:   "test-backup-bucket",
+					Region: "test-backup-region",
+				},
+			},
+			false,
+			false,
+			30,
+		)
+
+		Expect(probeRunners).To(HaveLen(2))
+		Expect(probeNames(probeRunners[0])).To(ContainElement("Object lock allows deletion"))
+		Expect(probeNames(probeRunners[0])).NotTo(ContainElement(ContainSubstring("Lifecycle rules")))
+		Expect(probeNames(probeRunners[1])).To(ContainElements(
+			"Lifecycle rules keep objects for 30 days",
+			"Can copy objects from bucket test-live-bucket",
+		))
+		Expect(probeRunners[0].Cleanup).NotTo(BeNil())
+		Expect(probeRunners[1].Cleanup).NotTo(BeNil())
+	})
+
+	It("keeps noncurrent versions for the default retention in versioned buckets", func() {
+		probeRunners := NewProbeRunners(
+			"test-versioned-resource",
+			config.LiveBucket{Region: "test-live-region", Name: "test-live-bucket"},
+			true,
+			true,
+		)
+
+		Expect(probeNames(probeRunners[0])).To(ContainElement("Lifecycle rules keep noncurrent versions for 7 days"))
+		Expect(probeRunners[0].Cleanup).To(BeNil())
+	})
+})
+
+var _ = Describe("GCS", func() {
+	It("should construct a live and a backup bucket probe runner", func() {
+		client := new(gcsfakes.FakeClient)
+
+		probeRunners := NewGCSProbeRunners(
+			"test-resource",
+			config.GCSBucket{Name: "test-live-bucket", BackupName: "test-backup-bucket"},
+			client,
+			true,
+		)
+
+		var buckets []Bucket
+		for _, probeRunner := range probeRunners {
+			buckets = append(buckets, probeRunner.Bucket)
+			Expect(probeRunner.ProbeSet).To(HaveLen(2))
+		}
+		Expect(buckets).To(ConsistOf(
+			Bucket{Resource: "test-resource", Name: "test-live-bucket", Type: Live},
+			Bucket{Resource: "test-resource", Name: "test-backup-bucket", Type: Backup},
+		))
+
+		Expect(probeRunners[1].ProbeSet[0].Probe("test-backup-bucket")).To(Succeed())
+		Expect(client.CanListObjectsArgsForCall(0)).To(Equal("test-backup-bucket"))
+		Expect(probeRunners[0].Cleanup).To(BeNil())
+	})
+
+	It("removes the test objects from both buckets when not read-only", func() {
+		client := new(gcsfakes.FakeClient)
+
+		probeRunners := NewGCSProbeRunners(
+			"test-resource",
+			config.GCSBucket{Name: "test-live-bucket", BackupName: "test-backup-bucket"},
+			client,
+			false,
+		)
+
+		for _, probeRunner := range probeRunners {
+			Expect(probeRunner.RemoveTestObjects()).To(Succeed())
+		}
+		Expect(client.RemoveTestObjectsCallCount()).To(Equal(2))
+		Expect(client.RemoveTestObjectsArgsForCall(1)).To(Equal("test-backup-bucket"))
+	})
+})
+
+var _ = Describe("Azure", func() {
+	It("should construct a probe runner for the container", func() {
+		client := new(azurefakes.FakeClient)
+
+		probeRunners := NewAzureProbeRunners(
+			"test-resource",
+			config.AzureContainer{Name: "test-container"},
+			client,
+			false,
+		)
+
+		Expect(probeRunners).To(HaveLen(1))
+		Expect(probeRunners[0].Bucket).To(Equal(Bucket{Resource: "test-resource", Name: "test-container", Type: Live}))
+		Expect(probeRunners[0].ProbeSet).To(HaveLen(5))
+
+		Expect(probeRunners[0].Cleanup("test-container")).To(Succeed())
+		Expect(client.RemoveTestBlobsArgsForCall(0)).To(Equal("test-container"))
+	})
+})
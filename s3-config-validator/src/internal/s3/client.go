@@ -10,4 +10,11 @@ type Client interface {
 	CanGetObjects(bucket string) error
 	CanGetObjectVersions(bucket string) error
 	CanPutObjects(bucket string) error
+	CanDeleteObjects(bucket string) error
+	CanCopyObjectsFrom(source, bucket string) error
+	KeepsNoncurrentVersionsFor(bucket string, days int) error
+	KeepsObjectsFor(bucket string, days int) error
+	AllowsDeletion(bucket string) error
+	CanUseEncryptionKey(bucket string) error
+	RemoveTestObjects(bucket string) error
 }
@@ -3,7 +3,10 @@ package s3
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/credentials"
@@ -12,6 +15,8 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/aws/aws-sdk-go-v2/service/sts"
+
+	"github.com/cloudfoundry/bosh-backup-and-restore/s3-config-validator/src/internal/testobject"
 )
 
 type S3Client struct {
@@ -77,6 +82,14 @@ func newS3ClientWithAssumedRole(region, endpoint, id, secret, role string, useIA
 	return s3.New(options, fns...)
 }
 
+// WithPathStyle chooses between path-style (<endpoint>/<bucket>) and
+// virtual-hosted-style (<bucket>.<endpoint>) addressing of buckets.
+func WithPathStyle(forcePathStyle bool) func(*s3.Options) {
+	return func(options *s3.Options) {
+		options.UsePathStyle = forcePathStyle
+	}
+}
+
 func (p *S3Client) IsUnversioned(bucket string) error {
 	isVersioned, err := p.getBucketVersioning(bucket)
 	if err != nil {
@@ -179,21 +192,132 @@ func (p *S3Client) CanGetObjectVersions(bucket string) (errListObjects error) {
 }
 
 func (p *S3Client) CanPutObjects(bucket string) (err error) {
-	fileContent := []byte("Test File, Please delete me if you are reading this")
+	_, err = p.putTestObject(bucket, testobject.Key("put"))
+	if err != nil {
+		return fmt.Errorf("could not put object into bucket %s: %s", bucket, err)
+	}
+
+	return
+}
+
+// CanDeleteObjects writes a test object of its own and deletes it again. On
+// versioned buckets the version it wrote is deleted, so nothing is left behind.
+func (p *S3Client) CanDeleteObjects(bucket string) error {
+	output, err := p.putTestObject(bucket, testobject.Key("delete"))
+	if err != nil {
+		return fmt.Errorf("could not put object to delete into bucket %s: %s", bucket, err)
+	}
+
+	_, err = p.S3Client.DeleteObject(context.TODO(), &s3.DeleteObjectInput{
+		Bucket:    aws.String(bucket),
+		Key:       aws.String(testobject.Key("delete")),
+		VersionId: output.VersionId,
+	})
+	if err != nil {
+		return fmt.Errorf("could not delete objects from bucket %s: %s", bucket, err)
+	}
+
+	return nil
+}
+
+func (p *S3Client) putTestObject(bucket, key string) (*s3.PutObjectOutput, error) {
+	fileContent := []byte(testobject.Content)
 	fileContentLength := int64(len(fileContent))
-	_, err = p.S3Client.PutObject(context.TODO(), &s3.PutObjectInput{
+
+	return p.S3Client.PutObject(context.TODO(), &s3.PutObjectInput{
 		Bucket:        aws.String(bucket),
-		Key:           aws.String("delete_me"),
+		Key:           aws.String(key),
 		ACL:           types.ObjectCannedACLPrivate,
 		Body:          bytes.NewReader(fileContent),
 		ContentLength: &fileContentLength,
 	})
+}
 
+// RemoveTestObjects deletes everything this run wrote into the bucket,
+// including every version and delete marker if the bucket has ever been
+// versioned.
+func (p *S3Client) RemoveTestObjects(bucket string) error {
+	output, err := p.S3Client.GetBucketVersioning(context.TODO(), &s3.GetBucketVersioningInput{
+		Bucket: aws.String(bucket),
+	})
 	if err != nil {
-		return fmt.Errorf("could not put object into bucket %s: %s", bucket, err)
+		return fmt.Errorf("could not check if bucket %s is versioned: %s", bucket, err)
 	}
 
-	return
+	var objects []types.ObjectIdentifier
+	if output.Status == "" {
+		objects, err = p.listTestObjects(bucket)
+	} else {
+		objects, err = p.listTestObjectVersions(bucket)
+	}
+	if err != nil {
+		return fmt.Errorf("could not list test objects in bucket %s: %s", bucket, err)
+	}
+
+	var leftovers []string
+	var lastErr error
+	for _, object := range objects {
+		_, err := p.S3Client.DeleteObject(context.TODO(), &s3.DeleteObjectInput{
+			Bucket:    aws.String(bucket),
+			Key:       object.Key,
+			VersionId: object.VersionId,
+		})
+		if err != nil {
+			leftovers = append(leftovers, aws.ToString(object.Key))
+			lastErr = err
+		}
+	}
+
+	if len(leftovers) > 0 {
+		return fmt.Errorf("could not delete %s from bucket %s: %s", strings.Join(leftovers, ", "), bucket, lastErr)
+	}
+
+	return nil
+}
+
+func (p *S3Client) listTestObjects(bucket string) ([]types.ObjectIdentifier, error) {
+	var objects []types.ObjectIdentifier
+
+	paginator := s3.NewListObjectsV2Paginator(p.S3Client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(bucket),
+		Prefix: aws.String(testobject.Prefix),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, err
+		}
+
+		for _, object := range page.Contents {
+			objects = append(objects, types.ObjectIdentifier{Key: object.Key})
+		}
+	}
+
+	return objects, nil
+}
+
+func (p *S3Client) listTestObjectVersions(bucket string) ([]types.ObjectIdentifier, error) {
+	var objects []types.ObjectIdentifier
+
+	paginator := s3.NewListObjectVersionsPaginator(p.S3Client, &s3.ListObjectVersionsInput{
+		Bucket: aws.String(bucket),
+		Prefix: aws.String(testobject.Prefix),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, err
+		}
+
+		for _, version := range page.Versions {
+			objects = append(objects, types.ObjectIdentifier{Key: version.Key, VersionId: version.VersionId})
+		}
+		for _, marker := range page.DeleteMarkers {
+			objects = append(objects, types.ObjectIdentifier{Key: marker.Key, VersionId: marker.VersionId})
+		}
+	}
+
+	return objects, nil
 }
 
 func (p *S3Client) CanListObjectVersions(bucket string) (err error) {
@@ -223,3 +347,177 @@ func (p *S3Client) IsVersioned(bucket string) (err error) {
 
 	return fmt.Errorf("bucket %s is unversioned", bucket)
 }
+
+// CanCopyObjectsFrom copies the test object written by CanPutObjects from the
+// source bucket, the way unversioned backups copy live blobs into the backup
+// bucket. The copy gets its own key, as S3 refuses to copy an object onto
+// itself when both buckets are the same.
+func (p *S3Client) CanCopyObjectsFrom(source, bucket string) error {
+	_, err := p.S3Client.CopyObject(context.TODO(), &s3.CopyObjectInput{
+		Bucket:     aws.String(bucket),
+		Key:        aws.String(testobject.Key("copy")),
+		CopySource: aws.String(source + "/" + testobject.Key("put")),
+	})
+	if err != nil {
+		return fmt.Errorf("could not copy objects from bucket %s into bucket %s: %s", source, bucket, err)
+	}
+
+	return nil
+}
+
+// KeepsNoncurrentVersionsFor checks that no lifecycle rule deletes the
+// noncurrent versions that versioned backups refer to within days.
+func (p *S3Client) KeepsNoncurrentVersionsFor(bucket string, days int) error {
+	rules, err := p.lifecycleRules(bucket)
+	if err != nil {
+		return err
+	}
+
+	for _, rule := range rules {
+		expiration := rule.NoncurrentVersionExpiration
+		if expiration != nil && expiration.NoncurrentDays != nil && int(*expiration.NoncurrentDays) < days {
+			return fmt.Errorf("lifecycle rule %s in bucket %s expires noncurrent versions after %d days, sooner than the %d days backups must be kept",
+				aws.ToString(rule.ID), bucket, *expiration.NoncurrentDays, days)
+		}
+	}
+
+	return nil
+}
+
+// KeepsObjectsFor checks that no lifecycle rule deletes the objects in an
+// unversioned backup bucket within days.
+func (p *S3Client) KeepsObjectsFor(bucket string, days int) error {
+	rules, err := p.lifecycleRules(bucket)
+	if err != nil {
+		return err
+	}
+
+	for _, rule := range rules {
+		expiration := rule.Expiration
+		if expiration == nil {
+			continue
+		}
+
+		if expiration.Date != nil {
+			return fmt.Errorf("lifecycle rule %s in bucket %s expires objects on %s",
+				aws.ToString(rule.ID), bucket, expiration.Date.Format("2006-01-02"))
+		}
+
+		if expiration.Days != nil && int(*expiration.Days) < days {
+			return fmt.Errorf("lifecycle rule %s in bucket %s expires objects after %d days, sooner than the %d days backups must be kept",
+				aws.ToString(rule.ID), bucket, *expiration.Days, days)
+		}
+	}
+
+	return nil
+}
+
+func (p *S3Client) lifecycleRules(bucket string) ([]types.LifecycleRule, error) {
+	output, err := p.S3Client.GetBucketLifecycleConfiguration(context.TODO(), &s3.GetBucketLifecycleConfigurationInput{
+		Bucket: aws.String(bucket),
+	})
+	if isNotConfigured(err, "NoSuchLifecycleConfiguration") {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not get lifecycle rules of bucket %s: %s", bucket, err)
+	}
+
+	var enabledRules []types.LifecycleRule
+	for _, rule := range output.Rules {
+		if rule.Status == types.ExpirationStatusEnabled {
+			enabledRules = append(enabledRules, rule)
+		}
+	}
+
+	return enabledRules, nil
+}
+
+// AllowsDeletion checks that object lock does not retain new objects by
+// default, which would stop blobs from being deleted from a live bucket.
+func (p *S3Client) AllowsDeletion(bucket string) error {
+	output, err := p.S3Client.GetObjectLockConfiguration(context.TODO(), &s3.GetObjectLockConfigurationInput{
+		Bucket: aws.String(bucket),
+	})
+	if isNotConfigured(err, "ObjectLockConfigurationNotFoundError") {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("could not get object lock configuration of bucket %s: %s", bucket, err)
+	}
+
+	configuration := output.ObjectLockConfiguration
+	if configuration == nil || configuration.ObjectLockEnabled != types.ObjectLockEnabledEnabled ||
+		configuration.Rule == nil || configuration.Rule.DefaultRetention == nil {
+		return nil
+	}
+
+	return fmt.Errorf("object lock in bucket %s retains new objects in %s mode, so they cannot be deleted",
+		bucket, configuration.Rule.DefaultRetention.Mode)
+}
+
+// CanUseEncryptionKey reads the first byte of an object when the bucket
+// encrypts with a KMS key by default, which needs permission to decrypt with
+// that key as well as to get objects.
+func (p *S3Client) CanUseEncryptionKey(bucket string) error {
+	output, err := p.S3Client.GetBucketEncryption(context.TODO(), &s3.GetBucketEncryptionInput{
+		Bucket: aws.String(bucket),
+	})
+	if isNotConfigured(err, "ServerSideEncryptionConfigurationNotFoundError") {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("could not get encryption configuration of bucket %s: %s", bucket, err)
+	}
+
+	keyID := ""
+	if output.ServerSideEncryptionConfiguration != nil {
+		for _, rule := range output.ServerSideEncryptionConfiguration.Rules {
+			byDefault := rule.ApplyServerSideEncryptionByDefault
+			if byDefault != nil && (byDefault.SSEAlgorithm == types.ServerSideEncryptionAwsKms || byDefault.SSEAlgorithm == types.ServerSideEncryptionAwsKmsDsse) {
+				keyID = aws.ToString(byDefault.KMSMasterKeyID)
+				if keyID == "" {
+					keyID = "aws/s3"
+				}
+			}
+		}
+	}
+	if keyID == "" {
+		return nil
+	}
+
+	listOutput, err := p.S3Client.ListObjectsV2(context.TODO(), &s3.ListObjectsV2Input{
+		Bucket:  aws.String(bucket),
+		MaxKeys: aws.Int32(1),
+	})
+	if err != nil {
+		return fmt.Errorf("could not list objects in bucket %s: %s", bucket, err)
+	}
+	if len(listOutput.Contents) == 0 {
+		return nil
+	}
+
+	object, err := p.S3Client.GetObject(context.TODO(), &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    listOutput.Contents[0].Key,
+		Range:  aws.String("bytes=0-0"),
+	})
+	if err != nil {
+		return fmt.Errorf("could not read objects encrypted with KMS key %s in bucket %s: %s", keyID, bucket, err)
+	}
+	defer object.Body.Close() //nolint:errcheck
+
+	_, err = io.Copy(io.Discard, object.Body)
+	return err
+}
+
+// isNotConfigured tells whether err reports that the bucket has no such
+// configuration, or that the S3-compatible store does not support it.
+func isNotConfigured(err error, notFoundCode string) bool {
+	var apiErr interface{ ErrorCode() string }
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+
+	return apiErr.ErrorCode() == notFoundCode || apiErr.ErrorCode() == "NotImplemented"
+}
@@ -2,6 +2,7 @@ package s3_test
 
 import (
 	"context"
+	"errors"
 	"net/http"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -11,6 +12,7 @@ import (
 	"github.com/onsi/gomega/ghttp"
 
 	"github.com/cloudfoundry/bosh-backup-and-restore/s3-config-validator/src/internal/s3"
+	"github.com/cloudfoundry/bosh-backup-and-restore/s3-config-validator/src/internal/testobject"
 )
 
 const (
@@ -176,6 +178,37 @@ var _ = Describe("S3Client", func() {
 			})
 		})
 
+		When("addressing buckets", func() {
+			var recorder *requestRecorder
+
+			BeforeEach(func() {
+				recorder = &requestRecorder{}
+			})
+
+			endpointOnly := func(options *awss3.Options) {
+				options.EndpointResolver = awss3.EndpointResolverFromURL("http://s3.example.com")
+				options.HTTPClient = recorder
+			}
+
+			It("puts the bucket in the path when forced to", func() {
+				probe, err := s3.NewS3Client("test-region", "", "test-id", "test-secret", false, endpointOnly, s3.WithPathStyle(true))
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(probe.IsVersioned("test-bucket")).NotTo(Succeed())
+				Expect(recorder.host).To(Equal("s3.example.com"))
+				Expect(recorder.path).To(Equal("/test-bucket"))
+			})
+
+			It("puts the bucket in the host name otherwise", func() {
+				probe, err := s3.NewS3Client("test-region", "", "test-id", "test-secret", false, endpointOnly, s3.WithPathStyle(false))
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(probe.IsVersioned("test-bucket")).NotTo(Succeed())
+				Expect(recorder.host).To(Equal("test-bucket.s3.example.com"))
+				Expect(recorder.path).To(Equal("/"))
+			})
+		})
+
 		Context("Bucket Versioning", func() {
 			When("I can get a bucket's versioning", func() {
 				Context("bucket has never been versioned", func() {
@@ -524,7 +557,7 @@ var _ = Describe("S3Client", func() {
 				BeforeEach(func() {
 					fakeS3Server.AppendHandlers(
 						ghttp.CombineHandlers(
-							ghttp.VerifyRequest("PUT", "/test-bucket/delete_me"),
+							ghttp.VerifyRequest("PUT", "/test-bucket/"+testobject.Key("put")),
 							ghttp.RespondWith(http.StatusOK, ""),
 						),
 					)
@@ -542,7 +575,7 @@ var _ = Describe("S3Client", func() {
 				BeforeEach(func() {
 					fakeS3Server.AppendHandlers(
 						ghttp.CombineHandlers(
-							ghttp.VerifyRequest("PUT", "/test-bucket/delete_me"),
+							ghttp.VerifyRequest("PUT", "/test-bucket/"+testobject.Key("put")),
 							ghttp.RespondWith(http.StatusForbidden, AccessDeniedResponse),
 						),
 					)
@@ -556,5 +589,429 @@ var _ = Describe("S3Client", func() {
 				})
 			})
 		})
+
+		Context("Copy Object", func() {
+			It("copies the test object from the source bucket", func() {
+				fakeS3Server.AppendHandlers(ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/backup-bucket/"+testobject.Key("copy")),
+					ghttp.VerifyHeaderKV("X-Amz-Copy-Source", "live-bucket/"+testobject.Key("put")),
+					ghttp.RespondWith(http.StatusOK, `<CopyObjectResult><ETag>"etag"</ETag></CopyObjectResult>`),
+				))
+
+				probe, err := s3.NewS3Client("test-region", fakeS3Server.URL(), "test-id", "test-secret", false, fakeS3ServerConfig)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(probe.CanCopyObjectsFrom("live-bucket", "backup-bucket")).To(Succeed())
+			})
+
+			It("returns an error when the copy is denied", func() {
+				fakeS3Server.AppendHandlers(ghttp.RespondWith(http.StatusForbidden, AccessDeniedResponse))
+
+				probe, err := s3.NewS3Client("test-region", fakeS3Server.URL(), "test-id", "test-secret", false, fakeS3ServerConfig)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(probe.CanCopyObjectsFrom("live-bucket", "backup-bucket")).To(MatchError(ContainSubstring(
+					"could not copy objects from bucket live-bucket into bucket backup-bucket: ",
+				)))
+			})
+		})
+
+		Context("Delete Object", func() {
+			var probe *s3.S3Client
+
+			BeforeEach(func() {
+				var err error
+				probe, err = s3.NewS3Client("test-region", fakeS3Server.URL(), "test-id", "test-secret", false, fakeS3ServerConfig)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("puts a test object and deletes it", func() {
+				fakeS3Server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/test-bucket/"+testobject.Key("delete")),
+						ghttp.RespondWith(http.StatusOK, ""),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("DELETE", "/test-bucket/"+testobject.Key("delete")),
+						ghttp.RespondWith(http.StatusNoContent, ""),
+					),
+				)
+
+				Expect(probe.CanDeleteObjects("test-bucket")).To(Succeed())
+			})
+
+			It("deletes the version it wrote on a versioned bucket", func() {
+				fakeS3Server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/test-bucket/"+testobject.Key("delete")),
+						ghttp.RespondWith(http.StatusOK, "", http.Header{"X-Amz-Version-Id": {"version-1"}}),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("DELETE", "/test-bucket/"+testobject.Key("delete")),
+						verifyQueryValue("versionId", "version-1"),
+						ghttp.RespondWith(http.StatusNoContent, ""),
+					),
+				)
+
+				Expect(probe.CanDeleteObjects("test-bucket")).To(Succeed())
+			})
+
+			It("returns an error when the delete is denied", func() {
+				fakeS3Server.AppendHandlers(
+					ghttp.RespondWith(http.StatusOK, ""),
+					ghttp.RespondWith(http.StatusForbidden, AccessDeniedResponse),
+				)
+
+				Expect(probe.CanDeleteObjects("test-bucket")).To(MatchError(ContainSubstring(
+					"could not delete objects from bucket test-bucket: ",
+				)))
+			})
+
+			It("returns an error when the test object can not be put", func() {
+				fakeS3Server.AppendHandlers(ghttp.RespondWith(http.StatusForbidden, AccessDeniedResponse))
+
+				Expect(probe.CanDeleteObjects("test-bucket")).To(MatchError(ContainSubstring(
+					"could not put object to delete into bucket test-bucket: ",
+				)))
+			})
+		})
+
+		Context("Remove Test Objects", func() {
+			var probe *s3.S3Client
+
+			BeforeEach(func() {
+				var err error
+				probe, err = s3.NewS3Client("test-region", fakeS3Server.URL(), "test-id", "test-secret", false, fakeS3ServerConfig)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			When("the bucket has never been versioned", func() {
+				BeforeEach(func() {
+					fakeS3Server.AppendHandlers(
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest("GET", "/test-bucket", "versioning"),
+							ghttp.RespondWith(http.StatusOK, VersioningDisabledResponse),
+						),
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest("GET", "/test-bucket"),
+							verifyQueryHas("list-type"),
+							verifyQueryValue("prefix", testobject.Prefix),
+							ghttp.RespondWith(http.StatusOK, `<ListBucketResult>
+								<Contents><Key>`+testobject.Key("put")+`</Key></Contents>
+								<Contents><Key>`+testobject.Key("copy")+`</Key></Contents>
+							</ListBucketResult>`),
+						),
+					)
+				})
+
+				It("deletes the objects under this run's prefix", func() {
+					fakeS3Server.AppendHandlers(
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest("DELETE", "/test-bucket/"+testobject.Key("put")),
+							ghttp.RespondWith(http.StatusNoContent, ""),
+						),
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest("DELETE", "/test-bucket/"+testobject.Key("copy")),
+							ghttp.RespondWith(http.StatusNoContent, ""),
+						),
+					)
+
+					Expect(probe.RemoveTestObjects("test-bucket")).To(Succeed())
+					Expect(fakeS3Server.ReceivedRequests()).To(HaveLen(4))
+				})
+
+				It("reports the objects it could not delete", func() {
+					fakeS3Server.AppendHandlers(
+						ghttp.RespondWith(http.StatusForbidden, AccessDeniedResponse),
+						ghttp.RespondWith(http.StatusNoContent, ""),
+					)
+
+					Expect(probe.RemoveTestObjects("test-bucket")).To(MatchError(ContainSubstring(
+						"could not delete " + testobject.Key("put") + " from bucket test-bucket: ",
+					)))
+				})
+			})
+
+			When("the bucket is versioned", func() {
+				It("deletes every version and delete marker under this run's prefix", func() {
+					fakeS3Server.AppendHandlers(
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest("GET", "/test-bucket", "versioning"),
+							ghttp.RespondWith(http.StatusOK, VersioningSuspendedResponse),
+						),
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest("GET", "/test-bucket"),
+							verifyQueryHas("versions"),
+							verifyQueryValue("prefix", testobject.Prefix),
+							ghttp.RespondWith(http.StatusOK, `<ListVersionsResult>
+								<Version><Key>`+testobject.Key("put")+`</Key><VersionId>version-1</VersionId></Version>
+								<Version><Key>`+testobject.Key("put")+`</Key><VersionId>version-2</VersionId></Version>
+								<DeleteMarker><Key>`+testobject.Key("delete")+`</Key><VersionId>marker-1</VersionId></DeleteMarker>
+							</ListVersionsResult>`),
+						),
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest("DELETE", "/test-bucket/"+testobject.Key("put")),
+							verifyQueryValue("versionId", "version-1"),
+							ghttp.RespondWith(http.StatusNoContent, ""),
+						),
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest("DELETE", "/test-bucket/"+testobject.Key("put")),
+							verifyQueryValue("versionId", "version-2"),
+							ghttp.RespondWith(http.StatusNoContent, ""),
+						),
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest("DELETE", "/test-bucket/"+testobject.Key("delete")),
+							verifyQueryValue("versionId", "marker-1"),
+							ghttp.RespondWith(http.StatusNoContent, ""),
+						),
+					)
+
+					Expect(probe.RemoveTestObjects("test-bucket")).To(Succeed())
+				})
+			})
+
+			It("returns an error when the test objects can not be listed", func() {
+				fakeS3Server.AppendHandlers(
+					ghttp.RespondWith(http.StatusOK, VersioningDisabledResponse),
+					ghttp.RespondWith(http.StatusForbidden, AccessDeniedResponse),
+				)
+
+				Expect(probe.RemoveTestObjects("test-bucket")).To(MatchError(ContainSubstring(
+					"could not list test objects in bucket test-bucket: ",
+				)))
+			})
+		})
+
+		Context("Lifecycle Rules", func() {
+			var probe *s3.S3Client
+
+			BeforeEach(func() {
+				var err error
+				probe, err = s3.NewS3Client("test-region", fakeS3Server.URL(), "test-id", "test-secret", false, fakeS3ServerConfig)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			respondWithRules := func(rules string) {
+				fakeS3Server.AppendHandlers(ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/test-bucket"),
+					verifyQueryHas("lifecycle"),
+					ghttp.RespondWith(http.StatusOK, `<LifecycleConfiguration>`+rules+`</LifecycleConfiguration>`),
+				))
+			}
+
+			When("there are no lifecycle rules", func() {
+				BeforeEach(func() {
+					fakeS3Server.AppendHandlers(ghttp.RespondWith(http.StatusNotFound, NotFoundResponse("NoSuchLifecycleConfiguration")))
+				})
+
+				It("keeps backups", func() {
+					Expect(probe.KeepsNoncurrentVersionsFor("test-bucket", 7)).To(Succeed())
+				})
+			})
+
+			When("a rule expires noncurrent versions too soon", func() {
+				BeforeEach(func() {
+					respondWithRules(`
+<Rule><ID>tidy-up</ID><Status>Enabled</Status><NoncurrentVersionExpiration><NoncurrentDays>3</NoncurrentDays></NoncurrentVersionExpiration></Rule>`)
+				})
+
+				It("returns an error naming the rule", func() {
+					Expect(probe.KeepsNoncurrentVersionsFor("test-bucket", 7)).To(MatchError(
+						"lifecycle rule tidy-up in bucket test-bucket expires noncurrent versions after 3 days, sooner than the 7 days backups must be kept",
+					))
+				})
+			})
+
+			When("the rule expiring noncurrent versions is disabled or late enough", func() {
+				BeforeEach(func() {
+					respondWithRules(`
+<Rule><ID>disabled</ID><Status>Disabled</Status><NoncurrentVersionExpiration><NoncurrentDays>1</NoncurrentDays></NoncurrentVersionExpiration></Rule>
+<Rule><ID>late</ID><Status>Enabled</Status><NoncurrentVersionExpiration><NoncurrentDays>30</NoncurrentDays></NoncurrentVersionExpiration></Rule>`)
+				})
+
+				It("keeps backups", func() {
+					Expect(probe.KeepsNoncurrentVersionsFor("test-bucket", 7)).To(Succeed())
+				})
+			})
+
+			When("a rule expires objects too soon", func() {
+				BeforeEach(func() {
+					respondWithRules(`
+<Rule><ID>expire</ID><Status>Enabled</Status><Expiration><Days>5</Days></Expiration></Rule>`)
+				})
+
+				It("returns an error naming the rule", func() {
+					Expect(probe.KeepsObjectsFor("test-bucket", 7)).To(MatchError(
+						"lifecycle rule expire in bucket test-bucket expires objects after 5 days, sooner than the 7 days backups must be kept",
+					))
+				})
+			})
+
+			When("a rule expires objects on a date", func() {
+				BeforeEach(func() {
+					respondWithRules(`
+<Rule><ID>expire</ID><Status>Enabled</Status><Expiration><Date>2030-01-01T00:00:00.000Z</Date></Expiration></Rule>`)
+				})
+
+				It("returns an error naming the rule", func() {
+					Expect(probe.KeepsObjectsFor("test-bucket", 7)).To(MatchError(
+						"lifecycle rule expire in bucket test-bucket expires objects on 2030-01-01",
+					))
+				})
+			})
+
+			When("the rules cannot be read", func() {
+				BeforeEach(func() {
+					fakeS3Server.AppendHandlers(ghttp.RespondWith(http.StatusForbidden, AccessDeniedResponse))
+				})
+
+				It("returns an error", func() {
+					Expect(probe.KeepsObjectsFor("test-bucket", 7)).To(MatchError(ContainSubstring(
+						"could not get lifecycle rules of bucket test-bucket: ",
+					)))
+				})
+			})
+		})
+
+		Context("Object Lock", func() {
+			var probe *s3.S3Client
+
+			BeforeEach(func() {
+				var err error
+				probe, err = s3.NewS3Client("test-region", fakeS3Server.URL(), "test-id", "test-secret", false, fakeS3ServerConfig)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("allows deletion without object lock", func() {
+				fakeS3Server.AppendHandlers(ghttp.RespondWith(http.StatusNotFound, NotFoundResponse("ObjectLockConfigurationNotFoundError")))
+
+				Expect(probe.AllowsDeletion("test-bucket")).To(Succeed())
+			})
+
+			It("allows deletion on stores without object lock support", func() {
+				fakeS3Server.AppendHandlers(ghttp.RespondWith(http.StatusNotImplemented, NotFoundResponse("NotImplemented")))
+
+				Expect(probe.AllowsDeletion("test-bucket")).To(Succeed())
+			})
+
+			It("allows deletion when object lock has no default retention", func() {
+				fakeS3Server.AppendHandlers(ghttp.RespondWith(http.StatusOK,
+					`<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled></ObjectLockConfiguration>`))
+
+				Expect(probe.AllowsDeletion("test-bucket")).To(Succeed())
+			})
+
+			It("returns an error when new objects are retained", func() {
+				fakeS3Server.AppendHandlers(ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/test-bucket"),
+					verifyQueryHas("object-lock"),
+					ghttp.RespondWith(http.StatusOK, `<ObjectLockConfiguration>
+<ObjectLockEnabled>Enabled</ObjectLockEnabled>
+<Rule><DefaultRetention><Mode>COMPLIANCE</Mode><Days>30</Days></DefaultRetention></Rule>
+</ObjectLockConfiguration>`),
+				))
+
+				Expect(probe.AllowsDeletion("test-bucket")).To(MatchError(
+					"object lock in bucket test-bucket retains new objects in COMPLIANCE mode, so they cannot be deleted",
+				))
+			})
+		})
+
+		Context("Encryption", func() {
+			var probe *s3.S3Client
+
+			BeforeEach(func() {
+				var err error
+				probe, err = s3.NewS3Client("test-region", fakeS3Server.URL(), "test-id", "test-secret", false, fakeS3ServerConfig)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("succeeds without default encryption", func() {
+				fakeS3Server.AppendHandlers(ghttp.RespondWith(http.StatusNotFound, NotFoundResponse("ServerSideEncryptionConfigurationNotFoundError")))
+
+				Expect(probe.CanUseEncryptionKey("test-bucket")).To(Succeed())
+			})
+
+			It("succeeds without checking objects when not encrypting with KMS", func() {
+				fakeS3Server.AppendHandlers(ghttp.RespondWith(http.StatusOK, EncryptionResponse("AES256", "")))
+
+				Expect(probe.CanUseEncryptionKey("test-bucket")).To(Succeed())
+				Expect(fakeS3Server.ReceivedRequests()).To(HaveLen(1))
+			})
+
+			When("the bucket encrypts with a KMS key", func() {
+				BeforeEach(func() {
+					fakeS3Server.AppendHandlers(
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest("GET", "/test-bucket"),
+							verifyQueryHas("encryption"),
+							ghttp.RespondWith(http.StatusOK, EncryptionResponse("aws:kms", "test-key")),
+						),
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest("GET", "/test-bucket"),
+							verifyQueryHas("max-keys"),
+							ghttp.RespondWith(http.StatusOK, ListObjectsResponse),
+						),
+					)
+				})
+
+				It("reads the first byte of an object", func() {
+					fakeS3Server.AppendHandlers(ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/test-bucket/1.mp4"),
+						ghttp.VerifyHeaderKV("Range", "bytes=0-0"),
+						ghttp.RespondWith(http.StatusPartialContent, "x"),
+					))
+
+					Expect(probe.CanUseEncryptionKey("test-bucket")).To(Succeed())
+				})
+
+				It("returns an error naming the key when the object cannot be decrypted", func() {
+					fakeS3Server.AppendHandlers(ghttp.RespondWith(http.StatusForbidden, AccessDeniedResponse))
+
+					Expect(probe.CanUseEncryptionKey("test-bucket")).To(MatchError(ContainSubstring(
+						"could not read objects encrypted with KMS key test-key in bucket test-bucket: ",
+					)))
+				})
+			})
+		})
 	})
 })
+
+type requestRecorder struct {
+	host, path string
+}
+
+func (r *requestRecorder) Do(request *http.Request) (*http.Response, error) {
+	r.host = request.URL.Host
+	r.path = request.URL.Path
+	return nil, errors.New("request recorded")
+}
+
+func NotFoundResponse(code string) string {
+	return `<?xml version="1.0" encoding="UTF-8"?>
+<Error>
+    <Code>` + code + `</Code>
+    <Message>Not found</Message>
+    <RequestId>request-id</RequestId>
+    <HostId>host-id</HostId>
+</Error>
+`
+}
+
+func EncryptionResponse(algorithm, keyID string) string {
+	return `<ServerSideEncryptionConfiguration><Rule><ApplyServerSideEncryptionByDefault>
+<SSEAlgorithm>` + algorithm + `</SSEAlgorithm><KMSMasterKeyID>` + keyID + `</KMSMasterKeyID>
+</ApplyServerSideEncryptionByDefault></Rule></ServerSideEncryptionConfiguration>`
+}
+
+func verifyQueryHas(key string) http.HandlerFunc {
+	return func(_ http.ResponseWriter, request *http.Request) {
+		Expect(request.URL.Query()).To(HaveKey(key))
+	}
+}
+
+func verifyQueryValue(key, value string) http.HandlerFunc {
+	return func(_ http.ResponseWriter, request *http.Request) {
+		Expect(request.URL.Query().Get(key)).To(Equal(value))
+	}
+}
@@ -8,6 +8,40 @@ import (
 )
 
 type FakeClient struct {
+	AllowsDeletionStub        func(string) error
+	allowsDeletionMutex       sync.RWMutex
+	allowsDeletionArgsForCall []struct {
+		arg1 string
+	}
+	allowsDeletionReturns struct {
+		result1 error
+	}
+	allowsDeletionReturnsOnCall map[int]struct {
+		result1 error
+	}
+	CanCopyObjectsFromStub        func(string, string) error
+	canCopyObjectsFromMutex       sync.RWMutex
+	canCopyObjectsFromArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	canCopyObjectsFromReturns struct {
+		result1 error
+	}
+	canCopyObjectsFromReturnsOnCall map[int]struct {
+		result1 error
+	}
+	CanDeleteObjectsStub        func(string) error
+	canDeleteObjectsMutex       sync.RWMutex
+	canDeleteObjectsArgsForCall []struct {
+		arg1 string
+	}
+	canDeleteObjectsReturns struct {
+		result1 error
+	}
+	canDeleteObjectsReturnsOnCall map[int]struct {
+		result1 error
+	}
 	CanGetObjectVersionsStub        func(string) error
 	canGetObjectVersionsMutex       sync.RWMutex
 	canGetObjectVersionsArgsForCall []struct {
@@ -63,6 +97,17 @@ type FakeClient struct {
 	canPutObjectsReturnsOnCall map[int]struct {
 		result1 error
 	}
+	CanUseEncryptionKeyStub        func(string) error
+	canUseEncryptionKeyMutex       sync.RWMutex
+	canUseEncryptionKeyArgsForCall []struct {
+		arg1 string
+	}
+	canUseEncryptionKeyReturns struct {
+		result1 error
+	}
+	canUseEncryptionKeyReturnsOnCall map[int]struct {
+		result1 error
+	}
 	IsUnversionedStub        func(string) error
 	isUnversionedMutex       sync.RWMutex
 	isUnversionedArgsForCall []struct {
@@ -85,25 +130,245 @@ type FakeClient struct {
 	isVersionedReturnsOnCall map[int]struct {
 		result1 error
 	}
+	KeepsNoncurrentVersionsForStub        func(string, int) error
+	keepsNoncurrentVersionsForMutex       sync.RWMutex
+	keepsNoncurrentVersionsForArgsForCall []struct {
+		arg1 string
+		arg2 int
+	}
+	keepsNoncurrentVersionsForReturns struct {
+		result1 error
+	}
+	keepsNoncurrentVersionsForReturnsOnCall map[int]struct {
+		result1 error
+	}
+	KeepsObjectsForStub        func(string, int) error
+	keepsObjectsForMutex       sync.RWMutex
+	keepsObjectsForArgsForCall []struct {
+		arg1 string
+		arg2 int
+	}
+	keepsObjectsForReturns struct {
+		result1 error
+	}
+	keepsObjectsForReturnsOnCall map[int]struct {
+		result1 error
+	}
+	RemoveTestObjectsStub        func(string) error
+	removeTestObjectsMutex       sync.RWMutex
+	removeTestObjectsArgsForCall []struct {
+		arg1 string
+	}
+	removeTestObjectsReturns struct {
+		result1 error
+	}
+	removeTestObjectsReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeClient) AllowsDeletion(arg1 string) error {
+	fake.allowsDeletionMutex.Lock()
+	ret, specificReturn := fake.allowsDeletionReturnsOnCall[len(fake.allowsDeletionArgsForCall)]
+	fake.allowsDeletionArgsForCall = append(fake.allowsDeletionArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.AllowsDeletionStub
+	fakeReturns := fake.allowsDeletionReturns
+	fake.recordInvocation("AllowsDeletion", []interface{}{arg1})
+	fake.allowsDeletionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeClient) AllowsDeletionCallCount() int {
+	fake.allowsDeletionMutex.RLock()
+	defer fake.allowsDeletionMutex.RUnlock()
+	return len(fake.allowsDeletionArgsForCall)
+}
+
+func (fake *FakeClient) AllowsDeletionCalls(stub func(string) error) {
+	fake.allowsDeletionMutex.Lock()
+	defer fake.allowsDeletionMutex.Unlock()
+	fake.AllowsDeletionStub = stub
+}
+
+func (fake *FakeClient) AllowsDeletionArgsForCall(i int) string {
+	fake.allowsDeletionMutex.RLock()
+	defer fake.allowsDeletionMutex.RUnlock()
+	argsForCall := fake.allowsDeletionArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) AllowsDeletionReturns(result1 error) {
+	fake.allowsDeletionMutex.Lock()
+	defer fake.allowsDeletionMutex.Unlock()
+	fake.AllowsDeletionStub = nil
+	fake.allowsDeletionReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) AllowsDeletionReturnsOnCall(i int, result1 error) {
+	fake.allowsDeletionMutex.Lock()
+	defer fake.allowsDeletionMutex.Unlock()
+	fake.AllowsDeletionStub = nil
+	if fake.allowsDeletionReturnsOnCall == nil {
+		fake.allowsDeletionReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.allowsDeletionReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) CanCopyObjectsFrom(arg1 string, arg2 string) error {
+	fake.canCopyObjectsFromMutex.Lock()
+	ret, specificReturn := fake.canCopyObjectsFromReturnsOnCall[len(fake.canCopyObjectsFromArgsForCall)]
+	fake.canCopyObjectsFromArgsForCall = append(fake.canCopyObjectsFromArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.CanCopyObjectsFromStub
+	fakeReturns := fake.canCopyObjectsFromReturns
+	fake.recordInvocation("CanCopyObjectsFrom", []interface{}{arg1, arg2})
+	fake.canCopyObjectsFromMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeClient) CanCopyObjectsFromCallCount() int {
+	fake.canCopyObjectsFromMutex.RLock()
+	defer fake.canCopyObjectsFromMutex.RUnlock()
+	return len(fake.canCopyObjectsFromArgsForCall)
+}
+
+func (fake *FakeClient) CanCopyObjectsFromCalls(stub func(string, string) error) {
+	fake.canCopyObjectsFromMutex.Lock()
+	defer fake.canCopyObjectsFromMutex.Unlock()
+	fake.CanCopyObjectsFromStub = stub
+}
+
+func (fake *FakeClient) CanCopyObjectsFromArgsForCall(i int) (string, string) {
+	fake.canCopyObjectsFromMutex.RLock()
+	defer fake.canCopyObjectsFromMutex.RUnlock()
+	argsForCall := fake.canCopyObjectsFromArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeClient) CanCopyObjectsFromReturns(result1 error) {
+	fake.canCopyObjectsFromMutex.Lock()
+	defer fake.canCopyObjectsFromMutex.Unlock()
+	fake.CanCopyObjectsFromStub = nil
+	fake.canCopyObjectsFromReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) CanCopyObjectsFromReturnsOnCall(i int, result1 error) {
+	fake.canCopyObjectsFromMutex.Lock()
+	defer fake.canCopyObjectsFromMutex.Unlock()
+	fake.CanCopyObjectsFromStub = nil
+	if fake.canCopyObjectsFromReturnsOnCall == nil {
+		fake.canCopyObjectsFromReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.canCopyObjectsFromReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) CanDeleteObjects(arg1 string) error {
+	fake.canDeleteObjectsMutex.Lock()
+	ret, specificReturn := fake.canDeleteObjectsReturnsOnCall[len(fake.canDeleteObjectsArgsForCall)]
+	fake.canDeleteObjectsArgsForCall = append(fake.canDeleteObjectsArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.CanDeleteObjectsStub
+	fakeReturns := fake.canDeleteObjectsReturns
+	fake.recordInvocation("CanDeleteObjects", []interface{}{arg1})
+	fake.canDeleteObjectsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeClient) CanDeleteObjectsCallCount() int {
+	fake.canDeleteObjectsMutex.RLock()
+	defer fake.canDeleteObjectsMutex.RUnlock()
+	return len(fake.canDeleteObjectsArgsForCall)
+}
+
+func (fake *FakeClient) CanDeleteObjectsCalls(stub func(string) error) {
+	fake.canDeleteObjectsMutex.Lock()
+	defer fake.canDeleteObjectsMutex.Unlock()
+	fake.CanDeleteObjectsStub = stub
+}
+
+func (fake *FakeClient) CanDeleteObjectsArgsForCall(i int) string {
+	fake.canDeleteObjectsMutex.RLock()
+	defer fake.canDeleteObjectsMutex.RUnlock()
+	argsForCall := fake.canDeleteObjectsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) CanDeleteObjectsReturns(result1 error) {
+	fake.canDeleteObjectsMutex.Lock()
+	defer fake.canDeleteObjectsMutex.Unlock()
+	fake.CanDeleteObjectsStub = nil
+	fake.canDeleteObjectsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) CanDeleteObjectsReturnsOnCall(i int, result1 error) {
+	fake.canDeleteObjectsMutex.Lock()
+	defer fake.canDeleteObjectsMutex.Unlock()
+	fake.CanDeleteObjectsStub = nil
+	if fake.canDeleteObjectsReturnsOnCall == nil {
+		fake.canDeleteObjectsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.canDeleteObjectsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeClient) CanGetObjectVersions(arg1 string) error {
 	fake.canGetObjectVersionsMutex.Lock()
 	ret, specificReturn := fake.canGetObjectVersionsReturnsOnCall[len(fake.canGetObjectVersionsArgsForCall)]
 	fake.canGetObjectVersionsArgsForCall = append(fake.canGetObjectVersionsArgsForCall, struct {
 		arg1 string
 	}{arg1})
+	stub := fake.CanGetObjectVersionsStub
+	fakeReturns := fake.canGetObjectVersionsReturns
 	fake.recordInvocation("CanGetObjectVersions", []interface{}{arg1})
 	fake.canGetObjectVersionsMutex.Unlock()
-	if fake.CanGetObjectVersionsStub != nil {
-		return fake.CanGetObjectVersionsStub(arg1)
+	if stub != nil {
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.canGetObjectVersionsReturns
 	return fakeReturns.result1
 }
 
@@ -155,15 +420,16 @@ func (fake *FakeClient) CanGetObjects(arg1 string) error {
 	fake.canGetObjectsArgsForCall = append(fake.canGetObjectsArgsForCall, struct {
 		arg1 string
 	}{arg1})
+	stub := fake.CanGetObjectsStub
+	fakeReturns := fake.canGetObjectsReturns
 	fake.recordInvocation("CanGetObjects", []interface{}{arg1})
 	fake.canGetObjectsMutex.Unlock()
-	if fake.CanGetObjectsStub != nil {
-		return fake.CanGetObjectsStub(arg1)
+	if stub != nil {
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.canGetObjectsReturns
 	return fakeReturns.result1
 }
 
@@ -215,15 +481,16 @@ func (fake *FakeClient) CanListObjectVersions(arg1 string) error {
 	fake.canListObjectVersionsArgsForCall = append(fake.canListObjectVersionsArgsForCall, struct {
 		arg1 string
 	}{arg1})
+	stub := fake.CanListObjectVersionsStub
+	fakeReturns := fake.canListObjectVersionsReturns
 	fake.recordInvocation("CanListObjectVersions", []interface{}{arg1})
 	fake.canListObjectVersionsMutex.Unlock()
-	if fake.CanListObjectVersionsStub != nil {
-		return fake.CanListObjectVersionsStub(arg1)
+	if stub != nil {
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.canListObjectVersionsReturns
 	return fakeReturns.result1
 }
 
@@ -275,15 +542,16 @@ func (fake *FakeClient) CanListObjects(arg1 string) error {
 	fake.canListObjectsArgsForCall = append(fake.canListObjectsArgsForCall, struct {
 		arg1 string
 	}{arg1})
+	stub := fake.CanListObjectsStub
+	fakeReturns := fake.canListObjectsReturns
 	fake.recordInvocation("CanListObjects", []interface{}{arg1})
 	fake.canListObjectsMutex.Unlock()
-	if fake.CanListObjectsStub != nil {
-		return fake.CanListObjectsStub(arg1)
+	if stub != nil {
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.canListObjectsReturns
 	return fakeReturns.result1
 }
 
@@ -335,15 +603,16 @@ func (fake *FakeClient) CanPutObjects(arg1 string) error {
 	fake.canPutObjectsArgsForCall = append(fake.canPutObjectsArgsForCall, struct {
 		arg1 string
 	}{arg1})
+	stub := fake.CanPutObjectsStub
+	fakeReturns := fake.canPutObjectsReturns
 	fake.recordInvocation("CanPutObjects", []interface{}{arg1})
 	fake.canPutObjectsMutex.Unlock()
-	if fake.CanPutObjectsStub != nil {
-		return fake.CanPutObjectsStub(arg1)
+	if stub != nil {
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.canPutObjectsReturns
 	return fakeReturns.result1
 }
 
@@ -389,21 +658,83 @@ func (fake *FakeClient) CanPutObjectsReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeClient) CanUseEncryptionKey(arg1 string) error {
+	fake.canUseEncryptionKeyMutex.Lock()
+	ret, specificReturn := fake.canUseEncryptionKeyReturnsOnCall[len(fake.canUseEncryptionKeyArgsForCall)]
+	fake.canUseEncryptionKeyArgsForCall = append(fake.canUseEncryptionKeyArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.CanUseEncryptionKeyStub
+	fakeReturns := fake.canUseEncryptionKeyReturns
+	fake.recordInvocation("CanUseEncryptionKey", []interface{}{arg1})
+	fake.canUseEncryptionKeyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeClient) CanUseEncryptionKeyCallCount() int {
+	fake.canUseEncryptionKeyMutex.RLock()
+	defer fake.canUseEncryptionKeyMutex.RUnlock()
+	return len(fake.canUseEncryptionKeyArgsForCall)
+}
+
+func (fake *FakeClient) CanUseEncryptionKeyCalls(stub func(string) error) {
+	fake.canUseEncryptionKeyMutex.Lock()
+	defer fake.canUseEncryptionKeyMutex.Unlock()
+	fake.CanUseEncryptionKeyStub = stub
+}
+
+func (fake *FakeClient) CanUseEncryptionKeyArgsForCall(i int) string {
+	fake.canUseEncryptionKeyMutex.RLock()
+	defer fake.canUseEncryptionKeyMutex.RUnlock()
+	argsForCall := fake.canUseEncryptionKeyArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) CanUseEncryptionKeyReturns(result1 error) {
+	fake.canUseEncryptionKeyMutex.Lock()
+	defer fake.canUseEncryptionKeyMutex.Unlock()
+	fake.CanUseEncryptionKeyStub = nil
+	fake.canUseEncryptionKeyReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) CanUseEncryptionKeyReturnsOnCall(i int, result1 error) {
+	fake.canUseEncryptionKeyMutex.Lock()
+	defer fake.canUseEncryptionKeyMutex.Unlock()
+	fake.CanUseEncryptionKeyStub = nil
+	if fake.canUseEncryptionKeyReturnsOnCall == nil {
+		fake.canUseEncryptionKeyReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.canUseEncryptionKeyReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeClient) IsUnversioned(arg1 string) error {
 	fake.isUnversionedMutex.Lock()
 	ret, specificReturn := fake.isUnversionedReturnsOnCall[len(fake.isUnversionedArgsForCall)]
 	fake.isUnversionedArgsForCall = append(fake.isUnversionedArgsForCall, struct {
 		arg1 string
 	}{arg1})
+	stub := fake.IsUnversionedStub
+	fakeReturns := fake.isUnversionedReturns
 	fake.recordInvocation("IsUnversioned", []interface{}{arg1})
 	fake.isUnversionedMutex.Unlock()
-	if fake.IsUnversionedStub != nil {
-		return fake.IsUnversionedStub(arg1)
+	if stub != nil {
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.isUnversionedReturns
 	return fakeReturns.result1
 }
 
@@ -455,15 +786,16 @@ func (fake *FakeClient) IsVersioned(arg1 string) error {
 	fake.isVersionedArgsForCall = append(fake.isVersionedArgsForCall, struct {
 		arg1 string
 	}{arg1})
+	stub := fake.IsVersionedStub
+	fakeReturns := fake.isVersionedReturns
 	fake.recordInvocation("IsVersioned", []interface{}{arg1})
 	fake.isVersionedMutex.Unlock()
-	if fake.IsVersionedStub != nil {
-		return fake.IsVersionedStub(arg1)
+	if stub != nil {
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.isVersionedReturns
 	return fakeReturns.result1
 }
 
@@ -509,9 +841,200 @@ func (fake *FakeClient) IsVersionedReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeClient) KeepsNoncurrentVersionsFor(arg1 string, arg2 int) error {
+	fake.keepsNoncurrentVersionsForMutex.Lock()
+	ret, specificReturn := fake.keepsNoncurrentVersionsForReturnsOnCall[len(fake.keepsNoncurrentVersionsForArgsForCall)]
+	fake.keepsNoncurrentVersionsForArgsForCall = append(fake.keepsNoncurrentVersionsForArgsForCall, struct {
+		arg1 string
+		arg2 int
+	}{arg1, arg2})
+	stub := fake.KeepsNoncurrentVersionsForStub
+	fakeReturns := fake.keepsNoncurrentVersionsForReturns
+	fake.recordInvocation("KeepsNoncurrentVersionsFor", []interface{}{arg1, arg2})
+	fake.keepsNoncurrentVersionsForMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeClient) KeepsNoncurrentVersionsForCallCount() int {
+	fake.keepsNoncurrentVersionsForMutex.RLock()
+	defer fake.keepsNoncurrentVersionsForMutex.RUnlock()
+	return len(fake.keepsNoncurrentVersionsForArgsForCall)
+}
+
+func (fake *FakeClient) KeepsNoncurrentVersionsForCalls(stub func(string, int) error) {
+	fake.keepsNoncurrentVersionsForMutex.Lock()
+	defer fake.keepsNoncurrentVersionsForMutex.Unlock()
+	fake.KeepsNoncurrentVersionsForStub = stub
+}
+
+func (fake *FakeClient) KeepsNoncurrentVersionsForArgsForCall(i int) (string, int) {
+	fake.keepsNoncurrentVersionsForMutex.RLock()
+	defer fake.keepsNoncurrentVersionsForMutex.RUnlock()
+	argsForCall := fake.keepsNoncurrentVersionsForArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeClient) KeepsNoncurrentVersionsForReturns(result1 error) {
+	fake.keepsNoncurrentVersionsForMutex.Lock()
+	defer fake.keepsNoncurrentVersionsForMutex.Unlock()
+	fake.KeepsNoncurrentVersionsForStub = nil
+	fake.keepsNoncurrentVersionsForReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) KeepsNoncurrentVersionsForReturnsOnCall(i int, result1 error) {
+	fake.keepsNoncurrentVersionsForMutex.Lock()
+	defer fake.keepsNoncurrentVersionsForMutex.Unlock()
+	fake.KeepsNoncurrentVersionsForStub = nil
+	if fake.keepsNoncurrentVersionsForReturnsOnCall == nil {
+		fake.keepsNoncurrentVersionsForReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.keepsNoncurrentVersionsForReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) KeepsObjectsFor(arg1 string, arg2 int) error {
+	fake.keepsObjectsForMutex.Lock()
+	ret, specificReturn := fake.keepsObjectsForReturnsOnCall[len(fake.keepsObjectsForArgsForCall)]
+	fake.keepsObjectsForArgsForCall = append(fake.keepsObjectsForArgsForCall, struct {
+		arg1 string
+		arg2 int
+	}{arg1, arg2})
+	stub := fake.KeepsObjectsForStub
+	fakeReturns := fake.keepsObjectsForReturns
+	fake.recordInvocation("KeepsObjectsFor", []interface{}{arg1, arg2})
+	fake.keepsObjectsForMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeClient) KeepsObjectsForCallCount() int {
+	fake.keepsObjectsForMutex.RLock()
+	defer fake.keepsObjectsForMutex.RUnlock()
+	return len(fake.keepsObjectsForArgsForCall)
+}
+
+func (fake *FakeClient) KeepsObjectsForCalls(stub func(string, int) error) {
+	fake.keepsObjectsForMutex.Lock()
+	defer fake.keepsObjectsForMutex.Unlock()
+	fake.KeepsObjectsForStub = stub
+}
+
+func (fake *FakeClient) KeepsObjectsForArgsForCall(i int) (string, int) {
+	fake.keepsObjectsForMutex.RLock()
+	defer fake.keepsObjectsForMutex.RUnlock()
+	argsForCall := fake.keepsObjectsForArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeClient) KeepsObjectsForReturns(result1 error) {
+	fake.keepsObjectsForMutex.Lock()
+	defer fake.keepsObjectsForMutex.Unlock()
+	fake.KeepsObjectsForStub = nil
+	fake.keepsObjectsForReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) KeepsObjectsForReturnsOnCall(i int, result1 error) {
+	fake.keepsObjectsForMutex.Lock()
+	defer fake.keepsObjectsForMutex.Unlock()
+	fake.KeepsObjectsForStub = nil
+	if fake.keepsObjectsForReturnsOnCall == nil {
+		fake.keepsObjectsForReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.keepsObjectsForReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) RemoveTestObjects(arg1 string) error {
+	fake.removeTestObjectsMutex.Lock()
+	ret, specificReturn := fake.removeTestObjectsReturnsOnCall[len(fake.removeTestObjectsArgsForCall)]
+	fake.removeTestObjectsArgsForCall = append(fake.removeTestObjectsArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.RemoveTestObjectsStub
+	fakeReturns := fake.removeTestObjectsReturns
+	fake.recordInvocation("RemoveTestObjects", []interface{}{arg1})
+	fake.removeTestObjectsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeClient) RemoveTestObjectsCallCount() int {
+	fake.removeTestObjectsMutex.RLock()
+	defer fake.removeTestObjectsMutex.RUnlock()
+	return len(fake.removeTestObjectsArgsForCall)
+}
+
+func (fake *FakeClient) RemoveTestObjectsCalls(stub func(string) error) {
+	fake.removeTestObjectsMutex.Lock()
+	defer fake.removeTestObjectsMutex.Unlock()
+	fake.RemoveTestObjectsStub = stub
+}
+
+func (fake *FakeClient) RemoveTestObjectsArgsForCall(i int) string {
+	fake.removeTestObjectsMutex.RLock()
+	defer fake.removeTestObjectsMutex.RUnlock()
+	argsForCall := fake.removeTestObjectsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) RemoveTestObjectsReturns(result1 error) {
+	fake.removeTestObjectsMutex.Lock()
+	defer fake.removeTestObjectsMutex.Unlock()
+	fake.RemoveTestObjectsStub = nil
+	fake.removeTestObjectsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) RemoveTestObjectsReturnsOnCall(i int, result1 error) {
+	fake.removeTestObjectsMutex.Lock()
+	defer fake.removeTestObjectsMutex.Unlock()
+	fake.RemoveTestObjectsStub = nil
+	if fake.removeTestObjectsReturnsOnCall == nil {
+		fake.removeTestObjectsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.removeTestObjectsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.allowsDeletionMutex.RLock()
+	defer fake.allowsDeletionMutex.RUnlock()
+	fake.canCopyObjectsFromMutex.RLock()
+	defer fake.canCopyObjectsFromMutex.RUnlock()
+	fake.canDeleteObjectsMutex.RLock()
+	defer fake.canDeleteObjectsMutex.RUnlock()
 	fake.canGetObjectVersionsMutex.RLock()
 	defer fake.canGetObjectVersionsMutex.RUnlock()
 	fake.canGetObjectsMutex.RLock()
@@ -522,10 +1045,18 @@ func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	defer fake.canListObjectsMutex.RUnlock()
 	fake.canPutObjectsMutex.RLock()
 	defer fake.canPutObjectsMutex.RUnlock()
+	fake.canUseEncryptionKeyMutex.RLock()
+	defer fake.canUseEncryptionKeyMutex.RUnlock()
 	fake.isUnversionedMutex.RLock()
 	defer fake.isUnversionedMutex.RUnlock()
 	fake.isVersionedMutex.RLock()
 	defer fake.isVersionedMutex.RUnlock()
+	fake.keepsNoncurrentVersionsForMutex.RLock()
+	defer fake.keepsNoncurrentVersionsForMutex.RUnlock()
+	fake.keepsObjectsForMutex.RLock()
+	defer fake.keepsObjectsForMutex.RUnlock()
+	fake.removeTestObjectsMutex.RLock()
+	defer fake.removeTestObjectsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -0,0 +1,38 @@
+// Package testobject names the objects that probes write into buckets. Every
+// run of the validator writes under its own prefix, so that it only removes
+// what it wrote itself and any leftovers can be traced back to their run.
+package testobject
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Root is the prefix shared by the test objects of all runs.
+const Root = "bbr-config-validator-test-objects/"
+
+// Content is written into every test object, for anyone who comes across one.
+const Content = "Written by bbr-s3-config-validator to check bucket permissions. " +
+	"It is removed at the end of the validation; if it is still here, it is safe to delete."
+
+// Prefix is unique to this run of the validator.
+var Prefix = NewPrefix(time.Now(), rand.Reader)
+
+// NewPrefix names a run by when it started, plus a random suffix for runs
+// started in the same second.
+func NewPrefix(now time.Time, random io.Reader) string {
+	suffix := make([]byte, 4)
+	if _, err := io.ReadFull(random, suffix); err != nil {
+		suffix = []byte(fmt.Sprintf("%04d", now.Nanosecond()%10000))
+	}
+
+	return fmt.Sprintf("%s%s-%s/", Root, now.UTC().Format("20060102T150405Z"), hex.EncodeToString(suffix))
+}
+
+// Key names a test object of this run.
+func Key(name string) string {
+	return Prefix + name
+}
@@ -0,0 +1,13 @@
+package testobject_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestTestObject(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "TestObject Suite")
+}
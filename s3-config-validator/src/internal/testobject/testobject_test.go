@@ -0,0 +1,42 @@
+package testobject_test
+
+import (
+	"bytes"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/cloudfoundry/bosh-backup-and-restore/s3-config-validator/src/internal/testobject"
+)
+
+var _ = Describe("NewPrefix", func() {
+	var now = time.Date(2021, 3, 4, 5, 6, 7, 0, time.FixedZone("CET", 3600))
+
+	It("names the run by its start time in UTC and a random suffix", func() {
+		prefix := testobject.NewPrefix(now, bytes.NewReader([]byte{0xde, 0xad, 0xbe, 0xef}))
+
+		Expect(prefix).To(Equal("bbr-config-validator-test-objects/20210304T040607Z-deadbeef/"))
+	})
+
+	It("differs between runs started in the same second", func() {
+		first := testobject.NewPrefix(now, bytes.NewReader([]byte{1, 2, 3, 4}))
+		second := testobject.NewPrefix(now, bytes.NewReader([]byte{5, 6, 7, 8}))
+
+		Expect(first).NotTo(Equal(second))
+	})
+
+	It("still names the run when no randomness is available", func() {
+		prefix := testobject.NewPrefix(now, strings.NewReader(""))
+
+		Expect(prefix).To(HavePrefix(testobject.Root + "20210304T040607Z-"))
+	})
+})
+
+var _ = Describe("Key", func() {
+	It("puts the object under this run's prefix", func() {
+		Expect(testobject.Key("put")).To(Equal(testobject.Prefix + "put"))
+		Expect(testobject.Prefix).To(HavePrefix(testobject.Root))
+	})
+})
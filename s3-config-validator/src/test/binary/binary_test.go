@@ -1,6 +1,7 @@
 package binary_test
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
@@ -35,7 +36,7 @@ var _ = Describe("binary tests", func() {
 
 				Context("is not valid", func() {
 					It("fails with an error message", func() {
-						Eventually(session, "60s").Should(gexec.Exit(1))
+						Eventually(session, "60s").Should(gexec.Exit(2))
 						Eventually(session.Out).Should(gbytes.Say(`Bad config`))
 					})
 				})
@@ -48,7 +49,7 @@ var _ = Describe("binary tests", func() {
 				})
 
 				It("fails with an error message", func() {
-					Eventually(session).Should(gexec.Exit(1))
+					Eventually(session).Should(gexec.Exit(2))
 					Eventually(session.Out).Should(gbytes.Say(`no such file`))
 				})
 			})
@@ -64,13 +65,104 @@ var _ = Describe("binary tests", func() {
 					})
 
 					It("fails with an error message", func() {
-						Eventually(session).Should(gexec.Exit(1))
+						Eventually(session).Should(gexec.Exit(2))
 						Eventually(session.Out).Should(gbytes.Say(
 							`open /var/vcap/jobs/s3-versioned-blobstore-backup-restorer/config/buckets.json: no such file`))
 					})
 				})
 			})
 
+			Context("with --blobstore gcs", func() {
+				When("there is no file at default location", func() {
+
+					BeforeEach(func() {
+						session = executeBBRValidatorVersioned("", "--blobstore", "gcs")
+					})
+
+					It("fails with an error message", func() {
+						Eventually(session).Should(gexec.Exit(2))
+						Eventually(session.Out).Should(gbytes.Say(
+							`open /var/vcap/jobs/gcs-blobstore-backup-restorer/config/buckets.json: no such file`))
+					})
+				})
+			})
+
+			Context("with --blobstore azure", func() {
+				When("there is no file at default location", func() {
+
+					BeforeEach(func() {
+						session = executeBBRValidatorVersioned("", "--blobstore", "azure")
+					})
+
+					It("fails with an error message", func() {
+						Eventually(session).Should(gexec.Exit(2))
+						Eventually(session.Out).Should(gbytes.Say(
+							`open /var/vcap/jobs/azure-blobstore-backup-restorer/config/containers.json: no such file`))
+					})
+				})
+			})
+
+			Context("with an unknown --blobstore", func() {
+				BeforeEach(func() {
+					session = executeBBRValidatorVersioned("", "--blobstore", "swift")
+				})
+
+				It("fails with an error message", func() {
+					Eventually(session).Should(gexec.Exit(2))
+					Eventually(session.Out).Should(gbytes.Say(`unknown blobstore "swift", expected one of s3, gcs or azure`))
+				})
+			})
+
+			Context("with --format json", func() {
+				When("there is no file at default location", func() {
+
+					BeforeEach(func() {
+						os.Unsetenv(ConfigPathEnv)
+						session = executeBBRValidatorVersioned("", "--format", "json")
+					})
+
+					It("writes only the error report", func() {
+						Eventually(session).Should(gexec.Exit(2))
+						Expect(string(session.Out.Contents())).To(MatchJSON(`{
+							"blobstore": "s3",
+							"config_path": "/var/vcap/jobs/s3-versioned-blobstore-backup-restorer/config/buckets.json",
+							"read_only": true,
+							"valid": false,
+							"error": "open /var/vcap/jobs/s3-versioned-blobstore-backup-restorer/config/buckets.json: no such file or directory",
+							"buckets": []
+						}`))
+					})
+				})
+			})
+
+			Context("with --format junit", func() {
+				When("there is no file at default location", func() {
+
+					BeforeEach(func() {
+						os.Unsetenv(ConfigPathEnv)
+						session = executeBBRValidatorVersioned("", "--format", "junit")
+					})
+
+					It("reports the configuration as an erroring test case", func() {
+						Eventually(session).Should(gexec.Exit(2))
+						Expect(string(session.Out.Contents())).To(HavePrefix(`<?xml`))
+						Expect(string(session.Out.Contents())).To(ContainSubstring(
+							`<testcase classname="s3" name="Configuration at /var/vcap/jobs/s3-versioned-blobstore-backup-restorer/config/buckets.json is valid"`))
+					})
+				})
+			})
+
+			Context("with an unknown --format", func() {
+				BeforeEach(func() {
+					session = executeBBRValidatorVersioned("", "--format", "yaml")
+				})
+
+				It("fails with an error message", func() {
+					Eventually(session).Should(gexec.Exit(2))
+					Eventually(session.Out).Should(gbytes.Say(`unknown format "yaml", expected one of text, json or junit`))
+				})
+			})
+
 			Context("with --unversioned", func() {
 				When("there is no file at default location", func() {
 
@@ -80,7 +172,7 @@ var _ = Describe("binary tests", func() {
 					})
 
 					It("fails with an error message", func() {
-						Eventually(session).Should(gexec.Exit(1))
+						Eventually(session).Should(gexec.Exit(2))
 						Eventually(session.Out).Should(gbytes.Say(
 							`open /var/vcap/jobs/s3-unversioned-blobstore-backup-restorer/config/buckets.json: no such file`))
 					})
@@ -118,10 +210,13 @@ var _ = Describe("binary tests", func() {
 				 * Bucket is versioned ... Yes
 				 * Can list object versions ... Yes
 				 * Can get object versions ... Yes
+				 * Lifecycle rules keep noncurrent versions for 7 days ... Yes
+				 * Object lock allows deletion ... Yes
+				 * Can use the bucket's KMS key ... Yes
 		
 				Good config
 		
-				Run with --validate-put-object to test writing objects to the buckets. Disclaimer: This will write test files to the buckets.
+				Run with --validate-put-object to test writing and deleting objects in the buckets. Test objects are removed once validation has finished.
 			`, versionedBucketName))))
 			})
 		})
@@ -154,9 +249,47 @@ var _ = Describe("binary tests", func() {
 				 * Can list object versions ... Yes
 				 * Can get object versions ... Yes
 				 * Can put objects ... Yes
+				 * Lifecycle rules keep noncurrent versions for 7 days ... Yes
+				 * Object lock allows deletion ... Yes
+				 * Can use the bucket's KMS key ... Yes
+				 * Can delete objects ... Yes
+
+				Removing test objects from test-resource's live bucket %s ... Done
 				
 				Good config
-			`, versionedBucketName))))
+			`, versionedBucketName, versionedBucketName))))
+			})
+		})
+
+		Context("--format json", func() {
+
+			BeforeEach(func() {
+				session = executeBBRValidatorVersioned(validVersionedConfigFile.Name(), "--format", "json")
+			})
+
+			It("writes a report of every probe", func() {
+				Eventually(session, "20s").Should(gexec.Exit(0))
+
+				var report struct {
+					Valid   bool `json:"valid"`
+					Buckets []struct {
+						Name   string `json:"name"`
+						Type   string `json:"type"`
+						Probes []struct {
+							Name   string `json:"name"`
+							Passed bool   `json:"passed"`
+						} `json:"probes"`
+					} `json:"buckets"`
+				}
+				Expect(json.Unmarshal(session.Out.Contents(), &report)).To(Succeed())
+
+				Expect(report.Valid).To(BeTrue())
+				Expect(report.Buckets).To(HaveLen(1))
+				Expect(report.Buckets[0].Name).To(Equal(versionedBucketName))
+				Expect(report.Buckets[0].Type).To(Equal("live"))
+				Expect(report.Buckets[0].Probes).To(HaveLen(6))
+				Expect(report.Buckets[0].Probes[0].Name).To(Equal("Bucket is versioned"))
+				Expect(report.Buckets[0].Probes[0].Passed).To(BeTrue())
 			})
 		})
 
@@ -189,15 +322,19 @@ var _ = Describe("binary tests", func() {
 				 * Bucket is not versioned ... Yes
 				 * Can list objects ... Yes
 				 * Can get objects ... Yes
+				 * Object lock allows deletion ... Yes
+				 * Can use the bucket's KMS key ... Yes
 
 				Validating test-resource's backup bucket %s ...
 				 * Bucket is not versioned ... Yes
 				 * Can list objects ... Yes
 				 * Can get objects ... Yes
+				 * Lifecycle rules keep objects for 7 days ... Yes
+				 * Can use the bucket's KMS key ... Yes
 				
 				Good config
 
-				Run with --validate-put-object to test writing objects to the buckets. Disclaimer: This will write test files to the buckets.
+				Run with --validate-put-object to test writing and deleting objects in the buckets. Test objects are removed once validation has finished.
 				`, unversionedBucketName, unversionedBucketName))))
 			})
 		})
@@ -230,15 +367,25 @@ var _ = Describe("binary tests", func() {
 				 * Can list objects ... Yes
 				 * Can get objects ... Yes
 				 * Can put objects ... Yes
+				 * Object lock allows deletion ... Yes
+				 * Can use the bucket's KMS key ... Yes
+				 * Can delete objects ... Yes
 
-				Validating test-resource's backup bucket %s ...
+				Validating test-resource's backup bucket %[1]s ...
 				 * Bucket is not versioned ... Yes
 				 * Can list objects ... Yes
 				 * Can get objects ... Yes
 				 * Can put objects ... Yes
+				 * Lifecycle rules keep objects for 7 days ... Yes
+				 * Can use the bucket's KMS key ... Yes
+				 * Can copy objects from bucket %[1]s ... Yes
+				 * Can delete objects ... Yes
+
+				Removing test objects from test-resource's live bucket %[1]s ... Done
+				Removing test objects from test-resource's backup bucket %[1]s ... Done
 				
 				Good config
-			`, unversionedBucketName, unversionedBucketName))))
+			`, unversionedBucketName))))
 			})
 		})
 	})
@@ -254,25 +401,47 @@ var _ = Describe("binary tests", func() {
 				Eventually(session, "60s").Should(gexec.Exit(0))
 				Expect(string(session.Out.Contents())).To(ContainSubstring(dedent(`
 					Validates a BOSH backup and restore bucket configuration.
-					By default it will assume versioned buckets unless specified otherwise.
+					By default it will assume versioned S3 buckets unless specified otherwise.
 					
 					The default config file locations are:
 					
-					 * versioned: /var/vcap/jobs/s3-versioned-blobstore-backup-restorer/config/buckets.json
-					 * unversioned: /var/vcap/jobs/s3-unversioned-blobstore-backup-restorer/config/buckets.json
-
+					 * s3 versioned: /var/vcap/jobs/s3-versioned-blobstore-backup-restorer/config/buckets.json
+					 * s3 unversioned: /var/vcap/jobs/s3-unversioned-blobstore-backup-restorer/config/buckets.json
+					 * gcs: /var/vcap/jobs/gcs-blobstore-backup-restorer/config/buckets.json
+					 * azure: /var/vcap/jobs/azure-blobstore-backup-restorer/config/containers.json
+					
 					Make sure to run this on the ‘backup_restore’ VM.
 					
 					USAGE:
-					  bbr-s3-config-validator [--validate-put-object]
+					  bbr-s3-config-validator [--blobstore s3|gcs|azure] [--validate-put-object] [--format text|json|junit]
 					
 					OPTIONS:
 					  --help                        Show usage.
-					  --unversioned                 Validate unversioned bucket configuration.
-					  --validate-put-object         Test writing objects to the buckets. Disclaimer: This will write test files to the buckets.
+					  --blobstore <type>            Blobstore to validate: s3 (default), gcs or azure.
+					  --unversioned                 Validate unversioned S3 bucket configuration.
+					  --endpoint <url>              Validate GCS or Azure configuration against this endpoint, e.g. a local emulator.
+					                                For Azure the storage account is appended to the path, as emulators expect.
+					  --retention-days <days>       Fail S3 buckets whose lifecycle rules delete backups sooner than this (default 7).
+					  --validate-put-object         Test writing and deleting objects in the buckets. Test objects are written under
+					                                bbr-config-validator-test-objects/<run>/ and removed once validation has finished.
+					                                For unversioned S3 buckets this also copies a test object into the backup bucket.
+					  --format <format>             Output format: text (default), json or junit. JSON and JUnit reports are written
+					                                to stdout in place of the text output.
 					
 					ENVIRONMENT VARIABLES:
-					  BBR_S3_BUCKETS_CONFIG=<path>  Override the default bucket configuration file location
+					  BBR_S3_BUCKETS_CONFIG=<path>        Override the default S3 bucket configuration file location
+					  BBR_GCS_BUCKETS_CONFIG=<path>       Override the default GCS bucket configuration file location
+					  BBR_GCS_SERVICE_ACCOUNT_KEY=<path>  Override the default GCS service account key location
+					                                      (/var/vcap/jobs/gcs-blobstore-backup-restorer/config/gcp-service-account-key.json)
+					  BBR_AZURE_CONTAINERS_CONFIG=<path>  Override the default Azure container configuration file location
+					
+					EXIT CODES:
+					  0  All buckets are valid.
+					  1  A probe failed for at least one bucket.
+					  2  The configuration or flags could not be used, so no buckets were validated.
+					
+					S3-COMPATIBLE STORES:
+					  Set "force_path_style": true on a bucket to address it as <endpoint>/<bucket> rather than <bucket>.<endpoint>.
 					`)))
 			})
 		})
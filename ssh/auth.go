@@ -0,0 +1,252 @@
+package ssh
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha1" //nolint:gosec
+	"encoding/base64"
+	"net"
+	"os"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// Credentials describe the ways bbr may authenticate to a VM that it
+// reaches directly, rather than through SSH users created by the director.
+type Credentials struct {
+	// PrivateKey is the contents of a private key.
+	PrivateKey string
+	// Certificate is the contents of an OpenSSH certificate for PrivateKey.
+	Certificate string
+	// UseAgent offers the keys held by the agent listening on SSH_AUTH_SOCK.
+	UseAgent bool
+}
+
+func (c Credentials) AuthMethods() ([]ssh.AuthMethod, error) {
+	var authMethods []ssh.AuthMethod
+
+	if c.PrivateKey != "" {
+		signer, err := ssh.ParsePrivateKey([]byte(c.PrivateKey))
+		if err != nil {
+			return nil, errors.Wrap(err, "ssh.NewConnection.ParsePrivateKey failed")
+		}
+
+		if c.Certificate != "" {
+			signer, err = certificateSigner(c.Certificate, signer)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		authMethods = append(authMethods, ssh.PublicKeys(signer))
+	} else if c.Certificate != "" {
+		return nil, errors.New("a certificate can only be used together with its private key")
+	}
+
+	if c.UseAgent {
+		agentClient, err := sshAgent()
+		if err != nil {
+			return nil, err
+		}
+
+		authMethods = append(authMethods, ssh.PublicKeysCallback(agentClient.Signers))
+	}
+
+	if len(authMethods) == 0 {
+		return nil, errors.New("no ssh credentials provided")
+	}
+	return authMethods, nil
+}
+
+var agents = struct {
+	sync.Mutex
+	clients map[string]agent.ExtendedAgent
+}{clients: map[string]agent.ExtendedAgent{}}
+
+// sshAgent connects to the agent listening on SSH_AUTH_SOCK once, and shares
+// that connection between every host that is authenticated with it.
+func sshAgent() (agent.ExtendedAgent, error) {
+	socket := os.Getenv("SSH_AUTH_SOCK")
+	if socket == "" {
+		return nil, errors.New("SSH_AUTH_SOCK is not set, is an ssh agent running?")
+	}
+
+	agents.Lock()
+	defer agents.Unlock()
+
+	if client, ok := agents.clients[socket]; ok {
+		return client, nil
+	}
+
+	connection, err := net.Dial("unix", socket)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to connect to ssh agent")
+	}
+
+	client := agent.NewClient(connection)
+	agents.clients[socket] = client
+	return client, nil
+}
+
+func certificateSigner(certificate string, signer ssh.Signer) (ssh.Signer, error) {
+	publicKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(certificate))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse ssh certificate")
+	}
+
+	cert, ok := publicKey.(*ssh.Certificate)
+	if !ok {
+		return nil, errors.New("failed to parse ssh certificate: not a certificate")
+	}
+
+	certSigner, err := ssh.NewCertSigner(cert, signer)
+	if err != nil {
+		return nil, errors.Wrap(err, "ssh certificate does not match the private key")
+	}
+	return certSigner, nil
+}
+
+// HostKeyCallback verifies host keys against a known_hosts file or a pinned
+// SHA256 or MD5 fingerprint, as printed by ssh-keygen -l. Host keys are not
+// verified at all when neither is given.
+func HostKeyCallback(knownHostsPath, fingerprint string) (ssh.HostKeyCallback, error) {
+	switch {
+	case knownHostsPath != "" && fingerprint != "":
+		return nil, errors.New("only one of a known_hosts file and a host key fingerprint can be used")
+	case knownHostsPath != "":
+		callback, err := knownhosts.New(knownHostsPath)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read known_hosts file")
+		}
+		return callback, nil
+	case fingerprint != "":
+		return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+			if ssh.FingerprintSHA256(key) == fingerprint || "MD5:"+ssh.FingerprintLegacyMD5(key) == fingerprint || ssh.FingerprintLegacyMD5(key) == fingerprint {
+				return nil
+			}
+			return errors.Errorf("host key fingerprint for %s is %s, expected %s", hostname, ssh.FingerprintSHA256(key), fingerprint)
+		}, nil
+	default:
+		return ssh.InsecureIgnoreHostKey(), nil //nolint:gosec
+	}
+}
+
+// HostKeyAlgorithms returns the host key algorithms that match the keys
+// recorded for address in a known_hosts file, so that a server preferring
+// another type of key is asked for the one that can be verified. It returns
+// nil, allowing every algorithm, when the file has no keys for address or
+// when it lists certificate authorities.
+func HostKeyAlgorithms(knownHostsPath, address string) ([]string, error) {
+	contents, err := os.ReadFile(knownHostsPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read known_hosts file")
+	}
+
+	host := knownhosts.Normalize(address)
+	var algorithms []string
+	seen := map[string]bool{}
+
+	for len(bytes.TrimSpace(contents)) > 0 {
+		marker, hosts, key, _, rest, err := ssh.ParseKnownHosts(contents)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read known_hosts file")
+		}
+		contents = rest
+
+		if marker == "cert-authority" {
+			return nil, nil
+		}
+		if marker != "" || !knownHostsEntryMatches(hosts, host) {
+			continue
+		}
+
+		for _, algorithm := range algorithmsForKeyType(key.Type()) {
+			if !seen[algorithm] {
+				seen[algorithm] = true
+				algorithms = append(algorithms, algorithm)
+			}
+		}
+	}
+
+	return algorithms, nil
+}
+
+// knownHostsEntryMatches matches host against the patterns of a known_hosts
+// entry, which may be hashed, contain wildcards or be negated.
+func knownHostsEntryMatches(patterns []string, host string) bool {
+	matched := false
+	for _, pattern := range patterns {
+		negated := strings.HasPrefix(pattern, "!")
+		pattern = strings.TrimPrefix(pattern, "!")
+
+		if knownHostsPatternMatches(pattern, host) {
+			if negated {
+				return false
+			}
+			matched = true
+		}
+	}
+	return matched
+}
+
+func knownHostsPatternMatches(pattern, host string) bool {
+	if strings.HasPrefix(pattern, "|1|") {
+		parts := strings.Split(pattern, "|")
+		if len(parts) != 4 {
+			return false
+		}
+		salt, err := base64.StdEncoding.DecodeString(parts[2])
+		if err != nil {
+			return false
+		}
+		hash := hmac.New(sha1.New, salt)
+		hash.Write([]byte(host))
+		return base64.StdEncoding.EncodeToString(hash.Sum(nil)) == parts[3]
+	}
+
+	matched, err := path.Match(knownhosts.Normalize(pattern), host)
+	return err == nil && matched
+}
+
+// algorithmsForKeyType lists the signature algorithms that a host key of
+// keyType can be presented with.
+func algorithmsForKeyType(keyType string) []string {
+	if keyType == ssh.KeyAlgoRSA {
+		return []string{ssh.KeyAlgoRSASHA512, ssh.KeyAlgoRSASHA256, ssh.KeyAlgoRSA}
+	}
+	return []string{keyType}
+}
+
+// HostKeyRecorder remembers the host key that the wrapped callback last
+// accepted, so that it can be recorded alongside a backup.
+type HostKeyRecorder struct {
+	sync.Mutex
+	fingerprint string
+}
+
+func (r *HostKeyRecorder) Wrap(callback ssh.HostKeyCallback) ssh.HostKeyCallback {
+	return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+		if err := callback(hostname, remote, key); err != nil {
+			return err
+		}
+
+		r.Lock()
+		defer r.Unlock()
+		r.fingerprint = ssh.FingerprintSHA256(key)
+		return nil
+	}
+}
+
+// Fingerprint returns the SHA256 fingerprint of the accepted host key, or an
+// empty string when no connection has been made yet.
+func (r *HostKeyRecorder) Fingerprint() string {
+	r.Lock()
+	defer r.Unlock()
+	return r.fingerprint
+}
@@ -15,18 +15,20 @@ import (
 	"net"
 	"os"
 
+	"github.com/cloudfoundry/bosh-backup-and-restore/tracing"
 	boshhttp "github.com/cloudfoundry/bosh-utils/httpclient"
 	proxy "github.com/cloudfoundry/socks5-proxy"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/codes"
 	"golang.org/x/crypto/ssh"
 )
 
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
 //counterfeiter:generate -o fakes/fake_ssh_connection.go . SSHConnection
 type SSHConnection interface {
-	Stream(cmd string, writer io.Writer) ([]byte, int, error)
-	StreamStdin(cmd string, reader io.Reader) ([]byte, []byte, int, error)
-	Run(cmd string) ([]byte, []byte, int, error)
+	Stream(ctx context.Context, cmd string, writer io.Writer) ([]byte, int, error)
+	StreamStdin(ctx context.Context, cmd string, reader io.Reader) ([]byte, []byte, int, error)
+	Run(ctx context.Context, cmd string) ([]byte, []byte, int, error)
 	Username() string
 }
 
@@ -45,27 +47,43 @@ func NewConnection(hostName, userName, privateKey string, publicKeyCallback ssh.
 }
 
 func NewConnectionWithServerAliveInterval(hostName, userName, privateKey string, publicKeyCallback ssh.HostKeyCallback, publicKeyAlgorithm []string, serverAliveInterval time.Duration, logger Logger) (SSHConnection, error) {
+	return newConnectionWithPrivateKey(hostName, userName, privateKey, publicKeyCallback, publicKeyAlgorithm, serverAliveInterval, nil, logger)
+}
+
+func newConnectionWithPrivateKey(hostName, userName, privateKey string, publicKeyCallback ssh.HostKeyCallback, publicKeyAlgorithm []string, serverAliveInterval time.Duration, dial boshhttp.DialContextFunc, logger Logger) (SSHConnection, error) {
 	parsedPrivateKey, err := ssh.ParsePrivateKey([]byte(privateKey))
 	if err != nil {
 		return nil, errors.Wrap(err, "ssh.NewConnection.ParsePrivateKey failed")
 	}
 
-	conn := Connection{
+	return newConnection(hostName, userName, []ssh.AuthMethod{ssh.PublicKeys(parsedPrivateKey)}, publicKeyCallback, publicKeyAlgorithm, serverAliveInterval, dial, logger), nil
+}
+
+// NewConnectionWithAuth connects with any of the given auth methods, such as
+// those built from Credentials, rather than with a single private key.
+func NewConnectionWithAuth(hostName, userName string, authMethods []ssh.AuthMethod, publicKeyCallback ssh.HostKeyCallback, publicKeyAlgorithm []string, logger Logger) SSHConnection {
+	return newConnection(hostName, userName, authMethods, publicKeyCallback, publicKeyAlgorithm, 60, nil, logger)
+}
+
+// newConnection dials hostName with dial, or directly or through
+// BOSH_ALL_PROXY when dial is nil.
+func newConnection(hostName, userName string, authMethods []ssh.AuthMethod, publicKeyCallback ssh.HostKeyCallback, publicKeyAlgorithm []string, serverAliveInterval time.Duration, dial boshhttp.DialContextFunc, logger Logger) Connection {
+	if dial == nil {
+		dial = createDialContextFunc()
+	}
+
+	return Connection{
 		host: defaultToSSHPort(hostName),
 		sshConfig: &ssh.ClientConfig{
-			User: userName,
-			Auth: []ssh.AuthMethod{
-				ssh.PublicKeys(parsedPrivateKey),
-			},
+			User:              userName,
+			Auth:              authMethods,
 			HostKeyCallback:   publicKeyCallback,
 			HostKeyAlgorithms: publicKeyAlgorithm,
 		},
 		logger:              logger,
 		serverAliveInterval: serverAliveInterval,
-		dialFunc:            createDialContextFunc(),
+		dialFunc:            dial,
 	}
-
-	return conn, nil
 }
 
 type Connection struct {
@@ -76,27 +94,29 @@ type Connection struct {
 	dialFunc            boshhttp.DialContextFunc
 }
 
-func (c Connection) Run(cmd string) (stdout, stderr []byte, exitCode int, err error) {
+func (c Connection) Run(ctx context.Context, cmd string) (stdout, stderr []byte, exitCode int, err error) {
 	stdoutBuffer := bytes.NewBuffer([]byte{})
 
-	stderr, exitCode, err = c.Stream(cmd, stdoutBuffer)
+	stderr, exitCode, err = c.Stream(ctx, cmd, stdoutBuffer)
 
 	return stdoutBuffer.Bytes(), stderr, exitCode, errors.Wrap(err, "ssh.Run failed")
 }
 
-func (c Connection) Stream(cmd string, stdoutWriter io.Writer) (stderr []byte, exitCode int, err error) {
+// Stream runs cmd on the remote, writing its stdout to stdoutWriter. When ctx
+// is done before cmd exits, cmd is sent SIGTERM and the session is closed.
+func (c Connection) Stream(ctx context.Context, cmd string, stdoutWriter io.Writer) (stderr []byte, exitCode int, err error) {
 	errBuffer := bytes.NewBuffer([]byte{})
 
-	exitCode, err = c.runInSession(cmd, stdoutWriter, errBuffer, nil)
+	exitCode, err = c.runInSession(ctx, cmd, stdoutWriter, errBuffer, nil)
 
 	return errBuffer.Bytes(), exitCode, errors.Wrap(err, "ssh.Stream failed")
 }
 
-func (c Connection) StreamStdin(cmd string, stdinReader io.Reader) (stdout, stderr []byte, exitCode int, err error) {
+func (c Connection) StreamStdin(ctx context.Context, cmd string, stdinReader io.Reader) (stdout, stderr []byte, exitCode int, err error) {
 	stdoutBuffer := bytes.NewBuffer([]byte{})
 	stderrBuffer := bytes.NewBuffer([]byte{})
 
-	exitCode, err = c.runInSession(cmd, stdoutBuffer, stderrBuffer, stdinReader)
+	exitCode, err = c.runInSession(ctx, cmd, stdoutBuffer, stderrBuffer, stdinReader)
 
 	return stdoutBuffer.Bytes(), stderrBuffer.Bytes(), exitCode, errors.Wrap(err, "ssh.StreamStdin failed")
 }
@@ -116,14 +136,15 @@ func (w *sessionClosingOnErrorWriter) Write(data []byte) (int, error) {
 	return n, err
 }
 
-func (c Connection) newClient() (*ssh.Client, error) {
-	conn, err := c.dialFunc(context.Background(), "tcp", c.host)
+func (c Connection) newClient(ctx context.Context) (*ssh.Client, error) {
+	conn, err := c.dialFunc(ctx, "tcp", c.host)
 	if err != nil {
 		return nil, err
 	}
 
 	client, chans, reqs, err := ssh.NewClientConn(conn, c.host, c.sshConfig)
 	if err != nil {
+		conn.Close() //nolint:errcheck
 		return nil, err
 	}
 
@@ -142,8 +163,10 @@ func createDialContextFunc() boshhttp.DialContextFunc {
 	dialFuncMutex.Lock()
 	defer dialFuncMutex.Unlock()
 
-	socksProxy := proxy.NewSocks5Proxy(proxy.NewHostKey(), log.New(os.Stdout, "sock5-proxy", log.LstdFlags), 60*time.Second)
-	dialFunc = boshhttp.SOCKS5DialContextFuncFromEnvironment(&net.Dialer{}, socksProxy)
+	if dialFunc == nil {
+		socksProxy := proxy.NewSocks5Proxy(proxy.NewHostKey(), log.New(os.Stdout, "sock5-proxy", log.LstdFlags), 60*time.Second)
+		dialFunc = boshhttp.SOCKS5DialContextFuncFromEnvironment(&net.Dialer{}, socksProxy)
+	}
 	return dialFunc
 }
 
@@ -151,6 +174,7 @@ func createDialContextFunc() boshhttp.DialContextFunc {
 type SSHSession interface {
 	Run(cmd string) error
 	SendRequest(name string, wantReply bool, payload []byte) (bool, error)
+	Signal(sig ssh.Signal) error
 	Close() error
 }
 
@@ -171,8 +195,43 @@ func buildSSHSessionImpl(client *ssh.Client, stdin io.Reader, stdout, stderr io.
 
 var buildSSHSession = buildSSHSessionImpl
 
-func (c Connection) runInSession(cmd string, stdout, stderr io.Writer, stdin io.Reader) (int, error) {
-	client, err := c.newClient()
+func (c Connection) runInSession(ctx context.Context, cmd string, stdout, stderr io.Writer, stdin io.Reader) (int, error) {
+	ctx, span := tracing.Start(ctx, "ssh command",
+		tracing.HostKey.String(c.host),
+		tracing.UserKey.String(c.sshConfig.User),
+		tracing.CommandKey.String(commandName(cmd)),
+	)
+
+	exitCode, err := c.execInSession(ctx, cmd, stdout, stderr, stdin)
+	if err == nil && exitCode != 0 {
+		span.SetStatus(codes.Error, fmt.Sprintf("exit code %d", exitCode))
+	}
+	tracing.End(span, err)
+
+	return exitCode, err
+}
+
+// commandName is the name of the executable that cmd runs, leaving out sudo,
+// the environment variables, the directory and the arguments, so that traces
+// do not record script paths or values passed to the command.
+func commandName(cmd string) string {
+	for _, word := range strings.Fields(cmd) {
+		if word == "sudo" || strings.HasPrefix(word, "-") || isEnvAssignment(word) {
+			continue
+		}
+		word = strings.Trim(word, `"'`)
+		return word[strings.LastIndexAny(word, `/\`)+1:]
+	}
+	return ""
+}
+
+func isEnvAssignment(word string) bool {
+	name, _, found := strings.Cut(word, "=")
+	return found && name != "" && !strings.ContainsAny(name, `/\"'`)
+}
+
+func (c Connection) execInSession(ctx context.Context, cmd string, stdout, stderr io.Writer, stdin io.Reader) (int, error) {
+	client, err := c.newClient(ctx)
 	if err != nil {
 		return -1, errors.Wrap(err, "ssh.Dial failed")
 	}
@@ -190,8 +249,19 @@ func (c Connection) runInSession(cmd string, stdout, stderr io.Writer, stdin io.
 	stopKeepAliveLoop := c.startKeepAliveLoop(session)
 	defer close(stopKeepAliveLoop)
 
+	stopKillingOnCancel := context.AfterFunc(ctx, func() {
+		c.logger.Debug("bbr", "Stopping '%s' on remote: %s", cmd, context.Cause(ctx))
+		session.Signal(ssh.SIGTERM) //nolint:errcheck
+		session.Close()             //nolint:errcheck
+	})
+	defer stopKillingOnCancel()
+
 	err = session.Run(cmd)
 
+	if err != nil && ctx.Err() != nil {
+		return -1, errors.Wrap(context.Cause(ctx), "ssh session stopped")
+	}
+
 	if stdoutWrappingWriter.writerError != nil {
 		return -1, errors.Wrap(stdoutWrappingWriter.writerError, "stdout.Write failed")
 	}
@@ -2,6 +2,7 @@ package ssh_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/base64"
 	"errors"
 	"io"
@@ -83,7 +84,7 @@ var _ = Describe("Connection", func() {
 
 			JustBeforeEach(func() {
 				Expect(connErr).NotTo(HaveOccurred())
-				stdOut, stdErr, exitCode, runError = conn.StreamStdin(command, reader)
+				stdOut, stdErr, exitCode, runError = conn.StreamStdin(context.Background(), command, reader)
 			})
 
 			BeforeEach(func() {
@@ -96,7 +97,7 @@ var _ = Describe("Connection", func() {
 			})
 
 			It("reads stdout from the reader", func() {
-				stdout, _, _, _ := conn.Run("cat /tmp/foo") //nolint:errcheck
+				stdout, _, _, _ := conn.Run(context.Background(), "cat /tmp/foo") //nolint:errcheck
 				Expect(string(stdout)).To(Equal("I am from the reader"))
 			})
 
@@ -120,7 +121,7 @@ var _ = Describe("Connection", func() {
 			var command string
 			JustBeforeEach(func() {
 				Expect(connErr).NotTo(HaveOccurred())
-				stdErr, exitCode, runError = conn.Stream(command, stdout)
+				stdErr, exitCode, runError = conn.Stream(context.Background(), command, stdout)
 			})
 			Context("success", func() {
 				BeforeEach(func() {
@@ -152,11 +153,13 @@ var _ = Describe("Connection", func() {
 			var exitCode int
 			var runError error
 			var command string
+			var ctx context.Context
 			JustBeforeEach(func() {
 				Expect(connErr).NotTo(HaveOccurred())
-				stdOut, stdErr, exitCode, runError = conn.Run(command)
+				stdOut, stdErr, exitCode, runError = conn.Run(ctx, command)
 			})
 			BeforeEach(func() {
+				ctx = context.Background()
 				command = "/tmp/foo"
 				instance1.CreateScript(command, `#!/usr/bin/env sh
 				echo "stdout"
@@ -181,9 +184,9 @@ var _ = Describe("Connection", func() {
 			Context("running multiple commands", func() {
 
 				It("does not fail", func() {
-					_, _, _, runError1 := conn.Run("ls")
-					_, _, _, runError2 := conn.Run("ls")
-					_, _, _, runError3 := conn.Run("ls")
+					_, _, _, runError1 := conn.Run(context.Background(), "ls")
+					_, _, _, runError2 := conn.Run(context.Background(), "ls")
+					_, _, _, runError3 := conn.Run(context.Background(), "ls")
 
 					Expect(runError1).NotTo(HaveOccurred())
 					Expect(runError2).NotTo(HaveOccurred())
@@ -240,6 +243,38 @@ var _ = Describe("Connection", func() {
 					Expect(msg).To(ContainSubstring("Did the network just fail? It looks like my ssh session to %s ended suddenly without getting an exit status from the remote VM", hostname))
 				})
 			})
+
+			When("the context is cancelled before the command finishes", func() {
+				var fakeSSHSession *fakes.FakeSSHSession
+
+				BeforeEach(func() {
+					var cancel context.CancelCauseFunc
+					ctx, cancel = context.WithCancelCause(context.Background())
+
+					closed := make(chan struct{})
+					fakeSSHSession = new(fakes.FakeSSHSession)
+					fakeSSHSession.CloseStub = func() error {
+						close(closed)
+						return nil
+					}
+					fakeSSHSession.RunStub = func(string) error {
+						cancel(errors.New("received terminated"))
+						<-closed
+						return new(gossh.ExitMissingError)
+					}
+					ssh.InjectBuildSSHSession(func(client *gossh.Client, stdin io.Reader, stdout, stderr io.Writer) (ssh.SSHSession, error) {
+						return fakeSSHSession, nil
+					})
+				})
+
+				It("stops the command and returns the cause of the cancellation", func() {
+					Expect(fakeSSHSession.SignalCallCount()).To(Equal(1))
+					Expect(fakeSSHSession.SignalArgsForCall(0)).To(Equal(gossh.SIGTERM))
+					Expect(fakeSSHSession.CloseCallCount()).To(Equal(1))
+					Expect(runError).To(MatchError(ContainSubstring("received terminated")))
+					Expect(exitCode).To(Equal(-1))
+				})
+			})
 		})
 	})
 
@@ -253,7 +288,7 @@ var _ = Describe("Connection", func() {
 
 			Context("Run", func() {
 				JustBeforeEach(func() {
-					_, _, _, err = conn.Run("ls")
+					_, _, _, err = conn.Run(context.Background(), "ls")
 				})
 
 				It("fails", func() {
@@ -263,7 +298,7 @@ var _ = Describe("Connection", func() {
 
 			Context("Stream", func() {
 				JustBeforeEach(func() {
-					_, _, err = conn.Stream("ls", bytes.NewBufferString("dont matter"))
+					_, _, err = conn.Stream(context.Background(), "ls", bytes.NewBufferString("dont matter"))
 				})
 
 				It("fails", func() {
@@ -273,7 +308,7 @@ var _ = Describe("Connection", func() {
 
 			Context("StreamStdin", func() {
 				JustBeforeEach(func() {
-					_, _, _, err = conn.StreamStdin("ls", bytes.NewBufferString("dont matter"))
+					_, _, _, err = conn.StreamStdin(context.Background(), "ls", bytes.NewBufferString("dont matter"))
 				})
 
 				It("fails", func() {
@@ -291,7 +326,7 @@ var _ = Describe("Connection", func() {
 
 			Context("Run", func() {
 				JustBeforeEach(func() {
-					_, _, _, err = conn.Run("ls")
+					_, _, _, err = conn.Run(context.Background(), "ls")
 				})
 
 				It("fails", func() {
@@ -301,7 +336,7 @@ var _ = Describe("Connection", func() {
 
 			Context("Stream", func() {
 				JustBeforeEach(func() {
-					_, _, err = conn.Stream("ls", bytes.NewBufferString("dont matter"))
+					_, _, err = conn.Stream(context.Background(), "ls", bytes.NewBufferString("dont matter"))
 				})
 
 				It("fails", func() {
@@ -311,7 +346,7 @@ var _ = Describe("Connection", func() {
 
 			Context("StreamStdin", func() {
 				JustBeforeEach(func() {
-					_, _, _, err = conn.StreamStdin("ls", bytes.NewBufferString("dont matter"))
+					_, _, _, err = conn.StreamStdin(context.Background(), "ls", bytes.NewBufferString("dont matter"))
 				})
 
 				It("fails", func() {
@@ -332,7 +367,7 @@ var _ = Describe("Connection", func() {
 			conn, connErr = ssh.NewConnectionWithServerAliveInterval(hostname, user, privateKey, gossh.FixedHostKey(hostPublicKey), []string{"rsa-sha2-256"}, 1, logger)
 			Expect(connErr).NotTo(HaveOccurred())
 
-			stdOut, _, _, _ = conn.Run("/tmp/produce") //nolint:errcheck
+			stdOut, _, _, _ = conn.Run(context.Background(), "/tmp/produce") //nolint:errcheck
 		})
 
 		It("keeps the connection alive", func() {
@@ -362,7 +397,7 @@ var _ = Describe("Connection", func() {
 				rapidKeepAliveSignalInterval,
 				logger)
 			Expect(connErr).NotTo(HaveOccurred())
-			stdErr, _, runError = conn.Stream(command, stdout)
+			stdErr, _, runError = conn.Stream(context.Background(), command, stdout)
 		})
 
 		It("does not hang forever", func() {
@@ -397,3 +432,16 @@ func publicKeyForDocker(privateKey string) string {
 
 	return "ssh-rsa " + base64.StdEncoding.EncodeToString(parsedPrivateKey.PublicKey().Marshal())
 }
+
+var _ = Describe("CommandName", func() {
+	DescribeTable("keeps only the name of the executable",
+		func(cmd, name string) {
+			Expect(ssh.CommandName(cmd)).To(Equal(name))
+		},
+		Entry("a script run with environment variables", "sudo ARTIFACT_DIRECTORY=/var/vcap/store/bbr-backup/redis/ BBR_VERSION=1.9.0 /var/vcap/jobs/redis/bin/bbr/backup", "backup"),
+		Entry("a command with arguments", "sudo tar -C /var/vcap/store/bbr-backup -c .", "tar"),
+		Entry("a shell running a quoted script", "sudo sh -c 'cd /var/vcap/store/bbr-backup && find . -type f'", "sh"),
+		Entry("a Windows script", `C:\var\vcap\jobs\redis\bin\bbr\backup.ps1`, "backup.ps1"),
+		Entry("an empty command", "", ""),
+	)
+})
@@ -7,3 +7,5 @@ func InjectBuildSSHSession(builder SSHSessionBuilder) {
 func ResetBuildSSHSession() {
 	buildSSHSession = buildSSHSessionImpl
 }
+
+var CommandName = commandName
@@ -2,6 +2,7 @@
 package fakes
 
 import (
+	"context"
 	"io"
 	"sync"
 
@@ -9,11 +10,12 @@ import (
 )
 
 type FakeRemoteRunner struct {
-	ArchiveAndDownloadStub        func(string, io.Writer) error
+	ArchiveAndDownloadStub        func(context.Context, string, io.Writer) error
 	archiveAndDownloadMutex       sync.RWMutex
 	archiveAndDownloadArgsForCall []struct {
-		arg1 string
-		arg2 io.Writer
+		arg1 context.Context
+		arg2 string
+		arg3 io.Writer
 	}
 	archiveAndDownloadReturns struct {
 		result1 error
@@ -21,10 +23,25 @@ type FakeRemoteRunner struct {
 	archiveAndDownloadReturnsOnCall map[int]struct {
 		result1 error
 	}
-	ChecksumDirectoryStub        func(string) (map[string]string, error)
+	ArchiveFilesAndDownloadStub        func(context.Context, string, []string, io.Writer) error
+	archiveFilesAndDownloadMutex       sync.RWMutex
+	archiveFilesAndDownloadArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 []string
+		arg4 io.Writer
+	}
+	archiveFilesAndDownloadReturns struct {
+		result1 error
+	}
+	archiveFilesAndDownloadReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ChecksumDirectoryStub        func(context.Context, string) (map[string]string, error)
 	checksumDirectoryMutex       sync.RWMutex
 	checksumDirectoryArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
+		arg2 string
 	}
 	checksumDirectoryReturns struct {
 		result1 map[string]string
@@ -44,10 +61,11 @@ type FakeRemoteRunner struct {
 	connectedUsernameReturnsOnCall map[int]struct {
 		result1 string
 	}
-	CreateDirectoryStub        func(string) error
+	CreateDirectoryStub        func(context.Context, string) error
 	createDirectoryMutex       sync.RWMutex
 	createDirectoryArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
+		arg2 string
 	}
 	createDirectoryReturns struct {
 		result1 error
@@ -55,10 +73,11 @@ type FakeRemoteRunner struct {
 	createDirectoryReturnsOnCall map[int]struct {
 		result1 error
 	}
-	DirectoryExistsStub        func(string) (bool, error)
+	DirectoryExistsStub        func(context.Context, string) (bool, error)
 	directoryExistsMutex       sync.RWMutex
 	directoryExistsArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
+		arg2 string
 	}
 	directoryExistsReturns struct {
 		result1 bool
@@ -68,11 +87,12 @@ type FakeRemoteRunner struct {
 		result1 bool
 		result2 error
 	}
-	ExtractAndUploadStub        func(io.Reader, string) error
+	ExtractAndUploadStub        func(context.Context, io.Reader, string) error
 	extractAndUploadMutex       sync.RWMutex
 	extractAndUploadArgsForCall []struct {
-		arg1 io.Reader
-		arg2 string
+		arg1 context.Context
+		arg2 io.Reader
+		arg3 string
 	}
 	extractAndUploadReturns struct {
 		result1 error
@@ -80,10 +100,25 @@ type FakeRemoteRunner struct {
 	extractAndUploadReturnsOnCall map[int]struct {
 		result1 error
 	}
-	FindFilesStub        func(string) ([]string, error)
+	FileSizesInBytesStub        func(context.Context, string) (map[string]int, error)
+	fileSizesInBytesMutex       sync.RWMutex
+	fileSizesInBytesArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	fileSizesInBytesReturns struct {
+		result1 map[string]int
+		result2 error
+	}
+	fileSizesInBytesReturnsOnCall map[int]struct {
+		result1 map[string]int
+		result2 error
+	}
+	FindFilesStub        func(context.Context, string) ([]string, error)
 	findFilesMutex       sync.RWMutex
 	findFilesArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
+		arg2 string
 	}
 	findFilesReturns struct {
 		result1 []string
@@ -93,9 +128,24 @@ type FakeRemoteRunner struct {
 		result1 []string
 		result2 error
 	}
-	IsWindowsStub        func() (bool, error)
+	FreeSpaceInBytesStub        func(context.Context, string) (int, error)
+	freeSpaceInBytesMutex       sync.RWMutex
+	freeSpaceInBytesArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	freeSpaceInBytesReturns struct {
+		result1 int
+		result2 error
+	}
+	freeSpaceInBytesReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
+	IsWindowsStub        func(context.Context) (bool, error)
 	isWindowsMutex       sync.RWMutex
 	isWindowsArgsForCall []struct {
+		arg1 context.Context
 	}
 	isWindowsReturns struct {
 		result1 bool
@@ -105,10 +155,11 @@ type FakeRemoteRunner struct {
 		result1 bool
 		result2 error
 	}
-	RemoveDirectoryStub        func(string) error
+	RemoveDirectoryStub        func(context.Context, string) error
 	removeDirectoryMutex       sync.RWMutex
 	removeDirectoryArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
+		arg2 string
 	}
 	removeDirectoryReturns struct {
 		result1 error
@@ -116,11 +167,12 @@ type FakeRemoteRunner struct {
 	removeDirectoryReturnsOnCall map[int]struct {
 		result1 error
 	}
-	RunScriptStub        func(string, string) error
+	RunScriptStub        func(context.Context, string, string) error
 	runScriptMutex       sync.RWMutex
 	runScriptArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
+		arg3 string
 	}
 	runScriptReturns struct {
 		result1 error
@@ -128,13 +180,14 @@ type FakeRemoteRunner struct {
 	runScriptReturnsOnCall map[int]struct {
 		result1 error
 	}
-	RunScriptWithEnvStub        func(string, map[string]string, string, io.Writer) error
+	RunScriptWithEnvStub        func(context.Context, string, map[string]string, string, io.Writer) error
 	runScriptWithEnvMutex       sync.RWMutex
 	runScriptWithEnvArgsForCall []struct {
-		arg1 string
-		arg2 map[string]string
-		arg3 string
-		arg4 io.Writer
+		arg1 context.Context
+		arg2 string
+		arg3 map[string]string
+		arg4 string
+		arg5 io.Writer
 	}
 	runScriptWithEnvReturns struct {
 		result1 error
@@ -142,10 +195,11 @@ type FakeRemoteRunner struct {
 	runScriptWithEnvReturnsOnCall map[int]struct {
 		result1 error
 	}
-	SizeInBytesStub        func(string) (int, error)
+	SizeInBytesStub        func(context.Context, string) (int, error)
 	sizeInBytesMutex       sync.RWMutex
 	sizeInBytesArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
+		arg2 string
 	}
 	sizeInBytesReturns struct {
 		result1 int
@@ -155,10 +209,11 @@ type FakeRemoteRunner struct {
 		result1 int
 		result2 error
 	}
-	SizeOfStub        func(string) (string, error)
+	SizeOfStub        func(context.Context, string) (string, error)
 	sizeOfMutex       sync.RWMutex
 	sizeOfArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
+		arg2 string
 	}
 	sizeOfReturns struct {
 		result1 string
@@ -172,19 +227,20 @@ type FakeRemoteRunner struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeRemoteRunner) ArchiveAndDownload(arg1 string, arg2 io.Writer) error {
+func (fake *FakeRemoteRunner) ArchiveAndDownload(arg1 context.Context, arg2 string, arg3 io.Writer) error {
 	fake.archiveAndDownloadMutex.Lock()
 	ret, specificReturn := fake.archiveAndDownloadReturnsOnCall[len(fake.archiveAndDownloadArgsForCall)]
 	fake.archiveAndDownloadArgsForCall = append(fake.archiveAndDownloadArgsForCall, struct {
-		arg1 string
-		arg2 io.Writer
-	}{arg1, arg2})
+		arg1 context.Context
+		arg2 string
+		arg3 io.Writer
+	}{arg1, arg2, arg3})
 	stub := fake.ArchiveAndDownloadStub
 	fakeReturns := fake.archiveAndDownloadReturns
-	fake.recordInvocation("ArchiveAndDownload", []interface{}{arg1, arg2})
+	fake.recordInvocation("ArchiveAndDownload", []interface{}{arg1, arg2, arg3})
 	fake.archiveAndDownloadMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1
@@ -198,17 +254,17 @@ func (fake *FakeRemoteRunner) ArchiveAndDownloadCallCount() int {
 	return len(fake.archiveAndDownloadArgsForCall)
 }
 
-func (fake *FakeRemoteRunner) ArchiveAndDownloadCalls(stub func(string, io.Writer) error) {
+func (fake *FakeRemoteRunner) ArchiveAndDownloadCalls(stub func(context.Context, string, io.Writer) error) {
 	fake.archiveAndDownloadMutex.Lock()
 	defer fake.archiveAndDownloadMutex.Unlock()
 	fake.ArchiveAndDownloadStub = stub
 }
 
-func (fake *FakeRemoteRunner) ArchiveAndDownloadArgsForCall(i int) (string, io.Writer) {
+func (fake *FakeRemoteRunner) ArchiveAndDownloadArgsForCall(i int) (context.Context, string, io.Writer) {
 	fake.archiveAndDownloadMutex.RLock()
 	defer fake.archiveAndDownloadMutex.RUnlock()
 	argsForCall := fake.archiveAndDownloadArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeRemoteRunner) ArchiveAndDownloadReturns(result1 error) {
@@ -234,18 +290,88 @@ func (fake *FakeRemoteRunner) ArchiveAndDownloadReturnsOnCall(i int, result1 err
 	}{result1}
 }
 
-func (fake *FakeRemoteRunner) ChecksumDirectory(arg1 string) (map[string]string, error) {
+func (fake *FakeRemoteRunner) ArchiveFilesAndDownload(arg1 context.Context, arg2 string, arg3 []string, arg4 io.Writer) error {
+	var arg3Copy []string
+	if arg3 != nil {
+		arg3Copy = make([]string, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.archiveFilesAndDownloadMutex.Lock()
+	ret, specificReturn := fake.archiveFilesAndDownloadReturnsOnCall[len(fake.archiveFilesAndDownloadArgsForCall)]
+	fake.archiveFilesAndDownloadArgsForCall = append(fake.archiveFilesAndDownloadArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 []string
+		arg4 io.Writer
+	}{arg1, arg2, arg3Copy, arg4})
+	stub := fake.ArchiveFilesAndDownloadStub
+	fakeReturns := fake.archiveFilesAndDownloadReturns
+	fake.recordInvocation("ArchiveFilesAndDownload", []interface{}{arg1, arg2, arg3Copy, arg4})
+	fake.archiveFilesAndDownloadMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeRemoteRunner) ArchiveFilesAndDownloadCallCount() int {
+	fake.archiveFilesAndDownloadMutex.RLock()
+	defer fake.archiveFilesAndDownloadMutex.RUnlock()
+	return len(fake.archiveFilesAndDownloadArgsForCall)
+}
+
+func (fake *FakeRemoteRunner) ArchiveFilesAndDownloadCalls(stub func(context.Context, string, []string, io.Writer) error) {
+	fake.archiveFilesAndDownloadMutex.Lock()
+	defer fake.archiveFilesAndDownloadMutex.Unlock()
+	fake.ArchiveFilesAndDownloadStub = stub
+}
+
+func (fake *FakeRemoteRunner) ArchiveFilesAndDownloadArgsForCall(i int) (context.Context, string, []string, io.Writer) {
+	fake.archiveFilesAndDownloadMutex.RLock()
+	defer fake.archiveFilesAndDownloadMutex.RUnlock()
+	argsForCall := fake.archiveFilesAndDownloadArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeRemoteRunner) ArchiveFilesAndDownloadReturns(result1 error) {
+	fake.archiveFilesAndDownloadMutex.Lock()
+	defer fake.archiveFilesAndDownloadMutex.Unlock()
+	fake.ArchiveFilesAndDownloadStub = nil
+	fake.archiveFilesAndDownloadReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRemoteRunner) ArchiveFilesAndDownloadReturnsOnCall(i int, result1 error) {
+	fake.archiveFilesAndDownloadMutex.Lock()
+	defer fake.archiveFilesAndDownloadMutex.Unlock()
+	fake.ArchiveFilesAndDownloadStub = nil
+	if fake.archiveFilesAndDownloadReturnsOnCall == nil {
+		fake.archiveFilesAndDownloadReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.archiveFilesAndDownloadReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRemoteRunner) ChecksumDirectory(arg1 context.Context, arg2 string) (map[string]string, error) {
 	fake.checksumDirectoryMutex.Lock()
 	ret, specificReturn := fake.checksumDirectoryReturnsOnCall[len(fake.checksumDirectoryArgsForCall)]
 	fake.checksumDirectoryArgsForCall = append(fake.checksumDirectoryArgsForCall, struct {
-		arg1 string
-	}{arg1})
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
 	stub := fake.ChecksumDirectoryStub
 	fakeReturns := fake.checksumDirectoryReturns
-	fake.recordInvocation("ChecksumDirectory", []interface{}{arg1})
+	fake.recordInvocation("ChecksumDirectory", []interface{}{arg1, arg2})
 	fake.checksumDirectoryMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -259,17 +385,17 @@ func (fake *FakeRemoteRunner) ChecksumDirectoryCallCount() int {
 	return len(fake.checksumDirectoryArgsForCall)
 }
 
-func (fake *FakeRemoteRunner) ChecksumDirectoryCalls(stub func(string) (map[string]string, error)) {
+func (fake *FakeRemoteRunner) ChecksumDirectoryCalls(stub func(context.Context, string) (map[string]string, error)) {
 	fake.checksumDirectoryMutex.Lock()
 	defer fake.checksumDirectoryMutex.Unlock()
 	fake.ChecksumDirectoryStub = stub
 }
 
-func (fake *FakeRemoteRunner) ChecksumDirectoryArgsForCall(i int) string {
+func (fake *FakeRemoteRunner) ChecksumDirectoryArgsForCall(i int) (context.Context, string) {
 	fake.checksumDirectoryMutex.RLock()
 	defer fake.checksumDirectoryMutex.RUnlock()
 	argsForCall := fake.checksumDirectoryArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeRemoteRunner) ChecksumDirectoryReturns(result1 map[string]string, result2 error) {
@@ -351,18 +477,19 @@ func (fake *FakeRemoteRunner) ConnectedUsernameReturnsOnCall(i int, result1 stri
 	}{result1}
 }
 
-func (fake *FakeRemoteRunner) CreateDirectory(arg1 string) error {
+func (fake *FakeRemoteRunner) CreateDirectory(arg1 context.Context, arg2 string) error {
 	fake.createDirectoryMutex.Lock()
 	ret, specificReturn := fake.createDirectoryReturnsOnCall[len(fake.createDirectoryArgsForCall)]
 	fake.createDirectoryArgsForCall = append(fake.createDirectoryArgsForCall, struct {
-		arg1 string
-	}{arg1})
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
 	stub := fake.CreateDirectoryStub
 	fakeReturns := fake.createDirectoryReturns
-	fake.recordInvocation("CreateDirectory", []interface{}{arg1})
+	fake.recordInvocation("CreateDirectory", []interface{}{arg1, arg2})
 	fake.createDirectoryMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
@@ -376,17 +503,17 @@ func (fake *FakeRemoteRunner) CreateDirectoryCallCount() int {
 	return len(fake.createDirectoryArgsForCall)
 }
 
-func (fake *FakeRemoteRunner) CreateDirectoryCalls(stub func(string) error) {
+func (fake *FakeRemoteRunner) CreateDirectoryCalls(stub func(context.Context, string) error) {
 	fake.createDirectoryMutex.Lock()
 	defer fake.createDirectoryMutex.Unlock()
 	fake.CreateDirectoryStub = stub
 }
 
-func (fake *FakeRemoteRunner) CreateDirectoryArgsForCall(i int) string {
+func (fake *FakeRemoteRunner) CreateDirectoryArgsForCall(i int) (context.Context, string) {
 	fake.createDirectoryMutex.RLock()
 	defer fake.createDirectoryMutex.RUnlock()
 	argsForCall := fake.createDirectoryArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeRemoteRunner) CreateDirectoryReturns(result1 error) {
@@ -412,18 +539,19 @@ func (fake *FakeRemoteRunner) CreateDirectoryReturnsOnCall(i int, result1 error)
 	}{result1}
 }
 
-func (fake *FakeRemoteRunner) DirectoryExists(arg1 string) (bool, error) {
+func (fake *FakeRemoteRunner) DirectoryExists(arg1 context.Context, arg2 string) (bool, error) {
 	fake.directoryExistsMutex.Lock()
 	ret, specificReturn := fake.directoryExistsReturnsOnCall[len(fake.directoryExistsArgsForCall)]
 	fake.directoryExistsArgsForCall = append(fake.directoryExistsArgsForCall, struct {
-		arg1 string
-	}{arg1})
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
 	stub := fake.DirectoryExistsStub
 	fakeReturns := fake.directoryExistsReturns
-	fake.recordInvocation("DirectoryExists", []interface{}{arg1})
+	fake.recordInvocation("DirectoryExists", []interface{}{arg1, arg2})
 	fake.directoryExistsMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -437,17 +565,17 @@ func (fake *FakeRemoteRunner) DirectoryExistsCallCount() int {
 	return len(fake.directoryExistsArgsForCall)
 }
 
-func (fake *FakeRemoteRunner) DirectoryExistsCalls(stub func(string) (bool, error)) {
+func (fake *FakeRemoteRunner) DirectoryExistsCalls(stub func(context.Context, string) (bool, error)) {
 	fake.directoryExistsMutex.Lock()
 	defer fake.directoryExistsMutex.Unlock()
 	fake.DirectoryExistsStub = stub
 }
 
-func (fake *FakeRemoteRunner) DirectoryExistsArgsForCall(i int) string {
+func (fake *FakeRemoteRunner) DirectoryExistsArgsForCall(i int) (context.Context, string) {
 	fake.directoryExistsMutex.RLock()
 	defer fake.directoryExistsMutex.RUnlock()
 	argsForCall := fake.directoryExistsArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeRemoteRunner) DirectoryExistsReturns(result1 bool, result2 error) {
@@ -476,19 +604,20 @@ func (fake *FakeRemoteRunner) DirectoryExistsReturnsOnCall(i int, result1 bool,
 	}{result1, result2}
 }
 
-func (fake *FakeRemoteRunner) ExtractAndUpload(arg1 io.Reader, arg2 string) error {
+func (fake *FakeRemoteRunner) ExtractAndUpload(arg1 context.Context, arg2 io.Reader, arg3 string) error {
 	fake.extractAndUploadMutex.Lock()
 	ret, specificReturn := fake.extractAndUploadReturnsOnCall[len(fake.extractAndUploadArgsForCall)]
 	fake.extractAndUploadArgsForCall = append(fake.extractAndUploadArgsForCall, struct {
-		arg1 io.Reader
-		arg2 string
-	}{arg1, arg2})
+		arg1 context.Context
+		arg2 io.Reader
+		arg3 string
+	}{arg1, arg2, arg3})
 	stub := fake.ExtractAndUploadStub
 	fakeReturns := fake.extractAndUploadReturns
-	fake.recordInvocation("ExtractAndUpload", []interface{}{arg1, arg2})
+	fake.recordInvocation("ExtractAndUpload", []interface{}{arg1, arg2, arg3})
 	fake.extractAndUploadMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1
@@ -502,17 +631,17 @@ func (fake *FakeRemoteRunner) ExtractAndUploadCallCount() int {
 	return len(fake.extractAndUploadArgsForCall)
 }
 
-func (fake *FakeRemoteRunner) ExtractAndUploadCalls(stub func(io.Reader, string) error) {
+func (fake *FakeRemoteRunner) ExtractAndUploadCalls(stub func(context.Context, io.Reader, string) error) {
 	fake.extractAndUploadMutex.Lock()
 	defer fake.extractAndUploadMutex.Unlock()
 	fake.ExtractAndUploadStub = stub
 }
 
-func (fake *FakeRemoteRunner) ExtractAndUploadArgsForCall(i int) (io.Reader, string) {
+func (fake *FakeRemoteRunner) ExtractAndUploadArgsForCall(i int) (context.Context, io.Reader, string) {
 	fake.extractAndUploadMutex.RLock()
 	defer fake.extractAndUploadMutex.RUnlock()
 	argsForCall := fake.extractAndUploadArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeRemoteRunner) ExtractAndUploadReturns(result1 error) {
@@ -538,18 +667,84 @@ func (fake *FakeRemoteRunner) ExtractAndUploadReturnsOnCall(i int, result1 error
 	}{result1}
 }
 
-func (fake *FakeRemoteRunner) FindFiles(arg1 string) ([]string, error) {
+func (fake *FakeRemoteRunner) FileSizesInBytes(arg1 context.Context, arg2 string) (map[string]int, error) {
+	fake.fileSizesInBytesMutex.Lock()
+	ret, specificReturn := fake.fileSizesInBytesReturnsOnCall[len(fake.fileSizesInBytesArgsForCall)]
+	fake.fileSizesInBytesArgsForCall = append(fake.fileSizesInBytesArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.FileSizesInBytesStub
+	fakeReturns := fake.fileSizesInBytesReturns
+	fake.recordInvocation("FileSizesInBytes", []interface{}{arg1, arg2})
+	fake.fileSizesInBytesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRemoteRunner) FileSizesInBytesCallCount() int {
+	fake.fileSizesInBytesMutex.RLock()
+	defer fake.fileSizesInBytesMutex.RUnlock()
+	return len(fake.fileSizesInBytesArgsForCall)
+}
+
+func (fake *FakeRemoteRunner) FileSizesInBytesCalls(stub func(context.Context, string) (map[string]int, error)) {
+	fake.fileSizesInBytesMutex.Lock()
+	defer fake.fileSizesInBytesMutex.Unlock()
+	fake.FileSizesInBytesStub = stub
+}
+
+func (fake *FakeRemoteRunner) FileSizesInBytesArgsForCall(i int) (context.Context, string) {
+	fake.fileSizesInBytesMutex.RLock()
+	defer fake.fileSizesInBytesMutex.RUnlock()
+	argsForCall := fake.fileSizesInBytesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeRemoteRunner) FileSizesInBytesReturns(result1 map[string]int, result2 error) {
+	fake.fileSizesInBytesMutex.Lock()
+	defer fake.fileSizesInBytesMutex.Unlock()
+	fake.FileSizesInBytesStub = nil
+	fake.fileSizesInBytesReturns = struct {
+		result1 map[string]int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRemoteRunner) FileSizesInBytesReturnsOnCall(i int, result1 map[string]int, result2 error) {
+	fake.fileSizesInBytesMutex.Lock()
+	defer fake.fileSizesInBytesMutex.Unlock()
+	fake.FileSizesInBytesStub = nil
+	if fake.fileSizesInBytesReturnsOnCall == nil {
+		fake.fileSizesInBytesReturnsOnCall = make(map[int]struct {
+			result1 map[string]int
+			result2 error
+		})
+	}
+	fake.fileSizesInBytesReturnsOnCall[i] = struct {
+		result1 map[string]int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRemoteRunner) FindFiles(arg1 context.Context, arg2 string) ([]string, error) {
 	fake.findFilesMutex.Lock()
 	ret, specificReturn := fake.findFilesReturnsOnCall[len(fake.findFilesArgsForCall)]
 	fake.findFilesArgsForCall = append(fake.findFilesArgsForCall, struct {
-		arg1 string
-	}{arg1})
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
 	stub := fake.FindFilesStub
 	fakeReturns := fake.findFilesReturns
-	fake.recordInvocation("FindFiles", []interface{}{arg1})
+	fake.recordInvocation("FindFiles", []interface{}{arg1, arg2})
 	fake.findFilesMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -563,17 +758,17 @@ func (fake *FakeRemoteRunner) FindFilesCallCount() int {
 	return len(fake.findFilesArgsForCall)
 }
 
-func (fake *FakeRemoteRunner) FindFilesCalls(stub func(string) ([]string, error)) {
+func (fake *FakeRemoteRunner) FindFilesCalls(stub func(context.Context, string) ([]string, error)) {
 	fake.findFilesMutex.Lock()
 	defer fake.findFilesMutex.Unlock()
 	fake.FindFilesStub = stub
 }
 
-func (fake *FakeRemoteRunner) FindFilesArgsForCall(i int) string {
+func (fake *FakeRemoteRunner) FindFilesArgsForCall(i int) (context.Context, string) {
 	fake.findFilesMutex.RLock()
 	defer fake.findFilesMutex.RUnlock()
 	argsForCall := fake.findFilesArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeRemoteRunner) FindFilesReturns(result1 []string, result2 error) {
@@ -602,17 +797,83 @@ func (fake *FakeRemoteRunner) FindFilesReturnsOnCall(i int, result1 []string, re
 	}{result1, result2}
 }
 
-func (fake *FakeRemoteRunner) IsWindows() (bool, error) {
+func (fake *FakeRemoteRunner) FreeSpaceInBytes(arg1 context.Context, arg2 string) (int, error) {
+	fake.freeSpaceInBytesMutex.Lock()
+	ret, specificReturn := fake.freeSpaceInBytesReturnsOnCall[len(fake.freeSpaceInBytesArgsForCall)]
+	fake.freeSpaceInBytesArgsForCall = append(fake.freeSpaceInBytesArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.FreeSpaceInBytesStub
+	fakeReturns := fake.freeSpaceInBytesReturns
+	fake.recordInvocation("FreeSpaceInBytes", []interface{}{arg1, arg2})
+	fake.freeSpaceInBytesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRemoteRunner) FreeSpaceInBytesCallCount() int {
+	fake.freeSpaceInBytesMutex.RLock()
+	defer fake.freeSpaceInBytesMutex.RUnlock()
+	return len(fake.freeSpaceInBytesArgsForCall)
+}
+
+func (fake *FakeRemoteRunner) FreeSpaceInBytesCalls(stub func(context.Context, string) (int, error)) {
+	fake.freeSpaceInBytesMutex.Lock()
+	defer fake.freeSpaceInBytesMutex.Unlock()
+	fake.FreeSpaceInBytesStub = stub
+}
+
+func (fake *FakeRemoteRunner) FreeSpaceInBytesArgsForCall(i int) (context.Context, string) {
+	fake.freeSpaceInBytesMutex.RLock()
+	defer fake.freeSpaceInBytesMutex.RUnlock()
+	argsForCall := fake.freeSpaceInBytesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeRemoteRunner) FreeSpaceInBytesReturns(result1 int, result2 error) {
+	fake.freeSpaceInBytesMutex.Lock()
+	defer fake.freeSpaceInBytesMutex.Unlock()
+	fake.FreeSpaceInBytesStub = nil
+	fake.freeSpaceInBytesReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRemoteRunner) FreeSpaceInBytesReturnsOnCall(i int, result1 int, result2 error) {
+	fake.freeSpaceInBytesMutex.Lock()
+	defer fake.freeSpaceInBytesMutex.Unlock()
+	fake.FreeSpaceInBytesStub = nil
+	if fake.freeSpaceInBytesReturnsOnCall == nil {
+		fake.freeSpaceInBytesReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.freeSpaceInBytesReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRemoteRunner) IsWindows(arg1 context.Context) (bool, error) {
 	fake.isWindowsMutex.Lock()
 	ret, specificReturn := fake.isWindowsReturnsOnCall[len(fake.isWindowsArgsForCall)]
 	fake.isWindowsArgsForCall = append(fake.isWindowsArgsForCall, struct {
-	}{})
+		arg1 context.Context
+	}{arg1})
 	stub := fake.IsWindowsStub
 	fakeReturns := fake.isWindowsReturns
-	fake.recordInvocation("IsWindows", []interface{}{})
+	fake.recordInvocation("IsWindows", []interface{}{arg1})
 	fake.isWindowsMutex.Unlock()
 	if stub != nil {
-		return stub()
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -626,12 +887,19 @@ func (fake *FakeRemoteRunner) IsWindowsCallCount() int {
 	return len(fake.isWindowsArgsForCall)
 }
 
-func (fake *FakeRemoteRunner) IsWindowsCalls(stub func() (bool, error)) {
+func (fake *FakeRemoteRunner) IsWindowsCalls(stub func(context.Context) (bool, error)) {
 	fake.isWindowsMutex.Lock()
 	defer fake.isWindowsMutex.Unlock()
 	fake.IsWindowsStub = stub
 }
 
+func (fake *FakeRemoteRunner) IsWindowsArgsForCall(i int) context.Context {
+	fake.isWindowsMutex.RLock()
+	defer fake.isWindowsMutex.RUnlock()
+	argsForCall := fake.isWindowsArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeRemoteRunner) IsWindowsReturns(result1 bool, result2 error) {
 	fake.isWindowsMutex.Lock()
 	defer fake.isWindowsMutex.Unlock()
@@ -658,18 +926,19 @@ func (fake *FakeRemoteRunner) IsWindowsReturnsOnCall(i int, result1 bool, result
 	}{result1, result2}
 }
 
-func (fake *FakeRemoteRunner) RemoveDirectory(arg1 string) error {
+func (fake *FakeRemoteRunner) RemoveDirectory(arg1 context.Context, arg2 string) error {
 	fake.removeDirectoryMutex.Lock()
 	ret, specificReturn := fake.removeDirectoryReturnsOnCall[len(fake.removeDirectoryArgsForCall)]
 	fake.removeDirectoryArgsForCall = append(fake.removeDirectoryArgsForCall, struct {
-		arg1 string
-	}{arg1})
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
 	stub := fake.RemoveDirectoryStub
 	fakeReturns := fake.removeDirectoryReturns
-	fake.recordInvocation("RemoveDirectory", []interface{}{arg1})
+	fake.recordInvocation("RemoveDirectory", []interface{}{arg1, arg2})
 	fake.removeDirectoryMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
@@ -683,17 +952,17 @@ func (fake *FakeRemoteRunner) RemoveDirectoryCallCount() int {
 	return len(fake.removeDirectoryArgsForCall)
 }
 
-func (fake *FakeRemoteRunner) RemoveDirectoryCalls(stub func(string) error) {
+func (fake *FakeRemoteRunner) RemoveDirectoryCalls(stub func(context.Context, string) error) {
 	fake.removeDirectoryMutex.Lock()
 	defer fake.removeDirectoryMutex.Unlock()
 	fake.RemoveDirectoryStub = stub
 }
 
-func (fake *FakeRemoteRunner) RemoveDirectoryArgsForCall(i int) string {
+func (fake *FakeRemoteRunner) RemoveDirectoryArgsForCall(i int) (context.Context, string) {
 	fake.removeDirectoryMutex.RLock()
 	defer fake.removeDirectoryMutex.RUnlock()
 	argsForCall := fake.removeDirectoryArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeRemoteRunner) RemoveDirectoryReturns(result1 error) {
@@ -719,19 +988,20 @@ func (fake *FakeRemoteRunner) RemoveDirectoryReturnsOnCall(i int, result1 error)
 	}{result1}
 }
 
-func (fake *FakeRemoteRunner) RunScript(arg1 string, arg2 string) error {
+func (fake *FakeRemoteRunner) RunScript(arg1 context.Context, arg2 string, arg3 string) error {
 	fake.runScriptMutex.Lock()
 	ret, specificReturn := fake.runScriptReturnsOnCall[len(fake.runScriptArgsForCall)]
 	fake.runScriptArgsForCall = append(fake.runScriptArgsForCall, struct {
-		arg1 string
+		arg1 context.Context
 		arg2 string
-	}{arg1, arg2})
+		arg3 string
+	}{arg1, arg2, arg3})
 	stub := fake.RunScriptStub
 	fakeReturns := fake.runScriptReturns
-	fake.recordInvocation("RunScript", []interface{}{arg1, arg2})
+	fake.recordInvocation("RunScript", []interface{}{arg1, arg2, arg3})
 	fake.runScriptMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1
@@ -745,17 +1015,17 @@ func (fake *FakeRemoteRunner) RunScriptCallCount() int {
 	return len(fake.runScriptArgsForCall)
 }
 
-func (fake *FakeRemoteRunner) RunScriptCalls(stub func(string, string) error) {
+func (fake *FakeRemoteRunner) RunScriptCalls(stub func(context.Context, string, string) error) {
 	fake.runScriptMutex.Lock()
 	defer fake.runScriptMutex.Unlock()
 	fake.RunScriptStub = stub
 }
 
-func (fake *FakeRemoteRunner) RunScriptArgsForCall(i int) (string, string) {
+func (fake *FakeRemoteRunner) RunScriptArgsForCall(i int) (context.Context, string, string) {
 	fake.runScriptMutex.RLock()
 	defer fake.runScriptMutex.RUnlock()
 	argsForCall := fake.runScriptArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeRemoteRunner) RunScriptReturns(result1 error) {
@@ -781,21 +1051,22 @@ func (fake *FakeRemoteRunner) RunScriptReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeRemoteRunner) RunScriptWithEnv(arg1 string, arg2 map[string]string, arg3 string, arg4 io.Writer) error {
+func (fake *FakeRemoteRunner) RunScriptWithEnv(arg1 context.Context, arg2 string, arg3 map[string]string, arg4 string, arg5 io.Writer) error {
 	fake.runScriptWithEnvMutex.Lock()
 	ret, specificReturn := fake.runScriptWithEnvReturnsOnCall[len(fake.runScriptWithEnvArgsForCall)]
 	fake.runScriptWithEnvArgsForCall = append(fake.runScriptWithEnvArgsForCall, struct {
-		arg1 string
-		arg2 map[string]string
-		arg3 string
-		arg4 io.Writer
-	}{arg1, arg2, arg3, arg4})
+		arg1 context.Context
+		arg2 string
+		arg3 map[string]string
+		arg4 string
+		arg5 io.Writer
+	}{arg1, arg2, arg3, arg4, arg5})
 	stub := fake.RunScriptWithEnvStub
 	fakeReturns := fake.runScriptWithEnvReturns
-	fake.recordInvocation("RunScriptWithEnv", []interface{}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("RunScriptWithEnv", []interface{}{arg1, arg2, arg3, arg4, arg5})
 	fake.runScriptWithEnvMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3, arg4)
+		return stub(arg1, arg2, arg3, arg4, arg5)
 	}
 	if specificReturn {
 		return ret.result1
@@ -809,17 +1080,17 @@ func (fake *FakeRemoteRunner) RunScriptWithEnvCallCount() int {
 	return len(fake.runScriptWithEnvArgsForCall)
 }
 
-func (fake *FakeRemoteRunner) RunScriptWithEnvCalls(stub func(string, map[string]string, string, io.Writer) error) {
+func (fake *FakeRemoteRunner) RunScriptWithEnvCalls(stub func(context.Context, string, map[string]string, string, io.Writer) error) {
 	fake.runScriptWithEnvMutex.Lock()
 	defer fake.runScriptWithEnvMutex.Unlock()
 	fake.RunScriptWithEnvStub = stub
 }
 
-func (fake *FakeRemoteRunner) RunScriptWithEnvArgsForCall(i int) (string, map[string]string, string, io.Writer) {
+func (fake *FakeRemoteRunner) RunScriptWithEnvArgsForCall(i int) (context.Context, string, map[string]string, string, io.Writer) {
 	fake.runScriptWithEnvMutex.RLock()
 	defer fake.runScriptWithEnvMutex.RUnlock()
 	argsForCall := fake.runScriptWithEnvArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
 }
 
 func (fake *FakeRemoteRunner) RunScriptWithEnvReturns(result1 error) {
@@ -845,18 +1116,19 @@ func (fake *FakeRemoteRunner) RunScriptWithEnvReturnsOnCall(i int, result1 error
 	}{result1}
 }
 
-func (fake *FakeRemoteRunner) SizeInBytes(arg1 string) (int, error) {
+func (fake *FakeRemoteRunner) SizeInBytes(arg1 context.Context, arg2 string) (int, error) {
 	fake.sizeInBytesMutex.Lock()
 	ret, specificReturn := fake.sizeInBytesReturnsOnCall[len(fake.sizeInBytesArgsForCall)]
 	fake.sizeInBytesArgsForCall = append(fake.sizeInBytesArgsForCall, struct {
-		arg1 string
-	}{arg1})
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
 	stub := fake.SizeInBytesStub
 	fakeReturns := fake.sizeInBytesReturns
-	fake.recordInvocation("SizeInBytes", []interface{}{arg1})
+	fake.recordInvocation("SizeInBytes", []interface{}{arg1, arg2})
 	fake.sizeInBytesMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -870,17 +1142,17 @@ func (fake *FakeRemoteRunner) SizeInBytesCallCount() int {
 	return len(fake.sizeInBytesArgsForCall)
 }
 
-func (fake *FakeRemoteRunner) SizeInBytesCalls(stub func(string) (int, error)) {
+func (fake *FakeRemoteRunner) SizeInBytesCalls(stub func(context.Context, string) (int, error)) {
 	fake.sizeInBytesMutex.Lock()
 	defer fake.sizeInBytesMutex.Unlock()
 	fake.SizeInBytesStub = stub
 }
 
-func (fake *FakeRemoteRunner) SizeInBytesArgsForCall(i int) string {
+func (fake *FakeRemoteRunner) SizeInBytesArgsForCall(i int) (context.Context, string) {
 	fake.sizeInBytesMutex.RLock()
 	defer fake.sizeInBytesMutex.RUnlock()
 	argsForCall := fake.sizeInBytesArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeRemoteRunner) SizeInBytesReturns(result1 int, result2 error) {
@@ -909,18 +1181,19 @@ func (fake *FakeRemoteRunner) SizeInBytesReturnsOnCall(i int, result1 int, resul
 	}{result1, result2}
 }
 
-func (fake *FakeRemoteRunner) SizeOf(arg1 string) (string, error) {
+func (fake *FakeRemoteRunner) SizeOf(arg1 context.Context, arg2 string) (string, error) {
 	fake.sizeOfMutex.Lock()
 	ret, specificReturn := fake.sizeOfReturnsOnCall[len(fake.sizeOfArgsForCall)]
 	fake.sizeOfArgsForCall = append(fake.sizeOfArgsForCall, struct {
-		arg1 string
-	}{arg1})
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
 	stub := fake.SizeOfStub
 	fakeReturns := fake.sizeOfReturns
-	fake.recordInvocation("SizeOf", []interface{}{arg1})
+	fake.recordInvocation("SizeOf", []interface{}{arg1, arg2})
 	fake.sizeOfMutex.Unlock()
 	if stub != nil {
-		return stub(arg1)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -934,17 +1207,17 @@ func (fake *FakeRemoteRunner) SizeOfCallCount() int {
 	return len(fake.sizeOfArgsForCall)
 }
 
-func (fake *FakeRemoteRunner) SizeOfCalls(stub func(string) (string, error)) {
+func (fake *FakeRemoteRunner) SizeOfCalls(stub func(context.Context, string) (string, error)) {
 	fake.sizeOfMutex.Lock()
 	defer fake.sizeOfMutex.Unlock()
 	fake.SizeOfStub = stub
 }
 
-func (fake *FakeRemoteRunner) SizeOfArgsForCall(i int) string {
+func (fake *FakeRemoteRunner) SizeOfArgsForCall(i int) (context.Context, string) {
 	fake.sizeOfMutex.RLock()
 	defer fake.sizeOfMutex.RUnlock()
 	argsForCall := fake.sizeOfArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeRemoteRunner) SizeOfReturns(result1 string, result2 error) {
@@ -978,6 +1251,8 @@ func (fake *FakeRemoteRunner) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.archiveAndDownloadMutex.RLock()
 	defer fake.archiveAndDownloadMutex.RUnlock()
+	fake.archiveFilesAndDownloadMutex.RLock()
+	defer fake.archiveFilesAndDownloadMutex.RUnlock()
 	fake.checksumDirectoryMutex.RLock()
 	defer fake.checksumDirectoryMutex.RUnlock()
 	fake.connectedUsernameMutex.RLock()
@@ -988,8 +1263,12 @@ func (fake *FakeRemoteRunner) Invocations() map[string][][]interface{} {
 	defer fake.directoryExistsMutex.RUnlock()
 	fake.extractAndUploadMutex.RLock()
 	defer fake.extractAndUploadMutex.RUnlock()
+	fake.fileSizesInBytesMutex.RLock()
+	defer fake.fileSizesInBytesMutex.RUnlock()
 	fake.findFilesMutex.RLock()
 	defer fake.findFilesMutex.RUnlock()
+	fake.freeSpaceInBytesMutex.RLock()
+	defer fake.freeSpaceInBytesMutex.RUnlock()
 	fake.isWindowsMutex.RLock()
 	defer fake.isWindowsMutex.RUnlock()
 	fake.removeDirectoryMutex.RLock()
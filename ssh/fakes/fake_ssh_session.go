@@ -5,6 +5,7 @@ import (
 	"sync"
 
 	"github.com/cloudfoundry/bosh-backup-and-restore/ssh"
+	ssha "golang.org/x/crypto/ssh"
 )
 
 type FakeSSHSession struct {
@@ -44,6 +45,17 @@ type FakeSSHSession struct {
 		result1 bool
 		result2 error
 	}
+	SignalStub        func(ssha.Signal) error
+	signalMutex       sync.RWMutex
+	signalArgsForCall []struct {
+		arg1 ssha.Signal
+	}
+	signalReturns struct {
+		result1 error
+	}
+	signalReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -233,6 +245,67 @@ func (fake *FakeSSHSession) SendRequestReturnsOnCall(i int, result1 bool, result
 	}{result1, result2}
 }
 
+func (fake *FakeSSHSession) Signal(arg1 ssha.Signal) error {
+	fake.signalMutex.Lock()
+	ret, specificReturn := fake.signalReturnsOnCall[len(fake.signalArgsForCall)]
+	fake.signalArgsForCall = append(fake.signalArgsForCall, struct {
+		arg1 ssha.Signal
+	}{arg1})
+	stub := fake.SignalStub
+	fakeReturns := fake.signalReturns
+	fake.recordInvocation("Signal", []interface{}{arg1})
+	fake.signalMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeSSHSession) SignalCallCount() int {
+	fake.signalMutex.RLock()
+	defer fake.signalMutex.RUnlock()
+	return len(fake.signalArgsForCall)
+}
+
+func (fake *FakeSSHSession) SignalCalls(stub func(ssha.Signal) error) {
+	fake.signalMutex.Lock()
+	defer fake.signalMutex.Unlock()
+	fake.SignalStub = stub
+}
+
+func (fake *FakeSSHSession) SignalArgsForCall(i int) ssha.Signal {
+	fake.signalMutex.RLock()
+	defer fake.signalMutex.RUnlock()
+	argsForCall := fake.signalArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeSSHSession) SignalReturns(result1 error) {
+	fake.signalMutex.Lock()
+	defer fake.signalMutex.Unlock()
+	fake.SignalStub = nil
+	fake.signalReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSSHSession) SignalReturnsOnCall(i int, result1 error) {
+	fake.signalMutex.Lock()
+	defer fake.signalMutex.Unlock()
+	fake.SignalStub = nil
+	if fake.signalReturnsOnCall == nil {
+		fake.signalReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.signalReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeSSHSession) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -242,6 +315,8 @@ func (fake *FakeSSHSession) Invocations() map[string][][]interface{} {
 	defer fake.runMutex.RUnlock()
 	fake.sendRequestMutex.RLock()
 	defer fake.sendRequestMutex.RUnlock()
+	fake.signalMutex.RLock()
+	defer fake.signalMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -0,0 +1,133 @@
+package ssh
+
+import (
+	"context"
+	"net"
+
+	boshhttp "github.com/cloudfoundry/bosh-utils/httpclient"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ssh"
+)
+
+// Hop is one jump host of a ProxyJump chain, with its own credentials and
+// host key verification.
+type Hop struct {
+	Address           string
+	User              string
+	AuthMethods       []ssh.AuthMethod
+	HostKeyCallback   ssh.HostKeyCallback
+	HostKeyAlgorithms []string
+}
+
+// JumpRoute sends connections to addresses within Networks through Hops, in
+// order, like `ssh -J`. A route without networks applies to every address.
+type JumpRoute struct {
+	Networks []*net.IPNet
+	Hops     []Hop
+}
+
+func (r JumpRoute) matches(address string) bool {
+	if len(r.Networks) == 0 {
+		return true
+	}
+
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		host = address
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+
+	for _, network := range r.Networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// ProxyJumpDialContextFuncFromRoutes dials through the first of routes that
+// matches the address. Other addresses, and the first hop of each route, are
+// dialled as before, through BOSH_ALL_PROXY when it is set. It returns nil
+// when there are no routes, so that connections are dialled as before.
+func ProxyJumpDialContextFuncFromRoutes(routes []JumpRoute) boshhttp.DialContextFunc {
+	if len(routes) == 0 {
+		return nil
+	}
+
+	return ProxyJumpDialContextFunc(routes, createDialContextFunc())
+}
+
+func ProxyJumpDialContextFunc(routes []JumpRoute, direct boshhttp.DialContextFunc) boshhttp.DialContextFunc {
+	return func(ctx context.Context, network, address string) (net.Conn, error) {
+		for _, route := range routes {
+			if route.matches(address) {
+				return dialThroughHops(ctx, route.Hops, direct, network, address)
+			}
+		}
+
+		return direct(ctx, network, address)
+	}
+}
+
+func dialThroughHops(ctx context.Context, hops []Hop, direct boshhttp.DialContextFunc, network, address string) (net.Conn, error) {
+	var clients []*ssh.Client
+	closeClients := func() {
+		for i := len(clients) - 1; i >= 0; i-- {
+			clients[i].Close() //nolint:errcheck
+		}
+	}
+
+	dial := direct
+	for _, hop := range hops {
+		hopAddress := defaultToSSHPort(hop.Address)
+
+		conn, err := dial(ctx, "tcp", hopAddress)
+		if err != nil {
+			closeClients()
+			return nil, errors.Wrapf(err, "failed to reach jump host %s", hop.Address)
+		}
+
+		clientConn, chans, reqs, err := ssh.NewClientConn(conn, hopAddress, &ssh.ClientConfig{
+			User:              hop.User,
+			Auth:              hop.AuthMethods,
+			HostKeyCallback:   hop.HostKeyCallback,
+			HostKeyAlgorithms: hop.HostKeyAlgorithms,
+		})
+		if err != nil {
+			conn.Close() //nolint:errcheck
+			closeClients()
+			return nil, errors.Wrapf(err, "failed to connect to jump host %s", hop.Address)
+		}
+
+		client := ssh.NewClient(clientConn, chans, reqs)
+		clients = append(clients, client)
+		dial = client.DialContext
+	}
+
+	conn, err := dial(ctx, network, address)
+	if err != nil {
+		closeClients()
+		return nil, errors.Wrapf(err, "failed to reach %s through jump host %s", address, hops[len(hops)-1].Address)
+	}
+
+	return &jumpConn{Conn: conn, clients: clients}, nil
+}
+
+// jumpConn closes the connections to the jump hosts together with the
+// tunnelled connection.
+type jumpConn struct {
+	net.Conn
+	clients []*ssh.Client
+}
+
+func (c *jumpConn) Close() error {
+	err := c.Conn.Close()
+	for i := len(c.clients) - 1; i >= 0; i-- {
+		c.clients[i].Close() //nolint:errcheck
+	}
+	return err
+}
@@ -0,0 +1,300 @@
+package ssh_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+
+	"github.com/cloudfoundry/bosh-backup-and-restore/ssh"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+var _ = Describe("ProxyJumpDialContextFunc", func() {
+	var clientSigner gossh.Signer
+	var jumpbox, bastion, target *inProcessSSHServer
+	var directDials []string
+	var direct func(ctx context.Context, network, address string) (net.Conn, error)
+
+	hopTo := func(server *inProcessSSHServer, user string) ssh.Hop {
+		return ssh.Hop{
+			Address:         server.Address(),
+			User:            user,
+			AuthMethods:     []gossh.AuthMethod{gossh.PublicKeys(clientSigner)},
+			HostKeyCallback: gossh.FixedHostKey(server.HostKey()),
+		}
+	}
+
+	runOnTarget := func(conn net.Conn) string {
+		clientConn, chans, reqs, err := gossh.NewClientConn(conn, target.Address(), &gossh.ClientConfig{
+			User:            "vcap",
+			Auth:            []gossh.AuthMethod{gossh.PublicKeys(clientSigner)},
+			HostKeyCallback: gossh.FixedHostKey(target.HostKey()),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		client := gossh.NewClient(clientConn, chans, reqs)
+		defer client.Close() //nolint:errcheck
+
+		session, err := client.NewSession()
+		Expect(err).NotTo(HaveOccurred())
+		output, err := session.Output("whoami")
+		Expect(err).NotTo(HaveOccurred())
+		return string(output)
+	}
+
+	BeforeEach(func() {
+		clientSigner = newTestSigner()
+		jumpbox = newInProcessSSHServer("jumpbox", "jumpbox", clientSigner.PublicKey())
+		bastion = newInProcessSSHServer("bastion", "bastion", clientSigner.PublicKey())
+		target = newInProcessSSHServer("target", "vcap", clientSigner.PublicKey())
+
+		directDials = nil
+		direct = func(ctx context.Context, network, address string) (net.Conn, error) {
+			directDials = append(directDials, address)
+			return (&net.Dialer{}).DialContext(ctx, network, address)
+		}
+	})
+
+	AfterEach(func() {
+		jumpbox.Close()
+		bastion.Close()
+		target.Close()
+	})
+
+	It("reaches the target through each hop in turn", func() {
+		dial := ssh.ProxyJumpDialContextFunc([]ssh.JumpRoute{{
+			Hops: []ssh.Hop{hopTo(jumpbox, "jumpbox"), hopTo(bastion, "bastion")},
+		}}, direct)
+
+		conn, err := dial(context.Background(), "tcp", target.Address())
+		Expect(err).NotTo(HaveOccurred())
+		defer conn.Close() //nolint:errcheck
+
+		Expect(runOnTarget(conn)).To(Equal("target\n"))
+		Expect(directDials).To(Equal([]string{jumpbox.Address()}))
+		Expect(jumpbox.Forwarded()).To(Equal([]string{bastion.Address()}))
+		Expect(bastion.Forwarded()).To(Equal([]string{target.Address()}))
+	})
+
+	It("uses the first route whose networks contain the target", func() {
+		_, otherNetwork, _ := net.ParseCIDR("10.0.16.0/20")
+		_, loopback, _ := net.ParseCIDR("127.0.0.0/8")
+
+		dial := ssh.ProxyJumpDialContextFunc([]ssh.JumpRoute{
+			{Networks: []*net.IPNet{otherNetwork}, Hops: []ssh.Hop{hopTo(jumpbox, "jumpbox")}},
+			{Networks: []*net.IPNet{loopback}, Hops: []ssh.Hop{hopTo(bastion, "bastion")}},
+		}, direct)
+
+		conn, err := dial(context.Background(), "tcp", target.Address())
+		Expect(err).NotTo(HaveOccurred())
+		defer conn.Close() //nolint:errcheck
+
+		Expect(runOnTarget(conn)).To(Equal("target\n"))
+		Expect(jumpbox.Forwarded()).To(BeEmpty())
+		Expect(bastion.Forwarded()).To(Equal([]string{target.Address()}))
+	})
+
+	It("dials directly when no route matches", func() {
+		_, otherNetwork, _ := net.ParseCIDR("10.0.16.0/20")
+
+		dial := ssh.ProxyJumpDialContextFunc([]ssh.JumpRoute{
+			{Networks: []*net.IPNet{otherNetwork}, Hops: []ssh.Hop{hopTo(jumpbox, "jumpbox")}},
+		}, direct)
+
+		conn, err := dial(context.Background(), "tcp", target.Address())
+		Expect(err).NotTo(HaveOccurred())
+		defer conn.Close() //nolint:errcheck
+
+		Expect(runOnTarget(conn)).To(Equal("target\n"))
+		Expect(directDials).To(Equal([]string{target.Address()}))
+		Expect(jumpbox.Forwarded()).To(BeEmpty())
+	})
+
+	It("verifies the host key of every hop", func() {
+		wrongHostKey := hopTo(bastion, "bastion")
+		wrongHostKey.HostKeyCallback = gossh.FixedHostKey(jumpbox.HostKey())
+
+		dial := ssh.ProxyJumpDialContextFunc([]ssh.JumpRoute{{
+			Hops: []ssh.Hop{hopTo(jumpbox, "jumpbox"), wrongHostKey},
+		}}, direct)
+
+		_, err := dial(context.Background(), "tcp", target.Address())
+		Expect(err).To(MatchError(ContainSubstring("failed to connect to jump host " + bastion.Address())))
+		Expect(err).To(MatchError(ContainSubstring("host key mismatch")))
+	})
+
+	It("authenticates to every hop with its own credentials", func() {
+		dial := ssh.ProxyJumpDialContextFunc([]ssh.JumpRoute{{
+			Hops: []ssh.Hop{hopTo(jumpbox, "jumpbox"), hopTo(bastion, "not-bastion")},
+		}}, direct)
+
+		_, err := dial(context.Background(), "tcp", target.Address())
+		Expect(err).To(MatchError(ContainSubstring("failed to connect to jump host " + bastion.Address())))
+		Expect(err).To(MatchError(ContainSubstring("unable to authenticate")))
+	})
+
+	It("fails when the last hop cannot reach the target", func() {
+		unreachable := newInProcessSSHServer("gone", "vcap", clientSigner.PublicKey())
+		unreachable.Close()
+
+		dial := ssh.ProxyJumpDialContextFunc([]ssh.JumpRoute{{
+			Hops: []ssh.Hop{hopTo(jumpbox, "jumpbox")},
+		}}, direct)
+
+		_, err := dial(context.Background(), "tcp", unreachable.Address())
+		Expect(err).To(MatchError(ContainSubstring(fmt.Sprintf("failed to reach %s through jump host %s", unreachable.Address(), jumpbox.Address()))))
+	})
+})
+
+func newTestSigner() gossh.Signer {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	Expect(err).NotTo(HaveOccurred())
+	signer, err := gossh.NewSignerFromKey(key)
+	Expect(err).NotTo(HaveOccurred())
+	return signer
+}
+
+// inProcessSSHServer accepts a single user and key, answers every command
+// with its name, and forwards direct-tcpip channels like a jump host.
+type inProcessSSHServer struct {
+	name      string
+	listener  net.Listener
+	hostKey   gossh.Signer
+	config    *gossh.ServerConfig
+	forwarded []string
+	sync.Mutex
+}
+
+func newInProcessSSHServer(name, user string, authorizedKey gossh.PublicKey) *inProcessSSHServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	Expect(err).NotTo(HaveOccurred())
+
+	server := &inProcessSSHServer{name: name, listener: listener, hostKey: newTestSigner()}
+	server.config = &gossh.ServerConfig{
+		PublicKeyCallback: func(conn gossh.ConnMetadata, key gossh.PublicKey) (*gossh.Permissions, error) {
+			if conn.User() == user && string(key.Marshal()) == string(authorizedKey.Marshal()) {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("unknown key for %s", conn.User())
+		},
+	}
+	server.config.AddHostKey(server.hostKey)
+
+	go server.serve()
+	return server
+}
+
+func (s *inProcessSSHServer) Address() string {
+	return s.listener.Addr().String()
+}
+
+func (s *inProcessSSHServer) HostKey() gossh.PublicKey {
+	return s.hostKey.PublicKey()
+}
+
+func (s *inProcessSSHServer) Forwarded() []string {
+	s.Lock()
+	defer s.Unlock()
+	return append([]string{}, s.forwarded...)
+}
+
+func (s *inProcessSSHServer) Close() {
+	s.listener.Close() //nolint:errcheck
+}
+
+func (s *inProcessSSHServer) serve() {
+	defer GinkgoRecover()
+
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		go s.handle(conn)
+	}
+}
+
+func (s *inProcessSSHServer) handle(conn net.Conn) {
+	serverConn, chans, reqs, err := gossh.NewServerConn(conn, s.config)
+	if err != nil {
+		conn.Close() //nolint:errcheck
+		return
+	}
+	defer serverConn.Close() //nolint:errcheck
+	go gossh.DiscardRequests(reqs)
+
+	for newChannel := range chans {
+		switch newChannel.ChannelType() {
+		case "direct-tcpip":
+			go s.forward(newChannel)
+		case "session":
+			go s.session(newChannel)
+		default:
+			newChannel.Reject(gossh.UnknownChannelType, "unsupported") //nolint:errcheck
+		}
+	}
+}
+
+func (s *inProcessSSHServer) forward(newChannel gossh.NewChannel) {
+	var destination struct {
+		Host       string
+		Port       uint32
+		OriginHost string
+		OriginPort uint32
+	}
+	if err := gossh.Unmarshal(newChannel.ExtraData(), &destination); err != nil {
+		newChannel.Reject(gossh.ConnectionFailed, err.Error()) //nolint:errcheck
+		return
+	}
+
+	address := net.JoinHostPort(destination.Host, fmt.Sprint(destination.Port))
+	s.Lock()
+	s.forwarded = append(s.forwarded, address)
+	s.Unlock()
+
+	upstream, err := net.Dial("tcp", address)
+	if err != nil {
+		newChannel.Reject(gossh.ConnectionFailed, err.Error()) //nolint:errcheck
+		return
+	}
+
+	channel, reqs, err := newChannel.Accept()
+	if err != nil {
+		upstream.Close() //nolint:errcheck
+		return
+	}
+	go gossh.DiscardRequests(reqs)
+
+	go func() {
+		io.Copy(channel, upstream) //nolint:errcheck
+		channel.Close()            //nolint:errcheck
+	}()
+	io.Copy(upstream, channel) //nolint:errcheck
+	upstream.Close()           //nolint:errcheck
+}
+
+func (s *inProcessSSHServer) session(newChannel gossh.NewChannel) {
+	channel, reqs, err := newChannel.Accept()
+	if err != nil {
+		return
+	}
+	defer channel.Close() //nolint:errcheck
+
+	for req := range reqs {
+		if req.Type != "exec" {
+			req.Reply(false, nil) //nolint:errcheck
+			continue
+		}
+
+		req.Reply(true, nil)                                                                 //nolint:errcheck
+		fmt.Fprintf(channel, "%s\n", s.name)                                                 //nolint:errcheck
+		channel.SendRequest("exit-status", false, gossh.Marshal(struct{ Status uint32 }{0})) //nolint:errcheck
+		return
+	}
+}
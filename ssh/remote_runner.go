@@ -1,6 +1,7 @@
 package ssh
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"strconv"
@@ -15,18 +16,21 @@ import (
 //counterfeiter:generate -o fakes/fake_remote_runner.go . RemoteRunner
 type RemoteRunner interface {
 	ConnectedUsername() string
-	DirectoryExists(dir string) (bool, error)
-	RemoveDirectory(dir string) error
-	ArchiveAndDownload(directory string, writer io.Writer) error
-	CreateDirectory(directory string) error
-	ExtractAndUpload(reader io.Reader, directory string) error
-	SizeOf(path string) (string, error)
-	SizeInBytes(path string) (int, error)
-	ChecksumDirectory(path string) (map[string]string, error)
-	RunScript(path, label string) error
-	RunScriptWithEnv(path string, env map[string]string, label string, stdout io.Writer) error
-	FindFiles(pattern string) ([]string, error)
-	IsWindows() (bool, error)
+	DirectoryExists(ctx context.Context, dir string) (bool, error)
+	RemoveDirectory(ctx context.Context, dir string) error
+	ArchiveAndDownload(ctx context.Context, directory string, writer io.Writer) error
+	ArchiveFilesAndDownload(ctx context.Context, directory string, files []string, writer io.Writer) error
+	CreateDirectory(ctx context.Context, directory string) error
+	ExtractAndUpload(ctx context.Context, reader io.Reader, directory string) error
+	SizeOf(ctx context.Context, path string) (string, error)
+	SizeInBytes(ctx context.Context, path string) (int, error)
+	FileSizesInBytes(ctx context.Context, directory string) (map[string]int, error)
+	FreeSpaceInBytes(ctx context.Context, path string) (int, error)
+	ChecksumDirectory(ctx context.Context, path string) (map[string]string, error)
+	RunScript(ctx context.Context, path, label string) error
+	RunScriptWithEnv(ctx context.Context, path string, env map[string]string, label string, stdout io.Writer) error
+	FindFiles(ctx context.Context, pattern string) ([]string, error)
+	IsWindows(ctx context.Context) (bool, error)
 }
 
 type SshRemoteRunner struct {
@@ -50,33 +54,53 @@ func (r SshRemoteRunner) ConnectedUsername() string {
 	return r.connection.Username()
 }
 
-func (r SshRemoteRunner) DirectoryExists(dir string) (bool, error) {
-	_, _, exitCode, err := r.connection.Run(fmt.Sprintf("sudo stat %s", dir))
+func (r SshRemoteRunner) DirectoryExists(ctx context.Context, dir string) (bool, error) {
+	_, _, exitCode, err := r.connection.Run(ctx, fmt.Sprintf("sudo stat %s", dir))
 	return exitCode == 0, err
 }
 
-func (r SshRemoteRunner) CreateDirectory(directory string) error {
-	_, err := r.runOnInstance("sudo mkdir -p " + directory)
+func (r SshRemoteRunner) CreateDirectory(ctx context.Context, directory string) error {
+	_, err := r.runOnInstance(ctx, "sudo mkdir -p "+directory)
 	return err
 }
 
-func (r SshRemoteRunner) RemoveDirectory(dir string) error {
-	_, err := r.runOnInstance(fmt.Sprintf("sudo rm -rf %s", dir))
+func (r SshRemoteRunner) RemoveDirectory(ctx context.Context, dir string) error {
+	_, err := r.runOnInstance(ctx, fmt.Sprintf("sudo rm -rf %s", dir))
 	return err
 }
 
-func (r SshRemoteRunner) ArchiveAndDownload(directory string, writer io.Writer) error {
-	stderr, exitCode, err := r.connection.Stream(fmt.Sprintf("sudo tar -C %s -c .", directory), writer)
+func (r SshRemoteRunner) ArchiveAndDownload(ctx context.Context, directory string, writer io.Writer) error {
+	stderr, exitCode, err := r.connection.Stream(ctx, fmt.Sprintf("sudo tar -C %s -c .", directory), writer)
 	return r.logAndCheckErrors([]byte{}, stderr, exitCode, err, "")
 }
 
-func (r SshRemoteRunner) ExtractAndUpload(reader io.Reader, directory string) error {
-	stdout, stderr, exitCode, err := r.connection.StreamStdin(fmt.Sprintf("sudo sh -c 'tar -C %s -x'", directory), reader)
+// ArchiveFilesAndDownload archives only the given files and directories, which
+// are relative to directory. Directories are archived without their contents.
+// The list is uploaded to a temporary file first, because a large list would
+// not fit on the command line.
+func (r SshRemoteRunner) ArchiveFilesAndDownload(ctx context.Context, directory string, files []string, writer io.Writer) error {
+	fileList := strings.NewReader(strings.Join(files, "\x00") + "\x00")
+	stdout, stderr, exitCode, err := r.connection.StreamStdin(ctx, "sudo sh -c 'list=$(mktemp) && cat > $list && echo $list'", fileList)
+	if err := r.logAndCheckErrors(stdout, stderr, exitCode, err, ""); err != nil {
+		return errors.Wrap(err, "failed to upload the list of files to archive")
+	}
+	listPath := strings.TrimSpace(string(stdout))
+
+	stderr, exitCode, err = r.connection.Stream(
+		ctx,
+		fmt.Sprintf("sudo sh -c 'tar -C %s -c --no-recursion --null -T %s; status=$?; rm -f %s; exit $status'", directory, listPath, listPath),
+		writer,
+	)
+	return r.logAndCheckErrors([]byte{}, stderr, exitCode, err, "")
+}
+
+func (r SshRemoteRunner) ExtractAndUpload(ctx context.Context, reader io.Reader, directory string) error {
+	stdout, stderr, exitCode, err := r.connection.StreamStdin(ctx, fmt.Sprintf("sudo sh -c 'tar -C %s -x'", directory), reader)
 	return r.logAndCheckErrors(stdout, stderr, exitCode, err, "")
 }
 
-func (r SshRemoteRunner) SizeOf(path string) (string, error) {
-	stdout, err := r.runOnInstance(fmt.Sprintf("sudo du -sh %s", path))
+func (r SshRemoteRunner) SizeOf(ctx context.Context, path string) (string, error) {
+	stdout, err := r.runOnInstance(ctx, fmt.Sprintf("sudo du -sh %s", path))
 	if err != nil {
 		return "", err
 	}
@@ -84,8 +108,8 @@ func (r SshRemoteRunner) SizeOf(path string) (string, error) {
 	return strings.Fields(string(stdout))[0], nil
 }
 
-func (r SshRemoteRunner) SizeInBytes(path string) (int, error) {
-	stdout, err := r.runOnInstance(fmt.Sprintf("sudo du -s %s", path))
+func (r SshRemoteRunner) SizeInBytes(ctx context.Context, path string) (int, error) {
+	stdout, err := r.runOnInstance(ctx, fmt.Sprintf("sudo du -s %s", path))
 	if err != nil {
 		return 0, err
 	}
@@ -98,8 +122,57 @@ func (r SshRemoteRunner) SizeInBytes(path string) (int, error) {
 	return size * 1024, nil
 }
 
-func (r SshRemoteRunner) ChecksumDirectory(path string) (map[string]string, error) {
-	stdout, err := r.runOnInstance(fmt.Sprintf("sudo sh -c 'cd %s && find . -type f | xargs shasum -a 256'", path))
+// FileSizesInBytes returns the size of every file under directory, keyed by its
+// path relative to directory as tar would name it, e.g. ./data/dump.sql.
+// Directories, including directory itself, are keyed with a trailing slash,
+// e.g. ./data/, and have a size of 0.
+func (r SshRemoteRunner) FileSizesInBytes(ctx context.Context, directory string) (map[string]int, error) {
+	stdout, err := r.runOnInstance(ctx, fmt.Sprintf(`sudo sh -c 'cd %s && find . -type d -printf "0 %%p/\0" -o -printf "%%s %%p\0"'`, directory))
+	if err != nil {
+		return nil, err
+	}
+
+	sizes := map[string]int{}
+	for _, entry := range strings.Split(stdout, "\x00") {
+		if entry == "" {
+			continue
+		}
+
+		parts := strings.SplitN(entry, " ", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("unexpected output from find: %q", entry)
+		}
+
+		size, err := strconv.Atoi(parts[0])
+		if err != nil {
+			return nil, fmt.Errorf("expected <%s> to be a number of bytes: failed to convert it to int", parts[0])
+		}
+		sizes[parts[1]] = size
+	}
+	return sizes, nil
+}
+
+func (r SshRemoteRunner) FreeSpaceInBytes(ctx context.Context, path string) (int, error) {
+	stdout, err := r.runOnInstance(ctx, fmt.Sprintf("sudo df -P -k %s", path))
+	if err != nil {
+		return 0, err
+	}
+
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	fields := strings.Fields(lines[len(lines)-1])
+	if len(fields) < 4 {
+		return 0, fmt.Errorf("unexpected output from df: %s", stdout)
+	}
+
+	available, err := strconv.Atoi(fields[3])
+	if err != nil {
+		return 0, fmt.Errorf("expected <%s> to be a number of kilobytes: failed to convert it to int", fields[3])
+	}
+	return available * 1024, nil
+}
+
+func (r SshRemoteRunner) ChecksumDirectory(ctx context.Context, path string) (map[string]string, error) {
+	stdout, err := r.runOnInstance(ctx, fmt.Sprintf("sudo sh -c 'cd %s && find . -type f | xargs shasum -a 256'", path))
 	if err != nil {
 		return nil, err
 	}
@@ -107,8 +180,8 @@ func (r SshRemoteRunner) ChecksumDirectory(path string) (map[string]string, erro
 	return convertShasToMap(stdout), nil
 }
 
-func (r SshRemoteRunner) RunScript(path, label string) error {
-	return r.RunScriptWithEnv(path, map[string]string{}, label, io.Discard)
+func (r SshRemoteRunner) RunScript(ctx context.Context, path, label string) error {
+	return r.RunScriptWithEnv(ctx, path, map[string]string{}, label, io.Discard)
 }
 
 // RunScriptWithEnv runs the script at 'path' on our remote
@@ -119,13 +192,13 @@ func (r SshRemoteRunner) RunScript(path, label string) error {
 // it to the Writer 'stdout' during the call. This means that if a
 // given script outputs very large amounts of data to stdout, we don't
 // cache it all in memory and run the risk of crashing the CLI.
-func (r SshRemoteRunner) RunScriptWithEnv(path string, env map[string]string, label string, stdout io.Writer) error {
+func (r SshRemoteRunner) RunScriptWithEnv(ctx context.Context, path string, env map[string]string, label string, stdout io.Writer) error {
 	var varsList = ""
 	for varName, value := range env {
 		varsList = varsList + varName + "=" + value + " "
 	}
 
-	stderr, exitCode, runErr := r.connection.Stream("sudo "+varsList+path, anonymousWriter{write: func(p []byte) (int, error) {
+	stderr, exitCode, runErr := r.connection.Stream(ctx, "sudo "+varsList+path, anonymousWriter{write: func(p []byte) (int, error) {
 		n, outErr := stdout.Write(p)
 
 		r.logger.Debug("bbr", "stdout: %s", string(p))
@@ -163,8 +236,8 @@ func (w anonymousWriter) Write(p []byte) (n int, err error) {
 	return w.write(p)
 }
 
-func (r SshRemoteRunner) FindFiles(pattern string) ([]string, error) {
-	stdout, stderr, exitCode, err := r.connection.Run(fmt.Sprintf("sudo sh -c 'find %s -type f'", pattern))
+func (r SshRemoteRunner) FindFiles(ctx context.Context, pattern string) ([]string, error) {
+	stdout, stderr, exitCode, err := r.connection.Run(ctx, fmt.Sprintf("sudo sh -c 'find %s -type f'", pattern))
 
 	r.logOutput(stdout, stderr, "find files")
 
@@ -185,8 +258,8 @@ func (r SshRemoteRunner) FindFiles(pattern string) ([]string, error) {
 	return strings.Split(output, "\n"), nil
 }
 
-func (r SshRemoteRunner) IsWindows() (bool, error) {
-	stdout, _, _, err := r.connection.Run(`echo %OS%`)
+func (r SshRemoteRunner) IsWindows(ctx context.Context) (bool, error) {
+	stdout, _, _, err := r.connection.Run(ctx, `echo %OS%`)
 	if err != nil {
 		return false, err
 	}
@@ -194,8 +267,8 @@ func (r SshRemoteRunner) IsWindows() (bool, error) {
 	return strings.TrimSpace(string(stdout)) == "Windows_NT", nil
 }
 
-func (r SshRemoteRunner) runOnInstance(cmd string) (string, error) {
-	stdout, stderr, exitCode, runErr := r.connection.Run(cmd)
+func (r SshRemoteRunner) runOnInstance(ctx context.Context, cmd string) (string, error) {
+	stdout, stderr, exitCode, runErr := r.connection.Run(ctx, cmd)
 
 	err := r.logAndCheckErrors(stdout, stderr, exitCode, runErr, "")
 	if err != nil {
@@ -230,8 +303,26 @@ func (r SshRemoteRunner) logOutput(stdout []byte, stderr []byte, label string) {
 	}
 }
 
+// ExitError is returned when a command exits non-zero on an instance.
+type ExitError struct {
+	stderr   string
+	exitCode int
+}
+
+func (e ExitError) Error() string {
+	return fmt.Sprintf("%s - exit code %d", e.stderr, e.exitCode)
+}
+
+func (e ExitError) ExitCode() int {
+	return e.exitCode
+}
+
+func (e ExitError) Stderr() string {
+	return e.stderr
+}
+
 func exitError(stderr []byte, exitCode int) error {
-	return errors.New(fmt.Sprintf("%s - exit code %d", strings.TrimSpace(string(stderr)), exitCode))
+	return ExitError{stderr: strings.TrimSpace(string(stderr)), exitCode: exitCode}
 }
 
 func convertShasToMap(shas string) map[string]string {
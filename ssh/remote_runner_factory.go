@@ -1,7 +1,40 @@
 package ssh
 
-import "golang.org/x/crypto/ssh"
+import (
+	"context"
+
+	"golang.org/x/crypto/ssh"
+)
 
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
 //counterfeiter:generate -o fakes/fake_remote_runner_factory.go . RemoteRunnerFactory
-type RemoteRunnerFactory func(host, user, privateKey string, publicKeyCallback ssh.HostKeyCallback, publicKeyAlgorithm []string, logger Logger) (RemoteRunner, error)
+type RemoteRunnerFactory func(ctx context.Context, host, user, privateKey string, publicKeyCallback ssh.HostKeyCallback, publicKeyAlgorithm []string, logger Logger) (RemoteRunner, error)
+
+//counterfeiter:generate -o fakes/fake_authenticated_remote_runner_factory.go . AuthenticatedRemoteRunnerFactory
+type AuthenticatedRemoteRunnerFactory func(ctx context.Context, host, user string, authMethods []ssh.AuthMethod, publicKeyCallback ssh.HostKeyCallback, publicKeyAlgorithm []string, logger Logger) (RemoteRunner, error)
+
+// NewRemoteRunnerFactory returns a RemoteRunnerFactory like NewRemoteRunner
+// whose connections go through the first of proxyJump that matches their host.
+func NewRemoteRunnerFactory(proxyJump []JumpRoute) RemoteRunnerFactory {
+	dial := ProxyJumpDialContextFuncFromRoutes(proxyJump)
+
+	return func(ctx context.Context, host, user, privateKey string, publicKeyCallback ssh.HostKeyCallback, publicKeyAlgorithm []string, logger Logger) (RemoteRunner, error) {
+		connection, err := newConnectionWithPrivateKey(host, user, privateKey, publicKeyCallback, publicKeyAlgorithm, 60, dial, logger)
+		if err != nil {
+			return SshRemoteRunner{}, err
+		}
+
+		return remoteRunnerForOS(ctx, host, connection, logger)
+	}
+}
+
+// NewAuthenticatedRemoteRunnerFactory is NewRemoteRunnerFactory for
+// connections that do not authenticate with a single private key.
+func NewAuthenticatedRemoteRunnerFactory(proxyJump []JumpRoute) AuthenticatedRemoteRunnerFactory {
+	dial := ProxyJumpDialContextFuncFromRoutes(proxyJump)
+
+	return func(ctx context.Context, host, user string, authMethods []ssh.AuthMethod, publicKeyCallback ssh.HostKeyCallback, publicKeyAlgorithm []string, logger Logger) (RemoteRunner, error) {
+		connection := newConnection(host, user, authMethods, publicKeyCallback, publicKeyAlgorithm, 60, dial, logger)
+		return remoteRunnerForOS(ctx, host, connection, logger)
+	}
+}
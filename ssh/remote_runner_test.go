@@ -2,8 +2,11 @@ package ssh_test
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"io"
 	"log"
+	"time"
 
 	"os"
 
@@ -50,7 +53,7 @@ var _ = Describe("SshRemoteRunner", func() {
 	})
 
 	var runCommand = func(cmd string) string {
-		stdout, stderr, status, err := sshConnection.Run(cmd)
+		stdout, stderr, status, err := sshConnection.Run(context.Background(), cmd)
 		Expect(err).NotTo(HaveOccurred())
 		Expect(status).To(Equal(0), string(stderr))
 		return string(stdout)
@@ -69,7 +72,7 @@ var _ = Describe("SshRemoteRunner", func() {
 	Describe("DirectoryExists", func() {
 		Context("When the directory does not exist", func() {
 			It("returns false", func() {
-				Expect(sshRemoteRunner.DirectoryExists("/tmp/non-existing-dir")).To(BeFalse())
+				Expect(sshRemoteRunner.DirectoryExists(context.Background(), "/tmp/non-existing-dir")).To(BeFalse())
 			})
 		})
 
@@ -80,7 +83,7 @@ var _ = Describe("SshRemoteRunner", func() {
 			})
 
 			It("returns false", func() {
-				Expect(sshRemoteRunner.DirectoryExists("/tmp/an-existing-dir")).To(BeTrue())
+				Expect(sshRemoteRunner.DirectoryExists(context.Background(), "/tmp/an-existing-dir")).To(BeTrue())
 			})
 		})
 
@@ -90,7 +93,7 @@ var _ = Describe("SshRemoteRunner", func() {
 			})
 
 			It("returns an error", func() {
-				_, err := sshRemoteRunner.DirectoryExists("whatever")
+				_, err := sshRemoteRunner.DirectoryExists(context.Background(), "whatever")
 				Expect(err).To(MatchError(ContainSubstring("ssh.Dial failed")))
 			})
 		})
@@ -99,8 +102,8 @@ var _ = Describe("SshRemoteRunner", func() {
 	Describe("CreateDirectory", func() {
 		It("creates a directory", func() {
 			makeAccessibleOnlyByRoot("/tmp")
-			Expect(sshRemoteRunner.CreateDirectory("/tmp/a-new-directory")).To(Succeed())
-			Expect(sshRemoteRunner.DirectoryExists("/tmp/a-new-directory")).To(BeTrue())
+			Expect(sshRemoteRunner.CreateDirectory(context.Background(), "/tmp/a-new-directory")).To(Succeed())
+			Expect(sshRemoteRunner.DirectoryExists(context.Background(), "/tmp/a-new-directory")).To(BeTrue())
 		})
 
 		Context("When the ssh connection fails", func() {
@@ -109,7 +112,7 @@ var _ = Describe("SshRemoteRunner", func() {
 			})
 
 			It("returns an error", func() {
-				err := sshRemoteRunner.CreateDirectory("whatever")
+				err := sshRemoteRunner.CreateDirectory(context.Background(), "whatever")
 				Expect(err).To(MatchError(ContainSubstring("ssh.Dial failed")))
 			})
 		})
@@ -123,10 +126,10 @@ var _ = Describe("SshRemoteRunner", func() {
 			})
 
 			It("removes the directory", func() {
-				err := sshRemoteRunner.RemoveDirectory("/tmp/existing-directory")
+				err := sshRemoteRunner.RemoveDirectory(context.Background(), "/tmp/existing-directory")
 
 				Expect(err).NotTo(HaveOccurred())
-				Expect(sshRemoteRunner.DirectoryExists("/tmp/non-existing-dir")).To(BeFalse())
+				Expect(sshRemoteRunner.DirectoryExists(context.Background(), "/tmp/non-existing-dir")).To(BeFalse())
 			})
 		})
 
@@ -136,7 +139,7 @@ var _ = Describe("SshRemoteRunner", func() {
 			})
 
 			It("returns an error", func() {
-				err := sshRemoteRunner.RemoveDirectory("whatever")
+				err := sshRemoteRunner.RemoveDirectory(context.Background(), "whatever")
 				Expect(err).To(MatchError(ContainSubstring("ssh.Dial failed")))
 			})
 		})
@@ -151,7 +154,7 @@ var _ = Describe("SshRemoteRunner", func() {
 
 			By("downloading and archiving the directory")
 			archiveFile := makeTmpFile("remote-runner-test-")
-			err := sshRemoteRunner.ArchiveAndDownload("/tmp/dir-to-archive", archiveFile)
+			err := sshRemoteRunner.ArchiveAndDownload(context.Background(), "/tmp/dir-to-archive", archiveFile)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(fileSize(archiveFile)).To(BeNumerically(">", 0))
 
@@ -159,7 +162,7 @@ var _ = Describe("SshRemoteRunner", func() {
 			runCommand("mkdir -p /tmp/uploaded-dir")
 			makeAccessibleOnlyByRoot("/tmp/uploaded-dir")
 			archiveFile = resetCursor(archiveFile)
-			err = sshRemoteRunner.ExtractAndUpload(archiveFile, "/tmp/uploaded-dir")
+			err = sshRemoteRunner.ExtractAndUpload(context.Background(), archiveFile, "/tmp/uploaded-dir")
 			Expect(err).NotTo(HaveOccurred())
 			lsOutput := runCommand("sudo ls /tmp/uploaded-dir")
 			Expect(lsOutput).To(Equal("file1\nfile2\n"))
@@ -169,7 +172,7 @@ var _ = Describe("SshRemoteRunner", func() {
 			Context("when the command fails", func() {
 				It("returns an error", func() {
 					archiveFile := makeTmpFile("remote-runner-test-")
-					err := sshRemoteRunner.ArchiveAndDownload("/tmp/unexisting-dir", archiveFile)
+					err := sshRemoteRunner.ArchiveAndDownload(context.Background(), "/tmp/unexisting-dir", archiveFile)
 					Expect(err).To(MatchError(ContainSubstring("No such file or directory")))
 				})
 			})
@@ -181,7 +184,7 @@ var _ = Describe("SshRemoteRunner", func() {
 
 				It("returns an error", func() {
 					archiveFile := makeTmpFile("remote-runner-test-")
-					err := sshRemoteRunner.ArchiveAndDownload("/tmp/unexisting-dir", archiveFile)
+					err := sshRemoteRunner.ArchiveAndDownload(context.Background(), "/tmp/unexisting-dir", archiveFile)
 					Expect(err).To(MatchError(ContainSubstring("ssh.Dial failed")))
 				})
 			})
@@ -191,7 +194,7 @@ var _ = Describe("SshRemoteRunner", func() {
 			Context("when the command fails", func() {
 				It("returns an error", func() {
 					notATar := makeTmpFile("remote-runner-test-")
-					err := sshRemoteRunner.ExtractAndUpload(notATar, "/tmp/arbitrary-dir")
+					err := sshRemoteRunner.ExtractAndUpload(context.Background(), notATar, "/tmp/arbitrary-dir")
 					Expect(err).To(MatchError(ContainSubstring("This does not look like a tar archive")))
 				})
 			})
@@ -203,13 +206,66 @@ var _ = Describe("SshRemoteRunner", func() {
 
 				It("returns an error", func() {
 					arbitraryFile := makeTmpFile("remote-runner-test-")
-					err := sshRemoteRunner.ExtractAndUpload(arbitraryFile, "/tmp/arbitrary-dir")
+					err := sshRemoteRunner.ExtractAndUpload(context.Background(), arbitraryFile, "/tmp/arbitrary-dir")
 					Expect(err).To(MatchError(ContainSubstring("ssh.Dial failed")))
 				})
 			})
 		})
 	})
 
+	Describe("remote archiving of a subset of files", func() {
+		It("archives only the listed files", func() {
+			runCommand("mkdir -p '/tmp/dir-to-split/sub dir'")
+			runCommand("echo 'one' > /tmp/dir-to-split/file1")
+			runCommand("echo 'two' > '/tmp/dir-to-split/sub dir/file 2'")
+			runCommand("echo 'three' > /tmp/dir-to-split/file3")
+			runCommand("mkdir -m 0750 /tmp/dir-to-split/empty")
+			makeAccessibleOnlyByRoot("/tmp/dir-to-split")
+
+			By("listing the files and directories with their sizes")
+			sizes, err := sshRemoteRunner.FileSizesInBytes(context.Background(), "/tmp/dir-to-split")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sizes).To(Equal(map[string]int{
+				"./":               0,
+				"./file1":          4,
+				"./sub dir/":       0,
+				"./sub dir/file 2": 4,
+				"./file3":          6,
+				"./empty/":         0,
+			}))
+
+			By("archiving some of them, without the contents of the directories")
+			archiveFile := makeTmpFile("remote-runner-test-")
+			err = sshRemoteRunner.ArchiveFilesAndDownload(context.Background(), "/tmp/dir-to-split", []string{"./", "./empty/", "./file1", "./sub dir/", "./sub dir/file 2"}, archiveFile)
+			Expect(err).NotTo(HaveOccurred())
+
+			runCommand("mkdir -p /tmp/uploaded-part")
+			makeAccessibleOnlyByRoot("/tmp/uploaded-part")
+			Expect(sshRemoteRunner.ExtractAndUpload(context.Background(), resetCursor(archiveFile), "/tmp/uploaded-part")).To(Succeed())
+			Expect(runCommand("sudo find /tmp/uploaded-part -type f | sort")).To(Equal("/tmp/uploaded-part/file1\n/tmp/uploaded-part/sub dir/file 2\n"))
+			Expect(runCommand("sudo stat -c %a /tmp/uploaded-part/empty")).To(Equal("750\n"))
+
+			By("removing the uploaded list of files")
+			Expect(runCommand("sudo find /tmp -maxdepth 1 -name 'tmp.*' | wc -l")).To(Equal("0\n"))
+		})
+
+		Context("when a listed file does not exist", func() {
+			It("returns an error", func() {
+				runCommand("mkdir -p /tmp/dir-to-split")
+				archiveFile := makeTmpFile("remote-runner-test-")
+				err := sshRemoteRunner.ArchiveFilesAndDownload(context.Background(), "/tmp/dir-to-split", []string{"./missing"}, archiveFile)
+				Expect(err).To(MatchError(ContainSubstring("Cannot stat")))
+			})
+		})
+
+		Context("when the directory does not exist", func() {
+			It("returns an error", func() {
+				_, err := sshRemoteRunner.FileSizesInBytes(context.Background(), "/tmp/unexisting-dir")
+				Expect(err).To(MatchError(ContainSubstring("can't cd")))
+			})
+		})
+	})
+
 	Describe("SizeOf", func() {
 		Context("when the file or directory exists", func() {
 			BeforeEach(func() {
@@ -220,13 +276,13 @@ var _ = Describe("SshRemoteRunner", func() {
 			})
 
 			It("returns a string with the specified file or directory size", func() {
-				Expect(sshRemoteRunner.SizeOf("/tmp/a-dir")).To(Equal("1.5M"))
+				Expect(sshRemoteRunner.SizeOf(context.Background(), "/tmp/a-dir")).To(Equal("1.5M"))
 			})
 		})
 
 		Context("when the directory does not exist", func() {
 			It("returns an error", func() {
-				_, err := sshRemoteRunner.SizeOf("/tmp/not-a-file")
+				_, err := sshRemoteRunner.SizeOf(context.Background(), "/tmp/not-a-file")
 				Expect(err).To(MatchError(ContainSubstring("No such file or directory")))
 			})
 		})
@@ -237,7 +293,7 @@ var _ = Describe("SshRemoteRunner", func() {
 			})
 
 			It("returns an error", func() {
-				_, err := sshRemoteRunner.SizeOf("whatever")
+				_, err := sshRemoteRunner.SizeOf(context.Background(), "whatever")
 				Expect(err).To(MatchError(ContainSubstring("ssh.Dial failed")))
 			})
 		})
@@ -253,13 +309,13 @@ var _ = Describe("SshRemoteRunner", func() {
 			})
 
 			It("returns a string with the specified file or directory size", func() {
-				Expect(sshRemoteRunner.SizeInBytes("/tmp/a-dir")).To(Equal(1540096))
+				Expect(sshRemoteRunner.SizeInBytes(context.Background(), "/tmp/a-dir")).To(Equal(1540096))
 			})
 		})
 
 		Context("when the directory does not exist", func() {
 			It("returns an error", func() {
-				_, err := sshRemoteRunner.SizeInBytes("/tmp/not-a-file")
+				_, err := sshRemoteRunner.SizeInBytes(context.Background(), "/tmp/not-a-file")
 				Expect(err).To(MatchError(ContainSubstring("No such file or directory")))
 			})
 		})
@@ -270,12 +326,27 @@ var _ = Describe("SshRemoteRunner", func() {
 			})
 
 			It("returns an error", func() {
-				_, err := sshRemoteRunner.SizeInBytes("whatever")
+				_, err := sshRemoteRunner.SizeInBytes(context.Background(), "whatever")
 				Expect(err).To(MatchError(ContainSubstring("ssh.Dial failed")))
 			})
 		})
 	})
 
+	Describe("FreeSpaceInBytes", func() {
+		Context("when the path exists", func() {
+			It("returns the space available on its filesystem", func() {
+				Expect(sshRemoteRunner.FreeSpaceInBytes(context.Background(), "/tmp")).To(BeNumerically(">", 0))
+			})
+		})
+
+		Context("when the path does not exist", func() {
+			It("returns an error", func() {
+				_, err := sshRemoteRunner.FreeSpaceInBytes(context.Background(), "/tmp/not-a-dir")
+				Expect(err).To(MatchError(ContainSubstring("No such file or directory")))
+			})
+		})
+	})
+
 	Describe("ChecksumDirectory", func() {
 		Context("when the file or directory exists", func() {
 			BeforeEach(func() {
@@ -286,7 +357,7 @@ var _ = Describe("SshRemoteRunner", func() {
 			})
 
 			It("calculates the SHA256 checksum for each file in the directory", func() {
-				Expect(sshRemoteRunner.ChecksumDirectory("/tmp/a-dir")).To(SatisfyAll(
+				Expect(sshRemoteRunner.ChecksumDirectory(context.Background(), "/tmp/a-dir")).To(SatisfyAll(
 					HaveLen(2),
 					HaveKeyWithValue("./file1", "b5bb9d8014a0f9b1d61e21e796d78dccdf1352f23cd32812f4850b878ae4944c"),
 					HaveKeyWithValue("./file2", "7d865e959b2466918c9863afca942d0fb89d7c9ac0c99bafc3749504ded97730"),
@@ -296,7 +367,7 @@ var _ = Describe("SshRemoteRunner", func() {
 
 		Context("when the directory does not exist", func() {
 			It("returns an error", func() {
-				_, err := sshRemoteRunner.ChecksumDirectory("/tmp/not-a-dir")
+				_, err := sshRemoteRunner.ChecksumDirectory(context.Background(), "/tmp/not-a-dir")
 				Expect(err).To(MatchError(ContainSubstring("can't cd to /tmp/not-a-dir")))
 			})
 		})
@@ -307,7 +378,7 @@ var _ = Describe("SshRemoteRunner", func() {
 			})
 
 			It("returns an error", func() {
-				_, err := sshRemoteRunner.ChecksumDirectory("whatever")
+				_, err := sshRemoteRunner.ChecksumDirectory(context.Background(), "whatever")
 				Expect(err).To(MatchError(ContainSubstring("ssh.Dial failed")))
 			})
 		})
@@ -322,7 +393,7 @@ var _ = Describe("SshRemoteRunner", func() {
 
 				stdoutBuffer := &bytes.Buffer{}
 
-				err := sshRemoteRunner.RunScriptWithEnv("/tmp/example-script", map[string]string{"env1": "foo", "env2": "bar"}, "", stdoutBuffer)
+				err := sshRemoteRunner.RunScriptWithEnv(context.Background(), "/tmp/example-script", map[string]string{"env1": "foo", "env2": "bar"}, "", stdoutBuffer)
 
 				Expect(err).NotTo(HaveOccurred())
 
@@ -333,6 +404,22 @@ var _ = Describe("SshRemoteRunner", func() {
 			})
 		})
 
+		Context("when the context is cancelled while the script runs", func() {
+			It("stops waiting for the script and returns the cause", func() {
+				runCommand("echo 'sleep 60' > /tmp/example-script")
+				runCommand("chmod +x /tmp/example-script")
+
+				ctx, cancel := context.WithCancelCause(context.Background())
+				time.AfterFunc(time.Second, func() { cancel(errors.New("received terminated")) })
+
+				start := time.Now()
+				err := sshRemoteRunner.RunScriptWithEnv(ctx, "/tmp/example-script", map[string]string{}, "", io.Discard)
+
+				Expect(err).To(MatchError(ContainSubstring("received terminated")))
+				Expect(time.Since(start)).To(BeNumerically("<", 30*time.Second))
+			})
+		})
+
 		Context("when the script is not there", func() {
 			It("returns a helpful error", func() {
 				// as of 2024/03/28 This has previously used `pcfplatformrecovery/backup-and-restore-node-with-ssh` from dockerhub. This image was based on a xenial 315.x stemcell. When the tests were updated to start a docker image based on jammy, the below started failing when the command used a /absolute/path/to/binary
@@ -340,7 +427,7 @@ var _ = Describe("SshRemoteRunner", func() {
 				//  [bbr] 2024/03/28 15:12:04 DEBUG - Trying to execute 'sudo env1=foo env2=bar /tmp/example-script' on remote
 				//  [bbr] 2024/03/28 15:12:06 DEBUG - stderr: sudo: a terminal is required to read the password; either use the -S option to read from standard input or configure an askpass helper
 				// this only happens when the path is absolute and I have not found any explanation for this. I'm changing the below test setup assuming this is harmless, but just in case leave the confusing error logs here for future reference. FWIW the image is setup to allow passwordless sudo for ALL USERS.
-				err := sshRemoteRunner.RunScriptWithEnv(`example-script`, map[string]string{"env1": "foo", "env2": "bar"}, "", io.Discard)
+				err := sshRemoteRunner.RunScriptWithEnv(context.Background(), `example-script`, map[string]string{"env1": "foo", "env2": "bar"}, "", io.Discard)
 
 				Expect(err).To(MatchError(ContainSubstring("command not found")))
 			})
@@ -351,7 +438,7 @@ var _ = Describe("SshRemoteRunner", func() {
 				runCommand("echo '>&2 echo example script has errorred; exit 12' > /tmp/example-script")
 				runCommand("chmod +x /tmp/example-script")
 
-				err := sshRemoteRunner.RunScriptWithEnv("/tmp/example-script", map[string]string{"env1": "foo", "env2": "bar"}, "", io.Discard)
+				err := sshRemoteRunner.RunScriptWithEnv(context.Background(), "/tmp/example-script", map[string]string{"env1": "foo", "env2": "bar"}, "", io.Discard)
 
 				Expect(err).To(MatchError(ContainSubstring("example script has errorred - exit code 12")))
 
@@ -363,7 +450,7 @@ var _ = Describe("SshRemoteRunner", func() {
 			})
 
 			It("returns an error", func() {
-				err := sshRemoteRunner.RunScriptWithEnv("whatever", map[string]string{}, "", io.Discard)
+				err := sshRemoteRunner.RunScriptWithEnv(context.Background(), "whatever", map[string]string{}, "", io.Discard)
 				Expect(err).To(MatchError(ContainSubstring("ssh.Dial failed")))
 			})
 		})
@@ -377,7 +464,7 @@ var _ = Describe("SshRemoteRunner", func() {
 				runCommand("touch /tmp/script-to-not-find")
 				makeAccessibleOnlyByRoot("/tmp")
 
-				files, err := sshRemoteRunner.FindFiles("/tmp/*to-find*")
+				files, err := sshRemoteRunner.FindFiles(context.Background(), "/tmp/*to-find*")
 				Expect(err).NotTo(HaveOccurred())
 				Expect(files).To(ConsistOf(
 					"/tmp/script-to-find",
@@ -389,7 +476,7 @@ var _ = Describe("SshRemoteRunner", func() {
 
 		Context("when there are no files that match the pattern", func() {
 			It("returns exactly those files", func() {
-				files, err := sshRemoteRunner.FindFiles("/tmp/this-file")
+				files, err := sshRemoteRunner.FindFiles(context.Background(), "/tmp/this-file")
 				Expect(err).NotTo(HaveOccurred())
 				Expect(files).To(HaveLen(0))
 			})
@@ -397,7 +484,7 @@ var _ = Describe("SshRemoteRunner", func() {
 
 		Context("when the find command errors", func() {
 			It("bubbles the error up", func() {
-				_, err := sshRemoteRunner.FindFiles("; cause-an-error")
+				_, err := sshRemoteRunner.FindFiles(context.Background(), "; cause-an-error")
 				Expect(err).To(MatchError(ContainSubstring("not found")))
 			})
 		})
@@ -407,7 +494,7 @@ var _ = Describe("SshRemoteRunner", func() {
 			})
 
 			It("returns an error", func() {
-				_, err := sshRemoteRunner.FindFiles("whatever")
+				_, err := sshRemoteRunner.FindFiles(context.Background(), "whatever")
 				Expect(err).To(MatchError(ContainSubstring("ssh.Dial failed")))
 			})
 		})
@@ -0,0 +1,324 @@
+package ssh
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/cloudfoundry/bosh-backup-and-restore/readwriter"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ssh"
+)
+
+const windowsSystemDrive = "C:"
+
+var windowsScriptExtensions = []string{".ps1", ".bat", ".cmd", ".exe"}
+
+// errSplitArtifactsUnsupported makes bbr drain artifacts from Windows instances
+// in a single stream.
+var errSplitArtifactsUnsupported = errors.New("splitting artifacts into parts is not supported on Windows instances")
+
+// WindowsRemoteRunner runs commands on Windows stemcells, whose OpenSSH
+// server starts commands with cmd.exe. Paths are accepted and returned in the
+// same unix form as on linux instances, e.g. /var/vcap/jobs, and are mapped
+// onto the system drive.
+type WindowsRemoteRunner struct {
+	logger     Logger
+	connection SSHConnection
+}
+
+func NewWindowsRemoteRunner(connection SSHConnection, logger Logger) RemoteRunner {
+	return WindowsRemoteRunner{
+		connection: connection,
+		logger:     logger,
+	}
+}
+
+// NewRemoteRunner connects to host and returns a WindowsRemoteRunner if it
+// turns out to be a Windows VM, or an SshRemoteRunner otherwise.
+func NewRemoteRunner(ctx context.Context, host, user, privateKey string, publicKeyCallback ssh.HostKeyCallback, publicKeyAlgorithm []string, logger Logger) (RemoteRunner, error) {
+	connection, err := NewConnection(host, user, privateKey, publicKeyCallback, publicKeyAlgorithm, logger)
+	if err != nil {
+		return SshRemoteRunner{}, err
+	}
+
+	return remoteRunnerForOS(ctx, host, connection, logger)
+}
+
+// NewRemoteRunnerWithAuth is NewRemoteRunner for connections that do not
+// authenticate with a single private key.
+func NewRemoteRunnerWithAuth(ctx context.Context, host, user string, authMethods []ssh.AuthMethod, publicKeyCallback ssh.HostKeyCallback, publicKeyAlgorithm []string, logger Logger) (RemoteRunner, error) {
+	return remoteRunnerForOS(ctx, host, NewConnectionWithAuth(host, user, authMethods, publicKeyCallback, publicKeyAlgorithm, logger), logger)
+}
+
+func remoteRunnerForOS(ctx context.Context, host string, connection SSHConnection, logger Logger) (RemoteRunner, error) {
+	sshRemoteRunner := SshRemoteRunner{connection: connection, logger: logger}
+	isWindows, err := sshRemoteRunner.IsWindows(ctx)
+	if err != nil {
+		return SshRemoteRunner{}, errors.Wrap(err, "failed to check os")
+	}
+
+	if isWindows {
+		logger.Debug("bbr", "%s is a Windows instance", host)
+		return NewWindowsRemoteRunner(connection, logger), nil
+	}
+	return sshRemoteRunner, nil
+}
+
+func (r WindowsRemoteRunner) ConnectedUsername() string {
+	return r.connection.Username()
+}
+
+func (r WindowsRemoteRunner) DirectoryExists(ctx context.Context, dir string) (bool, error) {
+	_, _, exitCode, err := r.connection.Run(ctx, powershell(fmt.Sprintf(
+		"if (Test-Path -LiteralPath %s -PathType Container) { exit 0 } else { exit 1 }", quote(windowsPath(dir)),
+	)))
+	return exitCode == 0, err
+}
+
+func (r WindowsRemoteRunner) CreateDirectory(ctx context.Context, directory string) error {
+	_, err := r.runOnInstance(ctx, powershell(fmt.Sprintf(
+		"New-Item -ItemType Directory -Force -Path %s | Out-Null", quote(windowsPath(directory)),
+	)))
+	return err
+}
+
+func (r WindowsRemoteRunner) RemoveDirectory(ctx context.Context, dir string) error {
+	_, err := r.runOnInstance(ctx, powershell(fmt.Sprintf(
+		"if (Test-Path -LiteralPath %[1]s) { Remove-Item -LiteralPath %[1]s -Recurse -Force }", quote(windowsPath(dir)),
+	)))
+	return err
+}
+
+// ArchiveAndDownload runs tar.exe straight from cmd.exe rather than from
+// PowerShell, which would re-encode the binary stream.
+func (r WindowsRemoteRunner) ArchiveAndDownload(ctx context.Context, directory string, writer io.Writer) error {
+	stderr, exitCode, err := r.connection.Stream(ctx, fmt.Sprintf(`tar.exe -C "%s" -c .`, windowsPath(directory)), writer)
+	return r.logAndCheckErrors([]byte{}, stderr, exitCode, err)
+}
+
+func (r WindowsRemoteRunner) ArchiveFilesAndDownload(ctx context.Context, directory string, files []string, writer io.Writer) error {
+	return errSplitArtifactsUnsupported
+}
+
+func (r WindowsRemoteRunner) ExtractAndUpload(ctx context.Context, reader io.Reader, directory string) error {
+	stdout, stderr, exitCode, err := r.connection.StreamStdin(ctx, fmt.Sprintf(`tar.exe -C "%s" -x`, windowsPath(directory)), reader)
+	return r.logAndCheckErrors(stdout, stderr, exitCode, err)
+}
+
+func (r WindowsRemoteRunner) SizeOf(ctx context.Context, path string) (string, error) {
+	size, err := r.SizeInBytes(ctx, path)
+	if err != nil {
+		return "", err
+	}
+
+	return readwriter.HumanReadableSize(size), nil
+}
+
+func (r WindowsRemoteRunner) SizeInBytes(ctx context.Context, path string) (int, error) {
+	stdout, err := r.runOnInstance(ctx, powershell(fmt.Sprintf(
+		"[long](Get-ChildItem -LiteralPath %s -Recurse -File -Force | Measure-Object -Property Length -Sum).Sum", quote(windowsPath(path)),
+	)))
+	if err != nil {
+		return 0, err
+	}
+
+	sizeString := strings.TrimSpace(stdout)
+	size, err := strconv.Atoi(sizeString)
+	if err != nil {
+		return 0, fmt.Errorf("expected <%s> to be a number of bytes: failed to convert it to int", sizeString)
+	}
+	return size, nil
+}
+
+func (r WindowsRemoteRunner) FileSizesInBytes(ctx context.Context, directory string) (map[string]int, error) {
+	return nil, errSplitArtifactsUnsupported
+}
+
+func (r WindowsRemoteRunner) FreeSpaceInBytes(ctx context.Context, path string) (int, error) {
+	stdout, err := r.runOnInstance(ctx, powershell(fmt.Sprintf(
+		"[long](New-Object System.IO.DriveInfo([System.IO.Path]::GetPathRoot(%s))).AvailableFreeSpace", quote(windowsPath(path)),
+	)))
+	if err != nil {
+		return 0, err
+	}
+
+	freeString := strings.TrimSpace(stdout)
+	free, err := strconv.Atoi(freeString)
+	if err != nil {
+		return 0, fmt.Errorf("expected <%s> to be a number of bytes: failed to convert it to int", freeString)
+	}
+	return free, nil
+}
+
+// ChecksumDirectory prints the hashes in the same format as shasum on linux,
+// so that the result can be compared with checksums computed by bbr locally.
+func (r WindowsRemoteRunner) ChecksumDirectory(ctx context.Context, path string) (map[string]string, error) {
+	stdout, err := r.runOnInstance(ctx, powershell(fmt.Sprintf(`$root = (Resolve-Path -LiteralPath %s).Path.TrimEnd('\')
+Get-ChildItem -LiteralPath $root -Recurse -File -Force | ForEach-Object {
+  $hash = (Get-FileHash -LiteralPath $_.FullName -Algorithm SHA256).Hash.ToLower()
+  $relative = $_.FullName.Substring($root.Length).TrimStart('\').Replace('\', '/')
+  "$hash  ./$relative"
+}`, quote(windowsPath(path)))))
+	if err != nil {
+		return nil, err
+	}
+
+	return convertShasToMap(strings.ReplaceAll(stdout, "\r\n", "\n")), nil
+}
+
+func (r WindowsRemoteRunner) RunScript(ctx context.Context, path, label string) error {
+	return r.RunScriptWithEnv(ctx, path, map[string]string{}, label, io.Discard)
+}
+
+// RunScriptWithEnv runs the script found by FindFiles at path, whatever its
+// extension. Environment variables holding absolute paths, such as
+// BBR_ARTIFACT_DIRECTORY, are converted to Windows paths.
+func (r WindowsRemoteRunner) RunScriptWithEnv(ctx context.Context, scriptPath string, env map[string]string, label string, stdout io.Writer) error {
+	var script strings.Builder
+	for varName, value := range env {
+		if strings.HasPrefix(value, "/") {
+			value = windowsPath(value)
+		}
+		fmt.Fprintf(&script, "$env:%s = %s\n", varName, quote(value))
+	}
+	fmt.Fprintf(&script, `$script = Get-ChildItem -LiteralPath %s -File | Where-Object { $_.Name -eq %[2]s -or $_.BaseName -eq %[2]s } | Select-Object -First 1
+if ($script -eq $null) { throw "script not found: %[3]s" }
+& $script.FullName
+exit $LASTEXITCODE`, quote(windowsPath(path.Dir(scriptPath))), quote(path.Base(scriptPath)), scriptPath)
+
+	stderr, exitCode, runErr := r.connection.Stream(ctx, powershell(script.String()), anonymousWriter{write: func(p []byte) (int, error) {
+		n, outErr := stdout.Write(p)
+
+		r.logger.Debug("bbr", "stdout: %s", string(p))
+
+		if outErr != nil {
+			return n, outErr
+		}
+
+		return len(p), nil
+	}})
+
+	r.logger.Debug("bbr", "stderr: %s", string(stderr))
+
+	if runErr != nil {
+		return runErr
+	}
+
+	if exitCode != 0 {
+		return exitError(stderr, exitCode)
+	}
+
+	return nil
+}
+
+// FindFiles returns unix paths without the script extension, so that
+// C:\var\vcap\jobs\job\bin\bbr\backup.ps1 is found as /var/vcap/jobs/job/bin/bbr/backup.
+func (r WindowsRemoteRunner) FindFiles(ctx context.Context, pattern string) ([]string, error) {
+	stdout, stderr, exitCode, err := r.connection.Run(ctx, powershell(fmt.Sprintf(
+		"Get-ChildItem -Path %s -File -Force -ErrorAction SilentlyContinue | ForEach-Object { $_.FullName }", quote(windowsPath(pattern)),
+	)))
+
+	r.logOutput(stdout, stderr)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if exitCode != 0 {
+		return nil, exitError(stderr, exitCode)
+	}
+
+	files := []string{}
+	for _, line := range strings.Split(strings.TrimSpace(string(stdout)), "\n") {
+		file := strings.TrimSpace(line)
+		if file == "" {
+			continue
+		}
+		files = append(files, trimScriptExtension(unixPath(file)))
+	}
+
+	if len(files) == 0 {
+		r.logger.Debug("bbr", "No files found for pattern '%s'", pattern)
+	}
+	return files, nil
+}
+
+func (r WindowsRemoteRunner) IsWindows(ctx context.Context) (bool, error) {
+	return true, nil
+}
+
+func (r WindowsRemoteRunner) runOnInstance(ctx context.Context, cmd string) (string, error) {
+	stdout, stderr, exitCode, runErr := r.connection.Run(ctx, cmd)
+
+	err := r.logAndCheckErrors(stdout, stderr, exitCode, runErr)
+	if err != nil {
+		return "", err
+	}
+
+	return string(stdout), nil
+}
+
+func (r WindowsRemoteRunner) logAndCheckErrors(stdout, stderr []byte, exitCode int, err error) error {
+	r.logOutput(stdout, stderr)
+
+	if err != nil {
+		return err
+	}
+
+	if exitCode != 0 {
+		return exitError(stderr, exitCode)
+	}
+
+	return nil
+}
+
+func (r WindowsRemoteRunner) logOutput(stdout []byte, stderr []byte) {
+	r.logger.Debug("bbr", "stdout: %s", string(stdout))
+	r.logger.Debug("bbr", "stderr: %s", string(stderr))
+}
+
+// powershell builds a cmd.exe command line that runs script. The script is
+// passed base64 encoded so that it does not have to survive cmd.exe quoting.
+func powershell(script string) string {
+	script = "$ErrorActionPreference = 'Stop'\n$ProgressPreference = 'SilentlyContinue'\n" + script
+
+	var encoded []byte
+	for _, unit := range utf16.Encode([]rune(script)) {
+		encoded = append(encoded, byte(unit), byte(unit>>8))
+	}
+
+	return "powershell.exe -NoProfile -NonInteractive -ExecutionPolicy Bypass -EncodedCommand " + base64.StdEncoding.EncodeToString(encoded)
+}
+
+func quote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+func windowsPath(unixPath string) string {
+	if strings.HasPrefix(unixPath, "/") {
+		unixPath = windowsSystemDrive + unixPath
+	}
+	return strings.ReplaceAll(unixPath, "/", `\`)
+}
+
+func unixPath(windowsPath string) string {
+	if len(windowsPath) >= 2 && windowsPath[1] == ':' {
+		windowsPath = windowsPath[2:]
+	}
+	return strings.ReplaceAll(windowsPath, `\`, "/")
+}
+
+func trimScriptExtension(file string) string {
+	for _, extension := range windowsScriptExtensions {
+		if strings.EqualFold(path.Ext(file), extension) {
+			return strings.TrimSuffix(file, path.Ext(file))
+		}
+	}
+	return file
+}
@@ -0,0 +1,221 @@
+package ssh_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"io"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/cloudfoundry/bosh-backup-and-restore/ssh"
+	"github.com/cloudfoundry/bosh-backup-and-restore/ssh/fakes"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WindowsRemoteRunner", func() {
+	var connection *fakes.FakeSSHConnection
+	var runner ssh.RemoteRunner
+
+	BeforeEach(func() {
+		connection = new(fakes.FakeSSHConnection)
+		runner = ssh.NewWindowsRemoteRunner(connection, new(fakes.FakeLogger))
+	})
+
+	decodePowershell := func(command string) string {
+		const prefix = "powershell.exe -NoProfile -NonInteractive -ExecutionPolicy Bypass -EncodedCommand "
+		Expect(command).To(HavePrefix(prefix))
+
+		encoded, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(command, prefix))
+		Expect(err).NotTo(HaveOccurred())
+
+		var units []uint16
+		for i := 0; i+1 < len(encoded); i += 2 {
+			units = append(units, uint16(encoded[i])|uint16(encoded[i+1])<<8)
+		}
+		return string(utf16.Decode(units))
+	}
+
+	runCommand := func(i int) string {
+		_, cmd := connection.RunArgsForCall(i)
+		return cmd
+	}
+
+	It("is a windows runner", func() {
+		Expect(runner.IsWindows(context.Background())).To(BeTrue())
+		Expect(connection.RunCallCount()).To(BeZero())
+	})
+
+	Describe("FindFiles", func() {
+		It("returns the scripts as unix paths without their extension", func() {
+			connection.RunReturns([]byte("C:\\var\\vcap\\jobs\\dotnet\\bin\\bbr\\backup.ps1\r\nC:\\var\\vcap\\jobs\\dotnet\\bin\\bbr\\restore.cmd\r\n"), nil, 0, nil)
+
+			files, err := runner.FindFiles(context.Background(), "/var/vcap/jobs/*/bin/bbr/*")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files).To(Equal([]string{
+				"/var/vcap/jobs/dotnet/bin/bbr/backup",
+				"/var/vcap/jobs/dotnet/bin/bbr/restore",
+			}))
+			Expect(decodePowershell(runCommand(0))).To(ContainSubstring(`Get-ChildItem -Path 'C:\var\vcap\jobs\*\bin\bbr\*'`))
+		})
+
+		It("returns no files when nothing matches", func() {
+			connection.RunReturns([]byte("\r\n"), nil, 0, nil)
+
+			Expect(runner.FindFiles(context.Background(), "/var/vcap/jobs/*/bin/bbr/*")).To(BeEmpty())
+		})
+
+		It("fails when powershell fails", func() {
+			connection.RunReturns(nil, []byte("access denied"), 1, nil)
+
+			_, err := runner.FindFiles(context.Background(), "/var/vcap/jobs/*/bin/bbr/*")
+			Expect(err).To(MatchError("access denied - exit code 1"))
+		})
+	})
+
+	Describe("RunScriptWithEnv", func() {
+		It("runs the script with the environment, converting paths", func() {
+			connection.StreamStub = func(_ context.Context, cmd string, writer io.Writer) ([]byte, int, error) {
+				_, err := writer.Write([]byte("script output"))
+				return nil, 0, err
+			}
+			stdout := &bytes.Buffer{}
+
+			err := runner.RunScriptWithEnv(
+				context.Background(),
+				"/var/vcap/jobs/dotnet/bin/bbr/backup",
+				map[string]string{"BBR_ARTIFACT_DIRECTORY": "/var/vcap/store/bbr-backup/dotnet/"},
+				"backup",
+				stdout,
+			)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stdout.String()).To(Equal("script output"))
+
+			_, cmd, _ := connection.StreamArgsForCall(0)
+			script := decodePowershell(cmd)
+			Expect(script).To(ContainSubstring(`$env:BBR_ARTIFACT_DIRECTORY = 'C:\var\vcap\store\bbr-backup\dotnet\'`))
+			Expect(script).To(ContainSubstring(`Get-ChildItem -LiteralPath 'C:\var\vcap\jobs\dotnet\bin\bbr' -File`))
+			Expect(script).To(ContainSubstring(`$_.BaseName -eq 'backup'`))
+		})
+
+		It("fails when the script exits non-zero", func() {
+			connection.StreamReturns([]byte("it broke"), 3, nil)
+
+			err := runner.RunScript(context.Background(), "/var/vcap/jobs/dotnet/bin/bbr/backup", "backup")
+			Expect(err).To(MatchError("it broke - exit code 3"))
+		})
+
+		It("fails when the connection fails", func() {
+			connection.StreamReturns(nil, 0, errors.New("connection reset"))
+
+			err := runner.RunScript(context.Background(), "/var/vcap/jobs/dotnet/bin/bbr/backup", "backup")
+			Expect(err).To(MatchError("connection reset"))
+		})
+	})
+
+	Describe("ArchiveAndDownload", func() {
+		It("streams the directory with tar.exe", func() {
+			writer := &bytes.Buffer{}
+
+			Expect(runner.ArchiveAndDownload(context.Background(), "/var/vcap/store/bbr-backup/dotnet", writer)).To(Succeed())
+
+			_, cmd, actualWriter := connection.StreamArgsForCall(0)
+			Expect(cmd).To(Equal(`tar.exe -C "C:\var\vcap\store\bbr-backup\dotnet" -c .`))
+			Expect(actualWriter).To(Equal(writer))
+		})
+	})
+
+	Describe("ExtractAndUpload", func() {
+		It("extracts the stream with tar.exe", func() {
+			reader := bytes.NewBufferString("tarball")
+
+			Expect(runner.ExtractAndUpload(context.Background(), reader, "/var/vcap/store/bbr-backup/dotnet")).To(Succeed())
+
+			_, cmd, actualReader := connection.StreamStdinArgsForCall(0)
+			Expect(cmd).To(Equal(`tar.exe -C "C:\var\vcap\store\bbr-backup\dotnet" -x`))
+			Expect(actualReader).To(Equal(reader))
+		})
+	})
+
+	Describe("ChecksumDirectory", func() {
+		It("hashes every file with Get-FileHash", func() {
+			connection.RunReturns([]byte("abc123  ./file1\r\ndef456  ./dir/file2\r\n"), nil, 0, nil)
+
+			checksums, err := runner.ChecksumDirectory(context.Background(), "/var/vcap/store/bbr-backup/dotnet")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(checksums).To(Equal(map[string]string{"./file1": "abc123", "./dir/file2": "def456"}))
+			Expect(decodePowershell(runCommand(0))).To(ContainSubstring("Get-FileHash"))
+		})
+	})
+
+	Describe("SizeInBytes and SizeOf", func() {
+		BeforeEach(func() {
+			connection.RunReturns([]byte("1572864\r\n"), nil, 0, nil)
+		})
+
+		It("returns the size of the directory", func() {
+			Expect(runner.SizeInBytes(context.Background(), "/var/vcap/store/bbr-backup/dotnet")).To(Equal(1572864))
+		})
+
+		It("returns the size in a human readable format", func() {
+			Expect(runner.SizeOf(context.Background(), "/var/vcap/store/bbr-backup/dotnet")).To(Equal("1.5M"))
+		})
+	})
+
+	Describe("FreeSpaceInBytes", func() {
+		It("returns the space available on the drive of the path", func() {
+			connection.RunReturns([]byte("2147483648\r\n"), nil, 0, nil)
+
+			Expect(runner.FreeSpaceInBytes(context.Background(), "/var/vcap/store")).To(Equal(2147483648))
+			Expect(decodePowershell(runCommand(0))).To(ContainSubstring(`GetPathRoot('C:\var\vcap\store')`))
+		})
+
+		It("fails when the output is not a number", func() {
+			connection.RunReturns([]byte("not a number\r\n"), nil, 0, nil)
+
+			_, err := runner.FreeSpaceInBytes(context.Background(), "/var/vcap/store")
+			Expect(err).To(MatchError(ContainSubstring("expected <not a number> to be a number of bytes")))
+		})
+	})
+
+	Describe("FileSizesInBytes and ArchiveFilesAndDownload", func() {
+		It("are not supported, so that artifacts are drained in a single stream", func() {
+			_, err := runner.FileSizesInBytes(context.Background(), "/var/vcap/store/bbr-backup/dotnet")
+			Expect(err).To(MatchError(ContainSubstring("not supported on Windows")))
+
+			err = runner.ArchiveFilesAndDownload(context.Background(), "/var/vcap/store/bbr-backup/dotnet", []string{"./a"}, io.Discard)
+			Expect(err).To(MatchError(ContainSubstring("not supported on Windows")))
+			Expect(connection.StreamCallCount()).To(BeZero())
+		})
+	})
+
+	Describe("DirectoryExists", func() {
+		It("returns true when Test-Path succeeds", func() {
+			connection.RunReturns(nil, nil, 0, nil)
+
+			Expect(runner.DirectoryExists(context.Background(), "/var/vcap/store/bbr-backup")).To(BeTrue())
+			Expect(decodePowershell(runCommand(0))).To(ContainSubstring(`Test-Path -LiteralPath 'C:\var\vcap\store\bbr-backup'`))
+		})
+
+		It("returns false when Test-Path fails", func() {
+			connection.RunReturns(nil, nil, 1, nil)
+
+			Expect(runner.DirectoryExists(context.Background(), "/var/vcap/store/bbr-backup")).To(BeFalse())
+		})
+	})
+
+	Describe("CreateDirectory and RemoveDirectory", func() {
+		It("creates and removes the directory", func() {
+			Expect(runner.CreateDirectory(context.Background(), "/var/vcap/store/bbr-backup/dotnet")).To(Succeed())
+			Expect(runner.RemoveDirectory(context.Background(), "/var/vcap/store/bbr-backup")).To(Succeed())
+
+			Expect(decodePowershell(runCommand(0))).To(ContainSubstring(`New-Item -ItemType Directory -Force -Path 'C:\var\vcap\store\bbr-backup\dotnet'`))
+			Expect(decodePowershell(runCommand(1))).To(ContainSubstring(`Remove-Item -LiteralPath 'C:\var\vcap\store\bbr-backup' -Recurse -Force`))
+		})
+	})
+})
@@ -1,6 +1,8 @@
 package standalone
 
 import (
+	"context"
+
 	"github.com/cloudfoundry/bosh-backup-and-restore/instance"
 	"github.com/cloudfoundry/bosh-backup-and-restore/orchestrator"
 	"github.com/cloudfoundry/bosh-backup-and-restore/ssh"
@@ -12,28 +14,32 @@ type DeployedInstance struct {
 }
 
 func NewDeployedInstance(instanceGroupName string, remoteRunner ssh.RemoteRunner, logger instance.Logger, jobs orchestrator.Jobs, artifactDirCreated bool) DeployedInstance {
+	return newDeployedInstance(instanceGroupName, "0", remoteRunner, logger, jobs, artifactDirCreated)
+}
+
+func newDeployedInstance(instanceGroupName, index string, remoteRunner ssh.RemoteRunner, logger instance.Logger, jobs orchestrator.Jobs, artifactDirCreated bool) DeployedInstance {
 	return DeployedInstance{
-		DeployedInstance: instance.NewDeployedInstance("0", instanceGroupName, "0", artifactDirCreated, remoteRunner, logger, jobs),
+		DeployedInstance: instance.NewDeployedInstance(index, instanceGroupName, index, artifactDirCreated, remoteRunner, logger, jobs),
 	}
 }
 
-func (i DeployedInstance) Cleanup() error {
+func (i DeployedInstance) Cleanup(ctx context.Context) error {
 	if !i.ArtifactDirCreated() {
 		i.Logger.Debug("bbr", "Backup directory was never created - skipping cleanup") //nolint:staticcheck
 		return nil
 	}
 
-	return i.cleanupArtifact()
+	return i.cleanupArtifact(ctx)
 }
 
-func (i DeployedInstance) CleanupPrevious() error {
-	return i.cleanupArtifact()
+func (i DeployedInstance) CleanupPrevious(ctx context.Context) error {
+	return i.cleanupArtifact(ctx)
 }
 
-func (i DeployedInstance) cleanupArtifact() error {
+func (i DeployedInstance) cleanupArtifact(ctx context.Context) error {
 	i.Logger.Info("bbr", "Cleaning up...") //nolint:staticcheck
 
-	err := i.RemoveArtifactDir()
+	err := i.RemoveArtifactDir(ctx)
 	if err != nil {
 		i.Logger.Error("bbr", "Backup artifact clean up failed") //nolint:staticcheck
 		return errors.Wrap(err, "Unable to clean up backup artifact")
@@ -1,56 +1,84 @@
 package standalone
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
 	"os"
+	"strings"
 
 	"github.com/cloudfoundry/bosh-backup-and-restore/instance"
 	"github.com/cloudfoundry/bosh-backup-and-restore/orchestrator"
 	"github.com/cloudfoundry/bosh-backup-and-restore/ssh"
 	"github.com/pkg/errors"
-
 	gossh "golang.org/x/crypto/ssh"
 )
 
+// directorInfoCommand asks the director for its UUID. The info endpoint does
+// not need credentials.
+const directorInfoCommand = "curl --silent --show-error --fail --insecure https://127.0.0.1:25555/info"
+
+// SSHConfig describes how bbr authenticates to the director VM, and how it
+// verifies the director's host key.
+type SSHConfig struct {
+	PrivateKeyPath     string `yaml:"private_key_path"`
+	CertificatePath    string `yaml:"certificate_path"`
+	UseAgent           bool   `yaml:"ssh_agent"`
+	KnownHostsPath     string `yaml:"known_hosts"`
+	HostKeyFingerprint string `yaml:"host_key_fingerprint"`
+}
+
 type DeploymentManager struct {
 	orchestrator.Logger
 	hostName            string
 	username            string
-	privateKeyFile      string
+	sshConfig           SSHConfig
+	allowOriginMismatch bool
 	jobFinder           instance.JobFinder
-	remoteRunnerFactory ssh.RemoteRunnerFactory
+	remoteRunnerFactory ssh.AuthenticatedRemoteRunnerFactory
+	origin              *orchestrator.Origin
 }
 
+// NewDeploymentManager returns a DeploymentManager for the director VM at
+// hostName. Unless allowOriginMismatch is set, it refuses to restore backups
+// that were taken from a different director.
 func NewDeploymentManager(
 	logger orchestrator.Logger,
-	hostName, username, privateKey string,
+	hostName, username string,
+	sshConfig SSHConfig,
+	allowOriginMismatch bool,
 	jobFinder instance.JobFinder,
-	remoteRunnerFactory ssh.RemoteRunnerFactory,
+	remoteRunnerFactory ssh.AuthenticatedRemoteRunnerFactory,
 ) DeploymentManager {
 	return DeploymentManager{
 		Logger:              logger,
 		hostName:            hostName,
 		username:            username,
-		privateKeyFile:      privateKey,
+		sshConfig:           sshConfig,
+		allowOriginMismatch: allowOriginMismatch,
 		jobFinder:           jobFinder,
 		remoteRunnerFactory: remoteRunnerFactory,
+		origin:              &orchestrator.Origin{},
 	}
 }
 
-func (dm DeploymentManager) Find(deploymentName string) (orchestrator.Deployment, error) {
-	keyContents, err := os.ReadFile(dm.privateKeyFile)
+func (dm DeploymentManager) Find(ctx context.Context, deploymentName string) (orchestrator.Deployment, error) {
+	remoteRunner, hostKey, err := connect(ctx, dm.Logger, dm.hostName, dm.username, dm.sshConfig, dm.remoteRunnerFactory)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed reading private key")
+		return nil, err
 	}
 
-	remoteRunner, err := dm.remoteRunnerFactory(dm.hostName, dm.username, string(keyContents), gossh.InsecureIgnoreHostKey(), nil, dm.Logger)
-	if err != nil {
-		return nil, err
+	*dm.origin = orchestrator.Origin{
+		Hostname:           dm.hostName,
+		HostKeyFingerprint: hostKey.Fingerprint(),
+		DirectorUUID:       dm.directorUUID(ctx, remoteRunner),
 	}
 
-	instanceIdentifier := instance.InstanceIdentifier{InstanceGroupName: "bosh", InstanceId: "0"}
+	// The director is always bosh/0. Other VMs are found by an InventoryDeploymentManager.
+	instanceIdentifier := instance.InstanceIdentifier{InstanceGroupName: "bosh", InstanceId: "0", InstanceIndex: "0", Bootstrap: true}
 
-	//TODO: change instanceIdentifier, its not always bosh
-	jobs, err := dm.jobFinder.FindJobs(instanceIdentifier, remoteRunner, instance.NewNoopManifestQuerier())
+	jobs, err := dm.jobFinder.FindJobs(ctx, instanceIdentifier, remoteRunner, instance.NewNoopManifestQuerier())
 	if err != nil {
 		return nil, err
 	}
@@ -60,6 +88,126 @@ func (dm DeploymentManager) Find(deploymentName string) (orchestrator.Deployment
 	}), nil
 }
 
-func (DeploymentManager) SaveManifest(deploymentName string, artifact orchestrator.Backup) error {
-	return nil
+// directorUUID returns an empty UUID rather than failing when the director
+// cannot be asked for it, as the UUID is only needed to protect restores.
+func (dm DeploymentManager) directorUUID(ctx context.Context, remoteRunner ssh.RemoteRunner) string {
+	stdout := &bytes.Buffer{}
+	if err := remoteRunner.RunScriptWithEnv(ctx, directorInfoCommand, map[string]string{}, "director info", stdout); err != nil {
+		dm.Warn("bbr", "Could not read the director UUID: %s", err)
+		return ""
+	}
+
+	var info struct {
+		UUID string `json:"uuid"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &info); err != nil {
+		dm.Warn("bbr", "Could not read the director UUID: %s", err)
+		return ""
+	}
+	return info.UUID
+}
+
+func connect(ctx context.Context, logger orchestrator.Logger, hostName, username string, sshConfig SSHConfig, remoteRunnerFactory ssh.AuthenticatedRemoteRunnerFactory) (ssh.RemoteRunner, *ssh.HostKeyRecorder, error) {
+	auth, err := authFor(logger, hostName, sshConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	hostKey := &ssh.HostKeyRecorder{}
+	remoteRunner, err := remoteRunnerFactory(ctx, hostName, username, auth.methods, hostKey.Wrap(auth.hostKeyCallback), auth.hostKeyAlgorithms, logger)
+	return remoteRunner, hostKey, err
+}
+
+// sshAuth is how bbr authenticates to a host and verifies its host key.
+type sshAuth struct {
+	methods           []gossh.AuthMethod
+	hostKeyCallback   gossh.HostKeyCallback
+	hostKeyAlgorithms []string
+}
+
+func authFor(logger orchestrator.Logger, hostName string, sshConfig SSHConfig) (sshAuth, error) {
+	credentials := ssh.Credentials{UseAgent: sshConfig.UseAgent}
+
+	if sshConfig.PrivateKeyPath != "" {
+		keyContents, err := os.ReadFile(sshConfig.PrivateKeyPath)
+		if err != nil {
+			return sshAuth{}, errors.Wrap(err, "failed reading private key")
+		}
+		credentials.PrivateKey = string(keyContents)
+	}
+
+	if sshConfig.CertificatePath != "" {
+		certificateContents, err := os.ReadFile(sshConfig.CertificatePath)
+		if err != nil {
+			return sshAuth{}, errors.Wrap(err, "failed reading certificate")
+		}
+		credentials.Certificate = string(certificateContents)
+	}
+
+	authMethods, err := credentials.AuthMethods()
+	if err != nil {
+		return sshAuth{}, err
+	}
+
+	hostKeyCallback, err := ssh.HostKeyCallback(sshConfig.KnownHostsPath, sshConfig.HostKeyFingerprint)
+	if err != nil {
+		return sshAuth{}, err
+	}
+	if sshConfig.KnownHostsPath == "" && sshConfig.HostKeyFingerprint == "" {
+		logger.Warn("bbr", "The host key of %s will not be verified. Provide a known_hosts file or a host key fingerprint to verify it.", hostName)
+	}
+
+	var hostKeyAlgorithms []string
+	if sshConfig.KnownHostsPath != "" {
+		hostKeyAlgorithms, err = ssh.HostKeyAlgorithms(sshConfig.KnownHostsPath, hostName)
+		if err != nil {
+			return sshAuth{}, err
+		}
+	}
+
+	return sshAuth{methods: authMethods, hostKeyCallback: hostKeyCallback, hostKeyAlgorithms: hostKeyAlgorithms}, nil
+}
+
+// SaveManifest records which director the backup is taken from, as there is
+// no manifest to save.
+func (dm DeploymentManager) SaveManifest(deploymentName string, artifact orchestrator.Backup) error {
+	return artifact.AddOrigin(*dm.origin)
+}
+
+func (dm DeploymentManager) VerifyOrigin(deploymentName string, artifact orchestrator.Backup) error {
+	backupOrigin, err := artifact.FetchOrigin()
+	if err != nil {
+		return errors.Wrap(err, "failed to read the origin of the backup")
+	}
+
+	if backupOrigin.IsZero() {
+		dm.Warn("bbr", "The backup does not record which director it was taken from, so it cannot be checked against %s.", dm.hostName)
+		return nil
+	}
+
+	var mismatches []string
+	if differ(backupOrigin.DirectorUUID, dm.origin.DirectorUUID) {
+		mismatches = append(mismatches, fmt.Sprintf("director UUID %s instead of %s", backupOrigin.DirectorUUID, dm.origin.DirectorUUID))
+	}
+	if differ(backupOrigin.HostKeyFingerprint, dm.origin.HostKeyFingerprint) {
+		mismatches = append(mismatches, fmt.Sprintf("host key %s instead of %s", backupOrigin.HostKeyFingerprint, dm.origin.HostKeyFingerprint))
+	}
+
+	if len(mismatches) == 0 {
+		return nil
+	}
+
+	message := fmt.Sprintf("The backup was taken from a different director at %s (%s)", backupOrigin.Hostname, strings.Join(mismatches, ", "))
+	if dm.allowOriginMismatch {
+		dm.Warn("bbr", "%s. Restoring it to %s anyway.", message, dm.hostName)
+		return nil
+	}
+
+	return errors.Errorf("%s. Use --allow-different-director to restore it to %s anyway.", message, dm.hostName)
+}
+
+// differ ignores values that were not recorded, e.g. when the director UUID
+// could not be read.
+func differ(recorded, current string) bool {
+	return recorded != "" && current != "" && recorded != current
 }
@@ -1,7 +1,14 @@
 package standalone_test
 
 import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/pem"
 	"fmt"
+	"io"
+	"net"
 	"os"
 
 	"github.com/cloudfoundry/bosh-backup-and-restore/instance"
@@ -10,9 +17,12 @@ import (
 	instancefakes "github.com/cloudfoundry/bosh-backup-and-restore/instance/fakes"
 	"github.com/cloudfoundry/bosh-backup-and-restore/orchestrator"
 	"github.com/cloudfoundry/bosh-backup-and-restore/orchestrator/fakes"
+	"github.com/cloudfoundry/bosh-backup-and-restore/ssh"
 	sshfakes "github.com/cloudfoundry/bosh-backup-and-restore/ssh/fakes"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	gossh "golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
 )
 
 var _ = Describe("DeploymentManager", func() {
@@ -23,19 +33,29 @@ var _ = Describe("DeploymentManager", func() {
 	var hostName = "hostname"
 	var username = "username"
 	var privateKey string
+	var sshConfig SSHConfig
 	var fakeJobFinder *instancefakes.FakeJobFinder
-	var remoteRunnerFactory *sshfakes.FakeRemoteRunnerFactory
+	var remoteRunnerFactory *sshfakes.FakeAuthenticatedRemoteRunnerFactory
 	var remoteRunner *sshfakes.FakeRemoteRunner
+	var allowOriginMismatch bool
 
 	BeforeEach(func() {
-		privateKey = createTempFile("privateKey")
+		privateKey = createTempFile(generatePrivateKey())
+		sshConfig = SSHConfig{PrivateKeyPath: privateKey}
+		allowOriginMismatch = false
 		logger = new(fakes.FakeLogger)
 		artifact = new(fakes.FakeBackup)
-		remoteRunnerFactory = new(sshfakes.FakeRemoteRunnerFactory)
+		remoteRunnerFactory = new(sshfakes.FakeAuthenticatedRemoteRunnerFactory)
 		fakeJobFinder = new(instancefakes.FakeJobFinder)
 		remoteRunner = new(sshfakes.FakeRemoteRunner)
+		remoteRunner.RunScriptWithEnvStub = func(_ context.Context, path string, env map[string]string, label string, stdout io.Writer) error {
+			_, err := stdout.Write([]byte(`{"name":"bosh","uuid":"director-uuid"}`))
+			return err
+		}
+	})
 
-		deploymentManager = NewDeploymentManager(logger, hostName, username, privateKey, fakeJobFinder, remoteRunnerFactory.Spy)
+	JustBeforeEach(func() {
+		deploymentManager = NewDeploymentManager(logger, hostName, username, sshConfig, allowOriginMismatch, fakeJobFinder, remoteRunnerFactory.Spy)
 	})
 
 	AfterEach(func() {
@@ -48,7 +68,7 @@ var _ = Describe("DeploymentManager", func() {
 		var fakeJobs orchestrator.Jobs
 
 		JustBeforeEach(func() {
-			actualDeployment, actualError = deploymentManager.Find(deploymentName)
+			actualDeployment, actualError = deploymentManager.Find(context.Background(), deploymentName)
 		})
 
 		Context("success", func() {
@@ -74,6 +94,139 @@ var _ = Describe("DeploymentManager", func() {
 					NewDeployedInstance("bosh", remoteRunner, logger, fakeJobs, false),
 				})))
 			})
+
+			It("authenticates with the private key", func() {
+				_, host, user, authMethods, _, _, _ := remoteRunnerFactory.ArgsForCall(0)
+				Expect(host).To(Equal(hostName))
+				Expect(user).To(Equal(username))
+				Expect(authMethods).To(HaveLen(1))
+			})
+
+			It("warns that the host key is not verified", func() {
+				Expect(logger.WarnCallCount()).To(Equal(1))
+				_, message, _ := logger.WarnArgsForCall(0)
+				Expect(message).To(ContainSubstring("will not be verified"))
+			})
+		})
+
+		Context("with a pinned host key fingerprint", func() {
+			var hostKey gossh.PublicKey
+
+			BeforeEach(func() {
+				signer, err := gossh.ParsePrivateKey([]byte(generatePrivateKey()))
+				Expect(err).NotTo(HaveOccurred())
+				hostKey = signer.PublicKey()
+
+				sshConfig.HostKeyFingerprint = gossh.FingerprintSHA256(hostKey)
+				remoteRunnerFactory.Returns(remoteRunner, nil)
+			})
+
+			It("only accepts the pinned host key", func() {
+				Expect(actualError).NotTo(HaveOccurred())
+				_, _, _, _, hostKeyCallback, _, _ := remoteRunnerFactory.ArgsForCall(0)
+
+				Expect(hostKeyCallback(hostName, nil, hostKey)).To(Succeed())
+
+				otherSigner, err := gossh.ParsePrivateKey([]byte(generatePrivateKey()))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(hostKeyCallback(hostName, nil, otherSigner.PublicKey())).To(MatchError(ContainSubstring("expected " + gossh.FingerprintSHA256(hostKey))))
+			})
+
+			It("does not warn", func() {
+				Expect(logger.WarnCallCount()).To(BeZero())
+			})
+		})
+
+		Context("with a known_hosts file", func() {
+			var hostKey gossh.PublicKey
+			var knownHosts string
+
+			BeforeEach(func() {
+				signer, err := gossh.ParsePrivateKey([]byte(generatePrivateKey()))
+				Expect(err).NotTo(HaveOccurred())
+				hostKey = signer.PublicKey()
+
+				knownHosts = createTempFile(knownhosts.Line([]string{"10.0.0.6"}, hostKey) + "\n")
+				sshConfig.KnownHostsPath = knownHosts
+				remoteRunnerFactory.Returns(remoteRunner, nil)
+			})
+
+			AfterEach(func() {
+				os.Remove(knownHosts) //nolint:errcheck
+			})
+
+			It("verifies the host key against it", func() {
+				Expect(actualError).NotTo(HaveOccurred())
+				_, _, _, _, hostKeyCallback, _, _ := remoteRunnerFactory.ArgsForCall(0)
+
+				address := &net.TCPAddr{IP: net.ParseIP("10.0.0.6"), Port: 22}
+				Expect(hostKeyCallback("10.0.0.6:22", address, hostKey)).To(Succeed())
+
+				otherSigner, err := gossh.ParsePrivateKey([]byte(generatePrivateKey()))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(hostKeyCallback("10.0.0.6:22", address, otherSigner.PublicKey())).NotTo(Succeed())
+			})
+
+			It("allows every host key algorithm when the host is not in it", func() {
+				Expect(actualError).NotTo(HaveOccurred())
+				_, _, _, _, _, hostKeyAlgorithms, _ := remoteRunnerFactory.ArgsForCall(0)
+				Expect(hostKeyAlgorithms).To(BeNil())
+			})
+
+			Context("when it records the keys of the host", func() {
+				BeforeEach(func() {
+					otherPrivateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+					Expect(err).NotTo(HaveOccurred())
+					otherHostKey, err := gossh.NewPublicKey(&otherPrivateKey.PublicKey)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(os.WriteFile(knownHosts, []byte(
+						knownhosts.Line([]string{knownhosts.HashHostname(hostName)}, hostKey)+"\n"+
+							knownhosts.Line([]string{"10.0.0.7"}, otherHostKey)+"\n",
+					), 0600)).To(Succeed())
+				})
+
+				It("asks the host for a key of the recorded type", func() {
+					Expect(actualError).NotTo(HaveOccurred())
+					_, _, _, _, _, hostKeyAlgorithms, _ := remoteRunnerFactory.ArgsForCall(0)
+					Expect(hostKeyAlgorithms).To(Equal([]string{gossh.KeyAlgoED25519}))
+				})
+			})
+		})
+
+		Context("with both a known_hosts file and a fingerprint", func() {
+			BeforeEach(func() {
+				sshConfig.KnownHostsPath = "/some/known_hosts"
+				sshConfig.HostKeyFingerprint = "SHA256:abc"
+			})
+
+			It("should fail", func() {
+				Expect(actualError).To(MatchError(ContainSubstring("only one of a known_hosts file and a host key fingerprint")))
+				Expect(remoteRunnerFactory.CallCount()).To(BeZero())
+			})
+		})
+
+		Context("can't read the certificate", func() {
+			BeforeEach(func() {
+				sshConfig.CertificatePath = "/does/not/exist-cert.pub"
+			})
+
+			It("should fail", func() {
+				Expect(actualError).To(MatchError(ContainSubstring("failed reading certificate")))
+				Expect(remoteRunnerFactory.CallCount()).To(BeZero())
+			})
+		})
+
+		Context("using the ssh agent when no agent is running", func() {
+			BeforeEach(func() {
+				sshConfig = SSHConfig{UseAgent: true}
+				GinkgoT().Setenv("SSH_AUTH_SOCK", "")
+			})
+
+			It("should fail", func() {
+				Expect(actualError).To(MatchError(ContainSubstring("SSH_AUTH_SOCK is not set")))
+				Expect(remoteRunnerFactory.CallCount()).To(BeZero())
+			})
 		})
 
 		Context("can't read private key", func() {
@@ -135,9 +288,160 @@ var _ = Describe("DeploymentManager", func() {
 	})
 
 	Describe("SaveManifest", func() {
-		It("does nothing", func() {
-			err := deploymentManager.SaveManifest(deploymentName, artifact)
+		var hostKey gossh.PublicKey
+
+		BeforeEach(func() {
+			signer, err := gossh.ParsePrivateKey([]byte(generatePrivateKey()))
 			Expect(err).NotTo(HaveOccurred())
+			hostKey = signer.PublicKey()
+
+			remoteRunnerFactory.Stub = func(_ context.Context, host, user string, authMethods []gossh.AuthMethod, hostKeyCallback gossh.HostKeyCallback, algorithms []string, logger ssh.Logger) (ssh.RemoteRunner, error) {
+				return remoteRunner, hostKeyCallback(host, nil, hostKey)
+			}
+		})
+
+		It("records the host, host key and director UUID the backup is taken from", func() {
+			_, err := deploymentManager.Find(context.Background(), deploymentName)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(deploymentManager.SaveManifest(deploymentName, artifact)).To(Succeed())
+
+			Expect(artifact.AddOriginCallCount()).To(Equal(1))
+			Expect(artifact.AddOriginArgsForCall(0)).To(Equal(orchestrator.Origin{
+				Hostname:           hostName,
+				HostKeyFingerprint: gossh.FingerprintSHA256(hostKey),
+				DirectorUUID:       "director-uuid",
+			}))
+
+			_, command, _, _, _ := remoteRunner.RunScriptWithEnvArgsForCall(0)
+			Expect(command).To(ContainSubstring("https://127.0.0.1:25555/info"))
+		})
+
+		Context("when the director UUID cannot be read", func() {
+			BeforeEach(func() {
+				remoteRunner.RunScriptWithEnvReturns(fmt.Errorf("curl: connection refused"))
+				remoteRunner.RunScriptWithEnvStub = nil
+			})
+
+			It("records the origin without it and warns", func() {
+				_, err := deploymentManager.Find(context.Background(), deploymentName)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(deploymentManager.SaveManifest(deploymentName, artifact)).To(Succeed())
+
+				Expect(artifact.AddOriginArgsForCall(0).DirectorUUID).To(BeEmpty())
+				_, message, args := logger.WarnArgsForCall(1)
+				Expect(fmt.Sprintf(message, args...)).To(Equal("Could not read the director UUID: curl: connection refused"))
+			})
+		})
+
+		Context("when the origin cannot be recorded", func() {
+			BeforeEach(func() {
+				artifact.AddOriginReturns(fmt.Errorf("disk full"))
+			})
+
+			It("fails", func() {
+				Expect(deploymentManager.SaveManifest(deploymentName, artifact)).To(MatchError("disk full"))
+			})
+		})
+	})
+
+	Describe("VerifyOrigin", func() {
+		var hostKey gossh.PublicKey
+		var verifyErr error
+
+		BeforeEach(func() {
+			signer, err := gossh.ParsePrivateKey([]byte(generatePrivateKey()))
+			Expect(err).NotTo(HaveOccurred())
+			hostKey = signer.PublicKey()
+
+			remoteRunnerFactory.Stub = func(_ context.Context, host, user string, authMethods []gossh.AuthMethod, hostKeyCallback gossh.HostKeyCallback, algorithms []string, logger ssh.Logger) (ssh.RemoteRunner, error) {
+				return remoteRunner, hostKeyCallback(host, nil, hostKey)
+			}
+		})
+
+		JustBeforeEach(func() {
+			_, err := deploymentManager.Find(context.Background(), deploymentName)
+			Expect(err).NotTo(HaveOccurred())
+
+			verifyErr = deploymentManager.VerifyOrigin(deploymentName, artifact)
+		})
+
+		Context("when the backup was taken from the same director", func() {
+			BeforeEach(func() {
+				artifact.FetchOriginReturns(orchestrator.Origin{
+					Hostname:           "old-hostname",
+					HostKeyFingerprint: gossh.FingerprintSHA256(hostKey),
+					DirectorUUID:       "director-uuid",
+				}, nil)
+			})
+
+			It("succeeds", func() {
+				Expect(verifyErr).NotTo(HaveOccurred())
+			})
+		})
+
+		Context("when the backup was taken from a different director", func() {
+			BeforeEach(func() {
+				artifact.FetchOriginReturns(orchestrator.Origin{
+					Hostname:           "10.0.0.7",
+					HostKeyFingerprint: "SHA256:other",
+					DirectorUUID:       "other-uuid",
+				}, nil)
+			})
+
+			It("refuses to restore it", func() {
+				Expect(verifyErr).To(MatchError(fmt.Sprintf(
+					"The backup was taken from a different director at 10.0.0.7 (director UUID other-uuid instead of director-uuid, host key SHA256:other instead of %s). Use --allow-different-director to restore it to hostname anyway.",
+					gossh.FingerprintSHA256(hostKey),
+				)))
+			})
+
+			Context("and restoring to a different director is allowed", func() {
+				BeforeEach(func() {
+					allowOriginMismatch = true
+				})
+
+				It("warns and continues", func() {
+					Expect(verifyErr).NotTo(HaveOccurred())
+
+					_, message, args := logger.WarnArgsForCall(logger.WarnCallCount() - 1)
+					Expect(fmt.Sprintf(message, args...)).To(HavePrefix("The backup was taken from a different director at 10.0.0.7"))
+				})
+			})
+		})
+
+		Context("when only the director UUID differs and the host key was not recorded", func() {
+			BeforeEach(func() {
+				artifact.FetchOriginReturns(orchestrator.Origin{Hostname: hostName, DirectorUUID: "other-uuid"}, nil)
+			})
+
+			It("refuses to restore it", func() {
+				Expect(verifyErr).To(MatchError(ContainSubstring("director UUID other-uuid instead of director-uuid")))
+			})
+		})
+
+		Context("when the backup does not record its origin", func() {
+			BeforeEach(func() {
+				artifact.FetchOriginReturns(orchestrator.Origin{}, nil)
+			})
+
+			It("warns and continues", func() {
+				Expect(verifyErr).NotTo(HaveOccurred())
+
+				_, message, _ := logger.WarnArgsForCall(logger.WarnCallCount() - 1)
+				Expect(message).To(ContainSubstring("does not record which director it was taken from"))
+			})
+		})
+
+		Context("when the origin cannot be read", func() {
+			BeforeEach(func() {
+				artifact.FetchOriginReturns(orchestrator.Origin{}, fmt.Errorf("bad metadata"))
+			})
+
+			It("fails", func() {
+				Expect(verifyErr).To(MatchError("failed to read the origin of the backup: bad metadata"))
+			})
 		})
 	})
 
@@ -159,7 +463,7 @@ var _ = Describe("DeployedInstance", func() {
 
 		JustBeforeEach(func() {
 			inst = NewDeployedInstance("group", remoteRunner, logger, []orchestrator.Job{}, artifactDirCreated)
-			err = inst.Cleanup()
+			err = inst.Cleanup(context.Background())
 		})
 
 		BeforeEach(func() {
@@ -172,7 +476,8 @@ var _ = Describe("DeployedInstance", func() {
 
 		It("removes the artifact directory", func() {
 			Expect(remoteRunner.RemoveDirectoryCallCount()).To(Equal(1))
-			Expect(remoteRunner.RemoveDirectoryArgsForCall(0)).To(Equal("/var/vcap/store/bbr-backup"))
+			_, dir := remoteRunner.RemoveDirectoryArgsForCall(0)
+			Expect(dir).To(Equal("/var/vcap/store/bbr-backup"))
 		})
 
 		Context("when the artifact directory was not created this time", func() {
@@ -204,7 +509,7 @@ var _ = Describe("DeployedInstance", func() {
 
 		JustBeforeEach(func() {
 			inst = NewDeployedInstance("group", remoteRunner, logger, []orchestrator.Job{}, artifactDirCreated)
-			err = inst.CleanupPrevious()
+			err = inst.CleanupPrevious(context.Background())
 		})
 
 		BeforeEach(func() {
@@ -217,7 +522,8 @@ var _ = Describe("DeployedInstance", func() {
 
 		It("removes the artifact directory", func() {
 			Expect(remoteRunner.RemoveDirectoryCallCount()).To(Equal(1))
-			Expect(remoteRunner.RemoveDirectoryArgsForCall(0)).To(Equal("/var/vcap/store/bbr-backup"))
+			_, dir := remoteRunner.RemoveDirectoryArgsForCall(0)
+			Expect(dir).To(Equal("/var/vcap/store/bbr-backup"))
 		})
 
 		Context("when the artifact directory was not created this time", func() {
@@ -227,7 +533,8 @@ var _ = Describe("DeployedInstance", func() {
 
 			It("does remove the artifact directory", func() {
 				Expect(remoteRunner.RemoveDirectoryCallCount()).To(Equal(1))
-				Expect(remoteRunner.RemoveDirectoryArgsForCall(0)).To(Equal("/var/vcap/store/bbr-backup"))
+				_, dir := remoteRunner.RemoveDirectoryArgsForCall(0)
+				Expect(dir).To(Equal("/var/vcap/store/bbr-backup"))
 			})
 		})
 
@@ -246,6 +553,15 @@ var _ = Describe("DeployedInstance", func() {
 	})
 })
 
+func generatePrivateKey() string {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	Expect(err).NotTo(HaveOccurred())
+
+	block, err := gossh.MarshalPrivateKey(key, "")
+	Expect(err).NotTo(HaveOccurred())
+	return string(pem.EncodeToMemory(block))
+}
+
 func createTempFile(contents string) string {
 	tempFile, err := os.CreateTemp("", "")
 	Expect(err).NotTo(HaveOccurred())
@@ -0,0 +1,147 @@
+package standalone
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// Inventory describes VMs that are not managed by BOSH but follow the bbr
+// script contract, e.g.
+//
+//	name: concourse
+//	username: vcap
+//	private_key_path: /home/me/.ssh/id_rsa
+//	known_hosts: /home/me/.ssh/known_hosts
+//	instance_groups:
+//	- name: web
+//	  lock_before: [worker]
+//	  hosts:
+//	  - address: 10.0.0.10
+//	- name: worker
+//	  username: worker
+//	  hosts:
+//	  - address: 10.0.0.11:2222
+//	  - address: 10.0.0.12
+//
+// SSH settings can be given at the top level, for an instance group or for a
+// single host, and the most specific one wins.
+type Inventory struct {
+	Name           string                   `yaml:"name"`
+	SSH            InventorySSH             `yaml:",inline"`
+	InstanceGroups []InventoryInstanceGroup `yaml:"instance_groups"`
+}
+
+type InventoryInstanceGroup struct {
+	Name string       `yaml:"name"`
+	SSH  InventorySSH `yaml:",inline"`
+	// LockBefore names the instance groups whose jobs may only be locked
+	// once every job in this instance group has been locked.
+	LockBefore []string        `yaml:"lock_before"`
+	Hosts      []InventoryHost `yaml:"hosts"`
+}
+
+type InventoryHost struct {
+	Address string       `yaml:"address"`
+	SSH     InventorySSH `yaml:",inline"`
+}
+
+type InventorySSH struct {
+	Username  string `yaml:"username"`
+	SSHConfig `yaml:",inline"`
+}
+
+func LoadInventory(path string) (Inventory, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return Inventory{}, errors.Wrap(err, "failed reading inventory")
+	}
+
+	var inventory Inventory
+	if err := yaml.UnmarshalStrict(contents, &inventory); err != nil {
+		return Inventory{}, errors.Wrapf(err, "failed parsing inventory %s", path)
+	}
+
+	if err := inventory.Validate(); err != nil {
+		return Inventory{}, errors.Wrapf(err, "invalid inventory %s", path)
+	}
+	return inventory, nil
+}
+
+func (inventory Inventory) Validate() error {
+	if inventory.Name == "" {
+		return errors.New("name is required")
+	}
+	if len(inventory.InstanceGroups) == 0 {
+		return errors.New("at least one instance group is required")
+	}
+
+	groupNames := map[string]bool{}
+	for _, group := range inventory.InstanceGroups {
+		if group.Name == "" {
+			return errors.New("every instance group needs a name")
+		}
+		if groupNames[group.Name] {
+			return fmt.Errorf("instance group %s is listed more than once", group.Name)
+		}
+		groupNames[group.Name] = true
+
+		if len(group.Hosts) == 0 {
+			return fmt.Errorf("instance group %s has no hosts", group.Name)
+		}
+
+		for index, host := range group.Hosts {
+			if host.Address == "" {
+				return fmt.Errorf("host %d of instance group %s has no address", index, group.Name)
+			}
+
+			ssh := inventory.SSHFor(group, host)
+			if ssh.Username == "" {
+				return fmt.Errorf("no username for host %s", host.Address)
+			}
+			if ssh.PrivateKeyPath == "" && !ssh.UseAgent {
+				return fmt.Errorf("no private_key_path or ssh_agent for host %s", host.Address)
+			}
+		}
+	}
+
+	for _, group := range inventory.InstanceGroups {
+		for _, lockBefore := range group.LockBefore {
+			if !groupNames[lockBefore] {
+				return fmt.Errorf("instance group %s should be locked before unknown instance group %s", group.Name, lockBefore)
+			}
+		}
+	}
+
+	return nil
+}
+
+// SSHFor returns the SSH settings for host, falling back to those of its
+// instance group and then to those of the whole inventory.
+func (inventory Inventory) SSHFor(group InventoryInstanceGroup, host InventoryHost) InventorySSH {
+	return inventory.SSH.overriddenBy(group.SSH).overriddenBy(host.SSH)
+}
+
+func (s InventorySSH) overriddenBy(override InventorySSH) InventorySSH {
+	if override.Username != "" {
+		s.Username = override.Username
+	}
+	if override.PrivateKeyPath != "" {
+		s.PrivateKeyPath = override.PrivateKeyPath
+	}
+	if override.CertificatePath != "" {
+		s.CertificatePath = override.CertificatePath
+	}
+	if override.UseAgent {
+		s.UseAgent = true
+	}
+	if override.KnownHostsPath != "" {
+		s.KnownHostsPath = override.KnownHostsPath
+	}
+	if override.HostKeyFingerprint != "" {
+		s.HostKeyFingerprint = override.HostKeyFingerprint
+	}
+	return s
+}
@@ -0,0 +1,71 @@
+package standalone
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/cloudfoundry/bosh-backup-and-restore/instance"
+	"github.com/cloudfoundry/bosh-backup-and-restore/orchestrator"
+	"github.com/cloudfoundry/bosh-backup-and-restore/ssh"
+	"github.com/pkg/errors"
+)
+
+// InventoryDeploymentManager finds the instances of VMs listed in an
+// Inventory. Hosts are indexed in the order they are listed within their
+// instance group, and the first host of each group is its bootstrap node.
+type InventoryDeploymentManager struct {
+	orchestrator.Logger
+	inventory           Inventory
+	jobFinder           instance.JobFinder
+	remoteRunnerFactory ssh.AuthenticatedRemoteRunnerFactory
+}
+
+func NewInventoryDeploymentManager(
+	logger orchestrator.Logger,
+	inventory Inventory,
+	jobFinder instance.JobFinder,
+	remoteRunnerFactory ssh.AuthenticatedRemoteRunnerFactory,
+) InventoryDeploymentManager {
+	return InventoryDeploymentManager{
+		Logger:              logger,
+		inventory:           inventory,
+		jobFinder:           jobFinder,
+		remoteRunnerFactory: remoteRunnerFactory,
+	}
+}
+
+func (dm InventoryDeploymentManager) Find(ctx context.Context, deploymentName string) (orchestrator.Deployment, error) {
+	var instances []orchestrator.Instance
+
+	for _, group := range dm.inventory.InstanceGroups {
+		for hostIndex, host := range group.Hosts {
+			index := strconv.Itoa(hostIndex)
+			hostSSH := dm.inventory.SSHFor(group, host)
+
+			remoteRunner, _, err := connect(ctx, dm.Logger, host.Address, hostSSH.Username, hostSSH.SSHConfig, dm.remoteRunnerFactory)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to connect to %s/%s at %s", group.Name, index, host.Address)
+			}
+
+			instanceIdentifier := instance.InstanceIdentifier{
+				InstanceGroupName: group.Name,
+				InstanceId:        index,
+				InstanceIndex:     index,
+				Bootstrap:         hostIndex == 0,
+			}
+
+			jobs, err := dm.jobFinder.FindJobs(ctx, instanceIdentifier, remoteRunner, instance.NewNoopManifestQuerier())
+			if err != nil {
+				return nil, err
+			}
+
+			instances = append(instances, newDeployedInstance(group.Name, index, remoteRunner, dm.Logger, jobs, false))
+		}
+	}
+
+	return orchestrator.NewDeployment(dm.Logger, instances), nil
+}
+
+func (InventoryDeploymentManager) SaveManifest(deploymentName string, artifact orchestrator.Backup) error {
+	return nil
+}
@@ -0,0 +1,132 @@
+package standalone_test
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	"github.com/cloudfoundry/bosh-backup-and-restore/instance"
+	instancefakes "github.com/cloudfoundry/bosh-backup-and-restore/instance/fakes"
+	"github.com/cloudfoundry/bosh-backup-and-restore/orchestrator"
+	"github.com/cloudfoundry/bosh-backup-and-restore/orchestrator/fakes"
+	sshfakes "github.com/cloudfoundry/bosh-backup-and-restore/ssh/fakes"
+	. "github.com/cloudfoundry/bosh-backup-and-restore/standalone"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("InventoryDeploymentManager", func() {
+	var deploymentManager InventoryDeploymentManager
+	var inventory Inventory
+	var privateKey string
+	var logger *fakes.FakeLogger
+	var fakeJobFinder *instancefakes.FakeJobFinder
+	var remoteRunnerFactory *sshfakes.FakeAuthenticatedRemoteRunnerFactory
+	var webRunner, workerRunner0, workerRunner1 *sshfakes.FakeRemoteRunner
+
+	BeforeEach(func() {
+		privateKey = createTempFile(generatePrivateKey())
+		logger = new(fakes.FakeLogger)
+		fakeJobFinder = new(instancefakes.FakeJobFinder)
+		remoteRunnerFactory = new(sshfakes.FakeAuthenticatedRemoteRunnerFactory)
+
+		webRunner = new(sshfakes.FakeRemoteRunner)
+		workerRunner0 = new(sshfakes.FakeRemoteRunner)
+		workerRunner1 = new(sshfakes.FakeRemoteRunner)
+		remoteRunnerFactory.ReturnsOnCall(0, webRunner, nil)
+		remoteRunnerFactory.ReturnsOnCall(1, workerRunner0, nil)
+		remoteRunnerFactory.ReturnsOnCall(2, workerRunner1, nil)
+
+		inventory = Inventory{
+			Name: "concourse",
+			SSH:  InventorySSH{Username: "vcap", SSHConfig: SSHConfig{PrivateKeyPath: privateKey}},
+			InstanceGroups: []InventoryInstanceGroup{
+				{Name: "web", Hosts: []InventoryHost{{Address: "10.0.0.10"}}},
+				{Name: "worker", SSH: InventorySSH{Username: "worker"}, Hosts: []InventoryHost{
+					{Address: "10.0.0.11:2222"},
+					{Address: "10.0.0.12"},
+				}},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		deploymentManager = NewInventoryDeploymentManager(logger, inventory, fakeJobFinder, remoteRunnerFactory.Spy)
+	})
+
+	AfterEach(func() {
+		os.Remove(privateKey) //nolint:errcheck
+	})
+
+	Describe("Find", func() {
+		It("returns an instance for every host, indexed within its instance group", func() {
+			webJobs := orchestrator.Jobs{instance.NewJob(nil, "", nil, "", instance.BackupAndRestoreScripts{"/var/vcap/jobs/atc/bin/bbr/backup"}, instance.Metadata{}, false, true)}
+			fakeJobFinder.FindJobsReturnsOnCall(0, webJobs, nil)
+			fakeJobFinder.FindJobsReturnsOnCall(1, orchestrator.Jobs{}, nil)
+			fakeJobFinder.FindJobsReturnsOnCall(2, orchestrator.Jobs{}, nil)
+
+			deployment, err := deploymentManager.Find(context.Background(), "concourse")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deployment.Instances()).To(HaveLen(3))
+
+			instances := deployment.Instances()
+			Expect(instances[0].Name()).To(Equal("web"))
+			Expect(instances[0].Index()).To(Equal("0"))
+			Expect(instances[0].Jobs()).To(Equal([]orchestrator.Job(webJobs)))
+			Expect(instances[1].Name()).To(Equal("worker"))
+			Expect(instances[1].Index()).To(Equal("0"))
+			Expect(instances[2].Name()).To(Equal("worker"))
+			Expect(instances[2].Index()).To(Equal("1"))
+		})
+
+		It("connects to each host with its own SSH settings", func() {
+			_, err := deploymentManager.Find(context.Background(), "concourse")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(remoteRunnerFactory.CallCount()).To(Equal(3))
+			for i, expected := range []struct{ host, user string }{
+				{"10.0.0.10", "vcap"},
+				{"10.0.0.11:2222", "worker"},
+				{"10.0.0.12", "worker"},
+			} {
+				_, host, user, _, _, _, _ := remoteRunnerFactory.ArgsForCall(i)
+				Expect(host).To(Equal(expected.host))
+				Expect(user).To(Equal(expected.user))
+			}
+		})
+
+		It("marks the first host of each instance group as its bootstrap node", func() {
+			_, err := deploymentManager.Find(context.Background(), "concourse")
+			Expect(err).NotTo(HaveOccurred())
+
+			identifiers := []instance.InstanceIdentifier{}
+			for i := 0; i < fakeJobFinder.FindJobsCallCount(); i++ {
+				_, identifier, _, _ := fakeJobFinder.FindJobsArgsForCall(i)
+				identifiers = append(identifiers, identifier)
+			}
+			Expect(identifiers).To(Equal([]instance.InstanceIdentifier{
+				{InstanceGroupName: "web", InstanceId: "0", InstanceIndex: "0", Bootstrap: true},
+				{InstanceGroupName: "worker", InstanceId: "0", InstanceIndex: "0", Bootstrap: true},
+				{InstanceGroupName: "worker", InstanceId: "1", InstanceIndex: "1", Bootstrap: false},
+			}))
+		})
+
+		It("fails when a host cannot be reached", func() {
+			remoteRunnerFactory.ReturnsOnCall(1, nil, errors.New("connection refused"))
+
+			_, err := deploymentManager.Find(context.Background(), "concourse")
+
+			Expect(err).To(MatchError("failed to connect to worker/0 at 10.0.0.11:2222: connection refused"))
+		})
+
+		It("fails when jobs cannot be found", func() {
+			fakeJobFinder.FindJobsReturns(nil, errors.New("no scripts"))
+
+			_, err := deploymentManager.Find(context.Background(), "concourse")
+
+			Expect(err).To(MatchError("no scripts"))
+		})
+	})
+})
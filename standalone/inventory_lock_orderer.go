@@ -0,0 +1,109 @@
+package standalone
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cloudfoundry/bosh-backup-and-restore/orchestrator"
+)
+
+// InventoryLockOrderer locks the jobs of an instance group before those of
+// the instance groups listed in its lock_before. Jobs of instance groups that
+// are not ordered against each other are ordered by the inner LockOrderer, so
+// the ordering declared in the jobs' own metadata still applies within them.
+type InventoryLockOrderer struct {
+	inventory Inventory
+	inner     orchestrator.LockOrderer
+}
+
+func NewInventoryLockOrderer(inventory Inventory, inner orchestrator.LockOrderer) InventoryLockOrderer {
+	return InventoryLockOrderer{
+		inventory: inventory,
+		inner:     inner,
+	}
+}
+
+func (lo InventoryLockOrderer) Order(jobs []orchestrator.Job) ([][]orchestrator.Job, error) {
+	layers, err := lo.instanceGroupLayers()
+	if err != nil {
+		return nil, err
+	}
+
+	layerOfGroup := map[string]int{}
+	for layerIndex, groupNames := range layers {
+		for _, groupName := range groupNames {
+			layerOfGroup[groupName] = layerIndex
+		}
+	}
+
+	jobsByLayer := make([][]orchestrator.Job, len(layers))
+	for _, job := range jobs {
+		layerIndex := layerOfGroup[instanceGroupOf(job)]
+		jobsByLayer[layerIndex] = append(jobsByLayer[layerIndex], job)
+	}
+
+	orderedJobs := [][]orchestrator.Job{}
+	for _, layerJobs := range jobsByLayer {
+		if len(layerJobs) == 0 {
+			continue
+		}
+
+		orderedLayer, err := lo.inner.Order(layerJobs)
+		if err != nil {
+			return nil, err
+		}
+		orderedJobs = append(orderedJobs, orderedLayer...)
+	}
+
+	return orderedJobs, nil
+}
+
+// instanceGroupLayers sorts the instance groups topologically by lock_before,
+// keeping the inventory order within each layer.
+func (lo InventoryLockOrderer) instanceGroupLayers() ([][]string, error) {
+	lockedAfter := map[string]int{}
+	for _, group := range lo.inventory.InstanceGroups {
+		for _, after := range group.LockBefore {
+			lockedAfter[after]++
+		}
+	}
+
+	remaining := lo.inventory.InstanceGroups
+	var layers [][]string
+	for len(remaining) > 0 {
+		var layer []string
+		var unlocked, next []InventoryInstanceGroup
+		for _, group := range remaining {
+			if lockedAfter[group.Name] == 0 {
+				layer = append(layer, group.Name)
+				unlocked = append(unlocked, group)
+			} else {
+				next = append(next, group)
+			}
+		}
+
+		if len(layer) == 0 {
+			var names []string
+			for _, group := range remaining {
+				names = append(names, group.Name)
+			}
+			return nil, fmt.Errorf("instance groups %s have a cyclic lock_before ordering", strings.Join(names, ", "))
+		}
+
+		for _, group := range unlocked {
+			for _, after := range group.LockBefore {
+				lockedAfter[after]--
+			}
+		}
+
+		layers = append(layers, layer)
+		remaining = next
+	}
+
+	return layers, nil
+}
+
+func instanceGroupOf(job orchestrator.Job) string {
+	groupName, _, _ := strings.Cut(job.InstanceIdentifier(), "/")
+	return groupName
+}
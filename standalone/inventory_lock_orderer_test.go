@@ -0,0 +1,89 @@
+package standalone_test
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry/bosh-backup-and-restore/orchestrator"
+	"github.com/cloudfoundry/bosh-backup-and-restore/orchestrator/fakes"
+	. "github.com/cloudfoundry/bosh-backup-and-restore/standalone"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("InventoryLockOrderer", func() {
+	var inventory Inventory
+	var inner *fakes.FakeLockOrderer
+	var webJob, workerJob, dbJob, otherDBJob *fakes.FakeJob
+
+	newJob := func(instanceIdentifier string) *fakes.FakeJob {
+		job := new(fakes.FakeJob)
+		job.InstanceIdentifierReturns(instanceIdentifier)
+		return job
+	}
+
+	BeforeEach(func() {
+		inventory = Inventory{
+			Name: "concourse",
+			InstanceGroups: []InventoryInstanceGroup{
+				{Name: "worker"},
+				{Name: "web", LockBefore: []string{"worker", "db"}},
+				{Name: "db", LockBefore: []string{"worker"}},
+			},
+		}
+
+		inner = new(fakes.FakeLockOrderer)
+		inner.OrderStub = func(jobs []orchestrator.Job) ([][]orchestrator.Job, error) {
+			return [][]orchestrator.Job{jobs}, nil
+		}
+
+		webJob = newJob("web/0")
+		workerJob = newJob("worker/0")
+		dbJob = newJob("db/0")
+		otherDBJob = newJob("db/1")
+	})
+
+	It("locks instance groups in lock_before order, ordering the jobs of each group with the inner orderer", func() {
+		orderedJobs, err := NewInventoryLockOrderer(inventory, inner).Order([]orchestrator.Job{workerJob, dbJob, webJob, otherDBJob})
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(orderedJobs).To(Equal([][]orchestrator.Job{
+			{webJob},
+			{dbJob, otherDBJob},
+			{workerJob},
+		}))
+		Expect(inner.OrderCallCount()).To(Equal(3))
+	})
+
+	It("orders instance groups without lock_before together", func() {
+		inventory.InstanceGroups = []InventoryInstanceGroup{{Name: "worker"}, {Name: "web"}, {Name: "db"}}
+
+		orderedJobs, err := NewInventoryLockOrderer(inventory, inner).Order([]orchestrator.Job{workerJob, dbJob, webJob})
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(orderedJobs).To(Equal([][]orchestrator.Job{{workerJob, dbJob, webJob}}))
+	})
+
+	It("skips instance groups without jobs", func() {
+		orderedJobs, err := NewInventoryLockOrderer(inventory, inner).Order([]orchestrator.Job{workerJob, webJob})
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(orderedJobs).To(Equal([][]orchestrator.Job{{webJob}, {workerJob}}))
+	})
+
+	It("fails when lock_before is cyclic", func() {
+		inventory.InstanceGroups[0].LockBefore = []string{"web"}
+
+		_, err := NewInventoryLockOrderer(inventory, inner).Order([]orchestrator.Job{workerJob, webJob})
+
+		Expect(err).To(MatchError("instance groups worker, web, db have a cyclic lock_before ordering"))
+	})
+
+	It("fails when the inner orderer fails", func() {
+		inner.OrderReturns(nil, errors.New("job cycle"))
+
+		_, err := NewInventoryLockOrderer(inventory, inner).Order([]orchestrator.Job{workerJob})
+
+		Expect(err).To(MatchError("job cycle"))
+	})
+})
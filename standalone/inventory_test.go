@@ -0,0 +1,137 @@
+package standalone_test
+
+import (
+	"fmt"
+	"os"
+
+	. "github.com/cloudfoundry/bosh-backup-and-restore/standalone"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Inventory", func() {
+	var inventoryPath string
+	var inventory Inventory
+	var loadErr error
+
+	loadInventory := func(contents string) {
+		inventoryPath = createTempFile(contents)
+		inventory, loadErr = LoadInventory(inventoryPath)
+	}
+
+	AfterEach(func() {
+		os.Remove(inventoryPath) //nolint:errcheck
+	})
+
+	Context("when the inventory is valid", func() {
+		BeforeEach(func() {
+			loadInventory(`---
+name: concourse
+username: vcap
+private_key_path: /keys/default
+known_hosts: /keys/known_hosts
+instance_groups:
+- name: web
+  lock_before: [worker]
+  hosts:
+  - address: 10.0.0.10
+- name: worker
+  username: worker
+  ssh_agent: true
+  hosts:
+  - address: 10.0.0.11:2222
+  - address: 10.0.0.12
+    private_key_path: /keys/worker-2
+    host_key_fingerprint: SHA256:abc
+`)
+		})
+
+		It("loads the instance groups and hosts", func() {
+			Expect(loadErr).NotTo(HaveOccurred())
+			Expect(inventory.Name).To(Equal("concourse"))
+			Expect(inventory.InstanceGroups).To(HaveLen(2))
+			Expect(inventory.InstanceGroups[0].Name).To(Equal("web"))
+			Expect(inventory.InstanceGroups[0].LockBefore).To(Equal([]string{"worker"}))
+			Expect(inventory.InstanceGroups[1].Hosts).To(HaveLen(2))
+			Expect(inventory.InstanceGroups[1].Hosts[0].Address).To(Equal("10.0.0.11:2222"))
+		})
+
+		It("uses the top level SSH settings when nothing overrides them", func() {
+			web := inventory.InstanceGroups[0]
+			Expect(inventory.SSHFor(web, web.Hosts[0])).To(Equal(InventorySSH{
+				Username:  "vcap",
+				SSHConfig: SSHConfig{PrivateKeyPath: "/keys/default", KnownHostsPath: "/keys/known_hosts"},
+			}))
+		})
+
+		It("overrides SSH settings per instance group and per host", func() {
+			worker := inventory.InstanceGroups[1]
+			Expect(inventory.SSHFor(worker, worker.Hosts[0])).To(Equal(InventorySSH{
+				Username: "worker",
+				SSHConfig: SSHConfig{
+					PrivateKeyPath: "/keys/default",
+					UseAgent:       true,
+					KnownHostsPath: "/keys/known_hosts",
+				},
+			}))
+			Expect(inventory.SSHFor(worker, worker.Hosts[1])).To(Equal(InventorySSH{
+				Username: "worker",
+				SSHConfig: SSHConfig{
+					PrivateKeyPath:     "/keys/worker-2",
+					UseAgent:           true,
+					KnownHostsPath:     "/keys/known_hosts",
+					HostKeyFingerprint: "SHA256:abc",
+				},
+			}))
+		})
+	})
+
+	Context("when the inventory cannot be read", func() {
+		It("fails", func() {
+			_, err := LoadInventory("/does/not/exist")
+			Expect(err).To(MatchError(ContainSubstring("failed reading inventory")))
+		})
+	})
+
+	Context("when the inventory has unknown keys", func() {
+		It("fails", func() {
+			loadInventory("name: foo\nhostz: []\n")
+			Expect(loadErr).To(MatchError(ContainSubstring("failed parsing inventory")))
+		})
+	})
+
+	DescribeTable("invalid inventories",
+		func(contents, expectedError string) {
+			loadInventory(contents)
+			Expect(loadErr).To(MatchError(ContainSubstring(fmt.Sprintf("invalid inventory %s: %s", inventoryPath, expectedError))))
+		},
+		Entry("without a name",
+			"instance_groups: [{name: web, hosts: [{address: a}]}]\nusername: u\nssh_agent: true",
+			"name is required"),
+		Entry("without instance groups",
+			"name: foo",
+			"at least one instance group is required"),
+		Entry("with an unnamed instance group",
+			"name: foo\ninstance_groups: [{hosts: [{address: a}]}]",
+			"every instance group needs a name"),
+		Entry("with a duplicate instance group",
+			"name: foo\nusername: u\nssh_agent: true\ninstance_groups: [{name: web, hosts: [{address: a}]}, {name: web, hosts: [{address: b}]}]",
+			"instance group web is listed more than once"),
+		Entry("with an instance group without hosts",
+			"name: foo\ninstance_groups: [{name: web}]",
+			"instance group web has no hosts"),
+		Entry("with a host without an address",
+			"name: foo\ninstance_groups: [{name: web, hosts: [{username: u}]}]",
+			"host 0 of instance group web has no address"),
+		Entry("with a host without a username",
+			"name: foo\nssh_agent: true\ninstance_groups: [{name: web, hosts: [{address: a}]}]",
+			"no username for host a"),
+		Entry("with a host without credentials",
+			"name: foo\nusername: u\ninstance_groups: [{name: web, hosts: [{address: a}]}]",
+			"no private_key_path or ssh_agent for host a"),
+		Entry("with lock_before referencing an unknown instance group",
+			"name: foo\nusername: u\nssh_agent: true\ninstance_groups: [{name: web, lock_before: [db], hosts: [{address: a}]}]",
+			"instance group web should be locked before unknown instance group db"),
+	)
+})
@@ -0,0 +1,144 @@
+package standalone
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/cloudfoundry/bosh-backup-and-restore/orchestrator"
+	"github.com/cloudfoundry/bosh-backup-and-restore/ssh"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// ProxyJumpConfig lists the jump hosts that SSH connections go through, e.g.
+//
+//	routes:
+//	- networks: [10.0.16.0/20]
+//	  hops:
+//	  - address: jumpbox.example.com
+//	    username: jumpbox
+//	    private_key_path: /home/me/.ssh/jumpbox
+//	    known_hosts: /home/me/.ssh/known_hosts
+//	  - address: 10.0.16.4
+//	    username: vcap
+//	    ssh_agent: true
+//	    host_key_fingerprint: SHA256:...
+//
+// The first route whose networks contain the address being connected to is
+// used. A route without networks matches every address.
+type ProxyJumpConfig struct {
+	Routes []ProxyJumpRoute `yaml:"routes"`
+}
+
+type ProxyJumpRoute struct {
+	Networks []string        `yaml:"networks"`
+	Hops     []InventoryHost `yaml:"hops"`
+}
+
+func LoadProxyJumpConfig(path string) (ProxyJumpConfig, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return ProxyJumpConfig{}, errors.Wrap(err, "failed reading proxy jump config")
+	}
+
+	var config ProxyJumpConfig
+	if err := yaml.UnmarshalStrict(contents, &config); err != nil {
+		return ProxyJumpConfig{}, errors.Wrapf(err, "failed parsing proxy jump config %s", path)
+	}
+
+	if err := config.Validate(); err != nil {
+		return ProxyJumpConfig{}, errors.Wrapf(err, "invalid proxy jump config %s", path)
+	}
+	return config, nil
+}
+
+func (config ProxyJumpConfig) Validate() error {
+	if len(config.Routes) == 0 {
+		return errors.New("at least one route is required")
+	}
+
+	for routeIndex, route := range config.Routes {
+		if len(route.Hops) == 0 {
+			return fmt.Errorf("route %d has no hops", routeIndex)
+		}
+
+		for _, network := range route.Networks {
+			if _, err := parseNetwork(network); err != nil {
+				return fmt.Errorf("route %d has an invalid network %s", routeIndex, network)
+			}
+		}
+
+		for hopIndex, hop := range route.Hops {
+			if hop.Address == "" {
+				return fmt.Errorf("hop %d of route %d has no address", hopIndex, routeIndex)
+			}
+			if hop.SSH.Username == "" {
+				return fmt.Errorf("no username for jump host %s", hop.Address)
+			}
+			if hop.SSH.PrivateKeyPath == "" && !hop.SSH.UseAgent {
+				return fmt.Errorf("no private_key_path or ssh_agent for jump host %s", hop.Address)
+			}
+		}
+	}
+
+	return nil
+}
+
+// JumpRoutes reads the credentials of every hop, so that problems with them
+// are reported before any connection is made.
+func (config ProxyJumpConfig) JumpRoutes(logger orchestrator.Logger) ([]ssh.JumpRoute, error) {
+	var routes []ssh.JumpRoute
+
+	for _, route := range config.Routes {
+		jumpRoute := ssh.JumpRoute{}
+
+		for _, network := range route.Networks {
+			ipNet, err := parseNetwork(network)
+			if err != nil {
+				return nil, err
+			}
+			jumpRoute.Networks = append(jumpRoute.Networks, ipNet)
+		}
+
+		for _, hop := range route.Hops {
+			auth, err := authFor(logger, hop.Address, hop.SSH.SSHConfig)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to set up jump host %s", hop.Address)
+			}
+
+			jumpRoute.Hops = append(jumpRoute.Hops, ssh.Hop{
+				Address:           hop.Address,
+				User:              hop.SSH.Username,
+				AuthMethods:       auth.methods,
+				HostKeyCallback:   auth.hostKeyCallback,
+				HostKeyAlgorithms: auth.hostKeyAlgorithms,
+			})
+		}
+
+		routes = append(routes, jumpRoute)
+	}
+
+	return routes, nil
+}
+
+// parseNetwork accepts a single IP address as well as a CIDR range.
+func parseNetwork(network string) (*net.IPNet, error) {
+	if !strings.Contains(network, "/") {
+		ip := net.ParseIP(network)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid IP address %s", network)
+		}
+
+		bits := 8 * net.IPv6len
+		if ip.To4() != nil {
+			ip = ip.To4()
+			bits = 8 * net.IPv4len
+		}
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+	}
+
+	_, ipNet, err := net.ParseCIDR(network)
+	return ipNet, err
+}
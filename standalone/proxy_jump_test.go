@@ -0,0 +1,129 @@
+package standalone_test
+
+import (
+	"fmt"
+	"net"
+	"os"
+
+	"github.com/cloudfoundry/bosh-backup-and-restore/orchestrator/fakes"
+	. "github.com/cloudfoundry/bosh-backup-and-restore/standalone"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ProxyJumpConfig", func() {
+	var configPath string
+	var privateKey string
+	var config ProxyJumpConfig
+	var loadErr error
+
+	loadConfig := func(contents string) {
+		configPath = createTempFile(contents)
+		config, loadErr = LoadProxyJumpConfig(configPath)
+	}
+
+	BeforeEach(func() {
+		privateKey = createTempFile(generatePrivateKey())
+	})
+
+	AfterEach(func() {
+		os.Remove(configPath) //nolint:errcheck
+		os.Remove(privateKey) //nolint:errcheck
+	})
+
+	Context("when the config is valid", func() {
+		BeforeEach(func() {
+			loadConfig(fmt.Sprintf(`---
+routes:
+- networks: [10.0.16.0/20, 10.0.32.5]
+  hops:
+  - address: jumpbox.example.com
+    username: jumpbox
+    private_key_path: %[1]s
+    host_key_fingerprint: SHA256:abc
+  - address: 10.0.16.4:2222
+    username: vcap
+    private_key_path: %[1]s
+- hops:
+  - address: jumpbox.example.com
+    username: jumpbox
+    private_key_path: %[1]s
+`, privateKey))
+		})
+
+		It("loads the routes", func() {
+			Expect(loadErr).NotTo(HaveOccurred())
+			Expect(config.Routes).To(HaveLen(2))
+			Expect(config.Routes[0].Networks).To(Equal([]string{"10.0.16.0/20", "10.0.32.5"}))
+			Expect(config.Routes[0].Hops[1].Address).To(Equal("10.0.16.4:2222"))
+			Expect(config.Routes[0].Hops[1].SSH.Username).To(Equal("vcap"))
+		})
+
+		It("converts them to jump routes with the credentials of every hop", func() {
+			logger := new(fakes.FakeLogger)
+
+			routes, err := config.JumpRoutes(logger)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(routes).To(HaveLen(2))
+			Expect(routes[0].Networks).To(Equal([]*net.IPNet{
+				{IP: net.IP{10, 0, 16, 0}, Mask: net.CIDRMask(20, 32)},
+				{IP: net.IP{10, 0, 32, 5}, Mask: net.CIDRMask(32, 32)},
+			}))
+			Expect(routes[0].Hops).To(HaveLen(2))
+			Expect(routes[0].Hops[0].Address).To(Equal("jumpbox.example.com"))
+			Expect(routes[0].Hops[0].User).To(Equal("jumpbox"))
+			Expect(routes[0].Hops[0].AuthMethods).To(HaveLen(1))
+			Expect(routes[0].Hops[0].HostKeyCallback).NotTo(BeNil())
+			Expect(routes[1].Networks).To(BeEmpty())
+
+			By("warning about the hops whose host keys are not verified", func() {
+				Expect(logger.WarnCallCount()).To(Equal(2))
+				_, message, args := logger.WarnArgsForCall(0)
+				Expect(fmt.Sprintf(message, args...)).To(ContainSubstring("The host key of 10.0.16.4:2222 will not be verified"))
+			})
+		})
+
+		Context("when the key of a hop cannot be read", func() {
+			It("fails", func() {
+				os.Remove(privateKey) //nolint:errcheck
+
+				_, err := config.JumpRoutes(new(fakes.FakeLogger))
+				Expect(err).To(MatchError(ContainSubstring("failed to set up jump host jumpbox.example.com: failed reading private key")))
+			})
+		})
+	})
+
+	Context("when the config cannot be read", func() {
+		It("fails", func() {
+			_, err := LoadProxyJumpConfig("/does/not/exist")
+			Expect(err).To(MatchError(ContainSubstring("failed reading proxy jump config")))
+		})
+	})
+
+	DescribeTable("invalid configs",
+		func(contents, expectedError string) {
+			loadConfig(contents)
+			Expect(loadErr).To(MatchError(ContainSubstring(fmt.Sprintf("invalid proxy jump config %s: %s", configPath, expectedError))))
+		},
+		Entry("without routes",
+			"routes: []",
+			"at least one route is required"),
+		Entry("with a route without hops",
+			"routes: [{networks: [10.0.0.0/8]}]",
+			"route 0 has no hops"),
+		Entry("with an invalid network",
+			"routes: [{networks: [10.0.0.0/33], hops: [{address: a, username: u, ssh_agent: true}]}]",
+			"route 0 has an invalid network 10.0.0.0/33"),
+		Entry("with a hop without an address",
+			"routes: [{hops: [{username: u, ssh_agent: true}]}]",
+			"hop 0 of route 0 has no address"),
+		Entry("with a hop without a username",
+			"routes: [{hops: [{address: a, ssh_agent: true}]}]",
+			"no username for jump host a"),
+		Entry("with a hop without credentials",
+			"routes: [{hops: [{address: a, username: u}]}]",
+			"no private_key_path or ssh_agent for jump host a"),
+	)
+})
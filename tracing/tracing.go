@@ -0,0 +1,109 @@
+package tracing
+
+import (
+	"context"
+	"os"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/cloudfoundry/bosh-backup-and-restore"
+
+const (
+	DeploymentKey = attribute.Key("bbr.deployment")
+	InstanceKey   = attribute.Key("bbr.instance")
+	JobKey        = attribute.Key("bbr.job")
+	PhaseKey      = attribute.Key("bbr.phase")
+	ArtifactKey   = attribute.Key("bbr.artifact")
+	BytesKey      = attribute.Key("bbr.bytes")
+	HostKey       = attribute.Key("bbr.ssh.host")
+	UserKey       = attribute.Key("bbr.ssh.user")
+	CommandKey    = attribute.Key("bbr.ssh.command")
+)
+
+type Config struct {
+	// File is a path that spans are written to as JSON, one span per line.
+	File string
+	// OTLPEndpoint is the URL of an OTLP/HTTP collector, e.g. http://localhost:4318.
+	OTLPEndpoint string
+	Version      string
+}
+
+func (c Config) Enabled() bool {
+	return c.File != "" || c.OTLPEndpoint != ""
+}
+
+// Setup installs a global tracer provider that exports spans as configured.
+// The returned function flushes and stops the exporters, and must be called
+// before bbr exits or buffered spans will be lost.
+func Setup(config Config) (func() error, error) {
+	if !config.Enabled() {
+		return func() error { return nil }, nil
+	}
+
+	var options []sdktrace.TracerProviderOption
+	var closers []func() error
+
+	if config.File != "" {
+		file, err := os.OpenFile(config.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to open trace file")
+		}
+		closers = append(closers, file.Close)
+
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(file))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create trace file exporter")
+		}
+		options = append(options, sdktrace.WithBatcher(exporter))
+	}
+
+	if config.OTLPEndpoint != "" {
+		exporter, err := otlptracehttp.New(context.Background(), otlptracehttp.WithEndpointURL(config.OTLPEndpoint))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create OTLP trace exporter")
+		}
+		options = append(options, sdktrace.WithBatcher(exporter))
+	}
+
+	options = append(options, sdktrace.WithResource(resource.NewSchemaless(
+		attribute.String("service.name", "bbr"),
+		attribute.String("service.version", config.Version),
+	)))
+
+	provider := sdktrace.NewTracerProvider(options...)
+	otel.SetTracerProvider(provider)
+
+	return func() error {
+		err := provider.Shutdown(context.Background())
+		for _, closer := range closers {
+			if closeErr := closer(); err == nil {
+				err = closeErr
+			}
+		}
+		return errors.Wrap(err, "failed to flush traces")
+	}, nil
+}
+
+// Start starts a span as a child of the span in ctx, if there is one. Spans
+// are no-ops until Setup has been called.
+func Start(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attributes...))
+}
+
+// End marks span as failed if err is not nil, then ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
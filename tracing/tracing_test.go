@@ -0,0 +1,68 @@
+package tracing_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/cloudfoundry/bosh-backup-and-restore/tracing"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+var _ = Describe("Tracing", func() {
+	AfterEach(func() {
+		otel.SetTracerProvider(noop.NewTracerProvider())
+	})
+
+	Context("when a trace file is configured", func() {
+		var traceFile string
+
+		BeforeEach(func() {
+			traceFile = filepath.Join(GinkgoT().TempDir(), "trace.json")
+		})
+
+		It("writes the spans to the file when it is shut down", func() {
+			shutdown, err := tracing.Setup(tracing.Config{File: traceFile, Version: "1.2.3"})
+			Expect(err).NotTo(HaveOccurred())
+
+			ctx, parent := tracing.Start(context.Background(), "backup", tracing.DeploymentKey.String("redis"))
+			_, child := tracing.Start(ctx, "pre-backup-lock", tracing.JobKey.String("redis-server"))
+			tracing.End(child, fmt.Errorf("lock failed"))
+			tracing.End(parent, nil)
+
+			Expect(shutdown()).To(Succeed())
+
+			contents, err := os.ReadFile(traceFile)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(contents)).To(ContainSubstring(`"Name":"backup"`))
+			Expect(string(contents)).To(ContainSubstring(`"Name":"pre-backup-lock"`))
+			Expect(string(contents)).To(ContainSubstring(`"Key":"bbr.deployment","Value":{"Type":"STRING","Value":"redis"}`))
+			Expect(string(contents)).To(ContainSubstring(`"Description":"lock failed"`))
+			Expect(string(contents)).To(ContainSubstring(`"Value":"1.2.3"`))
+		})
+
+		Context("and the file cannot be opened", func() {
+			It("returns an error", func() {
+				_, err := tracing.Setup(tracing.Config{File: filepath.Join(traceFile, "not-a-directory", "trace.json")})
+				Expect(err).To(MatchError(ContainSubstring("failed to open trace file")))
+			})
+		})
+	})
+
+	Context("when no exporter is configured", func() {
+		It("does not create a file and shuts down cleanly", func() {
+			shutdown, err := tracing.Setup(tracing.Config{})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, span := tracing.Start(context.Background(), "backup")
+			tracing.End(span, nil)
+
+			Expect(span.SpanContext().IsValid()).To(BeFalse())
+			Expect(shutdown()).To(Succeed())
+		})
+	})
+})